@@ -14,8 +14,10 @@ tool (
 
 require (
 	github.com/Nerzal/gocloak/v13 v13.9.0
+	github.com/SherClockHolmes/webpush-go v1.4.0
 	github.com/docker/go-connections v0.5.0
 	github.com/eclipse/paho.mqtt.golang v1.4.3
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/go-playground/validator/v10 v10.22.0
 	github.com/gofiber/contrib/jwt v1.0.10
 	github.com/gofiber/fiber/v2 v2.52.5
@@ -26,16 +28,19 @@ require (
 	github.com/minio/minio-go/v7 v7.0.83
 	github.com/pkg/errors v0.9.1
 	github.com/pressly/goose/v3 v3.21.1
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/spf13/viper v1.19.0
 	github.com/stillya/testcontainers-keycloak v0.3.1
 	github.com/stretchr/testify v1.10.0
 	github.com/swaggo/swag v1.16.4
+	github.com/teambition/rrule-go v1.8.2
 	github.com/testcontainers/testcontainers-go v0.34.0
 	github.com/testcontainers/testcontainers-go/modules/postgres v0.34.0
 	github.com/twpayne/go-geos v0.18.1
 	github.com/twpayne/pgx-geos v0.0.3
 	golang.org/x/net v0.35.0
 	golang.org/x/sync v0.11.0
+	modernc.org/sqlite v1.34.5
 )
 
 require (
@@ -362,7 +367,6 @@ require (
 	modernc.org/libc v1.55.3 // indirect
 	modernc.org/mathutil v1.6.0 // indirect
 	modernc.org/memory v1.8.0 // indirect
-	modernc.org/sqlite v1.34.5 // indirect
 	mvdan.cc/gofumpt v0.7.0 // indirect
 	mvdan.cc/unparam v0.0.0-20240528143540-8a5130ca722f // indirect
 	nhooyr.io/websocket v1.8.10 // indirect
@@ -71,6 +71,7 @@ type S3Config struct {
 	Endpoint string          `mapstructure:"endpoint"`
 	Region   string          `mapstructure:"region"`
 	RouteGpx S3ServiceConfig `mapstructure:"route-gpx"`
+	Report   S3ServiceConfig `mapstructure:"report"`
 	UseSSL   bool            `mapstructure:"use_ssl"`
 }
 
@@ -92,19 +93,134 @@ type DashboardConfig struct {
 	Title string `mapstructure:"title"`
 }
 
+type EventsDriver string
+
+const (
+	EventsDriverMemory   EventsDriver = "memory"
+	EventsDriverPostgres EventsDriver = "postgres"
+)
+
+// EventsConfig configures the event bus. The memory driver delivers the events inside one instance,
+// the postgres driver stores them in the database and shares them between several instances.
+type EventsConfig struct {
+	Driver      EventsDriver  `mapstructure:"driver"`
+	MaxAttempts int32         `mapstructure:"max_attempts"`
+	Backoff     time.Duration `mapstructure:"backoff"`
+	MaxBackoff  time.Duration `mapstructure:"max_backoff"`
+}
+
+// WeatherConfig configures the weather provider. Host is the base url of an Open-Meteo compatible api,
+// Models selects the weather models, e.g. icon_seamless for the models of the DWD. The crop coefficient
+// scales the reference evapotranspiration to the water use of the trees.
+type WeatherConfig struct {
+	Enable          bool          `mapstructure:"enable"`
+	Host            string        `mapstructure:"host"`
+	Models          string        `mapstructure:"models"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+	PastDays        int           `mapstructure:"past_days"`
+	ForecastDays    int           `mapstructure:"forecast_days"`
+	CropCoefficient float64       `mapstructure:"crop_coefficient"`
+}
+
+// PlannerConfig configures the proposals of watering plans. HorizonDays is the number of upcoming days
+// that can be planned, the tree clusters with an urgency below MinScore are not proposed. A proposal is
+// shortened until its route takes at most MaxDuration.
+type PlannerConfig struct {
+	HorizonDays        int            `mapstructure:"horizon_days"`
+	MaxClustersPerPlan int            `mapstructure:"max_clusters_per_plan"`
+	MaxDuration        time.Duration  `mapstructure:"max_duration"`
+	MinScore           float64        `mapstructure:"min_score"`
+	CrewSize           int            `mapstructure:"crew_size"`
+	Weights            PlannerWeights `mapstructure:"weights"`
+}
+
+// PlannerWeights weight the factors of the urgency of a tree cluster, they are normalized by their sum
+type PlannerWeights struct {
+	WateringStatus float64 `mapstructure:"watering_status"`
+	LastWatered    float64 `mapstructure:"last_watered"`
+	TreeAge        float64 `mapstructure:"tree_age"`
+	Forecast       float64 `mapstructure:"forecast"`
+}
+
+// WateringPlanTemplateConfig configures the materialization of the watering plan templates. The watering
+// plans are created LookaheadDays ahead. Holidays are the regional public holidays in addition to the
+// nationwide ones, either yearly as MM-DD or once as YYYY-MM-DD.
+type WateringPlanTemplateConfig struct {
+	LookaheadDays int      `mapstructure:"lookahead_days"`
+	Holidays      []string `mapstructure:"holidays"`
+}
+
+// ReportConfig configures the generated reports. The download links are signed with SigningKey and expire
+// after LinkExpiry. Without signing key a random key is used, the links are then only valid on this instance
+// until it is restarted.
+type ReportConfig struct {
+	SigningKey string        `mapstructure:"signing_key"`
+	LinkExpiry time.Duration `mapstructure:"link_expiry"`
+}
+
+// NotificationConfig configures the notifications. The quiet hours and digest times of the users are
+// interpreted in TimeZone. Emails are sent by SMTP and push messages by Web Push with the VAPID keys,
+// a disabled channel is skipped.
+type NotificationConfig struct {
+	TimeZone string     `mapstructure:"time_zone"`
+	SMTP     SMTPConfig `mapstructure:"smtp"`
+	Push     PushConfig `mapstructure:"push"`
+}
+
+type SMTPConfig struct {
+	Enable   bool   `mapstructure:"enable"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+	TLS      bool   `mapstructure:"tls"`
+}
+
+// PushConfig configures Web Push. Subject is a mailto: or https: url that identifies the sender to the
+// push services.
+type PushConfig struct {
+	Enable     bool          `mapstructure:"enable"`
+	PublicKey  string        `mapstructure:"public_key"`
+	PrivateKey string        `mapstructure:"private_key"`
+	Subject    string        `mapstructure:"subject"`
+	TTL        time.Duration `mapstructure:"ttl"`
+}
+
+// SchedulerConfig configures the scheduled jobs. The jobs run on the instance that holds the lock of
+// the scheduler, the other instances look for the lock every poll interval.
+type SchedulerConfig struct {
+	PollInterval time.Duration        `mapstructure:"poll_interval"`
+	Jobs         map[string]JobConfig `mapstructure:"jobs"`
+}
+
+// JobConfig configures a scheduled job. The schedule is a cron expression with five fields or a
+// descriptor like @daily, it may start with CRON_TZ=<time zone>.
+type JobConfig struct {
+	Schedule string `mapstructure:"schedule"`
+	Enabled  bool   `mapstructure:"enabled"`
+}
+
 type IdentityAuthConfig struct {
 	Enable       bool         `mapstructure:"enable"`
 	OidcProvider OidcProvider `mapstructure:"oidc_provider"`
 }
 
 type Config struct {
-	Server       ServerConfig       `mapstructure:"server"`
-	Dashboard    DashboardConfig    `mapstructure:"dashboard"`
-	Routing      RoutingConfig      `mapstructure:"routing"`
-	S3           S3Config           `mapstructure:"s3"`
-	MQTT         MQTTConfig         `mapstructure:"mqtt"`
-	IdentityAuth IdentityAuthConfig `mapstructure:"auth"`
-	Map          MapConfig          `mapstructure:"map"`
+	Server               ServerConfig               `mapstructure:"server"`
+	Dashboard            DashboardConfig            `mapstructure:"dashboard"`
+	Routing              RoutingConfig              `mapstructure:"routing"`
+	S3                   S3Config                   `mapstructure:"s3"`
+	MQTT                 MQTTConfig                 `mapstructure:"mqtt"`
+	IdentityAuth         IdentityAuthConfig         `mapstructure:"auth"`
+	Map                  MapConfig                  `mapstructure:"map"`
+	Events               EventsConfig               `mapstructure:"events"`
+	Scheduler            SchedulerConfig            `mapstructure:"scheduler"`
+	Weather              WeatherConfig              `mapstructure:"weather"`
+	Planner              PlannerConfig              `mapstructure:"planner"`
+	WateringPlanTemplate WateringPlanTemplateConfig `mapstructure:"watering_plan_template"`
+	Report               ReportConfig               `mapstructure:"report"`
+	Notification         NotificationConfig         `mapstructure:"notification"`
 }
 
 func InitConfig() (*Config, error) {
@@ -25,6 +25,51 @@ func InitViper() (*Config, error) {
 	viper.SetDefault("auth.enable", true)
 	viper.SetDefault("routing.enable", true)
 	viper.SetDefault("mqtt.enable", true)
+	viper.SetDefault("events.driver", "memory")
+	viper.SetDefault("events.max_attempts", 5)
+	viper.SetDefault("events.backoff", "1s")
+	viper.SetDefault("events.max_backoff", "1m")
+	viper.SetDefault("scheduler.poll_interval", "10s")
+	viper.SetDefault("scheduler.jobs.sensor_status.schedule", "0 */3 * * *")
+	viper.SetDefault("scheduler.jobs.sensor_status.enabled", true)
+	viper.SetDefault("scheduler.jobs.watering_plan_status.schedule", "0 2 * * *")
+	viper.SetDefault("scheduler.jobs.watering_plan_status.enabled", true)
+	viper.SetDefault("scheduler.jobs.tree_cluster_watering_status.schedule", "15 2 * * *")
+	viper.SetDefault("scheduler.jobs.tree_cluster_watering_status.enabled", true)
+	viper.SetDefault("scheduler.jobs.tree_watering_status.schedule", "30 2 * * *")
+	viper.SetDefault("scheduler.jobs.tree_watering_status.enabled", true)
+	viper.SetDefault("scheduler.jobs.weather.schedule", "0 */6 * * *")
+	viper.SetDefault("scheduler.jobs.weather.enabled", true)
+	viper.SetDefault("scheduler.jobs.watering_plan_templates.schedule", "45 2 * * *")
+	viper.SetDefault("scheduler.jobs.watering_plan_templates.enabled", true)
+	viper.SetDefault("scheduler.jobs.tree_status_snapshot.schedule", "55 2 * * *")
+	viper.SetDefault("scheduler.jobs.tree_status_snapshot.enabled", true)
+	viper.SetDefault("scheduler.jobs.alert_rules.schedule", "*/15 * * * *")
+	viper.SetDefault("scheduler.jobs.alert_rules.enabled", true)
+	viper.SetDefault("weather.enable", true)
+	viper.SetDefault("weather.host", "https://api.open-meteo.com")
+	viper.SetDefault("weather.timeout", "30s")
+	viper.SetDefault("weather.past_days", 30)
+	viper.SetDefault("weather.forecast_days", 7)
+	viper.SetDefault("weather.crop_coefficient", 0.7)
+	viper.SetDefault("planner.horizon_days", 3)
+	viper.SetDefault("planner.max_clusters_per_plan", 10)
+	viper.SetDefault("planner.max_duration", "8h")
+	viper.SetDefault("planner.min_score", 0.4)
+	viper.SetDefault("planner.crew_size", 2)
+	viper.SetDefault("planner.weights.watering_status", 0.4)
+	viper.SetDefault("planner.weights.last_watered", 0.2)
+	viper.SetDefault("planner.weights.tree_age", 0.15)
+	viper.SetDefault("planner.weights.forecast", 0.25)
+	viper.SetDefault("watering_plan_template.lookahead_days", 14)
+	viper.SetDefault("watering_plan_template.holidays", []string{"10-31"})
+	viper.SetDefault("report.link_expiry", "24h")
+	viper.SetDefault("notification.time_zone", "Europe/Berlin")
+	viper.SetDefault("notification.smtp.enable", false)
+	viper.SetDefault("notification.smtp.port", 587)
+	viper.SetDefault("notification.smtp.tls", true)
+	viper.SetDefault("notification.push.enable", false)
+	viper.SetDefault("notification.push.ttl", "24h")
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
@@ -0,0 +1,116 @@
+package entities
+
+import "time"
+
+type AlertRuleType string
+
+const (
+	// AlertRuleTypeTreeClusterStatus matches tree clusters with one of the watering statuses of the rule
+	AlertRuleTypeTreeClusterStatus AlertRuleType = "tree_cluster_status"
+	// AlertRuleTypeTreeClusterUnplanned matches tree clusters with one of the watering statuses of the rule that
+	// are not part of a planned or active watering plan within the window of the rule
+	AlertRuleTypeTreeClusterUnplanned AlertRuleType = "tree_cluster_unplanned"
+	// AlertRuleTypeSensorBattery matches sensors whose latest battery voltage is below the threshold of the rule
+	AlertRuleTypeSensorBattery AlertRuleType = "sensor_battery"
+)
+
+type AlertSeverity string
+
+const (
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityCritical AlertSeverity = "critical"
+)
+
+type AlertStatus string
+
+const (
+	// AlertStatusPending is an alert whose condition holds, but not yet for the duration of the rule
+	AlertStatusPending  AlertStatus = "pending"
+	AlertStatusFiring   AlertStatus = "firing"
+	AlertStatusResolved AlertStatus = "resolved"
+)
+
+type AlertSubjectType string
+
+const (
+	AlertSubjectTypeTreeCluster AlertSubjectType = "tree_cluster"
+	AlertSubjectTypeSensor      AlertSubjectType = "sensor"
+)
+
+// AlertRule is a condition on the tree clusters or sensors that is evaluated by a scheduled job. RegionIDs restrict
+// tree cluster rules to the regions, an empty list matches all regions. The condition has to hold for the duration
+// For before an alert fires, it is counted from the first evaluation that matched.
+type AlertRule struct {
+	ID               int32
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Name             string
+	Description      string
+	Type             AlertRuleType
+	Severity         AlertSeverity
+	RegionIDs        []int32
+	WateringStatuses []WateringStatus
+	// Threshold is the battery voltage of sensor battery rules
+	Threshold *float64
+	// Window is the planning horizon of tree cluster unplanned rules
+	Window time.Duration
+	For    time.Duration
+	// Notify sends a notification to the users when an alert of the rule fires
+	Notify  bool
+	Enabled bool
+}
+
+// AlertRuleCreate creates a rule, a rule without severity is a warning
+type AlertRuleCreate struct {
+	Name             string `validate:"required"`
+	Description      string
+	Type             AlertRuleType    `validate:"oneof=tree_cluster_status tree_cluster_unplanned sensor_battery"`
+	Severity         AlertSeverity    `validate:"omitempty,oneof=info warning critical"`
+	RegionIDs        []int32          `validate:"dive,gt=0"`
+	WateringStatuses []WateringStatus `validate:"dive,oneof=good moderate bad unknown 'just watered'"`
+	Threshold        *float64
+	Window           time.Duration `validate:"min=0"`
+	For              time.Duration `validate:"min=0"`
+	Notify           bool
+	Enabled          bool
+}
+
+type AlertRuleUpdate struct {
+	Name             string `validate:"required"`
+	Description      string
+	Type             AlertRuleType    `validate:"oneof=tree_cluster_status tree_cluster_unplanned sensor_battery"`
+	Severity         AlertSeverity    `validate:"omitempty,oneof=info warning critical"`
+	RegionIDs        []int32          `validate:"dive,gt=0"`
+	WateringStatuses []WateringStatus `validate:"dive,oneof=good moderate bad unknown 'just watered'"`
+	Threshold        *float64
+	Window           time.Duration `validate:"min=0"`
+	For              time.Duration `validate:"min=0"`
+	Notify           bool
+	Enabled          bool
+}
+
+// Alert tracks the condition of a rule for one tree cluster or sensor. CreatedAt is the first evaluation that
+// matched, an alert that stops matching while pending is removed, a firing alert is resolved and kept.
+type Alert struct {
+	ID             int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	RuleID         int32
+	SubjectType    AlertSubjectType
+	SubjectID      string
+	SubjectName    string
+	Status         AlertStatus
+	Message        string
+	Value          *float64
+	FiredAt        *time.Time
+	ResolvedAt     *time.Time
+	AcknowledgedAt *time.Time
+	AcknowledgedBy *string
+}
+
+type AlertQuery struct {
+	Statuses       []AlertStatus `query:"statuses"`
+	RuleID         *int32        `query:"rule_id"`
+	Unacknowledged bool          `query:"unacknowledged"`
+}
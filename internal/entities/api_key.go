@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// APIKey is a long-lived credential for machine integrations. Only a hash of the
+// key is stored, the plain key is returned once on creation.
+type APIKey struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	// Prefix is the public part of the key and is used to look it up
+	Prefix     string
+	KeyHash    string
+	Scopes     []PluginScope
+	CreatedBy  string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IsActive reports whether the key is neither revoked nor expired at the given time
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func (k *APIKey) Allows(scope PluginScope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+type APIKeyCreate struct {
+	Name      string        `validate:"required"`
+	Scopes    []PluginScope `validate:"required,min=1"`
+	ExpiresAt *time.Time
+	CreatedBy string
+}
+
+type APIKeyUpdate struct {
+	Name      string        `validate:"required"`
+	Scopes    []PluginScope `validate:"required,min=1"`
+	ExpiresAt *time.Time
+}
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+var AuditActions = []AuditAction{
+	AuditActionCreate,
+	AuditActionUpdate,
+	AuditActionDelete,
+}
+
+func (a AuditAction) IsValid() bool {
+	return slices.Contains(AuditActions, a)
+}
+
+type AuditEntityType string
+
+const (
+	AuditEntityTypeTree         AuditEntityType = "tree"
+	AuditEntityTypeTreeCluster  AuditEntityType = "tree_cluster"
+	AuditEntityTypeVehicle      AuditEntityType = "vehicle"
+	AuditEntityTypeWateringPlan AuditEntityType = "watering_plan"
+)
+
+var AuditEntityTypes = []AuditEntityType{
+	AuditEntityTypeTree,
+	AuditEntityTypeTreeCluster,
+	AuditEntityTypeVehicle,
+	AuditEntityTypeWateringPlan,
+}
+
+func (t AuditEntityType) IsValid() bool {
+	return slices.Contains(AuditEntityTypes, t)
+}
+
+// AuditChange holds the json encoded value of a field before and after a change.
+// Old is null on create, New is null on delete.
+type AuditChange struct {
+	Old json.RawMessage
+	New json.RawMessage
+}
+
+// AuditLog is a single write to a domain entity. UserID is the subject of the jwt
+// or "api-key:<prefix>" for requests authenticated with an api key.
+type AuditLog struct {
+	ID         int64
+	CreatedAt  time.Time
+	EntityType AuditEntityType
+	EntityID   int32
+	Action     AuditAction
+	UserID     string
+	// Changes maps the snake case field name to its old and new value
+	Changes map[string]AuditChange
+}
+
+type AuditLogQuery struct {
+	EntityType AuditEntityType `query:"entity_type"`
+	EntityID   *int32          `query:"entity_id"`
+	UserID     string          `query:"user_id"`
+	Action     AuditAction     `query:"action"`
+	From       *time.Time      `query:"-"`
+	To         *time.Time      `query:"-"`
+}
@@ -1,5 +1,11 @@
 package entities
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type Evaluation struct {
 	TreeCount             int64
 	TreeClusterCount      int64
@@ -20,3 +26,107 @@ type RegionEvaluation struct {
 	Name              string
 	WateringPlanCount int64
 }
+
+type EvaluationInterval string
+
+const (
+	EvaluationIntervalDay   EvaluationInterval = "day"
+	EvaluationIntervalWeek  EvaluationInterval = "week"
+	EvaluationIntervalMonth EvaluationInterval = "month"
+)
+
+type EvaluationGroupBy string
+
+const (
+	EvaluationGroupByNone        EvaluationGroupBy = ""
+	EvaluationGroupByRegion      EvaluationGroupBy = "region"
+	EvaluationGroupByTreeCluster EvaluationGroupBy = "tree_cluster"
+	EvaluationGroupByVehicle     EvaluationGroupBy = "vehicle"
+	EvaluationGroupByUser        EvaluationGroupBy = "user"
+)
+
+// EvaluationSeriesQuery selects the dates from and to including both, the length of the periods and the breakdown.
+// Without dates the last 30 days are evaluated, without interval the periods are days.
+type EvaluationSeriesQuery struct {
+	From     *time.Time         `query:"-"`
+	To       *time.Time         `query:"-"`
+	Interval EvaluationInterval `query:"interval"`
+	GroupBy  EvaluationGroupBy  `query:"group_by"`
+}
+
+// EvaluationSeries holds one series per group of the breakdown, without breakdown a single series of all watering plans
+type EvaluationSeries struct {
+	From     time.Time
+	To       time.Time
+	Interval EvaluationInterval
+	GroupBy  EvaluationGroupBy
+	Groups   []*EvaluationGroupSeries
+}
+
+// EvaluationGroupSeries is the series of a region, tree cluster, vehicle or user. Key is the id of the group,
+// it is empty for the series without breakdown and for the tree clusters without region.
+type EvaluationGroupSeries struct {
+	Key     string
+	Name    string
+	Total   *EvaluationPeriod
+	Periods []*EvaluationPeriod
+}
+
+// EvaluationPeriod holds the values of the watering plans dated within the period. The consumed water, refills,
+// distance, hours and watered trees count the finished and not completed watering plans. LitresPerTree and
+// GoodTreePercentage are nil without watered trees or recorded tree statuses.
+type EvaluationPeriod struct {
+	Start              time.Time
+	WateringPlanCount  int64
+	FinishedCount      int64
+	NotCompletedCount  int64
+	WaterConsumed      float64
+	Refills            float64
+	Distance           float64
+	Hours              float64
+	TreesWatered       int64
+	LitresPerTree      *float64
+	GoodTreePercentage *float64
+}
+
+// EvaluationWateringPlanFact is a tree cluster of a watering plan, a watering plan without tree clusters has a
+// single fact without tree cluster. TreeCount is the current number of trees of the tree cluster.
+type EvaluationWateringPlanFact struct {
+	WateringPlanID  int32
+	Date            time.Time
+	Status          WateringPlanStatus
+	Distance        float64
+	Duration        time.Duration
+	RefillCount     int32
+	ClusterCount    int64
+	TreeClusterID   *int32
+	TreeClusterName string
+	RegionID        *int32
+	RegionName      string
+	ConsumedWater   float64
+	TreeCount       int64
+}
+
+// EvaluationWateringPlanVehicle is a vehicle assigned to a watering plan
+type EvaluationWateringPlanVehicle struct {
+	WateringPlanID int32
+	VehicleID      int32
+	NumberPlate    string
+}
+
+// EvaluationWateringPlanUser is a user assigned to a watering plan
+type EvaluationWateringPlanUser struct {
+	WateringPlanID int32
+	UserID         uuid.UUID
+}
+
+// TreeStatusSnapshot is the number of trees of a tree cluster with the watering status at the date
+type TreeStatusSnapshot struct {
+	Date            time.Time
+	TreeClusterID   *int32
+	TreeClusterName string
+	RegionID        *int32
+	RegionName      string
+	WateringStatus  WateringStatus
+	TreeCount       int32
+}
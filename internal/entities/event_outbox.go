@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// OutboxEvent is a published event stored in the outbox of the postgres event bus. The payload
+// is the JSON encoding of the event, TxID the id of the transaction that appended it. The events
+// are ordered by TxID and ID.
+type OutboxEvent struct {
+	ID        int64
+	CreatedAt time.Time
+	EventType EventType
+	Payload   []byte
+	TxID      int64
+}
+
+// EventSubscription is the state of a durable subscription. LastEventTxID and LastEventID are the
+// offset of the subscription, Attempts counts the failed attempts to handle the event after it.
+type EventSubscription struct {
+	Name          string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	EventType     EventType
+	LastEventTxID int64
+	LastEventID   int64
+	Attempts      int32
+	LastError     *string
+	NextAttemptAt time.Time
+	LockedBy      *string
+	LockedUntil   *time.Time
+}
+
+// EventDeadLetter is an event a subscription failed to handle after all attempts
+type EventDeadLetter struct {
+	ID           int64
+	CreatedAt    time.Time
+	Subscription string
+	EventID      int64
+	EventType    EventType
+	Payload      []byte
+	Attempts     int32
+	Error        string
+}
@@ -0,0 +1,35 @@
+package entities
+
+// EventTypeStreamReset is sent on the event stream instead of the missed events if a client can not
+// resume from its last event id. The client has to reload the entities it shows.
+const EventTypeStreamReset EventType = "reset"
+
+// StreamEventTypes are the event types that are forwarded to the clients of the event stream
+var StreamEventTypes = []EventType{
+	EventTypeCreateTree,
+	EventTypeUpdateTree,
+	EventTypeDeleteTree,
+	EventTypeUpdateTreeCluster,
+	EventTypeNewSensorData,
+	EventTypeUpdateWateringPlan,
+}
+
+// StreamEvent is a domain event encoded for the clients of the event stream. The id is the id of the
+// event in the outbox and is used to resume the stream, it is empty if the event was not stored in
+// the outbox. Data is the same JSON payload that is delivered to webhooks.
+type StreamEvent struct {
+	ID   string
+	Type EventType
+	Data []byte
+}
+
+// EventStreamQuery filters the events of the event stream. An empty filter matches all events. If
+// entity ids are given, an event matches if it references at least one of them.
+type EventStreamQuery struct {
+	EventTypes      []EventType `query:"event_types"`
+	RegionIDs       []int32     `query:"region_ids"`
+	TreeIDs         []int32     `query:"tree_ids"`
+	TreeClusterIDs  []int32     `query:"tree_cluster_ids"`
+	SensorIDs       []string    `query:"sensor_ids"`
+	WateringPlanIDs []int32     `query:"watering_plan_ids"`
+}
@@ -13,6 +13,10 @@ const (
 	EventTypeUpdateTreeCluster  EventType = "update tree cluster"
 	EventTypeNewSensorData      EventType = "receive sensor data"
 	EventTypeUpdateWateringPlan EventType = "update watering plan"
+	EventTypeImportTrees        EventType = "import trees"
+	EventTypeCreateWateringPlan EventType = "create watering plan"
+	EventTypeUpdateSensor       EventType = "update sensor"
+	EventTypeFireAlert          EventType = "fire alert"
 )
 
 type BasicEvent struct {
@@ -104,3 +108,63 @@ func NewEventUpdateWateringPlan(prev, newWp *WateringPlan) EventUpdateWateringPl
 		New:        newWp,
 	}
 }
+
+type EventCreateWateringPlan struct {
+	BasicEvent
+	New *WateringPlan
+}
+
+func NewEventCreateWateringPlan(newWp *WateringPlan) EventCreateWateringPlan {
+	return EventCreateWateringPlan{
+		BasicEvent: BasicEvent{eventType: EventTypeCreateWateringPlan},
+		New:        newWp,
+	}
+}
+
+// EventUpdateSensor is published when the status of a sensor is changed by the sensor status job
+type EventUpdateSensor struct {
+	BasicEvent
+	Prev *Sensor
+	New  *Sensor
+}
+
+func NewEventUpdateSensor(prev, newSensor *Sensor) EventUpdateSensor {
+	return EventUpdateSensor{
+		BasicEvent: BasicEvent{eventType: EventTypeUpdateSensor},
+		Prev:       prev,
+		New:        newSensor,
+	}
+}
+
+// EventFireAlert is published when an alert of a rule starts firing
+type EventFireAlert struct {
+	BasicEvent
+	Rule  *AlertRule
+	Alert *Alert
+}
+
+func NewEventFireAlert(rule *AlertRule, alert *Alert) EventFireAlert {
+	return EventFireAlert{
+		BasicEvent: BasicEvent{eventType: EventTypeFireAlert},
+		Rule:       rule,
+		Alert:      alert,
+	}
+}
+
+// EventImportTrees is published once per committed tree import instead of one event per tree,
+// so every affected tree cluster is only recalculated once.
+type EventImportTrees struct {
+	BasicEvent
+	JobID          int32
+	Changes        []*TreeImportChange
+	TreeClusterIDs []int32
+}
+
+func NewEventImportTrees(jobID int32, changes []*TreeImportChange, treeClusterIDs []int32) EventImportTrees {
+	return EventImportTrees{
+		BasicEvent:     BasicEvent{eventType: EventTypeImportTrees},
+		JobID:          jobID,
+		Changes:        changes,
+		TreeClusterIDs: treeClusterIDs,
+	}
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "slices"
+
+type ExportFormat string
+
+const (
+	ExportFormatGeoJSON    ExportFormat = "geojson"
+	ExportFormatCSV        ExportFormat = "csv"
+	ExportFormatGeoPackage ExportFormat = "gpkg"
+)
+
+var ExportFormats = []ExportFormat{
+	ExportFormatGeoJSON,
+	ExportFormatCSV,
+	ExportFormatGeoPackage,
+}
+
+func (f ExportFormat) IsValid() bool {
+	return slices.Contains(ExportFormats, f)
+}
+
+// ExportResource is the kind of entity written by an export. The values match the api resources.
+type ExportResource string
+
+const (
+	ExportResourceTree         ExportResource = "tree"
+	ExportResourceTreeCluster  ExportResource = "cluster"
+	ExportResourceWateringPlan ExportResource = "watering-plan"
+)
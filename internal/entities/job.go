@@ -0,0 +1,65 @@
+package entities
+
+import "time"
+
+// The names of the registered jobs, the schedule of a job is configured by its name
+const (
+	JobSensorStatus              = "sensor_status"
+	JobWateringPlanStatus        = "watering_plan_status"
+	JobTreeClusterWateringStatus = "tree_cluster_watering_status"
+	JobTreeWateringStatus        = "tree_watering_status"
+	JobWeather                   = "weather"
+	JobWateringPlanTemplates     = "watering_plan_templates"
+	JobTreeStatusSnapshot        = "tree_status_snapshot"
+	JobAlertRules                = "alert_rules"
+)
+
+type JobRunStatus string
+
+const (
+	// JobRunStatusPending is a manual run that waits for the leader instance
+	JobRunStatusPending JobRunStatus = "pending"
+	JobRunStatusRunning JobRunStatus = "running"
+	JobRunStatusSuccess JobRunStatus = "success"
+	JobRunStatusFailed  JobRunStatus = "failed"
+)
+
+type JobRunTrigger string
+
+const (
+	JobRunTriggerSchedule JobRunTrigger = "schedule"
+	JobRunTriggerManual   JobRunTrigger = "manual"
+)
+
+// Job is a registered job together with its schedule. Disabled jobs only run when they are triggered manually.
+type Job struct {
+	Name      string
+	Schedule  string
+	Enabled   bool
+	NextRunAt *time.Time
+	LastRun   *JobRun
+}
+
+// JobRun is one execution of a job. Instance is the name of the instance that ran the job,
+// RequestedBy the user or api key that triggered a manual run.
+type JobRun struct {
+	ID          int64
+	CreatedAt   time.Time
+	Job         string
+	Trigger     JobRunTrigger
+	Status      JobRunStatus
+	Instance    string
+	RequestedBy string
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	Duration    *time.Duration
+	Error       *string
+}
+
+type JobRunCreate struct {
+	Job         string
+	Trigger     JobRunTrigger
+	Status      JobRunStatus
+	Instance    string
+	RequestedBy string
+}
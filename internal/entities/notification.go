@@ -0,0 +1,162 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeTreeClusterBad       NotificationType = "tree_cluster_bad"
+	NotificationTypeSensorOffline        NotificationType = "sensor_offline"
+	NotificationTypeWateringPlanAssigned NotificationType = "watering_plan_assigned"
+	NotificationTypeAlert                NotificationType = "alert"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationTypeTreeClusterBad,
+	NotificationTypeSensorOffline,
+	NotificationTypeWateringPlanAssigned,
+	NotificationTypeAlert,
+}
+
+// NotificationEventTypes are the event types that can create notifications
+var NotificationEventTypes = []EventType{
+	EventTypeUpdateTreeCluster,
+	EventTypeUpdateSensor,
+	EventTypeCreateWateringPlan,
+	EventTypeUpdateWateringPlan,
+	EventTypeFireAlert,
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelPush  NotificationChannel = "push"
+)
+
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelInApp,
+	NotificationChannelEmail,
+	NotificationChannelPush,
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusSent    NotificationDeliveryStatus = "sent"
+	NotificationDeliveryStatusFailed  NotificationDeliveryStatus = "failed"
+)
+
+// Notification is a message to one user. It is shown in the inbox of the user if the in-app channel is
+// enabled for its type, the other channels are sent by a NotificationDelivery.
+type Notification struct {
+	ID        int32
+	CreatedAt time.Time
+	UserID    uuid.UUID
+	Type      NotificationType
+	Title     string
+	Body      string
+	Link      string
+	InApp     bool
+	ReadAt    *time.Time
+	// EventID is the outbox id of the event the notification was created for, nil if it has no id
+	EventID *int64
+}
+
+type NotificationQuery struct {
+	Unread bool `query:"unread"`
+}
+
+// NotificationDelivery sends a notification by email or push. Deliveries are not sent before SendAfter,
+// which postpones them until the end of the quiet hours or the next digest of the user. All due
+// deliveries of a user and channel are bundled into one message.
+type NotificationDelivery struct {
+	ID             int32
+	CreatedAt      time.Time
+	NotificationID int32
+	UserID         uuid.UUID
+	Channel        NotificationChannel
+	Status         NotificationDeliveryStatus
+	Attempts       int32
+	SendAfter      time.Time
+	SentAt         *time.Time
+	LastError      *string
+	Notification   *Notification
+}
+
+// NotificationSettings are the preferences of a user. Channels holds the enabled channels per
+// notification type, a type without channels is not notified. The quiet hours and the digest time are
+// given as HH:MM in the time zone of the notifications, quiet hours may span midnight.
+type NotificationSettings struct {
+	UserID          uuid.UUID
+	UpdatedAt       time.Time
+	Channels        map[NotificationType][]NotificationChannel
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Digest          bool
+	DigestTime      string
+}
+
+// DefaultNotificationSettings are used for users that have not saved their preferences. Only the
+// assignment to a watering plan and alerts are sent by email, everything else is shown in the inbox.
+func DefaultNotificationSettings(userID uuid.UUID) *NotificationSettings {
+	return &NotificationSettings{
+		UserID: userID,
+		Channels: map[NotificationType][]NotificationChannel{
+			NotificationTypeTreeClusterBad:       {NotificationChannelInApp},
+			NotificationTypeSensorOffline:        {NotificationChannelInApp},
+			NotificationTypeWateringPlanAssigned: {NotificationChannelInApp, NotificationChannelEmail},
+			NotificationTypeAlert:                {NotificationChannelInApp, NotificationChannelEmail},
+		},
+		DigestTime: "07:00",
+	}
+}
+
+func (s *NotificationSettings) Enabled(notificationType NotificationType, channel NotificationChannel) bool {
+	return slices.Contains(s.Channels[notificationType], channel)
+}
+
+type NotificationSettingsUpdate struct {
+	Channels        map[NotificationType][]NotificationChannel
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Digest          bool
+	DigestTime      string
+}
+
+// PushSubscription is the Web Push subscription of a browser, the keys are base64 url encoded
+type PushSubscription struct {
+	ID        int32
+	CreatedAt time.Time
+	UserID    uuid.UUID
+	Endpoint  string
+	P256dh    string
+	Auth      string
+}
+
+type PushSubscriptionCreate struct {
+	Endpoint string `validate:"required,url"`
+	P256dh   string `validate:"required"`
+	Auth     string `validate:"required"`
+}
+
+// Mail is a plain text email
+type Mail struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// PushMessage is the payload of a push message that is shown by the service worker of the frontend
+type PushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Link  string `json:"link,omitempty"`
+}
@@ -0,0 +1,76 @@
+package entities
+
+import "time"
+
+// OGCCollectionID identifies a collection of the OGC API Features service
+type OGCCollectionID string
+
+const (
+	OGCCollectionTrees        OGCCollectionID = "trees"
+	OGCCollectionTreeClusters OGCCollectionID = "tree-clusters"
+	OGCCollectionSensors      OGCCollectionID = "sensors"
+	OGCCollectionRegions      OGCCollectionID = "regions"
+)
+
+type OGCQueryableType string
+
+const (
+	OGCQueryableTypeString  OGCQueryableType = "string"
+	OGCQueryableTypeInteger OGCQueryableType = "integer"
+	OGCQueryableTypeNumber  OGCQueryableType = "number"
+	OGCQueryableTypeBoolean OGCQueryableType = "boolean"
+)
+
+// OGCQueryable is a property of a collection that can be used to filter its features
+type OGCQueryable struct {
+	Name string
+	Type OGCQueryableType
+}
+
+type OGCCollection struct {
+	ID          OGCCollectionID
+	Title       string
+	Description string
+	Extent      *OGCExtent
+	Queryables  []OGCQueryable
+}
+
+// OGCExtent is the bounding box of all geometries and the time span of the
+// last changes of all features of a collection
+type OGCExtent struct {
+	BBox     *BoundingBox
+	Interval *TimeInterval
+}
+
+// BoundingBox in longitude and latitude of EPSG:4326
+type BoundingBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// TimeInterval is a closed interval, a missing start or end is unbounded
+type TimeInterval struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+type OGCFeature struct {
+	ID string
+	// Geometry is the geojson encoded geometry or nil if the feature has no geometry
+	Geometry   []byte
+	UpdatedAt  time.Time
+	Properties map[string]any
+}
+
+type OGCFeatureQuery struct {
+	BBox *BoundingBox
+	// Datetime filters on the time of the last change of a feature
+	Datetime *TimeInterval
+	// Properties must be contained in the properties of a feature. The service
+	// converts the values to the type of the queryable.
+	Properties map[string]any
+	Limit      int32
+	Offset     int32
+}
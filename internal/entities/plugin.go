@@ -1,7 +1,13 @@
 package entities
 
 import (
+	"fmt"
+	"math"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type AuthPlugin struct {
@@ -10,10 +16,238 @@ type AuthPlugin struct {
 }
 
 type Plugin struct {
-	Slug        string `validate:"required"`
-	Name        string `validate:"required"`
-	Path        url.URL
-	Version     string
-	Description string
-	Auth        AuthPlugin
+	Slug string `validate:"required"`
+	Name string `validate:"required"`
+	Path url.URL
+	// Version defaults to the negotiated host api version if the plugin does not declare one
+	Version      string
+	Description  string
+	Scopes       []PluginScope
+	Auth         AuthPlugin
+	Manifest     PluginManifest
+	Proxy        PluginProxy
+	Requirements PluginRequirements
+	// APIVersion is the host api version negotiated on registration
+	APIVersion    string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	LastHeartbeat time.Time
+}
+
+// PluginManifest describes how the plugin ui is integrated into the frontend navigation
+type PluginManifest struct {
+	// Entry is the path of the ui entry point relative to the plugin path
+	Entry     string
+	MenuLabel string
+	Icon      string
+	// Roles a user needs to see the plugin. An empty list allows every user.
+	Roles []UserRole
+}
+
+// IsValid checks that all roles are known and that the entry point is a relative
+// path inside the plugin, so the frontend never loads a plugin ui from another origin.
+func (m *PluginManifest) IsValid() bool {
+	for _, role := range m.Roles {
+		if ParseUserRole(string(role)) == UserRoleUnknown {
+			return false
+		}
+	}
+
+	if m.Entry == "" {
+		return true
+	}
+
+	entry, err := url.Parse(m.Entry)
+	if err != nil || entry.Scheme != "" || entry.Host != "" || strings.HasPrefix(m.Entry, "//") {
+		return false
+	}
+
+	return !slices.Contains(strings.Split(entry.Path, "/"), "..")
+}
+
+// PluginProxy configures the reverse proxy to the plugin.
+// Zero values fall back to the defaults of the plugin host.
+type PluginProxy struct {
+	Timeout     time.Duration `validate:"min=0"`
+	MaxBodySize int64         `validate:"min=0"`
+}
+
+// PluginHostAPIVersions are the api versions served by the plugin host, oldest first
+var PluginHostAPIVersions = []string{"v1"}
+
+type PluginFeature string
+
+const (
+	PluginFeatureScopes      PluginFeature = "scopes"
+	PluginFeatureWebhooks    PluginFeature = "webhooks"
+	PluginFeatureUIManifest  PluginFeature = "ui-manifest"
+	PluginFeatureProxyConfig PluginFeature = "proxy-config"
+)
+
+// PluginHostFeatures are the features supported by the plugin host
+var PluginHostFeatures = []PluginFeature{
+	PluginFeatureScopes,
+	PluginFeatureWebhooks,
+	PluginFeatureUIManifest,
+	PluginFeatureProxyConfig,
+}
+
+// PluginRequirements are declared by the plugin on registration. An empty
+// MinAPIVersion or MaxAPIVersion leaves the range open on that side.
+type PluginRequirements struct {
+	MinAPIVersion string
+	MaxAPIVersion string
+	Features      []PluginFeature
+}
+
+// PluginIncompatibility describes why a plugin can not be registered with this host
+type PluginIncompatibility struct {
+	Reason          string
+	MissingFeatures []PluginFeature
+}
+
+// Negotiate returns the highest host api version inside the requested range.
+// If the host can not satisfy the requirements the incompatibility is returned instead.
+func (r *PluginRequirements) Negotiate() (string, *PluginIncompatibility) {
+	minVersion, minOk := parseAPIVersion(r.MinAPIVersion, 0)
+	maxVersion, maxOk := parseAPIVersion(r.MaxAPIVersion, math.MaxInt)
+	if !minOk || !maxOk {
+		return "", &PluginIncompatibility{
+			Reason: fmt.Sprintf("invalid api version range %q - %q, expected versions like \"v1\"", r.MinAPIVersion, r.MaxAPIVersion),
+		}
+	}
+
+	missing := make([]PluginFeature, 0)
+	for _, feature := range r.Features {
+		if !slices.Contains(PluginHostFeatures, feature) {
+			missing = append(missing, feature)
+		}
+	}
+	if len(missing) > 0 {
+		return "", &PluginIncompatibility{
+			Reason:          "plugin requires features that are not supported by the host",
+			MissingFeatures: missing,
+		}
+	}
+
+	for _, version := range slices.Backward(PluginHostAPIVersions) {
+		v, _ := parseAPIVersion(version, 0)
+		if v >= minVersion && v <= maxVersion {
+			return version, nil
+		}
+	}
+
+	return "", &PluginIncompatibility{
+		Reason: fmt.Sprintf("no supported api version in range %q - %q", r.MinAPIVersion, r.MaxAPIVersion),
+	}
+}
+
+func parseAPIVersion(version string, fallback int) (int, bool) {
+	if version == "" {
+		return fallback, true
+	}
+
+	v, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil || !strings.HasPrefix(version, "v") || v < 1 {
+		return 0, false
+	}
+	return v, true
+}
+
+// PluginGrant binds a plugin slug to the client id that is allowed to register it
+// and to the scopes the plugin is allowed to request.
+type PluginGrant struct {
+	Slug      string `validate:"required"`
+	ClientID  string `validate:"required"`
+	Scopes    []PluginScope
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (g *PluginGrant) Allows(scopes ...PluginScope) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(g.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type PluginEventType string
+
+const (
+	PluginEventTypeRegister   PluginEventType = "register"
+	PluginEventTypeUnregister PluginEventType = "unregister"
+	PluginEventTypeTimeout    PluginEventType = "timeout"
+)
+
+type PluginEvent struct {
+	ID         int32
+	CreatedAt  time.Time
+	PluginSlug string
+	Type       PluginEventType
+	Version    string
+}
+
+type PluginResource string
+
+const (
+	PluginResourceInfo         PluginResource = "info"
+	PluginResourceTree         PluginResource = "tree"
+	PluginResourceTreeCluster  PluginResource = "cluster"
+	PluginResourceSensor       PluginResource = "sensor"
+	PluginResourceUser         PluginResource = "user"
+	PluginResourceRegion       PluginResource = "region"
+	PluginResourceVehicle      PluginResource = "vehicle"
+	PluginResourceWateringPlan PluginResource = "watering-plan"
+	PluginResourceEvaluation   PluginResource = "evaluation"
+	PluginResourcePlugin       PluginResource = "plugin"
+	PluginResourceWebhook      PluginResource = "webhook"
+	PluginResourceAPIKey       PluginResource = "api-key"
+	PluginResourceEvents       PluginResource = "events"
+	PluginResourceAlert        PluginResource = "alert"
+)
+
+var pluginResources = []PluginResource{
+	PluginResourceInfo,
+	PluginResourceTree,
+	PluginResourceTreeCluster,
+	PluginResourceSensor,
+	PluginResourceUser,
+	PluginResourceRegion,
+	PluginResourceVehicle,
+	PluginResourceWateringPlan,
+	PluginResourceEvaluation,
+	PluginResourcePlugin,
+	PluginResourceWebhook,
+	PluginResourceAPIKey,
+	PluginResourceEvents,
+	PluginResourceAlert,
+}
+
+type PluginAccess string
+
+const (
+	PluginAccessRead  PluginAccess = "read"
+	PluginAccessWrite PluginAccess = "write"
+)
+
+// PluginScope restricts which API resource a plugin token can use, e.g. "tree:read"
+type PluginScope string
+
+func NewPluginScope(resource PluginResource, access PluginAccess) PluginScope {
+	return PluginScope(fmt.Sprintf("%s:%s", resource, access))
+}
+
+func (s PluginScope) IsValid() bool {
+	resource, access, ok := strings.Cut(string(s), ":")
+	if !ok {
+		return false
+	}
+
+	if access != string(PluginAccessRead) && access != string(PluginAccessWrite) {
+		return false
+	}
+
+	return slices.Contains(pluginResources, PluginResource(resource))
 }
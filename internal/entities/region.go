@@ -7,4 +7,16 @@ type Region struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Name      string
+	// Geometry is the multi polygon of the region as WKT in longitude latitude order
+	Geometry string
+}
+
+type RegionCreate struct {
+	Name     string `validate:"required"`
+	Geometry string `validate:"required"`
+}
+
+type RegionUpdate struct {
+	Name     string `validate:"required"`
+	Geometry string `validate:"required"`
 }
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatPDF ReportFormat = "pdf"
+	ReportFormatCSV ReportFormat = "csv"
+)
+
+var ReportFormats = []ReportFormat{
+	ReportFormatPDF,
+	ReportFormatCSV,
+}
+
+func (f ReportFormat) IsValid() bool {
+	return slices.Contains(ReportFormats, f)
+}
+
+func (f ReportFormat) ContentType() string {
+	if f == ReportFormatCSV {
+		return "text/csv;charset=UTF-8"
+	}
+	return "application/pdf"
+}
+
+type ReportKind string
+
+const (
+	ReportKindWateringPlan ReportKind = "watering_plan"
+	ReportKindSeason       ReportKind = "season"
+)
+
+// Report is a generated report stored in the report bucket. URL is a signed download link that is valid until ExpiresAt.
+type Report struct {
+	Name      string
+	Kind      ReportKind
+	Format    ReportFormat
+	CreatedAt time.Time
+	URL       string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,24 @@
+package entities
+
+// Coordinate is a point in EPSG:4326
+type Coordinate struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// SpatialQuery filters entities by their location, all filters must match.
+// The fields are filled by the http handler, they can't be bound by the query parser.
+type SpatialQuery struct {
+	BBox *BoundingBox `query:"-"`
+	// Near sorts the entities by distance to the coordinate, the nearest first
+	Near *Coordinate `query:"-"`
+	// Radius in meters around Near the entities must be located in, zero does not filter
+	Radius float64 `query:"-"`
+	// Polygon is a polygon or multi polygon as WKT with longitude and latitude the entities must be located in
+	Polygon string `query:"-"`
+}
+
+type SensorQuery struct {
+	SpatialQuery
+	Query
+}
@@ -0,0 +1,25 @@
+package entities
+
+// TileCoordinate addresses a tile of the web mercator tile matrix set
+type TileCoordinate struct {
+	Z int32
+	X int32
+	Y int32
+}
+
+// IsValid reports if the tile exists at its zoom level
+func (t TileCoordinate) IsValid() bool {
+	if t.Z < 0 || t.Z > 30 {
+		return false
+	}
+	n := int32(1) << t.Z
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+type TileQuery struct {
+	TileCoordinate
+	// Attributes are the properties of the features that are written to the tile
+	Attributes []string
+	// Tolerance in web mercator meters the geometries are simplified with
+	Tolerance float64
+}
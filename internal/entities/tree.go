@@ -8,6 +8,7 @@ type Tree struct {
 	ID             int32
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	Version        int32
 	TreeCluster    *TreeCluster
 	Sensor         *Sensor
 	PlantingYear   int32
@@ -52,5 +53,6 @@ type TreeQuery struct {
 	WateringStatuses []WateringStatus `query:"watering_statuses"`
 	HasCluster       *bool            `query:"has_cluster"`
 	PlantingYears    []int32          `query:"planting_years"`
+	SpatialQuery
 	Query
 }
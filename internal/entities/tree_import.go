@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+type TreeImportFormat string
+
+const (
+	TreeImportFormatCSV        TreeImportFormat = "csv"
+	TreeImportFormatGeoJSON    TreeImportFormat = "geojson"
+	TreeImportFormatGeoPackage TreeImportFormat = "gpkg"
+)
+
+var TreeImportFormats = []TreeImportFormat{
+	TreeImportFormatCSV,
+	TreeImportFormatGeoJSON,
+	TreeImportFormatGeoPackage,
+}
+
+func (f TreeImportFormat) IsValid() bool {
+	return slices.Contains(TreeImportFormats, f)
+}
+
+type TreeImportStatus string
+
+const (
+	// TreeImportStatusPending waits for the dry run validation
+	TreeImportStatusPending    TreeImportStatus = "pending"
+	TreeImportStatusValidating TreeImportStatus = "validating"
+	// TreeImportStatusValidated passed the dry run and can be committed
+	TreeImportStatusValidated TreeImportStatus = "validated"
+	// TreeImportStatusInvalid has row errors and can not be committed
+	TreeImportStatusInvalid TreeImportStatus = "invalid"
+	// TreeImportStatusQueued waits for the commit
+	TreeImportStatusQueued     TreeImportStatus = "queued"
+	TreeImportStatusCommitting TreeImportStatus = "committing"
+	TreeImportStatusCommitted  TreeImportStatus = "committed"
+	TreeImportStatusFailed     TreeImportStatus = "failed"
+)
+
+// TreeImportField is a field of TreeCreate that can be filled from a column of the import file
+type TreeImportField string
+
+const (
+	TreeImportFieldNumber        TreeImportField = "number"
+	TreeImportFieldSpecies       TreeImportField = "species"
+	TreeImportFieldPlantingYear  TreeImportField = "planting_year"
+	TreeImportFieldLatitude      TreeImportField = "latitude"
+	TreeImportFieldLongitude     TreeImportField = "longitude"
+	TreeImportFieldDescription   TreeImportField = "description"
+	TreeImportFieldProvider      TreeImportField = "provider"
+	TreeImportFieldTreeClusterID TreeImportField = "tree_cluster_id"
+	TreeImportFieldSensorID      TreeImportField = "sensor_id"
+)
+
+var TreeImportFields = []TreeImportField{
+	TreeImportFieldNumber,
+	TreeImportFieldSpecies,
+	TreeImportFieldPlantingYear,
+	TreeImportFieldLatitude,
+	TreeImportFieldLongitude,
+	TreeImportFieldDescription,
+	TreeImportFieldProvider,
+	TreeImportFieldTreeClusterID,
+	TreeImportFieldSensorID,
+}
+
+func (f TreeImportField) IsValid() bool {
+	return slices.Contains(TreeImportFields, f)
+}
+
+// TreeImportMapping maps a field to the column of the import file. Fields without
+// a mapping are read from the column with the name of the field. For GeoJSON and
+// GeoPackage files the coordinates are taken from the point geometry unless they are mapped.
+type TreeImportMapping map[TreeImportField]string
+
+func (m TreeImportMapping) Column(field TreeImportField) string {
+	if column, ok := m[field]; ok && column != "" {
+		return column
+	}
+	return string(field)
+}
+
+// TreeImportRowError describes why a row of the import file can not be imported.
+// Rows are counted from 1, the csv header is not counted.
+type TreeImportRowError struct {
+	Row     int32
+	Field   TreeImportField
+	Message string
+}
+
+type TreeImportJob struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	FileName  string
+	Format    TreeImportFormat
+	Status    TreeImportStatus
+	Mapping   TreeImportMapping
+	// Provider is used for rows without a provider column
+	Provider    string
+	TotalRows   int32
+	ValidRows   int32
+	CreatedRows int32
+	UpdatedRows int32
+	RowErrors   []TreeImportRowError
+	Error       *string
+	CreatedBy   string
+	// ClaimedAt is the time an instance started to validate or commit the job
+	ClaimedAt *time.Time
+}
+
+// IsProcessing reports whether an instance validates or commits the job. A job whose claim is older than
+// the lease is not processed anymore, e.g. because the instance crashed, and is claimed again.
+func (j *TreeImportJob) IsProcessing(now time.Time, lease time.Duration) bool {
+	if j.Status != TreeImportStatusValidating && j.Status != TreeImportStatusCommitting {
+		return false
+	}
+	claimedAt := j.UpdatedAt
+	if j.ClaimedAt != nil {
+		claimedAt = *j.ClaimedAt
+	}
+	return now.Sub(claimedAt) < lease
+}
+
+type TreeImportCreate struct {
+	FileName  string           `validate:"required"`
+	Format    TreeImportFormat `validate:"required"`
+	Mapping   TreeImportMapping
+	Provider  string
+	File      []byte `validate:"required"`
+	CreatedBy string
+}
+
+// TreeImportChange is the result of importing one tree. Prev is nil if the tree was created.
+type TreeImportChange struct {
+	Prev *Tree
+	New  *Tree
+}
@@ -16,6 +16,7 @@ type TreeCluster struct {
 	ID             int32
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	Version        int32
 	WateringStatus WateringStatus
 	LastWatered    *time.Time
 	MoistureLevel  float64
@@ -30,6 +31,23 @@ type TreeCluster struct {
 	Name           string
 	Provider       string
 	AdditionalInfo map[string]interface{}
+	// Polygon is the area of the tree cluster as WKT with longitude and latitude
+	Polygon string
+	// PolygonManual is true if the polygon is drawn manually instead of computed from the trees
+	PolygonManual bool
+	// Area of the polygon in square meters
+	Area *float64
+	// Perimeter of the polygon in meters
+	Perimeter *float64
+}
+
+// TreeDensity returns the number of trees per hectare or 0 if the tree cluster has no area
+func (tc *TreeCluster) TreeDensity() float64 {
+	if tc.Area == nil || *tc.Area == 0 {
+		return 0
+	}
+
+	return float64(len(tc.Trees)) / *tc.Area * 10_000
 }
 
 type TreeClusterCreate struct {
@@ -40,6 +58,8 @@ type TreeClusterCreate struct {
 	TreeIDs        []*int32
 	Provider       string
 	AdditionalInfo map[string]interface{}
+	// Polygon overrides the computed area of the tree cluster, empty to compute it from the trees
+	Polygon string
 }
 
 type TreeClusterUpdate struct {
@@ -50,10 +70,44 @@ type TreeClusterUpdate struct {
 	Name           string `validate:"required"`
 	Provider       string
 	AdditionalInfo map[string]interface{}
+	// Polygon overrides the computed area of the tree cluster, empty to compute it from the trees
+	Polygon string
 }
 
 type TreeClusterQuery struct {
 	WateringStatuses []WateringStatus `query:"watering_statuses"`
 	Regions          []string         `query:"regions"`
+	SpatialQuery
 	Query
 }
+
+// TreeClusterSuggestionQuery configures the spatial clustering of trees into suggested tree clusters
+type TreeClusterSuggestionQuery struct {
+	// Distance is the maximum distance in meters between neighboring trees of a cluster
+	Distance float64 `query:"distance"`
+	// MinTrees is the minimum number of trees of a cluster, trees in smaller groups are not suggested
+	MinTrees int32 `query:"min_trees"`
+	// MaxTrees is the number of trees at which a group is split into smaller clusters
+	MaxTrees int32 `query:"max_trees"`
+	// IncludeClustered re-evaluates trees that already belong to a tree cluster
+	IncludeClustered bool `query:"include_clustered"`
+}
+
+type TreeClusterSuggestion struct {
+	TreeIDs       []int32
+	Latitude      float64
+	Longitude     float64
+	Region        *Region
+	SoilCondition TreeSoilCondition
+	// TreeClusterID is the existing tree cluster most of the trees belong to, nil if a new cluster is suggested
+	TreeClusterID *int32
+}
+
+// TreeClusterSuggestionAccept accepts a suggestion. Without TreeClusterID a new tree cluster is created,
+// otherwise the trees of the existing tree cluster are replaced.
+type TreeClusterSuggestionAccept struct {
+	TreeClusterID *int32
+	Name          string  `validate:"required_without=TreeClusterID"`
+	TreeIDs       []int32 `validate:"required,min=1"`
+	SoilCondition TreeSoilCondition
+}
@@ -21,6 +21,7 @@ type WateringPlan struct {
 	ID                 int32
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
+	Version            int32
 	Date               time.Time
 	Description        string
 	Status             WateringPlanStatus
@@ -50,6 +51,30 @@ type WateringPlanCreate struct {
 	AdditionalInfo map[string]interface{}
 }
 
+// WateringPlanProposalQuery selects the number of upcoming days to propose watering plans for, starting tomorrow.
+// Without days the planning horizon of the configuration is used.
+type WateringPlanProposalQuery struct {
+	Days int `query:"days"`
+}
+
+// TreeClusterUrgency is the urgency of watering a tree cluster between 0 and 1. BadAt is the first forecast
+// day on which the tree cluster is estimated to turn bad.
+type TreeClusterUrgency struct {
+	TreeCluster *TreeCluster
+	Score       float64
+	BadAt       *time.Time
+}
+
+// WateringPlanProposal is a draft of a watering plan that can be accepted as it is. Score is the sum of the
+// urgencies of the tree clusters, Route is nil if the route could not be optimized.
+type WateringPlanProposal struct {
+	Plan         WateringPlanCreate
+	TreeClusters []*TreeClusterUrgency
+	Transporter  *Vehicle
+	Score        float64
+	Route        *RouteMetadata
+}
+
 type WateringPlanUpdate struct {
 	Date             time.Time `validate:"required"`
 	Description      string
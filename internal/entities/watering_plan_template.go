@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WateringPlanTemplate creates a watering plan with its tree clusters, vehicles and users for every occurrence of
+// the recurrence rule within the season. RRule is the rule part of an RFC 5545 recurrence rule starting at
+// StartDate, e.g. FREQ=WEEKLY;BYDAY=TU. SeasonStart and SeasonEnd are month and day in the format MM-DD, an
+// empty season lasts the whole year.
+type WateringPlanTemplate struct {
+	ID             int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Name           string
+	Description    string
+	RRule          string
+	StartDate      time.Time
+	SeasonStart    string
+	SeasonEnd      string
+	TreeClusterIDs []int32
+	TransporterID  *int32
+	TrailerID      *int32
+	UserIDs        []uuid.UUID
+	Enabled        bool
+}
+
+type WateringPlanTemplateCreate struct {
+	Name           string `validate:"required"`
+	Description    string
+	RRule          string    `validate:"required"`
+	StartDate      time.Time `validate:"required"`
+	SeasonStart    string    `validate:"required_with=SeasonEnd,omitempty,datetime=01-02"`
+	SeasonEnd      string    `validate:"required_with=SeasonStart,omitempty,datetime=01-02"`
+	TreeClusterIDs []int32   `validate:"required,min=1"`
+	TransporterID  *int32    `validate:"required"`
+	TrailerID      *int32
+	UserIDs        []uuid.UUID `validate:"required,min=1,dive,required"`
+	Enabled        bool
+}
+
+type WateringPlanTemplateUpdate struct {
+	Name           string `validate:"required"`
+	Description    string
+	RRule          string    `validate:"required"`
+	StartDate      time.Time `validate:"required"`
+	SeasonStart    string    `validate:"required_with=SeasonEnd,omitempty,datetime=01-02"`
+	SeasonEnd      string    `validate:"required_with=SeasonStart,omitempty,datetime=01-02"`
+	TreeClusterIDs []int32   `validate:"required,min=1"`
+	TransporterID  *int32    `validate:"required"`
+	TrailerID      *int32
+	UserIDs        []uuid.UUID `validate:"required,min=1,dive,required"`
+	Enabled        bool
+}
+
+// WateringPlanTemplateOccurrence is a materialized occurrence of a template. WateringPlanID is nil if the
+// occurrence was skipped because of a holiday or the watering plan was deleted.
+type WateringPlanTemplateOccurrence struct {
+	TemplateID     int32
+	Date           time.Time
+	CreatedAt      time.Time
+	WateringPlanID *int32
+	Holiday        bool
+}
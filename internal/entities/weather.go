@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// WeatherLocation is the point of a region at which the weather of the region is fetched
+type WeatherLocation struct {
+	RegionID  int32
+	Latitude  float64
+	Longitude float64
+}
+
+// WeatherDay is the weather of a region on one day. Precipitation and the reference evapotranspiration
+// ET0 are given in millimeters, the temperatures in degrees Celsius. Forecast is true for days that have
+// not passed yet.
+type WeatherDay struct {
+	RegionID        int32
+	Date            time.Time
+	UpdatedAt       time.Time
+	Precipitation   float64
+	TemperatureMin  float64
+	TemperatureMax  float64
+	TemperatureMean float64
+	ET0             float64
+	Forecast        bool
+}
+
+// WaterBalance is the estimated soil water deficit of a tree cluster in millimeters at the end of a day.
+// A deficit of 0 means the soil is at field capacity.
+type WaterBalance struct {
+	TreeClusterID  int32
+	Date           time.Time
+	Deficit        float64
+	WateringStatus WateringStatus
+	Forecast       bool
+}
+
+// TreeClusterWaterBalance is the estimated water balance of a tree cluster from the weather of its region.
+// WateringStatus and Deficit are the estimate of today, BadAt is the first forecast day on which the tree
+// cluster is estimated to turn bad or nil if it stays above the threshold during the forecast.
+type TreeClusterWaterBalance struct {
+	TreeClusterID  int32
+	WateringStatus WateringStatus
+	Deficit        float64
+	BadAt          *time.Time
+	Days           []*WaterBalance
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending  WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusRetrying WebhookDeliveryStatus = "retrying"
+	WebhookDeliveryStatusSuccess  WebhookDeliveryStatus = "success"
+	WebhookDeliveryStatusFailed   WebhookDeliveryStatus = "failed"
+)
+
+// WebhookEventTypes are the event types that can be subscribed by a webhook
+var WebhookEventTypes = []EventType{
+	EventTypeCreateTree,
+	EventTypeUpdateTree,
+	EventTypeDeleteTree,
+	EventTypeUpdateTreeCluster,
+	EventTypeNewSensorData,
+	EventTypeUpdateWateringPlan,
+}
+
+type Webhook struct {
+	ID         int32
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Name       string
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	Enabled    bool
+}
+
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	return slices.Contains(w.EventTypes, eventType)
+}
+
+type WebhookCreate struct {
+	Name       string      `validate:"required"`
+	URL        string      `validate:"required,http_url"`
+	Secret     string      `validate:"omitempty,min=16"`
+	EventTypes []EventType `validate:"required,min=1"`
+	Enabled    bool
+}
+
+type WebhookUpdate struct {
+	Name       string      `validate:"required"`
+	URL        string      `validate:"required,http_url"`
+	EventTypes []EventType `validate:"required,min=1"`
+	Enabled    bool
+}
+
+type WebhookDelivery struct {
+	ID             int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	WebhookID      int32
+	EventID        string
+	EventType      EventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int32
+	LastStatusCode *int32
+	LastError      *string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+}
@@ -0,0 +1,112 @@
+package entities
+
+import "time"
+
+type AlertRuleType string // @Name AlertRuleType
+
+const (
+	AlertRuleTypeTreeClusterStatus    AlertRuleType = "tree_cluster_status"
+	AlertRuleTypeTreeClusterUnplanned AlertRuleType = "tree_cluster_unplanned"
+	AlertRuleTypeSensorBattery        AlertRuleType = "sensor_battery"
+)
+
+type AlertSeverity string // @Name AlertSeverity
+
+const (
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityCritical AlertSeverity = "critical"
+)
+
+type AlertStatus string // @Name AlertStatus
+
+const (
+	AlertStatusPending  AlertStatus = "pending"
+	AlertStatusFiring   AlertStatus = "firing"
+	AlertStatusResolved AlertStatus = "resolved"
+)
+
+type AlertSubjectType string // @Name AlertSubjectType
+
+const (
+	AlertSubjectTypeTreeCluster AlertSubjectType = "tree_cluster"
+	AlertSubjectTypeSensor      AlertSubjectType = "sensor"
+)
+
+// AlertRuleResponse is a rule that is evaluated periodically. The condition has to hold for for_seconds before an
+// alert fires, window_seconds is the planning horizon of tree_cluster_unplanned rules and threshold the battery
+// voltage of sensor_battery rules.
+type AlertRuleResponse struct {
+	ID               int32            `json:"id"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Type             AlertRuleType    `json:"type"`
+	Severity         AlertSeverity    `json:"severity"`
+	RegionIDs        []int32          `json:"region_ids"`
+	WateringStatuses []WateringStatus `json:"watering_statuses"`
+	Threshold        *float64         `json:"threshold,omitempty" validate:"optional"`
+	WindowSeconds    int64            `json:"window_seconds"`
+	ForSeconds       int64            `json:"for_seconds"`
+	Notify           bool             `json:"notify"`
+	Enabled          bool             `json:"enabled"`
+} // @Name AlertRule
+
+type AlertRuleListResponse struct {
+	Data []*AlertRuleResponse `json:"data"`
+} // @Name AlertRuleList
+
+// AlertRuleCreateRequest creates a rule. Tree cluster rules need watering statuses, tree_cluster_unplanned rules
+// a window and sensor_battery rules a threshold in volt. Without regions the rule applies to all regions.
+type AlertRuleCreateRequest struct {
+	Name             string           `json:"name"`
+	Description      string           `json:"description" validate:"optional"`
+	Type             AlertRuleType    `json:"type"`
+	Severity         AlertSeverity    `json:"severity" validate:"optional"`
+	RegionIDs        []int32          `json:"region_ids" validate:"optional"`
+	WateringStatuses []WateringStatus `json:"watering_statuses" validate:"optional"`
+	Threshold        *float64         `json:"threshold" validate:"optional"`
+	WindowSeconds    int64            `json:"window_seconds" validate:"optional"`
+	ForSeconds       int64            `json:"for_seconds" validate:"optional"`
+	Notify           bool             `json:"notify"`
+	Enabled          bool             `json:"enabled"`
+} // @Name AlertRuleCreate
+
+type AlertRuleUpdateRequest struct {
+	Name             string           `json:"name"`
+	Description      string           `json:"description" validate:"optional"`
+	Type             AlertRuleType    `json:"type"`
+	Severity         AlertSeverity    `json:"severity" validate:"optional"`
+	RegionIDs        []int32          `json:"region_ids" validate:"optional"`
+	WateringStatuses []WateringStatus `json:"watering_statuses" validate:"optional"`
+	Threshold        *float64         `json:"threshold" validate:"optional"`
+	WindowSeconds    int64            `json:"window_seconds" validate:"optional"`
+	ForSeconds       int64            `json:"for_seconds" validate:"optional"`
+	Notify           bool             `json:"notify"`
+	Enabled          bool             `json:"enabled"`
+} // @Name AlertRuleUpdate
+
+// AlertResponse is the state of a rule for one tree cluster or sensor. created_at is the first evaluation that
+// matched, the value is the measured battery voltage of sensor_battery rules.
+type AlertResponse struct {
+	ID             int32            `json:"id"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	RuleID         int32            `json:"rule_id"`
+	SubjectType    AlertSubjectType `json:"subject_type"`
+	SubjectID      string           `json:"subject_id"`
+	SubjectName    string           `json:"subject_name"`
+	Status         AlertStatus      `json:"status"`
+	Message        string           `json:"message"`
+	Value          *float64         `json:"value,omitempty" validate:"optional"`
+	FiredAt        *time.Time       `json:"fired_at,omitempty" validate:"optional"`
+	ResolvedAt     *time.Time       `json:"resolved_at,omitempty" validate:"optional"`
+	AcknowledgedAt *time.Time       `json:"acknowledged_at,omitempty" validate:"optional"`
+	AcknowledgedBy *string          `json:"acknowledged_by,omitempty" validate:"optional"`
+} // @Name Alert
+
+type AlertListResponse struct {
+	Data       []*AlertResponse `json:"data"`
+	Pagination *Pagination      `json:"pagination,omitempty" validate:"optional"`
+} // @Name AlertList
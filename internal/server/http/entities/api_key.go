@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+type APIKeyResponse struct {
+	ID         int32      `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" validate:"optional"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" validate:"optional"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" validate:"optional"`
+} // @Name APIKey
+
+// APIKeyCreateResponse contains the plain api key. The key is only returned once after creation.
+type APIKeyCreateResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+} // @Name APIKeyCreateResponse
+
+type APIKeyListResponse struct {
+	Data []*APIKeyResponse `json:"data"`
+} // @Name APIKeyList
+
+type APIKeyCreateRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" validate:"optional"`
+} // @Name APIKeyCreate
+
+type APIKeyUpdateRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" validate:"optional"`
+} // @Name APIKeyUpdate
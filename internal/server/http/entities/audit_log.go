@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string // @Name AuditAction
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+type AuditEntityType string // @Name AuditEntityType
+
+const (
+	AuditEntityTypeTree         AuditEntityType = "tree"
+	AuditEntityTypeTreeCluster  AuditEntityType = "tree_cluster"
+	AuditEntityTypeVehicle      AuditEntityType = "vehicle"
+	AuditEntityTypeWateringPlan AuditEntityType = "watering_plan"
+)
+
+type AuditChangeResponse struct {
+	Old json.RawMessage `json:"old" swaggertype:"object"`
+	New json.RawMessage `json:"new" swaggertype:"object"`
+} // @Name AuditChange
+
+type AuditLogResponse struct {
+	ID         int64                          `json:"id"`
+	CreatedAt  time.Time                      `json:"created_at"`
+	EntityType AuditEntityType                `json:"entity_type"`
+	EntityID   int32                          `json:"entity_id"`
+	Action     AuditAction                    `json:"action"`
+	UserID     string                         `json:"user_id"`
+	Changes    map[string]AuditChangeResponse `json:"changes"`
+} // @Name AuditLog
+
+type AuditLogListResponse struct {
+	Data       []*AuditLogResponse `json:"data"`
+	Pagination *Pagination         `json:"pagination,omitempty" validate:"optional"`
+} // @Name AuditLogList
@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 type EvaluationResponse struct {
 	TreeCount             int64                        `json:"tree_count"`
 	TreeClusterCount      int64                        `json:"treecluster_count"`
@@ -20,3 +22,52 @@ type RegionEvaluationResponse struct {
 	Name              string `json:"name"`
 	WateringPlanCount int64  `json:"watering_plan_count"`
 } // @Name RegionEvaluation
+
+type EvaluationInterval string // @Name EvaluationInterval
+
+const (
+	EvaluationIntervalDay   EvaluationInterval = "day"
+	EvaluationIntervalWeek  EvaluationInterval = "week"
+	EvaluationIntervalMonth EvaluationInterval = "month"
+)
+
+type EvaluationGroupBy string // @Name EvaluationGroupBy
+
+const (
+	EvaluationGroupByNone        EvaluationGroupBy = ""
+	EvaluationGroupByRegion      EvaluationGroupBy = "region"
+	EvaluationGroupByTreeCluster EvaluationGroupBy = "tree_cluster"
+	EvaluationGroupByVehicle     EvaluationGroupBy = "vehicle"
+	EvaluationGroupByUser        EvaluationGroupBy = "user"
+)
+
+type EvaluationSeriesResponse struct {
+	From     time.Time                        `json:"from"`
+	To       time.Time                        `json:"to"`
+	Interval EvaluationInterval               `json:"interval"`
+	GroupBy  EvaluationGroupBy                `json:"group_by"`
+	Groups   []*EvaluationGroupSeriesResponse `json:"groups"`
+} // @Name EvaluationSeries
+
+// EvaluationGroupSeriesResponse is the series of a region, tree cluster, vehicle or user identified by key.
+// The key is empty for the series without grouping and for the tree clusters without region.
+type EvaluationGroupSeriesResponse struct {
+	Key     string                      `json:"key"`
+	Name    string                      `json:"name"`
+	Total   *EvaluationPeriodResponse   `json:"total"`
+	Periods []*EvaluationPeriodResponse `json:"periods"`
+} // @Name EvaluationGroupSeries
+
+type EvaluationPeriodResponse struct {
+	Start              time.Time `json:"start"`
+	WateringPlanCount  int64     `json:"watering_plan_count"`
+	FinishedCount      int64     `json:"finished_count"`
+	NotCompletedCount  int64     `json:"not_completed_count"`
+	WaterConsumed      float64   `json:"water_consumed"`
+	Refills            float64   `json:"refills"`
+	Distance           float64   `json:"distance"`
+	Hours              float64   `json:"hours"`
+	TreesWatered       int64     `json:"trees_watered"`
+	LitresPerTree      *float64  `json:"litres_per_tree" validate:"optional"`
+	GoodTreePercentage *float64  `json:"good_tree_percentage" validate:"optional"`
+} // @Name EvaluationPeriod
@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+type JobRunStatus string // @Name JobRunStatus
+
+const (
+	JobRunStatusPending JobRunStatus = "pending"
+	JobRunStatusRunning JobRunStatus = "running"
+	JobRunStatusSuccess JobRunStatus = "success"
+	JobRunStatusFailed  JobRunStatus = "failed"
+)
+
+type JobRunTrigger string // @Name JobRunTrigger
+
+const (
+	JobRunTriggerSchedule JobRunTrigger = "schedule"
+	JobRunTriggerManual   JobRunTrigger = "manual"
+)
+
+type JobRunResponse struct {
+	ID          int64         `json:"id"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Job         string        `json:"job"`
+	Trigger     JobRunTrigger `json:"trigger"`
+	Status      JobRunStatus  `json:"status"`
+	Instance    string        `json:"instance"`
+	RequestedBy string        `json:"requested_by"`
+	StartedAt   *time.Time    `json:"started_at,omitempty" validate:"optional"`
+	FinishedAt  *time.Time    `json:"finished_at,omitempty" validate:"optional"`
+	DurationMs  *int64        `json:"duration_ms,omitempty" validate:"optional"`
+	Error       *string       `json:"error,omitempty" validate:"optional"`
+} // @Name JobRun
+
+type JobRunListResponse struct {
+	Data       []*JobRunResponse `json:"data"`
+	Pagination *Pagination       `json:"pagination,omitempty" validate:"optional"`
+} // @Name JobRunList
+
+type JobResponse struct {
+	Name      string          `json:"name"`
+	Schedule  string          `json:"schedule"`
+	Enabled   bool            `json:"enabled"`
+	NextRunAt *time.Time      `json:"next_run_at,omitempty" validate:"optional"`
+	LastRun   *JobRunResponse `json:"last_run,omitempty" validate:"optional"`
+} // @Name Job
+
+type JobListResponse struct {
+	Data []*JobResponse `json:"data"`
+} // @Name JobList
@@ -0,0 +1,64 @@
+package mapper
+
+import (
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapAlertRuleType MapAlertRuleTypeReq MapAlertSeverity MapAlertSeverityReq MapAlertStatus MapAlertSubjectType
+// goverter:extend MapWateringStatus MapWateringStatusReq MapDurationSeconds MapSecondsReq
+type AlertHTTPMapper interface {
+	// goverter:map Window WindowSeconds
+	// goverter:map For ForSeconds
+	FromRuleResponse(*domain.AlertRule) *entities.AlertRuleResponse
+	FromRuleResponseList([]*domain.AlertRule) []*entities.AlertRuleResponse
+	// goverter:map WindowSeconds Window
+	// goverter:map ForSeconds For
+	FromRuleCreateRequest(*entities.AlertRuleCreateRequest) *domain.AlertRuleCreate
+	// goverter:map WindowSeconds Window
+	// goverter:map ForSeconds For
+	FromRuleUpdateRequest(*entities.AlertRuleUpdateRequest) *domain.AlertRuleUpdate
+
+	FromResponse(*domain.Alert) *entities.AlertResponse
+	FromResponseList([]*domain.Alert) []*entities.AlertResponse
+}
+
+func MapAlertRuleType(ruleType domain.AlertRuleType) entities.AlertRuleType {
+	return entities.AlertRuleType(ruleType)
+}
+
+func MapAlertRuleTypeReq(ruleType entities.AlertRuleType) domain.AlertRuleType {
+	return domain.AlertRuleType(ruleType)
+}
+
+func MapAlertSeverity(severity domain.AlertSeverity) entities.AlertSeverity {
+	return entities.AlertSeverity(severity)
+}
+
+func MapAlertSeverityReq(severity entities.AlertSeverity) domain.AlertSeverity {
+	return domain.AlertSeverity(severity)
+}
+
+func MapAlertStatus(status domain.AlertStatus) entities.AlertStatus {
+	return entities.AlertStatus(status)
+}
+
+func MapAlertSubjectType(subjectType domain.AlertSubjectType) entities.AlertSubjectType {
+	return entities.AlertSubjectType(subjectType)
+}
+
+func MapWateringStatusReq(status entities.WateringStatus) domain.WateringStatus {
+	return domain.WateringStatus(status)
+}
+
+func MapDurationSeconds(duration time.Duration) int64 {
+	return int64(duration.Seconds())
+}
+
+func MapSecondsReq(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapAPIKeyScope MapAPIKeyScopeReq
+type APIKeyHTTPMapper interface {
+	FromResponse(*domain.APIKey) *entities.APIKeyResponse
+	FromResponseList([]*domain.APIKey) []*entities.APIKeyResponse
+	// goverter:ignore CreatedBy
+	FromCreateRequest(*entities.APIKeyCreateRequest) *domain.APIKeyCreate
+	FromUpdateRequest(*entities.APIKeyUpdateRequest) *domain.APIKeyUpdate
+}
+
+func MapAPIKeyScope(scope domain.PluginScope) string {
+	return string(scope)
+}
+
+func MapAPIKeyScopeReq(scope string) domain.PluginScope {
+	return domain.PluginScope(scope)
+}
@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapAuditEntityType MapAuditAction MapAuditChange
+type AuditLogHTTPMapper interface {
+	FromResponse(*domain.AuditLog) *entities.AuditLogResponse
+	FromResponseList([]*domain.AuditLog) []*entities.AuditLogResponse
+}
+
+func MapAuditEntityType(entityType domain.AuditEntityType) entities.AuditEntityType {
+	return entities.AuditEntityType(entityType)
+}
+
+func MapAuditAction(action domain.AuditAction) entities.AuditAction {
+	return entities.AuditAction(action)
+}
+
+func MapAuditChange(change domain.AuditChange) entities.AuditChangeResponse {
+	return entities.AuditChangeResponse{
+		Old: change.Old,
+		New: change.New,
+	}
+}
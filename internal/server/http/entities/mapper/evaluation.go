@@ -6,6 +6,17 @@ import (
 )
 
 // goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapEvaluationInterval MapEvaluationGroupBy
 type EvaluationHTTPMapper interface {
 	FromResponse(src *domain.Evaluation) *entities.EvaluationResponse
+	FromSeriesResponse(src *domain.EvaluationSeries) *entities.EvaluationSeriesResponse
+}
+
+func MapEvaluationInterval(interval domain.EvaluationInterval) entities.EvaluationInterval {
+	return entities.EvaluationInterval(interval)
+}
+
+func MapEvaluationGroupBy(groupBy domain.EvaluationGroupBy) entities.EvaluationGroupBy {
+	return entities.EvaluationGroupBy(groupBy)
 }
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapJobRunTrigger MapJobRunStatus MapJobRunDuration
+type JobHTTPMapper interface {
+	FromResponse(*domain.Job) *entities.JobResponse
+	FromResponseList([]*domain.Job) []*entities.JobResponse
+	// goverter:map Duration DurationMs
+	FromRunResponse(*domain.JobRun) *entities.JobRunResponse
+	FromRunResponseList([]*domain.JobRun) []*entities.JobRunResponse
+}
+
+func MapJobRunTrigger(trigger domain.JobRunTrigger) entities.JobRunTrigger {
+	return entities.JobRunTrigger(trigger)
+}
+
+func MapJobRunStatus(status domain.JobRunStatus) entities.JobRunStatus {
+	return entities.JobRunStatus(status)
+}
+
+func MapJobRunDuration(duration *time.Duration) *int64 {
+	if duration == nil {
+		return nil
+	}
+	ms := duration.Milliseconds()
+	return &ms
+}
@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapNotificationType MapNotificationTypeReq MapNotificationChannel MapNotificationChannelReq
+type NotificationHTTPMapper interface {
+	FromResponse(*domain.Notification) *entities.NotificationResponse
+	FromResponseList([]*domain.Notification) []*entities.NotificationResponse
+	FromSettingsResponse(*domain.NotificationSettings) *entities.NotificationSettingsResponse
+	FromSettingsUpdateRequest(*entities.NotificationSettingsUpdateRequest) *domain.NotificationSettingsUpdate
+	// goverter:map Keys.P256dh P256dh
+	// goverter:map Keys.Auth Auth
+	FromPushSubscriptionCreateRequest(*entities.PushSubscriptionCreateRequest) *domain.PushSubscriptionCreate
+	FromPushSubscriptionResponse(*domain.PushSubscription) *entities.PushSubscriptionResponse
+}
+
+func MapNotificationType(notificationType domain.NotificationType) entities.NotificationType {
+	return entities.NotificationType(notificationType)
+}
+
+func MapNotificationTypeReq(notificationType entities.NotificationType) domain.NotificationType {
+	return domain.NotificationType(notificationType)
+}
+
+func MapNotificationChannel(channel domain.NotificationChannel) entities.NotificationChannel {
+	return entities.NotificationChannel(channel)
+}
+
+func MapNotificationChannelReq(channel entities.NotificationChannel) domain.NotificationChannel {
+	return domain.NotificationChannel(channel)
+}
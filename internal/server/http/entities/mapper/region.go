@@ -8,5 +8,6 @@ import (
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
 type RegionHTTPMapper interface {
+	// goverter:ignore Geometry
 	FromResponse(src *domain.Region) *entities.RegionResponse
 }
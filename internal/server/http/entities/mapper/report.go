@@ -0,0 +1,21 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapReportFormat MapReportKind
+type ReportHTTPMapper interface {
+	FromResponse(src *domain.Report) *entities.ReportResponse
+}
+
+func MapReportFormat(format domain.ReportFormat) entities.ReportFormat {
+	return entities.ReportFormat(format)
+}
+
+func MapReportKind(kind domain.ReportKind) entities.ReportKind {
+	return entities.ReportKind(kind)
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapTreeImportFormat MapTreeImportStatus MapTreeImportMapping MapTreeImportField
+type TreeImportHTTPMapper interface {
+	FromResponse(*domain.TreeImportJob) *entities.TreeImportResponse
+	FromResponseList([]*domain.TreeImportJob) []*entities.TreeImportResponse
+}
+
+func MapTreeImportFormat(format domain.TreeImportFormat) entities.TreeImportFormat {
+	return entities.TreeImportFormat(format)
+}
+
+func MapTreeImportStatus(status domain.TreeImportStatus) entities.TreeImportStatus {
+	return entities.TreeImportStatus(status)
+}
+
+func MapTreeImportField(field domain.TreeImportField) string {
+	return string(field)
+}
+
+func MapTreeImportMapping(mapping domain.TreeImportMapping) map[string]string {
+	result := make(map[string]string, len(mapping))
+	for field, column := range mapping {
+		result[string(field)] = column
+	}
+	return result
+}
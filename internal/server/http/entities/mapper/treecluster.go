@@ -12,13 +12,31 @@ import (
 // goverter:extend MapWateringStatus MapSoilCondition MapSoilConditionReq MapTreesToIDs MapSensorStatus
 // goverter:ignoreMissing
 type TreeClusterHTTPMapper interface {
+	// goverter:map Polygon Polygon | github.com/green-ecolution/green-ecolution-backend/internal/utils:WKTToGeoJSON
+	// goverter:map . TreeDensity | MapTreeDensity
 	FromResponse(*domain.TreeCluster) *entities.TreeClusterResponse
 	FromResponseList([]*domain.TreeCluster) []*entities.TreeClusterInListResponse
+
+	// the polygon is converted from GeoJSON in the handler
+	// goverter:ignore Polygon
 	FromCreateRequest(*entities.TreeClusterCreateRequest) *domain.TreeClusterCreate
+	// goverter:ignore Polygon
 	FromUpdateRequest(*entities.TreeClusterUpdateRequest) *domain.TreeClusterUpdate
+	FromSuggestionResponse(*domain.TreeClusterSuggestion) *entities.TreeClusterSuggestionResponse
+	FromSuggestionAcceptRequest(*entities.TreeClusterSuggestionAcceptRequest) *domain.TreeClusterSuggestionAccept
 
 	// goverter:map Trees TreeIDs
+	// goverter:map Polygon Polygon | github.com/green-ecolution/green-ecolution-backend/internal/utils:WKTToGeoJSON
+	// goverter:map . TreeDensity | MapTreeDensity
 	FromInListResponse(*domain.TreeCluster) *entities.TreeClusterInListResponse
+
+	// the geometry of the region is only part of the region endpoints
+	// goverter:ignore Geometry
+	FromRegionResponse(*domain.Region) *entities.RegionResponse
+}
+
+func MapTreeDensity(tc *domain.TreeCluster) float64 {
+	return tc.TreeDensity()
 }
 
 func MapWateringStatus(status domain.WateringStatus) entities.WateringStatus {
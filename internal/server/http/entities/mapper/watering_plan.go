@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
@@ -13,7 +15,7 @@ import (
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:DurationToPtrFloat64
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:MapKeyValueInterface
 // goverter:extend MapWateringPlanStatus MapVehicleStatus MapVehicleType MapDrivingLicense MapWateringPlanStatusReq
-// goverter:extend MapWateringStatus MapSensorStatus MapSoilCondition MapTreesToIDs MapUUIDs MapUUIDReq
+// goverter:extend MapWateringStatus MapSensorStatus MapSoilCondition MapTreesToIDs MapUUIDs MapUUIDReq MapDuration
 type WateringPlanHTTPMapper interface {
 	FromResponse(*domain.WateringPlan) *entities.WateringPlanResponse
 	FromResponseList([]*domain.WateringPlan) []*entities.WateringPlanResponse
@@ -22,7 +24,28 @@ type WateringPlanHTTPMapper interface {
 
 	FromInListResponse(*domain.WateringPlan) *entities.WateringPlanInListResponse
 	// goverter:map Trees TreeIDs
+	// goverter:map Polygon Polygon | github.com/green-ecolution/green-ecolution-backend/internal/utils:WKTToGeoJSON
+	// goverter:map . TreeDensity | MapTreeDensity
 	FromTreeClusterInListResponse(*domain.TreeCluster) *entities.TreeClusterInListResponse
+	// goverter:ignore Geometry
+	FromRegionResponse(*domain.Region) *entities.RegionResponse
+
+	// goverter:map Plan.Date Date
+	// goverter:map Plan.Description Description
+	// goverter:map Plan.TreeClusterIDs TreeClusterIDs
+	// goverter:map Plan.TransporterID TransporterID
+	// goverter:map Plan.TrailerID TrailerID
+	// goverter:map Plan.UserIDs UserIDs
+	// goverter:map Route.Distance Distance
+	// goverter:map Route.Time Duration
+	// goverter:map Route.Refills RefillCount
+	FromProposalResponse(*domain.WateringPlanProposal) *entities.WateringPlanProposalResponse
+	FromProposalResponseList([]*domain.WateringPlanProposal) []*entities.WateringPlanProposalResponse
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map TreeCluster.ID ID
+	// goverter:map TreeCluster.Name Name
+	// goverter:map TreeCluster.WateringStatus WateringStatus
+	FromUrgencyResponse(*domain.TreeClusterUrgency) *entities.TreeClusterUrgencyResponse
 }
 
 func MapWateringPlanStatus(status domain.WateringPlanStatus) entities.WateringPlanStatus {
@@ -60,3 +83,7 @@ func MapUUIDReq(userIDs []string) []*uuid.UUID {
 
 	return mappedUserIDs
 }
+
+func MapDuration(duration time.Duration) float64 {
+	return float64(duration)
+}
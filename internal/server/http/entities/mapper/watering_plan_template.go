@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapUUIDStringReq MapUUID
+type WateringPlanTemplateHTTPMapper interface {
+	FromResponse(*domain.WateringPlanTemplate) *entities.WateringPlanTemplateResponse
+	FromResponseList([]*domain.WateringPlanTemplate) []*entities.WateringPlanTemplateResponse
+	FromCreateRequest(*entities.WateringPlanTemplateCreateRequest) *domain.WateringPlanTemplateCreate
+	FromUpdateRequest(*entities.WateringPlanTemplateUpdateRequest) *domain.WateringPlanTemplateUpdate
+	FromOccurrenceResponse(*domain.WateringPlanTemplateOccurrence) *entities.WateringPlanTemplateOccurrenceResponse
+	FromOccurrenceResponseList([]*domain.WateringPlanTemplateOccurrence) []*entities.WateringPlanTemplateOccurrenceResponse
+}
+
+// MapUUIDStringReq parses a user id of a request, invalid ids are mapped to the nil uuid and rejected by the validation
+func MapUUIDStringReq(userID string) uuid.UUID {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
+
+func MapUUID(userID uuid.UUID) uuid.UUID {
+	return userID
+}
@@ -0,0 +1,17 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapWateringStatus
+type WeatherHTTPMapper interface {
+	FromDayResponse(*domain.WeatherDay) *entities.WeatherDayResponse
+	FromDayResponseList([]*domain.WeatherDay) []*entities.WeatherDayResponse
+	FromWaterBalanceResponse(*domain.WaterBalance) *entities.WaterBalanceResponse
+	FromTreeClusterWaterBalanceResponse(*domain.TreeClusterWaterBalance) *entities.TreeClusterWaterBalanceResponse
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapWebhookEventType MapWebhookEventTypeReq MapWebhookDeliveryStatus
+type WebhookHTTPMapper interface {
+	FromResponse(*domain.Webhook) *entities.WebhookResponse
+	FromResponseList([]*domain.Webhook) []*entities.WebhookResponse
+	FromCreateRequest(*entities.WebhookCreateRequest) *domain.WebhookCreate
+	FromUpdateRequest(*entities.WebhookUpdateRequest) *domain.WebhookUpdate
+	FromDeliveryResponse(*domain.WebhookDelivery) *entities.WebhookDeliveryResponse
+	FromDeliveryResponseList([]*domain.WebhookDelivery) []*entities.WebhookDeliveryResponse
+}
+
+func MapWebhookEventType(eventType domain.EventType) string {
+	return string(eventType)
+}
+
+func MapWebhookEventTypeReq(eventType string) domain.EventType {
+	return domain.EventType(eventType)
+}
+
+func MapWebhookDeliveryStatus(status domain.WebhookDeliveryStatus) entities.WebhookDeliveryStatus {
+	return entities.WebhookDeliveryStatus(status)
+}
@@ -0,0 +1,79 @@
+package entities
+
+import "time"
+
+type NotificationType string // @Name NotificationType
+
+const (
+	NotificationTypeTreeClusterBad       NotificationType = "tree_cluster_bad"
+	NotificationTypeSensorOffline        NotificationType = "sensor_offline"
+	NotificationTypeWateringPlanAssigned NotificationType = "watering_plan_assigned"
+	NotificationTypeAlert                NotificationType = "alert"
+)
+
+type NotificationChannel string // @Name NotificationChannel
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelPush  NotificationChannel = "push"
+)
+
+// NotificationResponse is a notification in the inbox, the link is a path of the frontend
+type NotificationResponse struct {
+	ID        int32            `json:"id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Type      NotificationType `json:"type"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	Link      string           `json:"link"`
+	ReadAt    *time.Time       `json:"read_at,omitempty" validate:"optional"`
+} // @Name Notification
+
+type NotificationListResponse struct {
+	Data       []*NotificationResponse `json:"data"`
+	Pagination *Pagination             `json:"pagination,omitempty" validate:"optional"`
+} // @Name NotificationList
+
+type NotificationUnreadCountResponse struct {
+	Count int64 `json:"count"`
+} // @Name NotificationUnreadCount
+
+// NotificationSettingsResponse contains the enabled channels per notification type. Quiet hours and digest
+// time are given as HH:MM, email and push messages are postponed until the quiet hours end or the digest is sent.
+type NotificationSettingsResponse struct {
+	Channels        map[NotificationType][]NotificationChannel `json:"channels"`
+	QuietHoursStart string                                     `json:"quiet_hours_start"`
+	QuietHoursEnd   string                                     `json:"quiet_hours_end"`
+	Digest          bool                                       `json:"digest"`
+	DigestTime      string                                     `json:"digest_time"`
+} // @Name NotificationSettings
+
+type NotificationSettingsUpdateRequest struct {
+	Channels        map[NotificationType][]NotificationChannel `json:"channels"`
+	QuietHoursStart string                                     `json:"quiet_hours_start,omitempty" validate:"optional"`
+	QuietHoursEnd   string                                     `json:"quiet_hours_end,omitempty" validate:"optional"`
+	Digest          bool                                       `json:"digest"`
+	DigestTime      string                                     `json:"digest_time,omitempty" validate:"optional"`
+} // @Name NotificationSettingsUpdate
+
+type PushPublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+} // @Name PushPublicKey
+
+// PushSubscriptionCreateRequest has the shape of the json of a PushSubscription of the browser
+type PushSubscriptionCreateRequest struct {
+	Endpoint string               `json:"endpoint"`
+	Keys     PushSubscriptionKeys `json:"keys"`
+} // @Name PushSubscriptionCreate
+
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+} // @Name PushSubscriptionKeys
+
+type PushSubscriptionResponse struct {
+	ID        int32     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Endpoint  string    `json:"endpoint"`
+} // @Name PushSubscription
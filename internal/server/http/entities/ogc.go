@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type OGCLink struct {
+	Href  string `json:"href"`
+	Rel   string `json:"rel"`
+	Type  string `json:"type,omitempty" validate:"optional"`
+	Title string `json:"title,omitempty" validate:"optional"`
+} // @Name OGCLink
+
+type OGCLandingPageResponse struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Links       []*OGCLink `json:"links"`
+} // @Name OGCLandingPage
+
+type OGCConformanceResponse struct {
+	ConformsTo []string `json:"conformsTo"`
+} // @Name OGCConformance
+
+type OGCSpatialExtentResponse struct {
+	// BBox contains one bounding box as [minLon, minLat, maxLon, maxLat]
+	BBox [][]float64 `json:"bbox"`
+	CRS  string      `json:"crs"`
+} // @Name OGCSpatialExtent
+
+type OGCTemporalExtentResponse struct {
+	// Interval contains one interval as [start, end], null is unbounded
+	Interval [][]*time.Time `json:"interval"`
+	TRS      string         `json:"trs"`
+} // @Name OGCTemporalExtent
+
+type OGCExtentResponse struct {
+	Spatial  *OGCSpatialExtentResponse  `json:"spatial,omitempty" validate:"optional"`
+	Temporal *OGCTemporalExtentResponse `json:"temporal,omitempty" validate:"optional"`
+} // @Name OGCExtent
+
+type OGCCollectionResponse struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Extent      *OGCExtentResponse `json:"extent,omitempty" validate:"optional"`
+	ItemType    string             `json:"itemType"`
+	CRS         []string           `json:"crs"`
+	Links       []*OGCLink         `json:"links"`
+} // @Name OGCCollection
+
+type OGCCollectionListResponse struct {
+	Collections []*OGCCollectionResponse `json:"collections"`
+	Links       []*OGCLink               `json:"links"`
+} // @Name OGCCollectionList
+
+type OGCQueryableProperty struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+} // @Name OGCQueryableProperty
+
+// OGCQueryablesResponse is a json schema of the properties a collection can be filtered by
+type OGCQueryablesResponse struct {
+	Schema     string                           `json:"$schema"`
+	ID         string                           `json:"$id"`
+	Type       string                           `json:"type"`
+	Title      string                           `json:"title"`
+	Properties map[string]*OGCQueryableProperty `json:"properties"`
+} // @Name OGCQueryables
+
+type OGCFeatureResponse struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Geometry   json.RawMessage `json:"geometry" swaggertype:"object"`
+	Properties map[string]any  `json:"properties"`
+	Links      []*OGCLink      `json:"links,omitempty" validate:"optional"`
+} // @Name OGCFeature
+
+type OGCFeatureCollectionResponse struct {
+	Type           string                `json:"type"`
+	Features       []*OGCFeatureResponse `json:"features"`
+	Links          []*OGCLink            `json:"links"`
+	NumberMatched  int64                 `json:"numberMatched"`
+	NumberReturned int                   `json:"numberReturned"`
+	TimeStamp      time.Time             `json:"timeStamp"`
+} // @Name OGCFeatureCollection
@@ -1,25 +1,71 @@
 package entities
 
+import "time"
+
 type PluginResponse struct {
-	Slug        string `json:"slug"`
-	Name        string `json:"name"`
-	Version     string `json:"version"`
-	Description string `json:"description"`
-	HostPath    string `json:"host_path"`
+	Slug          string             `json:"slug"`
+	Name          string             `json:"name"`
+	Version       string             `json:"version"`
+	Description   string             `json:"description"`
+	HostPath      string             `json:"host_path"`
+	Scopes        []string           `json:"scopes"`
+	Manifest      PluginManifest     `json:"manifest"`
+	Proxy         PluginProxy        `json:"proxy"`
+	APIVersion    string             `json:"api_version"`
+	Requirements  PluginRequirements `json:"requirements"`
+	LastHeartbeat time.Time          `json:"last_heartbeat"`
 } // @name Plugin
 
+// PluginManifest describes how the plugin ui is integrated into the frontend navigation
+type PluginManifest struct {
+	Entry     string   `json:"entry"`
+	MenuLabel string   `json:"menu_label"`
+	Icon      string   `json:"icon"`
+	Roles     []string `json:"roles"`
+} // @name PluginManifest
+
+// PluginProxy configures the reverse proxy to the plugin. Zero values use the defaults of the plugin host.
+type PluginProxy struct {
+	TimeoutMs   int64 `json:"timeout_ms"`
+	MaxBodySize int64 `json:"max_body_size"`
+} // @name PluginProxy
+
+// PluginRequirements declares the host api version range and the host features a plugin needs.
+// Empty versions leave the range open on that side.
+type PluginRequirements struct {
+	MinAPIVersion string   `json:"min_api_version"`
+	MaxAPIVersion string   `json:"max_api_version"`
+	Features      []string `json:"features"`
+} // @name PluginRequirements
+
+type PluginHostCapabilitiesResponse struct {
+	APIVersions []string `json:"api_versions"`
+	Features    []string `json:"features"`
+} // @name PluginHostCapabilities
+
+type PluginIncompatibleResponse struct {
+	Error           string   `json:"error"`
+	APIVersions     []string `json:"api_versions"`
+	Features        []string `json:"features"`
+	MissingFeatures []string `json:"missing_features"`
+} // @name PluginIncompatible
+
 type PluginAuth struct {
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 } // @name PluginAuth
 
 type PluginRegisterRequest struct {
-	Slug        string     `json:"slug"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Version     string     `json:"version"`
-	Path        string     `json:"path"`
-	Auth        PluginAuth `json:"auth"`
+	Slug         string              `json:"slug"`
+	Name         string              `json:"name"`
+	Description  string              `json:"description"`
+	Version      string              `json:"version"`
+	Path         string              `json:"path"`
+	Scopes       []string            `json:"scopes"`
+	Manifest     *PluginManifest     `json:"manifest,omitempty" validate:"optional"`
+	Proxy        *PluginProxy        `json:"proxy,omitempty" validate:"optional"`
+	Requirements *PluginRequirements `json:"requirements,omitempty" validate:"optional"`
+	Auth         PluginAuth          `json:"auth"`
 } // @name PluginRegisterRequest
 
 type PluginRegisterResponse struct {
@@ -30,3 +76,33 @@ type PluginRegisterResponse struct {
 type PluginListResponse struct {
 	Plugins []PluginResponse `json:"plugins"`
 } // @name PluginListResponse
+
+type PluginGrantResponse struct {
+	Slug      string    `json:"slug"`
+	ClientID  string    `json:"client_id"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @name PluginGrant
+
+type PluginGrantRequest struct {
+	Slug     string   `json:"slug"`
+	ClientID string   `json:"client_id"`
+	Scopes   []string `json:"scopes"`
+} // @name PluginGrantRequest
+
+type PluginGrantListResponse struct {
+	Grants []PluginGrantResponse `json:"grants"`
+} // @name PluginGrantListResponse
+
+type PluginEventResponse struct {
+	ID         int32     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	PluginSlug string    `json:"plugin_slug"`
+	Type       string    `json:"type"`
+	Version    string    `json:"version"`
+} // @name PluginEvent
+
+type PluginEventListResponse struct {
+	Events []PluginEventResponse `json:"events"`
+} // @name PluginEventListResponse
@@ -1,11 +1,24 @@
 package entities
 
+import "encoding/json"
+
 type RegionResponse struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID       int32           `json:"id"`
+	Name     string          `json:"name"`
+	Geometry json.RawMessage `json:"geometry,omitempty" swaggertype:"object" validate:"optional"`
 } // @Name Region
 
 type RegionListResponse struct {
 	Data       []*RegionResponse `json:"data"`
 	Pagination *Pagination       `json:"pagination,omitempty" validate:"optional"`
 } // @Name RegionList
+
+type RegionCreateRequest struct {
+	Name     string          `json:"name"`
+	Geometry json.RawMessage `json:"geometry" swaggertype:"object"`
+} // @Name RegionCreate
+
+type RegionUpdateRequest struct {
+	Name     string          `json:"name"`
+	Geometry json.RawMessage `json:"geometry" swaggertype:"object"`
+} // @Name RegionUpdate
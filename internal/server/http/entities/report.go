@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+type ReportFormat string // @Name ReportFormat
+
+const (
+	ReportFormatPDF ReportFormat = "pdf"
+	ReportFormatCSV ReportFormat = "csv"
+)
+
+type ReportKind string // @Name ReportKind
+
+const (
+	ReportKindWateringPlan ReportKind = "watering_plan"
+	ReportKindSeason       ReportKind = "season"
+)
+
+// ReportResponse is a generated report, the url is a signed download link that works without authentication until expires_at
+type ReportResponse struct {
+	Name      string       `json:"name"`
+	Kind      ReportKind   `json:"kind"`
+	Format    ReportFormat `json:"format"`
+	CreatedAt time.Time    `json:"created_at"`
+	URL       string       `json:"url"`
+	ExpiresAt time.Time    `json:"expires_at"`
+} // @Name Report
@@ -8,6 +8,7 @@ type TreeResponse struct {
 	ID             int32                  `json:"id"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+	Version        int32                  `json:"version"`
 	TreeClusterID  *int32                 `json:"tree_cluster_id" validate:"optional"`
 	Sensor         *SensorResponse        `json:"sensor" validate:"optional"`
 	LastWatered    *time.Time             `json:"last_watered,omitempty" validate:"optional"`
@@ -0,0 +1,52 @@
+package entities
+
+import "time"
+
+type TreeImportFormat string // @Name TreeImportFormat
+
+const (
+	TreeImportFormatCSV        TreeImportFormat = "csv"
+	TreeImportFormatGeoJSON    TreeImportFormat = "geojson"
+	TreeImportFormatGeoPackage TreeImportFormat = "gpkg"
+)
+
+type TreeImportStatus string // @Name TreeImportStatus
+
+const (
+	TreeImportStatusPending    TreeImportStatus = "pending"
+	TreeImportStatusValidating TreeImportStatus = "validating"
+	TreeImportStatusValidated  TreeImportStatus = "validated"
+	TreeImportStatusInvalid    TreeImportStatus = "invalid"
+	TreeImportStatusQueued     TreeImportStatus = "queued"
+	TreeImportStatusCommitting TreeImportStatus = "committing"
+	TreeImportStatusCommitted  TreeImportStatus = "committed"
+	TreeImportStatusFailed     TreeImportStatus = "failed"
+)
+
+type TreeImportRowErrorResponse struct {
+	Row     int32  `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+} // @Name TreeImportRowError
+
+type TreeImportResponse struct {
+	ID          int32                         `json:"id"`
+	CreatedAt   time.Time                     `json:"created_at"`
+	UpdatedAt   time.Time                     `json:"updated_at"`
+	FileName    string                        `json:"file_name"`
+	Format      TreeImportFormat              `json:"format"`
+	Status      TreeImportStatus              `json:"status"`
+	Mapping     map[string]string             `json:"mapping"`
+	Provider    string                        `json:"provider"`
+	TotalRows   int32                         `json:"total_rows"`
+	ValidRows   int32                         `json:"valid_rows"`
+	CreatedRows int32                         `json:"created_rows"`
+	UpdatedRows int32                         `json:"updated_rows"`
+	RowErrors   []*TreeImportRowErrorResponse `json:"row_errors"`
+	Error       *string                       `json:"error,omitempty" validate:"optional"`
+	CreatedBy   string                        `json:"created_by"`
+} // @Name TreeImport
+
+type TreeImportListResponse struct {
+	Data []*TreeImportResponse `json:"data"`
+} // @Name TreeImportList
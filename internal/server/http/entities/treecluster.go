@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -18,6 +19,7 @@ type TreeClusterResponse struct {
 	ID             int32                  `json:"id"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+	Version        int32                  `json:"version"`
 	WateringStatus WateringStatus         `json:"watering_status"`
 	LastWatered    *time.Time             `json:"last_watered,omitempty" validate:"optional"`
 	MoistureLevel  float64                `json:"moisture_level"`
@@ -32,12 +34,18 @@ type TreeClusterResponse struct {
 	Name           string                 `json:"name"`
 	Provider       string                 `json:"provider,omitempty"`
 	AdditionalInfo map[string]interface{} `json:"additional_information,omitempty" validate:"optional"`
+	Polygon        json.RawMessage        `json:"polygon,omitempty" swaggertype:"object" validate:"optional"`
+	PolygonManual  bool                   `json:"polygon_manual"`
+	Area           *float64               `json:"area,omitempty" validate:"optional"`
+	Perimeter      *float64               `json:"perimeter,omitempty" validate:"optional"`
+	TreeDensity    float64                `json:"tree_density"`
 } // @Name TreeCluster
 
 type TreeClusterInListResponse struct {
 	ID             int32                  `json:"id"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+	Version        int32                  `json:"version"`
 	WateringStatus WateringStatus         `json:"watering_status"`
 	LastWatered    *time.Time             `json:"last_watered,omitempty" validate:"optional"`
 	MoistureLevel  float64                `json:"moisture_level"`
@@ -52,6 +60,11 @@ type TreeClusterInListResponse struct {
 	Name           string                 `json:"name"`
 	Provider       string                 `json:"provider,omitempty"`
 	AdditionalInfo map[string]interface{} `json:"additional_information,omitempty" validate:"optional"`
+	Polygon        json.RawMessage        `json:"polygon,omitempty" swaggertype:"object" validate:"optional"`
+	PolygonManual  bool                   `json:"polygon_manual"`
+	Area           *float64               `json:"area,omitempty" validate:"optional"`
+	Perimeter      *float64               `json:"perimeter,omitempty" validate:"optional"`
+	TreeDensity    float64                `json:"tree_density"`
 } // @Name TreeClusterInList
 
 type TreeClusterListResponse struct {
@@ -67,6 +80,7 @@ type TreeClusterCreateRequest struct {
 	Name           string                 `json:"name"`
 	Provider       string                 `json:"provider" validate:"optional"`
 	AdditionalInfo map[string]interface{} `json:"additional_information" validate:"optional"`
+	Polygon        json.RawMessage        `json:"polygon" swaggertype:"object" validate:"optional"`
 } // @Name TreeClusterCreate
 
 type TreeClusterUpdateRequest struct {
@@ -77,8 +91,29 @@ type TreeClusterUpdateRequest struct {
 	Name           string                 `json:"name"`
 	Provider       string                 `json:"provider" validate:"optional"`
 	AdditionalInfo map[string]interface{} `json:"additional_information" validate:"optional"`
+	Polygon        json.RawMessage        `json:"polygon" swaggertype:"object" validate:"optional"`
 } // @Name TreeClusterUpdate
 
 type TreeClusterAddTreesRequest struct {
 	TreeIDs []*int32 `json:"tree_ids"`
 } // @Name TreeClusterAddTrees
+
+type TreeClusterSuggestionResponse struct {
+	TreeIDs       []int32           `json:"tree_ids"`
+	Latitude      float64           `json:"latitude"`
+	Longitude     float64           `json:"longitude"`
+	Region        *RegionResponse   `json:"region,omitempty" validate:"optional"`
+	SoilCondition TreeSoilCondition `json:"soil_condition"`
+	TreeClusterID *int32            `json:"tree_cluster_id,omitempty" validate:"optional"`
+} // @Name TreeClusterSuggestion
+
+type TreeClusterSuggestionListResponse struct {
+	Data []*TreeClusterSuggestionResponse `json:"data"`
+} // @Name TreeClusterSuggestionList
+
+type TreeClusterSuggestionAcceptRequest struct {
+	TreeClusterID *int32            `json:"tree_cluster_id" validate:"optional"`
+	Name          string            `json:"name" validate:"optional"`
+	TreeIDs       []int32           `json:"tree_ids"`
+	SoilCondition TreeSoilCondition `json:"soil_condition" validate:"optional"`
+} // @Name TreeClusterSuggestionAccept
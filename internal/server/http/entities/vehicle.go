@@ -23,6 +23,7 @@ type VehicleResponse struct {
 	ID             int32                  `json:"id"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+	Version        int32                  `json:"version"`
 	ArchivedAt     *time.Time             `json:"archived_at,omitempty"`
 	NumberPlate    string                 `json:"number_plate"`
 	Description    string                 `json:"description"`
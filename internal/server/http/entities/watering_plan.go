@@ -21,6 +21,7 @@ type WateringPlanResponse struct {
 	ID                 int32                        `json:"id"`
 	CreatedAt          time.Time                    `json:"created_at"`
 	UpdatedAt          time.Time                    `json:"updated_at"`
+	Version            int32                        `json:"version"`
 	Date               time.Time                    `json:"date"`
 	Description        string                       `json:"description"`
 	Status             WateringPlanStatus           `json:"status"`
@@ -43,6 +44,7 @@ type WateringPlanInListResponse struct {
 	ID                 int32                        `json:"id"`
 	CreatedAt          time.Time                    `json:"created_at"`
 	UpdatedAt          time.Time                    `json:"updated_at"`
+	Version            int32                        `json:"version"`
 	Date               time.Time                    `json:"date"`
 	Description        string                       `json:"description"`
 	Status             WateringPlanStatus           `json:"status"`
@@ -92,3 +94,31 @@ type EvaluationValue struct {
 	TreeClusterID  int32    `json:"tree_cluster_id"`
 	ConsumedWater  *float64 `json:"consumed_water"`
 } // @Name EvaluationValue
+
+type TreeClusterUrgencyResponse struct {
+	ID             int32          `json:"id"`
+	Name           string         `json:"name"`
+	WateringStatus WateringStatus `json:"watering_status"`
+	Score          float64        `json:"score"`
+	BadAt          *time.Time     `json:"bad_at" validate:"optional"`
+} // @Name TreeClusterUrgency
+
+// WateringPlanProposalResponse has the fields of the create request, so that a proposal can be accepted as it is
+type WateringPlanProposalResponse struct {
+	Date           time.Time                     `json:"date"`
+	Description    string                        `json:"description"`
+	TreeClusterIDs []*int32                      `json:"tree_cluster_ids"`
+	TransporterID  *int32                        `json:"transporter_id"`
+	TrailerID      *int32                        `json:"trailer_id" validate:"optional"`
+	UserIDs        []*uuid.UUID                  `json:"user_ids"`
+	Score          float64                       `json:"score"`
+	TreeClusters   []*TreeClusterUrgencyResponse `json:"treeclusters"`
+	Transporter    *VehicleResponse              `json:"transporter"`
+	Distance       *float64                      `json:"distance" validate:"optional"`
+	Duration       *float64                      `json:"duration" validate:"optional"`
+	RefillCount    *int32                        `json:"refill_count" validate:"optional"`
+} // @Name WateringPlanProposal
+
+type WateringPlanProposalListResponse struct {
+	Data []*WateringPlanProposalResponse `json:"data"`
+} // @Name WateringPlanProposalList
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WateringPlanTemplateResponse struct {
+	ID             int32       `json:"id"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	RRule          string      `json:"rrule"`
+	StartDate      time.Time   `json:"start_date"`
+	SeasonStart    string      `json:"season_start"`
+	SeasonEnd      string      `json:"season_end"`
+	TreeClusterIDs []int32     `json:"tree_cluster_ids"`
+	TransporterID  *int32      `json:"transporter_id"`
+	TrailerID      *int32      `json:"trailer_id" validate:"optional"`
+	UserIDs        []uuid.UUID `json:"user_ids"`
+	Enabled        bool        `json:"enabled"`
+} // @Name WateringPlanTemplate
+
+type WateringPlanTemplateListResponse struct {
+	Data []*WateringPlanTemplateResponse `json:"data"`
+} // @Name WateringPlanTemplateList
+
+// WateringPlanTemplateCreateRequest creates a watering plan for every date of the recurrence rule within the season.
+// The rrule is the rule part of an RFC 5545 recurrence rule, e.g. FREQ=WEEKLY;BYDAY=TU, the season is given as MM-DD.
+type WateringPlanTemplateCreateRequest struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description" validate:"optional"`
+	RRule          string    `json:"rrule"`
+	StartDate      time.Time `json:"start_date"`
+	SeasonStart    string    `json:"season_start" validate:"optional"`
+	SeasonEnd      string    `json:"season_end" validate:"optional"`
+	TreeClusterIDs []int32   `json:"tree_cluster_ids"`
+	TransporterID  *int32    `json:"transporter_id"`
+	TrailerID      *int32    `json:"trailer_id" validate:"optional"`
+	UserIDs        []string  `json:"user_ids"`
+	Enabled        bool      `json:"enabled"`
+} // @Name WateringPlanTemplateCreate
+
+type WateringPlanTemplateUpdateRequest struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description" validate:"optional"`
+	RRule          string    `json:"rrule"`
+	StartDate      time.Time `json:"start_date"`
+	SeasonStart    string    `json:"season_start" validate:"optional"`
+	SeasonEnd      string    `json:"season_end" validate:"optional"`
+	TreeClusterIDs []int32   `json:"tree_cluster_ids"`
+	TransporterID  *int32    `json:"transporter_id"`
+	TrailerID      *int32    `json:"trailer_id" validate:"optional"`
+	UserIDs        []string  `json:"user_ids"`
+	Enabled        bool      `json:"enabled"`
+} // @Name WateringPlanTemplateUpdate
+
+// WateringPlanTemplateOccurrenceResponse is a date of a template that was created or skipped because of a holiday.
+// The watering plan id is missing for holidays and deleted watering plans.
+type WateringPlanTemplateOccurrenceResponse struct {
+	TemplateID     int32     `json:"template_id"`
+	Date           time.Time `json:"date"`
+	CreatedAt      time.Time `json:"created_at"`
+	WateringPlanID *int32    `json:"watering_plan_id,omitempty" validate:"optional"`
+	Holiday        bool      `json:"holiday"`
+} // @Name WateringPlanTemplateOccurrence
+
+type WateringPlanTemplateOccurrenceListResponse struct {
+	Data []*WateringPlanTemplateOccurrenceResponse `json:"data"`
+} // @Name WateringPlanTemplateOccurrenceList
@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+type WeatherDayResponse struct {
+	Date            time.Time `json:"date"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	Precipitation   float64   `json:"precipitation"`
+	TemperatureMin  float64   `json:"temperature_min"`
+	TemperatureMax  float64   `json:"temperature_max"`
+	TemperatureMean float64   `json:"temperature_mean"`
+	ET0             float64   `json:"et0"`
+	Forecast        bool      `json:"forecast"`
+} // @Name WeatherDay
+
+type WeatherDayListResponse struct {
+	Data []*WeatherDayResponse `json:"data"`
+} // @Name WeatherDayList
+
+type WaterBalanceResponse struct {
+	Date           time.Time      `json:"date"`
+	Deficit        float64        `json:"deficit"`
+	WateringStatus WateringStatus `json:"watering_status"`
+	Forecast       bool           `json:"forecast"`
+} // @Name WaterBalance
+
+type TreeClusterWaterBalanceResponse struct {
+	TreeClusterID  int32                   `json:"tree_cluster_id"`
+	WateringStatus WateringStatus          `json:"watering_status"`
+	Deficit        float64                 `json:"deficit"`
+	BadAt          *time.Time              `json:"bad_at,omitempty" validate:"optional"`
+	Days           []*WaterBalanceResponse `json:"days"`
+} // @Name TreeClusterWaterBalance
@@ -0,0 +1,66 @@
+package entities
+
+import "time"
+
+type WebhookDeliveryStatus string // @Name WebhookDeliveryStatus
+
+const (
+	WebhookDeliveryStatusPending  WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusRetrying WebhookDeliveryStatus = "retrying"
+	WebhookDeliveryStatusSuccess  WebhookDeliveryStatus = "success"
+	WebhookDeliveryStatusFailed   WebhookDeliveryStatus = "failed"
+)
+
+type WebhookResponse struct {
+	ID         int32     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Enabled    bool      `json:"enabled"`
+} // @Name Webhook
+
+// WebhookCreateResponse contains the secret of the webhook. The secret is only returned once after creation.
+type WebhookCreateResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+} // @Name WebhookCreateResponse
+
+type WebhookListResponse struct {
+	Data []*WebhookResponse `json:"data"`
+} // @Name WebhookList
+
+type WebhookCreateRequest struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty" validate:"optional"`
+	EventTypes []string `json:"event_types"`
+	Enabled    bool     `json:"enabled"`
+} // @Name WebhookCreate
+
+type WebhookUpdateRequest struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Enabled    bool     `json:"enabled"`
+} // @Name WebhookUpdate
+
+type WebhookDeliveryResponse struct {
+	ID             int32                 `json:"id"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	WebhookID      int32                 `json:"webhook_id"`
+	EventID        string                `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	LastStatusCode *int32                `json:"last_status_code,omitempty" validate:"optional"`
+	LastError      *string               `json:"last_error,omitempty" validate:"optional"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" validate:"optional"`
+} // @Name WebhookDelivery
+
+type WebhookDeliveryListResponse struct {
+	Data []*WebhookDeliveryResponse `json:"data"`
+} // @Name WebhookDeliveryList
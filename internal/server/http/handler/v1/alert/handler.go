@@ -0,0 +1,280 @@
+package alert
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+var (
+	alertMapper = generated.AlertHTTPMapperImpl{}
+)
+
+// @Summary		Get all alerts
+// @Description	Get the pending, firing and resolved alerts of all rules, newest first
+// @Id				get-all-alerts
+// @Tags			Alert
+// @Produce		json
+// @Success		200	{object}	entities.AlertListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert [get]
+// @Param			page			query	int			false	"Page"
+// @Param			limit			query	int			false	"Limit"
+// @Param			statuses		query	[]string	false	"Alert statuses (pending, firing, resolved)"
+// @Param			rule_id			query	int			false	"Alert Rule ID"
+// @Param			unacknowledged	query	bool		false	"Only alerts that are not acknowledged"
+// @Security		Keycloak
+func GetAllAlerts(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var query domain.AlertQuery
+		if err := c.QueryParser(&query); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+		}
+
+		domainData, totalCount, err := svc.GetAll(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.AlertListResponse{
+			Data:       alertMapper.FromResponseList(domainData),
+			Pagination: pagination.Create(ctx, totalCount),
+		})
+	}
+}
+
+// @Summary		Get alert by ID
+// @Description	Get alert by ID
+// @Id				get-alert-by-id
+// @Tags			Alert
+// @Produce		json
+// @Success		200	{object}	entities.AlertResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert/{id} [get]
+// @Param			id	path	int	true	"Alert ID"
+// @Security		Keycloak
+func GetAlertByID(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(alertMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Acknowledge alert
+// @Description	Mark an alert as seen by the current user or api key. An acknowledged alert keeps its status, the first acknowledgement is kept.
+// @Id				acknowledge-alert
+// @Tags			Alert
+// @Produce		json
+// @Success		200	{object}	entities.AlertResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert/{id}/acknowledge [post]
+// @Param			id	path	int	true	"Alert ID"
+// @Security		Keycloak
+func AcknowledgeAlert(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.Acknowledge(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(alertMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Get all alert rules
+// @Description	Get all alert rules
+// @Id				get-all-alert-rules
+// @Tags			Alert
+// @Produce		json
+// @Success		200	{object}	entities.AlertRuleListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert/rule [get]
+// @Security		Keycloak
+func GetAllAlertRules(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetAllRules(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.AlertRuleListResponse{
+			Data: alertMapper.FromRuleResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get alert rule by ID
+// @Description	Get alert rule by ID
+// @Id				get-alert-rule-by-id
+// @Tags			Alert
+// @Produce		json
+// @Success		200	{object}	entities.AlertRuleResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert/rule/{id} [get]
+// @Param			id	path	int	true	"Alert Rule ID"
+// @Security		Keycloak
+func GetAlertRuleByID(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetRuleByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(alertMapper.FromRuleResponse(domainData))
+	}
+}
+
+// @Summary		Create alert rule
+// @Description	Create an alert rule. The rules are evaluated by a scheduled job, an alert fires once the condition held for for_seconds.
+// @Id				create-alert-rule
+// @Tags			Alert
+// @Produce		json
+// @Success		201	{object}	entities.AlertRuleResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert/rule [post]
+// @Param			body	body	entities.AlertRuleCreateRequest	true	"Alert Rule Create Request"
+// @Security		Keycloak
+func CreateAlertRule(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.AlertRuleCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := alertMapper.FromRuleCreateRequest(&req)
+		domainData, err := svc.CreateRule(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(alertMapper.FromRuleResponse(domainData))
+	}
+}
+
+// @Summary		Update alert rule
+// @Description	Update an alert rule. The open alerts of the rule are re-evaluated by the next run of the job.
+// @Id				update-alert-rule
+// @Tags			Alert
+// @Produce		json
+// @Success		200	{object}	entities.AlertRuleResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert/rule/{id} [put]
+// @Param			id		path	int								true	"Alert Rule ID"
+// @Param			body	body	entities.AlertRuleUpdateRequest	true	"Alert Rule Update Request"
+// @Security		Keycloak
+func UpdateAlertRule(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.AlertRuleUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := alertMapper.FromRuleUpdateRequest(&req)
+		domainData, err := svc.UpdateRule(ctx, int32(id), domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(alertMapper.FromRuleResponse(domainData))
+	}
+}
+
+// @Summary		Delete alert rule
+// @Description	Delete an alert rule together with its alerts
+// @Id				delete-alert-rule
+// @Tags			Alert
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/alert/rule/{id} [delete]
+// @Param			id	path	int	true	"Alert Rule ID"
+// @Security		Keycloak
+func DeleteAlertRule(svc service.AlertService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.DeleteRule(ctx, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,477 @@
+package alert_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/alert"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllAlerts(t *testing.T) {
+	t.Run("should return alerts matching the filters", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert", alert.GetAllAlerts(mockAlertService))
+
+		expectedQuery := entities.AlertQuery{
+			Statuses:       []entities.AlertStatus{entities.AlertStatusPending, entities.AlertStatusFiring},
+			RuleID:         utils.P(int32(1)),
+			Unacknowledged: true,
+		}
+		mockAlertService.EXPECT().GetAll(mock.Anything, expectedQuery).Return(TestAlerts[:1], int64(1), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			"/v1/alert?statuses=pending&statuses=firing&rule_id=1&unacknowledged=true", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.AlertListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, TestAlert.ID, response.Data[0].ID)
+		assert.Equal(t, serverEntities.AlertStatusFiring, response.Data[0].Status)
+		assert.Equal(t, serverEntities.AlertSubjectTypeTreeCluster, response.Data[0].SubjectType)
+		assert.Equal(t, TestAlert.Message, response.Data[0].Message)
+		assert.Nil(t, response.Data[0].AcknowledgedAt)
+	})
+
+	t.Run("should return 400 for invalid filter", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert", alert.GetAllAlerts(mockAlertService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert?rule_id=abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockAlertService.AssertNotCalled(t, "GetAll")
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert", alert.GetAllAlerts(mockAlertService))
+
+		mockAlertService.EXPECT().GetAll(mock.Anything, entities.AlertQuery{}).Return(nil, int64(0), service.NewError(service.InternalError, "db down"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetAlertByID(t *testing.T) {
+	t.Run("should return alert", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert/:id", alert.GetAlertByID(mockAlertService))
+
+		mockAlertService.EXPECT().GetByID(mock.Anything, int32(2)).Return(TestAlerts[1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert/2", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.AlertResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), response.RuleID)
+		assert.Equal(t, serverEntities.AlertSubjectTypeSensor, response.SubjectType)
+		assert.Equal(t, TestAlerts[1].Value, response.Value)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert/:id", alert.GetAlertByID(mockAlertService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when alert not found", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert/:id", alert.GetAlertByID(mockAlertService))
+
+		mockAlertService.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, storage.ErrEntityNotFound("not found").Error()))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestAcknowledgeAlert(t *testing.T) {
+	t.Run("should acknowledge alert", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Post("/v1/alert/:id/acknowledge", alert.AcknowledgeAlert(mockAlertService))
+
+		acknowledged := *TestAlert
+		acknowledged.AcknowledgedAt = &now
+		acknowledged.AcknowledgedBy = utils.P("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+		mockAlertService.EXPECT().Acknowledge(mock.Anything, int32(1)).Return(&acknowledged, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/alert/1/acknowledge", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.AlertResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, acknowledged.AcknowledgedBy, response.AcknowledgedBy)
+		assert.NotNil(t, response.AcknowledgedAt)
+		assert.Equal(t, serverEntities.AlertStatusFiring, response.Status)
+	})
+
+	t.Run("should return 404 when alert not found", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Post("/v1/alert/:id/acknowledge", alert.AcknowledgeAlert(mockAlertService))
+
+		mockAlertService.EXPECT().Acknowledge(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, storage.ErrEntityNotFound("not found").Error()))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/alert/99/acknowledge", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetAllAlertRules(t *testing.T) {
+	t.Run("should return all rules", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert/rule", alert.GetAllAlertRules(mockAlertService))
+
+		mockAlertService.EXPECT().GetAllRules(mock.Anything).Return(TestAlertRules, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert/rule", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.AlertRuleListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, TestAlertRule.Name, response.Data[0].Name)
+		assert.Equal(t, serverEntities.AlertRuleTypeTreeClusterStatus, response.Data[0].Type)
+		assert.Equal(t, serverEntities.AlertSeverityCritical, response.Data[0].Severity)
+		assert.Equal(t, []serverEntities.WateringStatus{serverEntities.WateringStatusBad}, response.Data[0].WateringStatuses)
+		assert.Equal(t, int64(48*60*60), response.Data[0].ForSeconds)
+		assert.Nil(t, response.Data[0].Threshold)
+		assert.Equal(t, TestAlertRules[1].Threshold, response.Data[1].Threshold)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert/rule", alert.GetAllAlertRules(mockAlertService))
+
+		mockAlertService.EXPECT().GetAllRules(mock.Anything).Return(nil, service.NewError(service.InternalError, "db down"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert/rule", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetAlertRuleByID(t *testing.T) {
+	t.Run("should return rule", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert/rule/:id", alert.GetAlertRuleByID(mockAlertService))
+
+		mockAlertService.EXPECT().GetRuleByID(mock.Anything, int32(1)).Return(TestAlertRule, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert/rule/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.AlertRuleResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestAlertRule.ID, response.ID)
+		assert.Equal(t, TestAlertRule.RegionIDs, response.RegionIDs)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Get("/v1/alert/rule/:id", alert.GetAlertRuleByID(mockAlertService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/alert/rule/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCreateAlertRule(t *testing.T) {
+	t.Run("should create rule", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Post("/v1/alert/rule", alert.CreateAlertRule(mockAlertService))
+
+		reqBody := serverEntities.AlertRuleCreateRequest{
+			Name:             "Bad tree clusters without watering plan",
+			Type:             serverEntities.AlertRuleTypeTreeClusterUnplanned,
+			Severity:         serverEntities.AlertSeverityCritical,
+			RegionIDs:        []int32{1, 2},
+			WateringStatuses: []serverEntities.WateringStatus{serverEntities.WateringStatusBad},
+			WindowSeconds:    48 * 60 * 60,
+			ForSeconds:       60 * 60,
+			Notify:           true,
+			Enabled:          true,
+		}
+		mockAlertService.EXPECT().CreateRule(mock.Anything, mock.MatchedBy(func(c *entities.AlertRuleCreate) bool {
+			return c.Name == reqBody.Name &&
+				c.Type == entities.AlertRuleTypeTreeClusterUnplanned &&
+				c.Severity == entities.AlertSeverityCritical &&
+				assert.ObjectsAreEqual(reqBody.RegionIDs, c.RegionIDs) &&
+				assert.ObjectsAreEqual([]entities.WateringStatus{entities.WateringStatusBad}, c.WateringStatuses) &&
+				c.Window == 48*time.Hour &&
+				c.For == time.Hour &&
+				c.Notify && c.Enabled
+		})).Return(TestAlertRule, nil)
+
+		// when
+		body, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/alert/rule", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.AlertRuleResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestAlertRule.ID, response.ID)
+	})
+
+	t.Run("should map the battery threshold", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Post("/v1/alert/rule", alert.CreateAlertRule(mockAlertService))
+
+		mockAlertService.EXPECT().CreateRule(mock.Anything, mock.MatchedBy(func(c *entities.AlertRuleCreate) bool {
+			return c.Type == entities.AlertRuleTypeSensorBattery && c.Threshold != nil && *c.Threshold == 3.2
+		})).Return(TestAlertRules[1], nil)
+
+		// when
+		body := `{"name": "Low sensor battery", "type": "sensor_battery", "threshold": 3.2, "enabled": true}`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/alert/rule", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Post("/v1/alert/rule", alert.CreateAlertRule(mockAlertService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/alert/rule", bytes.NewBufferString(`{"name": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when rule is incomplete", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Post("/v1/alert/rule", alert.CreateAlertRule(mockAlertService))
+
+		mockAlertService.EXPECT().CreateRule(mock.Anything, mock.Anything).Return(nil, service.ErrAlertRuleInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/alert/rule", bytes.NewBufferString(`{"name": "Low battery", "type": "sensor_battery"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestUpdateAlertRule(t *testing.T) {
+	t.Run("should update rule", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Put("/v1/alert/rule/:id", alert.UpdateAlertRule(mockAlertService))
+
+		mockAlertService.EXPECT().UpdateRule(mock.Anything, int32(1), mock.MatchedBy(func(u *entities.AlertRuleUpdate) bool {
+			return u.For == 24*time.Hour && !u.Enabled
+		})).Return(TestAlertRule, nil)
+
+		// when
+		body := `{"name": "Bad tree clusters", "type": "tree_cluster_status", "watering_statuses": ["bad"], "for_seconds": 86400, "enabled": false}`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/alert/rule/1", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Put("/v1/alert/rule/:id", alert.UpdateAlertRule(mockAlertService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/alert/rule/abc", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDeleteAlertRule(t *testing.T) {
+	t.Run("should delete rule", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Delete("/v1/alert/rule/:id", alert.DeleteAlertRule(mockAlertService))
+
+		mockAlertService.EXPECT().DeleteRule(mock.Anything, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/alert/rule/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when rule not found", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockAlertService := serviceMock.NewMockAlertService(t)
+		app.Delete("/v1/alert/rule/:id", alert.DeleteAlertRule(mockAlertService))
+
+		mockAlertService.EXPECT().DeleteRule(mock.Anything, int32(99)).Return(service.NewError(service.NotFound, storage.ErrEntityNotFound("not found").Error()))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/alert/rule/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,18 @@
+package alert
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.AlertService) {
+	r.Get("/rule", GetAllAlertRules(svc))
+	r.Get("/rule/:id", GetAlertRuleByID(svc))
+	r.Post("/rule", CreateAlertRule(svc))
+	r.Put("/rule/:id", UpdateAlertRule(svc))
+	r.Delete("/rule/:id", DeleteAlertRule(svc))
+
+	r.Get("/", GetAllAlerts(svc))
+	r.Get("/:id", GetAlertByID(svc))
+	r.Post("/:id/acknowledge", AcknowledgeAlert(svc))
+}
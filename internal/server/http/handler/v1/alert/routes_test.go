@@ -0,0 +1,128 @@
+package alert_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/alert"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/alert", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockAlertService := serviceMock.NewMockAlertService(t)
+			app := fiber.New()
+			alert.RegisterRoutes(app, mockAlertService)
+
+			mockAlertService.EXPECT().GetAll(mock.Anything, entities.AlertQuery{}).Return(TestAlerts, int64(2), nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/alert/:id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockAlertService := serviceMock.NewMockAlertService(t)
+			app := fiber.New()
+			alert.RegisterRoutes(app, mockAlertService)
+
+			mockAlertService.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestAlert, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/alert/:id/acknowledge", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockAlertService := serviceMock.NewMockAlertService(t)
+			app := fiber.New()
+			alert.RegisterRoutes(app, mockAlertService)
+
+			mockAlertService.EXPECT().Acknowledge(mock.Anything, int32(1)).Return(TestAlert, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/1/acknowledge", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/alert/rule", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockAlertService := serviceMock.NewMockAlertService(t)
+			app := fiber.New()
+			alert.RegisterRoutes(app, mockAlertService)
+
+			mockAlertService.EXPECT().GetAllRules(mock.Anything).Return(TestAlertRules, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/rule", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/alert/rule/:id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockAlertService := serviceMock.NewMockAlertService(t)
+			app := fiber.New()
+			alert.RegisterRoutes(app, mockAlertService)
+
+			mockAlertService.EXPECT().GetRuleByID(mock.Anything, int32(1)).Return(TestAlertRule, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/rule/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call DELETE handler", func(t *testing.T) {
+			mockAlertService := serviceMock.NewMockAlertService(t)
+			app := fiber.New()
+			alert.RegisterRoutes(app, mockAlertService)
+
+			mockAlertService.EXPECT().DeleteRule(mock.Anything, int32(1)).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/rule/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	})
+}
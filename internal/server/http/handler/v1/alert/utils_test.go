@@ -0,0 +1,72 @@
+package alert_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	now = time.Now()
+
+	TestAlertRule = &entities.AlertRule{
+		ID:               1,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		Name:             "Bad tree clusters in the city center",
+		Description:      "Tree clusters that are bad for more than two days",
+		Type:             entities.AlertRuleTypeTreeClusterStatus,
+		Severity:         entities.AlertSeverityCritical,
+		RegionIDs:        []int32{1},
+		WateringStatuses: []entities.WateringStatus{entities.WateringStatusBad},
+		For:              48 * time.Hour,
+		Notify:           true,
+		Enabled:          true,
+	}
+
+	TestAlertRules = []*entities.AlertRule{
+		TestAlertRule,
+		{
+			ID:        2,
+			CreatedAt: now,
+			UpdatedAt: now,
+			Name:      "Low sensor battery",
+			Type:      entities.AlertRuleTypeSensorBattery,
+			Severity:  entities.AlertSeverityWarning,
+			RegionIDs: []int32{},
+			Threshold: utils.P(3.2),
+			Enabled:   true,
+		},
+	}
+
+	TestAlert = &entities.Alert{
+		ID:          1,
+		CreatedAt:   now.Add(-72 * time.Hour),
+		UpdatedAt:   now,
+		RuleID:      1,
+		SubjectType: entities.AlertSubjectTypeTreeCluster,
+		SubjectID:   "1",
+		SubjectName: "Solitüde Strand",
+		Status:      entities.AlertStatusFiring,
+		Message:     "The watering status of the tree cluster Solitüde Strand in Mürwik is bad.",
+		FiredAt:     utils.P(now.Add(-24 * time.Hour)),
+	}
+
+	TestAlerts = []*entities.Alert{
+		TestAlert,
+		{
+			ID:          2,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			RuleID:      2,
+			SubjectType: entities.AlertSubjectTypeSensor,
+			SubjectID:   "sensor-1",
+			SubjectName: "sensor-1",
+			Status:      entities.AlertStatusFiring,
+			Message:     "The battery of the sensor sensor-1 is at 3.10 V, below 3.20 V.",
+			Value:       utils.P(3.1),
+			FiredAt:     &now,
+		},
+	}
+)
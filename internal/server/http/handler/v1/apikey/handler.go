@@ -0,0 +1,229 @@
+package apikey
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+var (
+	apiKeyMapper = generated.APIKeyHTTPMapperImpl{}
+)
+
+// @Summary		Get all api keys
+// @Description	Get all api keys. The keys themselves are never returned, only their public prefix. Requires the admin role.
+// @Id				get-all-api-keys
+// @Tags			API Key
+// @Produce		json
+// @Success		200	{object}	entities.APIKeyListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/api-key [get]
+// @Security		Keycloak
+func GetAllAPIKeys(svc service.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.APIKeyListResponse{
+			Data: apiKeyMapper.FromResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get api key by ID
+// @Description	Get api key by ID. Requires the admin role.
+// @Id				get-api-key-by-id
+// @Tags			API Key
+// @Produce		json
+// @Success		200	{object}	entities.APIKeyResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/api-key/{id} [get]
+// @Param			id	path	int	true	"API Key ID"
+// @Security		Keycloak
+func GetAPIKeyByID(svc service.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(apiKeyMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Create api key
+// @Description	Create a scoped api key for a machine integration. The key is only returned in this response. Requires the admin role.
+// @Id				create-api-key
+// @Tags			API Key
+// @Produce		json
+// @Success		201	{object}	entities.APIKeyCreateResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/api-key [post]
+// @Param			body	body	entities.APIKeyCreateRequest	true	"API Key Create Request"
+// @Security		Keycloak
+func CreateAPIKey(svc service.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.APIKeyCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := apiKeyMapper.FromCreateRequest(&req)
+		domainReq.CreatedBy = creator(c)
+		domainData, plainKey, err := svc.Create(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entities.APIKeyCreateResponse{
+			APIKeyResponse: *apiKeyMapper.FromResponse(domainData),
+			Key:            plainKey,
+		})
+	}
+}
+
+// @Summary		Update api key
+// @Description	Update name, scopes and expiry of an api key. Requires the admin role.
+// @Id				update-api-key
+// @Tags			API Key
+// @Produce		json
+// @Success		200	{object}	entities.APIKeyResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/api-key/{id} [put]
+// @Param			id		path	int								true	"API Key ID"
+// @Param			body	body	entities.APIKeyUpdateRequest	true	"API Key Update Request"
+// @Security		Keycloak
+func UpdateAPIKey(svc service.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.APIKeyUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := apiKeyMapper.FromUpdateRequest(&req)
+		domainData, err := svc.Update(ctx, int32(id), domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(apiKeyMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Revoke api key
+// @Description	Revoke an api key. A revoked key is rejected immediately but kept for auditing. Requires the admin role.
+// @Id				revoke-api-key
+// @Tags			API Key
+// @Produce		json
+// @Success		200	{object}	entities.APIKeyResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/api-key/{id}/revoke [post]
+// @Param			id	path	int	true	"API Key ID"
+// @Security		Keycloak
+func RevokeAPIKey(svc service.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.Revoke(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(apiKeyMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete api key
+// @Description	Delete api key. Requires the admin role.
+// @Id				delete-api-key
+// @Tags			API Key
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/api-key/{id} [delete]
+// @Param			id	path	int	true	"API Key ID"
+// @Security		Keycloak
+func DeleteAPIKey(svc service.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.Delete(ctx, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// creator returns the user id of the jwt or the prefix of the api key that authenticated the request
+func creator(c *fiber.Ctx) string {
+	if apiKey, ok := c.UserContext().Value(enums.ContextKeyAPIKey).(*domain.APIKey); ok {
+		return "api-key:" + apiKey.Prefix
+	}
+
+	if claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims); ok {
+		if sub, err := claims.GetSubject(); err == nil {
+			return sub
+		}
+	}
+
+	return ""
+}
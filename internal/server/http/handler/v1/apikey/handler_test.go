@@ -0,0 +1,257 @@
+package apikey_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/apikey"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllAPIKeys(t *testing.T) {
+	t.Run("should return all api keys without hashes", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Get("/v1/api-key", apikey.GetAllAPIKeys(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().GetAll(mock.Anything).Return(TestAPIKeys, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/api-key", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response map[string][]map[string]any
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response["data"], 2)
+		assert.Equal(t, TestAPIKey.Prefix, response["data"][0]["prefix"])
+		assert.NotContains(t, response["data"][0], "key_hash")
+		assert.NotContains(t, response["data"][0], "key")
+		assert.Contains(t, response["data"][1], "revoked_at")
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Get("/v1/api-key", apikey.GetAllAPIKeys(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().GetAll(mock.Anything).Return(nil, service.NewError(service.InternalError, "service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/api-key", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetAPIKeyByID(t *testing.T) {
+	t.Run("should return api key", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Get("/v1/api-key/:id", apikey.GetAPIKeyByID(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestAPIKey, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/api-key/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.APIKeyResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestAPIKey.Name, response.Name)
+		assert.Equal(t, []string{"tree:read", "sensor:read"}, response.Scopes)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Get("/v1/api-key/:id", apikey.GetAPIKeyByID(mockAPIKeyService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/api-key/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when api key not found", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Get("/v1/api-key/:id", apikey.GetAPIKeyByID(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/api-key/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	t.Run("should create api key and return the plain key once", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Post("/v1/api-key", func(c *fiber.Ctx) error {
+			claims := golangJwt.MapClaims{"sub": "6a1078e8-80fd-458f-b74e-e388fe2dd6ab"}
+			c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+			return c.Next()
+		}, apikey.CreateAPIKey(mockAPIKeyService))
+
+		plainKey := "ge_0a1b2c3d4e5f_00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+		mockAPIKeyService.EXPECT().Create(mock.Anything, &entities.APIKeyCreate{
+			Name:      "Irrigation controller",
+			Scopes:    []entities.PluginScope{"tree:read", "sensor:read"},
+			CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		}).Return(TestAPIKey, plainKey, nil)
+
+		body, _ := json.Marshal(serverEntities.APIKeyCreateRequest{
+			Name:   "Irrigation controller",
+			Scopes: []string{"tree:read", "sensor:read"},
+		})
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/api-key", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.APIKeyCreateResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, plainKey, response.Key)
+		assert.Equal(t, TestAPIKey.Prefix, response.Prefix)
+	})
+
+	t.Run("should return 400 when scope is invalid", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Post("/v1/api-key", apikey.CreateAPIKey(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, "", service.ErrAPIKeyScopeInvalid)
+
+		body, _ := json.Marshal(serverEntities.APIKeyCreateRequest{
+			Name:   "Irrigation controller",
+			Scopes: []string{"tree:delete"},
+		})
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/api-key", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestUpdateAPIKey(t *testing.T) {
+	t.Run("should update api key", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Put("/v1/api-key/:id", apikey.UpdateAPIKey(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().Update(mock.Anything, int32(1), &entities.APIKeyUpdate{
+			Name:   "Irrigation controller",
+			Scopes: []entities.PluginScope{"tree:read"},
+		}).Return(TestAPIKey, nil)
+
+		body, _ := json.Marshal(serverEntities.APIKeyUpdateRequest{
+			Name:   "Irrigation controller",
+			Scopes: []string{"tree:read"},
+		})
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/api-key/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Run("should revoke api key", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Post("/v1/api-key/:id/revoke", apikey.RevokeAPIKey(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().Revoke(mock.Anything, int32(2)).Return(TestAPIKeys[1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/api-key/2/revoke", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.APIKeyResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.NotNil(t, response.RevokedAt)
+	})
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	t.Run("should delete api key", func(t *testing.T) {
+		app := fiber.New()
+		mockAPIKeyService := serviceMock.NewMockAPIKeyService(t)
+		app.Delete("/v1/api-key/:id", apikey.DeleteAPIKey(mockAPIKeyService))
+
+		mockAPIKeyService.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/api-key/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
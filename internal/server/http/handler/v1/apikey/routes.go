@@ -0,0 +1,15 @@
+package apikey
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.APIKeyService) {
+	r.Get("/", GetAllAPIKeys(svc))
+	r.Get("/:id", GetAPIKeyByID(svc))
+	r.Post("/", CreateAPIKey(svc))
+	r.Put("/:id", UpdateAPIKey(svc))
+	r.Post("/:id/revoke", RevokeAPIKey(svc))
+	r.Delete("/:id", DeleteAPIKey(svc))
+}
@@ -0,0 +1,37 @@
+package apikey_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	now = time.Now()
+
+	TestAPIKey = &entities.APIKey{
+		ID:        1,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      "Irrigation controller",
+		Prefix:    "0a1b2c3d4e5f",
+		KeyHash:   "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		Scopes:    []entities.PluginScope{"tree:read", "sensor:read"},
+		CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+	}
+
+	TestAPIKeys = []*entities.APIKey{
+		TestAPIKey,
+		{
+			ID:        2,
+			CreatedAt: now,
+			UpdatedAt: now,
+			Name:      "Legacy importer",
+			Prefix:    "f5e4d3c2b1a0",
+			KeyHash:   "6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b",
+			Scopes:    []entities.PluginScope{"tree:write"},
+			CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+			RevokedAt: &now,
+		},
+	}
+)
@@ -0,0 +1,180 @@
+package auditlog
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+var (
+	auditLogMapper = generated.AuditLogHTTPMapperImpl{}
+)
+
+// @Summary		Get audit log
+// @Description	Get the changes to trees, tree clusters, vehicles and watering plans of all users, newest first. Requires the admin role.
+// @Id				get-audit-log
+// @Tags			Audit Log
+// @Produce		json
+// @Success		200	{object}	entities.AuditLogListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/audit-log [get]
+// @Param			page		query	int		false	"Page"
+// @Param			limit		query	int		false	"Limit"
+// @Param			entity_type	query	string	false	"Entity type (tree, tree_cluster, vehicle, watering_plan)"
+// @Param			entity_id	query	int		false	"Entity ID"
+// @Param			user_id		query	string	false	"User ID or api-key:<prefix>"
+// @Param			action		query	string	false	"Action (create, update, delete)"
+// @Param			from		query	string	false	"Changes at or after this RFC 3339 timestamp"
+// @Param			to			query	string	false	"Changes at or before this RFC 3339 timestamp"
+// @Security		Keycloak
+func GetAuditLog(svc service.AuditLogService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var query domain.AuditLogQuery
+		if err := c.QueryParser(&query); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+		}
+
+		var err error
+		if query.From, err = parseTime(c.Query("from")); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "from must be a RFC 3339 timestamp"))
+		}
+		if query.To, err = parseTime(c.Query("to")); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "to must be a RFC 3339 timestamp"))
+		}
+
+		domainData, totalCount, err := svc.GetAll(ctx, &query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.AuditLogListResponse{
+			Data:       auditLogMapper.FromResponseList(domainData),
+			Pagination: pagination.Create(ctx, totalCount),
+		})
+	}
+}
+
+// @Summary		Get tree history
+// @Description	Get the changes to a tree, newest first
+// @Id				get-tree-history
+// @Tags			Tree
+// @Produce		json
+// @Success		200	{object}	entities.AuditLogListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree/{tree_id}/history [get]
+// @Param			tree_id	path	int	true	"Tree ID"
+// @Param			page	query	int	false	"Page"
+// @Param			limit	query	int	false	"Limit"
+// @Security		Keycloak
+func GetTreeHistory(svc service.AuditLogService) fiber.Handler {
+	return getHistory(svc, domain.AuditEntityTypeTree)
+}
+
+// @Summary		Get tree cluster history
+// @Description	Get the changes to a tree cluster, newest first
+// @Id				get-tree-cluster-history
+// @Tags			Tree Cluster
+// @Produce		json
+// @Success		200	{object}	entities.AuditLogListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/cluster/{cluster_id}/history [get]
+// @Param			cluster_id	path	int	true	"Tree Cluster ID"
+// @Param			page		query	int	false	"Page"
+// @Param			limit		query	int	false	"Limit"
+// @Security		Keycloak
+func GetTreeClusterHistory(svc service.AuditLogService) fiber.Handler {
+	return getHistory(svc, domain.AuditEntityTypeTreeCluster)
+}
+
+// @Summary		Get vehicle history
+// @Description	Get the changes to a vehicle, newest first
+// @Id				get-vehicle-history
+// @Tags			Vehicle
+// @Produce		json
+// @Success		200	{object}	entities.AuditLogListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/{id}/history [get]
+// @Param			id		path	int	true	"Vehicle ID"
+// @Param			page	query	int	false	"Page"
+// @Param			limit	query	int	false	"Limit"
+// @Security		Keycloak
+func GetVehicleHistory(svc service.AuditLogService) fiber.Handler {
+	return getHistory(svc, domain.AuditEntityTypeVehicle)
+}
+
+// @Summary		Get watering plan history
+// @Description	Get the changes to a watering plan, newest first
+// @Id				get-watering-plan-history
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		200	{object}	entities.AuditLogListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan/{id}/history [get]
+// @Param			id		path	int	true	"Watering Plan ID"
+// @Param			page	query	int	false	"Page"
+// @Param			limit	query	int	false	"Limit"
+// @Security		Keycloak
+func GetWateringPlanHistory(svc service.AuditLogService) fiber.Handler {
+	return getHistory(svc, domain.AuditEntityTypeWateringPlan)
+}
+
+// getHistory returns the history of the entity with the id route parameter. The history routes are
+// registered as /:id/history in the route groups of the resources.
+func getHistory(svc service.AuditLogService, entityType domain.AuditEntityType) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, totalCount, err := svc.GetHistory(ctx, entityType, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.AuditLogListResponse{
+			Data:       auditLogMapper.FromResponseList(domainData),
+			Pagination: pagination.Create(ctx, totalCount),
+		})
+	}
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	t = t.UTC()
+	return &t, nil
+}
@@ -0,0 +1,178 @@
+package auditlog_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/auditlog"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAuditLog(t *testing.T) {
+	t.Run("should return audit log matching the filters", func(t *testing.T) {
+		app := fiber.New()
+		mockAuditLogService := serviceMock.NewMockAuditLogService(t)
+		app.Get("/v1/audit-log", auditlog.GetAuditLog(mockAuditLogService))
+
+		from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		expectedQuery := &entities.AuditLogQuery{
+			EntityType: entities.AuditEntityTypeTree,
+			EntityID:   utils.P(int32(1)),
+			UserID:     "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+			Action:     entities.AuditActionUpdate,
+			From:       &from,
+		}
+		mockAuditLogService.EXPECT().GetAll(mock.Anything, expectedQuery).Return(TestAuditLogs[:1], int64(1), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			"/v1/audit-log?entity_type=tree&entity_id=1&user_id=6a1078e8-80fd-458f-b74e-e388fe2dd6ab&action=update&from=2025-03-01T01:00:00%2B01:00", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.AuditLogListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, serverEntities.AuditActionUpdate, response.Data[0].Action)
+		assert.Equal(t, serverEntities.AuditEntityTypeTree, response.Data[0].EntityType)
+		assert.JSONEq(t, `"Quercus robur"`, string(response.Data[0].Changes["species"].Old))
+		assert.JSONEq(t, `"Tilia cordata"`, string(response.Data[0].Changes["species"].New))
+	})
+
+	t.Run("should return 400 for invalid timestamp", func(t *testing.T) {
+		app := fiber.New()
+		mockAuditLogService := serviceMock.NewMockAuditLogService(t)
+		app.Get("/v1/audit-log", auditlog.GetAuditLog(mockAuditLogService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/audit-log?to=yesterday", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockAuditLogService.AssertNotCalled(t, "GetAll")
+	})
+
+	t.Run("should return 400 for invalid filter", func(t *testing.T) {
+		app := fiber.New()
+		mockAuditLogService := serviceMock.NewMockAuditLogService(t)
+		app.Get("/v1/audit-log", auditlog.GetAuditLog(mockAuditLogService))
+
+		mockAuditLogService.EXPECT().GetAll(mock.Anything, mock.Anything).Return(nil, int64(0), service.ErrAuditLogQueryInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/audit-log?entity_type=sensor", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app := fiber.New()
+		mockAuditLogService := serviceMock.NewMockAuditLogService(t)
+		app.Get("/v1/audit-log", auditlog.GetAuditLog(mockAuditLogService))
+
+		mockAuditLogService.EXPECT().GetAll(mock.Anything, mock.Anything).Return(nil, int64(0), service.NewError(service.InternalError, "service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/audit-log", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		handler    func(service.AuditLogService) fiber.Handler
+		entityType entities.AuditEntityType
+	}{
+		{"tree", "/v1/tree/:id/history", auditlog.GetTreeHistory, entities.AuditEntityTypeTree},
+		{"tree cluster", "/v1/cluster/:id/history", auditlog.GetTreeClusterHistory, entities.AuditEntityTypeTreeCluster},
+		{"vehicle", "/v1/vehicle/:id/history", auditlog.GetVehicleHistory, entities.AuditEntityTypeVehicle},
+		{"watering plan", "/v1/watering-plan/:id/history", auditlog.GetWateringPlanHistory, entities.AuditEntityTypeWateringPlan},
+	}
+
+	for _, tt := range tests {
+		t.Run("should return history of "+tt.name, func(t *testing.T) {
+			app := fiber.New()
+			mockAuditLogService := serviceMock.NewMockAuditLogService(t)
+			app.Get(tt.path, tt.handler(mockAuditLogService))
+
+			mockAuditLogService.EXPECT().GetHistory(mock.Anything, tt.entityType, int32(1)).Return(TestAuditLogs, int64(2), nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, strings.Replace(tt.path, ":id", "1", 1), nil)
+			resp, err := app.Test(req, -1)
+			defer resp.Body.Close()
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var response serverEntities.AuditLogListResponse
+			err = utils.ParseJSONResponse(resp, &response)
+			assert.NoError(t, err)
+			assert.Len(t, response.Data, 2)
+			assert.Equal(t, "api-key:0a1b2c3d4e5f", response.Data[1].UserID)
+		})
+	}
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		app := fiber.New()
+		mockAuditLogService := serviceMock.NewMockAuditLogService(t)
+		app.Get("/v1/tree/:id/history", auditlog.GetTreeHistory(mockAuditLogService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree/abc/history", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockAuditLogService.AssertNotCalled(t, "GetHistory")
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app := fiber.New()
+		mockAuditLogService := serviceMock.NewMockAuditLogService(t)
+		app.Get("/v1/tree/:id/history", auditlog.GetTreeHistory(mockAuditLogService))
+
+		mockAuditLogService.EXPECT().GetHistory(mock.Anything, entities.AuditEntityTypeTree, int32(1)).Return(nil, int64(0), service.NewError(service.InternalError, "service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree/1/history", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -0,0 +1,38 @@
+package auditlog_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	now = time.Now()
+
+	TestAuditLogs = []*entities.AuditLog{
+		{
+			ID:         2,
+			CreatedAt:  now,
+			EntityType: entities.AuditEntityTypeTree,
+			EntityID:   1,
+			Action:     entities.AuditActionUpdate,
+			UserID:     "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+			Changes: map[string]entities.AuditChange{
+				"species":         {Old: json.RawMessage(`"Quercus robur"`), New: json.RawMessage(`"Tilia cordata"`)},
+				"tree_cluster_id": {Old: json.RawMessage(`null`), New: json.RawMessage(`1`)},
+			},
+		},
+		{
+			ID:         1,
+			CreatedAt:  now.Add(-time.Hour),
+			EntityType: entities.AuditEntityTypeTree,
+			EntityID:   1,
+			Action:     entities.AuditActionCreate,
+			UserID:     "api-key:0a1b2c3d4e5f",
+			Changes: map[string]entities.AuditChange{
+				"species": {Old: json.RawMessage(`null`), New: json.RawMessage(`"Quercus robur"`)},
+			},
+		},
+	}
+)
@@ -31,6 +31,8 @@ func HandleError(err error) error {
 			code = fiber.StatusInternalServerError
 		case service.Conflict:
 			code = fiber.StatusConflict
+		case service.PreconditionFailed:
+			code = fiber.StatusPreconditionFailed
 		default:
 			slog.Debug("missing service error code", "code", svcErr.Code)
 		}
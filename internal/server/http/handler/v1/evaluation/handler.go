@@ -1,7 +1,10 @@
 package evaluation
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	_ "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
@@ -32,3 +35,55 @@ func GetEvaluation(svc service.EvaluationService) fiber.Handler {
 		return c.JSON(evaluationMapper.FromResponse(domainData))
 	}
 }
+
+// @Summary		Get evaluation series
+// @Description	Get the consumed water, finished and not completed watering plans, refills, distance and hours of the watering plans between from and to split into periods. The series can be grouped by region, tree cluster, vehicle or user. Without dates the last 30 days are evaluated.
+// @Id				get-evaluation-series
+// @Tags			Evaluation
+// @Produce		json
+// @Success		200	{object}	entities.EvaluationSeriesResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/evaluation/series [get]
+// @Param			from		query	string	false	"First day of the evaluation (YYYY-MM-DD)"
+// @Param			to			query	string	false	"Last day of the evaluation (YYYY-MM-DD)"
+// @Param			interval	query	string	false	"Length of the periods (day, week, month)"
+// @Param			group_by	query	string	false	"Grouping of the series (region, tree_cluster, vehicle, user)"
+// @Security		Keycloak
+func GetEvaluationSeries(svc service.EvaluationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var query domain.EvaluationSeriesQuery
+		if err := c.QueryParser(&query); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+		}
+
+		var err error
+		if query.From, err = parseDate(c.Query("from")); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "from must be a date in the format YYYY-MM-DD"))
+		}
+		if query.To, err = parseDate(c.Query("to")); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "to must be a date in the format YYYY-MM-DD"))
+		}
+
+		domainData, err := svc.GetSeries(c.Context(), &query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(evaluationMapper.FromSeriesResponse(domainData))
+	}
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
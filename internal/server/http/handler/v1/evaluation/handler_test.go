@@ -5,11 +5,13 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/evaluation"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/stretchr/testify/assert"
@@ -67,3 +69,129 @@ func TestGetEvaluation(t *testing.T) {
 		mockEvaluationService.AssertExpectations(t)
 	})
 }
+
+func TestGetEvaluationSeries(t *testing.T) {
+	t.Run("should return evaluation series successfully", func(t *testing.T) {
+		mockEvaluationService := serviceMock.NewMockEvaluationService(t)
+		app := fiber.New()
+		handler := evaluation.GetEvaluationSeries(mockEvaluationService)
+
+		from := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+		series := &entities.EvaluationSeries{
+			From:     from,
+			To:       to,
+			Interval: entities.EvaluationIntervalWeek,
+			GroupBy:  entities.EvaluationGroupByRegion,
+			Groups: []*entities.EvaluationGroupSeries{
+				{
+					Key:     "1",
+					Name:    "Mürwik",
+					Total:   &entities.EvaluationPeriod{Start: from, WateringPlanCount: 2, WaterConsumed: 150, LitresPerTree: utils.P(15.0)},
+					Periods: []*entities.EvaluationPeriod{{Start: from, WateringPlanCount: 2, WaterConsumed: 150, LitresPerTree: utils.P(15.0)}},
+				},
+			},
+		}
+
+		mockEvaluationService.EXPECT().GetSeries(
+			mock.Anything,
+			&entities.EvaluationSeriesQuery{
+				From:     &from,
+				To:       &to,
+				Interval: entities.EvaluationIntervalWeek,
+				GroupBy:  entities.EvaluationGroupByRegion,
+			},
+		).Return(series, nil)
+
+		app.Get("/v1/evaluation/series", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/evaluation/series?from=2025-06-01&to=2025-06-30&interval=week&group_by=region", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.EvaluationSeriesResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, serverEntities.EvaluationIntervalWeek, response.Interval)
+		assert.Equal(t, serverEntities.EvaluationGroupByRegion, response.GroupBy)
+		assert.Len(t, response.Groups, 1)
+		assert.Equal(t, "Mürwik", response.Groups[0].Name)
+		assert.Equal(t, 150.0, response.Groups[0].Total.WaterConsumed)
+		assert.Equal(t, utils.P(15.0), response.Groups[0].Periods[0].LitresPerTree)
+		assert.Nil(t, response.Groups[0].Periods[0].GoodTreePercentage)
+
+		mockEvaluationService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 when date is invalid", func(t *testing.T) {
+		mockEvaluationService := serviceMock.NewMockEvaluationService(t)
+		app := fiber.New()
+		handler := evaluation.GetEvaluationSeries(mockEvaluationService)
+
+		app.Get("/v1/evaluation/series", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/evaluation/series?from=01.06.2025", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockEvaluationService.AssertNotCalled(t, "GetSeries")
+	})
+
+	t.Run("should return 400 when query is invalid", func(t *testing.T) {
+		mockEvaluationService := serviceMock.NewMockEvaluationService(t)
+		app := fiber.New()
+		handler := evaluation.GetEvaluationSeries(mockEvaluationService)
+
+		mockEvaluationService.EXPECT().GetSeries(
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, service.ErrEvaluationQueryInvalid)
+
+		app.Get("/v1/evaluation/series", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/evaluation/series?interval=year", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockEvaluationService.AssertExpectations(t)
+	})
+
+	t.Run("should return 500 when service returns an error", func(t *testing.T) {
+		mockEvaluationService := serviceMock.NewMockEvaluationService(t)
+		app := fiber.New()
+		handler := evaluation.GetEvaluationSeries(mockEvaluationService)
+
+		mockEvaluationService.EXPECT().GetSeries(
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, errors.New("service error"))
+
+		app.Get("/v1/evaluation/series", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/evaluation/series", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		mockEvaluationService.AssertExpectations(t)
+	})
+}
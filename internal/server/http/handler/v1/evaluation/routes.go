@@ -7,4 +7,5 @@ import (
 
 func RegisterRoutes(r fiber.Router, svc service.EvaluationService) {
 	r.Get("/", GetEvaluation(svc))
+	r.Get("/series", GetEvaluationSeries(svc))
 }
@@ -27,6 +27,27 @@ func TestRegisterRoutes(t *testing.T) {
 			// when
 			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
 
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+	t.Run("/v1/evaluation/series", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockEvaluationService := serviceMock.NewMockEvaluationService(t)
+			app := fiber.New()
+			evaluation.RegisterRoutes(app, mockEvaluationService)
+
+			mockEvaluationService.EXPECT().GetSeries(
+				mock.Anything,
+				mock.Anything,
+			).Return(&entities.EvaluationSeries{}, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/series", nil)
+
 			// then
 			resp, err := app.Test(req)
 			defer resp.Body.Close()
@@ -0,0 +1,98 @@
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// heartbeatInterval keeps idle connections open behind proxies that close inactive connections. A
+// disconnected client is only noticed when a write fails, so it is also the time until its
+// subscription ends.
+const heartbeatInterval = 15 * time.Second
+
+// @Summary		Stream domain events
+// @Description	Stream the changes to trees, tree clusters, sensor data and watering plans as server-sent events. The event name is the event type,
+// @Description	the data is the same JSON payload that is delivered to webhooks. Events without a region are not sent if region_ids is set.
+// @Description	If entity ids are given, only events referencing at least one of them are sent.
+// @Description	A reconnecting client receives the missed events after the Last-Event-ID header. If they are no longer available, a reset event is sent instead
+// @Description	and the client has to reload the entities it shows. If the in-memory event bus is used, only the latest events of the same instance can be resumed.
+// @Id				stream-events
+// @Tags			Events
+// @Produce		text/event-stream
+// @Success		200	{string}	string
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/events/stream [get]
+// @Param			event_types			query	[]string	false	"Event types (create tree, update tree, delete tree, update tree cluster, receive sensor data, update watering plan)"
+// @Param			region_ids			query	[]int		false	"Region IDs"
+// @Param			tree_ids			query	[]int		false	"Tree IDs"
+// @Param			tree_cluster_ids	query	[]int		false	"Tree cluster IDs"
+// @Param			sensor_ids			query	[]string	false	"Sensor IDs"
+// @Param			watering_plan_ids	query	[]int		false	"Watering plan IDs"
+// @Param			last_event_id		query	string		false	"Last event ID, if the Last-Event-ID header can not be set"
+// @Param			Last-Event-ID		header	string		false	"Last event ID"
+// @Security		Keycloak
+func StreamEvents(svc service.EventStreamService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var query domain.EventStreamQuery
+		if err := c.QueryParser(&query); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+		}
+
+		lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+
+		// the stream outlives the handler, the subscription ends when the client disconnects
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := svc.Subscribe(ctx, &query, lastEventID)
+		if err != nil {
+			cancel()
+			return errorhandler.HandleError(err)
+		}
+
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Set(fiber.HeaderConnection, "keep-alive")
+		c.Set("X-Accel-Buffering", "no")
+
+		// the context of the request is only done when the server shuts down, not when the client disconnects
+		shutdown := c.Context().Done()
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer cancel()
+			ticker := time.NewTicker(heartbeatInterval)
+			defer ticker.Stop()
+
+			_, _ = fmt.Fprint(w, ": connected\n\n")
+			for {
+				if err := w.Flush(); err != nil {
+					return
+				}
+
+				select {
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+					if event.ID != "" {
+						_, _ = fmt.Fprintf(w, "id: %s\n", event.ID)
+					}
+					_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, event.Data)
+				case <-ticker.C:
+					_, _ = fmt.Fprint(w, ": heartbeat\n\n")
+				case <-shutdown:
+					return
+				}
+			}
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package eventstream_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/eventstream"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupApp(t *testing.T) (*fiber.App, *serviceMock.MockEventStreamService) {
+	app := fiber.New(fiber.Config{EnableSplittingOnParsers: true})
+	mockStreamService := serviceMock.NewMockEventStreamService(t)
+	app.Get("/v1/events/stream", eventstream.StreamEvents(mockStreamService))
+	return app, mockStreamService
+}
+
+func closedStream(events ...*entities.StreamEvent) <-chan *entities.StreamEvent {
+	ch := make(chan *entities.StreamEvent, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+func TestStreamEvents(t *testing.T) {
+	t.Run("should stream events as server-sent events", func(t *testing.T) {
+		app, mockStreamService := setupApp(t)
+		query := &entities.EventStreamQuery{
+			EventTypes: []entities.EventType{entities.EventTypeUpdateTree, entities.EventTypeDeleteTree},
+			RegionIDs:  []int32{1, 2},
+			SensorIDs:  []string{"sensor-1"},
+		}
+		event := &entities.StreamEvent{ID: "1", Type: entities.EventTypeUpdateTree, Data: []byte(`{"id":"1"}`)}
+		mockStreamService.EXPECT().Subscribe(mock.Anything, query, "").Return(closedStream(event), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			"/v1/events/stream?event_types=update%20tree,delete%20tree&region_ids=1,2&sensor_ids=sensor-1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, "no-cache", resp.Header.Get(fiber.HeaderCacheControl))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, ": connected\n\nid: 1\nevent: update tree\ndata: {\"id\":\"1\"}\n\n", string(body))
+	})
+
+	t.Run("should not send id of event without id", func(t *testing.T) {
+		app, mockStreamService := setupApp(t)
+		event := &entities.StreamEvent{Type: entities.EventTypeDeleteTree, Data: []byte(`{}`)}
+		mockStreamService.EXPECT().Subscribe(mock.Anything, &entities.EventStreamQuery{}, "").Return(closedStream(event), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/events/stream", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, ": connected\n\nevent: delete tree\ndata: {}\n\n", string(body))
+	})
+
+	t.Run("should resume after the last event id header", func(t *testing.T) {
+		app, mockStreamService := setupApp(t)
+		mockStreamService.EXPECT().Subscribe(mock.Anything, &entities.EventStreamQuery{}, "7").Return(closedStream(), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/events/stream", nil)
+		req.Header.Set("Last-Event-ID", "7")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should resume after the last event id query parameter", func(t *testing.T) {
+		app, mockStreamService := setupApp(t)
+		mockStreamService.EXPECT().Subscribe(mock.Anything, &entities.EventStreamQuery{}, "7").Return(closedStream(), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/events/stream?last_event_id=7", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for unsupported event type", func(t *testing.T) {
+		app, mockStreamService := setupApp(t)
+		mockStreamService.EXPECT().Subscribe(mock.Anything, mock.Anything, "").Return(nil, service.ErrEventStreamTypeInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/events/stream?event_types=import%20trees", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid region id", func(t *testing.T) {
+		app, mockStreamService := setupApp(t)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/events/stream?region_ids=abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockStreamService.AssertNotCalled(t, "Subscribe")
+	})
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var contentTypes = map[domain.ExportFormat]string{
+	domain.ExportFormatGeoJSON:    "application/geo+json",
+	domain.ExportFormatCSV:        "text/csv;charset=UTF-8",
+	domain.ExportFormatGeoPackage: "application/geopackage+sqlite3",
+}
+
+// @Summary		Export entities
+// @Description	Export all trees, tree clusters or watering plans matching the filters as GeoJSON feature collection, CSV or GeoPackage.
+// @Description	The file is streamed while the entities are read, errors after the first byte abort the response.
+// @Description	Tree exports accept the filters of the tree list, cluster exports the filters of the tree cluster list.
+// @Id				export
+// @Tags			Export
+// @Produce		application/geo+json
+// @Produce		text/csv
+// @Produce		application/geopackage+sqlite3
+// @Success		200	{file}		file
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/export/{resource} [get]
+// @Param			resource			path	string		true	"Resource (tree, cluster, watering-plan)"
+// @Param			format				query	string		false	"Format (geojson, csv, gpkg), defaults to geojson"
+// @Param			provider			query	string		false	"Provider"
+// @Param			watering_statuses	query	[]string	false	"Watering statuses (tree, cluster)"
+// @Param			planting_years		query	[]int		false	"Planting years (tree)"
+// @Param			has_cluster			query	bool		false	"Has cluster (tree)"
+// @Param			regions				query	[]string	false	"Regions (cluster)"
+// @Security		Keycloak
+func Export(svc service.ExportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		resource := domain.ExportResource(strings.Clone(c.Params("resource")))
+		format := domain.ExportFormat(c.Query("format", string(domain.ExportFormatGeoJSON)))
+		if !format.IsValid() {
+			return errorhandler.HandleError(service.ErrExportFormatInvalid)
+		}
+
+		var export func(w io.Writer) error
+		switch resource {
+		case domain.ExportResourceTree:
+			var filter domain.TreeQuery
+			if err := c.QueryParser(&filter); err != nil {
+				return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+			}
+			export = func(w io.Writer) error { return svc.ExportTrees(ctx, w, format, filter) }
+		case domain.ExportResourceTreeCluster:
+			var filter domain.TreeClusterQuery
+			if err := c.QueryParser(&filter); err != nil {
+				return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+			}
+			export = func(w io.Writer) error { return svc.ExportTreeClusters(ctx, w, format, filter) }
+		case domain.ExportResourceWateringPlan:
+			var filter domain.Query
+			if err := c.QueryParser(&filter); err != nil {
+				return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+			}
+			export = func(w io.Writer) error { return svc.ExportWateringPlans(ctx, w, format, filter) }
+		default:
+			return errorhandler.HandleError(service.ErrExportResourceInvalid)
+		}
+
+		c.Set(fiber.HeaderContentType, contentTypes[format])
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s.%s", resource, format))
+		ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := export(w); err != nil {
+				logger.GetLogger(ctx).Error("failed to stream export", "error", err, "resource", resource, "format", format)
+			}
+		})
+
+		return nil
+	}
+}
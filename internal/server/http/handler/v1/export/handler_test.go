@@ -0,0 +1,146 @@
+package export_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/export"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupApp(t *testing.T) (*fiber.App, *serviceMock.MockExportService) {
+	app := fiber.New()
+	mockExportService := serviceMock.NewMockExportService(t)
+	app.Get("/v1/export/:resource", export.Export(mockExportService))
+	return app, mockExportService
+}
+
+func TestExport(t *testing.T) {
+	t.Run("should stream trees as geojson by default", func(t *testing.T) {
+		app, mockExportService := setupApp(t)
+		query := entities.TreeQuery{
+			WateringStatuses: []entities.WateringStatus{entities.WateringStatusBad},
+			PlantingYears:    []int32{2010},
+			Query:            entities.Query{Provider: "city"},
+		}
+		mockExportService.EXPECT().ExportTrees(mock.Anything, mock.Anything, entities.ExportFormatGeoJSON, query).
+			RunAndReturn(func(_ context.Context, w io.Writer, _ entities.ExportFormat, _ entities.TreeQuery) error {
+				_, err := io.WriteString(w, `{"type":"FeatureCollection","features":[]}`)
+				return err
+			})
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			"/v1/export/tree?watering_statuses=bad&planting_years=2010&provider=city", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/geo+json", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, "attachment; filename=tree.geojson", resp.Header.Get(fiber.HeaderContentDisposition))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, `{"type":"FeatureCollection","features":[]}`, string(body))
+	})
+
+	t.Run("should stream tree clusters as csv", func(t *testing.T) {
+		app, mockExportService := setupApp(t)
+		query := entities.TreeClusterQuery{Regions: []string{"Mürwik"}}
+		mockExportService.EXPECT().ExportTreeClusters(mock.Anything, mock.Anything, entities.ExportFormatCSV, query).
+			RunAndReturn(func(_ context.Context, w io.Writer, _ entities.ExportFormat, _ entities.TreeClusterQuery) error {
+				_, err := io.WriteString(w, "id,name\n1,Cluster 1\n")
+				return err
+			})
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/export/cluster?format=csv&regions=M%C3%BCrwik", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv;charset=UTF-8", resp.Header.Get(fiber.HeaderContentType))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "id,name\n1,Cluster 1\n", string(body))
+	})
+
+	t.Run("should stream watering plans as geopackage", func(t *testing.T) {
+		app, mockExportService := setupApp(t)
+		mockExportService.EXPECT().ExportWateringPlans(mock.Anything, mock.Anything, entities.ExportFormatGeoPackage, entities.Query{}).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/export/watering-plan?format=gpkg", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/geopackage+sqlite3", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, "attachment; filename=watering-plan.gpkg", resp.Header.Get(fiber.HeaderContentDisposition))
+	})
+
+	t.Run("should return 400 when format is invalid", func(t *testing.T) {
+		app, mockExportService := setupApp(t)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/export/tree?format=shp", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockExportService.AssertNotCalled(t, "ExportTrees")
+	})
+
+	t.Run("should return 400 when resource is invalid", func(t *testing.T) {
+		app, _ := setupApp(t)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/export/sensor", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when filter is invalid", func(t *testing.T) {
+		app, _ := setupApp(t)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/export/tree?planting_years=abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should keep status when export fails while streaming", func(t *testing.T) {
+		app, mockExportService := setupApp(t)
+		mockExportService.EXPECT().ExportWateringPlans(mock.Anything, mock.Anything, entities.ExportFormatGeoJSON, entities.Query{}).
+			Return(service.NewError(service.InternalError, "failed"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/export/watering-plan", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,10 @@
+package export
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.ExportService) {
+	r.Get("/:resource", Export(svc))
+}
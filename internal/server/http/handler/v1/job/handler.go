@@ -0,0 +1,120 @@
+package job
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+var (
+	jobMapper = generated.JobHTTPMapperImpl{}
+)
+
+// @Summary		Get all jobs
+// @Description	Get all scheduled jobs with their schedule, next run and last run. Requires the admin role.
+// @Id				get-all-jobs
+// @Tags			Job
+// @Produce		json
+// @Success		200	{object}	entities.JobListResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/jobs [get]
+// @Security		Keycloak
+func GetAllJobs(svc service.JobService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.JobListResponse{
+			Data: jobMapper.FromResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get job by name
+// @Description	Get a scheduled job with its schedule, next run and last run. Requires the admin role.
+// @Id				get-job-by-name
+// @Tags			Job
+// @Produce		json
+// @Success		200	{object}	entities.JobResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/jobs/{name} [get]
+// @Param			name	path	string	true	"Job name"
+// @Security		Keycloak
+func GetJobByName(svc service.JobService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetByName(ctx, c.Params("name"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(jobMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Get job runs
+// @Description	Get the runs of a job, newest first. Requires the admin role.
+// @Id				get-job-runs
+// @Tags			Job
+// @Produce		json
+// @Success		200	{object}	entities.JobRunListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/jobs/{name}/runs [get]
+// @Param			name	path	string	true	"Job name"
+// @Param			page	query	int		false	"Page"
+// @Param			limit	query	int		false	"Limit"
+// @Security		Keycloak
+func GetJobRuns(svc service.JobService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, totalCount, err := svc.GetRuns(ctx, c.Params("name"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.JobRunListResponse{
+			Data:       jobMapper.FromRunResponseList(domainData),
+			Pagination: pagination.Create(ctx, totalCount),
+		})
+	}
+}
+
+// @Summary		Trigger job run
+// @Description	Queue a manual run of a job, also if the job is disabled. The run is started by the leader instance within the poll interval of the scheduler. Requires the admin role.
+// @Id				trigger-job-run
+// @Tags			Job
+// @Produce		json
+// @Success		202	{object}	entities.JobRunResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/jobs/{name}/run [post]
+// @Param			name	path	string	true	"Job name"
+// @Security		Keycloak
+func TriggerJobRun(svc service.JobService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.Trigger(ctx, c.Params("name"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusAccepted).JSON(jobMapper.FromRunResponse(domainData))
+	}
+}
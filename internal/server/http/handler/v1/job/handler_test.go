@@ -0,0 +1,200 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/job"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllJobs(t *testing.T) {
+	t.Run("should return all jobs", func(t *testing.T) {
+		app := fiber.New()
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Get("/v1/jobs", job.GetAllJobs(mockJobService))
+
+		mockJobService.EXPECT().GetAll(mock.Anything).Return(TestJobs, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/jobs", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.JobListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, entities.JobSensorStatus, response.Data[0].Name)
+		assert.True(t, response.Data[0].Enabled)
+		assert.NotNil(t, response.Data[0].NextRunAt)
+		assert.Equal(t, serverEntities.JobRunStatusFailed, response.Data[0].LastRun.Status)
+		assert.Equal(t, int64(1500), *response.Data[0].LastRun.DurationMs)
+		assert.Equal(t, "database error", *response.Data[0].LastRun.Error)
+		assert.Nil(t, response.Data[1].NextRunAt)
+		assert.Nil(t, response.Data[1].LastRun)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app := fiber.New()
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Get("/v1/jobs", job.GetAllJobs(mockJobService))
+
+		mockJobService.EXPECT().GetAll(mock.Anything).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/jobs", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetJobByName(t *testing.T) {
+	t.Run("should return job", func(t *testing.T) {
+		app := fiber.New()
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Get("/v1/jobs/:name", job.GetJobByName(mockJobService))
+
+		mockJobService.EXPECT().GetByName(mock.Anything, entities.JobSensorStatus).Return(TestJobs[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/jobs/sensor_status", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.JobResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "0 */3 * * *", response.Schedule)
+	})
+
+	t.Run("should return 404 for unknown job", func(t *testing.T) {
+		app := fiber.New()
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Get("/v1/jobs/:name", job.GetJobByName(mockJobService))
+
+		mockJobService.EXPECT().GetByName(mock.Anything, "unknown").Return(nil, service.ErrJobNotFound)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/jobs/unknown", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetJobRuns(t *testing.T) {
+	t.Run("should return runs of the job paginated", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.PaginationMiddleware())
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Get("/v1/jobs/:name/runs", job.GetJobRuns(mockJobService))
+
+		mockJobService.EXPECT().GetRuns(mock.Anything, entities.JobSensorStatus).Return(TestJobRuns[:1], int64(2), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/jobs/sensor_status/runs?page=1&limit=1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.JobRunListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, int64(2), response.Data[0].ID)
+		assert.Equal(t, serverEntities.JobRunTriggerSchedule, response.Data[0].Trigger)
+		assert.Equal(t, "backend-6d4f8a1c", response.Data[0].Instance)
+		assert.Equal(t, int64(2), response.Pagination.Total)
+		assert.Equal(t, int32(2), *response.Pagination.NextPage)
+	})
+
+	t.Run("should return 404 for unknown job", func(t *testing.T) {
+		app := fiber.New()
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Get("/v1/jobs/:name/runs", job.GetJobRuns(mockJobService))
+
+		mockJobService.EXPECT().GetRuns(mock.Anything, "unknown").Return(nil, int64(0), service.ErrJobNotFound)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/jobs/unknown/runs", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestTriggerJobRun(t *testing.T) {
+	t.Run("should queue manual run", func(t *testing.T) {
+		app := fiber.New()
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Post("/v1/jobs/:name/run", job.TriggerJobRun(mockJobService))
+
+		mockJobService.EXPECT().Trigger(mock.Anything, entities.JobSensorStatus).Return(TestJobRuns[1], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/jobs/sensor_status/run", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var response serverEntities.JobRunResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, serverEntities.JobRunStatusPending, response.Status)
+		assert.Equal(t, serverEntities.JobRunTriggerManual, response.Trigger)
+		assert.Equal(t, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab", response.RequestedBy)
+		assert.Nil(t, response.StartedAt)
+	})
+
+	t.Run("should return 404 for unknown job", func(t *testing.T) {
+		app := fiber.New()
+		mockJobService := serviceMock.NewMockJobService(t)
+		app.Post("/v1/jobs/:name/run", job.TriggerJobRun(mockJobService))
+
+		mockJobService.EXPECT().Trigger(mock.Anything, "unknown").Return(nil, service.ErrJobNotFound)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/jobs/unknown/run", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
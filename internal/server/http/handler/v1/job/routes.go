@@ -0,0 +1,13 @@
+package job
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.JobService) {
+	r.Get("/", GetAllJobs(svc))
+	r.Get("/:name", GetJobByName(svc))
+	r.Get("/:name/runs", GetJobRuns(svc))
+	r.Post("/:name/run", TriggerJobRun(svc))
+}
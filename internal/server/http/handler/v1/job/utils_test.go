@@ -0,0 +1,49 @@
+package job_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	now = time.Now()
+
+	TestJobRuns = []*entities.JobRun{
+		{
+			ID:         2,
+			CreatedAt:  now,
+			Job:        entities.JobSensorStatus,
+			Trigger:    entities.JobRunTriggerSchedule,
+			Status:     entities.JobRunStatusFailed,
+			Instance:   "backend-6d4f8a1c",
+			StartedAt:  utils.P(now),
+			FinishedAt: utils.P(now.Add(1500 * time.Millisecond)),
+			Duration:   utils.P(1500 * time.Millisecond),
+			Error:      utils.P("database error"),
+		},
+		{
+			ID:          1,
+			CreatedAt:   now.Add(-time.Hour),
+			Job:         entities.JobSensorStatus,
+			Trigger:     entities.JobRunTriggerManual,
+			Status:      entities.JobRunStatusPending,
+			RequestedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		},
+	}
+
+	TestJobs = []*entities.Job{
+		{
+			Name:      entities.JobSensorStatus,
+			Schedule:  "0 */3 * * *",
+			Enabled:   true,
+			NextRunAt: utils.P(now.Add(time.Hour)),
+			LastRun:   TestJobRuns[0],
+		},
+		{
+			Name:     entities.JobTreeWateringStatus,
+			Schedule: "30 2 * * *",
+		},
+	}
+)
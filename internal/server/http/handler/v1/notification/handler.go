@@ -0,0 +1,323 @@
+package notification
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+var (
+	notificationMapper = generated.NotificationHTTPMapperImpl{}
+)
+
+// @Summary		Get notifications
+// @Description	Get the notifications in the inbox of the current user, newest first
+// @Id				get-all-notifications
+// @Tags			Notification
+// @Produce		json
+// @Success		200	{object}	entities.NotificationListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification [get]
+// @Param			page	query	int		false	"Page"
+// @Param			limit	query	int		false	"Limit"
+// @Param			unread	query	bool	false	"Only unread notifications"
+// @Security		Keycloak
+func GetAllNotifications(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		var query domain.NotificationQuery
+		if err := c.QueryParser(&query); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+		}
+
+		domainData, totalCount, err := svc.GetAll(ctx, userID, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.NotificationListResponse{
+			Data:       notificationMapper.FromResponseList(domainData),
+			Pagination: pagination.Create(ctx, totalCount),
+		})
+	}
+}
+
+// @Summary		Get number of unread notifications
+// @Description	Get the number of unread notifications of the current user
+// @Id				get-unread-notification-count
+// @Tags			Notification
+// @Produce		json
+// @Success		200	{object}	entities.NotificationUnreadCountResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/unread-count [get]
+// @Security		Keycloak
+func GetUnreadCount(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		count, err := svc.CountUnread(ctx, userID)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.NotificationUnreadCountResponse{Count: count})
+	}
+}
+
+// @Summary		Mark notification as read
+// @Description	Mark a notification of the current user as read
+// @Id				mark-notification-read
+// @Tags			Notification
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/{id}/read [post]
+// @Param			id	path	int	true	"Notification ID"
+// @Security		Keycloak
+func MarkNotificationRead(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.MarkRead(ctx, userID, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// @Summary		Mark all notifications as read
+// @Description	Mark all notifications of the current user as read
+// @Id				mark-all-notifications-read
+// @Tags			Notification
+// @Success		204
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/read-all [post]
+// @Security		Keycloak
+func MarkAllNotificationsRead(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.MarkAllRead(ctx, userID); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// @Summary		Get notification settings
+// @Description	Get the notification settings of the current user. Users without settings get the default settings.
+// @Id				get-notification-settings
+// @Tags			Notification
+// @Produce		json
+// @Success		200	{object}	entities.NotificationSettingsResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/settings [get]
+// @Security		Keycloak
+func GetNotificationSettings(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetSettings(ctx, userID)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(notificationMapper.FromSettingsResponse(domainData))
+	}
+}
+
+// @Summary		Update notification settings
+// @Description	Update the channels per notification type, the quiet hours and the daily digest of the current user. Types that are left out are not notified.
+// @Id				update-notification-settings
+// @Tags			Notification
+// @Produce		json
+// @Success		200	{object}	entities.NotificationSettingsResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/settings [put]
+// @Param			body	body	entities.NotificationSettingsUpdateRequest	true	"Notification Settings Update Request"
+// @Security		Keycloak
+func UpdateNotificationSettings(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.NotificationSettingsUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.UpdateSettings(ctx, userID, notificationMapper.FromSettingsUpdateRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(notificationMapper.FromSettingsResponse(domainData))
+	}
+}
+
+// @Summary		Get push public key
+// @Description	Get the VAPID public key that is needed to subscribe to push messages
+// @Id				get-push-public-key
+// @Tags			Notification
+// @Produce		json
+// @Success		200	{object}	entities.PushPublicKeyResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/push/public-key [get]
+// @Security		Keycloak
+func GetPushPublicKey(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		key, err := svc.GetPushPublicKey(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.PushPublicKeyResponse{PublicKey: key})
+	}
+}
+
+// @Summary		Create push subscription
+// @Description	Store the push subscription of a browser for the current user. An existing subscription of the endpoint is replaced.
+// @Id				create-push-subscription
+// @Tags			Notification
+// @Produce		json
+// @Success		201	{object}	entities.PushSubscriptionResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/push/subscription [post]
+// @Param			body	body	entities.PushSubscriptionCreateRequest	true	"Push Subscription Create Request"
+// @Security		Keycloak
+func CreatePushSubscription(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.PushSubscriptionCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.SubscribePush(ctx, userID, notificationMapper.FromPushSubscriptionCreateRequest(&req))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(notificationMapper.FromPushSubscriptionResponse(domainData))
+	}
+}
+
+// @Summary		Delete push subscription
+// @Description	Delete the push subscription of a browser of the current user
+// @Id				delete-push-subscription
+// @Tags			Notification
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/notification/push/subscription [delete]
+// @Param			endpoint	query	string	true	"Endpoint of the push subscription"
+// @Security		Keycloak
+func DeletePushSubscription(svc service.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		userID, err := currentUser(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		endpoint := c.Query("endpoint")
+		if endpoint == "" {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "endpoint is required"))
+		}
+
+		if err := svc.UnsubscribePush(ctx, userID, endpoint); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// currentUser returns the id of the user of the jwt. Requests authenticated by an api key have no user.
+func currentUser(c *fiber.Ctx) (uuid.UUID, error) {
+	claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+	if !ok {
+		return uuid.Nil, service.ErrNotificationUserMissing
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil {
+		return uuid.Nil, service.ErrNotificationUserMissing
+	}
+
+	userID, err := uuid.Parse(sub)
+	if err != nil {
+		return uuid.Nil, service.ErrNotificationUserMissing
+	}
+
+	return userID, nil
+}
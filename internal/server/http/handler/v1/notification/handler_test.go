@@ -0,0 +1,336 @@
+package notification_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/notification"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllNotifications(t *testing.T) {
+	t.Run("should return notifications of current user", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Get("/v1/notification", withUser, notification.GetAllNotifications(mockNotificationService))
+
+		mockNotificationService.EXPECT().GetAll(mock.Anything, TestUserID, entities.NotificationQuery{Unread: true}).Return(TestNotifications, int64(2), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/notification?unread=true", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.NotificationListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, serverEntities.NotificationTypeTreeClusterBad, response.Data[0].Type)
+		assert.Equal(t, "/treecluster/1", response.Data[0].Link)
+		assert.Nil(t, response.Data[0].ReadAt)
+		assert.NotNil(t, response.Data[1].ReadAt)
+	})
+
+	t.Run("should return 403 without user", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Get("/v1/notification", notification.GetAllNotifications(mockNotificationService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/notification", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Get("/v1/notification", withUser, notification.GetAllNotifications(mockNotificationService))
+
+		mockNotificationService.EXPECT().GetAll(mock.Anything, TestUserID, entities.NotificationQuery{}).Return(nil, int64(0), service.NewError(service.InternalError, "service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/notification", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	t.Run("should return number of unread notifications", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Get("/v1/notification/unread-count", withUser, notification.GetUnreadCount(mockNotificationService))
+
+		mockNotificationService.EXPECT().CountUnread(mock.Anything, TestUserID).Return(int64(3), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/notification/unread-count", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.NotificationUnreadCountResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), response.Count)
+	})
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	t.Run("should mark notification as read", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Post("/v1/notification/:id/read", withUser, notification.MarkNotificationRead(mockNotificationService))
+
+		mockNotificationService.EXPECT().MarkRead(mock.Anything, TestUserID, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/notification/1/read", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Post("/v1/notification/:id/read", withUser, notification.MarkNotificationRead(mockNotificationService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/notification/abc/read", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 for notification of other user", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Post("/v1/notification/:id/read", withUser, notification.MarkNotificationRead(mockNotificationService))
+
+		mockNotificationService.EXPECT().MarkRead(mock.Anything, TestUserID, int32(9)).Return(service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/notification/9/read", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	t.Run("should mark all notifications as read", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Post("/v1/notification/read-all", withUser, notification.MarkAllNotificationsRead(mockNotificationService))
+
+		mockNotificationService.EXPECT().MarkAllRead(mock.Anything, TestUserID).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/notification/read-all", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestGetNotificationSettings(t *testing.T) {
+	t.Run("should return settings of current user", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Get("/v1/notification/settings", withUser, notification.GetNotificationSettings(mockNotificationService))
+
+		mockNotificationService.EXPECT().GetSettings(mock.Anything, TestUserID).Return(TestSettings, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/notification/settings", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.NotificationSettingsResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []serverEntities.NotificationChannel{serverEntities.NotificationChannelInApp, serverEntities.NotificationChannelPush}, response.Channels[serverEntities.NotificationTypeTreeClusterBad])
+		assert.Equal(t, "22:00", response.QuietHoursStart)
+		assert.Equal(t, "06:00", response.QuietHoursEnd)
+	})
+}
+
+func TestUpdateNotificationSettings(t *testing.T) {
+	t.Run("should update settings of current user", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Put("/v1/notification/settings", withUser, notification.UpdateNotificationSettings(mockNotificationService))
+
+		mockNotificationService.EXPECT().UpdateSettings(mock.Anything, TestUserID, &entities.NotificationSettingsUpdate{
+			Channels: map[entities.NotificationType][]entities.NotificationChannel{
+				entities.NotificationTypeTreeClusterBad: {entities.NotificationChannelInApp, entities.NotificationChannelPush},
+			},
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "06:00",
+		}).Return(TestSettings, nil)
+
+		body := []byte(`{"channels":{"tree_cluster_bad":["in_app","push"]},"quiet_hours_start":"22:00","quiet_hours_end":"06:00","digest":false}`)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/notification/settings", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid settings", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Put("/v1/notification/settings", withUser, notification.UpdateNotificationSettings(mockNotificationService))
+
+		mockNotificationService.EXPECT().UpdateSettings(mock.Anything, TestUserID, mock.Anything).Return(nil, service.ErrNotificationSettings)
+
+		body := []byte(`{"channels":{"unknown":["in_app"]}}`)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/notification/settings", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestGetPushPublicKey(t *testing.T) {
+	t.Run("should return public key", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Get("/v1/notification/push/public-key", notification.GetPushPublicKey(mockNotificationService))
+
+		mockNotificationService.EXPECT().GetPushPublicKey(mock.Anything).Return("public-key", nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/notification/push/public-key", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.PushPublicKeyResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "public-key", response.PublicKey)
+	})
+}
+
+func TestCreatePushSubscription(t *testing.T) {
+	t.Run("should store push subscription of browser", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Post("/v1/notification/push/subscription", withUser, notification.CreatePushSubscription(mockNotificationService))
+
+		mockNotificationService.EXPECT().SubscribePush(mock.Anything, TestUserID, &entities.PushSubscriptionCreate{
+			Endpoint: "https://push.example.com/1",
+			P256dh:   "key",
+			Auth:     "auth",
+		}).Return(&entities.PushSubscription{ID: 1, CreatedAt: now, UserID: TestUserID, Endpoint: "https://push.example.com/1"}, nil)
+
+		body := []byte(`{"endpoint":"https://push.example.com/1","expirationTime":null,"keys":{"p256dh":"key","auth":"auth"}}`)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/notification/push/subscription", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.PushSubscriptionResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), response.ID)
+		assert.Equal(t, "https://push.example.com/1", response.Endpoint)
+	})
+}
+
+func TestDeletePushSubscription(t *testing.T) {
+	t.Run("should delete push subscription", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Delete("/v1/notification/push/subscription", withUser, notification.DeletePushSubscription(mockNotificationService))
+
+		mockNotificationService.EXPECT().UnsubscribePush(mock.Anything, TestUserID, "https://push.example.com/1").Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/notification/push/subscription?endpoint=https%3A%2F%2Fpush.example.com%2F1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should return 400 without endpoint", func(t *testing.T) {
+		app := fiber.New()
+		mockNotificationService := serviceMock.NewMockNotificationService(t)
+		app.Delete("/v1/notification/push/subscription", withUser, notification.DeletePushSubscription(mockNotificationService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/notification/push/subscription", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.NotificationService) {
+	r.Get("/", GetAllNotifications(svc))
+	r.Get("/unread-count", GetUnreadCount(svc))
+	r.Post("/read-all", MarkAllNotificationsRead(svc))
+	r.Get("/settings", GetNotificationSettings(svc))
+	r.Put("/settings", UpdateNotificationSettings(svc))
+	r.Get("/push/public-key", GetPushPublicKey(svc))
+	r.Post("/push/subscription", CreatePushSubscription(svc))
+	r.Delete("/push/subscription", DeletePushSubscription(svc))
+	r.Post("/:id/read", MarkNotificationRead(svc))
+}
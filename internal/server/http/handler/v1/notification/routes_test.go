@@ -0,0 +1,114 @@
+package notification_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/notification"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/notification", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockNotificationService := serviceMock.NewMockNotificationService(t)
+			app := fiber.New()
+			app.Use(withUser)
+			notification.RegisterRoutes(app, mockNotificationService)
+
+			mockNotificationService.EXPECT().GetAll(mock.Anything, TestUserID, mock.Anything).Return(TestNotifications, int64(2), nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/notification/read-all", func(t *testing.T) {
+		t.Run("should call POST handler and not the read handler of a notification", func(t *testing.T) {
+			mockNotificationService := serviceMock.NewMockNotificationService(t)
+			app := fiber.New()
+			app.Use(withUser)
+			notification.RegisterRoutes(app, mockNotificationService)
+
+			mockNotificationService.EXPECT().MarkAllRead(mock.Anything, TestUserID).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/read-all", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/notification/:id/read", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockNotificationService := serviceMock.NewMockNotificationService(t)
+			app := fiber.New()
+			app.Use(withUser)
+			notification.RegisterRoutes(app, mockNotificationService)
+
+			mockNotificationService.EXPECT().MarkRead(mock.Anything, TestUserID, int32(1)).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/1/read", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/notification/settings", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockNotificationService := serviceMock.NewMockNotificationService(t)
+			app := fiber.New()
+			app.Use(withUser)
+			notification.RegisterRoutes(app, mockNotificationService)
+
+			mockNotificationService.EXPECT().GetSettings(mock.Anything, TestUserID).Return(TestSettings, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/settings", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/notification/push/public-key", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockNotificationService := serviceMock.NewMockNotificationService(t)
+			app := fiber.New()
+			notification.RegisterRoutes(app, mockNotificationService)
+
+			mockNotificationService.EXPECT().GetPushPublicKey(mock.Anything).Return("public-key", nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/push/public-key", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
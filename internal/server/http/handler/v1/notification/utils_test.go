@@ -0,0 +1,59 @@
+package notification_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+var (
+	now = time.Now()
+
+	TestUserID = uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+
+	TestNotifications = []*entities.Notification{
+		{
+			ID:        1,
+			CreatedAt: now,
+			UserID:    TestUserID,
+			Type:      entities.NotificationTypeTreeClusterBad,
+			Title:     "Tree cluster Cluster A needs water",
+			Body:      "The watering status of the tree cluster Cluster A changed to bad.",
+			Link:      "/treecluster/1",
+			InApp:     true,
+		},
+		{
+			ID:        2,
+			CreatedAt: now,
+			UserID:    TestUserID,
+			Type:      entities.NotificationTypeSensorOffline,
+			Title:     "Sensor sensor-1 is offline",
+			Body:      "The sensor sensor-1 stopped sending data and was marked as offline.",
+			Link:      "/sensors/sensor-1",
+			InApp:     true,
+			ReadAt:    &now,
+		},
+	}
+
+	TestSettings = &entities.NotificationSettings{
+		UserID: TestUserID,
+		Channels: map[entities.NotificationType][]entities.NotificationChannel{
+			entities.NotificationTypeTreeClusterBad: {entities.NotificationChannelInApp, entities.NotificationChannelPush},
+		},
+		QuietHoursStart: "22:00",
+		QuietHoursEnd:   "06:00",
+		DigestTime:      "07:00",
+	}
+)
+
+// withUser sets the claims of the test user like the jwt middleware
+func withUser(c *fiber.Ctx) error {
+	claims := golangJwt.MapClaims{"sub": TestUserID.String()}
+	c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+	return c.Next()
+}
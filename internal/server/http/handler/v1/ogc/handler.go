@@ -0,0 +1,327 @@
+package ogc
+
+import (
+	"maps"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeGeoJSON = "application/geo+json"
+	trsGregorian       = "http://www.opengis.net/def/uom/ISO-8601/0/Gregorian"
+)
+
+var conformanceClasses = []string{
+	"http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/core",
+	"http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/geojson",
+	"http://www.opengis.net/spec/ogcapi-features-3/1.0/conf/queryables",
+	"http://www.opengis.net/spec/ogcapi-features-3/1.0/conf/queryables-query-parameters",
+}
+
+// @Summary		OGC API landing page
+// @Description	Landing page of the OGC API Features service with links to the conformance classes and collections
+// @Id				get-ogc-landing-page
+// @Tags			OGC API Features
+// @Produce		json
+// @Success		200	{object}	entities.OGCLandingPageResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Router			/v1/ogc [get]
+// @Security		Keycloak
+func GetLandingPage() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		self := selfURL(c)
+		return c.JSON(entities.OGCLandingPageResponse{
+			Title:       "Green Ecolution",
+			Description: "Trees, tree clusters, sensors and regions as OGC API Features",
+			Links: []*entities.OGCLink{
+				{Href: self, Rel: "self", Type: contentTypeJSON, Title: "This document"},
+				{Href: strings.TrimSuffix(self, "/ogc") + "/swagger/doc.json", Rel: "service-desc", Type: contentTypeJSON, Title: "API definition"},
+				{Href: self + "/conformance", Rel: "conformance", Type: contentTypeJSON, Title: "Conformance classes"},
+				{Href: self + "/collections", Rel: "data", Type: contentTypeJSON, Title: "Collections"},
+			},
+		})
+	}
+}
+
+// @Summary		OGC API conformance classes
+// @Description	List the OGC API conformance classes implemented by this service
+// @Id				get-ogc-conformance
+// @Tags			OGC API Features
+// @Produce		json
+// @Success		200	{object}	entities.OGCConformanceResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Router			/v1/ogc/conformance [get]
+// @Security		Keycloak
+func GetConformance() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(entities.OGCConformanceResponse{ConformsTo: conformanceClasses})
+	}
+}
+
+// @Summary		Get all OGC API collections
+// @Description	Get the trees, tree clusters, sensors and regions collections with their spatial and temporal extent
+// @Id				get-all-ogc-collections
+// @Tags			OGC API Features
+// @Produce		json
+// @Success		200	{object}	entities.OGCCollectionListResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/ogc/collections [get]
+// @Security		Keycloak
+func GetCollections(svc service.OGCService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		collections, err := svc.GetCollections(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		self := selfURL(c)
+		return c.JSON(entities.OGCCollectionListResponse{
+			Collections: utils.Map(collections, func(collection *domain.OGCCollection) *entities.OGCCollectionResponse {
+				return mapCollection(collection, self+"/"+string(collection.ID))
+			}),
+			Links: []*entities.OGCLink{
+				{Href: self, Rel: "self", Type: contentTypeJSON, Title: "This document"},
+			},
+		})
+	}
+}
+
+// @Summary		Get an OGC API collection
+// @Description	Get a collection with its spatial and temporal extent
+// @Id				get-ogc-collection
+// @Tags			OGC API Features
+// @Produce		json
+// @Success		200	{object}	entities.OGCCollectionResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/ogc/collections/{collectionId} [get]
+// @Param			collectionId	path	string	true	"Collection ID (trees, tree-clusters, sensors, regions)"
+// @Security		Keycloak
+func GetCollection(svc service.OGCService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		collection, err := svc.GetCollection(ctx, collectionID(c))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapCollection(collection, selfURL(c)))
+	}
+}
+
+// @Summary		Get the queryables of an OGC API collection
+// @Description	Get a JSON schema of the properties the features of a collection can be filtered by
+// @Id				get-ogc-queryables
+// @Tags			OGC API Features
+// @Produce		application/schema+json
+// @Success		200	{object}	entities.OGCQueryablesResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/ogc/collections/{collectionId}/queryables [get]
+// @Param			collectionId	path	string	true	"Collection ID (trees, tree-clusters, sensors, regions)"
+// @Security		Keycloak
+func GetQueryables(svc service.OGCService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		collection, err := svc.GetCollection(ctx, collectionID(c))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		properties := make(map[string]*entities.OGCQueryableProperty, len(collection.Queryables))
+		for _, q := range collection.Queryables {
+			properties[q.Name] = &entities.OGCQueryableProperty{Title: q.Name, Type: string(q.Type)}
+		}
+
+		return c.JSON(entities.OGCQueryablesResponse{
+			Schema:     "https://json-schema.org/draft/2019-09/schema",
+			ID:         selfURL(c),
+			Type:       "object",
+			Title:      collection.Title,
+			Properties: properties,
+		}, "application/schema+json")
+	}
+}
+
+// @Summary		Get the features of an OGC API collection
+// @Description	Get the features of a collection as GeoJSON feature collection. The features can be filtered by bbox,
+// @Description	datetime of the last change and by the queryables of the collection given as query parameters.
+// @Id				get-ogc-features
+// @Tags			OGC API Features
+// @Produce		application/geo+json
+// @Success		200	{object}	entities.OGCFeatureCollectionResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/ogc/collections/{collectionId}/items [get]
+// @Param			collectionId	path	string	true	"Collection ID (trees, tree-clusters, sensors, regions)"
+// @Param			bbox			query	string	false	"Bounding box as minLon,minLat,maxLon,maxLat"
+// @Param			bbox-crs		query	string	false	"CRS of the bounding box, only CRS84 is supported"
+// @Param			datetime		query	string	false	"RFC 3339 date time or interval start/end, .. is an open bound"
+// @Param			limit			query	int		false	"Limit, defaults to 10"
+// @Param			offset			query	int		false	"Offset"
+// @Security		Keycloak
+func GetFeatures(svc service.OGCService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		query, err := parseFeatureQuery(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		features, totalCount, err := svc.GetFeatures(ctx, collectionID(c), query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		self := selfURL(c)
+		links := []*entities.OGCLink{
+			{Href: pageURL(c, self, query.Offset, query.Limit), Rel: "self", Type: contentTypeGeoJSON, Title: "This document"},
+			{Href: strings.TrimSuffix(self, "/items"), Rel: "collection", Type: contentTypeJSON, Title: "The collection"},
+		}
+		if next := int64(query.Offset) + int64(len(features)); len(features) > 0 && next < totalCount {
+			links = append(links, &entities.OGCLink{Href: pageURL(c, self, int32(next), query.Limit), Rel: "next", Type: contentTypeGeoJSON, Title: "Next page"})
+		}
+		if query.Offset > 0 {
+			prev := max(query.Offset-query.Limit, 0)
+			links = append(links, &entities.OGCLink{Href: pageURL(c, self, prev, query.Limit), Rel: "prev", Type: contentTypeGeoJSON, Title: "Previous page"})
+		}
+
+		return c.JSON(entities.OGCFeatureCollectionResponse{
+			Type:           string(domain.FeatureCollection),
+			Features:       utils.Map(features, func(f *domain.OGCFeature) *entities.OGCFeatureResponse { return mapFeature(f, nil) }),
+			Links:          links,
+			NumberMatched:  totalCount,
+			NumberReturned: len(features),
+			TimeStamp:      time.Now().UTC(),
+		}, contentTypeGeoJSON)
+	}
+}
+
+// @Summary		Get a feature of an OGC API collection
+// @Description	Get a feature of a collection as GeoJSON feature
+// @Id				get-ogc-feature
+// @Tags			OGC API Features
+// @Produce		application/geo+json
+// @Success		200	{object}	entities.OGCFeatureResponse
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/ogc/collections/{collectionId}/items/{featureId} [get]
+// @Param			collectionId	path	string	true	"Collection ID (trees, tree-clusters, sensors, regions)"
+// @Param			featureId		path	string	true	"Feature ID"
+// @Security		Keycloak
+func GetFeature(svc service.OGCService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		feature, err := svc.GetFeature(ctx, collectionID(c), strings.Clone(c.Params("featureId")))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		self := selfURL(c)
+		return c.JSON(mapFeature(feature, []*entities.OGCLink{
+			{Href: self, Rel: "self", Type: contentTypeGeoJSON, Title: "This document"},
+			{Href: self[:strings.LastIndex(self, "/items/")], Rel: "collection", Type: contentTypeJSON, Title: "The collection"},
+		}), contentTypeGeoJSON)
+	}
+}
+
+func collectionID(c *fiber.Ctx) domain.OGCCollectionID {
+	return domain.OGCCollectionID(strings.Clone(c.Params("collectionId")))
+}
+
+// selfURL returns the absolute url of the request without query
+func selfURL(c *fiber.Ctx) string {
+	return c.BaseURL() + strings.TrimSuffix(c.Path(), "/")
+}
+
+// pageURL returns the url of the request with the given offset and limit, all other query parameters are kept
+func pageURL(c *fiber.Ctx, self string, offset, limit int32) string {
+	values := url.Values{}
+	for name, value := range c.Queries() {
+		values.Set(name, value)
+	}
+	values.Set("offset", strconv.Itoa(int(offset)))
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(int(limit)))
+	}
+
+	return self + "?" + values.Encode()
+}
+
+func mapCollection(collection *domain.OGCCollection, self string) *entities.OGCCollectionResponse {
+	return &entities.OGCCollectionResponse{
+		ID:          string(collection.ID),
+		Title:       collection.Title,
+		Description: collection.Description,
+		Extent:      mapExtent(collection.Extent),
+		ItemType:    "feature",
+		CRS:         []string{crs84},
+		Links: []*entities.OGCLink{
+			{Href: self, Rel: "self", Type: contentTypeJSON, Title: "This document"},
+			{Href: self + "/items", Rel: "items", Type: contentTypeGeoJSON, Title: collection.Title},
+			{Href: self + "/queryables", Rel: "http://www.opengis.net/def/rel/ogc/1.0/queryables", Type: "application/schema+json", Title: "Queryables"},
+		},
+	}
+}
+
+func mapExtent(extent *domain.OGCExtent) *entities.OGCExtentResponse {
+	if extent == nil {
+		return nil
+	}
+
+	result := &entities.OGCExtentResponse{}
+	if extent.BBox != nil {
+		result.Spatial = &entities.OGCSpatialExtentResponse{
+			BBox: [][]float64{{extent.BBox.MinLon, extent.BBox.MinLat, extent.BBox.MaxLon, extent.BBox.MaxLat}},
+			CRS:  crs84,
+		}
+	}
+	if extent.Interval != nil && (extent.Interval.Start != nil || extent.Interval.End != nil) {
+		result.Temporal = &entities.OGCTemporalExtentResponse{
+			Interval: [][]*time.Time{{extent.Interval.Start, extent.Interval.End}},
+			TRS:      trsGregorian,
+		}
+	}
+
+	return result
+}
+
+func mapFeature(feature *domain.OGCFeature, links []*entities.OGCLink) *entities.OGCFeatureResponse {
+	properties := maps.Clone(feature.Properties)
+	if properties == nil {
+		properties = make(map[string]any, 1)
+	}
+	properties["updated_at"] = feature.UpdatedAt
+
+	return &entities.OGCFeatureResponse{
+		Type:       string(domain.Feature),
+		ID:         feature.ID,
+		Geometry:   feature.Geometry,
+		Properties: properties,
+		Links:      links,
+	}
+}
@@ -0,0 +1,352 @@
+package ogc_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/ogc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	updatedAt = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	testCollection = &entities.OGCCollection{
+		ID:          entities.OGCCollectionTrees,
+		Title:       "Trees",
+		Description: "Trees",
+		Extent: &entities.OGCExtent{
+			BBox:     &entities.BoundingBox{MinLon: 9.4, MinLat: 54.7, MaxLon: 9.5, MaxLat: 54.9},
+			Interval: &entities.TimeInterval{Start: &updatedAt, End: &updatedAt},
+		},
+		Queryables: []entities.OGCQueryable{
+			{Name: "species", Type: entities.OGCQueryableTypeString},
+			{Name: "planting_year", Type: entities.OGCQueryableTypeInteger},
+		},
+	}
+
+	testFeatures = []*entities.OGCFeature{
+		{
+			ID:         "1",
+			Geometry:   []byte(`{"type":"Point","coordinates":[9.48,54.82]}`),
+			UpdatedAt:  updatedAt,
+			Properties: map[string]any{"species": "Quercus robur"},
+		},
+		{
+			ID:         "2",
+			UpdatedAt:  updatedAt,
+			Properties: map[string]any{"species": "Betula pendula"},
+		},
+	}
+)
+
+func setupApp(t *testing.T) (*fiber.App, *serviceMock.MockOGCService) {
+	app := fiber.New()
+	mockOGCService := serviceMock.NewMockOGCService(t)
+	app.Route("/v1/ogc", func(router fiber.Router) { ogc.RegisterRoutes(router, mockOGCService) })
+	return app, mockOGCService
+}
+
+func decode[T any](t *testing.T, resp *http.Response) T {
+	var result T
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(body, &result))
+	return result
+}
+
+func TestGetLandingPage(t *testing.T) {
+	t.Run("should return links to conformance and collections", func(t *testing.T) {
+		app, _ := setupApp(t)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode[serverEntities.OGCLandingPageResponse](t, resp)
+		rels := map[string]string{}
+		for _, l := range got.Links {
+			rels[l.Rel] = l.Href
+		}
+		assert.Equal(t, "http://example.com/v1/ogc", rels["self"])
+		assert.Equal(t, "http://example.com/v1/ogc/conformance", rels["conformance"])
+		assert.Equal(t, "http://example.com/v1/ogc/collections", rels["data"])
+		assert.Equal(t, "http://example.com/v1/swagger/doc.json", rels["service-desc"])
+	})
+}
+
+func TestGetConformance(t *testing.T) {
+	t.Run("should return core and geojson conformance classes", func(t *testing.T) {
+		app, _ := setupApp(t)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/conformance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode[serverEntities.OGCConformanceResponse](t, resp)
+		assert.Contains(t, got.ConformsTo, "http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/core")
+		assert.Contains(t, got.ConformsTo, "http://www.opengis.net/spec/ogcapi-features-1/1.0/conf/geojson")
+	})
+}
+
+func TestGetCollections(t *testing.T) {
+	t.Run("should return all collections with extent and links", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetCollections(mock.Anything).Return([]*entities.OGCCollection{testCollection}, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode[serverEntities.OGCCollectionListResponse](t, resp)
+		assert.Len(t, got.Collections, 1)
+		assert.Equal(t, "trees", got.Collections[0].ID)
+		assert.Equal(t, [][]float64{{9.4, 54.7, 9.5, 54.9}}, got.Collections[0].Extent.Spatial.BBox)
+		assert.Equal(t, "http://example.com/v1/ogc/collections/trees/items", got.Collections[0].Links[1].Href)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetCollections(mock.Anything).Return(nil, service.NewError(service.InternalError, "failed"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetCollection(t *testing.T) {
+	t.Run("should return collection", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetCollection(mock.Anything, entities.OGCCollectionTrees).Return(testCollection, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode[serverEntities.OGCCollectionResponse](t, resp)
+		assert.Equal(t, "trees", got.ID)
+		assert.Equal(t, "feature", got.ItemType)
+		assert.Equal(t, "http://example.com/v1/ogc/collections/trees", got.Links[0].Href)
+	})
+
+	t.Run("should return 404 when collection is unknown", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetCollection(mock.Anything, entities.OGCCollectionID("vehicles")).Return(nil, service.ErrOGCCollectionNotFound)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/vehicles", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetQueryables(t *testing.T) {
+	t.Run("should return queryables as json schema", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetCollection(mock.Anything, entities.OGCCollectionTrees).Return(testCollection, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees/queryables", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/schema+json", resp.Header.Get(fiber.HeaderContentType))
+		got := decode[serverEntities.OGCQueryablesResponse](t, resp)
+		assert.Equal(t, "object", got.Type)
+		assert.Equal(t, "integer", got.Properties["planting_year"].Type)
+		assert.Equal(t, "string", got.Properties["species"].Type)
+	})
+}
+
+func TestGetFeatures(t *testing.T) {
+	t.Run("should return features with filters and paging links", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		query := &entities.OGCFeatureQuery{
+			BBox:       &entities.BoundingBox{MinLon: 9.4, MinLat: 54.7, MaxLon: 9.5, MaxLat: 54.9},
+			Datetime:   &entities.TimeInterval{Start: &start},
+			Properties: map[string]any{"species": "Quercus robur"},
+			Limit:      2,
+			Offset:     2,
+		}
+		mockOGCService.EXPECT().GetFeatures(mock.Anything, entities.OGCCollectionTrees, query).Return(testFeatures, 5, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			"http://example.com/v1/ogc/collections/trees/items?bbox=9.4,54.7,9.5,54.9&datetime=2025-01-01T00:00:00Z/..&species=Quercus%20robur&limit=2&offset=2", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/geo+json", resp.Header.Get(fiber.HeaderContentType))
+		got := decode[serverEntities.OGCFeatureCollectionResponse](t, resp)
+		assert.Equal(t, "FeatureCollection", got.Type)
+		assert.Equal(t, int64(5), got.NumberMatched)
+		assert.Equal(t, 2, got.NumberReturned)
+		assert.Equal(t, "1", got.Features[0].ID)
+		assert.JSONEq(t, `{"type":"Point","coordinates":[9.48,54.82]}`, string(got.Features[0].Geometry))
+		assert.Equal(t, "null", string(got.Features[1].Geometry))
+		assert.Equal(t, "2025-03-01T08:00:00Z", got.Features[0].Properties["updated_at"])
+
+		rels := map[string]string{}
+		for _, l := range got.Links {
+			rels[l.Rel] = l.Href
+		}
+		assert.Contains(t, rels["next"], "offset=4")
+		assert.Contains(t, rels["next"], "species=Quercus+robur")
+		assert.Contains(t, rels["prev"], "offset=0")
+		assert.Equal(t, "http://example.com/v1/ogc/collections/trees", rels["collection"])
+	})
+
+	t.Run("should not return next link on last page", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetFeatures(mock.Anything, entities.OGCCollectionTrees, &entities.OGCFeatureQuery{Limit: 10}).Return(testFeatures, 2, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees/items", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode[serverEntities.OGCFeatureCollectionResponse](t, resp)
+		for _, l := range got.Links {
+			assert.NotEqual(t, "next", l.Rel)
+			assert.NotEqual(t, "prev", l.Rel)
+		}
+	})
+
+	t.Run("should use instant as datetime interval", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		instant := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+		query := &entities.OGCFeatureQuery{Datetime: &entities.TimeInterval{Start: &instant, End: &instant}, Limit: 10}
+		mockOGCService.EXPECT().GetFeatures(mock.Anything, entities.OGCCollectionTrees, query).Return(nil, 0, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees/items?datetime=2025-03-01T08:00:00Z", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when query is invalid", func(t *testing.T) {
+		for name, query := range map[string]string{
+			"bbox with three values": "bbox=9.4,54.7,9.5",
+			"bbox with text":         "bbox=a,b,c,d",
+			"bbox min above max":     "bbox=9.5,54.9,9.4,54.7",
+			"bbox in other crs":      "bbox=1,2,3,4&bbox-crs=EPSG:25832",
+			"datetime":               "datetime=yesterday",
+			"limit":                  "limit=abc",
+			"offset":                 "offset=-1",
+		} {
+			t.Run(name, func(t *testing.T) {
+				app, _ := setupApp(t)
+
+				// when
+				req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees/items?"+query, nil)
+				resp, err := app.Test(req, -1)
+				defer resp.Body.Close()
+
+				// then
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("should return 400 when property is not queryable", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetFeatures(mock.Anything, entities.OGCCollectionTrees, mock.Anything).Return(nil, 0, service.ErrOGCPropertyUnknown)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees/items?color=green", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestGetFeature(t *testing.T) {
+	t.Run("should return feature with links", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetFeature(mock.Anything, entities.OGCCollectionTrees, "1").Return(testFeatures[0], nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees/items/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/geo+json", resp.Header.Get(fiber.HeaderContentType))
+		got := decode[serverEntities.OGCFeatureResponse](t, resp)
+		assert.Equal(t, "Feature", got.Type)
+		assert.Equal(t, "Quercus robur", got.Properties["species"])
+		assert.Equal(t, "http://example.com/v1/ogc/collections/trees/items/1", got.Links[0].Href)
+		assert.Equal(t, "http://example.com/v1/ogc/collections/trees", got.Links[1].Href)
+	})
+
+	t.Run("should return 404 when feature does not exist", func(t *testing.T) {
+		app, mockOGCService := setupApp(t)
+		mockOGCService.EXPECT().GetFeature(mock.Anything, entities.OGCCollectionTrees, "42").
+			Return(nil, service.NewError(service.NotFound, "feature not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/ogc/collections/trees/items/42", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
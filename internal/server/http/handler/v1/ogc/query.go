@@ -0,0 +1,127 @@
+package ogc
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	crs84 = "http://www.opengis.net/def/crs/OGC/1.3/CRS84"
+	// defaultLimit is set here to build the links to the previous and next page
+	defaultLimit int32 = 10
+)
+
+// reservedParams are the query parameters that are no property filters
+var reservedParams = map[string]bool{
+	"bbox":     true,
+	"bbox-crs": true,
+	"datetime": true,
+	"limit":    true,
+	"offset":   true,
+	"page":     true,
+	"f":        true,
+}
+
+func parseFeatureQuery(c *fiber.Ctx) (*domain.OGCFeatureQuery, error) {
+	query := &domain.OGCFeatureQuery{}
+
+	if bboxCRS := c.Query("bbox-crs"); bboxCRS != "" && bboxCRS != crs84 {
+		return nil, service.NewError(service.BadRequest, "only bbox in CRS84 is supported")
+	}
+
+	bbox, err := spatial.ParseBBox(c.Query("bbox"))
+	if err != nil {
+		return nil, err
+	}
+	query.BBox = bbox
+
+	datetime, err := parseDatetime(c.Query("datetime"))
+	if err != nil {
+		return nil, err
+	}
+	query.Datetime = datetime
+
+	limit, err := parseInt32(c.Query("limit"), "limit")
+	if err != nil {
+		return nil, err
+	}
+	query.Limit = limit
+	if query.Limit == 0 {
+		query.Limit = defaultLimit
+	}
+
+	offset, err := parseInt32(c.Query("offset"), "offset")
+	if err != nil {
+		return nil, err
+	}
+	query.Offset = offset
+
+	for name, value := range c.Queries() {
+		if reservedParams[name] {
+			continue
+		}
+		if query.Properties == nil {
+			query.Properties = make(map[string]any)
+		}
+		query.Properties[strings.Clone(name)] = strings.Clone(value)
+	}
+
+	return query, nil
+}
+
+// parseDatetime parses an RFC 3339 instant or an interval "start/end" where an
+// empty or ".." bound is open. An interval without any bound does not filter.
+func parseDatetime(value string) (*domain.TimeInterval, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	start, end, isInterval := strings.Cut(value, "/")
+	if !isInterval {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "datetime must be a RFC 3339 date time or interval")
+		}
+		return &domain.TimeInterval{Start: &t, End: &t}, nil
+	}
+
+	interval := &domain.TimeInterval{}
+	for _, bound := range []struct {
+		value string
+		dst   **time.Time
+	}{{start, &interval.Start}, {end, &interval.End}} {
+		if bound.value == "" || bound.value == ".." {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "datetime must be a RFC 3339 date time or interval")
+		}
+		*bound.dst = &t
+	}
+
+	if interval.Start == nil && interval.End == nil {
+		return nil, nil
+	}
+
+	return interval, nil
+}
+
+func parseInt32(value, name string) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || v < 0 {
+		return 0, service.NewError(service.BadRequest, name+" must be a positive number")
+	}
+
+	return int32(v), nil
+}
@@ -0,0 +1,16 @@
+package ogc
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.OGCService) {
+	r.Get("/", GetLandingPage())
+	r.Get("/conformance", GetConformance())
+	r.Get("/collections", GetCollections(svc))
+	r.Get("/collections/:collectionId", GetCollection(svc))
+	r.Get("/collections/:collectionId/queryables", GetQueryables(svc))
+	r.Get("/collections/:collectionId/items", GetFeatures(svc))
+	r.Get("/collections/:collectionId/items/:featureId", GetFeature(svc))
+}
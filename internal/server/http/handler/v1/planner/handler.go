@@ -0,0 +1,93 @@
+package planner
+
+import (
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	wateringPlanMapper = generated.WateringPlanHTTPMapperImpl{}
+)
+
+// @Summary		Get watering plan proposals
+// @Description	Propose watering plans for the upcoming days starting tomorrow. The most urgent tree clusters by watering status, last watering, tree age and forecast are assigned to the available transporters and crews with a matching driving license. A proposal can be accepted as it is.
+// @Id				get-watering-plan-proposals
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanProposalListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan/proposals [get]
+// @Param			days	query	int	false	"Number of upcoming days to plan, defaults to the planning horizon"
+// @Security		Keycloak
+func GetWateringPlanProposals(svc service.PlannerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var query domain.WateringPlanProposalQuery
+		if err := c.QueryParser(&query); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+		}
+
+		domainData, err := svc.GetProposals(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WateringPlanProposalListResponse{
+			Data: wateringPlanMapper.FromProposalResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Accept watering plan proposals
+// @Description	Create the watering plans of the accepted proposals. A tree cluster can only be part of one proposal and a vehicle or user of one proposal per day,
+// @Description	including the planned and active watering plans. If a watering plan can not be created, the watering plans created before it are kept
+// @Description	and the proposals have to be loaded again.
+// @Id				accept-watering-plan-proposals
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		201	{object}	entities.WateringPlanListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan/proposals [post]
+// @Param			body	body	[]entities.WateringPlanCreateRequest	true	"Accepted watering plan proposals"
+// @Security		Keycloak
+func AcceptWateringPlanProposals(svc service.PlannerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req []*entities.WateringPlanCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := make([]*domain.WateringPlanCreate, len(req))
+		for i, plan := range req {
+			domainReq[i] = wateringPlanMapper.FromCreateRequest(plan)
+		}
+
+		domainData, err := svc.AcceptProposals(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]*entities.WateringPlanInListResponse, len(domainData))
+		for i, wp := range domainData {
+			data[i] = wateringPlanMapper.FromInListResponse(wp)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entities.WateringPlanListResponse{
+			Data: data,
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package planner_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/planner"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWateringPlanProposals(t *testing.T) {
+	t.Run("should return watering plan proposals", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockPlannerService := serviceMock.NewMockPlannerService(t)
+		app.Get("/v1/watering-plan/proposals", planner.GetWateringPlanProposals(mockPlannerService))
+
+		mockPlannerService.EXPECT().GetProposals(mock.Anything, entities.WateringPlanProposalQuery{Days: 2}).Return(TestProposals, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan/proposals?days=2", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringPlanProposalListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+
+		got := response.Data[0]
+		assert.Equal(t, TestProposals[0].Plan.Date, got.Date)
+		assert.Equal(t, TestProposals[0].Plan.TreeClusterIDs, got.TreeClusterIDs)
+		assert.Equal(t, TestProposals[0].Plan.TransporterID, got.TransporterID)
+		assert.Equal(t, TestProposals[0].Plan.UserIDs, got.UserIDs)
+		assert.Equal(t, 1.5, got.Score)
+		assert.Equal(t, TestTransporter.NumberPlate, got.Transporter.NumberPlate)
+		assert.Len(t, got.TreeClusters, 2)
+		assert.Equal(t, int32(1), got.TreeClusters[0].ID)
+		assert.Equal(t, "Solitüde Strand", got.TreeClusters[0].Name)
+		assert.Equal(t, serverEntities.WateringStatusBad, got.TreeClusters[0].WateringStatus)
+		assert.Equal(t, 0.9, got.TreeClusters[0].Score)
+		assert.Equal(t, TestProposals[0].TreeClusters[0].BadAt, got.TreeClusters[0].BadAt)
+		assert.Equal(t, utils.P(12.5), got.Distance)
+		assert.Equal(t, utils.DurationToPtrFloat64(TestProposals[0].Route.Time), got.Duration)
+		assert.Equal(t, utils.P(int32(1)), got.RefillCount)
+
+		assert.Nil(t, response.Data[1].Distance)
+		assert.Nil(t, response.Data[1].Duration)
+		assert.Nil(t, response.Data[1].RefillCount)
+	})
+
+	t.Run("should use the planning horizon without days", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockPlannerService := serviceMock.NewMockPlannerService(t)
+		app.Get("/v1/watering-plan/proposals", planner.GetWateringPlanProposals(mockPlannerService))
+
+		mockPlannerService.EXPECT().GetProposals(mock.Anything, entities.WateringPlanProposalQuery{}).Return([]*entities.WateringPlanProposal{}, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan/proposals", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringPlanProposalListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Empty(t, response.Data)
+	})
+
+	t.Run("should return 400 for invalid days", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockPlannerService := serviceMock.NewMockPlannerService(t)
+		app.Get("/v1/watering-plan/proposals", planner.GetWateringPlanProposals(mockPlannerService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan/proposals?days=abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when days are out of the planning horizon", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockPlannerService := serviceMock.NewMockPlannerService(t)
+		app.Get("/v1/watering-plan/proposals", planner.GetWateringPlanProposals(mockPlannerService))
+
+		mockPlannerService.EXPECT().GetProposals(mock.Anything, entities.WateringPlanProposalQuery{Days: 30}).Return(nil, service.ErrProposalQueryInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan/proposals?days=30", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestAcceptWateringPlanProposals(t *testing.T) {
+	t.Run("should create watering plans of the proposals as returned", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockPlannerService := serviceMock.NewMockPlannerService(t)
+		app.Get("/v1/watering-plan/proposals", planner.GetWateringPlanProposals(mockPlannerService))
+		app.Post("/v1/watering-plan/proposals", planner.AcceptWateringPlanProposals(mockPlannerService))
+
+		mockPlannerService.EXPECT().GetProposals(mock.Anything, entities.WateringPlanProposalQuery{}).Return(TestProposals[:1], nil)
+		mockPlannerService.EXPECT().AcceptProposals(
+			mock.Anything,
+			mock.MatchedBy(func(plans []*entities.WateringPlanCreate) bool {
+				return len(plans) == 1 &&
+					plans[0].Date.Equal(TestProposals[0].Plan.Date) &&
+					assert.ObjectsAreEqual(TestProposals[0].Plan.TreeClusterIDs, plans[0].TreeClusterIDs) &&
+					assert.ObjectsAreEqual(TestProposals[0].Plan.TransporterID, plans[0].TransporterID) &&
+					assert.ObjectsAreEqual(TestProposals[0].Plan.UserIDs, plans[0].UserIDs)
+			}),
+		).Return(TestWateringPlans, nil)
+
+		getReq, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan/proposals", nil)
+		getResp, err := app.Test(getReq, -1)
+		assert.NoError(t, err)
+		defer getResp.Body.Close()
+		var proposals serverEntities.WateringPlanProposalListResponse
+		assert.NoError(t, utils.ParseJSONResponse(getResp, &proposals))
+		body, _ := json.Marshal(proposals.Data)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-plan/proposals", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.WateringPlanListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, TestWateringPlans[0].ID, response.Data[0].ID)
+		assert.Len(t, response.Data[0].TreeClusters, 2)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockPlannerService := serviceMock.NewMockPlannerService(t)
+		app.Post("/v1/watering-plan/proposals", planner.AcceptWateringPlanProposals(mockPlannerService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-plan/proposals", bytes.NewBufferString(`{"date": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when proposals are in conflict", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockPlannerService := serviceMock.NewMockPlannerService(t)
+		app.Post("/v1/watering-plan/proposals", planner.AcceptWateringPlanProposals(mockPlannerService))
+
+		mockPlannerService.EXPECT().AcceptProposals(mock.Anything, mock.Anything).Return(nil, service.ErrProposalConflict)
+
+		// when
+		body := `[{"date": "2025-07-03T00:00:00Z", "tree_cluster_ids": [1], "transporter_id": 1, "user_ids": ["6a1078e8-80fd-458f-b74e-e388fe2dd6ab"]},` +
+			`{"date": "2025-07-04T00:00:00Z", "tree_cluster_ids": [1], "transporter_id": 1, "user_ids": ["6a1078e8-80fd-458f-b74e-e388fe2dd6ab"]}]`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-plan/proposals", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
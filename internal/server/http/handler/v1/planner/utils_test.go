@@ -0,0 +1,77 @@
+package planner_test
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	TestUserID = uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+
+	TestTransporter = &entities.Vehicle{
+		ID:             1,
+		NumberPlate:    "FL ZB 9876",
+		WaterCapacity:  2000,
+		Status:         entities.VehicleStatusAvailable,
+		Type:           entities.VehicleTypeTransporter,
+		DrivingLicense: entities.DrivingLicenseB,
+	}
+
+	TestProposals = []*entities.WateringPlanProposal{
+		{
+			Plan: entities.WateringPlanCreate{
+				Date:           time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC),
+				TreeClusterIDs: []*int32{utils.P(int32(1)), utils.P(int32(3))},
+				TransporterID:  utils.P(int32(1)),
+				UserIDs:        []*uuid.UUID{&TestUserID},
+			},
+			TreeClusters: []*entities.TreeClusterUrgency{
+				{
+					TreeCluster: &entities.TreeCluster{ID: 1, Name: "Solitüde Strand", WateringStatus: entities.WateringStatusBad},
+					Score:       0.9,
+					BadAt:       utils.P(time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					TreeCluster: &entities.TreeCluster{ID: 3, Name: "Flensburger Stadion", WateringStatus: entities.WateringStatusModerate},
+					Score:       0.6,
+				},
+			},
+			Transporter: TestTransporter,
+			Score:       1.5,
+			Route:       &entities.RouteMetadata{Distance: 12.5, Refills: 1, Time: 2 * time.Hour},
+		},
+		{
+			Plan: entities.WateringPlanCreate{
+				Date:           time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC),
+				TreeClusterIDs: []*int32{utils.P(int32(2))},
+				TransporterID:  utils.P(int32(1)),
+				UserIDs:        []*uuid.UUID{&TestUserID},
+			},
+			TreeClusters: []*entities.TreeClusterUrgency{
+				{
+					TreeCluster: &entities.TreeCluster{ID: 2, Name: "Sankt-Jürgen-Platz", WateringStatus: entities.WateringStatusUnknown},
+					Score:       0.5,
+				},
+			},
+			Transporter: TestTransporter,
+			Score:       0.5,
+		},
+	}
+
+	TestWateringPlans = []*entities.WateringPlan{
+		{
+			ID:          1,
+			Date:        time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC),
+			Status:      entities.WateringPlanStatusPlanned,
+			Transporter: TestTransporter,
+			UserIDs:     []*uuid.UUID{&TestUserID},
+			TreeClusters: []*entities.TreeCluster{
+				{ID: 1, Name: "Solitüde Strand"},
+				{ID: 3, Name: "Flensburger Stadion"},
+			},
+		},
+	}
+)
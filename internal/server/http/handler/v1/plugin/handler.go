@@ -1,40 +1,40 @@
 package plugin
 
 import (
-	"log"
+	"errors"
 	"log/slog"
-	"net/http/httputil"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	golangJwt "github.com/golang-jwt/jwt/v5"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
 )
 
-func getPluginFiles(svc service.PluginService) fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
-		pluginParam := strings.Clone(c.Params("plugin"))
-		plugin, err := svc.Get(ctx, pluginParam)
-		if err != nil {
-			return err
-		}
+// HeaderAPIVersion contains the host api version negotiated on registration
+const HeaderAPIVersion = "X-GE-API-Version"
 
-		reverseProxy := httputil.ReverseProxy{
-			Rewrite: func(r *httputil.ProxyRequest) {
-				r.SetURL(&plugin.Path)
-				r.Out.Host = r.In.Host
-				r.Out.URL.Path = strings.Replace(r.In.URL.Path, "/api/v1/plugin/"+plugin.Slug, plugin.Path.String(), 1)
-				r.SetXForwarded()
-			},
-		}
-
-		return adaptor.HTTPHandler(&reverseProxy)(c)
+// @Summary		Get the capabilities of the plugin host
+// @Description	Get the api versions and features supported by the plugin host. Plugins can use it to check their compatibility before registering.
+// @Id				get-plugin-host-capabilities
+// @Tags			Plugin
+// @Produce		json
+// @Success		200	{object}	entities.PluginHostCapabilitiesResponse
+// @Router			/v1/plugin/capabilities [get]
+func GetHostCapabilities() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(entities.PluginHostCapabilitiesResponse{
+			APIVersions: domain.PluginHostAPIVersions,
+			Features:    utils.Map(domain.PluginHostFeatures, mapPluginFeatureResponse),
+		})
 	}
 }
 
@@ -44,10 +44,12 @@ func getPluginFiles(svc service.PluginService) fiber.Handler {
 // @Tags			Plugin
 // @Produce		json
 // @Success		200	{object}	entities.ClientTokenResponse
+// @Header			200	{string}	X-GE-API-Version	"Negotiated host api version"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		409	{object}	entities.PluginIncompatibleResponse
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/plugin [post]
 //
@@ -72,11 +74,15 @@ func RegisterPlugin(svc service.PluginService) fiber.Handler {
 		}
 
 		plugin := &domain.Plugin{
-			Name:        req.Name,
-			Path:        *path,
-			Version:     req.Version,
-			Description: req.Description,
-			Slug:        req.Slug,
+			Name:         req.Name,
+			Path:         *path,
+			Version:      req.Version,
+			Description:  req.Description,
+			Slug:         req.Slug,
+			Scopes:       utils.Map(req.Scopes, mapPluginScope),
+			Manifest:     mapPluginManifest(req.Manifest),
+			Proxy:        mapPluginProxy(req.Proxy),
+			Requirements: mapPluginRequirements(req.Requirements),
 			Auth: domain.AuthPlugin{
 				ClientID:     req.Auth.ClientID,
 				ClientSecret: req.Auth.ClientSecret,
@@ -86,9 +92,15 @@ func RegisterPlugin(svc service.PluginService) fiber.Handler {
 		token, err := svc.Register(c.Context(), plugin)
 		if err != nil {
 			slog.Error("Failed to register plugin", "error", err)
-			return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+			var incompatibleErr service.PluginIncompatibleError
+			if errors.As(err, &incompatibleErr) {
+				return c.Status(fiber.StatusConflict).JSON(mapPluginIncompatibleResponse(&incompatibleErr))
+			}
+			return errorhandler.HandleError(err)
 		}
 
+		c.Set(HeaderAPIVersion, plugin.APIVersion)
+
 		response := entities.ClientTokenResponse{
 			AccessToken:  token.AccessToken,
 			ExpiresIn:    token.ExpiresIn,
@@ -102,12 +114,13 @@ func RegisterPlugin(svc service.PluginService) fiber.Handler {
 }
 
 // @Summary		Unregister a plugin
-// @Description	Unregister a plugin
+// @Description	Unregister a plugin. Only the client the plugin is granted for and admins can unregister it.
 // @Id				unregister-plugin
 // @Tags			Plugin
 // @Produce		json
 // @Success		204
 // @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/plugin/{plugin_slug}/unregister [post]
@@ -117,14 +130,20 @@ func UnregisterPlugin(svc service.PluginService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
 		slug := strings.Clone(c.Params("plugin"))
-		svc.Unregister(ctx, slug)
+		if err := checkPluginClient(c, svc, slug); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.Unregister(ctx, slug); err != nil {
+			return errorhandler.HandleError(err)
+		}
 
 		return c.SendStatus(fiber.StatusNoContent)
 	}
 }
 
 // @Summary		Heartbeat for a plugin
-// @Description	Heartbeat for a plugin
+// @Description	Heartbeat for a plugin. Only the client the plugin is granted for and admins can send it.
 // @Id				plugin-heartbeat
 // @Tags			Plugin
 // @Produce		json
@@ -141,6 +160,10 @@ func PluginHeartbeat(svc service.PluginService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
 		slug := strings.Clone(c.Params("plugin"))
+		if err := checkPluginClient(c, svc, slug); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
 		if err := svc.HeartBeat(ctx, slug); err != nil {
 			slog.Error("Failed to heartbeat", "plugin", slug, "error", err)
 			return c.Status(fiber.StatusBadRequest).SendString(err.Error())
@@ -151,7 +174,7 @@ func PluginHeartbeat(svc service.PluginService) fiber.Handler {
 }
 
 // @Summary		Get a list of all registered plugins
-// @Description	Get a list of all registered plugins
+// @Description	Get a list of all registered plugins. A plugin whose manifest has roles is only listed for users with one of these roles.
 // @Id				get-plugins-list
 // @Tags			Plugin
 // @Produce		json
@@ -166,20 +189,18 @@ func PluginHeartbeat(svc service.PluginService) fiber.Handler {
 func GetPluginsList(svc service.PluginService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
-		p, h := svc.GetAll(ctx)
-		log.Println(h)
-		plugins := utils.Map(p, func(plugin domain.Plugin) entities.PluginResponse {
-			return entities.PluginResponse{
-				Slug:        plugin.Slug,
-				Name:        plugin.Name,
-				Version:     plugin.Version,
-				Description: plugin.Description,
-				HostPath:    plugin.Path.String(),
-			}
+		plugins, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		// plugins the caller has no role for are not shown
+		plugins = utils.Filter(plugins, func(plugin domain.Plugin) bool {
+			return hasPluginRole(c, &plugin)
 		})
 
 		return c.Status(fiber.StatusOK).JSON(entities.PluginListResponse{
-			Plugins: plugins,
+			Plugins: utils.Map(plugins, mapPluginResponse),
 		})
 	}
 }
@@ -207,13 +228,11 @@ func GetPluginInfo(svc service.PluginService) fiber.Handler {
 			return c.Status(fiber.StatusNotFound).SendString("plugin not found")
 		}
 
-		return c.Status(fiber.StatusOK).JSON(entities.PluginResponse{
-			Slug:        plugin.Slug,
-			Name:        plugin.Name,
-			Version:     plugin.Version,
-			Description: plugin.Description,
-			HostPath:    plugin.Path.String(),
-		})
+		if !hasPluginRole(c, &plugin) {
+			return errorhandler.HandleError(service.ErrPluginRoleRequired)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(mapPluginResponse(plugin))
 	}
 }
 
@@ -262,3 +281,265 @@ func RefreshToken(svc service.PluginService) fiber.Handler {
 		return c.Status(fiber.StatusOK).JSON(response)
 	}
 }
+
+// @Summary		Get the registration history of a plugin
+// @Description	Get the register, unregister and timeout events of a plugin
+// @Id				get-plugin-history
+// @Tags			Plugin
+// @Produce		json
+// @Success		200	{object}	entities.PluginEventListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/plugin/{plugin_slug}/history [get]
+// @Param			plugin_slug	path	string	true	"Slug of the plugin"
+// @Security		Keycloak
+func GetPluginHistory(svc service.PluginService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		slug := strings.Clone(c.Params("plugin"))
+		events, err := svc.GetHistory(ctx, slug)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(entities.PluginEventListResponse{
+			Events: utils.Map(events, mapPluginEventResponse),
+		})
+	}
+}
+
+// @Summary		Get all plugin grants
+// @Description	Get all plugin grants of the allow-list. A grant binds a plugin slug to a client id and the scopes the plugin may request. Requires the admin role.
+// @Id				get-plugin-grants
+// @Tags			Plugin
+// @Produce		json
+// @Success		200	{object}	entities.PluginGrantListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/plugin/grants [get]
+// @Security		Keycloak
+func GetPluginGrants(svc service.PluginService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		grants, err := svc.GetAllGrants(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(entities.PluginGrantListResponse{
+			Grants: utils.Map(grants, mapPluginGrantResponse),
+		})
+	}
+}
+
+// @Summary		Create or update a plugin grant
+// @Description	Create or update a plugin grant. Only the granted client is allowed to register the plugin with a subset of the granted scopes. Requires the admin role.
+// @Id				save-plugin-grant
+// @Tags			Plugin
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	entities.PluginGrantResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/plugin/grants [post]
+// @Param			body	body	entities.PluginGrantRequest	true	"Plugin grant"
+// @Security		Keycloak
+func SavePluginGrant(svc service.PluginService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		var req entities.PluginGrantRequest
+		if err := c.BodyParser(&req); err != nil {
+			err = service.NewError(service.BadRequest, err.Error())
+			return errorhandler.HandleError(err)
+		}
+
+		grant, err := svc.SaveGrant(ctx, &domain.PluginGrant{
+			Slug:     req.Slug,
+			ClientID: req.ClientID,
+			Scopes:   utils.Map(req.Scopes, mapPluginScope),
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(mapPluginGrantResponse(grant))
+	}
+}
+
+// @Summary		Delete a plugin grant
+// @Description	Delete a plugin grant. A registered plugin with this slug will be unregistered. Requires the admin role.
+// @Id				delete-plugin-grant
+// @Tags			Plugin
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/plugin/grants/{plugin_slug} [delete]
+// @Param			plugin_slug	path	string	true	"Slug of the plugin"
+// @Security		Keycloak
+func DeletePluginGrant(svc service.PluginService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		slug := strings.Clone(c.Params("plugin"))
+		if err := svc.DeleteGrant(ctx, slug); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func mapPluginScope(scope string) domain.PluginScope {
+	return domain.PluginScope(scope)
+}
+
+func mapPluginScopeResponse(scope domain.PluginScope) string {
+	return string(scope)
+}
+
+func mapPluginResponse(plugin domain.Plugin) entities.PluginResponse {
+	return entities.PluginResponse{
+		Slug:        plugin.Slug,
+		Name:        plugin.Name,
+		Version:     plugin.Version,
+		Description: plugin.Description,
+		HostPath:    plugin.Path.String(),
+		Scopes:      utils.Map(plugin.Scopes, mapPluginScopeResponse),
+		Manifest: entities.PluginManifest{
+			Entry:     plugin.Manifest.Entry,
+			MenuLabel: plugin.Manifest.MenuLabel,
+			Icon:      plugin.Manifest.Icon,
+			Roles: utils.Map(plugin.Manifest.Roles, func(r domain.UserRole) string {
+				return string(r)
+			}),
+		},
+		Proxy: entities.PluginProxy{
+			TimeoutMs:   plugin.Proxy.Timeout.Milliseconds(),
+			MaxBodySize: plugin.Proxy.MaxBodySize,
+		},
+		APIVersion: plugin.APIVersion,
+		Requirements: entities.PluginRequirements{
+			MinAPIVersion: plugin.Requirements.MinAPIVersion,
+			MaxAPIVersion: plugin.Requirements.MaxAPIVersion,
+			Features:      utils.Map(plugin.Requirements.Features, mapPluginFeatureResponse),
+		},
+		LastHeartbeat: plugin.LastHeartbeat,
+	}
+}
+
+func mapPluginRequirements(requirements *entities.PluginRequirements) domain.PluginRequirements {
+	if requirements == nil {
+		return domain.PluginRequirements{Features: []domain.PluginFeature{}}
+	}
+
+	return domain.PluginRequirements{
+		MinAPIVersion: requirements.MinAPIVersion,
+		MaxAPIVersion: requirements.MaxAPIVersion,
+		Features: utils.Map(requirements.Features, func(f string) domain.PluginFeature {
+			return domain.PluginFeature(f)
+		}),
+	}
+}
+
+func mapPluginFeatureResponse(feature domain.PluginFeature) string {
+	return string(feature)
+}
+
+func mapPluginIncompatibleResponse(err *service.PluginIncompatibleError) entities.PluginIncompatibleResponse {
+	return entities.PluginIncompatibleResponse{
+		Error:           err.Reason,
+		APIVersions:     domain.PluginHostAPIVersions,
+		Features:        utils.Map(domain.PluginHostFeatures, mapPluginFeatureResponse),
+		MissingFeatures: utils.Map(err.MissingFeatures, mapPluginFeatureResponse),
+	}
+}
+
+func mapPluginManifest(manifest *entities.PluginManifest) domain.PluginManifest {
+	if manifest == nil {
+		return domain.PluginManifest{Roles: []domain.UserRole{}}
+	}
+
+	return domain.PluginManifest{
+		Entry:     manifest.Entry,
+		MenuLabel: manifest.MenuLabel,
+		Icon:      manifest.Icon,
+		Roles: utils.Map(manifest.Roles, func(r string) domain.UserRole {
+			return domain.UserRole(r)
+		}),
+	}
+}
+
+func mapPluginProxy(proxy *entities.PluginProxy) domain.PluginProxy {
+	if proxy == nil {
+		return domain.PluginProxy{}
+	}
+
+	return domain.PluginProxy{
+		Timeout:     time.Duration(proxy.TimeoutMs) * time.Millisecond,
+		MaxBodySize: proxy.MaxBodySize,
+	}
+}
+
+func mapPluginGrantResponse(grant *domain.PluginGrant) entities.PluginGrantResponse {
+	return entities.PluginGrantResponse{
+		Slug:      grant.Slug,
+		ClientID:  grant.ClientID,
+		Scopes:    utils.Map(grant.Scopes, mapPluginScopeResponse),
+		CreatedAt: grant.CreatedAt,
+		UpdatedAt: grant.UpdatedAt,
+	}
+}
+
+func mapPluginEventResponse(event *domain.PluginEvent) entities.PluginEventResponse {
+	return entities.PluginEventResponse{
+		ID:         event.ID,
+		CreatedAt:  event.CreatedAt,
+		PluginSlug: event.PluginSlug,
+		Type:       string(event.Type),
+		Version:    event.Version,
+	}
+}
+
+// checkPluginClient allows the lifecycle requests of a plugin only to the client the plugin is granted for and to admins.
+// Requests without claims pass because the authentication is disabled.
+func checkPluginClient(c *fiber.Ctx, svc service.PluginService, slug string) error {
+	claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+	if !ok || middleware.IsAdmin(claims) {
+		return nil
+	}
+
+	clientID, _ := claims["azp"].(string)
+	return svc.CheckClient(c.Context(), clientID, slug)
+}
+
+// hasPluginRole reports whether the user has one of the roles of the plugin manifest. Plugins without roles are
+// open to every user, admins see all plugins. Api keys have no roles and requests without claims pass because
+// the authentication is disabled.
+func hasPluginRole(c *fiber.Ctx, plugin *domain.Plugin) bool {
+	if len(plugin.Manifest.Roles) == 0 {
+		return true
+	}
+
+	if _, ok := c.UserContext().Value(enums.ContextKeyAPIKey).(*domain.APIKey); ok {
+		return false
+	}
+
+	claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+	if !ok || middleware.IsAdmin(claims) {
+		return true
+	}
+
+	roles := middleware.RealmRoles(claims)
+	return slices.ContainsFunc(plugin.Manifest.Roles, func(role domain.UserRole) bool {
+		return slices.Contains(roles, string(role))
+	})
+}
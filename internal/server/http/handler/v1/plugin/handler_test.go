@@ -0,0 +1,240 @@
+package plugin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/plugin"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetHostCapabilities(t *testing.T) {
+	t.Run("should return api versions and features of the host", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/v1/plugin/capabilities", plugin.GetHostCapabilities())
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/plugin/capabilities", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.PluginHostCapabilitiesResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.PluginHostAPIVersions, response.APIVersions)
+		assert.Contains(t, response.Features, string(entities.PluginFeatureWebhooks))
+	})
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	t.Run("should return negotiated api version", func(t *testing.T) {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		app.Post("/v1/plugin/register", plugin.RegisterPlugin(mockPluginService))
+
+		mockPluginService.EXPECT().Register(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, p *entities.Plugin) (*entities.ClientToken, error) {
+				p.APIVersion = "v1"
+				return &entities.ClientToken{AccessToken: "access-token"}, nil
+			})
+
+		// when
+		resp, err := app.Test(registerRequest(t), -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "v1", resp.Header.Get(plugin.HeaderAPIVersion))
+	})
+
+	t.Run("should return 409 with host capabilities when plugin is incompatible", func(t *testing.T) {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		app.Post("/v1/plugin/register", plugin.RegisterPlugin(mockPluginService))
+
+		mockPluginService.EXPECT().Register(mock.Anything, mock.Anything).Return(nil, service.PluginIncompatibleError{
+			PluginIncompatibility: entities.PluginIncompatibility{
+				Reason:          "plugin requires features that are not supported by the host",
+				MissingFeatures: []entities.PluginFeature{"teleport"},
+			},
+		})
+
+		// when
+		resp, err := app.Test(registerRequest(t), -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		var response serverEntities.PluginIncompatibleResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"teleport"}, response.MissingFeatures)
+		assert.Equal(t, entities.PluginHostAPIVersions, response.APIVersions)
+	})
+}
+
+func TestGetPluginsList(t *testing.T) {
+	withRoles := func(roles ...any) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			claims := golangJwt.MapClaims{"realm_access": map[string]any{"roles": roles}}
+			c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+			return c.Next()
+		}
+	}
+	plugins := []entities.Plugin{
+		{Slug: "open"},
+		{Slug: "tbz-only", Manifest: entities.PluginManifest{Roles: []entities.UserRole{entities.UserRoleTbz}}},
+		{Slug: "grenzregion-only", Manifest: entities.PluginManifest{Roles: []entities.UserRole{entities.UserRoleSmarteGrenzregion}}},
+	}
+	listSlugs := func(t *testing.T, roles ...any) []string {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		app.Get("/v1/plugin", withRoles(roles...), plugin.GetPluginsList(mockPluginService))
+		mockPluginService.EXPECT().GetAll(mock.Anything).Return(plugins, nil)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/plugin", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.PluginListResponse
+		assert.NoError(t, utils.ParseJSONResponse(resp, &response))
+		return utils.Map(response.Plugins, func(p serverEntities.PluginResponse) string { return p.Slug })
+	}
+
+	t.Run("should only list plugins the user has a role for", func(t *testing.T) {
+		// when
+		got := listSlugs(t, "tbz")
+
+		// then
+		assert.Equal(t, []string{"open", "tbz-only"}, got)
+	})
+
+	t.Run("should list all plugins for admins", func(t *testing.T) {
+		// when
+		got := listSlugs(t, "admin")
+
+		// then
+		assert.Equal(t, []string{"open", "tbz-only", "grenzregion-only"}, got)
+	})
+}
+
+func TestPluginLifecycle(t *testing.T) {
+	withClaims := func(claims golangJwt.MapClaims) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+			return c.Next()
+		}
+	}
+
+	t.Run("should reject heartbeat of a foreign plugin", func(t *testing.T) {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		app.Post("/v1/plugin/:plugin/heartbeat", withClaims(golangJwt.MapClaims{"azp": "other-client"}), plugin.PluginHeartbeat(mockPluginService))
+
+		mockPluginService.EXPECT().CheckClient(mock.Anything, "other-client", "csv-import").Return(service.ErrPluginNotGranted)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/plugin/csv-import/heartbeat", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		mockPluginService.AssertNotCalled(t, "HeartBeat", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject unregister of a foreign plugin", func(t *testing.T) {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		app.Post("/v1/plugin/:plugin/unregister", withClaims(golangJwt.MapClaims{"azp": "other-client"}), plugin.UnregisterPlugin(mockPluginService))
+
+		mockPluginService.EXPECT().CheckClient(mock.Anything, "other-client", "csv-import").Return(service.ErrPluginNotGranted)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/plugin/csv-import/unregister", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		mockPluginService.AssertNotCalled(t, "Unregister", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should accept heartbeat of the own plugin", func(t *testing.T) {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		app.Post("/v1/plugin/:plugin/heartbeat", withClaims(golangJwt.MapClaims{"azp": "csv-import-client"}), plugin.PluginHeartbeat(mockPluginService))
+
+		mockPluginService.EXPECT().CheckClient(mock.Anything, "csv-import-client", "csv-import").Return(nil)
+		mockPluginService.EXPECT().HeartBeat(mock.Anything, "csv-import").Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/plugin/csv-import/heartbeat", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should let admins unregister any plugin", func(t *testing.T) {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		claims := golangJwt.MapClaims{"azp": "frontend", "realm_access": map[string]any{"roles": []any{"admin"}}}
+		app.Post("/v1/plugin/:plugin/unregister", withClaims(claims), plugin.UnregisterPlugin(mockPluginService))
+
+		mockPluginService.EXPECT().Unregister(mock.Anything, "csv-import").Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/plugin/csv-import/unregister", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func registerRequest(t *testing.T) *http.Request {
+	body, err := json.Marshal(serverEntities.PluginRegisterRequest{
+		Slug:    "csv-import",
+		Name:    "CSV Import",
+		Version: "v1.0.0",
+		Path:    "http://localhost:8080/plugins/csv-import",
+		Requirements: &serverEntities.PluginRequirements{
+			MinAPIVersion: "v1",
+			Features:      []string{"teleport"},
+		},
+		Auth: serverEntities.PluginAuth{ClientID: "csv-import-client", ClientSecret: "secret"},
+	})
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/plugin/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
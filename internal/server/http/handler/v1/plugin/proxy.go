@@ -0,0 +1,220 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	defaultProxyTimeout     = 10 * time.Second
+	maxProxyTimeout         = 60 * time.Second
+	defaultProxyMaxBodySize = 10 << 20
+	maxProxyMaxBodySize     = 50 << 20
+
+	breakerThreshold = 5
+	breakerCooldown  = 30 * time.Second
+)
+
+var (
+	errPluginResponseTooLarge = errors.New("plugin response exceeds the size limit")
+
+	// headers of the user that must not be forwarded to the plugin
+	strippedRequestHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+	// headers of the plugin that must not be forwarded to the user
+	strippedResponseHeaders = []string{"Set-Cookie", "Server", "X-Powered-By", "Access-Control-Allow-Origin", "Access-Control-Allow-Credentials"}
+)
+
+// circuitBreaker opens after breakerThreshold consecutive failures and rejects
+// requests until breakerCooldown has passed. After the cooldown one request is
+// let through; if it fails the breaker opens again.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	now       func() time.Time
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.now().Before(b.openUntil) {
+		return false
+	}
+
+	if b.failures >= breakerThreshold {
+		// half-open: let this request through but block the next ones until it reports back
+		b.openUntil = b.now().Add(breakerCooldown)
+	}
+	return true
+}
+
+func (b *circuitBreaker) report(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if success {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.failures >= breakerThreshold {
+		b.openUntil = b.now().Add(breakerCooldown)
+	}
+}
+
+type pluginProxy struct {
+	transport http.RoundTripper
+	now       func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func newPluginProxy(transport http.RoundTripper) *pluginProxy {
+	return &pluginProxy{
+		transport: transport,
+		now:       time.Now,
+		breakers:  make(map[string]*circuitBreaker),
+	}
+}
+
+func (p *pluginProxy) breaker(slug string) *circuitBreaker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b, ok := p.breakers[slug]
+	if !ok {
+		b = &circuitBreaker{now: p.now}
+		p.breakers[slug] = b
+	}
+	return b
+}
+
+// handler returns the reverse proxy for the plugin. Every response with a status >= 500
+// or a transport error is counted as a failure of the plugin.
+func (p *pluginProxy) handler(plugin *domain.Plugin, breaker *circuitBreaker) http.Handler {
+	timeout := clamp(plugin.Proxy.Timeout, defaultProxyTimeout, maxProxyTimeout)
+	maxBodySize := clamp(plugin.Proxy.MaxBodySize, defaultProxyMaxBodySize, maxProxyMaxBodySize)
+
+	reverseProxy := &httputil.ReverseProxy{
+		Transport: p.transport,
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(&plugin.Path)
+			r.Out.Host = r.In.Host
+			r.Out.URL.Path = strings.Replace(r.In.URL.Path, "/api/v1/plugin/"+plugin.Slug, plugin.Path.String(), 1)
+			for _, header := range strippedRequestHeaders {
+				r.Out.Header.Del(header)
+			}
+			r.SetXForwarded()
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			if resp.ContentLength > maxBodySize {
+				return errPluginResponseTooLarge
+			}
+			if resp.ContentLength < 0 {
+				// the size of a chunked response is only known after reading it, buffer it so that an
+				// oversized response is rejected before the status is sent
+				body, err := readLimited(resp.Body, maxBodySize)
+				if err != nil {
+					return err
+				}
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			for _, header := range strippedResponseHeaders {
+				resp.Header.Del(header)
+			}
+			resp.Header.Set("X-Content-Type-Options", "nosniff")
+
+			breaker.report(resp.StatusCode < http.StatusInternalServerError)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			slog.Warn("failed to proxy request to plugin", "plugin", plugin.Slug, "path", r.URL.Path, "error", err)
+			if errors.Is(err, errPluginResponseTooLarge) {
+				breaker.report(true)
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+
+			breaker.report(false)
+			if errors.Is(err, context.DeadlineExceeded) {
+				http.Error(w, "plugin did not respond in time", http.StatusGatewayTimeout)
+				return
+			}
+			http.Error(w, "plugin is not reachable", http.StatusBadGateway)
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		reverseProxy.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getPluginFiles(svc service.PluginService) fiber.Handler {
+	return getPluginFilesWithProxy(svc, newPluginProxy(http.DefaultTransport))
+}
+
+func getPluginFilesWithProxy(svc service.PluginService, proxy *pluginProxy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		pluginParam := strings.Clone(c.Params("plugin"))
+		plugin, err := svc.Get(ctx, pluginParam)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		if !hasPluginRole(c, &plugin) {
+			return errorhandler.HandleError(service.ErrPluginRoleRequired)
+		}
+
+		// a plugin that missed its heartbeat is not proxied anymore, even before the cleanup removed it
+		if proxy.now().Sub(plugin.LastHeartbeat) > svc.HeartbeatTimeout() {
+			return fiber.NewError(fiber.StatusServiceUnavailable, fmt.Sprintf("plugin %s is not healthy", plugin.Slug))
+		}
+
+		breaker := proxy.breaker(plugin.Slug)
+		if !breaker.allow() {
+			return fiber.NewError(fiber.StatusServiceUnavailable, fmt.Sprintf("plugin %s is temporarily unavailable", plugin.Slug))
+		}
+
+		return adaptor.HTTPHandler(proxy.handler(&plugin, breaker))(c)
+	}
+}
+
+// readLimited reads and closes the body. It fails if the body is larger than limit bytes.
+func readLimited(body io.ReadCloser, limit int64) ([]byte, error) {
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, errPluginResponseTooLarge
+	}
+	return data, nil
+}
+
+func clamp[T int64 | time.Duration](value, fallback, maxValue T) T {
+	if value <= 0 {
+		return fallback
+	}
+	return min(value, maxValue)
+}
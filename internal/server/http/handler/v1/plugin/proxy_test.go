@@ -0,0 +1,298 @@
+package plugin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPluginFiles(t *testing.T) {
+	t.Run("should proxy request and sanitize headers", func(t *testing.T) {
+		var gotReq *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotReq = r
+			w.Header().Set("Set-Cookie", "session=plugin")
+			w.Header().Set("Server", "plugin-server")
+			_, _ = w.Write([]byte("console.log('plugin')"))
+		}))
+		defer server.Close()
+
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(testProxyPlugin(server.URL), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		req.Header.Set("Authorization", "Bearer user-token")
+		req.Header.Set("Cookie", "session=user")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "console.log('plugin')", string(body))
+		assert.Empty(t, resp.Header.Get("Set-Cookie"))
+		assert.Empty(t, resp.Header.Get("Server"))
+		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+
+		assert.Equal(t, "/index.js", gotReq.URL.Path)
+		assert.Empty(t, gotReq.Header.Get("Authorization"))
+		assert.Empty(t, gotReq.Header.Get("Cookie"))
+	})
+
+	t.Run("should forward other methods with their body", func(t *testing.T) {
+		var gotMethod string
+		var gotBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(testProxyPlugin(server.URL), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/plugin/csv-import/jobs", strings.NewReader(`{"file":"trees.csv"}`))
+		req.Header.Set("Authorization", "Bearer user-token")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, http.MethodPost, gotMethod)
+		assert.Equal(t, `{"file":"trees.csv"}`, string(gotBody))
+	})
+
+	t.Run("should return 404 when plugin is not registered", func(t *testing.T) {
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(entities.Plugin{}, service.NewError(service.NotFound, "plugin not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("should return 503 when plugin missed its heartbeat", func(t *testing.T) {
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		plugin := testProxyPlugin("http://localhost:1")
+		plugin.LastHeartbeat = time.Now().Add(-time.Hour)
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(plugin, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+
+	t.Run("should return 403 when user lacks a role of the plugin", func(t *testing.T) {
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		app.Use("/api/v1/plugin/:plugin", withRealmRoles("green-ecolution"), getPluginFilesWithProxy(mockPluginService, newPluginProxy(http.DefaultTransport)))
+		plugin := testProxyPlugin("http://localhost:1")
+		plugin.Manifest.Roles = []entities.UserRole{entities.UserRoleTbz}
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(plugin, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should proxy request when user has a role of the plugin", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("console.log('plugin')"))
+		}))
+		defer server.Close()
+
+		app := fiber.New()
+		mockPluginService := serviceMock.NewMockPluginService(t)
+		mockPluginService.EXPECT().HeartbeatTimeout().Return(5 * time.Minute)
+		app.Use("/api/v1/plugin/:plugin", withRealmRoles("tbz"), getPluginFilesWithProxy(mockPluginService, newPluginProxy(http.DefaultTransport)))
+		plugin := testProxyPlugin(server.URL)
+		plugin.Manifest.Roles = []entities.UserRole{entities.UserRoleTbz, entities.UserRoleSmarteGrenzregion}
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(plugin, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return 502 when response exceeds the size limit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(strings.Repeat("a", 2048)))
+		}))
+		defer server.Close()
+
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		plugin := testProxyPlugin(server.URL)
+		plugin.Proxy.MaxBodySize = 1024
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(plugin, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	})
+
+	t.Run("should return 502 when chunked response exceeds the size limit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			for range 4 {
+				_, _ = w.Write([]byte(strings.Repeat("a", 512)))
+				w.(http.Flusher).Flush()
+			}
+		}))
+		defer server.Close()
+
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		plugin := testProxyPlugin(server.URL)
+		plugin.Proxy.MaxBodySize = 1024
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(plugin, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	})
+
+	t.Run("should proxy chunked response within the size limit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			for range 2 {
+				_, _ = w.Write([]byte(strings.Repeat("a", 512)))
+				w.(http.Flusher).Flush()
+			}
+		}))
+		defer server.Close()
+
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		plugin := testProxyPlugin(server.URL)
+		plugin.Proxy.MaxBodySize = 1024
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(plugin, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Len(t, body, 1024)
+	})
+
+	t.Run("should open circuit after consecutive failures", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		app, mockPluginService := setupProxyApp(t, newPluginProxy(http.DefaultTransport))
+		mockPluginService.EXPECT().Get(mock.Anything, "csv-import").Return(testProxyPlugin(server.URL), nil)
+
+		// when
+		statusCodes := make([]int, 0, breakerThreshold+1)
+		for range breakerThreshold + 1 {
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/plugin/csv-import/index.js", nil)
+			resp, err := app.Test(req, -1)
+			assert.NoError(t, err)
+			statusCodes = append(statusCodes, resp.StatusCode)
+			resp.Body.Close()
+		}
+
+		// then
+		assert.Equal(t, breakerThreshold, calls)
+		assert.Equal(t, http.StatusInternalServerError, statusCodes[0])
+		assert.Equal(t, http.StatusServiceUnavailable, statusCodes[breakerThreshold])
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("should half-open after cooldown and close on success", func(t *testing.T) {
+		now := time.Now()
+		b := &circuitBreaker{now: func() time.Time { return now }}
+		for range breakerThreshold {
+			b.report(false)
+		}
+		assert.False(t, b.allow())
+
+		// when
+		now = now.Add(breakerCooldown)
+
+		// then
+		assert.True(t, b.allow())
+		assert.False(t, b.allow(), "only one request is allowed while half-open")
+		b.report(true)
+		assert.True(t, b.allow())
+	})
+}
+
+func setupProxyApp(t *testing.T, proxy *pluginProxy) (*fiber.App, *serviceMock.MockPluginService) {
+	app := fiber.New()
+	mockPluginService := serviceMock.NewMockPluginService(t)
+	mockPluginService.EXPECT().HeartbeatTimeout().Return(5 * time.Minute).Maybe()
+	app.Use("/api/v1/plugin/:plugin", getPluginFilesWithProxy(mockPluginService, proxy))
+	return app, mockPluginService
+}
+
+func withRealmRoles(roles ...any) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := golangJwt.MapClaims{"realm_access": map[string]any{"roles": roles}}
+		c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+		return c.Next()
+	}
+}
+
+func testProxyPlugin(rawURL string) entities.Plugin {
+	path, _ := url.Parse(rawURL)
+	return entities.Plugin{
+		Slug:          "csv-import",
+		Path:          *path,
+		LastHeartbeat: time.Now(),
+	}
+}
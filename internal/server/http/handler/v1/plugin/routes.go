@@ -5,25 +5,31 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
-func RegisterRoutes(r fiber.Router, svc service.PluginService, middlewares ...fiber.Handler) {
-	handlers := append([]fiber.Handler{}, middlewares...)
-	handlers = append(handlers, GetPluginsList(svc))
-	r.Get("/", handlers...)
+// RegisterRoutes registers the plugin routes. The read routes are guarded by readMiddlewares, heartbeat
+// and unregister are called by the plugins themselves and guarded by lifecycleMiddlewares. Their handlers
+// additionally allow only the client of the plugin and admins.
+func RegisterRoutes(r fiber.Router, svc service.PluginService, readMiddlewares, lifecycleMiddlewares []fiber.Handler) {
+	r.Get("/", withMiddlewares(readMiddlewares, GetPluginsList(svc))...)
 
+	r.Get("/capabilities", GetHostCapabilities())
 	r.Post("/register", RegisterPlugin(svc))
 
-	handlers = append([]fiber.Handler{}, middlewares...)
-	handlers = append(handlers, GetPluginInfo(svc))
-	r.Get("/:plugin", handlers...)
-
-	handlers = append([]fiber.Handler{}, middlewares...)
-	handlers = append(handlers, PluginHeartbeat(svc))
-	r.Post("/:plugin/heartbeat", handlers...)
-
-	handlers = append([]fiber.Handler{}, middlewares...)
-	handlers = append(handlers, UnregisterPlugin(svc))
-	r.Post("/:plugin/unregister", handlers...)
+	r.Get("/:plugin", withMiddlewares(readMiddlewares, GetPluginInfo(svc))...)
+	r.Get("/:plugin/history", withMiddlewares(readMiddlewares, GetPluginHistory(svc))...)
+	r.Post("/:plugin/heartbeat", withMiddlewares(lifecycleMiddlewares, PluginHeartbeat(svc))...)
+	r.Post("/:plugin/unregister", withMiddlewares(lifecycleMiddlewares, UnregisterPlugin(svc))...)
 
 	r.Post("/:plugin/token/refresh", RefreshToken(svc))
 	r.Use("/:plugin", getPluginFiles(svc))
 }
+
+func withMiddlewares(middlewares []fiber.Handler, handler fiber.Handler) []fiber.Handler {
+	handlers := append([]fiber.Handler{}, middlewares...)
+	return append(handlers, handler)
+}
+
+func RegisterGrantRoutes(r fiber.Router, svc service.PluginService) {
+	r.Get("/", GetPluginGrants(svc))
+	r.Post("/", SavePluginGrant(svc))
+	r.Delete("/:plugin", DeletePluginGrant(svc))
+}
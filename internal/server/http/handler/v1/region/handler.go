@@ -1,6 +1,7 @@
 package region
 
 import (
+	"encoding/json"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -31,12 +32,7 @@ func GetAllRegions(svc service.RegionService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
-		dto := utils.Map(r, func(region *domain.Region) *entities.RegionResponse {
-			return &entities.RegionResponse{
-				ID:   region.ID,
-				Name: region.Name,
-			}
-		})
+		dto := utils.Map(r, mapRegionResponse)
 
 		return c.JSON(entities.RegionListResponse{
 			Data:       dto,
@@ -71,6 +67,152 @@ func GetRegionByID(svc service.RegionService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
-		return c.JSON(r)
+		return c.JSON(mapRegionResponse(r))
+	}
+}
+
+// @Summary		Create region
+// @Description	Create a region with a GeoJSON polygon or multi polygon. The geometry must be valid and must not overlap with other regions. Tree clusters inside the region are assigned to it.
+// @Id				create-region
+// @Tags			Region
+// @Produce		json
+// @Success		201	{object}	entities.RegionResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		409	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/region [post]
+// @Param			body	body	entities.RegionCreateRequest	true	"Region Create Request"
+// @Security		Keycloak
+func CreateRegion(svc service.RegionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.RegionCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		geometry, err := geoJSONToWKT(req.Geometry)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		r, err := svc.Create(ctx, &domain.RegionCreate{
+			Name:     req.Name,
+			Geometry: geometry,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(mapRegionResponse(r))
+	}
+}
+
+// @Summary		Update region
+// @Description	Update a region with a GeoJSON polygon or multi polygon. The geometry must be valid and must not overlap with other regions. Tree clusters are reassigned to the region containing them.
+// @Id				update-region
+// @Tags			Region
+// @Produce		json
+// @Success		200	{object}	entities.RegionResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		409	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/region/{id} [put]
+// @Param			id		path	int								true	"Region ID"
+// @Param			body	body	entities.RegionUpdateRequest	true	"Region Update Request"
+// @Security		Keycloak
+func UpdateRegion(svc service.RegionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.RegionUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		geometry, err := geoJSONToWKT(req.Geometry)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		// linter complains about overflows, but we are sure that the ID is not going to be bigger than int32
+		//nolint: gosec
+		r, err := svc.Update(ctx, int32(id), &domain.RegionUpdate{
+			Name:     req.Name,
+			Geometry: geometry,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapRegionResponse(r))
 	}
 }
+
+// @Summary		Delete region
+// @Description	Delete a region. Tree clusters of the region are left without region.
+// @Id				delete-region
+// @Tags			Region
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/region/{id} [delete]
+// @Param			id	path	int	true	"Region ID"
+// @Security		Keycloak
+func DeleteRegion(svc service.RegionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		// linter complains about overflows, but we are sure that the ID is not going to be bigger than int32
+		//nolint: gosec
+		if err := svc.Delete(ctx, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func mapRegionResponse(region *domain.Region) *entities.RegionResponse {
+	dto := &entities.RegionResponse{
+		ID:   region.ID,
+		Name: region.Name,
+	}
+
+	dto.Geometry = utils.WKTToGeoJSON(region.Geometry)
+	return dto
+}
+
+// geoJSONToWKT converts the GeoJSON geometry of a request to WKT. The service validates the geometry itself.
+func geoJSONToWKT(geometry json.RawMessage) (string, error) {
+	if len(geometry) == 0 {
+		return "", service.NewError(service.BadRequest, "geometry is required")
+	}
+
+	wkt, err := utils.GeoJSONToWKT(geometry)
+	if err != nil {
+		return "", service.NewError(service.BadRequest, "geometry must be a GeoJSON geometry")
+	}
+
+	return wkt, nil
+}
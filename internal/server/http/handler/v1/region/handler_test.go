@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -298,3 +299,178 @@ func TestGetRegionByID(t *testing.T) {
 		mockRegionService.AssertExpectations(t)
 	})
 }
+
+const testGeoJSON = `{"type":"Polygon","coordinates":[[[9.4,54.7],[9.5,54.7],[9.5,54.8],[9.4,54.8],[9.4,54.7]]]}`
+
+func TestCreateRegion(t *testing.T) {
+	t.Run("should create region with geometry as WKT", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Post("/v1/region", region.CreateRegion(mockRegionService))
+
+		mockRegionService.EXPECT().Create(
+			mock.Anything,
+			mock.MatchedBy(func(r *entities.RegionCreate) bool {
+				return r.Name == "Region C" && strings.HasPrefix(r.Geometry, "POLYGON")
+			}),
+		).Return(&entities.Region{ID: 3, Name: "Region C", Geometry: "POLYGON ((9.4 54.7, 9.5 54.7, 9.5 54.8, 9.4 54.8, 9.4 54.7))"}, nil)
+
+		// when
+		body := `{"name":"Region C","geometry":` + testGeoJSON + `}`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/region", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.RegionResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), response.ID)
+		assert.JSONEq(t, testGeoJSON, string(response.Geometry))
+	})
+
+	t.Run("should return 400 when geometry is no GeoJSON", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Post("/v1/region", region.CreateRegion(mockRegionService))
+
+		for _, body := range []string{
+			`{"name":"Region C"}`,
+			`{"name":"Region C","geometry":{"type":"Polygon"}}`,
+		} {
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/region", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			defer resp.Body.Close()
+
+			// then
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		}
+
+		mockRegionService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 409 when region overlaps", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Post("/v1/region", region.CreateRegion(mockRegionService))
+
+		mockRegionService.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, service.ErrRegionOverlapping)
+
+		// when
+		body := `{"name":"Region C","geometry":` + testGeoJSON + `}`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/region", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestUpdateRegion(t *testing.T) {
+	t.Run("should update region", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Put("/v1/region/:id", region.UpdateRegion(mockRegionService))
+
+		mockRegionService.EXPECT().Update(
+			mock.Anything,
+			int32(1),
+			mock.MatchedBy(func(r *entities.RegionUpdate) bool {
+				return r.Name == "Region A" && strings.HasPrefix(r.Geometry, "POLYGON")
+			}),
+		).Return(&entities.Region{ID: 1, Name: "Region A"}, nil)
+
+		// when
+		body := `{"name":"Region A","geometry":` + testGeoJSON + `}`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/region/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid ID", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Put("/v1/region/:id", region.UpdateRegion(mockRegionService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/region/invalid-id", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when region not found", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Put("/v1/region/:id", region.UpdateRegion(mockRegionService))
+
+		mockRegionService.EXPECT().Update(mock.Anything, int32(1), mock.Anything).
+			Return(nil, service.NewError(service.NotFound, "region not found"))
+
+		// when
+		body := `{"name":"Region A","geometry":` + testGeoJSON + `}`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/region/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestDeleteRegion(t *testing.T) {
+	t.Run("should delete region", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Delete("/v1/region/:id", region.DeleteRegion(mockRegionService))
+
+		mockRegionService.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/region/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when region not found", func(t *testing.T) {
+		mockRegionService := serviceMock.NewMockRegionService(t)
+		app := fiber.New()
+		app.Delete("/v1/region/:id", region.DeleteRegion(mockRegionService))
+
+		mockRegionService.EXPECT().Delete(mock.Anything, int32(1)).
+			Return(service.NewError(service.NotFound, "region not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/region/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
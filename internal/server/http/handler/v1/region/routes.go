@@ -8,4 +8,7 @@ import (
 func RegisterRoutes(r fiber.Router, svc service.RegionService) {
 	r.Get("/", GetAllRegions(svc))
 	r.Get("/:id", GetRegionByID(svc))
+	r.Post("/", CreateRegion(svc))
+	r.Put("/:id", UpdateRegion(svc))
+	r.Delete("/:id", DeleteRegion(svc))
 }
@@ -3,6 +3,7 @@ package region
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -42,6 +43,28 @@ func TestRegisterRoutes(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 		})
+
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockRegionService := serviceMock.NewMockRegionService(t)
+			app := fiber.New()
+			RegisterRoutes(app, mockRegionService)
+
+			mockRegionService.EXPECT().Create(
+				mock.Anything,
+				mock.Anything,
+			).Return(&entities.Region{ID: 1, Name: "Region A"}, nil)
+
+			// when
+			body := `{"name":"Region A","geometry":{"type":"Polygon","coordinates":[[[9.4,54.7],[9.5,54.7],[9.5,54.8],[9.4,54.7]]]}}`
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
 	})
 
 	t.Run("/v1/region/:id", func(t *testing.T) {
@@ -64,5 +87,48 @@ func TestRegisterRoutes(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 		})
+
+		t.Run("should call PUT handler", func(t *testing.T) {
+			mockRegionService := serviceMock.NewMockRegionService(t)
+			app := fiber.New()
+			RegisterRoutes(app, mockRegionService)
+
+			mockRegionService.EXPECT().Update(
+				mock.Anything,
+				int32(1),
+				mock.Anything,
+			).Return(&entities.Region{ID: 1, Name: "Region A"}, nil)
+
+			// when
+			body := `{"name":"Region A","geometry":{"type":"Polygon","coordinates":[[[9.4,54.7],[9.5,54.7],[9.5,54.8],[9.4,54.7]]]}}`
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/1", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call DELETE handler", func(t *testing.T) {
+			mockRegionService := serviceMock.NewMockRegionService(t)
+			app := fiber.New()
+			RegisterRoutes(app, mockRegionService)
+
+			mockRegionService.EXPECT().Delete(
+				mock.Anything,
+				int32(1),
+			).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
 	})
 }
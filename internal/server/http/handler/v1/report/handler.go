@@ -0,0 +1,123 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	_ "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	reportMapper = generated.ReportHTTPMapperImpl{}
+)
+
+// @Summary		Create watering plan report
+// @Description	Create the route sheet of a watering plan with the stops in order, a map of the route, the planned and consumed litres per tree cluster and signature fields for the crew. The report is stored and can be downloaded with the signed url until it expires.
+// @Id				create-watering-plan-report
+// @Tags			Report
+// @Produce		json
+// @Success		201	{object}	entities.ReportResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/report/watering-plan/{id} [post]
+// @Param			id		path	int		true	"Watering Plan ID"
+// @Param			format	query	string	false	"Format (pdf, csv), defaults to pdf"
+// @Security		Keycloak
+func CreateWateringPlanReport(svc service.ReportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		format := domain.ReportFormat(c.Query("format", string(domain.ReportFormatPDF)))
+		domainData, err := svc.CreateWateringPlanReport(ctx, int32(id), format)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(reportMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Create season report
+// @Description	Create a summary of all watering plans of a year per month, region and vehicle. The report is stored and can be downloaded with the signed url until it expires.
+// @Id				create-season-report
+// @Tags			Report
+// @Produce		json
+// @Success		201	{object}	entities.ReportResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/report/season [post]
+// @Param			year	query	int		true	"Year of the season"
+// @Param			format	query	string	false	"Format (pdf, csv), defaults to pdf"
+// @Security		Keycloak
+func CreateSeasonReport(svc service.ReportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		year, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			return errorhandler.HandleError(service.ErrReportYearInvalid)
+		}
+
+		format := domain.ReportFormat(c.Query("format", string(domain.ReportFormatPDF)))
+		domainData, err := svc.CreateSeasonReport(ctx, year, format)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(reportMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Download report
+// @Description	Download a generated report. The link is signed and needs no authentication, it is rejected once it expired or was changed.
+// @Id				download-report
+// @Tags			Report
+// @Produce		application/pdf
+// @Produce		text/csv
+// @Success		200	{file}		file
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/report/download/{name} [get]
+// @Param			name		path	string	true	"Report file name"
+// @Param			expires		query	int		true	"Expiry of the link as unix timestamp"
+// @Param			signature	query	string	true	"Signature of the link"
+func DownloadReport(svc service.ReportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		name := strings.Clone(c.Params("name"))
+		expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+		if err != nil {
+			return errorhandler.HandleError(service.ErrReportLinkInvalid)
+		}
+
+		fileStream, err := svc.Download(ctx, name, expires, c.Query("signature"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+		defer fileStream.Close()
+
+		format := domain.ReportFormat(strings.TrimPrefix(path.Ext(name), "."))
+		c.Set(fiber.HeaderContentType, format.ContentType())
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", name))
+		_, err = io.Copy(c.Response().BodyWriter(), fileStream)
+		return errorhandler.HandleError(err)
+	}
+}
@@ -0,0 +1,219 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/report"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type readSeekCloser struct {
+	*bytes.Reader
+}
+
+func (readSeekCloser) Close() error { return nil }
+
+var testReport = &entities.Report{
+	Name:      "watering-plan-1-20250611T143000Z.pdf",
+	Kind:      entities.ReportKindWateringPlan,
+	Format:    entities.ReportFormatPDF,
+	CreatedAt: time.Date(2025, time.June, 11, 14, 30, 0, 0, time.UTC),
+	URL:       "/v1/report/download/watering-plan-1-20250611T143000Z.pdf?expires=1749655800&signature=abc",
+	ExpiresAt: time.Date(2025, time.June, 12, 14, 30, 0, 0, time.UTC),
+}
+
+func TestCreateWateringPlanReport(t *testing.T) {
+	t.Run("should create pdf report by default", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Post("/v1/report/watering-plan/:id", report.CreateWateringPlanReport(mockReportService))
+
+		mockReportService.EXPECT().CreateWateringPlanReport(mock.Anything, int32(1), entities.ReportFormatPDF).Return(testReport, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/report/watering-plan/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.ReportResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, testReport.Name, response.Name)
+		assert.Equal(t, serverEntities.ReportKindWateringPlan, response.Kind)
+		assert.Equal(t, serverEntities.ReportFormatPDF, response.Format)
+		assert.Equal(t, testReport.URL, response.URL)
+		assert.Equal(t, testReport.ExpiresAt, response.ExpiresAt)
+	})
+
+	t.Run("should pass the requested format", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Post("/v1/report/watering-plan/:id", report.CreateWateringPlanReport(mockReportService))
+
+		mockReportService.EXPECT().CreateWateringPlanReport(mock.Anything, int32(1), entities.ReportFormatCSV).Return(testReport, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/report/watering-plan/1?format=csv", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid ID format", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Post("/v1/report/watering-plan/:id", report.CreateWateringPlanReport(mockReportService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/report/watering-plan/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid format", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Post("/v1/report/watering-plan/:id", report.CreateWateringPlanReport(mockReportService))
+
+		mockReportService.EXPECT().CreateWateringPlanReport(mock.Anything, int32(1), entities.ReportFormat("xlsx")).Return(nil, service.ErrReportFormatInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/report/watering-plan/1?format=xlsx", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when watering plan does not exist", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Post("/v1/report/watering-plan/:id", report.CreateWateringPlanReport(mockReportService))
+
+		mockReportService.EXPECT().CreateWateringPlanReport(mock.Anything, int32(1), entities.ReportFormatPDF).Return(nil, service.NewError(service.NotFound, "watering plan not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/report/watering-plan/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCreateSeasonReport(t *testing.T) {
+	t.Run("should create season report", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Post("/v1/report/season", report.CreateSeasonReport(mockReportService))
+
+		mockReportService.EXPECT().CreateSeasonReport(mock.Anything, 2025, entities.ReportFormatCSV).Return(testReport, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/report/season?year=2025&format=csv", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("should return 400 without year", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Post("/v1/report/season", report.CreateSeasonReport(mockReportService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/report/season", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDownloadReport(t *testing.T) {
+	t.Run("should stream the report", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Get("/v1/report/download/:name", report.DownloadReport(mockReportService))
+
+		mockReportService.EXPECT().Download(mock.Anything, "season-2025.csv", int64(1749655800), "abc").
+			Return(readSeekCloser{bytes.NewReader([]byte("group_by,key\n"))}, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/report/download/season-2025.csv?expires=1749655800&signature=abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv;charset=UTF-8", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, "attachment; filename=season-2025.csv", resp.Header.Get(fiber.HeaderContentDisposition))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "group_by,key\n", string(body))
+	})
+
+	t.Run("should return 403 when link is invalid", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Get("/v1/report/download/:name", report.DownloadReport(mockReportService))
+
+		mockReportService.EXPECT().Download(mock.Anything, "season-2025.csv", int64(1749655800), "abc").Return(nil, service.ErrReportLinkInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/report/download/season-2025.csv?expires=1749655800&signature=abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should return 403 without expiry", func(t *testing.T) {
+		mockReportService := serviceMock.NewMockReportService(t)
+		app := fiber.New()
+		app.Get("/v1/report/download/:name", report.DownloadReport(mockReportService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/report/download/season-2025.csv?signature=abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
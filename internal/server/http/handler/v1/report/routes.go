@@ -0,0 +1,16 @@
+package report
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.ReportService) {
+	r.Post("/watering-plan/:id", CreateWateringPlanReport(svc))
+	r.Post("/season", CreateSeasonReport(svc))
+}
+
+// RegisterPublicRoutes registers the download of reports, the signed link replaces the authentication
+func RegisterPublicRoutes(r fiber.Router, svc service.ReportService) {
+	r.Get("/download/:name", DownloadReport(svc))
+}
@@ -0,0 +1,76 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/report"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/report/watering-plan/:id", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockReportService := serviceMock.NewMockReportService(t)
+			app := fiber.New()
+			report.RegisterRoutes(app, mockReportService)
+
+			mockReportService.EXPECT().CreateWateringPlanReport(mock.Anything, int32(1), mock.Anything).Return(testReport, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/watering-plan/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/report/season", func(t *testing.T) {
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockReportService := serviceMock.NewMockReportService(t)
+			app := fiber.New()
+			report.RegisterRoutes(app, mockReportService)
+
+			mockReportService.EXPECT().CreateSeasonReport(mock.Anything, 2025, mock.Anything).Return(testReport, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/season?year=2025", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	})
+}
+
+func TestRegisterPublicRoutes(t *testing.T) {
+	t.Run("/v1/report/download/:name", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockReportService := serviceMock.NewMockReportService(t)
+			app := fiber.New()
+			report.RegisterPublicRoutes(app, mockReportService)
+
+			mockReportService.EXPECT().Download(mock.Anything, "season-2025.pdf", int64(1), "abc").
+				Return(readSeekCloser{bytes.NewReader(nil)}, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/download/season-2025.pdf?expires=1&signature=abc", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 )
@@ -31,16 +32,26 @@ var (
 // @Param			page		query	int		false	"Page"
 // @Param			limit		query	int		false	"Limit"
 // @Param			provider	query	string	false	"Provider"
+// @Param			bbox		query	string	false	"Bounding box as minLon,minLat,maxLon,maxLat"
+// @Param			near		query	string	false	"Sort by distance to lat,lon, the nearest first"
+// @Param			radius		query	number	false	"Radius in meters around near"
+// @Param			polygon		query	string	false	"Polygon or multi polygon as WKT or GeoJSON with longitude and latitude"
 // @Security		Keycloak
 func GetAllSensors(svc service.SensorService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
-		var query domain.Query
+		var query domain.SensorQuery
 
 		if err := c.QueryParser(&query); err != nil {
 			return errorhandler.HandleError(err)
 		}
 
+		spatialQuery, err := spatial.ParseQuery(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+		query.SpatialQuery = spatialQuery
+
 		domainData, totalCount, err := svc.GetAll(ctx, query)
 		if err != nil {
 			return errorhandler.HandleError(err)
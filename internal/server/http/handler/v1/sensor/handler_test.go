@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,7 +30,7 @@ func TestGetAllSensors(t *testing.T) {
 
 		mockSensorService.EXPECT().GetAll(
 			mock.Anything,
-			entities.Query{},
+			entities.SensorQuery{},
 		).Return(TestSensorList, int64(len(TestSensorList)), nil)
 
 		// when
@@ -64,7 +65,7 @@ func TestGetAllSensors(t *testing.T) {
 
 		mockSensorService.EXPECT().GetAll(
 			mock.Anything,
-			entities.Query{},
+			entities.SensorQuery{},
 		).Return(TestSensorList, int64(len(TestSensorList)), nil)
 
 		// when
@@ -135,7 +136,7 @@ func TestGetAllSensors(t *testing.T) {
 
 		mockSensorService.EXPECT().GetAll(
 			mock.Anything,
-			entities.Query{Provider: "test-provider"},
+			entities.SensorQuery{Query: entities.Query{Provider: "test-provider"}},
 		).Return(TestSensorList, int64(len(TestSensorList)), nil)
 
 		app.Get("/v1/sensor", handler)
@@ -173,7 +174,7 @@ func TestGetAllSensors(t *testing.T) {
 
 		mockSensorService.EXPECT().GetAll(
 			mock.Anything,
-			entities.Query{},
+			entities.SensorQuery{},
 		).Return([]*entities.Sensor{}, int64(0), nil)
 
 		// when
@@ -207,7 +208,7 @@ func TestGetAllSensors(t *testing.T) {
 
 		mockSensorService.EXPECT().GetAll(
 			mock.Anything,
-			entities.Query{},
+			entities.SensorQuery{},
 		).Return(nil, int64(0), errors.New("service error"))
 
 		// when
@@ -221,6 +222,54 @@ func TestGetAllSensors(t *testing.T) {
 
 		mockSensorService.AssertExpectations(t)
 	})
+
+	t.Run("should return sensors filtered by polygon", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.PaginationMiddleware())
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		handler := sensor.GetAllSensors(mockSensorService)
+		app.Get("/v1/sensor", handler)
+
+		mockSensorService.EXPECT().GetAll(
+			mock.Anything,
+			mock.MatchedBy(func(q entities.SensorQuery) bool {
+				return strings.HasPrefix(q.Polygon, "POLYGON") && q.BBox == nil && q.Near == nil
+			}),
+		).Return(TestSensorList, int64(len(TestSensorList)), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor", nil)
+		query := req.URL.Query()
+		query.Add("polygon", `{"type":"Polygon","coordinates":[[[9.4,54.7],[9.5,54.7],[9.5,54.9],[9.4,54.7]]]}`)
+		req.URL.RawQuery = query.Encode()
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		mockSensorService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 when radius is given without near", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.PaginationMiddleware())
+		mockSensorService := serviceMock.NewMockSensorService(t)
+		handler := sensor.GetAllSensors(mockSensorService)
+		app.Get("/v1/sensor", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/sensor?radius=100", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockSensorService.AssertNotCalled(t, "GetAll")
+	})
 }
 
 func TestGetAllSensorDataById(t *testing.T) {
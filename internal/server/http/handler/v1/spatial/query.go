@@ -0,0 +1,144 @@
+package spatial
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/twpayne/go-geos"
+)
+
+// maxRadius limits the radius of the near filter to the size of a city
+const maxRadius = 50_000
+
+// ParseQuery reads the spatial filters from the query parameters bbox, near, radius and polygon
+func ParseQuery(c *fiber.Ctx) (domain.SpatialQuery, error) {
+	var query domain.SpatialQuery
+
+	bbox, err := ParseBBox(c.Query("bbox"))
+	if err != nil {
+		return query, err
+	}
+	query.BBox = bbox
+
+	near, err := parseCoordinate(c.Query("near"))
+	if err != nil {
+		return query, err
+	}
+	query.Near = near
+
+	if radius := c.Query("radius"); radius != "" {
+		if near == nil {
+			return query, service.NewError(service.BadRequest, "radius can only be used together with near")
+		}
+		v, err := strconv.ParseFloat(radius, 64)
+		if err != nil || v <= 0 || v > maxRadius {
+			return query, service.NewError(service.BadRequest, "radius must be a positive number of meters up to 50000")
+		}
+		query.Radius = v
+	}
+
+	polygon, err := parsePolygon(c.Query("polygon"))
+	if err != nil {
+		return query, err
+	}
+	query.Polygon = polygon
+
+	return query, nil
+}
+
+// RejectQuery returns a bad request error if any spatial filter is given. It is used by list endpoints
+// of entities without a position, so a spatial filter is not silently ignored.
+func RejectQuery(c *fiber.Ctx) error {
+	for _, param := range []string{"bbox", "near", "radius", "polygon"} {
+		if c.Query(param) != "" {
+			return service.NewError(service.BadRequest, param+" is not supported because the entities have no position")
+		}
+	}
+
+	return nil
+}
+
+// ParseBBox parses a bounding box as "minLon,minLat,maxLon,maxLat". A bounding box with
+// six values contains the minimum and maximum height which are ignored.
+func ParseBBox(value string) (*domain.BoundingBox, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 && len(parts) != 6 {
+		return nil, service.NewError(service.BadRequest, "bbox must have four or six values")
+	}
+
+	coords := make([]float64, len(parts))
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, service.NewError(service.BadRequest, "bbox must contain numbers")
+		}
+		coords[i] = v
+	}
+
+	if len(coords) == 6 {
+		coords = []float64{coords[0], coords[1], coords[3], coords[4]}
+	}
+
+	// bounding boxes crossing the antimeridian are not supported
+	if coords[0] > coords[2] || coords[1] > coords[3] {
+		return nil, service.NewError(service.BadRequest, "minimum of bbox must not be greater than maximum")
+	}
+
+	return &domain.BoundingBox{MinLon: coords[0], MinLat: coords[1], MaxLon: coords[2], MaxLat: coords[3]}, nil
+}
+
+// parseCoordinate parses a coordinate as "lat,lon" like the coordinates of the entities
+func parseCoordinate(value string) (*domain.Coordinate, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	lat, lon, ok := strings.Cut(value, ",")
+	if !ok {
+		return nil, service.NewError(service.BadRequest, "near must be latitude and longitude separated by a comma")
+	}
+
+	latitude, errLat := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	longitude, errLon := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if errLat != nil || errLon != nil || latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, service.NewError(service.BadRequest, "near must be a valid latitude and longitude")
+	}
+
+	return &domain.Coordinate{Latitude: latitude, Longitude: longitude}, nil
+}
+
+// parsePolygon parses a polygon or multi polygon given as WKT or GeoJSON and returns it as WKT
+func parsePolygon(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	var geom *geos.Geom
+	var err error
+	if strings.HasPrefix(value, "{") {
+		geom, err = geos.NewGeomFromGeoJSON(value)
+	} else {
+		geom, err = geos.NewGeomFromWKT(value)
+	}
+	if err != nil {
+		return "", service.NewError(service.BadRequest, "polygon must be WKT or GeoJSON")
+	}
+
+	if typeID := geom.TypeID(); typeID != geos.TypeIDPolygon && typeID != geos.TypeIDMultiPolygon {
+		return "", service.NewError(service.BadRequest, "polygon must be a polygon or multi polygon")
+	}
+
+	if geom.IsEmpty() || !geom.IsValid() {
+		return "", service.NewError(service.BadRequest, "polygon must not be empty or self-intersecting")
+	}
+
+	return geom.ToWKT(), nil
+}
@@ -0,0 +1,137 @@
+package spatial_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
+	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geos"
+)
+
+func parse(t *testing.T, query string) (entities.SpatialQuery, int) {
+	var got entities.SpatialQuery
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		var err error
+		if got, err = spatial.ParseQuery(c); err != nil {
+			return errorhandler.HandleError(err)
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/?"+query, nil)
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	return got, resp.StatusCode
+}
+
+func assertPolygon(t *testing.T, expected, actual string) {
+	want, err := geos.NewGeomFromWKT(expected)
+	assert.NoError(t, err)
+	got, err := geos.NewGeomFromWKT(actual)
+	assert.NoError(t, err)
+	assert.True(t, want.Equals(got), "expected %s, got %s", expected, actual)
+}
+
+func TestParseQuery(t *testing.T) {
+	t.Run("should return empty query without parameters", func(t *testing.T) {
+		got, status := parse(t, "")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, entities.SpatialQuery{}, got)
+	})
+
+	t.Run("should parse bbox", func(t *testing.T) {
+		got, status := parse(t, "bbox=9.4,54.7,9.5,54.9")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, &entities.BoundingBox{MinLon: 9.4, MinLat: 54.7, MaxLon: 9.5, MaxLat: 54.9}, got.BBox)
+	})
+
+	t.Run("should ignore heights of bbox with six values", func(t *testing.T) {
+		got, status := parse(t, "bbox=9.4,54.7,0,9.5,54.9,100")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, &entities.BoundingBox{MinLon: 9.4, MinLat: 54.7, MaxLon: 9.5, MaxLat: 54.9}, got.BBox)
+	})
+
+	t.Run("should parse near as latitude and longitude with radius", func(t *testing.T) {
+		got, status := parse(t, "near=54.82,9.48&radius=250")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, &entities.Coordinate{Latitude: 54.82, Longitude: 9.48}, got.Near)
+		assert.Equal(t, 250.0, got.Radius)
+	})
+
+	t.Run("should parse polygon as WKT", func(t *testing.T) {
+		got, status := parse(t, "polygon=POLYGON((9.4 54.7, 9.5 54.7, 9.5 54.9, 9.4 54.7))")
+
+		assert.Equal(t, http.StatusOK, status)
+		assertPolygon(t, "POLYGON ((9.4 54.7, 9.5 54.7, 9.5 54.9, 9.4 54.7))", got.Polygon)
+	})
+
+	t.Run("should parse polygon as GeoJSON", func(t *testing.T) {
+		got, status := parse(t, `polygon={"type":"Polygon","coordinates":[[[9.4,54.7],[9.5,54.7],[9.5,54.9],[9.4,54.7]]]}`)
+
+		assert.Equal(t, http.StatusOK, status)
+		assertPolygon(t, "POLYGON ((9.4 54.7, 9.5 54.7, 9.5 54.9, 9.4 54.7))", got.Polygon)
+	})
+
+	t.Run("should return 400 when query is invalid", func(t *testing.T) {
+		for name, query := range map[string]string{
+			"bbox with three values": "bbox=9.4,54.7,9.5",
+			"bbox min above max":     "bbox=9.5,54.9,9.4,54.7",
+			"near without longitude": "near=54.82",
+			"near out of range":      "near=95,9.48",
+			"radius without near":    "radius=100",
+			"negative radius":        "near=54.82,9.48&radius=-1",
+			"radius too large":       "near=54.82,9.48&radius=100000",
+			"polygon is a point":     "polygon=POINT(9.4 54.7)",
+			"polygon is no geometry": "polygon=abc",
+			"polygon is invalid":     "polygon=POLYGON((0 0, 1 1, 1 0, 0 1, 0 0))",
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, status := parse(t, query)
+				assert.Equal(t, http.StatusBadRequest, status)
+			})
+		}
+	})
+}
+
+func TestRejectQuery(t *testing.T) {
+	reject := func(t *testing.T, query string) int {
+		app := fiber.New()
+		app.Get("/", func(c *fiber.Ctx) error {
+			if err := spatial.RejectQuery(c); err != nil {
+				return errorhandler.HandleError(err)
+			}
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/?"+query, nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	t.Run("should accept query without spatial filter", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, reject(t, "page=1"))
+	})
+
+	t.Run("should return 400 when a spatial filter is given", func(t *testing.T) {
+		for _, query := range []string{"bbox=9.4,54.7,9.5,54.9", "near=54.82,9.48", "radius=100", "polygon=POLYGON((0 0, 1 0, 1 1, 0 0))"} {
+			t.Run(query, func(t *testing.T) {
+				assert.Equal(t, http.StatusBadRequest, reject(t, query))
+			})
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package tile
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const contentTypeMVT = "application/vnd.mapbox-vector-tile"
+
+// @Summary		Get a vector tile
+// @Description	Get the trees, tree clusters, sensors or regions intersecting a web mercator tile as Mapbox Vector Tile.
+// @Description	Trees and tree clusters carry their watering status as attribute, geometries are simplified depending on the zoom level.
+// @Description	Tiles without features and tiles below the minimum zoom level of the layer are answered with 204.
+// @Description	The response has an ETag, requests with a matching If-None-Match header are answered with 304.
+// @Id				get-tile
+// @Tags			Tile
+// @Produce		application/vnd.mapbox-vector-tile
+// @Success		200	{file}	file
+// @Success		204
+// @Success		304
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tiles/{layer}/{z}/{x}/{y}.mvt [get]
+// @Param			layer	path	string	true	"Layer (trees, tree-clusters, sensors, regions)"
+// @Param			z		path	int		true	"Zoom level"
+// @Param			x		path	int		true	"Column"
+// @Param			y		path	int		true	"Row"
+// @Security		Keycloak
+func GetTile(svc service.TileService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		coord, err := parseCoordinate(c)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		tile, err := svc.GetTile(ctx, domain.OGCCollectionID(strings.Clone(c.Params("layer"))), coord)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		// the client revalidates every tile with the etag because features change at any time
+		c.Set(fiber.HeaderCacheControl, "private, no-cache")
+		if len(tile) == 0 {
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+
+		c.Set(fiber.HeaderContentType, contentTypeMVT)
+		return c.Send(tile)
+	}
+}
+
+func parseCoordinate(c *fiber.Ctx) (domain.TileCoordinate, error) {
+	var coord domain.TileCoordinate
+	for _, p := range []struct {
+		name string
+		dst  *int32
+	}{{"z", &coord.Z}, {"x", &coord.X}, {"y", &coord.Y}} {
+		v, err := strconv.ParseInt(c.Params(p.name), 10, 32)
+		if err != nil {
+			return coord, service.NewError(service.BadRequest, "invalid tile coordinate format")
+		}
+		*p.dst = int32(v)
+	}
+
+	return coord, nil
+}
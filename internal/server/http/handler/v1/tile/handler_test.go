@@ -0,0 +1,124 @@
+package tile_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/tile"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testTile = []byte{0x1a, 0x05, 0x0a, 0x03, 0x74, 0x72, 0x65}
+
+func setupApp(t *testing.T) (*fiber.App, *serviceMock.MockTileService) {
+	app := fiber.New()
+	mockTileService := serviceMock.NewMockTileService(t)
+	app.Route("/v1/tiles", func(router fiber.Router) { tile.RegisterRoutes(router, mockTileService) })
+	return app, mockTileService
+}
+
+func TestGetTile(t *testing.T) {
+	t.Run("should return tile with etag", func(t *testing.T) {
+		app, mockTileService := setupApp(t)
+		mockTileService.EXPECT().GetTile(mock.Anything, entities.OGCCollectionTrees, entities.TileCoordinate{Z: 15, X: 17247, Y: 10305}).Return(testTile, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tiles/trees/15/17247/10305.mvt", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/vnd.mapbox-vector-tile", resp.Header.Get(fiber.HeaderContentType))
+		assert.Equal(t, "private, no-cache", resp.Header.Get(fiber.HeaderCacheControl))
+		assert.NotEmpty(t, resp.Header.Get(fiber.HeaderETag))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, testTile, body)
+	})
+
+	t.Run("should return 304 when etag matches", func(t *testing.T) {
+		app, mockTileService := setupApp(t)
+		mockTileService.EXPECT().GetTile(mock.Anything, entities.OGCCollectionTreeClusters, entities.TileCoordinate{Z: 12, X: 2155, Y: 1288}).Return(testTile, nil)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tiles/tree-clusters/12/2155/1288.mvt", nil)
+		first, err := app.Test(req, -1)
+		assert.Nil(t, err)
+		defer first.Body.Close()
+
+		// when
+		req, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tiles/tree-clusters/12/2155/1288.mvt", nil)
+		req.Header.Set(fiber.HeaderIfNoneMatch, first.Header.Get(fiber.HeaderETag))
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Empty(t, body)
+	})
+
+	t.Run("should return 204 when tile is empty", func(t *testing.T) {
+		app, mockTileService := setupApp(t)
+		mockTileService.EXPECT().GetTile(mock.Anything, entities.OGCCollectionTrees, entities.TileCoordinate{Z: 5, X: 16, Y: 10}).Return([]byte{}, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tiles/trees/5/16/10.mvt", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(fiber.HeaderETag))
+	})
+
+	t.Run("should return 400 when coordinate is not a number", func(t *testing.T) {
+		app, _ := setupApp(t)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tiles/trees/15/abc/10305.mvt", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when coordinate is out of range", func(t *testing.T) {
+		app, mockTileService := setupApp(t)
+		mockTileService.EXPECT().GetTile(mock.Anything, entities.OGCCollectionRegions, entities.TileCoordinate{Z: 1, X: 5, Y: 0}).Return(nil, service.ErrTileCoordinateInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tiles/regions/1/5/0.mvt", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when layer is unknown", func(t *testing.T) {
+		app, mockTileService := setupApp(t)
+		mockTileService.EXPECT().GetTile(mock.Anything, entities.OGCCollectionID("vehicles"), entities.TileCoordinate{Z: 15, X: 1, Y: 1}).Return(nil, service.ErrTileLayerNotFound)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tiles/vehicles/15/1/1.mvt", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,11 @@
+package tile
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.TileService) {
+	r.Get("/:layer/:z/:x/:y.mvt", etag.New(), GetTile(svc))
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 )
 
@@ -36,6 +38,10 @@ var (
 // @Param			watering_statuses	query	[]string	false	"watering status (good, moderate, bad)"
 // @Param			planting_years		query	[]int		false	"planting_years"
 // @Param			has_cluster			query	bool		false	"has cluster"
+// @Param			bbox				query	string		false	"Bounding box as minLon,minLat,maxLon,maxLat"
+// @Param			near				query	string		false	"Sort by distance to lat,lon, the nearest first"
+// @Param			radius				query	number		false	"Radius in meters around near"
+// @Param			polygon				query	string		false	"Polygon or multi polygon as WKT or GeoJSON with longitude and latitude"
 // @Security		Keycloak
 func GetAllTrees(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -69,6 +75,7 @@ func GetAllTrees(svc service.TreeService) fiber.Handler {
 // @Tags			Tree
 // @Produce		json
 // @Success		200	{object}	entities.TreeResponse
+// @Header			200	{string}	ETag	"Version of the tree"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -95,6 +102,7 @@ func GetTreeByID(svc service.TreeService) fiber.Handler {
 
 		data := mapTreeToDto(domainData)
 
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(data)
 	}
 }
@@ -134,6 +142,7 @@ func GetTreeBySensorID(svc service.TreeService) fiber.Handler {
 // @Tags			Tree
 // @Produce		json
 // @Success		200	{object}	entities.TreeResponse
+// @Header			200	{string}	ETag	"Version of the tree"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -157,6 +166,7 @@ func CreateTree(svc service.TreeService) fiber.Handler {
 		}
 
 		data := mapTreeToDto(domainData)
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.Status(fiber.StatusCreated).JSON(data)
 	}
 }
@@ -167,15 +177,18 @@ func CreateTree(svc service.TreeService) fiber.Handler {
 // @Tags			Tree
 // @Produce		json
 // @Success		200	{object}	entities.TreeResponse
+// @Header			200	{string}	ETag	"Version of the tree"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/tree/{tree_id} [put]
 // @Security		Keycloak
-// @Param			tree_id	path	int							false	"Tree ID"
-// @Param			body	body	entities.TreeUpdateRequest	true	"Tree to update"
+// @Param			tree_id		path	int							false	"Tree ID"
+// @Param			body		body	entities.TreeUpdateRequest	true	"Tree to update"
+// @Param			If-Match	header	string						false	"ETag of the tree, the request fails with 412 if the tree has been modified since"
 func UpdateTree(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
@@ -194,6 +207,7 @@ func UpdateTree(svc service.TreeService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 		data := mapTreeToDto(domainData)
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(data)
 	}
 }
@@ -208,9 +222,11 @@ func UpdateTree(svc service.TreeService) fiber.Handler {
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/tree/{tree_id} [delete]
-// @Param			tree_id	path	int	false	"Tree ID"
+// @Param			tree_id		path	int		false	"Tree ID"
+// @Param			If-Match	header	string	false	"ETag of the tree, the request fails with 412 if the tree has been modified since"
 // @Security		Keycloak
 func DeleteTree(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -242,5 +258,11 @@ func fillTreeQueryParams(c *fiber.Ctx) (domain.TreeQuery, error) {
 		return domain.TreeQuery{}, err
 	}
 
+	spatialQuery, err := spatial.ParseQuery(c)
+	if err != nil {
+		return domain.TreeQuery{}, err
+	}
+	filter.SpatialQuery = spatialQuery
+
 	return filter, nil
 }
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
@@ -144,6 +145,58 @@ func TestGetAllTrees(t *testing.T) {
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		mockTreeService.AssertExpectations(t)
 	})
+
+	t.Run("should return trees filtered by bounding box and radius", func(t *testing.T) {
+		app := fiber.New(fiber.Config{
+			EnableSplittingOnParsers: true,
+		})
+
+		mockTreeService := serviceMock.NewMockTreeService(t)
+		app.Get("/v1/tree", tree.GetAllTrees(mockTreeService))
+
+		mockTreeService.EXPECT().GetAll(
+			mock.Anything,
+			entities.TreeQuery{
+				SpatialQuery: entities.SpatialQuery{
+					BBox:   &entities.BoundingBox{MinLon: 9.4, MinLat: 54.7, MaxLon: 9.5, MaxLat: 54.9},
+					Near:   &entities.Coordinate{Latitude: 54.82, Longitude: 9.48},
+					Radius: 250,
+				},
+			},
+		).Return(testFilterTrees, int64(len(testFilterTrees)), nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree", nil)
+		query := req.URL.Query()
+		query.Add("bbox", "9.4,54.7,9.5,54.9")
+		query.Add("near", "54.82,9.48")
+		query.Add("radius", "250")
+		req.URL.RawQuery = query.Encode()
+
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		mockTreeService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 when bounding box is invalid", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeService := serviceMock.NewMockTreeService(t)
+		app.Get("/v1/tree", tree.GetAllTrees(mockTreeService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree?bbox=9.4,54.7", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockTreeService.AssertNotCalled(t, "GetAll")
+	})
 }
 
 func TestGetTreeBySensorID(t *testing.T) {
@@ -306,6 +359,7 @@ func TestUpdateTree(t *testing.T) {
 		// then
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, etag.New(testTree.Version), resp.Header.Get(fiber.HeaderETag))
 
 		var response httpEntities.TreeResponse
 		err = json.NewDecoder(resp.Body).Decode(&response)
@@ -374,6 +428,33 @@ func TestUpdateTree(t *testing.T) {
 		mockTreeService.AssertExpectations(t)
 	})
 
+	t.Run("should return 412 Precondition Failed if the tree has been modified", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeService := serviceMock.NewMockTreeService(t)
+		app.Put("/v1/tree/:id", tree.UpdateTree(mockTreeService))
+
+		treeID := int32(999)
+		mockTreeService.EXPECT().Update(
+			mock.Anything,
+			treeID,
+			mock.AnythingOfType("*entities.TreeUpdate"),
+		).Return(nil, service.ErrVersionMismatch)
+
+		// when
+		reqBody := TestTreeUpdateRequest
+		reqBodyBytes, _ := json.Marshal(reqBody)
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/tree/"+strconv.Itoa(int(treeID)), bytes.NewBuffer(reqBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		mockTreeService.AssertExpectations(t)
+	})
+
 	t.Run("should return 500 Internal Server Error on service error", func(t *testing.T) {
 		app := fiber.New()
 		mockTreeService := serviceMock.NewMockTreeService(t)
@@ -9,7 +9,10 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 )
 
@@ -34,6 +37,10 @@ var (
 // @Param			watering_statuses	query	[]string	false	"Watering statuses"
 // @Param			regions				query	[]string	false	"Regions"
 // @Param			provider			query	string		false	"Provider"
+// @Param			bbox				query	string		false	"Bounding box as minLon,minLat,maxLon,maxLat"
+// @Param			near				query	string		false	"Sort by distance to lat,lon, the nearest first"
+// @Param			radius				query	number		false	"Radius in meters around near"
+// @Param			polygon				query	string		false	"Polygon or multi polygon as WKT or GeoJSON with longitude and latitude"
 // @Security		Keycloak
 func GetAllTreeClusters(svc service.TreeClusterService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -67,6 +74,7 @@ func GetAllTreeClusters(svc service.TreeClusterService) fiber.Handler {
 // @Tags			Tree Cluster
 // @Produce		json
 // @Success		200	{object}	entities.TreeClusterResponse
+// @Header			200	{string}	ETag	"Version of the tree cluster"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -90,6 +98,7 @@ func GetTreeClusterByID(svc service.TreeClusterService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(treeClusterMapper.FromResponse(domainData))
 	}
 }
@@ -100,6 +109,7 @@ func GetTreeClusterByID(svc service.TreeClusterService) fiber.Handler {
 // @Tags			Tree Cluster
 // @Produce		json
 // @Success		201	{object}	entities.TreeClusterResponse
+// @Header			201	{string}	ETag	"Version of the tree cluster"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -117,13 +127,20 @@ func CreateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 
+		polygon, err := utils.GeoJSONToWKT(req.Polygon)
+		if err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "polygon must be a GeoJSON geometry"))
+		}
+
 		domainReq := treeClusterMapper.FromCreateRequest(&req)
+		domainReq.Polygon = polygon
 		domainData, err := svc.Create(ctx, domainReq)
 		if err != nil {
 			return errorhandler.HandleError(err)
 		}
 
 		data := treeClusterMapper.FromResponse(domainData)
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.Status(fiber.StatusCreated).JSON(data)
 	}
 }
@@ -134,14 +151,17 @@ func CreateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 // @Tags			Tree Cluster
 // @Produce		json
 // @Success		200	{object}	entities.TreeClusterResponse
+// @Header			200	{string}	ETag	"Version of the tree cluster"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/cluster/{cluster_id} [put]
 // @Param			cluster_id	path	int									true	"Tree Cluster ID"
 // @Param			body		body	entities.TreeClusterUpdateRequest	true	"Tree Cluster Update Request"
+// @Param			If-Match	header	string								false	"ETag of the tree cluster, the request fails with 412 if the tree cluster has been modified since"
 // @Security		Keycloak
 func UpdateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -157,12 +177,19 @@ func UpdateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 
+		polygon, err := utils.GeoJSONToWKT(req.Polygon)
+		if err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, "polygon must be a GeoJSON geometry"))
+		}
+
 		domainReq := treeClusterMapper.FromUpdateRequest(&req)
+		domainReq.Polygon = polygon
 		domainData, err := svc.Update(ctx, int32(id), domainReq)
 		if err != nil {
 			return errorhandler.HandleError(err)
 		}
 
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(treeClusterMapper.FromResponse(domainData))
 	}
 }
@@ -177,9 +204,11 @@ func UpdateTreeCluster(svc service.TreeClusterService) fiber.Handler {
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/cluster/{cluster_id} [delete]
-// @Param			cluster_id	path	int	true	"Tree Cluster ID"
+// @Param			cluster_id	path	int		true	"Tree Cluster ID"
+// @Param			If-Match	header	string	false	"ETag of the tree cluster, the request fails with 412 if the tree cluster has been modified since"
 // @Security		Keycloak
 func DeleteTreeCluster(svc service.TreeClusterService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -199,6 +228,91 @@ func DeleteTreeCluster(svc service.TreeClusterService) fiber.Handler {
 	}
 }
 
+// @Summary		Get tree cluster suggestions
+// @Description	Suggest tree clusters by grouping trees that are close to each other. By default only trees without a tree cluster are grouped.
+// @Id				get-tree-cluster-suggestions
+// @Tags			Tree Cluster
+// @Produce		json
+// @Success		200	{object}	entities.TreeClusterSuggestionListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/cluster/suggestions [get]
+// @Param			distance			query	number	false	"Maximum distance in meters between neighbouring trees, defaults to 30"
+// @Param			min_trees			query	int		false	"Minimum number of trees per suggestion, defaults to 2"
+// @Param			max_trees			query	int		false	"Maximum number of trees per suggestion, defaults to 40"
+// @Param			include_clustered	query	bool	false	"Re-evaluate trees that already belong to a tree cluster"
+// @Security		Keycloak
+func GetTreeClusterSuggestions(svc service.TreeClusterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var query domain.TreeClusterSuggestionQuery
+		if err := c.QueryParser(&query); err != nil {
+			return errorhandler.HandleError(service.NewError(service.BadRequest, err.Error()))
+		}
+
+		domainData, err := svc.GetSuggestions(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]*entities.TreeClusterSuggestionResponse, len(domainData))
+		for i, suggestion := range domainData {
+			data[i] = treeClusterMapper.FromSuggestionResponse(suggestion)
+		}
+
+		return c.JSON(entities.TreeClusterSuggestionListResponse{
+			Data: data,
+		})
+	}
+}
+
+// @Summary		Accept tree cluster suggestions
+// @Description	Create or update the tree clusters of the accepted suggestions in a single transaction
+// @Id				accept-tree-cluster-suggestions
+// @Tags			Tree Cluster
+// @Produce		json
+// @Success		201	{object}	entities.TreeClusterListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/cluster/suggestions [post]
+// @Param			body	body	[]entities.TreeClusterSuggestionAcceptRequest	true	"Accepted tree cluster suggestions"
+// @Security		Keycloak
+func AcceptTreeClusterSuggestions(svc service.TreeClusterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req []*entities.TreeClusterSuggestionAcceptRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := make([]*domain.TreeClusterSuggestionAccept, len(req))
+		for i, accept := range req {
+			domainReq[i] = treeClusterMapper.FromSuggestionAcceptRequest(accept)
+		}
+
+		domainData, err := svc.AcceptSuggestions(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]*entities.TreeClusterInListResponse, len(domainData))
+		for i, tc := range domainData {
+			data[i] = treeClusterMapper.FromInListResponse(tc)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entities.TreeClusterListResponse{
+			Data: data,
+		})
+	}
+}
+
 func fillTreeClusterQueryParams(c *fiber.Ctx) (domain.TreeClusterQuery, error) {
 	var filter domain.TreeClusterQuery
 
@@ -206,5 +320,11 @@ func fillTreeClusterQueryParams(c *fiber.Ctx) (domain.TreeClusterQuery, error) {
 		return domain.TreeClusterQuery{}, err
 	}
 
+	spatialQuery, err := spatial.ParseQuery(c)
+	if err != nil {
+		return domain.TreeClusterQuery{}, err
+	}
+	filter.SpatialQuery = spatialQuery
+
 	return filter, nil
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -450,6 +452,7 @@ func TestGetTreeClusterByID(t *testing.T) {
 		// then
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, etag.New(TestCluster.Version), resp.Header.Get(fiber.HeaderETag))
 
 		var response serverEntities.TreeClusterResponse
 		err = utils.ParseJSONResponse(resp, &response)
@@ -574,6 +577,70 @@ func TestCreateTreeCluster(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("should create tree cluster with polygon and return its metrics", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.CreateTreeCluster(mockClusterService)
+		app.Post("/v1/cluster", handler)
+
+		created := &entities.TreeCluster{
+			ID:            1,
+			Name:          "Cluster 1",
+			Polygon:       "POLYGON ((9.44 54.8, 9.45 54.8, 9.45 54.81, 9.44 54.8))",
+			PolygonManual: true,
+			Area:          utils.P(20000.0),
+			Perimeter:     utils.P(600.0),
+			Trees:         []*entities.Tree{{ID: 1}, {ID: 2}},
+		}
+
+		mockClusterService.EXPECT().Create(
+			mock.Anything,
+			mock.MatchedBy(func(tc *entities.TreeClusterCreate) bool {
+				return strings.HasPrefix(tc.Polygon, "POLYGON")
+			}),
+		).Return(created, nil)
+
+		// when
+		body := []byte(`{"name": "Cluster 1", "tree_ids": [], "polygon": {"type": "Polygon", "coordinates": [[[9.44, 54.8], [9.45, 54.8], [9.45, 54.81], [9.44, 54.8]]]}}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/cluster", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.TreeClusterResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"type":"Polygon","coordinates":[[[9.44,54.8],[9.45,54.8],[9.45,54.81],[9.44,54.8]]]}`, string(response.Polygon))
+		assert.True(t, response.PolygonManual)
+		assert.Equal(t, 20000.0, *response.Area)
+		assert.Equal(t, 600.0, *response.Perimeter)
+		assert.Equal(t, 1.0, response.TreeDensity)
+
+		mockClusterService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 Bad Request for invalid polygon", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.CreateTreeCluster(mockClusterService)
+		app.Post("/v1/cluster", handler)
+
+		// when
+		body := []byte(`{"name": "Cluster 1", "tree_ids": [], "polygon": {"type": "Polygon"}}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/cluster", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
 	t.Run("should return 500 Internal Server Error for service failure", func(t *testing.T) {
 		app := fiber.New()
 		mockClusterService := serviceMock.NewMockTreeClusterService(t)
@@ -694,6 +761,33 @@ func TestUpdateTreeCluster(t *testing.T) {
 		mockClusterService.AssertExpectations(t)
 	})
 
+	t.Run("should return 412 Precondition Failed if the tree cluster has been modified", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.UpdateTreeCluster(mockClusterService)
+		app.Put("/v1/cluster/:treecluster_id", handler)
+
+		mockClusterService.EXPECT().Update(
+			mock.Anything,
+			int32(1),
+			mock.Anything,
+		).Return(nil, service.ErrVersionMismatch)
+
+		// when
+		body, _ := json.Marshal(TestClusterRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/cluster/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		mockClusterService.AssertExpectations(t)
+	})
+
 	t.Run("should return 500 Internal Server Error for service failure", func(t *testing.T) {
 		app := fiber.New()
 		mockClusterService := serviceMock.NewMockTreeClusterService(t)
@@ -778,3 +872,167 @@ func TestDeleteTreeCluster(t *testing.T) {
 		mockClusterService.AssertExpectations(t)
 	})
 }
+
+func TestGetTreeClusterSuggestions(t *testing.T) {
+	t.Run("should return tree cluster suggestions successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.GetTreeClusterSuggestions(mockClusterService)
+		app.Get("/v1/cluster/suggestions", handler)
+
+		suggestions := []*entities.TreeClusterSuggestion{
+			{
+				TreeIDs:       []int32{1, 2},
+				Latitude:      54.82124518093376,
+				Longitude:     9.485702120628517,
+				Region:        &entities.Region{ID: 1, Name: "Mürwik"},
+				SoilCondition: entities.TreeSoilConditionSandig,
+				TreeClusterID: utils.P(int32(1)),
+			},
+		}
+
+		mockClusterService.EXPECT().GetSuggestions(
+			mock.Anything, entities.TreeClusterSuggestionQuery{Distance: 25, MaxTrees: 10, IncludeClustered: true},
+		).Return(suggestions, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/suggestions?distance=25&max_trees=10&include_clustered=true", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.TreeClusterSuggestionListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, []int32{1, 2}, response.Data[0].TreeIDs)
+		assert.Equal(t, "Mürwik", response.Data[0].Region.Name)
+		assert.Equal(t, serverEntities.TreeSoilConditionSandig, response.Data[0].SoilCondition)
+		assert.Equal(t, int32(1), *response.Data[0].TreeClusterID)
+
+		mockClusterService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 Bad Request for invalid query", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.GetTreeClusterSuggestions(mockClusterService)
+		app.Get("/v1/cluster/suggestions", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/suggestions?distance=far", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 Bad Request when service rejects query", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.GetTreeClusterSuggestions(mockClusterService)
+		app.Get("/v1/cluster/suggestions", handler)
+
+		mockClusterService.EXPECT().GetSuggestions(
+			mock.Anything, entities.TreeClusterSuggestionQuery{Distance: 1000},
+		).Return(nil, service.ErrSuggestionQueryInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/suggestions?distance=1000", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockClusterService.AssertExpectations(t)
+	})
+}
+
+func TestAcceptTreeClusterSuggestions(t *testing.T) {
+	t.Run("should accept tree cluster suggestions successfully", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.AcceptTreeClusterSuggestions(mockClusterService)
+		app.Post("/v1/cluster/suggestions", handler)
+
+		mockClusterService.EXPECT().AcceptSuggestions(
+			mock.Anything,
+			[]*entities.TreeClusterSuggestionAccept{
+				{Name: "Cluster 1", TreeIDs: []int32{1, 2}, SoilCondition: entities.TreeSoilConditionLehmig},
+			},
+		).Return([]*entities.TreeCluster{TestCluster}, nil)
+
+		// when
+		body, _ := json.Marshal([]serverEntities.TreeClusterSuggestionAcceptRequest{
+			{Name: "Cluster 1", TreeIDs: []int32{1, 2}, SoilCondition: serverEntities.TreeSoilConditionLehmig},
+		})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/cluster/suggestions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.TreeClusterListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, TestCluster.Name, response.Data[0].Name)
+
+		mockClusterService.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 Bad Request for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.AcceptTreeClusterSuggestions(mockClusterService)
+		app.Post("/v1/cluster/suggestions", handler)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/cluster/suggestions", bytes.NewBufferString(`{"tree_ids": [1]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 Bad Request when a tree is part of two suggestions", func(t *testing.T) {
+		app := fiber.New()
+		mockClusterService := serviceMock.NewMockTreeClusterService(t)
+		handler := treecluster.AcceptTreeClusterSuggestions(mockClusterService)
+		app.Post("/v1/cluster/suggestions", handler)
+
+		mockClusterService.EXPECT().AcceptSuggestions(
+			mock.Anything,
+			mock.Anything,
+		).Return(nil, service.ErrSuggestionTreeTwice)
+
+		// when
+		body, _ := json.Marshal([]serverEntities.TreeClusterSuggestionAcceptRequest{
+			{Name: "Cluster 1", TreeIDs: []int32{1, 2}},
+			{Name: "Cluster 2", TreeIDs: []int32{2}},
+		})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/cluster/suggestions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockClusterService.AssertExpectations(t)
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 func RegisterRoutes(r fiber.Router, svc service.TreeClusterService) {
 	r.Get("/", GetAllTreeClusters(svc))
+	r.Get("/suggestions", GetTreeClusterSuggestions(svc))
+	r.Post("/suggestions", AcceptTreeClusterSuggestions(svc))
 	r.Get("/:treecluster_id", GetTreeClusterByID(svc))
 	r.Post("/", CreateTreeCluster(svc))
 	r.Put("/:treecluster_id", UpdateTreeCluster(svc))
@@ -65,6 +65,49 @@ func TestRegisterRoutes(t *testing.T) {
 		})
 	})
 
+	t.Run("/v1/cluster/suggestions", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockClusterService := serviceMock.NewMockTreeClusterService(t)
+			app := fiber.New()
+			treecluster.RegisterRoutes(app, mockClusterService)
+
+			mockClusterService.EXPECT().GetSuggestions(
+				mock.Anything,
+				entities.TreeClusterSuggestionQuery{},
+			).Return([]*entities.TreeClusterSuggestion{}, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/suggestions", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call POST handler", func(t *testing.T) {
+			mockClusterService := serviceMock.NewMockTreeClusterService(t)
+			app := fiber.New()
+			treecluster.RegisterRoutes(app, mockClusterService)
+
+			mockClusterService.EXPECT().AcceptSuggestions(
+				mock.Anything,
+				mock.Anything,
+			).Return([]*entities.TreeCluster{TestCluster}, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/suggestions", bytes.NewBufferString(`[{"name": "Cluster 1", "tree_ids": [1]}]`))
+			req.Header.Set("Content-Type", "application/json")
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	})
+
 	t.Run("/v1/cluster/:id", func(t *testing.T) {
 		t.Run("should call GET handler", func(t *testing.T) {
 			mockClusterService := serviceMock.NewMockTreeClusterService(t)
@@ -0,0 +1,238 @@
+package treeimport
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+var (
+	treeImportMapper = generated.TreeImportHTTPMapperImpl{}
+)
+
+// @Summary		Get all tree imports
+// @Description	Get all tree import jobs, newest first
+// @Id				get-all-tree-imports
+// @Tags			Tree Import
+// @Produce		json
+// @Success		200	{object}	entities.TreeImportListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree-import [get]
+// @Security		Keycloak
+func GetAllTreeImports(svc service.TreeImportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.TreeImportListResponse{
+			Data: treeImportMapper.FromResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get tree import by ID
+// @Description	Get a tree import job by ID including the row errors of the dry run
+// @Id				get-tree-import-by-id
+// @Tags			Tree Import
+// @Produce		json
+// @Success		200	{object}	entities.TreeImportResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree-import/{id} [get]
+// @Param			id	path	int	true	"Tree Import ID"
+// @Security		Keycloak
+func GetTreeImportByID(svc service.TreeImportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(treeImportMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Create tree import
+// @Description	Upload a csv, geojson or geopackage file with trees. The file is validated asynchronously (dry run), poll the import job for the result and commit it afterwards.
+// @Id				create-tree-import
+// @Tags			Tree Import
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		202	{object}	entities.TreeImportResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree-import [post]
+// @Param			file		formData	file	true	"Tree inventory file"
+// @Param			format		formData	string	false	"File format (csv, geojson, gpkg), detected from the file extension if empty"
+// @Param			mapping		formData	string	false	"JSON object mapping tree fields to columns of the file, e.g. {\"number\":\"Baumnummer\"}"
+// @Param			provider	formData	string	false	"Provider of all trees without provider column"
+// @Security		Keycloak
+func CreateTreeImport(svc service.TreeImportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		mapping := domain.TreeImportMapping{}
+		if raw := c.FormValue("mapping"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid mapping: "+err.Error())
+			}
+		}
+
+		format := domain.TreeImportFormat(c.FormValue("format"))
+		if format == "" {
+			format = formatFromFileName(fileHeader.Filename)
+		}
+
+		domainData, err := svc.Create(ctx, &domain.TreeImportCreate{
+			FileName:  fileHeader.Filename,
+			Format:    format,
+			Mapping:   mapping,
+			Provider:  c.FormValue("provider"),
+			File:      data,
+			CreatedBy: creator(c),
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusAccepted).JSON(treeImportMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Commit tree import
+// @Description	Queue a validated tree import job. All trees are created or updated in a single transaction, existing trees are matched by provider and number.
+// @Id				commit-tree-import
+// @Tags			Tree Import
+// @Produce		json
+// @Success		202	{object}	entities.TreeImportResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		409	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree-import/{id}/commit [post]
+// @Param			id	path	int	true	"Tree Import ID"
+// @Security		Keycloak
+func CommitTreeImport(svc service.TreeImportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.Commit(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusAccepted).JSON(treeImportMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete tree import
+// @Description	Delete a tree import job and its uploaded file. Imported trees are kept.
+// @Id				delete-tree-import
+// @Tags			Tree Import
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		409	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree-import/{id} [delete]
+// @Param			id	path	int	true	"Tree Import ID"
+// @Security		Keycloak
+func DeleteTreeImport(svc service.TreeImportService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.Delete(ctx, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func formatFromFileName(name string) domain.TreeImportFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return domain.TreeImportFormatCSV
+	case ".geojson", ".json":
+		return domain.TreeImportFormatGeoJSON
+	case ".gpkg":
+		return domain.TreeImportFormatGeoPackage
+	default:
+		return ""
+	}
+}
+
+// creator returns the user id of the jwt or the prefix of the api key that authenticated the request
+func creator(c *fiber.Ctx) string {
+	if apiKey, ok := c.UserContext().Value(enums.ContextKeyAPIKey).(*domain.APIKey); ok {
+		return "api-key:" + apiKey.Prefix
+	}
+
+	if claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims); ok {
+		if sub, err := claims.GetSubject(); err == nil {
+			return sub
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,313 @@
+package treeimport_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/treeimport"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newUploadRequest(t *testing.T, fileName, content string, fields map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", fileName)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	for key, value := range fields {
+		assert.NoError(t, writer.WriteField(key, value))
+	}
+	assert.NoError(t, writer.Close())
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/tree-import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestGetAllTreeImports(t *testing.T) {
+	t.Run("should return all tree imports", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Get("/v1/tree-import", treeimport.GetAllTreeImports(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().GetAll(mock.Anything).Return(TestTreeImports, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree-import", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.TreeImportListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, serverEntities.TreeImportStatusInvalid, response.Data[0].Status)
+		assert.Equal(t, "Baumnummer", response.Data[0].Mapping["number"])
+		assert.Equal(t, int32(2), response.Data[1].CreatedRows)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Get("/v1/tree-import", treeimport.GetAllTreeImports(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().GetAll(mock.Anything).Return(nil, service.NewError(service.InternalError, "service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree-import", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetTreeImportByID(t *testing.T) {
+	t.Run("should return tree import with row errors", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Get("/v1/tree-import/:id", treeimport.GetTreeImportByID(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestTreeImport, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree-import/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.TreeImportResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestTreeImport.ID, response.ID)
+		assert.Len(t, response.RowErrors, 1)
+		assert.Equal(t, int32(2), response.RowErrors[0].Row)
+		assert.Equal(t, "planting_year", response.RowErrors[0].Field)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Get("/v1/tree-import/:id", treeimport.GetTreeImportByID(mockTreeImportService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree-import/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when tree import does not exist", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Get("/v1/tree-import/:id", treeimport.GetTreeImportByID(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().GetByID(mock.Anything, int32(1)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/tree-import/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCreateTreeImport(t *testing.T) {
+	t.Run("should accept uploaded file and detect format from file name", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Post("/v1/tree-import", treeimport.CreateTreeImport(mockTreeImportService))
+
+		content := "Baumnummer;planting_year\nT-1;2010\n"
+		mockTreeImportService.EXPECT().Create(mock.Anything, mock.MatchedBy(func(c *entities.TreeImportCreate) bool {
+			return c.FileName == "trees.csv" &&
+				c.Format == entities.TreeImportFormatCSV &&
+				c.Mapping[entities.TreeImportFieldNumber] == "Baumnummer" &&
+				c.Provider == "city" &&
+				string(c.File) == content
+		})).Return(TestTreeImport, nil)
+
+		// when
+		req := newUploadRequest(t, "trees.csv", content, map[string]string{
+			"mapping":  `{"number":"Baumnummer"}`,
+			"provider": "city",
+		})
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	})
+
+	t.Run("should prefer format of the form", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Post("/v1/tree-import", treeimport.CreateTreeImport(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().Create(mock.Anything, mock.MatchedBy(func(c *entities.TreeImportCreate) bool {
+			return c.Format == entities.TreeImportFormatGeoJSON
+		})).Return(TestTreeImport, nil)
+
+		// when
+		req := newUploadRequest(t, "export.txt", `{"type":"FeatureCollection","features":[]}`, map[string]string{"format": "geojson"})
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when mapping is invalid json", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Post("/v1/tree-import", treeimport.CreateTreeImport(mockTreeImportService))
+
+		// when
+		req := newUploadRequest(t, "trees.csv", "number\nT-1\n", map[string]string{"mapping": "number=Baumnummer"})
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when file is missing", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Post("/v1/tree-import", treeimport.CreateTreeImport(mockTreeImportService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/tree-import", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 when service rejects format", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Post("/v1/tree-import", treeimport.CreateTreeImport(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, service.ErrTreeImportFormatInvalid)
+
+		// when
+		req := newUploadRequest(t, "trees.xlsx", "data", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCommitTreeImport(t *testing.T) {
+	t.Run("should queue tree import", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Post("/v1/tree-import/:id/commit", treeimport.CommitTreeImport(mockTreeImportService))
+
+		queued := *TestTreeImport
+		queued.Status = entities.TreeImportStatusQueued
+		mockTreeImportService.EXPECT().Commit(mock.Anything, int32(1)).Return(&queued, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/tree-import/1/commit", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var response serverEntities.TreeImportResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, serverEntities.TreeImportStatusQueued, response.Status)
+	})
+
+	t.Run("should return 409 when tree import is not validated", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Post("/v1/tree-import/:id/commit", treeimport.CommitTreeImport(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().Commit(mock.Anything, int32(1)).Return(nil, service.ErrTreeImportNotValidated)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/tree-import/1/commit", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestDeleteTreeImport(t *testing.T) {
+	t.Run("should delete tree import", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Delete("/v1/tree-import/:id", treeimport.DeleteTreeImport(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/tree-import/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should return 409 when tree import is in progress", func(t *testing.T) {
+		app := fiber.New()
+		mockTreeImportService := serviceMock.NewMockTreeImportService(t)
+		app.Delete("/v1/tree-import/:id", treeimport.DeleteTreeImport(mockTreeImportService))
+
+		mockTreeImportService.EXPECT().Delete(mock.Anything, int32(1)).Return(service.ErrTreeImportInProgress)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/tree-import/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
@@ -0,0 +1,14 @@
+package treeimport
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.TreeImportService) {
+	r.Get("/", GetAllTreeImports(svc))
+	r.Get("/:id", GetTreeImportByID(svc))
+	r.Post("/", CreateTreeImport(svc))
+	r.Post("/:id/commit", CommitTreeImport(svc))
+	r.Delete("/:id", DeleteTreeImport(svc))
+}
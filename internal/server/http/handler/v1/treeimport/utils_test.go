@@ -0,0 +1,47 @@
+package treeimport_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	now = time.Now()
+
+	TestTreeImport = &entities.TreeImportJob{
+		ID:        1,
+		CreatedAt: now,
+		UpdatedAt: now,
+		FileName:  "trees.csv",
+		Format:    entities.TreeImportFormatCSV,
+		Status:    entities.TreeImportStatusInvalid,
+		Mapping:   entities.TreeImportMapping{entities.TreeImportFieldNumber: "Baumnummer"},
+		Provider:  "city",
+		TotalRows: 2,
+		ValidRows: 1,
+		RowErrors: []entities.TreeImportRowError{
+			{Row: 2, Field: entities.TreeImportFieldPlantingYear, Message: `"abc" is not a valid year`},
+		},
+		CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+	}
+
+	TestTreeImports = []*entities.TreeImportJob{
+		TestTreeImport,
+		{
+			ID:          2,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			FileName:    "trees.geojson",
+			Format:      entities.TreeImportFormatGeoJSON,
+			Status:      entities.TreeImportStatusCommitted,
+			Mapping:     entities.TreeImportMapping{},
+			TotalRows:   3,
+			ValidRows:   3,
+			CreatedRows: 2,
+			UpdatedRows: 1,
+			RowErrors:   []entities.TreeImportRowError{},
+			CreatedBy:   "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		},
+	}
+)
@@ -9,7 +9,9 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 )
 
@@ -18,7 +20,7 @@ var (
 )
 
 // @Summary		Get all vehicles
-// @Description	Get all vehicles
+// @Description	Get all vehicles. Vehicles have no position, so the spatial filters bbox, near, radius and polygon are rejected.
 // @Id				get-all-vehicles
 // @Tags			Vehicle
 // @Produce		json
@@ -48,6 +50,10 @@ func GetAllVehicles(svc service.VehicleService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
+		if err := spatial.RejectQuery(c); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
 		domainData, totalCount, err = svc.GetAll(ctx, query)
 
 		if err != nil {
@@ -72,6 +78,7 @@ func GetAllVehicles(svc service.VehicleService) fiber.Handler {
 // @Tags			Vehicle
 // @Produce		json
 // @Success		200	{object}	entities.VehicleResponse
+// @Header			200	{string}	ETag	"Version of the vehicle"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -95,6 +102,7 @@ func GetVehicleByID(svc service.VehicleService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(vehicleMapper.FromResponse(domainData))
 	}
 }
@@ -138,6 +146,7 @@ func GetVehicleByPlate(svc service.VehicleService) fiber.Handler {
 // @Tags			Vehicle
 // @Produce		json
 // @Success		201	{object}	entities.VehicleResponse
+// @Header			201	{string}	ETag	"Version of the vehicle"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -162,6 +171,7 @@ func CreateVehicle(svc service.VehicleService) fiber.Handler {
 		}
 
 		data := vehicleMapper.FromResponse(domainData)
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.Status(fiber.StatusCreated).JSON(data)
 	}
 }
@@ -172,14 +182,17 @@ func CreateVehicle(svc service.VehicleService) fiber.Handler {
 // @Tags			Vehicle
 // @Produce		json
 // @Success		200	{object}	entities.VehicleResponse
+// @Header			200	{string}	ETag	"Version of the vehicle"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/vehicle/{id} [put]
-// @Param			id		path	string							true	"Vehicle ID"
-// @Param			body	body	entities.VehicleUpdateRequest	true	"Vehicle Update Request"
+// @Param			id			path	string							true	"Vehicle ID"
+// @Param			body		body	entities.VehicleUpdateRequest	true	"Vehicle Update Request"
+// @Param			If-Match	header	string							false	"ETag of the vehicle, the request fails with 412 if the vehicle has been modified since"
 // @Security		Keycloak
 func UpdateVehicle(svc service.VehicleService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -201,6 +214,7 @@ func UpdateVehicle(svc service.VehicleService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(vehicleMapper.FromResponse(domainData))
 	}
 }
@@ -273,9 +287,11 @@ func ArchiveVehicle(svc service.VehicleService) fiber.Handler {
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/vehicle/{id} [delete]
-// @Param			id	path	int	true	"Vehicle ID"
+// @Param			id			path	int		true	"Vehicle ID"
+// @Param			If-Match	header	string	false	"ETag of the vehicle, the request fails with 412 if the vehicle has been modified since"
 // @Security		Keycloak
 func DeleteVehicle(svc service.VehicleService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -15,6 +15,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -128,6 +129,23 @@ func TestGetAllVehicles(t *testing.T) {
 		mockVehicleService.AssertExpectations(t)
 	})
 
+	t.Run("should return error when a spatial filter is given", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(middleware.PaginationMiddleware())
+		mockVehicleService := serviceMock.NewMockVehicleService(t)
+		handler := vehicle.GetAllVehicles(mockVehicleService)
+		app.Get("/v1/vehicle", handler)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/vehicle?bbox=9.4,54.7,9.5,54.9", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockVehicleService.AssertNotCalled(t, "GetAll")
+	})
+
 	t.Run("should return all vehicles successfully with provider", func(t *testing.T) {
 		app := fiber.New()
 		mockVehicleService := serviceMock.NewMockVehicleService(t)
@@ -332,6 +350,7 @@ func TestGetVehicleByID(t *testing.T) {
 		// then
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, etag.New(TestVehicle.Version), resp.Header.Get(fiber.HeaderETag))
 
 		var response serverEntities.VehicleResponse
 		err = utils.ParseJSONResponse(resp, &response)
@@ -671,6 +690,33 @@ func TestUpdateVehicle(t *testing.T) {
 		mockVehicleService.AssertExpectations(t)
 	})
 
+	t.Run("should return 412 Precondition Failed if the vehicle has been modified", func(t *testing.T) {
+		app := fiber.New()
+		mockVehicleService := serviceMock.NewMockVehicleService(t)
+		handler := vehicle.UpdateVehicle(mockVehicleService)
+		app.Put("/v1/vehicle/:id", handler)
+
+		mockVehicleService.EXPECT().Update(
+			mock.Anything,
+			int32(1),
+			mock.Anything,
+		).Return(nil, service.ErrVersionMismatch)
+
+		// when
+		body, _ := json.Marshal(TestVehicleRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/vehicle/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		mockVehicleService.AssertExpectations(t)
+	})
+
 	t.Run("should return 500 Internal Server Error for service failure", func(t *testing.T) {
 		app := fiber.New()
 		mockVehicleService := serviceMock.NewMockVehicleService(t)
@@ -13,6 +13,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 )
 
@@ -68,6 +69,7 @@ func GetAllWateringPlans(svc service.WateringPlanService) fiber.Handler {
 // @Tags			Watering Plan
 // @Produce		json
 // @Success		200	{object}	entities.WateringPlanResponse
+// @Header			200	{string}	ETag	"Version of the watering plan"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -91,6 +93,7 @@ func GetWateringPlanByID(svc service.WateringPlanService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(wateringPlanMapper.FromResponse(domainData))
 	}
 }
@@ -101,6 +104,7 @@ func GetWateringPlanByID(svc service.WateringPlanService) fiber.Handler {
 // @Tags			Watering Plan
 // @Produce		json
 // @Success		201	{object}	entities.WateringPlanResponse
+// @Header			201	{string}	ETag	"Version of the watering plan"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -125,6 +129,7 @@ func CreateWateringPlan(svc service.WateringPlanService) fiber.Handler {
 		}
 
 		data := wateringPlanMapper.FromResponse(domainData)
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.Status(fiber.StatusCreated).JSON(data)
 	}
 }
@@ -135,14 +140,17 @@ func CreateWateringPlan(svc service.WateringPlanService) fiber.Handler {
 // @Tags			Watering Plan
 // @Produce		json
 // @Success		200	{object}	entities.WateringPlanResponse
+// @Header			200	{string}	ETag	"Version of the watering plan"
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/watering-plan/{id} [put]
-// @Param			id		path	string								true	"Watering Plan ID"
-// @Param			body	body	entities.WateringPlanUpdateRequest	true	"Watering Plan Update Request"
+// @Param			id			path	string								true	"Watering Plan ID"
+// @Param			body		body	entities.WateringPlanUpdateRequest	true	"Watering Plan Update Request"
+// @Param			If-Match	header	string								false	"ETag of the watering plan, the request fails with 412 if the watering plan has been modified since"
 // @Security		Keycloak
 func UpdateWateringPlan(svc service.WateringPlanService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -164,6 +172,7 @@ func UpdateWateringPlan(svc service.WateringPlanService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
+		c.Set(fiber.HeaderETag, etag.New(domainData.Version))
 		return c.JSON(wateringPlanMapper.FromResponse(domainData))
 	}
 }
@@ -178,9 +187,11 @@ func UpdateWateringPlan(svc service.WateringPlanService) fiber.Handler {
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
+// @Failure		412	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
 // @Router			/v1/watering-plan/{id} [delete]
-// @Param			id	path	int	true	"Watering Plan ID"
+// @Param			id			path	int		true	"Watering Plan ID"
+// @Param			If-Match	header	string	false	"ETag of the watering plan, the request fails with 412 if the watering plan has been modified since"
 // @Security		Keycloak
 func DeleteWateringPlan(svc service.WateringPlanService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -16,6 +16,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -240,6 +241,7 @@ func TestGetWateringPlanByID(t *testing.T) {
 		// then
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, etag.New(TestWateringPlans[0].Version), resp.Header.Get(fiber.HeaderETag))
 
 		var response serverEntities.WateringPlanResponse
 		err = utils.ParseJSONResponse(resp, &response)
@@ -484,6 +486,33 @@ func TestUpdateWateringPlan(t *testing.T) {
 		mockWateringPlanService.AssertExpectations(t)
 	})
 
+	t.Run("should return 412 Precondition Failed if the watering plan has been modified", func(t *testing.T) {
+		app := fiber.New()
+		mockWateringPlanService := serviceMock.NewMockWateringPlanService(t)
+		handler := wateringplan.UpdateWateringPlan(mockWateringPlanService)
+		app.Put("/v1/watering-plan/:id", handler)
+
+		mockWateringPlanService.EXPECT().Update(
+			mock.Anything,
+			int32(1),
+			mock.Anything,
+		).Return(nil, service.ErrVersionMismatch)
+
+		// when
+		body, _ := json.Marshal(TestWateringPlanRequest)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/watering-plan/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		mockWateringPlanService.AssertExpectations(t)
+	})
+
 	t.Run("should return 500 Internal Server Error for service failure", func(t *testing.T) {
 		app := fiber.New()
 		mockWateringPlanService := serviceMock.NewMockWateringPlanService(t)
@@ -0,0 +1,210 @@
+package wateringplantemplate
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	templateMapper = generated.WateringPlanTemplateHTTPMapperImpl{}
+)
+
+// @Summary		Get all watering plan templates
+// @Description	Get all recurring watering plan templates
+// @Id				get-all-watering-plan-templates
+// @Tags			Watering Plan Template
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanTemplateListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan-template [get]
+// @Security		Keycloak
+func GetAllWateringPlanTemplates(svc service.WateringPlanTemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WateringPlanTemplateListResponse{
+			Data: templateMapper.FromResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get watering plan template by ID
+// @Description	Get watering plan template by ID
+// @Id				get-watering-plan-template-by-id
+// @Tags			Watering Plan Template
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanTemplateResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan-template/{id} [get]
+// @Param			id	path	int	true	"Watering Plan Template ID"
+// @Security		Keycloak
+func GetWateringPlanTemplateByID(svc service.WateringPlanTemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(templateMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Get occurrences of a watering plan template
+// @Description	Get the dates of a watering plan template that were created as watering plan or skipped because of a holiday. The created watering plans are edited or canceled like any other watering plan.
+// @Id				get-watering-plan-template-occurrences
+// @Tags			Watering Plan Template
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanTemplateOccurrenceListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan-template/{id}/occurrences [get]
+// @Param			id	path	int	true	"Watering Plan Template ID"
+// @Security		Keycloak
+func GetWateringPlanTemplateOccurrences(svc service.WateringPlanTemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetOccurrences(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WateringPlanTemplateOccurrenceListResponse{
+			Data: templateMapper.FromOccurrenceResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Create watering plan template
+// @Description	Create a recurring watering plan template. The watering plans are created ahead of time by a scheduled job, holidays are skipped.
+// @Id				create-watering-plan-template
+// @Tags			Watering Plan Template
+// @Produce		json
+// @Success		201	{object}	entities.WateringPlanTemplateResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan-template [post]
+// @Param			body	body	entities.WateringPlanTemplateCreateRequest	true	"Watering Plan Template Create Request"
+// @Security		Keycloak
+func CreateWateringPlanTemplate(svc service.WateringPlanTemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.WateringPlanTemplateCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := templateMapper.FromCreateRequest(&req)
+		domainData, err := svc.Create(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(templateMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Update watering plan template
+// @Description	Update a watering plan template. The changes apply to the watering plans that are not created yet.
+// @Id				update-watering-plan-template
+// @Tags			Watering Plan Template
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanTemplateResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan-template/{id} [put]
+// @Param			id		path	int											true	"Watering Plan Template ID"
+// @Param			body	body	entities.WateringPlanTemplateUpdateRequest	true	"Watering Plan Template Update Request"
+// @Security		Keycloak
+func UpdateWateringPlanTemplate(svc service.WateringPlanTemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.WateringPlanTemplateUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := templateMapper.FromUpdateRequest(&req)
+		domainData, err := svc.Update(ctx, int32(id), domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(templateMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete watering plan template
+// @Description	Delete a watering plan template. The already created watering plans are kept.
+// @Id				delete-watering-plan-template
+// @Tags			Watering Plan Template
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan-template/{id} [delete]
+// @Param			id	path	int	true	"Watering Plan Template ID"
+// @Security		Keycloak
+func DeleteWateringPlanTemplate(svc service.WateringPlanTemplateService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.Delete(ctx, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,308 @@
+package wateringplantemplate_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	wateringplantemplate "github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/watering_plan_template"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllWateringPlanTemplates(t *testing.T) {
+	t.Run("should return all templates", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Get("/v1/watering-plan-template", wateringplantemplate.GetAllWateringPlanTemplates(mockTemplateService))
+
+		mockTemplateService.EXPECT().GetAll(mock.Anything).Return(TestTemplates, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan-template", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringPlanTemplateListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, TestTemplate.Name, response.Data[0].Name)
+		assert.Equal(t, TestTemplate.RRule, response.Data[0].RRule)
+		assert.Equal(t, TestTemplate.StartDate, response.Data[0].StartDate)
+		assert.Equal(t, TestTemplate.SeasonStart, response.Data[0].SeasonStart)
+		assert.Equal(t, TestTemplate.TreeClusterIDs, response.Data[0].TreeClusterIDs)
+		assert.Equal(t, TestTemplate.UserIDs, response.Data[0].UserIDs)
+		assert.Nil(t, response.Data[1].TrailerID)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Get("/v1/watering-plan-template", wateringplantemplate.GetAllWateringPlanTemplates(mockTemplateService))
+
+		mockTemplateService.EXPECT().GetAll(mock.Anything).Return(nil, service.NewError(service.InternalError, "db down"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan-template", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetWateringPlanTemplateByID(t *testing.T) {
+	t.Run("should return template", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Get("/v1/watering-plan-template/:id", wateringplantemplate.GetWateringPlanTemplateByID(mockTemplateService))
+
+		mockTemplateService.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestTemplate, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan-template/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringPlanTemplateResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestTemplate.ID, response.ID)
+		assert.Equal(t, TestTemplate.TransporterID, response.TransporterID)
+		assert.Equal(t, TestTemplate.TrailerID, response.TrailerID)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Get("/v1/watering-plan-template/:id", wateringplantemplate.GetWateringPlanTemplateByID(mockTemplateService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan-template/abc", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when template not found", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Get("/v1/watering-plan-template/:id", wateringplantemplate.GetWateringPlanTemplateByID(mockTemplateService))
+
+		mockTemplateService.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, storage.ErrEntityNotFound("not found").Error()))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan-template/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetWateringPlanTemplateOccurrences(t *testing.T) {
+	t.Run("should return occurrences", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Get("/v1/watering-plan-template/:id/occurrences", wateringplantemplate.GetWateringPlanTemplateOccurrences(mockTemplateService))
+
+		mockTemplateService.EXPECT().GetOccurrences(mock.Anything, int32(1)).Return(TestOccurrences, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/watering-plan-template/1/occurrences", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WateringPlanTemplateOccurrenceListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, utils.P(int32(7)), response.Data[0].WateringPlanID)
+		assert.False(t, response.Data[0].Holiday)
+		assert.Nil(t, response.Data[1].WateringPlanID)
+		assert.True(t, response.Data[1].Holiday)
+	})
+}
+
+func TestCreateWateringPlanTemplate(t *testing.T) {
+	t.Run("should create template", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Post("/v1/watering-plan-template", wateringplantemplate.CreateWateringPlanTemplate(mockTemplateService))
+
+		reqBody := serverEntities.WateringPlanTemplateCreateRequest{
+			Name:           TestTemplate.Name,
+			RRule:          TestTemplate.RRule,
+			StartDate:      TestTemplate.StartDate,
+			SeasonStart:    TestTemplate.SeasonStart,
+			SeasonEnd:      TestTemplate.SeasonEnd,
+			TreeClusterIDs: TestTemplate.TreeClusterIDs,
+			TransporterID:  TestTemplate.TransporterID,
+			UserIDs:        []string{"6a1078e8-80fd-458f-b74e-e388fe2dd6ab"},
+			Enabled:        true,
+		}
+		mockTemplateService.EXPECT().Create(mock.Anything, mock.MatchedBy(func(c *entities.WateringPlanTemplateCreate) bool {
+			return c.Name == reqBody.Name &&
+				c.RRule == reqBody.RRule &&
+				c.StartDate.Equal(reqBody.StartDate) &&
+				c.SeasonEnd == reqBody.SeasonEnd &&
+				assert.ObjectsAreEqual(reqBody.TreeClusterIDs, c.TreeClusterIDs) &&
+				assert.ObjectsAreEqual(TestTemplate.UserIDs, c.UserIDs) &&
+				c.Enabled
+		})).Return(TestTemplate, nil)
+
+		// when
+		body, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-plan-template", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.WateringPlanTemplateResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestTemplate.ID, response.ID)
+	})
+
+	t.Run("should map invalid user ids to the nil uuid", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Post("/v1/watering-plan-template", wateringplantemplate.CreateWateringPlanTemplate(mockTemplateService))
+
+		mockTemplateService.EXPECT().Create(mock.Anything, mock.MatchedBy(func(c *entities.WateringPlanTemplateCreate) bool {
+			return len(c.UserIDs) == 1 && c.UserIDs[0] == uuid.Nil
+		})).Return(nil, service.NewError(service.BadRequest, "validation error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-plan-template", bytes.NewBufferString(`{"user_ids": ["invalid"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Post("/v1/watering-plan-template", wateringplantemplate.CreateWateringPlanTemplate(mockTemplateService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/watering-plan-template", bytes.NewBufferString(`{"name": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestUpdateWateringPlanTemplate(t *testing.T) {
+	t.Run("should update template", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Put("/v1/watering-plan-template/:id", wateringplantemplate.UpdateWateringPlanTemplate(mockTemplateService))
+
+		mockTemplateService.EXPECT().Update(mock.Anything, int32(1), mock.MatchedBy(func(u *entities.WateringPlanTemplateUpdate) bool {
+			return u.RRule == "FREQ=WEEKLY;BYDAY=WE" && !u.Enabled
+		})).Return(TestTemplate, nil)
+
+		// when
+		body := `{"name": "Wednesday round", "rrule": "FREQ=WEEKLY;BYDAY=WE", "start_date": "2025-04-01T00:00:00Z", "tree_cluster_ids": [1], "transporter_id": 2, "user_ids": ["6a1078e8-80fd-458f-b74e-e388fe2dd6ab"], "enabled": false}`
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/watering-plan-template/1", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return 400 for invalid recurrence rule", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Put("/v1/watering-plan-template/:id", wateringplantemplate.UpdateWateringPlanTemplate(mockTemplateService))
+
+		mockTemplateService.EXPECT().Update(mock.Anything, int32(1), mock.Anything).Return(nil, service.ErrTemplateRRuleInvalid)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/watering-plan-template/1", bytes.NewBufferString(`{"rrule": "FREQ=HOURLY"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestDeleteWateringPlanTemplate(t *testing.T) {
+	t.Run("should delete template", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+		app.Delete("/v1/watering-plan-template/:id", wateringplantemplate.DeleteWateringPlanTemplate(mockTemplateService))
+
+		mockTemplateService.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/watering-plan-template/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
@@ -0,0 +1,15 @@
+package wateringplantemplate
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.WateringPlanTemplateService) {
+	r.Get("/", GetAllWateringPlanTemplates(svc))
+	r.Get("/:id", GetWateringPlanTemplateByID(svc))
+	r.Get("/:id/occurrences", GetWateringPlanTemplateOccurrences(svc))
+	r.Post("/", CreateWateringPlanTemplate(svc))
+	r.Put("/:id", UpdateWateringPlanTemplate(svc))
+	r.Delete("/:id", DeleteWateringPlanTemplate(svc))
+}
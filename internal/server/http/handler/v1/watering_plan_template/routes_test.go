@@ -0,0 +1,89 @@
+package wateringplantemplate_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	wateringplantemplate "github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/watering_plan_template"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/watering-plan-template", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+			app := fiber.New()
+			wateringplantemplate.RegisterRoutes(app, mockTemplateService)
+
+			mockTemplateService.EXPECT().GetAll(mock.Anything).Return(TestTemplates, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/watering-plan-template/:id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+			app := fiber.New()
+			wateringplantemplate.RegisterRoutes(app, mockTemplateService)
+
+			mockTemplateService.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestTemplate, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call DELETE handler", func(t *testing.T) {
+			mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+			app := fiber.New()
+			wateringplantemplate.RegisterRoutes(app, mockTemplateService)
+
+			mockTemplateService.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/watering-plan-template/:id/occurrences", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockTemplateService := serviceMock.NewMockWateringPlanTemplateService(t)
+			app := fiber.New()
+			wateringplantemplate.RegisterRoutes(app, mockTemplateService)
+
+			mockTemplateService.EXPECT().GetOccurrences(mock.Anything, int32(1)).Return(TestOccurrences, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1/occurrences", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package wateringplantemplate_test
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	now = time.Now()
+
+	TestTemplate = &entities.WateringPlanTemplate{
+		ID:             1,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		Name:           "Tuesday and friday round",
+		Description:    "Young trees of the city center",
+		RRule:          "FREQ=WEEKLY;BYDAY=TU,FR",
+		StartDate:      time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+		SeasonStart:    "04-01",
+		SeasonEnd:      "09-30",
+		TreeClusterIDs: []int32{1, 2},
+		TransporterID:  utils.P(int32(2)),
+		TrailerID:      utils.P(int32(1)),
+		UserIDs:        []uuid.UUID{uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")},
+		Enabled:        true,
+	}
+
+	TestTemplates = []*entities.WateringPlanTemplate{
+		TestTemplate,
+		{
+			ID:             2,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			Name:           "Monthly round",
+			RRule:          "FREQ=MONTHLY;BYMONTHDAY=1",
+			StartDate:      time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			TreeClusterIDs: []int32{3},
+			TransporterID:  utils.P(int32(2)),
+			UserIDs:        []uuid.UUID{uuid.MustParse("05c028d1-f0b0-4a2b-8b2d-2b8c5f2b0e8e")},
+			Enabled:        false,
+		},
+	}
+
+	TestOccurrences = []*entities.WateringPlanTemplateOccurrence{
+		{
+			TemplateID:     1,
+			Date:           time.Date(2025, time.April, 15, 0, 0, 0, 0, time.UTC),
+			CreatedAt:      now,
+			WateringPlanID: utils.P(int32(7)),
+		},
+		{
+			TemplateID: 1,
+			Date:       time.Date(2025, time.April, 18, 0, 0, 0, 0, time.UTC),
+			CreatedAt:  now,
+			Holiday:    true,
+		},
+	}
+)
@@ -0,0 +1,81 @@
+package weather
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	weatherMapper = generated.WeatherHTTPMapperImpl{}
+)
+
+// @Summary		Get region weather
+// @Description	Get the daily precipitation, temperature and reference evapotranspiration ET0 of a region, oldest first. The days from today on are forecast.
+// @Id				get-region-weather
+// @Tags			Region
+// @Produce		json
+// @Success		200	{object}	entities.WeatherDayListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/region/{id}/weather [get]
+// @Param			id	path	int	true	"Region ID"
+// @Security		Keycloak
+func GetRegionWeather(svc service.WeatherService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		// linter complains about overflows, but we are sure that the ID is not going to be bigger than int32
+		//nolint: gosec
+		domainData, err := svc.GetByRegion(c.Context(), int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WeatherDayListResponse{
+			Data: weatherMapper.FromDayResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get tree cluster water balance
+// @Description	Get the soil water deficit of a tree cluster estimated from the weather of its region, with the estimated watering status of today and the first forecast day on which the tree cluster turns bad.
+// @Id				get-tree-cluster-water-balance
+// @Tags			Tree Cluster
+// @Produce		json
+// @Success		200	{object}	entities.TreeClusterWaterBalanceResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/cluster/{cluster_id}/water-balance [get]
+// @Param			cluster_id	path	int	true	"Tree Cluster ID"
+// @Security		Keycloak
+func GetTreeClusterWaterBalance(svc service.WeatherService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		// linter complains about overflows, but we are sure that the ID is not going to be bigger than int32
+		//nolint: gosec
+		domainData, err := svc.GetWaterBalance(c.Context(), int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(weatherMapper.FromTreeClusterWaterBalanceResponse(domainData))
+	}
+}
@@ -0,0 +1,146 @@
+package weather_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/weather"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRegionWeather(t *testing.T) {
+	t.Run("should return weather days of the region", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockWeatherService := serviceMock.NewMockWeatherService(t)
+		app.Get("/v1/region/:id/weather", weather.GetRegionWeather(mockWeatherService))
+
+		mockWeatherService.EXPECT().GetByRegion(mock.Anything, int32(1)).Return(TestWeatherDays, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/region/1/weather", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WeatherDayListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, TestWeatherDays[0].Date, response.Data[0].Date)
+		assert.Equal(t, 4.2, response.Data[0].Precipitation)
+		assert.Equal(t, 3.8, response.Data[0].ET0)
+		assert.False(t, response.Data[0].Forecast)
+		assert.True(t, response.Data[1].Forecast)
+	})
+
+	t.Run("should return 400 for invalid ID", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockWeatherService := serviceMock.NewMockWeatherService(t)
+		app.Get("/v1/region/:id/weather", weather.GetRegionWeather(mockWeatherService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/region/abc/weather", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 for unknown region", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockWeatherService := serviceMock.NewMockWeatherService(t)
+		app.Get("/v1/region/:id/weather", weather.GetRegionWeather(mockWeatherService))
+
+		mockWeatherService.EXPECT().GetByRegion(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "region not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/region/99/weather", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetTreeClusterWaterBalance(t *testing.T) {
+	t.Run("should return water balance of the tree cluster", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockWeatherService := serviceMock.NewMockWeatherService(t)
+		app.Get("/v1/cluster/:id/water-balance", weather.GetTreeClusterWaterBalance(mockWeatherService))
+
+		mockWeatherService.EXPECT().GetWaterBalance(mock.Anything, int32(1)).Return(TestWaterBalance, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/1/water-balance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.TreeClusterWaterBalanceResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), response.TreeClusterID)
+		assert.Equal(t, serverEntities.WateringStatusModerate, response.WateringStatus)
+		assert.Equal(t, 30.0, response.Deficit)
+		assert.Equal(t, time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC), *response.BadAt)
+		assert.Len(t, response.Days, 3)
+		assert.Equal(t, serverEntities.WateringStatusBad, response.Days[2].WateringStatus)
+	})
+
+	t.Run("should return 400 for invalid ID", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockWeatherService := serviceMock.NewMockWeatherService(t)
+		app.Get("/v1/cluster/:id/water-balance", weather.GetTreeClusterWaterBalance(mockWeatherService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/abc/water-balance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		// given
+		app := fiber.New()
+		mockWeatherService := serviceMock.NewMockWeatherService(t)
+		app.Get("/v1/cluster/:id/water-balance", weather.GetTreeClusterWaterBalance(mockWeatherService))
+
+		mockWeatherService.EXPECT().GetWaterBalance(mock.Anything, int32(1)).Return(nil, errors.New("service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/cluster/1/water-balance", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -0,0 +1,45 @@
+package weather_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var (
+	TestWeatherDays = []*entities.WeatherDay{
+		{
+			RegionID:        1,
+			Date:            time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:       time.Date(2025, 7, 2, 6, 0, 0, 0, time.UTC),
+			Precipitation:   4.2,
+			TemperatureMin:  12.1,
+			TemperatureMax:  24.3,
+			TemperatureMean: 18.2,
+			ET0:             3.8,
+		},
+		{
+			RegionID:        1,
+			Date:            time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:       time.Date(2025, 7, 2, 6, 0, 0, 0, time.UTC),
+			TemperatureMin:  14.0,
+			TemperatureMax:  28.5,
+			TemperatureMean: 21.3,
+			ET0:             5.1,
+			Forecast:        true,
+		},
+	}
+
+	TestWaterBalance = &entities.TreeClusterWaterBalance{
+		TreeClusterID:  1,
+		WateringStatus: entities.WateringStatusModerate,
+		Deficit:        30,
+		BadAt:          utils.P(time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC)),
+		Days: []*entities.WaterBalance{
+			{TreeClusterID: 1, Date: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Deficit: 15, WateringStatus: entities.WateringStatusGood},
+			{TreeClusterID: 1, Date: time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC), Deficit: 30, WateringStatus: entities.WateringStatusModerate, Forecast: true},
+			{TreeClusterID: 1, Date: time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC), Deficit: 45, WateringStatus: entities.WateringStatusBad, Forecast: true},
+		},
+	}
+)
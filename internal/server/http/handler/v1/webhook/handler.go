@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	webhookMapper = generated.WebhookHTTPMapperImpl{}
+)
+
+// @Summary		Get all webhooks
+// @Description	Get all webhooks. Requires the admin role.
+// @Id				get-all-webhooks
+// @Tags			Webhook
+// @Produce		json
+// @Success		200	{object}	entities.WebhookListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/webhook [get]
+// @Security		Keycloak
+func GetAllWebhooks(svc service.WebhookService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		domainData, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WebhookListResponse{
+			Data: webhookMapper.FromResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Get webhook by ID
+// @Description	Get webhook by ID. Requires the admin role.
+// @Id				get-webhook-by-id
+// @Tags			Webhook
+// @Produce		json
+// @Success		200	{object}	entities.WebhookResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/webhook/{id} [get]
+// @Param			id	path	int	true	"Webhook ID"
+// @Security		Keycloak
+func GetWebhookByID(svc service.WebhookService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetByID(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(webhookMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Get deliveries of a webhook
+// @Description	Get the latest deliveries of a webhook including their status and retry information. Requires the admin role.
+// @Id				get-webhook-deliveries
+// @Tags			Webhook
+// @Produce		json
+// @Success		200	{object}	entities.WebhookDeliveryListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/webhook/{id}/deliveries [get]
+// @Param			id	path	int	true	"Webhook ID"
+// @Security		Keycloak
+func GetWebhookDeliveries(svc service.WebhookService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		domainData, err := svc.GetDeliveries(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WebhookDeliveryListResponse{
+			Data: webhookMapper.FromDeliveryResponseList(domainData),
+		})
+	}
+}
+
+// @Summary		Create webhook
+// @Description	Create a webhook. The secret used to sign the payloads is only returned in this response. The url must point to a public host. Requires the admin role.
+// @Id				create-webhook
+// @Tags			Webhook
+// @Produce		json
+// @Success		201	{object}	entities.WebhookCreateResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/webhook [post]
+// @Param			body	body	entities.WebhookCreateRequest	true	"Webhook Create Request"
+// @Security		Keycloak
+func CreateWebhook(svc service.WebhookService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.WebhookCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := webhookMapper.FromCreateRequest(&req)
+		domainData, err := svc.Create(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entities.WebhookCreateResponse{
+			WebhookResponse: *webhookMapper.FromResponse(domainData),
+			Secret:          domainData.Secret,
+		})
+	}
+}
+
+// @Summary		Update webhook
+// @Description	Update webhook. The url must point to a public host. Requires the admin role.
+// @Id				update-webhook
+// @Tags			Webhook
+// @Produce		json
+// @Success		200	{object}	entities.WebhookResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/webhook/{id} [put]
+// @Param			id		path	int								true	"Webhook ID"
+// @Param			body	body	entities.WebhookUpdateRequest	true	"Webhook Update Request"
+// @Security		Keycloak
+func UpdateWebhook(svc service.WebhookService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.WebhookUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := webhookMapper.FromUpdateRequest(&req)
+		domainData, err := svc.Update(ctx, int32(id), domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(webhookMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete webhook
+// @Description	Delete webhook and its delivery log. Requires the admin role.
+// @Id				delete-webhook
+// @Tags			Webhook
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/webhook/{id} [delete]
+// @Param			id	path	int	true	"Webhook ID"
+// @Security		Keycloak
+func DeleteWebhook(svc service.WebhookService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			err := service.NewError(service.BadRequest, "invalid ID format")
+			return errorhandler.HandleError(err)
+		}
+
+		if err := svc.Delete(ctx, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,270 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	serverEntities "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/webhook"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllWebhooks(t *testing.T) {
+	t.Run("should return all webhooks without secrets", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Get("/v1/webhook", webhook.GetAllWebhooks(mockWebhookService))
+
+		mockWebhookService.EXPECT().GetAll(mock.Anything).Return(TestWebhooks, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/webhook", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response map[string][]map[string]any
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response["data"], 2)
+		assert.Equal(t, TestWebhook.Name, response["data"][0]["name"])
+		assert.NotContains(t, response["data"][0], "secret")
+	})
+
+	t.Run("should return 500 when service fails", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Get("/v1/webhook", webhook.GetAllWebhooks(mockWebhookService))
+
+		mockWebhookService.EXPECT().GetAll(mock.Anything).Return(nil, service.NewError(service.InternalError, "service error"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/webhook", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetWebhookByID(t *testing.T) {
+	t.Run("should return webhook", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Get("/v1/webhook/:id", webhook.GetWebhookByID(mockWebhookService))
+
+		mockWebhookService.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestWebhook, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/webhook/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WebhookResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestWebhook.ID, response.ID)
+		assert.Equal(t, TestWebhook.URL, response.URL)
+		assert.Equal(t, []string{"create tree", "update tree"}, response.EventTypes)
+	})
+
+	t.Run("should return 400 for invalid id", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Get("/v1/webhook/:id", webhook.GetWebhookByID(mockWebhookService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/webhook/invalid", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when webhook not found", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Get("/v1/webhook/:id", webhook.GetWebhookByID(mockWebhookService))
+
+		mockWebhookService.EXPECT().GetByID(mock.Anything, int32(99)).Return(nil, service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/webhook/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	t.Run("should return deliveries of webhook", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Get("/v1/webhook/:id/deliveries", webhook.GetWebhookDeliveries(mockWebhookService))
+
+		mockWebhookService.EXPECT().GetDeliveries(mock.Anything, int32(1)).Return(TestWebhookDeliveries, nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/webhook/1/deliveries", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response serverEntities.WebhookDeliveryListResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, serverEntities.WebhookDeliveryStatusSuccess, response.Data[0].Status)
+		assert.Equal(t, TestWebhookDeliveries[0].EventID, response.Data[0].EventID)
+	})
+}
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("should create webhook and return secret", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Post("/v1/webhook", webhook.CreateWebhook(mockWebhookService))
+
+		reqBody := serverEntities.WebhookCreateRequest{
+			Name:       TestWebhook.Name,
+			URL:        TestWebhook.URL,
+			EventTypes: []string{"create tree", "update tree"},
+			Enabled:    true,
+		}
+
+		mockWebhookService.EXPECT().Create(mock.Anything, &entities.WebhookCreate{
+			Name:       TestWebhook.Name,
+			URL:        TestWebhook.URL,
+			EventTypes: TestWebhook.EventTypes,
+			Enabled:    true,
+		}).Return(TestWebhook, nil)
+
+		// when
+		body, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/webhook", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response serverEntities.WebhookCreateResponse
+		err = utils.ParseJSONResponse(resp, &response)
+		assert.NoError(t, err)
+		assert.Equal(t, TestWebhook.ID, response.ID)
+		assert.Equal(t, TestWebhook.Secret, response.Secret)
+	})
+
+	t.Run("should return 400 for invalid request body", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Post("/v1/webhook", webhook.CreateWebhook(mockWebhookService))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/webhook", bytes.NewBufferString("invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	t.Run("should update webhook", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Put("/v1/webhook/:id", webhook.UpdateWebhook(mockWebhookService))
+
+		reqBody := serverEntities.WebhookUpdateRequest{
+			Name:       TestWebhook.Name,
+			URL:        TestWebhook.URL,
+			EventTypes: []string{"create tree", "update tree"},
+			Enabled:    true,
+		}
+
+		mockWebhookService.EXPECT().Update(mock.Anything, int32(1), &entities.WebhookUpdate{
+			Name:       TestWebhook.Name,
+			URL:        TestWebhook.URL,
+			EventTypes: TestWebhook.EventTypes,
+			Enabled:    true,
+		}).Return(TestWebhook, nil)
+
+		// when
+		body, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/v1/webhook/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Run("should delete webhook", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Delete("/v1/webhook/:id", webhook.DeleteWebhook(mockWebhookService))
+
+		mockWebhookService.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/webhook/1", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should return 404 when webhook not found", func(t *testing.T) {
+		app := fiber.New()
+		mockWebhookService := serviceMock.NewMockWebhookService(t)
+		app.Delete("/v1/webhook/:id", webhook.DeleteWebhook(mockWebhookService))
+
+		mockWebhookService.EXPECT().Delete(mock.Anything, int32(99)).Return(service.NewError(service.NotFound, "not found"))
+
+		// when
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/v1/webhook/99", nil)
+		resp, err := app.Test(req, -1)
+		defer resp.Body.Close()
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
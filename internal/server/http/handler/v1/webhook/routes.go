@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(r fiber.Router, svc service.WebhookService) {
+	r.Get("/", GetAllWebhooks(svc))
+	r.Get("/:id", GetWebhookByID(svc))
+	r.Get("/:id/deliveries", GetWebhookDeliveries(svc))
+	r.Post("/", CreateWebhook(svc))
+	r.Put("/:id", UpdateWebhook(svc))
+	r.Delete("/:id", DeleteWebhook(svc))
+}
@@ -0,0 +1,89 @@
+package webhook_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/webhook"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("/v1/webhook", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockWebhookService := serviceMock.NewMockWebhookService(t)
+			app := fiber.New()
+			webhook.RegisterRoutes(app, mockWebhookService)
+
+			mockWebhookService.EXPECT().GetAll(mock.Anything).Return(TestWebhooks, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/webhook/:id", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockWebhookService := serviceMock.NewMockWebhookService(t)
+			app := fiber.New()
+			webhook.RegisterRoutes(app, mockWebhookService)
+
+			mockWebhookService.EXPECT().GetByID(mock.Anything, int32(1)).Return(TestWebhook, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+
+		t.Run("should call DELETE handler", func(t *testing.T) {
+			mockWebhookService := serviceMock.NewMockWebhookService(t)
+			app := fiber.New()
+			webhook.RegisterRoutes(app, mockWebhookService)
+
+			mockWebhookService.EXPECT().Delete(mock.Anything, int32(1)).Return(nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/1", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	})
+
+	t.Run("/v1/webhook/:id/deliveries", func(t *testing.T) {
+		t.Run("should call GET handler", func(t *testing.T) {
+			mockWebhookService := serviceMock.NewMockWebhookService(t)
+			app := fiber.New()
+			webhook.RegisterRoutes(app, mockWebhookService)
+
+			mockWebhookService.EXPECT().GetDeliveries(mock.Anything, int32(1)).Return(TestWebhookDeliveries, nil)
+
+			// when
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/1/deliveries", nil)
+
+			// then
+			resp, err := app.Test(req)
+			defer resp.Body.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package webhook_test
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var (
+	now = time.Now()
+
+	TestWebhook = &entities.Webhook{
+		ID:         1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Name:       "Irrigation system",
+		URL:        "https://example.com/hook",
+		Secret:     "0123456789abcdef0123456789abcdef",
+		EventTypes: []entities.EventType{entities.EventTypeCreateTree, entities.EventTypeUpdateTree},
+		Enabled:    true,
+	}
+
+	TestWebhooks = []*entities.Webhook{
+		TestWebhook,
+		{
+			ID:         2,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Name:       "Dashboard",
+			URL:        "http://localhost:8081/webhook",
+			Secret:     "fedcba9876543210fedcba9876543210",
+			EventTypes: []entities.EventType{entities.EventTypeNewSensorData},
+			Enabled:    false,
+		},
+	}
+
+	TestWebhookDeliveries = []*entities.WebhookDelivery{
+		{
+			ID:            1,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+			WebhookID:     1,
+			EventID:       "3f1c5a52-2a4a-4c4e-9b9e-4d2f3f1c5a52",
+			EventType:     entities.EventTypeCreateTree,
+			Status:        entities.WebhookDeliveryStatusSuccess,
+			Attempts:      1,
+			NextAttemptAt: now,
+			DeliveredAt:   &now,
+		},
+	}
+)
@@ -23,7 +23,10 @@ func (s *Server) middleware() *fiber.App {
 		"request_id":   middleware.RequestID(),
 		"app_logger":   middleware.AppLogger(logFn),
 		"pagination":   middleware.PaginationMiddleware(),
-		"auth":         middleware.NewJWTMiddleware(&s.cfg.IdentityAuth, s.services.AuthService),
+		"auth": middleware.NewAuthMiddleware(
+			middleware.NewJWTMiddleware(&s.cfg.IdentityAuth, s.services.AuthService),
+			s.services.APIKeyService,
+		),
 	}
 
 	slog.Info("setting up fiber middlewares", "size", len(middlewares), "service", "fiber")
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+// RealmRoleAdmin is the realm role of the identity provider that grants access to the administration endpoints
+const RealmRoleAdmin = "admin"
+
+// RequireAdmin restricts requests to users with the admin realm role. Requests made with an
+// api key are rejected, requests without claims pass because the authentication is disabled.
+func RequireAdmin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Value(enums.ContextKeyAPIKey).(*entities.APIKey); ok {
+			return errorhandler.HandleError(service.ErrAdminRoleRequired)
+		}
+
+		claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+		if !ok {
+			return c.Next()
+		}
+
+		if !IsAdmin(claims) {
+			return errorhandler.HandleError(service.ErrAdminRoleRequired)
+		}
+
+		return c.Next()
+	}
+}
+
+// IsAdmin reports whether the claims contain the admin realm role
+func IsAdmin(claims golangJwt.MapClaims) bool {
+	return slices.Contains(RealmRoles(claims), RealmRoleAdmin)
+}
+
+// RealmRoles returns the realm roles of the identity provider contained in the claims
+func RealmRoles(claims golangJwt.MapClaims) []string {
+	realmAccess, _ := claims["realm_access"].(map[string]any)
+	roles, _ := realmAccess["roles"].([]any)
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if name, ok := role.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAdminApp(key any, value any) *fiber.App {
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		if value != nil {
+			c.SetUserContext(context.WithValue(c.UserContext(), key, value))
+		}
+		return c.Next()
+	})
+
+	app.Get("/audit-log", RequireAdmin(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	return app
+}
+
+func TestRequireAdmin(t *testing.T) {
+	t.Run("should pass request without claims", func(t *testing.T) {
+		// given
+		app := setupAdminApp(enums.ContextKeyClaims, nil)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/audit-log", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should pass user with admin role", func(t *testing.T) {
+		// given
+		claims := golangJwt.MapClaims{"realm_access": map[string]any{"roles": []any{"offline_access", "admin"}}}
+		app := setupAdminApp(enums.ContextKeyClaims, claims)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/audit-log", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return forbidden for user without admin role", func(t *testing.T) {
+		// given
+		claims := golangJwt.MapClaims{"realm_access": map[string]any{"roles": []any{"tbz"}}}
+		app := setupAdminApp(enums.ContextKeyClaims, claims)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/audit-log", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should return forbidden for user without realm roles", func(t *testing.T) {
+		// given
+		app := setupAdminApp(enums.ContextKeyClaims, golangJwt.MapClaims{"sub": "6a1078e8-80fd-458f-b74e-e388fe2dd6ab"})
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/audit-log", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should return forbidden for api key", func(t *testing.T) {
+		// given
+		app := setupAdminApp(enums.ContextKeyAPIKey, &entities.APIKey{Scopes: []entities.PluginScope{"tree:read"}})
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/audit-log", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+}
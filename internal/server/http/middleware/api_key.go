@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/wrapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+const (
+	HeaderAPIKey       = "X-API-Key"
+	AuthSchemeAPIKey   = "ApiKey"
+	authorizationField = "Authorization"
+)
+
+// NewAuthMiddleware accepts api keys as an alternative to jwt tokens. Requests with an api key
+// in the X-API-Key header or an "Authorization: ApiKey <key>" header are authenticated with the
+// api key service, all other requests are passed to the jwt middleware.
+func NewAuthMiddleware(jwtMiddleware fiber.Handler, svc service.APIKeyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := apiKeyFromRequest(c)
+		if key == "" {
+			return jwtMiddleware(c)
+		}
+
+		apiKey, err := svc.Authenticate(c.Context(), key)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		c.SetUserContext(context.WithValue(c.Context(), enums.ContextKeyAPIKey, apiKey))
+
+		fiberCtx := wrapper.NewFiberCtx(c)
+		_ = fiberCtx.WithLogger("api_key_id", apiKey.ID)
+		c.Locals(enums.ContextKeyActor, "api-key:"+apiKey.Prefix)
+		// services receive the fasthttp context, which resolves values from the locals
+		c.Locals(enums.ContextKeyAPIKey, apiKey)
+
+		return c.Next()
+	}
+}
+
+// RejectAPIKey rejects requests made with an api key, e.g. on endpoints that only plugins call with their own token
+func RejectAPIKey() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Value(enums.ContextKeyAPIKey).(*entities.APIKey); ok {
+			return errorhandler.HandleError(service.ErrAPIKeyScopeNotGranted)
+		}
+		return c.Next()
+	}
+}
+
+func apiKeyFromRequest(c *fiber.Ctx) string {
+	if key := c.Get(HeaderAPIKey); key != "" {
+		return key
+	}
+
+	scheme, key, ok := strings.Cut(c.Get(authorizationField), " ")
+	if !ok || !strings.EqualFold(scheme, AuthSchemeAPIKey) {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
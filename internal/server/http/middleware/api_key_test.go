@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testAPIKey = "ge_0a1b2c3d4e5f_00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+
+func setupAuthApp(svc service.APIKeyService) *fiber.App {
+	app := fiber.New()
+
+	jwtMiddleware := func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusTeapot).SendString("jwt")
+	}
+	app.Use(NewAuthMiddleware(jwtMiddleware, svc))
+	app.Get("/tree", func(c *fiber.Ctx) error {
+		key, ok := c.UserContext().Value(enums.ContextKeyAPIKey).(*entities.APIKey)
+		if !ok {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(key.Name)
+	})
+	app.Get("/service-context", func(c *fiber.Ctx) error {
+		key, ok := c.Context().Value(enums.ContextKeyAPIKey).(*entities.APIKey)
+		if !ok {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(key.Name)
+	})
+	app.Get("/actor", func(c *fiber.Ctx) error {
+		actor, _ := c.Context().Value(enums.ContextKeyActor).(string)
+		return c.SendString(actor)
+	})
+
+	return app
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Run("should authenticate with api key header", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockAPIKeyService(t)
+		app := setupAuthApp(svc)
+		svc.EXPECT().Authenticate(mock.Anything, testAPIKey).Return(&entities.APIKey{ID: 1, Name: "Irrigation controller"}, nil)
+
+		req := httptest.NewRequest(fiber.MethodGet, "/tree", nil)
+		req.Header.Set(HeaderAPIKey, testAPIKey)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should set api key prefix as actor of the request", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockAPIKeyService(t)
+		app := setupAuthApp(svc)
+		svc.EXPECT().Authenticate(mock.Anything, testAPIKey).Return(&entities.APIKey{ID: 1, Prefix: "0a1b2c3d4e5f"}, nil)
+
+		req := httptest.NewRequest(fiber.MethodGet, "/actor", nil)
+		req.Header.Set(HeaderAPIKey, testAPIKey)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "api-key:0a1b2c3d4e5f", string(body))
+	})
+
+	t.Run("should pass api key to the context of the services", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockAPIKeyService(t)
+		app := setupAuthApp(svc)
+		svc.EXPECT().Authenticate(mock.Anything, testAPIKey).Return(&entities.APIKey{ID: 1, Name: "Irrigation controller"}, nil)
+
+		req := httptest.NewRequest(fiber.MethodGet, "/service-context", nil)
+		req.Header.Set(HeaderAPIKey, testAPIKey)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "Irrigation controller", string(body))
+	})
+
+	t.Run("should authenticate with api key authorization scheme", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockAPIKeyService(t)
+		app := setupAuthApp(svc)
+		svc.EXPECT().Authenticate(mock.Anything, testAPIKey).Return(&entities.APIKey{ID: 1}, nil)
+
+		req := httptest.NewRequest(fiber.MethodGet, "/tree", nil)
+		req.Header.Set("Authorization", "ApiKey "+testAPIKey)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return unauthorized for invalid api key", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockAPIKeyService(t)
+		app := setupAuthApp(svc)
+		svc.EXPECT().Authenticate(mock.Anything, "invalid").Return(nil, service.ErrAPIKeyInvalid)
+
+		req := httptest.NewRequest(fiber.MethodGet, "/tree", nil)
+		req.Header.Set(HeaderAPIKey, "invalid")
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("should fall back to jwt middleware for bearer tokens", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockAPIKeyService(t)
+		app := setupAuthApp(svc)
+
+		req := httptest.NewRequest(fiber.MethodGet, "/tree", nil)
+		req.Header.Set("Authorization", "Bearer token")
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusTeapot, resp.StatusCode)
+	})
+}
+
+func TestRejectAPIKey(t *testing.T) {
+	setupApp := func(apiKey *entities.APIKey) *fiber.App {
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			if apiKey != nil {
+				c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyAPIKey, apiKey))
+			}
+			return c.Next()
+		})
+		app.Use(RejectAPIKey())
+		app.Post("/plugin/csv-import/heartbeat", func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+		return app
+	}
+
+	t.Run("should reject requests with api key", func(t *testing.T) {
+		// given
+		app := setupApp(&entities.APIKey{ID: 1, Scopes: []entities.PluginScope{"plugin:write"}})
+		req := httptest.NewRequest(fiber.MethodPost, "/plugin/csv-import/heartbeat", nil)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should pass requests without api key", func(t *testing.T) {
+		// given
+		app := setupApp(nil)
+		req := httptest.NewRequest(fiber.MethodPost, "/plugin/csv-import/heartbeat", nil)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
+)
+
+// IfMatch parses the If-Match header of update and delete requests. The repositories reject
+// the write with 412 Precondition Failed if the version of the entity does not match.
+func IfMatch() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodPut && c.Method() != fiber.MethodDelete {
+			return c.Next()
+		}
+
+		header := c.Get(fiber.HeaderIfMatch)
+		if header == "" {
+			return c.Next()
+		}
+
+		versions, err := etag.ParseIfMatch(header)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		c.Locals(enums.ContextKeyIfMatch, versions)
+		return c.Next()
+	}
+}
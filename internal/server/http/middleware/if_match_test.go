@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupIfMatchApp() *fiber.App {
+	app := fiber.New()
+
+	handler := func(c *fiber.Ctx) error {
+		versions, ok := c.Context().Value(enums.ContextKeyIfMatch).([]int32)
+		if !ok {
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+		return c.JSON(versions)
+	}
+
+	app.Use(IfMatch())
+	app.Get("/tree/1", handler)
+	app.Put("/tree/1", handler)
+	app.Delete("/tree/1", handler)
+
+	return app
+}
+
+func TestIfMatch(t *testing.T) {
+	t.Run("should pass request without header", func(t *testing.T) {
+		// given
+		app := setupIfMatchApp()
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPut, "/tree/1", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should store versions of update request", func(t *testing.T) {
+		// given
+		app := setupIfMatchApp()
+		req := httptest.NewRequest(fiber.MethodPut, "/tree/1", nil)
+		req.Header.Set(fiber.HeaderIfMatch, `"2", "3"`)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "[2,3]", string(body))
+	})
+
+	t.Run("should store versions of delete request", func(t *testing.T) {
+		// given
+		app := setupIfMatchApp()
+		req := httptest.NewRequest(fiber.MethodDelete, "/tree/1", nil)
+		req.Header.Set(fiber.HeaderIfMatch, `"2"`)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "[2]", string(body))
+	})
+
+	t.Run("should ignore header of read request", func(t *testing.T) {
+		// given
+		app := setupIfMatchApp()
+		req := httptest.NewRequest(fiber.MethodGet, "/tree/1", nil)
+		req.Header.Set(fiber.HeaderIfMatch, "invalid")
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("should return bad request on invalid header", func(t *testing.T) {
+		// given
+		app := setupIfMatchApp()
+		req := httptest.NewRequest(fiber.MethodPut, "/tree/1", nil)
+		req.Header.Set(fiber.HeaderIfMatch, `"abc"`)
+
+		// when
+		resp, err := app.Test(req)
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
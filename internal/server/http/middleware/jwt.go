@@ -92,6 +92,7 @@ func successHandler(c *fiber.Ctx, svc service.AuthService) error {
 	}
 
 	_ = fiberCtx.WithLogger("user_id", userID)
+	c.Locals(enums.ContextKeyActor, userID)
 
 	return c.Next()
 }
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+// PluginScope restricts requests made with a plugin token or an api key to the granted scopes.
+// Safe methods require read access to the resource, all other methods require write access.
+func PluginScope(svc service.PluginService, resource entities.PluginResource) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		access := entities.PluginAccessWrite
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			access = entities.PluginAccessRead
+		}
+		return checkPluginScope(c, svc, entities.NewPluginScope(resource, access))
+	}
+}
+
+// PluginReadScope is like PluginScope but requires read access for every method. It is used for post requests
+// that only read the resource, e.g. to generate a report of it.
+func PluginReadScope(svc service.PluginService, resource entities.PluginResource) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return checkPluginScope(c, svc, entities.NewPluginScope(resource, entities.PluginAccessRead))
+	}
+}
+
+func checkPluginScope(c *fiber.Ctx, svc service.PluginService, scope entities.PluginScope) error {
+	if apiKey, ok := c.UserContext().Value(enums.ContextKeyAPIKey).(*entities.APIKey); ok {
+		if !apiKey.Allows(scope) {
+			return errorhandler.HandleError(service.ErrAPIKeyScopeNotGranted)
+		}
+		return c.Next()
+	}
+
+	claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+	if !ok {
+		return c.Next()
+	}
+
+	clientID, _ := claims["azp"].(string)
+	if err := svc.CheckScope(c.Context(), clientID, scope); err != nil {
+		return errorhandler.HandleError(err)
+	}
+
+	return c.Next()
+}
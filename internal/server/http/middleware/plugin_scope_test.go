@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupPluginScopeApp(svc service.PluginService, claims golangJwt.MapClaims) *fiber.App {
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		if claims != nil {
+			c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+		}
+		return c.Next()
+	})
+
+	handler := func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	}
+	app.Get("/tree", PluginScope(svc, entities.PluginResourceTree), handler)
+	app.Post("/tree", PluginScope(svc, entities.PluginResourceTree), handler)
+	app.Post("/report", PluginReadScope(svc, entities.PluginResourceTree), handler)
+
+	return app
+}
+
+func TestPluginScope(t *testing.T) {
+	t.Run("should pass request without claims", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockPluginService(t)
+		app := setupPluginScopeApp(svc, nil)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/tree", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should check read scope for get requests", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockPluginService(t)
+		app := setupPluginScopeApp(svc, golangJwt.MapClaims{"azp": "csv-import-client"})
+		svc.EXPECT().CheckScope(mock.Anything, "csv-import-client", entities.PluginScope("tree:read")).Return(nil)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/tree", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should check write scope for post requests", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockPluginService(t)
+		app := setupPluginScopeApp(svc, golangJwt.MapClaims{"azp": "csv-import-client"})
+		svc.EXPECT().CheckScope(mock.Anything, "csv-import-client", entities.PluginScope("tree:write")).Return(nil)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/tree", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should check read scope for post requests that only read", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockPluginService(t)
+		app := setupPluginScopeApp(svc, golangJwt.MapClaims{"azp": "csv-import-client"})
+		svc.EXPECT().CheckScope(mock.Anything, "csv-import-client", entities.PluginScope("tree:read")).Return(nil)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/report", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return forbidden when scope is not granted", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockPluginService(t)
+		app := setupPluginScopeApp(svc, golangJwt.MapClaims{"azp": "csv-import-client"})
+		svc.EXPECT().CheckScope(mock.Anything, "csv-import-client", entities.PluginScope("tree:write")).Return(service.ErrPluginScopeNotGranted)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/tree", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should allow api key with granted scope", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockPluginService(t)
+		app := setupAPIKeyScopeApp(svc, &entities.APIKey{Scopes: []entities.PluginScope{"tree:read"}})
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/tree", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return forbidden when api key lacks scope", func(t *testing.T) {
+		// given
+		svc := serviceMock.NewMockPluginService(t)
+		app := setupAPIKeyScopeApp(svc, &entities.APIKey{Scopes: []entities.PluginScope{"tree:read"}})
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/tree", nil))
+
+		// then
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func setupAPIKeyScopeApp(svc service.PluginService, key *entities.APIKey) *fiber.App {
+	app := fiber.New()
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyAPIKey, key))
+		return c.Next()
+	})
+
+	handler := func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	}
+	app.Get("/tree", PluginScope(svc, entities.PluginResourceTree), handler)
+	app.Post("/tree", PluginScope(svc, entities.PluginResourceTree), handler)
+
+	return app
+}
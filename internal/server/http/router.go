@@ -1,18 +1,37 @@
 package http
 
 import (
+	"slices"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/alert"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/apikey"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/auditlog"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/evaluation"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/eventstream"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/export"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/info"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/job"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/notification"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/ogc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/planner"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/plugin"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/region"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/report"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/tile"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/treecluster"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/treeimport"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/user"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/vehicle"
 	wateringplan "github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/watering_plan"
+	wateringplantemplate "github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/watering_plan_template"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/weather"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/webhook"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
@@ -36,51 +55,178 @@ func (s *Server) v1(router fiber.Router, authMiddlewares ...fiber.Handler) {
 
 	app.Route("/info", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceInfo))
 		info.RegisterRoutes(router, s.services.InfoService)
 	})
 
 	app.Route("/cluster", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceTreeCluster))
+		router.Use(middleware.IfMatch())
 		treecluster.RegisterRoutes(router, s.services.TreeClusterService)
+		router.Get("/:id/history", auditlog.GetTreeClusterHistory(s.services.AuditLogService))
+		router.Get("/:id/water-balance", weather.GetTreeClusterWaterBalance(s.services.WeatherService))
 	})
 
 	app.Route("/tree", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceTree))
+		router.Use(middleware.IfMatch())
 		tree.RegisterRoutes(router, s.services.TreeService)
+		router.Get("/:id/history", auditlog.GetTreeHistory(s.services.AuditLogService))
+	})
+
+	app.Route("/tree-import", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceTree))
+		treeimport.RegisterRoutes(router, s.services.TreeImportService)
+	})
+
+	app.Route("/export", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		// every resource needs the read scope of the exported entities
+		router.Use("/"+string(domain.ExportResourceTree), middleware.PluginScope(s.services.PluginService, domain.PluginResourceTree))
+		router.Use("/"+string(domain.ExportResourceTreeCluster), middleware.PluginScope(s.services.PluginService, domain.PluginResourceTreeCluster))
+		router.Use("/"+string(domain.ExportResourceWateringPlan), middleware.PluginScope(s.services.PluginService, domain.PluginResourceWateringPlan))
+		export.RegisterRoutes(router, s.services.ExportService)
+	})
+
+	app.Route("/ogc", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		// every collection needs the read scope of its entities
+		router.Use("/collections/"+string(domain.OGCCollectionTrees), middleware.PluginScope(s.services.PluginService, domain.PluginResourceTree))
+		router.Use("/collections/"+string(domain.OGCCollectionTreeClusters), middleware.PluginScope(s.services.PluginService, domain.PluginResourceTreeCluster))
+		router.Use("/collections/"+string(domain.OGCCollectionSensors), middleware.PluginScope(s.services.PluginService, domain.PluginResourceSensor))
+		router.Use("/collections/"+string(domain.OGCCollectionRegions), middleware.PluginScope(s.services.PluginService, domain.PluginResourceRegion))
+		ogc.RegisterRoutes(router, s.services.OGCService)
+	})
+
+	app.Route("/tiles", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		// every layer needs the read scope of its entities
+		router.Use("/"+string(domain.OGCCollectionTrees), middleware.PluginScope(s.services.PluginService, domain.PluginResourceTree))
+		router.Use("/"+string(domain.OGCCollectionTreeClusters), middleware.PluginScope(s.services.PluginService, domain.PluginResourceTreeCluster))
+		router.Use("/"+string(domain.OGCCollectionSensors), middleware.PluginScope(s.services.PluginService, domain.PluginResourceSensor))
+		router.Use("/"+string(domain.OGCCollectionRegions), middleware.PluginScope(s.services.PluginService, domain.PluginResourceRegion))
+		tile.RegisterRoutes(router, s.services.TileService)
 	})
 
 	app.Route("/sensor", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceSensor))
 		sensor.RegisterRoutes(router, s.services.SensorService)
 	})
 
 	app.Route("/user", func(router fiber.Router) {
 		user.RegisterPublicRoutes(router, s.services.AuthService)
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceUser))
 		user.RegisterRoutes(router, s.services.AuthService)
 	})
 
 	app.Route("/region", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceRegion))
 		region.RegisterRoutes(router, s.services.RegionService)
+		router.Get("/:id/weather", weather.GetRegionWeather(s.services.WeatherService))
 	})
 
 	app.Route("/vehicle", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceVehicle))
+		router.Use(middleware.IfMatch())
 		vehicle.RegisterRoutes(router, s.services.VehicleService)
+		router.Get("/:id/history", auditlog.GetVehicleHistory(s.services.AuditLogService))
 	})
 
 	app.Route("/watering-plan", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceWateringPlan))
+		router.Use(middleware.IfMatch())
+		router.Get("/proposals", planner.GetWateringPlanProposals(s.services.PlannerService))
+		router.Post("/proposals", planner.AcceptWateringPlanProposals(s.services.PlannerService))
 		wateringplan.RegisterRoutes(router, s.services.WateringPlanService)
+		router.Get("/:id/history", auditlog.GetWateringPlanHistory(s.services.AuditLogService))
+	})
+
+	app.Route("/watering-plan-template", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceWateringPlan))
+		wateringplantemplate.RegisterRoutes(router, s.services.WateringPlanTemplateService)
 	})
 
 	app.Route("/evaluation", func(router fiber.Router) {
 		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceEvaluation))
 		evaluation.RegisterRoutes(router, s.services.EvaluationService)
 	})
 
+	app.Route("/report", func(router fiber.Router) {
+		report.RegisterPublicRoutes(router, s.services.ReportService)
+		router.Use(authMiddleware...)
+		// a report needs the read scope of the entities it is generated from
+		router.Use("/watering-plan", middleware.PluginReadScope(s.services.PluginService, domain.PluginResourceWateringPlan))
+		router.Use("/season", middleware.PluginReadScope(s.services.PluginService, domain.PluginResourceEvaluation))
+		report.RegisterRoutes(router, s.services.ReportService)
+	})
+
+	app.Route("/alert", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceAlert))
+		alert.RegisterRoutes(router, s.services.AlertService)
+	})
+
+	app.Route("/webhook", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		// webhooks send requests from the backend to arbitrary targets and are managed by admins only
+		router.Use(middleware.RequireAdmin())
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceWebhook))
+		webhook.RegisterRoutes(router, s.services.WebhookService)
+	})
+
+	app.Route("/api-key", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		// api keys grant long-lived access to other resources and are managed by admins only
+		router.Use(middleware.RequireAdmin())
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceAPIKey))
+		apikey.RegisterRoutes(router, s.services.APIKeyService)
+	})
+
+	app.Route("/events", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourceEvents))
+		eventstream.RegisterRoutes(router, s.services.EventStreamService)
+	})
+
+	app.Route("/audit-log", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		router.Use(middleware.RequireAdmin())
+		auditlog.RegisterRoutes(router, s.services.AuditLogService)
+	})
+
+	// notifications belong to the user of the token, requests with an api key are rejected by the handlers
+	app.Route("/notification", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		notification.RegisterRoutes(router, s.services.NotificationService)
+	})
+
+	app.Route("/jobs", func(router fiber.Router) {
+		router.Use(authMiddleware...)
+		router.Use(middleware.RequireAdmin())
+		job.RegisterRoutes(router, s.services.JobService)
+	})
+
 	app.Route("/plugin", func(router fiber.Router) {
-		plugin.RegisterRoutes(router, s.services.PluginService, authMiddlewares...)
+		// granting scopes to plugins is an administration task
+		router.Route("/grants", func(router fiber.Router) {
+			router.Use(authMiddleware...)
+			router.Use(middleware.RequireAdmin())
+			router.Use(middleware.PluginScope(s.services.PluginService, domain.PluginResourcePlugin))
+			plugin.RegisterGrantRoutes(router, s.services.PluginService)
+		})
+		// api keys need the plugin scope to read the plugins and can not act as a plugin
+		readMiddlewares := append(slices.Clone(authMiddlewares), middleware.PluginScope(s.services.PluginService, domain.PluginResourcePlugin))
+		lifecycleMiddlewares := append(slices.Clone(authMiddlewares), middleware.RejectAPIKey())
+		plugin.RegisterRoutes(router, s.services.PluginService, readMiddlewares, lifecycleMiddlewares)
 	})
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
@@ -21,6 +22,9 @@ type HTTPError struct {
 	Method string `json:"method"`
 } // @Name HTTPError
 
+// bodyLimit allows uploads of tree inventory files for the tree import
+const bodyLimit = 64 * 1024 * 1024
+
 type Server struct {
 	cfg      *config.Config
 	services *service.Services
@@ -39,6 +43,7 @@ func (s *Server) Run(ctx context.Context) error {
 		ServerHeader:             s.cfg.Dashboard.Title,
 		ErrorHandler:             errorHandler,
 		EnableSplittingOnParsers: true,
+		BodyLimit:                bodyLimit,
 	})
 
 	app.Mount("/", s.middleware())
@@ -48,19 +53,19 @@ func (s *Server) Run(ctx context.Context) error {
 		s.services.PluginService.StartCleanup(ctx)
 	}()
 
-	sensorStatusScheduler := worker.NewScheduler(3*time.Hour, worker.SchedulerFunc(s.services.SensorService.UpdateStatuses))
-	go sensorStatusScheduler.Run(ctx)
-
-	var onceADay = 24 * time.Hour
+	if err := s.registerJobs(); err != nil {
+		return err
+	}
+	go s.services.JobService.Run(ctx)
 
-	wateringPlanStatusScheduler := worker.NewScheduler(onceADay, worker.SchedulerFunc(s.services.WateringPlanService.UpdateStatuses))
-	go wateringPlanStatusScheduler.Run(ctx)
+	webhookDeliveryScheduler := worker.NewScheduler(10*time.Second, worker.SchedulerFunc(s.services.WebhookService.DeliverDue))
+	go webhookDeliveryScheduler.Run(ctx)
 
-	clusterWateringStatusScheduler := worker.NewScheduler(onceADay, worker.SchedulerFunc(s.services.TreeClusterService.UpdateWateringStatuses))
-	go clusterWateringStatusScheduler.Run(ctx)
+	notificationDeliveryScheduler := worker.NewScheduler(time.Minute, worker.SchedulerFunc(s.services.NotificationService.DeliverDue))
+	go notificationDeliveryScheduler.Run(ctx)
 
-	treeWateringStatusScheduler := worker.NewScheduler(onceADay, worker.SchedulerFunc(s.services.TreeService.UpdateWateringStatuses))
-	go treeWateringStatusScheduler.Run(ctx)
+	treeImportScheduler := worker.NewScheduler(5*time.Second, worker.SchedulerFunc(s.services.TreeImportService.ProcessPending))
+	go treeImportScheduler.Run(ctx)
 
 	go func() {
 		<-ctx.Done()
@@ -74,6 +79,27 @@ func (s *Server) Run(ctx context.Context) error {
 	return app.Listen(fmt.Sprintf(":%d", s.cfg.Server.Port))
 }
 
+// registerJobs registers the jobs of the job scheduler, their schedules are configured by name
+func (s *Server) registerJobs() error {
+	jobs := map[string]func(context.Context) error{
+		entities.JobSensorStatus:              s.services.SensorService.UpdateStatuses,
+		entities.JobWateringPlanStatus:        s.services.WateringPlanService.UpdateStatuses,
+		entities.JobTreeClusterWateringStatus: s.services.TreeClusterService.UpdateWateringStatuses,
+		entities.JobTreeWateringStatus:        s.services.TreeService.UpdateWateringStatuses,
+		entities.JobWeather:                   s.services.WeatherService.Update,
+		entities.JobWateringPlanTemplates:     s.services.WateringPlanTemplateService.Materialize,
+		entities.JobTreeStatusSnapshot:        s.services.EvaluationService.SnapshotTreeStatuses,
+		entities.JobAlertRules:                s.services.AlertService.Evaluate,
+	}
+
+	for name, work := range jobs {
+		if err := s.services.JobService.Register(name, work); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func errorHandler(c *fiber.Ctx, err error) error {
 	code := fiber.StatusInternalServerError
 
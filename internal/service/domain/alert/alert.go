@@ -0,0 +1,222 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+)
+
+var _ service.AlertService = (*AlertService)(nil)
+
+type AlertService struct {
+	alertRepo        storage.AlertRepository
+	treeClusterRepo  storage.TreeClusterRepository
+	sensorRepo       storage.SensorRepository
+	wateringPlanRepo storage.WateringPlanRepository
+	regionRepo       storage.RegionRepository
+	eventManager     worker.EventBus
+	validator        *validator.Validate
+	now              func() time.Time
+}
+
+func NewAlertService(
+	alertRepo storage.AlertRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+	sensorRepo storage.SensorRepository,
+	wateringPlanRepo storage.WateringPlanRepository,
+	regionRepo storage.RegionRepository,
+	eventManager worker.EventBus,
+) *AlertService {
+	return &AlertService{
+		alertRepo:        alertRepo,
+		treeClusterRepo:  treeClusterRepo,
+		sensorRepo:       sensorRepo,
+		wateringPlanRepo: wateringPlanRepo,
+		regionRepo:       regionRepo,
+		eventManager:     eventManager,
+		validator:        validator.New(),
+		now:              time.Now,
+	}
+}
+
+func (s *AlertService) GetAllRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	log := logger.GetLogger(ctx)
+	rules, err := s.alertRepo.GetAllRules(ctx)
+	if err != nil {
+		log.Debug("failed to fetch alert rules", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return rules, nil
+}
+
+func (s *AlertService) GetRuleByID(ctx context.Context, id int32) (*entities.AlertRule, error) {
+	log := logger.GetLogger(ctx)
+	rule, err := s.alertRepo.GetRuleByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch alert rule by id", "error", err, "rule_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return rule, nil
+}
+
+func (s *AlertService) CreateRule(ctx context.Context, createData *entities.AlertRuleCreate) (*entities.AlertRule, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate struct from create alert rule", "error", err, "raw_rule", fmt.Sprintf("%+v", createData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validateRule(ctx, createData.Type, createData.WateringStatuses, createData.Threshold, createData.Window, createData.RegionIDs); err != nil {
+		return nil, err
+	}
+
+	created, err := s.alertRepo.CreateRule(ctx, func(r *entities.AlertRule, _ storage.AlertRepository) (bool, error) {
+		r.Name = createData.Name
+		r.Description = createData.Description
+		r.Type = createData.Type
+		r.Severity = severityOrDefault(createData.Severity)
+		r.RegionIDs = createData.RegionIDs
+		r.WateringStatuses = createData.WateringStatuses
+		r.Threshold = createData.Threshold
+		r.Window = createData.Window
+		r.For = createData.For
+		r.Notify = createData.Notify
+		r.Enabled = createData.Enabled
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create alert rule", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("alert rule created successfully", "rule_id", created.ID)
+	return created, nil
+}
+
+func (s *AlertService) UpdateRule(ctx context.Context, id int32, updateData *entities.AlertRuleUpdate) (*entities.AlertRule, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(updateData); err != nil {
+		log.Debug("failed to validate struct from update alert rule", "error", err, "raw_rule", fmt.Sprintf("%+v", updateData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validateRule(ctx, updateData.Type, updateData.WateringStatuses, updateData.Threshold, updateData.Window, updateData.RegionIDs); err != nil {
+		return nil, err
+	}
+
+	err := s.alertRepo.UpdateRule(ctx, id, func(r *entities.AlertRule, _ storage.AlertRepository) (bool, error) {
+		r.Name = updateData.Name
+		r.Description = updateData.Description
+		r.Type = updateData.Type
+		r.Severity = severityOrDefault(updateData.Severity)
+		r.RegionIDs = updateData.RegionIDs
+		r.WateringStatuses = updateData.WateringStatuses
+		r.Threshold = updateData.Threshold
+		r.Window = updateData.Window
+		r.For = updateData.For
+		r.Notify = updateData.Notify
+		r.Enabled = updateData.Enabled
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update alert rule", "error", err, "rule_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("alert rule updated successfully", "rule_id", id)
+	return s.GetRuleByID(ctx, id)
+}
+
+func (s *AlertService) DeleteRule(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	if err := s.alertRepo.DeleteRule(ctx, id); err != nil {
+		log.Debug("failed to delete alert rule", "error", err, "rule_id", id)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("alert rule deleted successfully", "rule_id", id)
+	return nil
+}
+
+func (s *AlertService) GetAll(ctx context.Context, query entities.AlertQuery) ([]*entities.Alert, int64, error) {
+	log := logger.GetLogger(ctx)
+	alerts, totalCount, err := s.alertRepo.GetAll(ctx, query)
+	if err != nil {
+		log.Debug("failed to fetch alerts", "error", err)
+		return nil, 0, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return alerts, totalCount, nil
+}
+
+func (s *AlertService) GetByID(ctx context.Context, id int32) (*entities.Alert, error) {
+	log := logger.GetLogger(ctx)
+	alert, err := s.alertRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch alert by id", "error", err, "alert_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return alert, nil
+}
+
+func (s *AlertService) Acknowledge(ctx context.Context, id int32) (*entities.Alert, error) {
+	log := logger.GetLogger(ctx)
+	actor, _ := ctx.Value(enums.ContextKeyActor).(string)
+	if err := s.alertRepo.Acknowledge(ctx, id, actor, s.now()); err != nil {
+		log.Debug("failed to acknowledge alert", "error", err, "alert_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("alert acknowledged", "alert_id", id, "acknowledged_by", actor)
+	return s.GetByID(ctx, id)
+}
+
+// validateRule checks the fields that are required by the type of the rule and that the regions exist
+func (s *AlertService) validateRule(ctx context.Context, ruleType entities.AlertRuleType, statuses []entities.WateringStatus, threshold *float64, window time.Duration, regionIDs []int32) error {
+	log := logger.GetLogger(ctx)
+	var valid bool
+	switch ruleType {
+	case entities.AlertRuleTypeTreeClusterStatus:
+		valid = len(statuses) > 0
+	case entities.AlertRuleTypeTreeClusterUnplanned:
+		valid = len(statuses) > 0 && window > 0
+	case entities.AlertRuleTypeSensorBattery:
+		valid = threshold != nil && *threshold > 0
+	}
+	if !valid {
+		log.Debug("alert rule is missing a field of its type", "type", ruleType)
+		return service.ErrAlertRuleInvalid
+	}
+
+	for _, regionID := range regionIDs {
+		if _, err := s.regionRepo.GetByID(ctx, regionID); err != nil {
+			log.Debug("failed to get region of alert rule", "error", err, "region_id", regionID)
+			return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+		}
+	}
+
+	return nil
+}
+
+func severityOrDefault(severity entities.AlertSeverity) entities.AlertSeverity {
+	if severity == "" {
+		return entities.AlertSeverityWarning
+	}
+	return severity
+}
+
+func (s *AlertService) Ready() bool {
+	return s.alertRepo != nil && s.treeClusterRepo != nil && s.sensorRepo != nil && s.wateringPlanRepo != nil
+}
@@ -0,0 +1,336 @@
+package alert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testNow = time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+type testMocks struct {
+	alertRepo        *storageMock.MockAlertRepository
+	treeClusterRepo  *storageMock.MockTreeClusterRepository
+	sensorRepo       *storageMock.MockSensorRepository
+	wateringPlanRepo *storageMock.MockWateringPlanRepository
+	regionRepo       *storageMock.MockRegionRepository
+	eventManager     *worker.EventManager
+}
+
+func newTestService(t *testing.T) (*AlertService, testMocks) {
+	m := testMocks{
+		alertRepo:        storageMock.NewMockAlertRepository(t),
+		treeClusterRepo:  storageMock.NewMockTreeClusterRepository(t),
+		sensorRepo:       storageMock.NewMockSensorRepository(t),
+		wateringPlanRepo: storageMock.NewMockWateringPlanRepository(t),
+		regionRepo:       storageMock.NewMockRegionRepository(t),
+		eventManager:     worker.NewEventManager(entities.EventTypeFireAlert),
+	}
+	svc := NewAlertService(m.alertRepo, m.treeClusterRepo, m.sensorRepo, m.wateringPlanRepo, m.regionRepo, m.eventManager)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+func testRuleCreate() *entities.AlertRuleCreate {
+	return &entities.AlertRuleCreate{
+		Name:             "Bad tree clusters in the city center",
+		Type:             entities.AlertRuleTypeTreeClusterStatus,
+		RegionIDs:        []int32{1},
+		WateringStatuses: []entities.WateringStatus{entities.WateringStatusBad},
+		For:              48 * time.Hour,
+		Notify:           true,
+		Enabled:          true,
+	}
+}
+
+func TestAlertService_CreateRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create rule with default severity", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		createData := testRuleCreate()
+		m.regionRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.Region{ID: 1}, nil)
+		m.alertRepo.EXPECT().CreateRule(ctx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*entities.AlertRule, storage.AlertRepository) (bool, error)) (*entities.AlertRule, error) {
+			rule := &entities.AlertRule{ID: 1}
+			ok, err := fn(rule, m.alertRepo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return rule, nil
+		})
+
+		// when
+		got, err := svc.CreateRule(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, createData.Name, got.Name)
+		assert.Equal(t, entities.AlertSeverityWarning, got.Severity)
+		assert.Equal(t, createData.RegionIDs, got.RegionIDs)
+		assert.Equal(t, createData.WateringStatuses, got.WateringStatuses)
+		assert.Equal(t, createData.For, got.For)
+		assert.True(t, got.Notify)
+		assert.True(t, got.Enabled)
+	})
+
+	t.Run("should return validation error for unknown watering status", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		createData := testRuleCreate()
+		createData.WateringStatuses = []entities.WateringStatus{"dry"}
+
+		// when
+		got, err := svc.CreateRule(ctx, createData)
+
+		// then
+		assert.Nil(t, got)
+		assertBadRequest(t, err)
+	})
+
+	t.Run("should return validation error for negative duration", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		createData := testRuleCreate()
+		createData.For = -time.Hour
+
+		// when
+		got, err := svc.CreateRule(ctx, createData)
+
+		// then
+		assert.Nil(t, got)
+		assertBadRequest(t, err)
+	})
+
+	tests := []struct {
+		name   string
+		modify func(c *entities.AlertRuleCreate)
+	}{
+		{
+			name:   "status rule without watering statuses",
+			modify: func(c *entities.AlertRuleCreate) { c.WateringStatuses = nil },
+		},
+		{
+			name: "unplanned rule without window",
+			modify: func(c *entities.AlertRuleCreate) {
+				c.Type = entities.AlertRuleTypeTreeClusterUnplanned
+			},
+		},
+		{
+			name: "battery rule without threshold",
+			modify: func(c *entities.AlertRuleCreate) {
+				c.Type = entities.AlertRuleTypeSensorBattery
+				c.WateringStatuses = nil
+			},
+		},
+		{
+			name: "battery rule with zero threshold",
+			modify: func(c *entities.AlertRuleCreate) {
+				c.Type = entities.AlertRuleTypeSensorBattery
+				c.Threshold = utils.P(0.0)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run("should return error for "+tt.name, func(t *testing.T) {
+			// given
+			svc, _ := newTestService(t)
+			createData := testRuleCreate()
+			tt.modify(createData)
+
+			// when
+			got, err := svc.CreateRule(ctx, createData)
+
+			// then
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, service.ErrAlertRuleInvalid)
+		})
+	}
+
+	t.Run("should return not found error when a region does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.regionRepo.EXPECT().GetByID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.CreateRule(ctx, testRuleCreate())
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+}
+
+func TestAlertService_UpdateRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should update rule", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		updateData := &entities.AlertRuleUpdate{
+			Name:      "Low sensor battery",
+			Type:      entities.AlertRuleTypeSensorBattery,
+			Severity:  entities.AlertSeverityCritical,
+			Threshold: utils.P(3.2),
+			Enabled:   false,
+		}
+		rule := &entities.AlertRule{ID: 1, Name: "Bad tree clusters", Type: entities.AlertRuleTypeTreeClusterStatus, Enabled: true}
+		m.alertRepo.EXPECT().UpdateRule(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.AlertRule, storage.AlertRepository) (bool, error)) error {
+			ok, err := fn(rule, m.alertRepo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return nil
+		})
+		m.alertRepo.EXPECT().GetRuleByID(ctx, int32(1)).Return(rule, nil)
+
+		// when
+		got, err := svc.UpdateRule(ctx, 1, updateData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, updateData.Name, got.Name)
+		assert.Equal(t, entities.AlertRuleTypeSensorBattery, got.Type)
+		assert.Equal(t, entities.AlertSeverityCritical, got.Severity)
+		assert.Equal(t, updateData.Threshold, got.Threshold)
+		assert.False(t, got.Enabled)
+	})
+
+	t.Run("should return not found error when rule does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.alertRepo.EXPECT().UpdateRule(ctx, int32(99), mock.Anything).Return(storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.UpdateRule(ctx, 99, &entities.AlertRuleUpdate{
+			Name:      "Low sensor battery",
+			Type:      entities.AlertRuleTypeSensorBattery,
+			Threshold: utils.P(3.2),
+		})
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+}
+
+func TestAlertService_DeleteRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete rule", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.alertRepo.EXPECT().DeleteRule(ctx, int32(1)).Return(nil)
+
+		// when
+		err := svc.DeleteRule(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found error when rule does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.alertRepo.EXPECT().DeleteRule(ctx, int32(99)).Return(storage.ErrEntityNotFound("not found"))
+
+		// when
+		err := svc.DeleteRule(ctx, 99)
+
+		// then
+		assertNotFound(t, err)
+	})
+}
+
+func TestAlertService_GetAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return alerts matching the query", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		query := entities.AlertQuery{Statuses: []entities.AlertStatus{entities.AlertStatusFiring}, Unacknowledged: true}
+		alerts := []*entities.Alert{{ID: 1, Status: entities.AlertStatusFiring}}
+		m.alertRepo.EXPECT().GetAll(ctx, query).Return(alerts, int64(1), nil)
+
+		// when
+		got, totalCount, err := svc.GetAll(ctx, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, alerts, got)
+		assert.Equal(t, int64(1), totalCount)
+	})
+}
+
+func TestAlertService_Acknowledge(t *testing.T) {
+	t.Run("should acknowledge alert by the acting user", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.WithValue(context.Background(), enums.ContextKeyActor, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+		acknowledged := &entities.Alert{
+			ID:             1,
+			Status:         entities.AlertStatusFiring,
+			AcknowledgedAt: &testNow,
+			AcknowledgedBy: utils.P("6a1078e8-80fd-458f-b74e-e388fe2dd6ab"),
+		}
+		m.alertRepo.EXPECT().Acknowledge(ctx, int32(1), "6a1078e8-80fd-458f-b74e-e388fe2dd6ab", testNow).Return(nil)
+		m.alertRepo.EXPECT().GetByID(ctx, int32(1)).Return(acknowledged, nil)
+
+		// when
+		got, err := svc.Acknowledge(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, acknowledged, got)
+	})
+
+	t.Run("should return not found error when alert does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		m.alertRepo.EXPECT().Acknowledge(ctx, int32(99), "", testNow).Return(storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Acknowledge(ctx, 99)
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+}
+
+func TestAlertService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false if the service is not ready", func(t *testing.T) {
+		svc := NewAlertService(nil, nil, nil, nil, nil, nil)
+		assert.False(t, svc.Ready())
+	})
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+	var svcErr service.Error
+	if assert.ErrorAs(t, err, &svcErr) {
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	}
+}
+
+func assertBadRequest(t *testing.T, err error) {
+	t.Helper()
+	var svcErr service.Error
+	if assert.ErrorAs(t, err, &svcErr) {
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	}
+}
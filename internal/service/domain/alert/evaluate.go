@@ -0,0 +1,348 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+)
+
+// match is a tree cluster or sensor that fulfills the condition of a rule
+type match struct {
+	subjectType entities.AlertSubjectType
+	subjectID   string
+	subjectName string
+	message     string
+	value       *float64
+}
+
+type subjectKey struct {
+	subjectType entities.AlertSubjectType
+	subjectID   string
+}
+
+// snapshot holds the tree clusters, sensors and watering plans of one evaluation. They are fetched on first use,
+// so every rule of an evaluation sees the same state and nothing is fetched that no rule needs.
+type snapshot struct {
+	clusters []*entities.TreeCluster
+	sensors  []*entities.Sensor
+	plans    []*entities.WateringPlan
+}
+
+func (s *AlertService) Evaluate(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	rules, err := s.alertRepo.GetAllRules(ctx)
+	if err != nil {
+		log.Error("failed to fetch alert rules", "error", err)
+		return err
+	}
+
+	now := s.now()
+	snap := &snapshot{}
+	var failed int
+	for _, rule := range rules {
+		if err := s.evaluate(ctx, rule, snap, now); err != nil {
+			log.Error("failed to evaluate alert rule", "error", err, "rule_id", rule.ID)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to evaluate %d of %d alert rules", failed, len(rules))
+	}
+
+	log.Info("evaluated alert rules", "rules", len(rules))
+	return nil
+}
+
+// evaluate updates the open alerts of a rule. New matches create a pending alert, pending alerts fire once the
+// condition held for the duration of the rule. Alerts that no longer match are removed while pending and resolved
+// once they fired. The open alerts of a disabled rule are closed the same way.
+func (s *AlertService) evaluate(ctx context.Context, rule *entities.AlertRule, snap *snapshot, now time.Time) error {
+	var matches []*match
+	if rule.Enabled {
+		var err error
+		matches, err = s.match(ctx, rule, snap, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	open, err := s.alertRepo.GetOpenByRuleID(ctx, rule.ID)
+	if err != nil {
+		return err
+	}
+
+	openBySubject := make(map[subjectKey]*entities.Alert, len(open))
+	for _, alert := range open {
+		openBySubject[subjectKey{alert.SubjectType, alert.SubjectID}] = alert
+	}
+
+	var errs []error
+	for _, m := range matches {
+		key := subjectKey{m.subjectType, m.subjectID}
+		alert, ok := openBySubject[key]
+		if !ok {
+			errs = append(errs, s.open(ctx, rule, m, now))
+			continue
+		}
+		delete(openBySubject, key)
+
+		alert.SubjectName = m.subjectName
+		alert.Message = m.message
+		alert.Value = m.value
+		fired := alert.Status == entities.AlertStatusPending && !now.Before(alert.CreatedAt.Add(rule.For))
+		if fired {
+			alert.Status = entities.AlertStatusFiring
+			alert.FiredAt = &now
+		}
+
+		err := s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+			if err := s.alertRepo.Update(ctx, alert); err != nil {
+				return err
+			}
+			if fired {
+				s.publishFireEvent(ctx, rule, alert)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, alert := range openBySubject {
+		errs = append(errs, s.close(ctx, alert, now))
+	}
+
+	return errors.Join(errs...)
+}
+
+// open creates the alert of a new match, it fires immediately if the rule has no duration
+func (s *AlertService) open(ctx context.Context, rule *entities.AlertRule, m *match, now time.Time) error {
+	alert := &entities.Alert{
+		CreatedAt:   now,
+		RuleID:      rule.ID,
+		SubjectType: m.subjectType,
+		SubjectID:   m.subjectID,
+		SubjectName: m.subjectName,
+		Status:      entities.AlertStatusPending,
+		Message:     m.message,
+		Value:       m.value,
+	}
+	if rule.For <= 0 {
+		alert.Status = entities.AlertStatusFiring
+		alert.FiredAt = &now
+	}
+
+	return s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		created, err := s.alertRepo.Create(ctx, alert)
+		if err != nil {
+			return err
+		}
+
+		if created.Status == entities.AlertStatusFiring {
+			s.publishFireEvent(ctx, rule, created)
+		}
+		return nil
+	})
+}
+
+// close removes a pending alert and resolves a firing alert
+func (s *AlertService) close(ctx context.Context, alert *entities.Alert, now time.Time) error {
+	if alert.Status == entities.AlertStatusPending {
+		return s.alertRepo.Delete(ctx, alert.ID)
+	}
+
+	alert.Status = entities.AlertStatusResolved
+	alert.ResolvedAt = &now
+	if err := s.alertRepo.Update(ctx, alert); err != nil {
+		return err
+	}
+
+	logger.GetLogger(ctx).Info("alert resolved", "alert_id", alert.ID, "rule_id", alert.RuleID)
+	return nil
+}
+
+func (s *AlertService) publishFireEvent(ctx context.Context, rule *entities.AlertRule, alert *entities.Alert) {
+	log := logger.GetLogger(ctx)
+	log.Info("alert fired", "alert_id", alert.ID, "rule_id", rule.ID, "subject_id", alert.SubjectID)
+	if err := s.eventManager.Publish(ctx, entities.NewEventFireAlert(rule, alert)); err != nil {
+		log.Error("error while sending event after alert fired", "err", err, "alert_id", alert.ID)
+	}
+}
+
+func (s *AlertService) match(ctx context.Context, rule *entities.AlertRule, snap *snapshot, now time.Time) ([]*match, error) {
+	switch rule.Type {
+	case entities.AlertRuleTypeTreeClusterStatus:
+		clusters, err := s.clusters(ctx, snap)
+		if err != nil {
+			return nil, err
+		}
+		return matchClusterStatus(rule, clusters), nil
+	case entities.AlertRuleTypeTreeClusterUnplanned:
+		clusters, err := s.clusters(ctx, snap)
+		if err != nil {
+			return nil, err
+		}
+		plans, err := s.plans(ctx, snap)
+		if err != nil {
+			return nil, err
+		}
+		return matchClusterUnplanned(rule, clusters, plans, now), nil
+	case entities.AlertRuleTypeSensorBattery:
+		sensors, err := s.sensors(ctx, snap)
+		if err != nil {
+			return nil, err
+		}
+		return matchSensorBattery(rule, sensors), nil
+	default:
+		return nil, fmt.Errorf("unknown alert rule type %q", rule.Type)
+	}
+}
+
+func (s *AlertService) clusters(ctx context.Context, snap *snapshot) ([]*entities.TreeCluster, error) {
+	if snap.clusters == nil {
+		clusters, _, err := s.treeClusterRepo.GetAll(ctx, entities.TreeClusterQuery{})
+		if err != nil {
+			return nil, err
+		}
+		snap.clusters = clusters
+	}
+	return snap.clusters, nil
+}
+
+func (s *AlertService) sensors(ctx context.Context, snap *snapshot) ([]*entities.Sensor, error) {
+	if snap.sensors == nil {
+		sensors, _, err := s.sensorRepo.GetAll(ctx, entities.SensorQuery{})
+		if err != nil {
+			return nil, err
+		}
+		snap.sensors = sensors
+	}
+	return snap.sensors, nil
+}
+
+func (s *AlertService) plans(ctx context.Context, snap *snapshot) ([]*entities.WateringPlan, error) {
+	if snap.plans == nil {
+		plans, _, err := s.wateringPlanRepo.GetAll(ctx, entities.Query{})
+		if err != nil {
+			return nil, err
+		}
+		snap.plans = plans
+	}
+	return snap.plans, nil
+}
+
+func matchClusterStatus(rule *entities.AlertRule, clusters []*entities.TreeCluster) []*match {
+	var matches []*match
+	for _, cluster := range clusters {
+		if !clusterMatches(rule, cluster) {
+			continue
+		}
+		matches = append(matches, &match{
+			subjectType: entities.AlertSubjectTypeTreeCluster,
+			subjectID:   strconv.Itoa(int(cluster.ID)),
+			subjectName: cluster.Name,
+			message:     fmt.Sprintf("The watering status of the tree cluster %s%s is %s.", cluster.Name, inRegion(cluster), cluster.WateringStatus),
+		})
+	}
+	return matches
+}
+
+// matchClusterUnplanned matches the tree clusters that are not part of a planned or active watering plan from today
+// until the end of the window
+func matchClusterUnplanned(rule *entities.AlertRule, clusters []*entities.TreeCluster, plans []*entities.WateringPlan, now time.Time) []*match {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	until := now.Add(rule.Window)
+	planned := make(map[int32]struct{})
+	for _, wp := range plans {
+		if wp.Status != entities.WateringPlanStatusPlanned && wp.Status != entities.WateringPlanStatusActive {
+			continue
+		}
+		if wp.Date.Before(today) || wp.Date.After(until) {
+			continue
+		}
+		for _, tc := range wp.TreeClusters {
+			planned[tc.ID] = struct{}{}
+		}
+	}
+
+	var matches []*match
+	for _, cluster := range clusters {
+		if !clusterMatches(rule, cluster) {
+			continue
+		}
+		if _, ok := planned[cluster.ID]; ok {
+			continue
+		}
+		matches = append(matches, &match{
+			subjectType: entities.AlertSubjectTypeTreeCluster,
+			subjectID:   strconv.Itoa(int(cluster.ID)),
+			subjectName: cluster.Name,
+			message: fmt.Sprintf("The tree cluster %s%s is %s and not part of a watering plan within %s.",
+				cluster.Name, inRegion(cluster), cluster.WateringStatus, formatHours(rule.Window)),
+		})
+	}
+	return matches
+}
+
+// matchSensorBattery matches the sensors whose latest data reports a battery voltage below the threshold. A voltage
+// of zero is not reported by the sensor and never matches.
+func matchSensorBattery(rule *entities.AlertRule, sensors []*entities.Sensor) []*match {
+	if rule.Threshold == nil {
+		return nil
+	}
+
+	var matches []*match
+	for _, sensor := range sensors {
+		if sensor.LatestData == nil || sensor.LatestData.Data == nil {
+			continue
+		}
+		battery := sensor.LatestData.Data.Battery
+		if battery <= 0 || battery >= *rule.Threshold {
+			continue
+		}
+		matches = append(matches, &match{
+			subjectType: entities.AlertSubjectTypeSensor,
+			subjectID:   sensor.ID,
+			subjectName: sensor.ID,
+			message:     fmt.Sprintf("The battery of the sensor %s is at %.2f V, below %.2f V.", sensor.ID, battery, *rule.Threshold),
+			value:       &battery,
+		})
+	}
+	return matches
+}
+
+// clusterMatches reports if the tree cluster is not archived, has one of the watering statuses and lies in one of
+// the regions of the rule
+func clusterMatches(rule *entities.AlertRule, cluster *entities.TreeCluster) bool {
+	if cluster.Archived || !slices.Contains(rule.WateringStatuses, cluster.WateringStatus) {
+		return false
+	}
+	if len(rule.RegionIDs) == 0 {
+		return true
+	}
+	return cluster.Region != nil && slices.Contains(rule.RegionIDs, cluster.Region.ID)
+}
+
+func inRegion(cluster *entities.TreeCluster) string {
+	if cluster.Region == nil {
+		return ""
+	}
+	return " in " + cluster.Region.Name
+}
+
+// formatHours formats a window like 48 hours or 1.5 hours
+func formatHours(d time.Duration) string {
+	hours := strconv.FormatFloat(d.Hours(), 'f', -1, 64)
+	if hours == "1" {
+		return "1 hour"
+	}
+	return hours + " hours"
+}
@@ -0,0 +1,337 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testRegion      = &entities.Region{ID: 1, Name: "Mürwik"}
+	testOtherRegion = &entities.Region{ID: 2, Name: "Altstadt"}
+)
+
+func testClusters() []*entities.TreeCluster {
+	return []*entities.TreeCluster{
+		{ID: 1, Name: "Solitüde Strand", WateringStatus: entities.WateringStatusBad, Region: testRegion},
+		{ID: 2, Name: "Flensburger Hafen", WateringStatus: entities.WateringStatusBad, Region: testOtherRegion},
+		{ID: 3, Name: "Twedter Plack", WateringStatus: entities.WateringStatusGood, Region: testRegion},
+		{ID: 4, Name: "Archived", WateringStatus: entities.WateringStatusBad, Region: testRegion, Archived: true},
+	}
+}
+
+func testStatusRule() *entities.AlertRule {
+	return &entities.AlertRule{
+		ID:               1,
+		Name:             "Bad tree clusters in Mürwik",
+		Type:             entities.AlertRuleTypeTreeClusterStatus,
+		Severity:         entities.AlertSeverityCritical,
+		RegionIDs:        []int32{1},
+		WateringStatuses: []entities.WateringStatus{entities.WateringStatusBad},
+		For:              48 * time.Hour,
+		Notify:           true,
+		Enabled:          true,
+	}
+}
+
+// runEvents subscribes to the fire alert events and runs the event manager until the test ends
+func runEvents(t *testing.T, m testMocks) <-chan entities.Event {
+	t.Helper()
+	_, ch, err := m.eventManager.Subscribe(entities.EventTypeFireAlert)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go m.eventManager.Run(ctx)
+	return ch
+}
+
+func receiveFireEvent(t *testing.T, ch <-chan entities.Event) entities.EventFireAlert {
+	t.Helper()
+	select {
+	case event := <-ch:
+		e, ok := event.(entities.EventFireAlert)
+		assert.True(t, ok)
+		return e
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("event was not received")
+		return entities.EventFireAlert{}
+	}
+}
+
+func assertNoEvent(t *testing.T, ch <-chan entities.Event) {
+	t.Helper()
+	select {
+	case event := <-ch:
+		t.Fatalf("unexpected event %v", event.Type())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAlertService_Evaluate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create pending alert for new match in region", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ch := runEvents(t, m)
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{testStatusRule()}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(testClusters(), int64(4), nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(1)).Return(nil, nil)
+		m.alertRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entities.Alert) (*entities.Alert, error) {
+			assert.Equal(t, entities.AlertStatusPending, a.Status)
+			assert.Equal(t, testNow, a.CreatedAt)
+			assert.Equal(t, entities.AlertSubjectTypeTreeCluster, a.SubjectType)
+			assert.Equal(t, "1", a.SubjectID)
+			assert.Equal(t, "Solitüde Strand", a.SubjectName)
+			assert.Equal(t, "The watering status of the tree cluster Solitüde Strand in Mürwik is bad.", a.Message)
+			assert.Nil(t, a.FiredAt)
+			created := *a
+			created.ID = 1
+			return &created, nil
+		}).Once()
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assertNoEvent(t, ch)
+	})
+
+	t.Run("should keep alert pending while the duration is not reached", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ch := runEvents(t, m)
+		pending := &entities.Alert{ID: 1, CreatedAt: testNow.Add(-47 * time.Hour), RuleID: 1, SubjectType: entities.AlertSubjectTypeTreeCluster, SubjectID: "1", Status: entities.AlertStatusPending}
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{testStatusRule()}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(testClusters(), int64(4), nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(1)).Return([]*entities.Alert{pending}, nil)
+		m.alertRepo.EXPECT().Update(ctx, mock.MatchedBy(func(a *entities.Alert) bool {
+			return a.ID == 1 && a.Status == entities.AlertStatusPending && a.FiredAt == nil
+		})).Return(nil)
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assertNoEvent(t, ch)
+	})
+
+	t.Run("should fire pending alert once the duration is reached", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ch := runEvents(t, m)
+		rule := testStatusRule()
+		pending := &entities.Alert{ID: 1, CreatedAt: testNow.Add(-48 * time.Hour), RuleID: 1, SubjectType: entities.AlertSubjectTypeTreeCluster, SubjectID: "1", Status: entities.AlertStatusPending}
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{rule}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(testClusters(), int64(4), nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(1)).Return([]*entities.Alert{pending}, nil)
+		m.alertRepo.EXPECT().Update(ctx, mock.MatchedBy(func(a *entities.Alert) bool {
+			return a.ID == 1 && a.Status == entities.AlertStatusFiring && a.FiredAt != nil && a.FiredAt.Equal(testNow)
+		})).Return(nil)
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		event := receiveFireEvent(t, ch)
+		assert.Equal(t, rule, event.Rule)
+		assert.Equal(t, int32(1), event.Alert.ID)
+		assert.Equal(t, "Solitüde Strand", event.Alert.SubjectName)
+	})
+
+	t.Run("should delete pending alert and resolve firing alert that no longer match", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ch := runEvents(t, m)
+		clusters := testClusters()
+		clusters[0].WateringStatus = entities.WateringStatusJustWatered
+		pending := &entities.Alert{ID: 1, CreatedAt: testNow.Add(-time.Hour), RuleID: 1, SubjectType: entities.AlertSubjectTypeTreeCluster, SubjectID: "1", Status: entities.AlertStatusPending}
+		firing := &entities.Alert{ID: 2, CreatedAt: testNow.Add(-72 * time.Hour), RuleID: 1, SubjectType: entities.AlertSubjectTypeTreeCluster, SubjectID: "4", Status: entities.AlertStatusFiring}
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{testStatusRule()}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(clusters, int64(4), nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(1)).Return([]*entities.Alert{pending, firing}, nil)
+		m.alertRepo.EXPECT().Delete(ctx, int32(1)).Return(nil)
+		m.alertRepo.EXPECT().Update(ctx, mock.MatchedBy(func(a *entities.Alert) bool {
+			return a.ID == 2 && a.Status == entities.AlertStatusResolved && a.ResolvedAt != nil && a.ResolvedAt.Equal(testNow)
+		})).Return(nil)
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assertNoEvent(t, ch)
+	})
+
+	t.Run("should close the open alerts of a disabled rule", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		rule := testStatusRule()
+		rule.Enabled = false
+		firing := &entities.Alert{ID: 2, RuleID: 1, SubjectType: entities.AlertSubjectTypeTreeCluster, SubjectID: "1", Status: entities.AlertStatusFiring}
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{rule}, nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(1)).Return([]*entities.Alert{firing}, nil)
+		m.alertRepo.EXPECT().Update(ctx, mock.MatchedBy(func(a *entities.Alert) bool {
+			return a.ID == 2 && a.Status == entities.AlertStatusResolved
+		})).Return(nil)
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		m.treeClusterRepo.AssertNotCalled(t, "GetAll")
+	})
+
+	t.Run("should match clusters of all regions without region filter", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		rule := testStatusRule()
+		rule.RegionIDs = []int32{}
+		var subjects []string
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{rule}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(testClusters(), int64(4), nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(1)).Return(nil, nil)
+		m.alertRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entities.Alert) (*entities.Alert, error) {
+			subjects = append(subjects, a.SubjectID)
+			return a, nil
+		})
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, subjects)
+	})
+
+	t.Run("should match bad clusters without watering plan within the window", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		rule := &entities.AlertRule{
+			ID:               2,
+			Name:             "Bad tree clusters without watering plan",
+			Type:             entities.AlertRuleTypeTreeClusterUnplanned,
+			WateringStatuses: []entities.WateringStatus{entities.WateringStatusBad},
+			Window:           48 * time.Hour,
+			Enabled:          true,
+		}
+		plans := []*entities.WateringPlan{
+			// planned for cluster 1 within the window
+			{ID: 1, Date: testNow.Add(24 * time.Hour), Status: entities.WateringPlanStatusPlanned, TreeClusters: []*entities.TreeCluster{{ID: 1}}},
+			// too late for cluster 2
+			{ID: 2, Date: testNow.Add(72 * time.Hour), Status: entities.WateringPlanStatusPlanned, TreeClusters: []*entities.TreeCluster{{ID: 2}}},
+			// canceled plans do not count
+			{ID: 3, Date: testNow, Status: entities.WateringPlanStatusCanceled, TreeClusters: []*entities.TreeCluster{{ID: 2}}},
+		}
+		var created []*entities.Alert
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{rule}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(testClusters(), int64(4), nil)
+		m.wateringPlanRepo.EXPECT().GetAll(ctx, entities.Query{}).Return(plans, int64(3), nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(2)).Return(nil, nil)
+		m.alertRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entities.Alert) (*entities.Alert, error) {
+			created = append(created, a)
+			return a, nil
+		})
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		if assert.Len(t, created, 1) {
+			assert.Equal(t, "2", created[0].SubjectID)
+			assert.Equal(t, entities.AlertStatusFiring, created[0].Status)
+			assert.Equal(t, "The tree cluster Flensburger Hafen in Altstadt is bad and not part of a watering plan within 48 hours.", created[0].Message)
+		}
+	})
+
+	t.Run("should fire immediately for sensors with low battery", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ch := runEvents(t, m)
+		rule := &entities.AlertRule{
+			ID:        3,
+			Name:      "Low sensor battery",
+			Type:      entities.AlertRuleTypeSensorBattery,
+			Threshold: utils.P(3.2),
+			Notify:    true,
+			Enabled:   true,
+		}
+		sensors := []*entities.Sensor{
+			{ID: "sensor-1", LatestData: &entities.SensorData{Data: &entities.MqttPayload{Battery: 3.1}}},
+			{ID: "sensor-2", LatestData: &entities.SensorData{Data: &entities.MqttPayload{Battery: 3.4}}},
+			{ID: "sensor-3", LatestData: &entities.SensorData{Data: &entities.MqttPayload{Battery: 0}}},
+			{ID: "sensor-4"},
+		}
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{rule}, nil)
+		m.sensorRepo.EXPECT().GetAll(ctx, entities.SensorQuery{}).Return(sensors, int64(4), nil)
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(3)).Return(nil, nil)
+		m.alertRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entities.Alert) (*entities.Alert, error) {
+			created := *a
+			created.ID = 5
+			return &created, nil
+		}).Once()
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.NoError(t, err)
+		event := receiveFireEvent(t, ch)
+		assert.Equal(t, int32(5), event.Alert.ID)
+		assert.Equal(t, entities.AlertSubjectTypeSensor, event.Alert.SubjectType)
+		assert.Equal(t, "sensor-1", event.Alert.SubjectID)
+		assert.Equal(t, entities.AlertStatusFiring, event.Alert.Status)
+		assert.Equal(t, utils.P(3.1), event.Alert.Value)
+		assert.Equal(t, "The battery of the sensor sensor-1 is at 3.10 V, below 3.20 V.", event.Alert.Message)
+	})
+
+	t.Run("should fetch the tree clusters once and evaluate the remaining rules when a rule fails", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		other := testStatusRule()
+		other.ID = 2
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return([]*entities.AlertRule{testStatusRule(), other}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, entities.TreeClusterQuery{}).Return(testClusters(), int64(4), nil).Once()
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(1)).Return(nil, errors.New("db down"))
+		m.alertRepo.EXPECT().GetOpenByRuleID(ctx, int32(2)).Return(nil, nil)
+		m.alertRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entities.Alert) (*entities.Alert, error) {
+			assert.Equal(t, int32(2), a.RuleID)
+			return a, nil
+		}).Once()
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.ErrorContains(t, err, "failed to evaluate 1 of 2 alert rules")
+	})
+
+	t.Run("should return error when rules can not be fetched", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.alertRepo.EXPECT().GetAllRules(ctx).Return(nil, errors.New("db down"))
+
+		// when
+		err := svc.Evaluate(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatHours(t *testing.T) {
+	assert.Equal(t, "1 hour", formatHours(time.Hour))
+	assert.Equal(t, "48 hours", formatHours(48*time.Hour))
+	assert.Equal(t, "1.5 hours", formatHours(90*time.Minute))
+}
@@ -0,0 +1,321 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+// KeyPrefix marks a string as api key of this application, e.g. "ge_1a2b3c4d5e6f_<secret>"
+const KeyPrefix = "ge"
+
+const (
+	prefixLength = 6
+	secretLength = 32
+)
+
+type APIKeyServiceConfig struct {
+	lastUsedInterval time.Duration
+	now              func() time.Time
+}
+
+type APIKeyServiceOption func(*APIKeyServiceConfig)
+
+var defaultAPIKeyServiceConfig = APIKeyServiceConfig{
+	lastUsedInterval: time.Minute,
+	now:              time.Now,
+}
+
+// WithLastUsedInterval sets how often the last used timestamp of a key is written to the storage
+func WithLastUsedInterval(interval time.Duration) APIKeyServiceOption {
+	slog.Debug("use api key service with last used interval", "interval", interval)
+	return func(cfg *APIKeyServiceConfig) {
+		cfg.lastUsedInterval = interval
+	}
+}
+
+type APIKeyService struct {
+	APIKeyServiceConfig
+	apiKeyRepo storage.APIKeyRepository
+	validator  *validator.Validate
+}
+
+var _ service.APIKeyService = (*APIKeyService)(nil)
+
+func NewAPIKeyService(apiKeyRepo storage.APIKeyRepository, opts ...APIKeyServiceOption) *APIKeyService {
+	cfg := defaultAPIKeyServiceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &APIKeyService{
+		APIKeyServiceConfig: cfg,
+		apiKeyRepo:          apiKeyRepo,
+		validator:           validator.New(),
+	}
+}
+
+func (s *APIKeyService) GetAll(ctx context.Context) ([]*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	keys, err := s.apiKeyRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch api keys", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return keys, nil
+}
+
+func (s *APIKeyService) GetByID(ctx context.Context, id int32) (*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch api key by id", "error", err, "api_key_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return key, nil
+}
+
+func (s *APIKeyService) Create(ctx context.Context, createData *entities.APIKeyCreate) (*entities.APIKey, string, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate struct from create api key", "error", err, "raw_api_key", fmt.Sprintf("%+v", createData))
+		return nil, "", service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validate(createData.Scopes, createData.ExpiresAt); err != nil {
+		log.Debug("invalid api key", "error", err, "scopes", createData.Scopes, "expires_at", createData.ExpiresAt)
+		return nil, "", err
+	}
+
+	if caller := callerKey(ctx); caller != nil {
+		if !holdsScopes(caller, createData.Scopes) {
+			log.Warn("api key tried to create a key with scopes it does not hold", "api_key_id", caller.ID, "scopes", createData.Scopes)
+			return nil, "", service.ErrAPIKeyScopeNotGranted
+		}
+		if !expiresWithin(caller, createData.ExpiresAt) {
+			log.Warn("api key tried to create a key that outlives it", "api_key_id", caller.ID, "expires_at", createData.ExpiresAt)
+			return nil, "", service.ErrAPIKeyExpiryNotBounded
+		}
+	}
+
+	prefix, plainKey, err := generateKey()
+	if err != nil {
+		log.Error("failed to generate api key", "error", err)
+		return nil, "", service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	created, err := s.apiKeyRepo.Create(ctx, func(key *entities.APIKey, _ storage.APIKeyRepository) (bool, error) {
+		key.Name = createData.Name
+		key.Prefix = prefix
+		key.KeyHash = hashKey(plainKey)
+		key.Scopes = createData.Scopes
+		key.CreatedBy = createData.CreatedBy
+		key.ExpiresAt = createData.ExpiresAt
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create api key", "error", err)
+		return nil, "", service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("api key created successfully", "api_key_id", created.ID, "api_key_prefix", created.Prefix, "scopes", created.Scopes)
+	return created, plainKey, nil
+}
+
+func (s *APIKeyService) Update(ctx context.Context, id int32, updateData *entities.APIKeyUpdate) (*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(updateData); err != nil {
+		log.Debug("failed to validate struct from update api key", "error", err, "raw_api_key", fmt.Sprintf("%+v", updateData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validate(updateData.Scopes, updateData.ExpiresAt); err != nil {
+		log.Debug("invalid api key", "error", err, "scopes", updateData.Scopes, "expires_at", updateData.ExpiresAt)
+		return nil, err
+	}
+
+	if caller := callerKey(ctx); caller != nil {
+		// a key must not extend its own scopes or expiry
+		if caller.ID == id {
+			log.Warn("api key tried to update itself", "api_key_id", caller.ID)
+			return nil, service.ErrAPIKeyUpdateSelf
+		}
+		if !holdsScopes(caller, updateData.Scopes) {
+			log.Warn("api key tried to grant scopes it does not hold", "api_key_id", caller.ID, "target_api_key_id", id, "scopes", updateData.Scopes)
+			return nil, service.ErrAPIKeyScopeNotGranted
+		}
+		if !expiresWithin(caller, updateData.ExpiresAt) {
+			log.Warn("api key tried to extend a key beyond its own expiry", "api_key_id", caller.ID, "target_api_key_id", id, "expires_at", updateData.ExpiresAt)
+			return nil, service.ErrAPIKeyExpiryNotBounded
+		}
+	}
+
+	err := s.apiKeyRepo.Update(ctx, id, func(key *entities.APIKey, _ storage.APIKeyRepository) (bool, error) {
+		key.Name = updateData.Name
+		key.Scopes = updateData.Scopes
+		key.ExpiresAt = updateData.ExpiresAt
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update api key", "error", err, "api_key_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("api key updated successfully", "api_key_id", id)
+	return s.GetByID(ctx, id)
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id int32) (*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	err := s.apiKeyRepo.Update(ctx, id, func(key *entities.APIKey, _ storage.APIKeyRepository) (bool, error) {
+		if key.RevokedAt != nil {
+			return false, nil
+		}
+		now := s.now()
+		key.RevokedAt = &now
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to revoke api key", "error", err, "api_key_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("api key revoked", "api_key_id", id)
+	return s.GetByID(ctx, id)
+}
+
+func (s *APIKeyService) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	if err := s.apiKeyRepo.Delete(ctx, id); err != nil {
+		log.Debug("failed to delete api key", "error", err, "api_key_id", id)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("api key deleted successfully", "api_key_id", id)
+	return nil
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, plainKey string) (*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	prefix, ok := parsePrefix(plainKey)
+	if !ok {
+		log.Debug("malformed api key")
+		return nil, service.ErrAPIKeyInvalid
+	}
+
+	key, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if errors.As(err, &entityNotFoundErr) {
+			log.Debug("unknown api key", "api_key_prefix", prefix)
+			return nil, service.ErrAPIKeyInvalid
+		}
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashKey(plainKey))) != 1 {
+		log.Warn("api key with known prefix but wrong secret was used", "api_key_id", key.ID, "api_key_prefix", prefix)
+		return nil, service.ErrAPIKeyInvalid
+	}
+
+	now := s.now()
+	if !key.IsActive(now) {
+		log.Debug("inactive api key was used", "api_key_id", key.ID, "revoked_at", key.RevokedAt, "expires_at", key.ExpiresAt)
+		return nil, service.ErrAPIKeyInvalid
+	}
+
+	// writing on every request is not needed, the timestamp only has to be roughly accurate
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= s.lastUsedInterval {
+		if err := s.apiKeyRepo.UpdateLastUsed(ctx, key.ID, now); err != nil {
+			log.Error("failed to update last used of api key", "error", err, "api_key_id", key.ID)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, nil
+}
+
+func (s *APIKeyService) Ready() bool {
+	return s.apiKeyRepo != nil
+}
+
+func (s *APIKeyService) validate(scopes []entities.PluginScope, expiresAt *time.Time) error {
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return service.ErrAPIKeyScopeInvalid
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return service.ErrAPIKeyExpiryInPast
+	}
+
+	return nil
+}
+
+// callerKey returns the api key that authenticated the request, nil if a user made the request
+func callerKey(ctx context.Context) *entities.APIKey {
+	key, _ := ctx.Value(enums.ContextKeyAPIKey).(*entities.APIKey)
+	return key
+}
+
+// holdsScopes reports whether the key holds all scopes, so a key can not hand out more access than it has
+func holdsScopes(key *entities.APIKey, scopes []entities.PluginScope) bool {
+	for _, scope := range scopes {
+		if !key.Allows(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// expiresWithin reports whether a key with the expiry expires no later than the key. A key handed out by another
+// key must expire, otherwise it would outlive the key that created it.
+func expiresWithin(key *entities.APIKey, expiresAt *time.Time) bool {
+	if expiresAt == nil {
+		return false
+	}
+	return key.ExpiresAt == nil || !expiresAt.After(*key.ExpiresAt)
+}
+
+// generateKey returns the public prefix and the plain key "ge_<prefix>_<secret>"
+func generateKey() (prefix, plainKey string, err error) {
+	b := make([]byte, prefixLength+secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	prefix = hex.EncodeToString(b[:prefixLength])
+	return prefix, fmt.Sprintf("%s_%s_%s", KeyPrefix, prefix, hex.EncodeToString(b[prefixLength:])), nil
+}
+
+func parsePrefix(plainKey string) (string, bool) {
+	parts := strings.Split(plainKey, "_")
+	if len(parts) != 3 || parts[0] != KeyPrefix || len(parts[1]) != 2*prefixLength || len(parts[2]) != 2*secretLength {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// hashKey hashes the plain key. A fast hash is sufficient because the key has 256 bits of entropy.
+func hashKey(plainKey string) string {
+	sum := sha256.Sum256([]byte(plainKey))
+	return hex.EncodeToString(sum[:])
+}
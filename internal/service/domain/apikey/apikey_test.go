@@ -0,0 +1,457 @@
+package apikey
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPlainKey = "ge_0a1b2c3d4e5f_00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+
+func TestAPIKeyService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create api key and store only its hash", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		createData := &entities.APIKeyCreate{
+			Name:      "Irrigation controller",
+			Scopes:    []entities.PluginScope{"tree:read"},
+			CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		}
+
+		var stored *entities.APIKey
+		repo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*entities.APIKey, storage.APIKeyRepository) (bool, error)) (*entities.APIKey, error) {
+			stored = &entities.APIKey{}
+			ok, err := fn(stored, repo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return stored, nil
+		})
+
+		// when
+		got, plainKey, err := svc.Create(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, createData.Name, got.Name)
+		assert.Equal(t, createData.Scopes, got.Scopes)
+		assert.Equal(t, createData.CreatedBy, got.CreatedBy)
+		assert.True(t, strings.HasPrefix(plainKey, KeyPrefix+"_"+stored.Prefix+"_"))
+		assert.Equal(t, hashKey(plainKey), stored.KeyHash)
+		assert.NotContains(t, stored.KeyHash, plainKey)
+	})
+
+	t.Run("should return error when scope is invalid", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+
+		// when
+		got, plainKey, err := svc.Create(ctx, &entities.APIKeyCreate{
+			Name:   "Irrigation controller",
+			Scopes: []entities.PluginScope{"tree:delete"},
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.Empty(t, plainKey)
+		assert.ErrorIs(t, err, service.ErrAPIKeyScopeInvalid)
+	})
+
+	t.Run("should return error when expiry is in the past", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		expiresAt := time.Now().Add(-time.Hour)
+
+		// when
+		got, _, err := svc.Create(ctx, &entities.APIKeyCreate{
+			Name:      "Irrigation controller",
+			Scopes:    []entities.PluginScope{"tree:read"},
+			ExpiresAt: &expiresAt,
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyExpiryInPast)
+	})
+
+	t.Run("should return validation error when scopes are missing", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+
+		// when
+		got, _, err := svc.Create(ctx, &entities.APIKeyCreate{Name: "Irrigation controller"})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorContains(t, err, "validation error")
+	})
+
+	t.Run("should reject scopes the calling api key does not hold", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		callerCtx := context.WithValue(ctx, enums.ContextKeyAPIKey, testAPIKey())
+
+		// when
+		got, plainKey, err := svc.Create(callerCtx, &entities.APIKeyCreate{
+			Name:   "Escalated",
+			Scopes: []entities.PluginScope{"tree:read", "api-key:write"},
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.Empty(t, plainKey)
+		assert.ErrorIs(t, err, service.ErrAPIKeyScopeNotGranted)
+	})
+
+	t.Run("should create api key with scopes the calling api key holds", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		caller := testAPIKey()
+		caller.ExpiresAt = utils.P(time.Now().Add(48 * time.Hour))
+		callerCtx := context.WithValue(ctx, enums.ContextKeyAPIKey, caller)
+
+		repo.EXPECT().Create(callerCtx, mock.Anything).Return(&entities.APIKey{ID: 2, Scopes: []entities.PluginScope{"tree:read"}}, nil)
+
+		// when
+		got, _, err := svc.Create(callerCtx, &entities.APIKeyCreate{
+			Name:      "Delegated",
+			Scopes:    []entities.PluginScope{"tree:read"},
+			ExpiresAt: utils.P(time.Now().Add(24 * time.Hour)),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), got.ID)
+	})
+
+	t.Run("should reject key without expiry created by an api key", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		callerCtx := context.WithValue(ctx, enums.ContextKeyAPIKey, testAPIKey())
+
+		// when
+		got, plainKey, err := svc.Create(callerCtx, &entities.APIKeyCreate{
+			Name:   "Unbounded",
+			Scopes: []entities.PluginScope{"tree:read"},
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.Empty(t, plainKey)
+		assert.ErrorIs(t, err, service.ErrAPIKeyExpiryNotBounded)
+	})
+
+	t.Run("should reject key that expires after the calling api key", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		caller := testAPIKey()
+		caller.ExpiresAt = utils.P(time.Now().Add(24 * time.Hour))
+		callerCtx := context.WithValue(ctx, enums.ContextKeyAPIKey, caller)
+
+		// when
+		got, _, err := svc.Create(callerCtx, &entities.APIKeyCreate{
+			Name:      "Outliving",
+			Scopes:    []entities.PluginScope{"tree:read"},
+			ExpiresAt: utils.P(time.Now().Add(48 * time.Hour)),
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyExpiryNotBounded)
+	})
+}
+
+func TestAPIKeyService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should update name, scopes and expiry", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		key := testAPIKey()
+		updateData := &entities.APIKeyUpdate{
+			Name:   "Renamed",
+			Scopes: []entities.PluginScope{"tree:read", "tree:write"},
+		}
+
+		repo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.APIKey, storage.APIKeyRepository) (bool, error)) error {
+			ok, err := fn(key, repo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return nil
+		})
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(key, nil)
+
+		// when
+		got, err := svc.Update(ctx, 1, updateData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, updateData.Name, got.Name)
+		assert.Equal(t, updateData.Scopes, got.Scopes)
+	})
+
+	t.Run("should reject api key updating itself", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		caller := testAPIKey()
+		caller.Scopes = []entities.PluginScope{"tree:read", "api-key:write"}
+		callerCtx := context.WithValue(ctx, enums.ContextKeyAPIKey, caller)
+
+		// when
+		got, err := svc.Update(callerCtx, caller.ID, &entities.APIKeyUpdate{
+			Name:   caller.Name,
+			Scopes: []entities.PluginScope{"tree:read", "api-key:write"},
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyUpdateSelf)
+	})
+
+	t.Run("should reject granting scopes the calling api key does not hold", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		caller := testAPIKey()
+		caller.Scopes = []entities.PluginScope{"tree:read", "api-key:write"}
+		callerCtx := context.WithValue(ctx, enums.ContextKeyAPIKey, caller)
+
+		// when
+		got, err := svc.Update(callerCtx, 2, &entities.APIKeyUpdate{
+			Name:   "Other key",
+			Scopes: []entities.PluginScope{"tree:write"},
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyScopeNotGranted)
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should authenticate valid key and update last used", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		now := time.Now()
+		svc.now = func() time.Time { return now }
+		repo.EXPECT().GetByPrefix(ctx, "0a1b2c3d4e5f").Return(testAPIKey(), nil)
+		repo.EXPECT().UpdateLastUsed(ctx, int32(1), now).Return(nil)
+
+		// when
+		got, err := svc.Authenticate(ctx, testPlainKey)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.ID)
+		assert.Equal(t, now, *got.LastUsedAt)
+	})
+
+	t.Run("should not update last used within the interval", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo, WithLastUsedInterval(time.Hour))
+		key := testAPIKey()
+		lastUsed := time.Now().Add(-time.Minute)
+		key.LastUsedAt = &lastUsed
+		repo.EXPECT().GetByPrefix(ctx, "0a1b2c3d4e5f").Return(key, nil)
+
+		// when
+		got, err := svc.Authenticate(ctx, testPlainKey)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, lastUsed, *got.LastUsedAt)
+		repo.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject malformed key without storage lookup", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+
+		// when
+		got, err := svc.Authenticate(ctx, "not-an-api-key")
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyInvalid)
+	})
+
+	t.Run("should reject unknown key", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		repo.EXPECT().GetByPrefix(ctx, "0a1b2c3d4e5f").Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Authenticate(ctx, testPlainKey)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyInvalid)
+	})
+
+	t.Run("should reject key with wrong secret", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		repo.EXPECT().GetByPrefix(ctx, "0a1b2c3d4e5f").Return(testAPIKey(), nil)
+
+		// when
+		got, err := svc.Authenticate(ctx, "ge_0a1b2c3d4e5f_ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyInvalid)
+	})
+
+	t.Run("should reject revoked key", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		key := testAPIKey()
+		revokedAt := time.Now().Add(-time.Minute)
+		key.RevokedAt = &revokedAt
+		repo.EXPECT().GetByPrefix(ctx, "0a1b2c3d4e5f").Return(key, nil)
+
+		// when
+		got, err := svc.Authenticate(ctx, testPlainKey)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyInvalid)
+	})
+
+	t.Run("should reject expired key", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		key := testAPIKey()
+		expiresAt := time.Now().Add(-time.Minute)
+		key.ExpiresAt = &expiresAt
+		repo.EXPECT().GetByPrefix(ctx, "0a1b2c3d4e5f").Return(key, nil)
+
+		// when
+		got, err := svc.Authenticate(ctx, testPlainKey)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrAPIKeyInvalid)
+	})
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should set revoked at", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		now := time.Now()
+		svc.now = func() time.Time { return now }
+
+		key := testAPIKey()
+		repo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.APIKey, storage.APIKeyRepository) (bool, error)) error {
+			ok, err := fn(key, repo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return nil
+		})
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(key, nil)
+
+		// when
+		got, err := svc.Revoke(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, now, *got.RevokedAt)
+	})
+
+	t.Run("should keep first revocation time", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+
+		key := testAPIKey()
+		revokedAt := time.Now().Add(-time.Hour)
+		key.RevokedAt = &revokedAt
+		repo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.APIKey, storage.APIKeyRepository) (bool, error)) error {
+			ok, err := fn(key, repo)
+			assert.False(t, ok)
+			assert.NoError(t, err)
+			return nil
+		})
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(key, nil)
+
+		// when
+		got, err := svc.Revoke(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, revokedAt, *got.RevokedAt)
+	})
+
+	t.Run("should return not found error when api key does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAPIKeyRepository(t)
+		svc := NewAPIKeyService(repo)
+		repo.EXPECT().Update(ctx, int32(1), mock.Anything).Return(storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Revoke(ctx, 1)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, service.NotFound, svcErr.Code)
+		}
+	})
+}
+
+func TestAPIKeyService_Ready(t *testing.T) {
+	t.Run("should return true when repository is set", func(t *testing.T) {
+		svc := NewAPIKeyService(storageMock.NewMockAPIKeyRepository(t))
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false when repository is nil", func(t *testing.T) {
+		svc := NewAPIKeyService(nil)
+		assert.False(t, svc.Ready())
+	})
+}
+
+func testAPIKey() *entities.APIKey {
+	return &entities.APIKey{
+		ID:        1,
+		Name:      "Irrigation controller",
+		Prefix:    "0a1b2c3d4e5f",
+		KeyHash:   hashKey(testPlainKey),
+		Scopes:    []entities.PluginScope{"tree:read"},
+		CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+	}
+}
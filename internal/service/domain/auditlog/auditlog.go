@@ -0,0 +1,65 @@
+package auditlog
+
+import (
+	"context"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type AuditLogService struct {
+	auditLogRepo storage.AuditLogRepository
+}
+
+var _ service.AuditLogService = (*AuditLogService)(nil)
+
+func NewAuditLogService(auditLogRepo storage.AuditLogRepository) *AuditLogService {
+	return &AuditLogService{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+func (s *AuditLogService) GetAll(ctx context.Context, query *domain.AuditLogQuery) ([]*domain.AuditLog, int64, error) {
+	log := logger.GetLogger(ctx)
+	if err := validateQuery(query); err != nil {
+		log.Debug("audit log query is invalid", "error", err, "entity_type", query.EntityType, "action", query.Action)
+		return nil, 0, err
+	}
+
+	logs, totalCount, err := s.auditLogRepo.GetAll(ctx, query)
+	if err != nil {
+		log.Debug("failed to fetch audit logs", "error", err)
+		return nil, 0, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return logs, totalCount, nil
+}
+
+func (s *AuditLogService) GetHistory(ctx context.Context, entityType domain.AuditEntityType, id int32) ([]*domain.AuditLog, int64, error) {
+	return s.GetAll(ctx, &domain.AuditLogQuery{
+		EntityType: entityType,
+		EntityID:   &id,
+	})
+}
+
+func validateQuery(query *domain.AuditLogQuery) error {
+	if query.EntityType != "" && !query.EntityType.IsValid() {
+		return service.ErrAuditLogQueryInvalid
+	}
+
+	if query.Action != "" && !query.Action.IsValid() {
+		return service.ErrAuditLogQueryInvalid
+	}
+
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return service.ErrAuditLogQueryInvalid
+	}
+
+	return nil
+}
+
+func (s *AuditLogService) Ready() bool {
+	return s.auditLogRepo != nil
+}
@@ -0,0 +1,156 @@
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogService_GetAll(t *testing.T) {
+	t.Run("should return audit logs matching the query", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAuditLogRepository(t)
+		svc := NewAuditLogService(repo)
+		query := &entities.AuditLogQuery{
+			EntityType: entities.AuditEntityTypeVehicle,
+			UserID:     "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+			Action:     entities.AuditActionUpdate,
+		}
+		repo.EXPECT().GetAll(context.Background(), query).Return(testAuditLogs, int64(len(testAuditLogs)), nil)
+
+		// when
+		got, totalCount, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testAuditLogs, got)
+		assert.Equal(t, int64(2), totalCount)
+	})
+
+	t.Run("should return error on unknown entity type", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAuditLogRepository(t)
+		svc := NewAuditLogService(repo)
+
+		// when
+		got, _, err := svc.GetAll(context.Background(), &entities.AuditLogQuery{EntityType: "sensor"})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrAuditLogQueryInvalid)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error on unknown action", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAuditLogRepository(t)
+		svc := NewAuditLogService(repo)
+
+		// when
+		got, _, err := svc.GetAll(context.Background(), &entities.AuditLogQuery{Action: "archive"})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrAuditLogQueryInvalid)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when from is after to", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAuditLogRepository(t)
+		svc := NewAuditLogService(repo)
+		now := time.Now()
+
+		// when
+		got, _, err := svc.GetAll(context.Background(), &entities.AuditLogQuery{
+			From: utils.P(now),
+			To:   utils.P(now.Add(-time.Hour)),
+		})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrAuditLogQueryInvalid)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAuditLogRepository(t)
+		svc := NewAuditLogService(repo)
+		query := &entities.AuditLogQuery{}
+		repo.EXPECT().GetAll(context.Background(), query).Return(nil, int64(0), errors.New("db error"))
+
+		// when
+		got, _, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestAuditLogService_GetHistory(t *testing.T) {
+	t.Run("should return audit logs of the entity", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockAuditLogRepository(t)
+		svc := NewAuditLogService(repo)
+		expectedQuery := &entities.AuditLogQuery{
+			EntityType: entities.AuditEntityTypeTree,
+			EntityID:   utils.P(int32(1)),
+		}
+		repo.EXPECT().GetAll(context.Background(), expectedQuery).Return(testAuditLogs[:1], int64(1), nil)
+
+		// when
+		got, totalCount, err := svc.GetHistory(context.Background(), entities.AuditEntityTypeTree, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testAuditLogs[:1], got)
+		assert.Equal(t, int64(1), totalCount)
+	})
+}
+
+func TestAuditLogService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		// given
+		svc := NewAuditLogService(storageMock.NewMockAuditLogRepository(t))
+
+		// then
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false if the service is not ready", func(t *testing.T) {
+		// given
+		svc := NewAuditLogService(nil)
+
+		// then
+		assert.False(t, svc.Ready())
+	})
+}
+
+var testAuditLogs = []*entities.AuditLog{
+	{
+		ID:         1,
+		CreatedAt:  time.Now(),
+		EntityType: entities.AuditEntityTypeTree,
+		EntityID:   1,
+		Action:     entities.AuditActionUpdate,
+		UserID:     "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		Changes: map[string]entities.AuditChange{
+			"species": {Old: []byte(`"Quercus robur"`), New: []byte(`"Tilia cordata"`)},
+		},
+	},
+	{
+		ID:         2,
+		CreatedAt:  time.Now(),
+		EntityType: entities.AuditEntityTypeVehicle,
+		EntityID:   2,
+		Action:     entities.AuditActionDelete,
+		UserID:     "api-key:1a2b3c",
+		Changes:    map[string]entities.AuditChange{},
+	},
+}
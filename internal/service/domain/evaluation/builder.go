@@ -0,0 +1,242 @@
+package evaluation
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+type seriesGroupKey struct {
+	key  string
+	name string
+}
+
+// seriesGroup accumulates the periods of a group. The watering plans are counted once per group even if
+// several tree clusters of a plan belong to the same group.
+type seriesGroup struct {
+	seriesGroupKey
+	periods   []*entities.EvaluationPeriod
+	plans     map[int32]bool
+	goodTrees []int64
+	known     []int64
+}
+
+// seriesBuilder splits the watering plan facts and tree status snapshots into the groups and periods of a series
+type seriesBuilder struct {
+	series *entities.EvaluationSeries
+	starts []time.Time
+	index  map[time.Time]int
+	byKey  map[string]*seriesGroup
+}
+
+func newSeriesBuilder(series *entities.EvaluationSeries) *seriesBuilder {
+	starts := periodStarts(series.From, series.To, series.Interval)
+	index := make(map[time.Time]int, len(starts))
+	for i, start := range starts {
+		index[start] = i
+	}
+
+	b := &seriesBuilder{
+		series: series,
+		starts: starts,
+		index:  index,
+		byKey:  make(map[string]*seriesGroup),
+	}
+	if series.GroupBy == entities.EvaluationGroupByNone {
+		b.group(seriesGroupKey{})
+	}
+	return b
+}
+
+func (b *seriesBuilder) group(key seriesGroupKey) *seriesGroup {
+	if g, ok := b.byKey[key.key]; ok {
+		return g
+	}
+
+	g := &seriesGroup{
+		seriesGroupKey: key,
+		periods:        make([]*entities.EvaluationPeriod, len(b.starts)),
+		plans:          make(map[int32]bool),
+		goodTrees:      make([]int64, len(b.starts)),
+		known:          make([]int64, len(b.starts)),
+	}
+	for i, start := range b.starts {
+		g.periods[i] = &entities.EvaluationPeriod{Start: start}
+	}
+	b.byKey[key.key] = g
+	return g
+}
+
+func (b *seriesBuilder) period(date time.Time) (int, bool) {
+	i, ok := b.index[periodStart(date, b.series.Interval)]
+	return i, ok
+}
+
+// factKey returns the group of a fact when the series is grouped by region or tree cluster
+func (b *seriesBuilder) factKey(fact *entities.EvaluationWateringPlanFact) seriesGroupKey {
+	switch b.series.GroupBy {
+	case entities.EvaluationGroupByRegion:
+		return seriesGroupKey{key: idKey(fact.RegionID), name: fact.RegionName}
+	case entities.EvaluationGroupByTreeCluster:
+		return seriesGroupKey{key: idKey(fact.TreeClusterID), name: fact.TreeClusterName}
+	default:
+		return seriesGroupKey{}
+	}
+}
+
+// addFacts adds the facts to the group of their region or tree cluster. The distance, duration and refills of a
+// watering plan are split evenly among its tree clusters.
+func (b *seriesBuilder) addFacts(facts []*entities.EvaluationWateringPlanFact) {
+	for _, fact := range facts {
+		i, ok := b.period(fact.Date)
+		if !ok {
+			continue
+		}
+
+		share := 1.0
+		if fact.ClusterCount > 1 {
+			share = 1 / float64(fact.ClusterCount)
+		}
+		g := b.group(b.factKey(fact))
+		g.countPlan(i, fact)
+		if isEvaluated(fact.Status) {
+			p := g.periods[i]
+			p.WaterConsumed += fact.ConsumedWater
+			p.TreesWatered += fact.TreeCount
+			p.Distance += fact.Distance * share
+			p.Hours += fact.Duration.Hours() * share
+			p.Refills += float64(fact.RefillCount) * share
+		}
+	}
+}
+
+// addAssignedFacts adds every watering plan in full to the groups of the vehicles or users assigned to it
+func (b *seriesBuilder) addAssignedFacts(facts []*entities.EvaluationWateringPlanFact, assignments map[int32][]seriesGroupKey) {
+	for _, fact := range facts {
+		i, ok := b.period(fact.Date)
+		if !ok {
+			continue
+		}
+
+		for _, key := range assignments[fact.WateringPlanID] {
+			g := b.group(key)
+			first := g.countPlan(i, fact)
+			if !isEvaluated(fact.Status) {
+				continue
+			}
+
+			p := g.periods[i]
+			p.WaterConsumed += fact.ConsumedWater
+			p.TreesWatered += fact.TreeCount
+			if first {
+				p.Distance += fact.Distance
+				p.Hours += fact.Duration.Hours()
+				p.Refills += float64(fact.RefillCount)
+			}
+		}
+	}
+}
+
+// addSnapshots adds the tree statuses recorded on the days of the periods
+func (b *seriesBuilder) addSnapshots(snapshots []*entities.TreeStatusSnapshot) {
+	for _, s := range snapshots {
+		i, ok := b.period(s.Date)
+		if !ok || s.WateringStatus == entities.WateringStatusUnknown {
+			continue
+		}
+
+		var key seriesGroupKey
+		switch b.series.GroupBy {
+		case entities.EvaluationGroupByRegion:
+			key = seriesGroupKey{key: idKey(s.RegionID), name: s.RegionName}
+		case entities.EvaluationGroupByTreeCluster:
+			key = seriesGroupKey{key: idKey(s.TreeClusterID), name: s.TreeClusterName}
+		}
+
+		g := b.group(key)
+		g.known[i] += int64(s.TreeCount)
+		if s.WateringStatus == entities.WateringStatusGood || s.WateringStatus == entities.WateringStatusJustWatered {
+			g.goodTrees[i] += int64(s.TreeCount)
+		}
+	}
+}
+
+// groups returns the groups ordered by name with the KPIs and totals of their periods
+func (b *seriesBuilder) groups() []*entities.EvaluationGroupSeries {
+	groups := make([]*entities.EvaluationGroupSeries, 0, len(b.byKey))
+	for _, g := range b.byKey {
+		total := &entities.EvaluationPeriod{Start: b.series.From}
+		var good, known int64
+		for i, p := range g.periods {
+			setKPIs(p, g.goodTrees[i], g.known[i])
+
+			total.WateringPlanCount += p.WateringPlanCount
+			total.FinishedCount += p.FinishedCount
+			total.NotCompletedCount += p.NotCompletedCount
+			total.WaterConsumed += p.WaterConsumed
+			total.Refills += p.Refills
+			total.Distance += p.Distance
+			total.Hours += p.Hours
+			total.TreesWatered += p.TreesWatered
+			good += g.goodTrees[i]
+			known += g.known[i]
+		}
+		setKPIs(total, good, known)
+
+		groups = append(groups, &entities.EvaluationGroupSeries{
+			Key:     g.key,
+			Name:    g.name,
+			Total:   total,
+			Periods: g.periods,
+		})
+	}
+
+	slices.SortFunc(groups, func(a, b *entities.EvaluationGroupSeries) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Key, b.Key))
+	})
+	return groups
+}
+
+// countPlan counts the watering plan of the fact once per group and reports if it was counted for the first time
+func (g *seriesGroup) countPlan(i int, fact *entities.EvaluationWateringPlanFact) bool {
+	if g.plans[fact.WateringPlanID] {
+		return false
+	}
+	g.plans[fact.WateringPlanID] = true
+
+	p := g.periods[i]
+	p.WateringPlanCount++
+	switch fact.Status {
+	case entities.WateringPlanStatusFinished:
+		p.FinishedCount++
+	case entities.WateringPlanStatusNotCompeted:
+		p.NotCompletedCount++
+	}
+	return true
+}
+
+func setKPIs(p *entities.EvaluationPeriod, good, known int64) {
+	if p.TreesWatered > 0 {
+		litres := p.WaterConsumed / float64(p.TreesWatered)
+		p.LitresPerTree = &litres
+	}
+	if known > 0 {
+		percentage := float64(good) / float64(known) * 100
+		p.GoodTreePercentage = &percentage
+	}
+}
+
+// isEvaluated reports if the consumed water, distance and duration of a watering plan with the status are recorded
+func isEvaluated(status entities.WateringPlanStatus) bool {
+	return status == entities.WateringPlanStatusFinished || status == entities.WateringPlanStatusNotCompeted
+}
+
+func idKey(id *int32) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*id))
+}
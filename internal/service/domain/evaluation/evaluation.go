@@ -2,6 +2,7 @@ package evaluation
 
 import (
 	"context"
+	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
@@ -15,6 +16,9 @@ type EvaluationService struct {
 	sensorRepo       storage.SensorRepository
 	wateringPlanRepo storage.WateringPlanRepository
 	vehicleRepo      storage.VehicleRepository
+	evaluationRepo   storage.EvaluationRepository
+	userRepo         storage.UserRepository
+	now              func() time.Time
 }
 
 func NewEvaluationService(
@@ -23,6 +27,8 @@ func NewEvaluationService(
 	sensorRepo storage.SensorRepository,
 	wateringPlanRepo storage.WateringPlanRepository,
 	vehicleRepo storage.VehicleRepository,
+	evaluationRepo storage.EvaluationRepository,
+	userRepo storage.UserRepository,
 ) service.EvaluationService {
 	return &EvaluationService{
 		treeClusterRepo:  treeClusterRepo,
@@ -30,6 +36,9 @@ func NewEvaluationService(
 		sensorRepo:       sensorRepo,
 		wateringPlanRepo: wateringPlanRepo,
 		vehicleRepo:      vehicleRepo,
+		evaluationRepo:   evaluationRepo,
+		userRepo:         userRepo,
+		now:              time.Now,
 	}
 }
 
@@ -103,5 +112,7 @@ func (e *EvaluationService) Ready() bool {
 		e.treeRepo != nil &&
 		e.sensorRepo != nil &&
 		e.wateringPlanRepo != nil &&
-		e.vehicleRepo != nil
+		e.vehicleRepo != nil &&
+		e.evaluationRepo != nil &&
+		e.userRepo != nil
 }
@@ -51,8 +51,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(expectedEvaluation.TreeCount, nil)
@@ -75,8 +77,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(int64(0), errors.New("internal error"))
 		evaluation, err := svc.GetEvaluation(context.Background())
@@ -98,8 +102,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(int64(0), errors.New("internal error"))
@@ -122,8 +128,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(expectedEvaluation.TreeCount, nil)
@@ -147,8 +155,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(expectedEvaluation.TreeCount, nil)
@@ -174,8 +184,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(expectedEvaluation.TreeCount, nil)
@@ -202,8 +214,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(expectedEvaluation.TreeCount, nil)
@@ -231,8 +245,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(expectedEvaluation.TreeCount, nil)
@@ -261,8 +277,10 @@ func TestEvaluationService_GetAll(t *testing.T) {
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		sensorRepo := storageMock.NewMockSensorRepository(t)
 		vehicleRepo := storageMock.NewMockVehicleRepository(t)
+		evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
 
-		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo)
+		svc := evaluation.NewEvaluationService(clusterRepo, treeRepo, sensorRepo, wateringPlanRepo, vehicleRepo, evaluationRepo, userRepo)
 
 		clusterRepo.EXPECT().GetCount(context.Background(), entities.TreeClusterQuery{}).Return(expectedEvaluation.TreeClusterCount, nil)
 		treeRepo.EXPECT().GetCount(context.Background(), entities.TreeQuery{}).Return(expectedEvaluation.TreeCount, nil)
@@ -0,0 +1,199 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	defaultSeriesDays = 30
+	maxSeriesPeriods  = 366
+)
+
+func (e *EvaluationService) GetSeries(ctx context.Context, query *entities.EvaluationSeriesQuery) (*entities.EvaluationSeries, error) {
+	log := logger.GetLogger(ctx)
+
+	series, err := e.newSeries(query)
+	if err != nil {
+		log.Debug("invalid evaluation series query", "error", err)
+		return nil, service.ErrEvaluationQueryInvalid
+	}
+
+	facts, err := e.evaluationRepo.GetWateringPlanFacts(ctx, series.From, series.To)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	b := newSeriesBuilder(series)
+	switch series.GroupBy {
+	case entities.EvaluationGroupByVehicle:
+		vehicles, err := e.evaluationRepo.GetWateringPlanVehicles(ctx, series.From, series.To)
+		if err != nil {
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+		assignments := make(map[int32][]seriesGroupKey)
+		for _, v := range vehicles {
+			assignments[v.WateringPlanID] = append(assignments[v.WateringPlanID], seriesGroupKey{key: strconv.Itoa(int(v.VehicleID)), name: v.NumberPlate})
+		}
+		b.addAssignedFacts(facts, assignments)
+	case entities.EvaluationGroupByUser:
+		users, err := e.evaluationRepo.GetWateringPlanUsers(ctx, series.From, series.To)
+		if err != nil {
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+		names, err := e.userNames(ctx, users)
+		if err != nil {
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+		assignments := make(map[int32][]seriesGroupKey)
+		for _, u := range users {
+			id := u.UserID.String()
+			assignments[u.WateringPlanID] = append(assignments[u.WateringPlanID], seriesGroupKey{key: id, name: names[id]})
+		}
+		b.addAssignedFacts(facts, assignments)
+	default:
+		b.addFacts(facts)
+
+		snapshots, err := e.evaluationRepo.GetTreeStatusSnapshots(ctx, series.From, series.To)
+		if err != nil {
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+		b.addSnapshots(snapshots)
+	}
+
+	series.Groups = b.groups()
+	return series, nil
+}
+
+func (e *EvaluationService) SnapshotTreeStatuses(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	today := utils.ToDate(e.now().Local())
+	if err := e.evaluationRepo.CreateTreeStatusSnapshot(ctx, today); err != nil {
+		log.Error("failed to create tree status snapshot", "error", err, "date", today)
+		return err
+	}
+
+	log.Info("tree status snapshot created", "date", today)
+	return nil
+}
+
+// newSeries validates the query and fills in the defaults
+func (e *EvaluationService) newSeries(query *entities.EvaluationSeriesQuery) (*entities.EvaluationSeries, error) {
+	series := &entities.EvaluationSeries{
+		To:       utils.ToDate(e.now().Local()),
+		Interval: entities.EvaluationIntervalDay,
+	}
+	if query == nil {
+		query = &entities.EvaluationSeriesQuery{}
+	}
+
+	if query.To != nil {
+		series.To = utils.ToDate(*query.To)
+	}
+	series.From = series.To.AddDate(0, 0, -(defaultSeriesDays - 1))
+	if query.From != nil {
+		series.From = utils.ToDate(*query.From)
+	}
+	if series.From.After(series.To) {
+		return nil, errors.New("from must not be after to")
+	}
+
+	if query.Interval != "" {
+		series.Interval = query.Interval
+	}
+	if !slices.Contains([]entities.EvaluationInterval{
+		entities.EvaluationIntervalDay,
+		entities.EvaluationIntervalWeek,
+		entities.EvaluationIntervalMonth,
+	}, series.Interval) {
+		return nil, errors.New("interval must be day, week or month")
+	}
+
+	series.GroupBy = query.GroupBy
+	if !slices.Contains([]entities.EvaluationGroupBy{
+		entities.EvaluationGroupByNone,
+		entities.EvaluationGroupByRegion,
+		entities.EvaluationGroupByTreeCluster,
+		entities.EvaluationGroupByVehicle,
+		entities.EvaluationGroupByUser,
+	}, series.GroupBy) {
+		return nil, errors.New("group_by must be region, tree_cluster, vehicle or user")
+	}
+
+	if len(periodStarts(series.From, series.To, series.Interval)) > maxSeriesPeriods {
+		return nil, errors.New("too many periods, choose a shorter time range or a longer interval")
+	}
+
+	return series, nil
+}
+
+// userNames resolves the display names of the users, users that do not exist anymore are named by their id
+func (e *EvaluationService) userNames(ctx context.Context, users []*entities.EvaluationWateringPlanUser) (map[string]string, error) {
+	names := make(map[string]string)
+	ids := make([]string, 0)
+	for _, u := range users {
+		id := u.UserID.String()
+		if _, ok := names[id]; !ok {
+			names[id] = id
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	found, err := e.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range found {
+		name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+		if name == "" {
+			name = u.Username
+		}
+		names[u.ID.String()] = name
+	}
+	return names, nil
+}
+
+// periodStart returns the first day of the period the date belongs to, weeks start on monday
+func periodStart(date time.Time, interval entities.EvaluationInterval) time.Time {
+	date = utils.ToDate(date)
+	switch interval {
+	case entities.EvaluationIntervalWeek:
+		return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	case entities.EvaluationIntervalMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return date
+	}
+}
+
+// periodStarts returns the starts of all periods between from and to including both
+func periodStarts(from, to time.Time, interval entities.EvaluationInterval) []time.Time {
+	starts := make([]time.Time, 0)
+	for start := periodStart(from, interval); !start.After(to); {
+		starts = append(starts, start)
+		switch interval {
+		case entities.EvaluationIntervalWeek:
+			start = start.AddDate(0, 0, 7)
+		case entities.EvaluationIntervalMonth:
+			start = start.AddDate(0, 1, 0)
+		default:
+			start = start.AddDate(0, 0, 1)
+		}
+		if len(starts) > maxSeriesPeriods {
+			break
+		}
+	}
+	return starts
+}
@@ -0,0 +1,291 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	seriesToday = time.Date(2025, time.June, 11, 0, 0, 0, 0, time.UTC)
+	userID      = uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+	otherUserID = uuid.MustParse("05c028d9-62ef-4dcc-aa79-6b2fe9ce6f42")
+)
+
+func newSeriesService(t *testing.T) (*EvaluationService, *storageMock.MockEvaluationRepository, *storageMock.MockUserRepository) {
+	evaluationRepo := storageMock.NewMockEvaluationRepository(t)
+	userRepo := storageMock.NewMockUserRepository(t)
+	svc := NewEvaluationService(
+		storageMock.NewMockTreeClusterRepository(t),
+		storageMock.NewMockTreeRepository(t),
+		storageMock.NewMockSensorRepository(t),
+		storageMock.NewMockWateringPlanRepository(t),
+		storageMock.NewMockVehicleRepository(t),
+		evaluationRepo,
+		userRepo,
+	).(*EvaluationService)
+	svc.now = func() time.Time { return seriesToday.Add(15 * time.Hour) }
+	return svc, evaluationRepo, userRepo
+}
+
+// seriesFacts returns a finished watering plan with two tree clusters in two regions, a planned watering plan and a
+// not completed watering plan without tree clusters
+func seriesFacts() []*entities.EvaluationWateringPlanFact {
+	return []*entities.EvaluationWateringPlanFact{
+		{
+			WateringPlanID: 1, Date: seriesToday, Status: entities.WateringPlanStatusFinished,
+			Distance: 10, Duration: 2 * time.Hour, RefillCount: 2, ClusterCount: 2,
+			TreeClusterID: utils.P(int32(1)), TreeClusterName: "Stadion", RegionID: utils.P(int32(1)), RegionName: "Mürwik",
+			ConsumedWater: 100, TreeCount: 4,
+		},
+		{
+			WateringPlanID: 1, Date: seriesToday, Status: entities.WateringPlanStatusFinished,
+			Distance: 10, Duration: 2 * time.Hour, RefillCount: 2, ClusterCount: 2,
+			TreeClusterID: utils.P(int32(2)), TreeClusterName: "Hafen", RegionID: utils.P(int32(2)), RegionName: "Altstadt",
+			ConsumedWater: 50, TreeCount: 6,
+		},
+		{
+			WateringPlanID: 2, Date: seriesToday, Status: entities.WateringPlanStatusPlanned,
+			Distance: 5, Duration: time.Hour, ClusterCount: 1,
+			TreeClusterID: utils.P(int32(1)), TreeClusterName: "Stadion", RegionID: utils.P(int32(1)), RegionName: "Mürwik",
+			TreeCount: 4,
+		},
+		{
+			WateringPlanID: 3, Date: seriesToday.AddDate(0, 0, -1), Status: entities.WateringPlanStatusNotCompeted,
+			Distance: 3, Duration: 30 * time.Minute, RefillCount: 1,
+		},
+	}
+}
+
+func TestEvaluationService_GetSeries(t *testing.T) {
+	t.Run("should return the last 30 days without query", func(t *testing.T) {
+		// given
+		svc, evaluationRepo, _ := newSeriesService(t)
+		from := seriesToday.AddDate(0, 0, -29)
+		snapshots := []*entities.TreeStatusSnapshot{
+			{Date: seriesToday, TreeClusterID: utils.P(int32(1)), WateringStatus: entities.WateringStatusGood, TreeCount: 4},
+			{Date: seriesToday, TreeClusterID: utils.P(int32(2)), WateringStatus: entities.WateringStatusJustWatered, TreeCount: 2},
+			{Date: seriesToday, TreeClusterID: utils.P(int32(2)), WateringStatus: entities.WateringStatusBad, TreeCount: 2},
+			{Date: seriesToday, TreeClusterID: utils.P(int32(2)), WateringStatus: entities.WateringStatusUnknown, TreeCount: 2},
+		}
+
+		evaluationRepo.EXPECT().GetWateringPlanFacts(mock.Anything, from, seriesToday).Return(seriesFacts(), nil)
+		evaluationRepo.EXPECT().GetTreeStatusSnapshots(mock.Anything, from, seriesToday).Return(snapshots, nil)
+
+		// when
+		got, err := svc.GetSeries(context.Background(), nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, from, got.From)
+		assert.Equal(t, seriesToday, got.To)
+		assert.Equal(t, entities.EvaluationIntervalDay, got.Interval)
+		assert.Len(t, got.Groups, 1)
+
+		group := got.Groups[0]
+		assert.Empty(t, group.Key)
+		assert.Len(t, group.Periods, 30)
+
+		today := group.Periods[29]
+		assert.Equal(t, seriesToday, today.Start)
+		assert.Equal(t, int64(2), today.WateringPlanCount)
+		assert.Equal(t, int64(1), today.FinishedCount)
+		assert.Equal(t, 150.0, today.WaterConsumed)
+		assert.Equal(t, int64(10), today.TreesWatered)
+		assert.InDelta(t, 10.0, today.Distance, 0.0001)
+		assert.InDelta(t, 2.0, today.Hours, 0.0001)
+		assert.InDelta(t, 2.0, today.Refills, 0.0001)
+		assert.Equal(t, utils.P(15.0), today.LitresPerTree)
+		assert.Equal(t, utils.P(75.0), today.GoodTreePercentage)
+
+		yesterday := group.Periods[28]
+		assert.Equal(t, int64(1), yesterday.NotCompletedCount)
+		assert.Equal(t, 3.0, yesterday.Distance)
+		assert.Nil(t, yesterday.LitresPerTree)
+		assert.Nil(t, yesterday.GoodTreePercentage)
+
+		assert.Equal(t, int64(3), group.Total.WateringPlanCount)
+		assert.InDelta(t, 13.0, group.Total.Distance, 0.0001)
+		assert.InDelta(t, 2.5, group.Total.Hours, 0.0001)
+		assert.Equal(t, utils.P(75.0), group.Total.GoodTreePercentage)
+	})
+
+	t.Run("should split the watering plans by region", func(t *testing.T) {
+		// given
+		svc, evaluationRepo, _ := newSeriesService(t)
+		query := &entities.EvaluationSeriesQuery{
+			From:     utils.P(seriesToday.AddDate(0, 0, -1)),
+			To:       utils.P(seriesToday),
+			Interval: entities.EvaluationIntervalWeek,
+			GroupBy:  entities.EvaluationGroupByRegion,
+		}
+
+		evaluationRepo.EXPECT().GetWateringPlanFacts(mock.Anything, *query.From, *query.To).Return(seriesFacts(), nil)
+		evaluationRepo.EXPECT().GetTreeStatusSnapshots(mock.Anything, *query.From, *query.To).Return(nil, nil)
+
+		// when
+		got, err := svc.GetSeries(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Groups, 3)
+
+		// the plan without tree clusters has no region
+		assert.Empty(t, got.Groups[0].Key)
+		assert.Equal(t, int64(1), got.Groups[0].Total.NotCompletedCount)
+
+		altstadt := got.Groups[1]
+		assert.Equal(t, "2", altstadt.Key)
+		assert.Equal(t, "Altstadt", altstadt.Name)
+		assert.Len(t, altstadt.Periods, 1)
+		assert.Equal(t, time.Date(2025, time.June, 9, 0, 0, 0, 0, time.UTC), altstadt.Periods[0].Start)
+		assert.Equal(t, int64(1), altstadt.Total.WateringPlanCount)
+		assert.Equal(t, 50.0, altstadt.Total.WaterConsumed)
+		assert.InDelta(t, 5.0, altstadt.Total.Distance, 0.0001)
+		assert.InDelta(t, 1.0, altstadt.Total.Refills, 0.0001)
+
+		muerwik := got.Groups[2]
+		assert.Equal(t, "Mürwik", muerwik.Name)
+		assert.Equal(t, int64(2), muerwik.Total.WateringPlanCount)
+		assert.Equal(t, int64(1), muerwik.Total.FinishedCount)
+		assert.Equal(t, 100.0, muerwik.Total.WaterConsumed)
+		assert.Equal(t, utils.P(25.0), muerwik.Total.LitresPerTree)
+	})
+
+	t.Run("should attribute the watering plans to every assigned vehicle", func(t *testing.T) {
+		// given
+		svc, evaluationRepo, _ := newSeriesService(t)
+		query := &entities.EvaluationSeriesQuery{
+			From:     utils.P(seriesToday),
+			To:       utils.P(seriesToday),
+			Interval: entities.EvaluationIntervalMonth,
+			GroupBy:  entities.EvaluationGroupByVehicle,
+		}
+		vehicles := []*entities.EvaluationWateringPlanVehicle{
+			{WateringPlanID: 1, VehicleID: 1, NumberPlate: "B-1234"},
+			{WateringPlanID: 1, VehicleID: 2, NumberPlate: "B-5678"},
+		}
+
+		evaluationRepo.EXPECT().GetWateringPlanFacts(mock.Anything, seriesToday, seriesToday).Return(seriesFacts(), nil)
+		evaluationRepo.EXPECT().GetWateringPlanVehicles(mock.Anything, seriesToday, seriesToday).Return(vehicles, nil)
+
+		// when
+		got, err := svc.GetSeries(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Groups, 2)
+		for i, plate := range []string{"B-1234", "B-5678"} {
+			assert.Equal(t, plate, got.Groups[i].Name)
+			assert.Equal(t, time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), got.Groups[i].Periods[0].Start)
+			assert.Equal(t, int64(1), got.Groups[i].Total.WateringPlanCount)
+			assert.Equal(t, 150.0, got.Groups[i].Total.WaterConsumed)
+			assert.Equal(t, 10.0, got.Groups[i].Total.Distance)
+			assert.Equal(t, 2.0, got.Groups[i].Total.Hours)
+			assert.Nil(t, got.Groups[i].Total.GoodTreePercentage)
+		}
+	})
+
+	t.Run("should name the users and fall back to the id of unknown users", func(t *testing.T) {
+		// given
+		svc, evaluationRepo, userRepo := newSeriesService(t)
+		query := &entities.EvaluationSeriesQuery{
+			From:    utils.P(seriesToday),
+			To:      utils.P(seriesToday),
+			GroupBy: entities.EvaluationGroupByUser,
+		}
+		users := []*entities.EvaluationWateringPlanUser{
+			{WateringPlanID: 1, UserID: userID},
+			{WateringPlanID: 2, UserID: otherUserID},
+		}
+
+		evaluationRepo.EXPECT().GetWateringPlanFacts(mock.Anything, seriesToday, seriesToday).Return(seriesFacts(), nil)
+		evaluationRepo.EXPECT().GetWateringPlanUsers(mock.Anything, seriesToday, seriesToday).Return(users, nil)
+		userRepo.EXPECT().GetByIDs(mock.Anything, []string{userID.String(), otherUserID.String()}).Return([]*entities.User{
+			{ID: userID, FirstName: "Toni", LastName: "Tester"},
+		}, nil)
+
+		// when
+		got, err := svc.GetSeries(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got.Groups, 2)
+		assert.Equal(t, otherUserID.String(), got.Groups[0].Name)
+		assert.Equal(t, int64(1), got.Groups[0].Total.WateringPlanCount)
+		assert.Zero(t, got.Groups[0].Total.WaterConsumed)
+		assert.Equal(t, "Toni Tester", got.Groups[1].Name)
+		assert.Equal(t, 150.0, got.Groups[1].Total.WaterConsumed)
+	})
+
+	t.Run("should return error when query is invalid", func(t *testing.T) {
+		queries := map[string]*entities.EvaluationSeriesQuery{
+			"from after to":    {From: utils.P(seriesToday), To: utils.P(seriesToday.AddDate(0, 0, -1))},
+			"unknown interval": {Interval: "year"},
+			"unknown group":    {GroupBy: "species"},
+			"too many periods": {From: utils.P(seriesToday.AddDate(-2, 0, 0))},
+		}
+
+		for name, query := range queries {
+			t.Run(name, func(t *testing.T) {
+				// given
+				svc, _, _ := newSeriesService(t)
+
+				// when
+				got, err := svc.GetSeries(context.Background(), query)
+
+				// then
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, service.ErrEvaluationQueryInvalid)
+			})
+		}
+	})
+
+	t.Run("should return error when facts can not be fetched", func(t *testing.T) {
+		// given
+		svc, evaluationRepo, _ := newSeriesService(t)
+		evaluationRepo.EXPECT().GetWateringPlanFacts(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+		// when
+		got, err := svc.GetSeries(context.Background(), nil)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestEvaluationService_SnapshotTreeStatuses(t *testing.T) {
+	t.Run("should snapshot the tree statuses of today", func(t *testing.T) {
+		// given
+		svc, evaluationRepo, _ := newSeriesService(t)
+		evaluationRepo.EXPECT().CreateTreeStatusSnapshot(mock.Anything, seriesToday).Return(nil)
+
+		// when
+		err := svc.SnapshotTreeStatuses(context.Background())
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when snapshot fails", func(t *testing.T) {
+		// given
+		svc, evaluationRepo, _ := newSeriesService(t)
+		evaluationRepo.EXPECT().CreateTreeStatusSnapshot(mock.Anything, seriesToday).Return(errors.New("db error"))
+
+		// when
+		err := svc.SnapshotTreeStatuses(context.Background())
+
+		// then
+		assert.Error(t, err)
+	})
+}
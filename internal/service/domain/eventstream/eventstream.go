@@ -0,0 +1,336 @@
+package eventstream
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/webhook"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+)
+
+const (
+	// defaultMaxMissedEvents is the number of missed events per event type a client can resume from the
+	// outbox and the number of events of the in-memory event bus that are kept
+	defaultMaxMissedEvents = 1000
+	// clientBufferSize is the number of events a client may fall behind before it is disconnected
+	clientBufferSize = 64
+)
+
+var _ service.EventStreamService = (*EventStreamService)(nil)
+
+// EventStreamService sends the events of this instance to the connected clients. The id of an event
+// is its id in the outbox of the postgres event bus, so a client that reconnects to another instance
+// or after a restart resumes from the outbox. Events delivered by the in-memory event bus get a
+// sequence number of this instance as id and the latest of them are kept in memory, so a client
+// can only resume them from the same instance until it is restarted.
+type EventStreamService struct {
+	eventRepo       storage.EventRepository
+	maxMissedEvents int32
+
+	mutex   sync.Mutex
+	clients map[*client]struct{}
+
+	// instance distinguishes the ids of the in-memory events from the ids of another instance or run
+	instance string
+	seq      int64
+	// recent are the latest in-memory events in the order of their sequence number, dropped is the
+	// sequence number of the latest event that no longer fits into recent
+	recent  []*memoryEntry
+	dropped int64
+}
+
+// memoryEntry is an event of the in-memory event bus with its sequence number
+type memoryEntry struct {
+	seq   int64
+	entry *entry
+}
+
+// entry is an encoded event together with the entities it references
+type entry struct {
+	event *domain.StreamEvent
+	refs  refs
+}
+
+type client struct {
+	query *domain.EventStreamQuery
+	// events is nil while the missed events of the client are loaded, the events sent in the meantime are pending
+	events  chan *domain.StreamEvent
+	pending []*domain.StreamEvent
+}
+
+type EventStreamServiceOption func(*EventStreamService)
+
+// WithMaxMissedEvents sets the number of missed events per event type a client can resume from the
+// outbox and the number of events of the in-memory event bus that are kept
+func WithMaxMissedEvents(maxMissedEvents int32) EventStreamServiceOption {
+	return func(s *EventStreamService) {
+		s.maxMissedEvents = maxMissedEvents
+	}
+}
+
+func NewEventStreamService(eventRepo storage.EventRepository, opts ...EventStreamServiceOption) *EventStreamService {
+	s := &EventStreamService{
+		eventRepo:       eventRepo,
+		maxMissedEvents: defaultMaxMissedEvents,
+		clients:         make(map[*client]struct{}),
+		instance:        strings.ReplaceAll(uuid.NewString(), "-", "")[:8],
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *EventStreamService) HandleEvent(ctx context.Context, event domain.Event) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var id string
+	eventID, fromOutbox := ctx.Value(enums.ContextKeyEventID).(int64)
+	if fromOutbox {
+		id = formatID(eventID)
+	} else {
+		s.seq++
+		id = s.formatMemoryID(s.seq)
+	}
+
+	e, err := newEntry(id, time.Now(), event)
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to encode event for the event stream", "error", err, "event_type", event.Type())
+		return err
+	}
+
+	if !fromOutbox {
+		s.recent = append(s.recent, &memoryEntry{seq: s.seq, entry: e})
+		if len(s.recent) > int(s.maxMissedEvents) {
+			s.dropped = s.recent[0].seq
+			s.recent = s.recent[1:]
+		}
+	}
+
+	for c := range s.clients {
+		if !e.matches(c.query) {
+			continue
+		}
+
+		if c.events == nil {
+			c.pending = append(c.pending, e.event)
+			continue
+		}
+
+		select {
+		case c.events <- e.event:
+		default:
+			logger.GetLogger(ctx).Warn("event stream client is too slow, disconnect client", "event_id", id)
+			s.disconnect(c)
+		}
+	}
+
+	return nil
+}
+
+func (s *EventStreamService) Subscribe(ctx context.Context, query *domain.EventStreamQuery, lastEventID string) (<-chan *domain.StreamEvent, error) {
+	for _, eventType := range query.EventTypes {
+		if !slices.Contains(domain.StreamEventTypes, eventType) {
+			logger.GetLogger(ctx).Debug("event type is not supported by the event stream", "event_type", eventType)
+			return nil, service.ErrEventStreamTypeInvalid
+		}
+	}
+
+	// the client is registered before the missed events are loaded, so no event is lost in between
+	c := &client{query: query}
+	s.mutex.Lock()
+	s.clients[c] = struct{}{}
+	s.mutex.Unlock()
+
+	var missed []*domain.StreamEvent
+	if afterSeq, ok := s.parseMemoryID(lastEventID); ok {
+		missed = s.missedMemoryEvents(query, afterSeq)
+	} else if lastEventID != "" {
+		var err error
+		missed, err = s.missedEvents(ctx, query, lastEventID)
+		if err != nil {
+			s.mutex.Lock()
+			delete(s.clients, c)
+			s.mutex.Unlock()
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c.events = make(chan *domain.StreamEvent, len(missed)+len(c.pending)+clientBufferSize)
+	for _, event := range missed {
+		c.events <- event
+	}
+	for _, event := range c.pending {
+		if event.ID == "" || !slices.ContainsFunc(missed, func(m *domain.StreamEvent) bool { return m.ID == event.ID }) {
+			c.events <- event
+		}
+	}
+	c.pending = nil
+
+	go func() {
+		<-ctx.Done()
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.disconnect(c)
+	}()
+
+	return c.events, nil
+}
+
+// missedEvents returns the events of the outbox after the last event id that match the query in the
+// order they were delivered. A reset event is returned instead if the last event id is unknown or
+// the client has missed too many events.
+func (s *EventStreamService) missedEvents(ctx context.Context, query *domain.EventStreamQuery, lastEventID string) ([]*domain.StreamEvent, error) {
+	latestID, err := s.eventRepo.GetLatestID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reset := []*domain.StreamEvent{{ID: formatID(latestID), Type: domain.EventTypeStreamReset, Data: []byte("{}")}}
+
+	afterID, ok := parseID(lastEventID)
+	if !ok || afterID > latestID {
+		return reset, nil
+	}
+
+	eventTypes := query.EventTypes
+	if len(eventTypes) == 0 {
+		eventTypes = domain.StreamEventTypes
+	}
+
+	var outboxEvents []*domain.OutboxEvent
+	for _, eventType := range eventTypes {
+		events, err := s.eventRepo.GetAfter(ctx, eventType, afterID, s.maxMissedEvents+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > int(s.maxMissedEvents) {
+			return reset, nil
+		}
+		outboxEvents = append(outboxEvents, events...)
+	}
+
+	slices.SortFunc(outboxEvents, func(a, b *domain.OutboxEvent) int {
+		return cmp.Or(cmp.Compare(a.TxID, b.TxID), cmp.Compare(a.ID, b.ID))
+	})
+
+	missed := make([]*domain.StreamEvent, 0, len(outboxEvents))
+	for _, outboxEvent := range outboxEvents {
+		event, err := worker.DecodeEvent(outboxEvent.EventType, outboxEvent.Payload)
+		if err != nil {
+			logger.GetLogger(ctx).Error("failed to decode missed event, event is skipped", "error", err, "event_id", outboxEvent.ID)
+			continue
+		}
+
+		e, err := newEntry(formatID(outboxEvent.ID), outboxEvent.CreatedAt, event)
+		if err != nil {
+			logger.GetLogger(ctx).Debug("failed to encode missed event for the event stream", "error", err, "event_id", outboxEvent.ID)
+			continue
+		}
+
+		if e.matches(query) {
+			missed = append(missed, e.event)
+		}
+	}
+	return missed, nil
+}
+
+// missedMemoryEvents returns the in-memory events after the sequence number that match the query. A
+// reset event is returned instead if the sequence number is unknown or the events are no longer kept.
+func (s *EventStreamService) missedMemoryEvents(query *domain.EventStreamQuery, afterSeq int64) []*domain.StreamEvent {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if afterSeq > s.seq || afterSeq < s.dropped {
+		return []*domain.StreamEvent{{ID: s.formatMemoryID(s.seq), Type: domain.EventTypeStreamReset, Data: []byte("{}")}}
+	}
+
+	var missed []*domain.StreamEvent
+	for _, m := range s.recent {
+		if m.seq > afterSeq && m.entry.matches(query) {
+			missed = append(missed, m.entry.event)
+		}
+	}
+	return missed
+}
+
+// newEntry encodes the event as webhook payload. Events without id get a random payload id.
+func newEntry(id string, createdAt time.Time, event domain.Event) (*entry, error) {
+	payloadID := id
+	if payloadID == "" {
+		payloadID = uuid.NewString()
+	}
+
+	data, err := webhook.NewPayload(payloadID, createdAt, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry{
+		event: &domain.StreamEvent{ID: id, Type: event.Type(), Data: data},
+		refs:  eventRefs(event),
+	}, nil
+}
+
+// disconnect removes the client and closes its channel. The caller must hold the mutex.
+func (s *EventStreamService) disconnect(c *client) {
+	if _, ok := s.clients[c]; !ok {
+		return
+	}
+	delete(s.clients, c)
+	close(c.events)
+}
+
+// formatID returns the stream event id of an outbox event id
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// parseID returns the outbox event id of a stream event id
+func parseID(id string) (int64, bool) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// formatMemoryID returns the stream event id of an in-memory event
+func (s *EventStreamService) formatMemoryID(seq int64) string {
+	return s.instance + "-" + strconv.FormatInt(seq, 10)
+}
+
+// parseMemoryID returns the sequence number of a stream event id of an in-memory event. An id of
+// another instance or run is reported with sequence number -1, so that it is reset.
+func (s *EventStreamService) parseMemoryID(id string) (int64, bool) {
+	instance, seq, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(seq, 10, 64)
+	if instance != s.instance || err != nil || n < 0 {
+		return -1, true
+	}
+	return n, true
+}
+
+func (s *EventStreamService) Ready() bool {
+	return s.clients != nil
+}
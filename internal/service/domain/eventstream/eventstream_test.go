@@ -0,0 +1,392 @@
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testRegion  = &entities.Region{ID: 1, Name: "Mürwik"}
+	testCluster = &entities.TreeCluster{ID: 2, Name: "Cluster 2", Region: testRegion}
+	testTree    = &entities.Tree{ID: 3, Number: "T-3", TreeCluster: testCluster, Sensor: &entities.Sensor{ID: "sensor-1"}}
+	otherTree   = &entities.Tree{ID: 4, Number: "T-4"}
+)
+
+func receive(t *testing.T, events <-chan *entities.StreamEvent) []*entities.StreamEvent {
+	t.Helper()
+	var received []*entities.StreamEvent
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return received
+			}
+			received = append(received, event)
+		default:
+			return received
+		}
+	}
+}
+
+func withEventID(id int64) context.Context {
+	return context.WithValue(context.Background(), enums.ContextKeyEventID, id)
+}
+
+func outboxEvent(t *testing.T, id, txID int64, event entities.Event) *entities.OutboxEvent {
+	t.Helper()
+	payload, err := worker.EncodeEvent(event)
+	assert.NoError(t, err)
+	return &entities.OutboxEvent{ID: id, TxID: txID, EventType: event.Type(), Payload: payload, CreatedAt: time.Now()}
+}
+
+func TestEventStreamService_HandleEvent(t *testing.T) {
+	t.Run("should send event as webhook payload to subscribed clients", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{}, "")
+		assert.NoError(t, err)
+
+		// when
+		err = svc.HandleEvent(withEventID(7), entities.NewEventCreateTree(testTree, nil))
+
+		// then
+		assert.NoError(t, err)
+		received := receive(t, events)
+		assert.Len(t, received, 1)
+		assert.Equal(t, "7", received[0].ID)
+		assert.Equal(t, entities.EventTypeCreateTree, received[0].Type)
+
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal(received[0].Data, &payload))
+		assert.Equal(t, received[0].ID, payload["id"])
+		assert.Equal(t, "create tree", payload["type"])
+		assert.Equal(t, float64(3), payload["data"].(map[string]any)["new"].(map[string]any)["id"])
+	})
+
+	t.Run("should send event without outbox id with a sequence id of the instance", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{}, "")
+		assert.NoError(t, err)
+
+		// when
+		err = svc.HandleEvent(context.Background(), entities.NewEventDeleteTree(testTree))
+
+		// then
+		assert.NoError(t, err)
+		received := receive(t, events)
+		assert.Len(t, received, 1)
+		assert.Equal(t, svc.instance+"-1", received[0].ID)
+
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal(received[0].Data, &payload))
+		assert.Equal(t, received[0].ID, payload["id"])
+	})
+
+	t.Run("should return error for unsupported event", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{}, "")
+		assert.NoError(t, err)
+
+		// when
+		err = svc.HandleEvent(withEventID(1), entities.NewEventImportTrees(1, nil, nil))
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, receive(t, events))
+	})
+
+	t.Run("should filter events by the query", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query entities.EventStreamQuery
+			want  bool
+		}{
+			{name: "empty query", query: entities.EventStreamQuery{}, want: true},
+			{name: "matching event type", query: entities.EventStreamQuery{EventTypes: []entities.EventType{entities.EventTypeUpdateTree}}, want: true},
+			{name: "other event type", query: entities.EventStreamQuery{EventTypes: []entities.EventType{entities.EventTypeDeleteTree}}, want: false},
+			{name: "matching region", query: entities.EventStreamQuery{RegionIDs: []int32{1}}, want: true},
+			{name: "other region", query: entities.EventStreamQuery{RegionIDs: []int32{9}}, want: false},
+			{name: "matching tree", query: entities.EventStreamQuery{TreeIDs: []int32{3}}, want: true},
+			{name: "matching cluster of the tree", query: entities.EventStreamQuery{TreeClusterIDs: []int32{2}}, want: true},
+			{name: "matching sensor of the tree", query: entities.EventStreamQuery{SensorIDs: []string{"sensor-1"}}, want: true},
+			{name: "any matching entity", query: entities.EventStreamQuery{TreeIDs: []int32{9}, TreeClusterIDs: []int32{2}}, want: true},
+			{name: "other entities", query: entities.EventStreamQuery{TreeIDs: []int32{9}, WateringPlanIDs: []int32{2}}, want: false},
+			{name: "matching entity in other region", query: entities.EventStreamQuery{RegionIDs: []int32{9}, TreeIDs: []int32{3}}, want: false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// given
+				svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+				events, err := svc.Subscribe(context.Background(), &tt.query, "")
+				assert.NoError(t, err)
+
+				// when
+				err = svc.HandleEvent(context.Background(), entities.NewEventUpdateTree(otherTree, testTree, nil))
+
+				// then
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, len(receive(t, events)) == 1)
+			})
+		}
+	})
+
+	t.Run("should match tree clusters of a watering plan", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{RegionIDs: []int32{1}, TreeClusterIDs: []int32{2}}, "")
+		assert.NoError(t, err)
+		plan := &entities.WateringPlan{ID: 5, TreeClusters: []*entities.TreeCluster{testCluster}}
+
+		// when
+		err = svc.HandleEvent(context.Background(), entities.NewEventUpdateWateringPlan(plan, plan))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, receive(t, events), 1)
+	})
+
+	t.Run("should disconnect client that does not read the events", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{}, "")
+		assert.NoError(t, err)
+
+		// when
+		for range clientBufferSize + 1 {
+			assert.NoError(t, svc.HandleEvent(context.Background(), entities.NewEventDeleteTree(otherTree)))
+		}
+
+		// then
+		assert.Len(t, receive(t, events), clientBufferSize)
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.Empty(t, svc.clients)
+	})
+}
+
+func TestEventStreamService_Subscribe(t *testing.T) {
+	t.Run("should return error for unsupported event type", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+
+		// when
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{EventTypes: []entities.EventType{entities.EventTypeImportTrees}}, "")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrEventStreamTypeInvalid)
+		assert.Nil(t, events)
+	})
+
+	t.Run("should close channel when context is canceled", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := svc.Subscribe(ctx, &entities.EventStreamQuery{}, "")
+		assert.NoError(t, err)
+
+		// when
+		cancel()
+
+		// then
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("should send missed events of the outbox in transaction order", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		svc := NewEventStreamService(repo)
+		query := &entities.EventStreamQuery{EventTypes: []entities.EventType{entities.EventTypeDeleteTree, entities.EventTypeUpdateTree}}
+
+		repo.EXPECT().GetLatestID(context.Background()).Return(9, nil)
+		repo.EXPECT().GetAfter(context.Background(), entities.EventTypeDeleteTree, int64(5), int32(defaultMaxMissedEvents+1)).
+			Return([]*entities.OutboxEvent{outboxEvent(t, 8, 20, entities.NewEventDeleteTree(testTree))}, nil)
+		repo.EXPECT().GetAfter(context.Background(), entities.EventTypeUpdateTree, int64(5), int32(defaultMaxMissedEvents+1)).
+			Return([]*entities.OutboxEvent{outboxEvent(t, 9, 10, entities.NewEventUpdateTree(otherTree, otherTree, nil))}, nil)
+
+		// when
+		events, err := svc.Subscribe(context.Background(), query, "5")
+
+		// then
+		assert.NoError(t, err)
+		received := receive(t, events)
+		assert.Len(t, received, 2)
+		assert.Equal(t, "9", received[0].ID)
+		assert.Equal(t, entities.EventTypeUpdateTree, received[0].Type)
+		assert.Equal(t, "8", received[1].ID)
+		assert.Equal(t, entities.EventTypeDeleteTree, received[1].Type)
+	})
+
+	t.Run("should not send event twice that is handled while the missed events are loaded", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		svc := NewEventStreamService(repo)
+		query := &entities.EventStreamQuery{EventTypes: []entities.EventType{entities.EventTypeDeleteTree}}
+
+		repo.EXPECT().GetLatestID(context.Background()).Return(8, nil)
+		repo.EXPECT().GetAfter(context.Background(), entities.EventTypeDeleteTree, int64(7), int32(defaultMaxMissedEvents+1)).
+			Run(func(context.Context, entities.EventType, int64, int32) {
+				assert.NoError(t, svc.HandleEvent(withEventID(8), entities.NewEventDeleteTree(testTree)))
+				assert.NoError(t, svc.HandleEvent(withEventID(9), entities.NewEventDeleteTree(otherTree)))
+			}).
+			Return([]*entities.OutboxEvent{outboxEvent(t, 8, 20, entities.NewEventDeleteTree(testTree))}, nil)
+
+		// when
+		events, err := svc.Subscribe(context.Background(), query, "7")
+
+		// then
+		assert.NoError(t, err)
+		received := receive(t, events)
+		assert.Len(t, received, 2)
+		assert.Equal(t, "8", received[0].ID)
+		assert.Equal(t, "9", received[1].ID)
+	})
+
+	t.Run("should send nothing if the client has seen the latest event", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		svc := NewEventStreamService(repo)
+
+		repo.EXPECT().GetLatestID(context.Background()).Return(1, nil)
+		for _, eventType := range entities.StreamEventTypes {
+			repo.EXPECT().GetAfter(context.Background(), eventType, int64(1), int32(defaultMaxMissedEvents+1)).Return(nil, nil)
+		}
+
+		// when
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{}, "1")
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, receive(t, events))
+	})
+
+	t.Run("should send reset event if the client can not resume", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			lastEventID string
+			missed      int
+		}{
+			{name: "invalid id", lastEventID: "invalid"},
+			{name: "future event", lastEventID: "99"},
+			{name: "too many missed events", lastEventID: "1", missed: 3},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// given
+				repo := storageMock.NewMockEventRepository(t)
+				svc := NewEventStreamService(repo, WithMaxMissedEvents(2))
+				query := &entities.EventStreamQuery{EventTypes: []entities.EventType{entities.EventTypeDeleteTree}}
+
+				repo.EXPECT().GetLatestID(context.Background()).Return(4, nil)
+				if tt.missed > 0 {
+					missed := make([]*entities.OutboxEvent, tt.missed)
+					for i := range missed {
+						missed[i] = outboxEvent(t, int64(i+2), int64(i+2), entities.NewEventDeleteTree(otherTree))
+					}
+					repo.EXPECT().GetAfter(context.Background(), entities.EventTypeDeleteTree, int64(1), int32(3)).Return(missed, nil)
+				}
+
+				// when
+				events, err := svc.Subscribe(context.Background(), query, tt.lastEventID)
+
+				// then
+				assert.NoError(t, err)
+				received := receive(t, events)
+				assert.Len(t, received, 1)
+				assert.Equal(t, entities.EventTypeStreamReset, received[0].Type)
+				assert.Equal(t, "4", received[0].ID)
+			})
+		}
+	})
+
+	t.Run("should send missed events of the in-memory event bus", func(t *testing.T) {
+		// given
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		query := &entities.EventStreamQuery{EventTypes: []entities.EventType{entities.EventTypeDeleteTree}}
+
+		first, err := svc.Subscribe(context.Background(), query, "")
+		assert.NoError(t, err)
+		assert.NoError(t, svc.HandleEvent(context.Background(), entities.NewEventDeleteTree(testTree)))
+		assert.NoError(t, svc.HandleEvent(context.Background(), entities.NewEventCreateTree(otherTree, nil)))
+		assert.NoError(t, svc.HandleEvent(context.Background(), entities.NewEventDeleteTree(otherTree)))
+		lastEventID := receive(t, first)[0].ID
+
+		// when
+		events, err := svc.Subscribe(context.Background(), query, lastEventID)
+
+		// then
+		assert.NoError(t, err)
+		received := receive(t, events)
+		assert.Len(t, received, 1)
+		assert.Equal(t, svc.instance+"-3", received[0].ID)
+		assert.Equal(t, entities.EventTypeDeleteTree, received[0].Type)
+	})
+
+	t.Run("should send reset event if the in-memory events can not be resumed", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			lastEventID func(svc *EventStreamService) string
+		}{
+			{name: "id of another instance", lastEventID: func(*EventStreamService) string { return "00000000-1" }},
+			{name: "future event", lastEventID: func(svc *EventStreamService) string { return svc.instance + "-99" }},
+			{name: "event no longer kept", lastEventID: func(svc *EventStreamService) string { return svc.instance + "-1" }},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// given
+				svc := NewEventStreamService(storageMock.NewMockEventRepository(t), WithMaxMissedEvents(2))
+				for range 4 {
+					assert.NoError(t, svc.HandleEvent(context.Background(), entities.NewEventDeleteTree(testTree)))
+				}
+
+				// when
+				events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{}, tt.lastEventID(svc))
+
+				// then
+				assert.NoError(t, err)
+				received := receive(t, events)
+				assert.Len(t, received, 1)
+				assert.Equal(t, entities.EventTypeStreamReset, received[0].Type)
+				assert.Equal(t, svc.instance+"-4", received[0].ID)
+			})
+		}
+	})
+
+	t.Run("should return error if the missed events can not be loaded", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		svc := NewEventStreamService(repo)
+
+		repo.EXPECT().GetLatestID(context.Background()).Return(0, errors.New("db error"))
+
+		// when
+		events, err := svc.Subscribe(context.Background(), &entities.EventStreamQuery{}, "1")
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, events)
+		assert.Empty(t, svc.clients)
+	})
+}
+
+func TestEventStreamService_Ready(t *testing.T) {
+	t.Run("should be ready", func(t *testing.T) {
+		svc := NewEventStreamService(storageMock.NewMockEventRepository(t))
+		assert.True(t, svc.Ready())
+	})
+}
@@ -0,0 +1,107 @@
+package eventstream
+
+import (
+	"slices"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// refs are the ids of the entities an event references, before and after the change
+type refs struct {
+	regionIDs       []int32
+	treeIDs         []int32
+	treeClusterIDs  []int32
+	sensorIDs       []string
+	wateringPlanIDs []int32
+}
+
+func eventRefs(event domain.Event) refs {
+	var r refs
+	switch e := event.(type) {
+	case domain.EventCreateTree:
+		r.addTree(e.New)
+	case domain.EventUpdateTree:
+		r.addTree(e.Prev)
+		r.addTree(e.New)
+	case domain.EventDeleteTree:
+		r.addTree(e.Prev)
+	case domain.EventUpdateTreeCluster:
+		r.addTreeCluster(e.Prev, true)
+		r.addTreeCluster(e.New, true)
+	case domain.EventNewSensorData:
+		if e.New != nil {
+			r.sensorIDs = append(r.sensorIDs, e.New.SensorID)
+		}
+	case domain.EventUpdateWateringPlan:
+		r.addWateringPlan(e.Prev)
+		r.addWateringPlan(e.New)
+	}
+	return r
+}
+
+func (r *refs) addTree(t *domain.Tree) {
+	if t == nil {
+		return
+	}
+
+	r.treeIDs = append(r.treeIDs, t.ID)
+	if t.Sensor != nil {
+		r.sensorIDs = append(r.sensorIDs, t.Sensor.ID)
+	}
+	r.addTreeCluster(t.TreeCluster, false)
+}
+
+func (r *refs) addTreeCluster(tc *domain.TreeCluster, withTrees bool) {
+	if tc == nil {
+		return
+	}
+
+	r.treeClusterIDs = append(r.treeClusterIDs, tc.ID)
+	if tc.Region != nil {
+		r.regionIDs = append(r.regionIDs, tc.Region.ID)
+	}
+
+	if withTrees {
+		for _, t := range tc.Trees {
+			r.treeIDs = append(r.treeIDs, t.ID)
+		}
+	}
+}
+
+func (r *refs) addWateringPlan(wp *domain.WateringPlan) {
+	if wp == nil {
+		return
+	}
+
+	r.wateringPlanIDs = append(r.wateringPlanIDs, wp.ID)
+	for _, tc := range wp.TreeClusters {
+		r.addTreeCluster(tc, false)
+	}
+}
+
+// matches reports whether the event passes the filters of the query. The event type and the region
+// must match if they are given, the entity ids match if the event references any of them.
+func (e *entry) matches(query *domain.EventStreamQuery) bool {
+	if len(query.EventTypes) > 0 && !slices.Contains(query.EventTypes, e.event.Type) {
+		return false
+	}
+
+	if len(query.RegionIDs) > 0 && !containsAny(query.RegionIDs, e.refs.regionIDs) {
+		return false
+	}
+
+	if len(query.TreeIDs) == 0 && len(query.TreeClusterIDs) == 0 && len(query.SensorIDs) == 0 && len(query.WateringPlanIDs) == 0 {
+		return true
+	}
+
+	return containsAny(query.TreeIDs, e.refs.treeIDs) ||
+		containsAny(query.TreeClusterIDs, e.refs.treeClusterIDs) ||
+		containsAny(query.SensorIDs, e.refs.sensorIDs) ||
+		containsAny(query.WateringPlanIDs, e.refs.wateringPlanIDs)
+}
+
+func containsAny[T comparable](filter, ids []T) bool {
+	return slices.ContainsFunc(ids, func(id T) bool {
+		return slices.Contains(filter, id)
+	})
+}
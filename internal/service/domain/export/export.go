@@ -0,0 +1,132 @@
+package export
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+type ExportServiceConfig struct {
+	pageSize int32
+}
+
+type ExportServiceOption func(*ExportServiceConfig)
+
+var defaultExportServiceConfig = ExportServiceConfig{
+	pageSize: 500,
+}
+
+// WithPageSize sets the number of entities read from the repository at once
+func WithPageSize(pageSize int32) ExportServiceOption {
+	slog.Debug("use export service with page size", "page_size", pageSize)
+	return func(cfg *ExportServiceConfig) {
+		cfg.pageSize = pageSize
+	}
+}
+
+type ExportService struct {
+	ExportServiceConfig
+	treeRepo         storage.TreeRepository
+	treeClusterRepo  storage.TreeClusterRepository
+	wateringPlanRepo storage.WateringPlanRepository
+}
+
+var _ service.ExportService = (*ExportService)(nil)
+
+func NewExportService(
+	treeRepo storage.TreeRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+	wateringPlanRepo storage.WateringPlanRepository,
+	opts ...ExportServiceOption,
+) *ExportService {
+	cfg := defaultExportServiceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &ExportService{
+		ExportServiceConfig: cfg,
+		treeRepo:            treeRepo,
+		treeClusterRepo:     treeClusterRepo,
+		wateringPlanRepo:    wateringPlanRepo,
+	}
+}
+
+func (s *ExportService) ExportTrees(ctx context.Context, w io.Writer, format entities.ExportFormat, query entities.TreeQuery) error {
+	return export(ctx, s.pageSize, w, format, &treeLayer, func(ctx context.Context) ([]*entities.Tree, int64, error) {
+		return s.treeRepo.GetAll(ctx, query)
+	}, treeFeature)
+}
+
+func (s *ExportService) ExportTreeClusters(ctx context.Context, w io.Writer, format entities.ExportFormat, query entities.TreeClusterQuery) error {
+	return export(ctx, s.pageSize, w, format, &treeClusterLayer, func(ctx context.Context) ([]*entities.TreeCluster, int64, error) {
+		return s.treeClusterRepo.GetAll(ctx, query)
+	}, treeClusterFeature)
+}
+
+func (s *ExportService) ExportWateringPlans(ctx context.Context, w io.Writer, format entities.ExportFormat, query entities.Query) error {
+	return export(ctx, s.pageSize, w, format, &wateringPlanLayer, func(ctx context.Context) ([]*entities.WateringPlan, int64, error) {
+		return s.wateringPlanRepo.GetAll(ctx, query)
+	}, wateringPlanFeature)
+}
+
+// export reads the entities page by page with fetch and writes them to w
+func export[T any](
+	ctx context.Context,
+	pageSize int32,
+	w io.Writer,
+	format entities.ExportFormat,
+	l *layer,
+	fetch func(ctx context.Context) ([]T, int64, error),
+	toFeature func(T) *feature,
+) error {
+	log := logger.GetLogger(ctx)
+	if !format.IsValid() {
+		return service.ErrExportFormatInvalid
+	}
+
+	fw, err := newFeatureWriter(ctx, w, format, l)
+	if err != nil {
+		log.Debug("failed to create export writer", "error", err, "format", format, "layer", l.name)
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	for page := int32(1); ; page++ {
+		data, totalCount, err := fetch(pagination.WithValues(ctx, page, pageSize))
+		if err != nil {
+			fw.Abort()
+			log.Debug("failed to fetch entities for export", "error", err, "layer", l.name, "page", page)
+			return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+		}
+
+		for _, entity := range data {
+			if err := fw.Write(toFeature(entity)); err != nil {
+				fw.Abort()
+				log.Debug("failed to write feature of export", "error", err, "layer", l.name)
+				return err
+			}
+		}
+
+		if len(data) == 0 || int64(page)*int64(pageSize) >= totalCount {
+			break
+		}
+	}
+
+	if err := fw.Close(); err != nil {
+		log.Debug("failed to finish export", "error", err, "layer", l.name)
+		return err
+	}
+
+	log.Info("exported entities", "layer", l.name, "format", format)
+	return nil
+}
+
+func (s *ExportService) Ready() bool {
+	return s.treeRepo != nil && s.treeClusterRepo != nil && s.wateringPlanRepo != nil
+}
@@ -0,0 +1,336 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testRepos struct {
+	treeRepo         *storageMock.MockTreeRepository
+	clusterRepo      *storageMock.MockTreeClusterRepository
+	wateringPlanRepo *storageMock.MockWateringPlanRepository
+}
+
+func setupTest(t *testing.T) (*testRepos, *ExportService) {
+	repos := &testRepos{
+		treeRepo:         storageMock.NewMockTreeRepository(t),
+		clusterRepo:      storageMock.NewMockTreeClusterRepository(t),
+		wateringPlanRepo: storageMock.NewMockWateringPlanRepository(t),
+	}
+	svc := NewExportService(repos.treeRepo, repos.clusterRepo, repos.wateringPlanRepo, WithPageSize(2))
+	return repos, svc
+}
+
+var (
+	lastWatered = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	testTrees = []*entities.Tree{
+		{
+			ID:             1,
+			Number:         "T-1",
+			Species:        "Quercus robur",
+			PlantingYear:   2010,
+			Latitude:       54.82,
+			Longitude:      9.48,
+			WateringStatus: entities.WateringStatusGood,
+			LastWatered:    &lastWatered,
+			TreeCluster:    &entities.TreeCluster{ID: 1, Name: "Cluster 1"},
+			Sensor: &entities.Sensor{
+				ID:     "sensor-1",
+				Status: entities.SensorStatusOnline,
+				LatestData: &entities.SensorData{
+					CreatedAt: lastWatered,
+					Data: &entities.MqttPayload{
+						Battery:     3.4,
+						Humidity:    42,
+						Temperature: 11.5,
+						Watermarks: []entities.Watermark{
+							{Depth: 30, Centibar: 28},
+							{Depth: 60, Centibar: 30},
+							{Depth: 90, Centibar: 35},
+						},
+					},
+				},
+			},
+		},
+		{ID: 2, Number: "T-2", Species: "Tilia cordata", Latitude: 54.83, Longitude: 9.49, WateringStatus: entities.WateringStatusUnknown},
+		{ID: 3, Number: "T-3", Species: "Acer", Latitude: 54.84, Longitude: 9.5, WateringStatus: entities.WateringStatusBad},
+	}
+
+	testClusters = []*entities.TreeCluster{
+		{ID: 1, Name: "Cluster 1", Latitude: utils.P(54.82), Longitude: utils.P(9.48), Region: &entities.Region{Name: "Mürwik"}},
+		{ID: 2, Name: "Cluster 2"},
+	}
+
+	testWateringPlans = []*entities.WateringPlan{
+		{
+			ID:           1,
+			Date:         lastWatered,
+			Status:       entities.WateringPlanStatusPlanned,
+			TreeClusters: testClusters,
+			Transporter:  &entities.Vehicle{NumberPlate: "FL ZB 123"},
+		},
+	}
+)
+
+// expectTreePages returns the test trees in pages of two and checks the page of each call
+func expectTreePages(repo *storageMock.MockTreeRepository, query entities.TreeQuery) {
+	repo.EXPECT().GetAll(mock.Anything, query).RunAndReturn(func(ctx context.Context, _ entities.TreeQuery) ([]*entities.Tree, int64, error) {
+		page, limit, err := pagination.GetValues(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		start := min(int((page-1)*limit), len(testTrees))
+		end := min(start+int(limit), len(testTrees))
+		return testTrees[start:end], int64(len(testTrees)), nil
+	}).Times(2)
+}
+
+func TestExportService_ExportTrees(t *testing.T) {
+	ctx := context.Background()
+	query := entities.TreeQuery{WateringStatuses: []entities.WateringStatus{entities.WateringStatusGood}}
+
+	t.Run("should write all pages as geojson feature collection", func(t *testing.T) {
+		// given
+		repos, svc := setupTest(t)
+		expectTreePages(repos.treeRepo, query)
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportTrees(ctx, &buf, entities.ExportFormatGeoJSON, query)
+
+		// then
+		assert.NoError(t, err)
+
+		var fc struct {
+			Type     string `json:"type"`
+			Features []struct {
+				Geometry struct {
+					Type        string    `json:"type"`
+					Coordinates []float64 `json:"coordinates"`
+				} `json:"geometry"`
+				Properties map[string]any `json:"properties"`
+			} `json:"features"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+		assert.Equal(t, "FeatureCollection", fc.Type)
+		assert.Len(t, fc.Features, 3)
+		assert.Equal(t, "Point", fc.Features[0].Geometry.Type)
+		assert.Equal(t, []float64{9.48, 54.82}, fc.Features[0].Geometry.Coordinates)
+		assert.Equal(t, "T-1", fc.Features[0].Properties["number"])
+		assert.Equal(t, "good", fc.Features[0].Properties["watering_status"])
+		assert.Equal(t, "sensor-1", fc.Features[0].Properties["sensor_id"])
+		assert.Equal(t, 3.4, fc.Features[0].Properties["battery"])
+		assert.Equal(t, float64(35), fc.Features[0].Properties["centibar_90"])
+		assert.Equal(t, "2025-03-01T08:00:00Z", fc.Features[0].Properties["last_watered"])
+		assert.Nil(t, fc.Features[1].Properties["sensor_id"])
+		assert.Equal(t, "T-3", fc.Features[2].Properties["number"])
+	})
+
+	t.Run("should write csv with header and coordinates", func(t *testing.T) {
+		// given
+		repos, svc := setupTest(t)
+		expectTreePages(repos.treeRepo, query)
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportTrees(ctx, &buf, entities.ExportFormatCSV, query)
+
+		// then
+		assert.NoError(t, err)
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, rows, 4)
+		header := rows[0]
+		assert.Equal(t, "id", header[0])
+		assert.Equal(t, []string{"latitude", "longitude"}, header[len(header)-2:])
+		assert.Equal(t, []string{"1", "T-1", "Quercus robur", "2010", "good"}, rows[1][:5])
+		assert.Equal(t, []string{"54.82", "9.48"}, rows[1][len(header)-2:])
+		assert.Equal(t, "", rows[2][8]) // tree cluster id
+	})
+
+	t.Run("should write geopackage with feature table", func(t *testing.T) {
+		// given
+		repos, svc := setupTest(t)
+		expectTreePages(repos.treeRepo, query)
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportTrees(ctx, &buf, entities.ExportFormatGeoPackage, query)
+
+		// then
+		assert.NoError(t, err)
+
+		db := openGeoPackage(t, buf.Bytes())
+		var table, geometryType string
+		var srsID int
+		require.NoError(t, db.QueryRow(`SELECT table_name, geometry_type_name, srs_id FROM gpkg_geometry_columns`).Scan(&table, &geometryType, &srsID))
+		assert.Equal(t, "trees", table)
+		assert.Equal(t, "POINT", geometryType)
+		assert.Equal(t, wgs84SrsID, srsID)
+
+		var fid int32
+		var number string
+		var geom []byte
+		require.NoError(t, db.QueryRow(`SELECT fid, number, geom FROM trees ORDER BY fid LIMIT 1`).Scan(&fid, &number, &geom))
+		assert.Equal(t, int32(1), fid)
+		assert.Equal(t, "T-1", number)
+		assert.Equal(t, gpkgGeometry(&geometry{typ: geometryTypePoint, coordinates: []coordinate{{longitude: 9.48, latitude: 54.82}}}), geom)
+
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM trees`).Scan(&count))
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("should return error when format is invalid", func(t *testing.T) {
+		// given
+		_, svc := setupTest(t)
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportTrees(ctx, &buf, entities.ExportFormat("shp"), query)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrExportFormatInvalid)
+		assert.Empty(t, buf.Bytes())
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		repos, svc := setupTest(t)
+		repos.treeRepo.EXPECT().GetAll(mock.Anything, query).Return(nil, 0, errors.New("internal error"))
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportTrees(ctx, &buf, entities.ExportFormatGeoJSON, query)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestExportService_ExportTreeClusters(t *testing.T) {
+	t.Run("should write null geometry for clusters without trees", func(t *testing.T) {
+		// given
+		repos, svc := setupTest(t)
+		query := entities.TreeClusterQuery{Regions: []string{"Mürwik"}}
+		repos.clusterRepo.EXPECT().GetAll(mock.Anything, query).Return(testClusters, int64(len(testClusters)), nil)
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportTreeClusters(context.Background(), &buf, entities.ExportFormatGeoJSON, query)
+
+		// then
+		assert.NoError(t, err)
+
+		var fc struct {
+			Features []struct {
+				Geometry   *struct{}      `json:"geometry"`
+				Properties map[string]any `json:"properties"`
+			} `json:"features"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+		assert.Len(t, fc.Features, 2)
+		assert.NotNil(t, fc.Features[0].Geometry)
+		assert.Equal(t, "Mürwik", fc.Features[0].Properties["region"])
+		assert.Nil(t, fc.Features[1].Geometry)
+	})
+}
+
+func TestExportService_ExportWateringPlans(t *testing.T) {
+	t.Run("should use cluster centers as multi point", func(t *testing.T) {
+		// given
+		repos, svc := setupTest(t)
+		repos.wateringPlanRepo.EXPECT().GetAll(mock.Anything, entities.Query{}).Return(testWateringPlans, int64(1), nil)
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportWateringPlans(context.Background(), &buf, entities.ExportFormatGeoJSON, entities.Query{})
+
+		// then
+		assert.NoError(t, err)
+
+		var fc struct {
+			Features []struct {
+				Geometry struct {
+					Type        string      `json:"type"`
+					Coordinates [][]float64 `json:"coordinates"`
+				} `json:"geometry"`
+				Properties map[string]any `json:"properties"`
+			} `json:"features"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+		assert.Len(t, fc.Features, 1)
+		assert.Equal(t, "MultiPoint", fc.Features[0].Geometry.Type)
+		assert.Equal(t, [][]float64{{9.48, 54.82}}, fc.Features[0].Geometry.Coordinates)
+		assert.Equal(t, "1,2", fc.Features[0].Properties["tree_cluster_ids"])
+		assert.Equal(t, "2025-03-01", fc.Features[0].Properties["date"])
+		assert.Equal(t, "FL ZB 123", fc.Features[0].Properties["transporter"])
+	})
+
+	t.Run("should write multi point geometry to geopackage", func(t *testing.T) {
+		// given
+		repos, svc := setupTest(t)
+		repos.wateringPlanRepo.EXPECT().GetAll(mock.Anything, entities.Query{}).Return(testWateringPlans, int64(1), nil)
+		var buf bytes.Buffer
+
+		// when
+		err := svc.ExportWateringPlans(context.Background(), &buf, entities.ExportFormatGeoPackage, entities.Query{})
+
+		// then
+		assert.NoError(t, err)
+
+		db := openGeoPackage(t, buf.Bytes())
+		var geometryType string
+		require.NoError(t, db.QueryRow(`SELECT geometry_type_name FROM gpkg_geometry_columns`).Scan(&geometryType))
+		assert.Equal(t, "MULTIPOINT", geometryType)
+
+		var status string
+		require.NoError(t, db.QueryRow(`SELECT status FROM watering_plans WHERE fid = 1`).Scan(&status))
+		assert.Equal(t, "planned", status)
+	})
+}
+
+func TestExportService_Ready(t *testing.T) {
+	t.Run("should return true if all repositories are set", func(t *testing.T) {
+		_, svc := setupTest(t)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false if a repository is missing", func(t *testing.T) {
+		svc := NewExportService(nil, nil, nil)
+		assert.False(t, svc.Ready())
+	})
+}
+
+func openGeoPackage(t *testing.T, data []byte) *sql.DB {
+	path := filepath.Join(t.TempDir(), "export.gpkg")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	var applicationID int
+	require.NoError(t, db.QueryRow(`PRAGMA application_id`).Scan(&applicationID))
+	require.Equal(t, gpkgApplicationID, applicationID)
+	return db
+}
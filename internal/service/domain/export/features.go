@@ -0,0 +1,256 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+type columnType int
+
+const (
+	columnTypeText columnType = iota
+	columnTypeInteger
+	columnTypeReal
+)
+
+type column struct {
+	name string
+	typ  columnType
+}
+
+type geometryType string
+
+const (
+	geometryTypePoint      geometryType = "POINT"
+	geometryTypeMultiPoint geometryType = "MULTIPOINT"
+)
+
+type coordinate struct {
+	longitude float64
+	latitude  float64
+}
+
+// geometry is either a point or a multi point in EPSG:4326. A geometry without coordinates is written as null.
+type geometry struct {
+	typ         geometryType
+	coordinates []coordinate
+}
+
+func (g *geometry) isEmpty() bool {
+	return g == nil || len(g.coordinates) == 0
+}
+
+// feature is one exported entity. The values are in the order of the columns of the layer
+// and are either nil, string, int64 or float64.
+type feature struct {
+	geometry *geometry
+	values   []any
+}
+
+// layer describes the shape of all features of an export. The first column is always the id of the entity.
+type layer struct {
+	name         string
+	geometryType geometryType
+	columns      []column
+}
+
+var watermarkDepths = []int{30, 60, 90}
+
+var treeLayer = layer{
+	name:         "trees",
+	geometryType: geometryTypePoint,
+	columns: []column{
+		{name: "id", typ: columnTypeInteger},
+		{name: "number", typ: columnTypeText},
+		{name: "species", typ: columnTypeText},
+		{name: "planting_year", typ: columnTypeInteger},
+		{name: "watering_status", typ: columnTypeText},
+		{name: "last_watered", typ: columnTypeText},
+		{name: "description", typ: columnTypeText},
+		{name: "provider", typ: columnTypeText},
+		{name: "tree_cluster_id", typ: columnTypeInteger},
+		{name: "tree_cluster_name", typ: columnTypeText},
+		{name: "sensor_id", typ: columnTypeText},
+		{name: "sensor_status", typ: columnTypeText},
+		{name: "sensor_data_at", typ: columnTypeText},
+		{name: "battery", typ: columnTypeReal},
+		{name: "humidity", typ: columnTypeReal},
+		{name: "temperature", typ: columnTypeReal},
+		{name: "centibar_30", typ: columnTypeInteger},
+		{name: "centibar_60", typ: columnTypeInteger},
+		{name: "centibar_90", typ: columnTypeInteger},
+	},
+}
+
+var treeClusterLayer = layer{
+	name:         "tree_clusters",
+	geometryType: geometryTypePoint,
+	columns: []column{
+		{name: "id", typ: columnTypeInteger},
+		{name: "name", typ: columnTypeText},
+		{name: "address", typ: columnTypeText},
+		{name: "description", typ: columnTypeText},
+		{name: "region", typ: columnTypeText},
+		{name: "watering_status", typ: columnTypeText},
+		{name: "moisture_level", typ: columnTypeReal},
+		{name: "last_watered", typ: columnTypeText},
+		{name: "soil_condition", typ: columnTypeText},
+		{name: "archived", typ: columnTypeInteger},
+		{name: "provider", typ: columnTypeText},
+	},
+}
+
+var wateringPlanLayer = layer{
+	name:         "watering_plans",
+	geometryType: geometryTypeMultiPoint,
+	columns: []column{
+		{name: "id", typ: columnTypeInteger},
+		{name: "date", typ: columnTypeText},
+		{name: "description", typ: columnTypeText},
+		{name: "status", typ: columnTypeText},
+		{name: "distance", typ: columnTypeReal},
+		{name: "total_water_required", typ: columnTypeReal},
+		{name: "refill_count", typ: columnTypeInteger},
+		{name: "transporter", typ: columnTypeText},
+		{name: "trailer", typ: columnTypeText},
+		{name: "tree_cluster_ids", typ: columnTypeText},
+		{name: "provider", typ: columnTypeText},
+	},
+}
+
+func treeFeature(t *entities.Tree) *feature {
+	var clusterID, clusterName any
+	if t.TreeCluster != nil {
+		clusterID, clusterName = int64(t.TreeCluster.ID), t.TreeCluster.Name
+	}
+
+	sensorValues := make([]any, 6+len(watermarkDepths))
+	if t.Sensor != nil {
+		sensorValues[0], sensorValues[1] = t.Sensor.ID, string(t.Sensor.Status)
+		if t.Sensor.LatestData != nil && t.Sensor.LatestData.Data != nil {
+			data := t.Sensor.LatestData.Data
+			sensorValues[2] = formatTime(t.Sensor.LatestData.CreatedAt)
+			sensorValues[3], sensorValues[4], sensorValues[5] = data.Battery, data.Humidity, data.Temperature
+			for i, depth := range watermarkDepths {
+				for _, wm := range data.Watermarks {
+					if wm.Depth == depth {
+						sensorValues[6+i] = int64(wm.Centibar)
+					}
+				}
+			}
+		}
+	}
+
+	values := []any{
+		int64(t.ID),
+		t.Number,
+		t.Species,
+		int64(t.PlantingYear),
+		string(t.WateringStatus),
+		formatTimePtr(t.LastWatered),
+		t.Description,
+		t.Provider,
+		clusterID,
+		clusterName,
+	}
+
+	return &feature{
+		geometry: &geometry{
+			typ:         geometryTypePoint,
+			coordinates: []coordinate{{longitude: t.Longitude, latitude: t.Latitude}},
+		},
+		values: append(values, sensorValues...),
+	}
+}
+
+func treeClusterFeature(tc *entities.TreeCluster) *feature {
+	geom := &geometry{typ: geometryTypePoint}
+	if tc.Latitude != nil && tc.Longitude != nil {
+		geom.coordinates = []coordinate{{longitude: *tc.Longitude, latitude: *tc.Latitude}}
+	}
+
+	var region any
+	if tc.Region != nil {
+		region = tc.Region.Name
+	}
+
+	var archived int64
+	if tc.Archived {
+		archived = 1
+	}
+
+	return &feature{
+		geometry: geom,
+		values: []any{
+			int64(tc.ID),
+			tc.Name,
+			tc.Address,
+			tc.Description,
+			region,
+			string(tc.WateringStatus),
+			tc.MoistureLevel,
+			formatTimePtr(tc.LastWatered),
+			string(tc.SoilCondition),
+			archived,
+			tc.Provider,
+		},
+	}
+}
+
+// wateringPlanFeature uses the centers of the tree clusters of the plan as geometry
+func wateringPlanFeature(wp *entities.WateringPlan) *feature {
+	geom := &geometry{typ: geometryTypeMultiPoint}
+	clusterIDs := make([]string, len(wp.TreeClusters))
+	for i, tc := range wp.TreeClusters {
+		clusterIDs[i] = strconv.Itoa(int(tc.ID))
+
+		if tc.Latitude != nil && tc.Longitude != nil {
+			geom.coordinates = append(geom.coordinates, coordinate{longitude: *tc.Longitude, latitude: *tc.Latitude})
+		}
+	}
+
+	var distance, waterRequired, transporter, trailer any
+	if wp.Distance != nil {
+		distance = *wp.Distance
+	}
+	if wp.TotalWaterRequired != nil {
+		waterRequired = *wp.TotalWaterRequired
+	}
+	if wp.Transporter != nil {
+		transporter = wp.Transporter.NumberPlate
+	}
+	if wp.Trailer != nil {
+		trailer = wp.Trailer.NumberPlate
+	}
+
+	return &feature{
+		geometry: geom,
+		values: []any{
+			int64(wp.ID),
+			wp.Date.Format(time.DateOnly),
+			wp.Description,
+			string(wp.Status),
+			distance,
+			waterRequired,
+			int64(wp.RefillCount),
+			transporter,
+			trailer,
+			strings.Join(clusterIDs, ","),
+			wp.Provider,
+		},
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatTimePtr(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return formatTime(*t)
+}
@@ -0,0 +1,230 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	// registers the "sqlite" driver used to write geopackage files
+	_ "modernc.org/sqlite"
+)
+
+const (
+	wgs84SrsID = 4326
+	// gpkgApplicationID is "GPKG" as big endian integer
+	gpkgApplicationID = 0x47504B47
+	gpkgUserVersion   = 10200
+)
+
+// gpkgSchema creates the tables required by the geopackage specification together with the
+// spatial reference systems EPSG:4326 and the two undefined systems every geopackage must contain.
+var gpkgSchema = []string{
+	fmt.Sprintf("PRAGMA application_id = %d", gpkgApplicationID),
+	fmt.Sprintf("PRAGMA user_version = %d", gpkgUserVersion),
+	`CREATE TABLE gpkg_spatial_ref_sys (
+		srs_name TEXT NOT NULL,
+		srs_id INTEGER NOT NULL PRIMARY KEY,
+		organization TEXT NOT NULL,
+		organization_coordsys_id INTEGER NOT NULL,
+		definition TEXT NOT NULL,
+		description TEXT
+	)`,
+	`INSERT INTO gpkg_spatial_ref_sys VALUES
+		('Undefined cartesian SRS', -1, 'NONE', -1, 'undefined', 'undefined cartesian coordinate reference system'),
+		('Undefined geographic SRS', 0, 'NONE', 0, 'undefined', 'undefined geographic coordinate reference system'),
+		('WGS 84 geodetic', 4326, 'EPSG', 4326, 'GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,` +
+		`AUTHORITY["EPSG","7030"]],AUTHORITY["EPSG","6326"]],PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],` +
+		`UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],AUTHORITY["EPSG","4326"]]', 'longitude/latitude coordinates in decimal degrees on the WGS 84 spheroid')`,
+	`CREATE TABLE gpkg_contents (
+		table_name TEXT NOT NULL PRIMARY KEY,
+		data_type TEXT NOT NULL,
+		identifier TEXT UNIQUE,
+		description TEXT DEFAULT '',
+		last_change DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+		min_x DOUBLE,
+		min_y DOUBLE,
+		max_x DOUBLE,
+		max_y DOUBLE,
+		srs_id INTEGER REFERENCES gpkg_spatial_ref_sys(srs_id)
+	)`,
+	`CREATE TABLE gpkg_geometry_columns (
+		table_name TEXT NOT NULL,
+		column_name TEXT NOT NULL,
+		geometry_type_name TEXT NOT NULL,
+		srs_id INTEGER NOT NULL,
+		z TINYINT NOT NULL,
+		m TINYINT NOT NULL,
+		PRIMARY KEY (table_name, column_name)
+	)`,
+}
+
+// geoPackageWriter writes the features into a geopackage in a temporary file. A geopackage is a
+// sqlite database and can not be streamed, the file is copied to the writer when it is closed.
+type geoPackageWriter struct {
+	ctx   context.Context
+	w     io.Writer
+	layer *layer
+	path  string
+	db    *sql.DB
+	tx    *sql.Tx
+	stmt  *sql.Stmt
+}
+
+func newGeoPackageWriter(ctx context.Context, w io.Writer, l *layer) (*geoPackageWriter, error) {
+	file, err := os.CreateTemp("", "export-*.gpkg")
+	if err != nil {
+		return nil, err
+	}
+	path := file.Name()
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	g := &geoPackageWriter{ctx: ctx, w: w, layer: l, path: path}
+	if err := g.init(); err != nil {
+		g.Abort()
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (g *geoPackageWriter) init() error {
+	var err error
+	g.db, err = sql.Open("sqlite", g.path)
+	if err != nil {
+		return err
+	}
+	// the transaction and the prepared statement must use the same connection
+	g.db.SetMaxOpenConns(1)
+
+	for _, stmt := range gpkgSchema {
+		if _, err := g.db.ExecContext(g.ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	// the first column is the id of the entity and is stored as fid
+	columns := []string{"fid INTEGER PRIMARY KEY AUTOINCREMENT", "geom " + string(g.layer.geometryType)}
+	names := []string{"fid", "geom"}
+	for _, col := range g.layer.columns[1:] {
+		columns = append(columns, col.name+" "+sqliteType(col.typ))
+		names = append(names, col.name)
+	}
+
+	//nolint:gosec // table and column names are constants of the layer
+	if _, err := g.db.ExecContext(g.ctx, fmt.Sprintf("CREATE TABLE %s (%s)", g.layer.name, strings.Join(columns, ", "))); err != nil {
+		return err
+	}
+
+	_, err = g.db.ExecContext(g.ctx, `INSERT INTO gpkg_contents (table_name, data_type, identifier, srs_id) VALUES (?, 'features', ?, ?)`,
+		g.layer.name, g.layer.name, wgs84SrsID)
+	if err != nil {
+		return err
+	}
+
+	_, err = g.db.ExecContext(g.ctx, `INSERT INTO gpkg_geometry_columns VALUES (?, 'geom', ?, ?, 0, 0)`,
+		g.layer.name, string(g.layer.geometryType), wgs84SrsID)
+	if err != nil {
+		return err
+	}
+
+	g.tx, err = g.db.BeginTx(g.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")
+	//nolint:gosec // table and column names are constants of the layer
+	g.stmt, err = g.tx.PrepareContext(g.ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		g.layer.name, strings.Join(names, ", "), placeholders))
+	return err
+}
+
+func (g *geoPackageWriter) Write(f *feature) error {
+	args := make([]any, 0, len(f.values)+1)
+	args = append(args, f.values[0], gpkgGeometry(f.geometry))
+	args = append(args, f.values[1:]...)
+
+	_, err := g.stmt.ExecContext(g.ctx, args...)
+	return err
+}
+
+func (g *geoPackageWriter) Close() error {
+	defer g.Abort()
+
+	if err := g.stmt.Close(); err != nil {
+		return err
+	}
+	if err := g.tx.Commit(); err != nil {
+		return err
+	}
+	if err := g.db.Close(); err != nil {
+		return err
+	}
+
+	file, err := os.Open(g.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(g.w, file)
+	return err
+}
+
+func (g *geoPackageWriter) Abort() {
+	if g.db != nil {
+		g.db.Close()
+	}
+	os.Remove(g.path)
+}
+
+func sqliteType(typ columnType) string {
+	switch typ {
+	case columnTypeInteger:
+		return "INTEGER"
+	case columnTypeReal:
+		return "REAL"
+	default:
+		return "TEXT"
+	}
+}
+
+// gpkgGeometry encodes a geometry as geopackage geometry blob, a header without envelope
+// followed by the little endian wkb geometry. Empty geometries are stored as null.
+func gpkgGeometry(geom *geometry) []byte {
+	if geom.isEmpty() {
+		return nil
+	}
+
+	// magic, version 0 and flags for little endian byte order without envelope
+	blob := []byte{'G', 'P', 0, 0x01}
+	blob = binary.LittleEndian.AppendUint32(blob, wgs84SrsID)
+
+	if geom.typ == geometryTypePoint {
+		return appendWKBPoint(blob, geom.coordinates[0])
+	}
+
+	blob = append(blob, 0x01)
+	blob = binary.LittleEndian.AppendUint32(blob, 4) // wkb multi point
+	//nolint:gosec // a plan has only a few clusters
+	blob = binary.LittleEndian.AppendUint32(blob, uint32(len(geom.coordinates)))
+	for _, c := range geom.coordinates {
+		blob = appendWKBPoint(blob, c)
+	}
+	return blob
+}
+
+func appendWKBPoint(blob []byte, c coordinate) []byte {
+	blob = append(blob, 0x01)
+	blob = binary.LittleEndian.AppendUint32(blob, 1) // wkb point
+	blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(c.longitude))
+	return binary.LittleEndian.AppendUint64(blob, math.Float64bits(c.latitude))
+}
@@ -0,0 +1,187 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// featureWriter writes the features of one layer. Close must be called after the last feature
+// to finish the file, the underlying writer is not closed. Abort releases all resources of a
+// writer that will not be finished.
+type featureWriter interface {
+	Write(f *feature) error
+	Close() error
+	Abort()
+}
+
+func newFeatureWriter(ctx context.Context, w io.Writer, format entities.ExportFormat, l *layer) (featureWriter, error) {
+	switch format {
+	case entities.ExportFormatGeoJSON:
+		return newGeoJSONWriter(w, l)
+	case entities.ExportFormatCSV:
+		return newCSVWriter(w, l)
+	case entities.ExportFormatGeoPackage:
+		return newGeoPackageWriter(ctx, w, l)
+	default:
+		return nil, service.ErrExportFormatInvalid
+	}
+}
+
+type geoJSONWriter struct {
+	w     *bufio.Writer
+	layer *layer
+	count int
+}
+
+type geoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+type geoJSONFeature struct {
+	Type       string           `json:"type"`
+	Geometry   *geoJSONGeometry `json:"geometry"`
+	Properties map[string]any   `json:"properties"`
+}
+
+// newGeoJSONWriter writes a feature collection. The features are encoded one by one
+// so only the current feature is held in memory.
+func newGeoJSONWriter(w io.Writer, l *layer) (*geoJSONWriter, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(`{"type":"FeatureCollection","name":` + strconv.Quote(l.name) + `,"features":[`); err != nil {
+		return nil, err
+	}
+
+	return &geoJSONWriter{w: bw, layer: l}, nil
+}
+
+func (g *geoJSONWriter) Write(f *feature) error {
+	properties := make(map[string]any, len(g.layer.columns))
+	for i, col := range g.layer.columns {
+		properties[col.name] = f.values[i]
+	}
+
+	data, err := json.Marshal(geoJSONFeature{
+		Type:       "Feature",
+		Geometry:   toGeoJSONGeometry(f.geometry),
+		Properties: properties,
+	})
+	if err != nil {
+		return err
+	}
+
+	if g.count > 0 {
+		if err := g.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	g.count++
+
+	if _, err := g.w.Write(data); err != nil {
+		return err
+	}
+
+	// flush every few features to keep the buffer of the writer small
+	if g.w.Buffered() > 32*1024 {
+		return g.w.Flush()
+	}
+	return nil
+}
+
+func (g *geoJSONWriter) Close() error {
+	if _, err := g.w.WriteString("]}"); err != nil {
+		return err
+	}
+	return g.w.Flush()
+}
+
+func (g *geoJSONWriter) Abort() {}
+
+func toGeoJSONGeometry(geom *geometry) *geoJSONGeometry {
+	if geom.isEmpty() {
+		return nil
+	}
+
+	coords := make([][2]float64, len(geom.coordinates))
+	for i, c := range geom.coordinates {
+		coords[i] = [2]float64{c.longitude, c.latitude}
+	}
+
+	if geom.typ == geometryTypePoint {
+		return &geoJSONGeometry{Type: "Point", Coordinates: coords[0]}
+	}
+	return &geoJSONGeometry{Type: "MultiPoint", Coordinates: coords}
+}
+
+type csvWriter struct {
+	w        *csv.Writer
+	layer    *layer
+	withGeom bool
+}
+
+// newCSVWriter writes a header row followed by one row per feature. Point layers get
+// a latitude and longitude column, multi points can not be represented and are left out.
+func newCSVWriter(w io.Writer, l *layer) (*csvWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w), layer: l, withGeom: l.geometryType == geometryTypePoint}
+
+	header := make([]string, 0, len(l.columns)+2)
+	for _, col := range l.columns {
+		header = append(header, col.name)
+	}
+	if c.withGeom {
+		header = append(header, "latitude", "longitude")
+	}
+
+	if err := c.w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *csvWriter) Write(f *feature) error {
+	row := make([]string, 0, len(f.values)+2)
+	for _, value := range f.values {
+		row = append(row, formatCSVValue(value))
+	}
+
+	if c.withGeom {
+		if f.geometry.isEmpty() {
+			row = append(row, "", "")
+		} else {
+			coord := f.geometry.coordinates[0]
+			row = append(row, formatCSVValue(coord.latitude), formatCSVValue(coord.longitude))
+		}
+	}
+
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Abort() {}
+
+func formatCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,215 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/robfig/cron/v3"
+)
+
+const defaultPollInterval = 10 * time.Second
+
+var _ service.JobService = (*JobService)(nil)
+
+type JobService struct {
+	jobRepo      storage.JobRepository
+	cfg          config.SchedulerConfig
+	instance     string
+	pollInterval time.Duration
+
+	mutex sync.Mutex
+	jobs  map[string]*job
+}
+
+// job is a registered job. The schedule is nil if the job has no schedule in the configuration.
+type job struct {
+	name     string
+	spec     string
+	enabled  bool
+	schedule cron.Schedule
+	work     func(ctx context.Context) error
+}
+
+func NewJobService(jobRepo storage.JobRepository, cfg config.SchedulerConfig) *JobService {
+	pollInterval := cfg.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	return &JobService{
+		jobRepo:      jobRepo,
+		cfg:          cfg,
+		instance:     newInstance(),
+		pollInterval: pollInterval,
+		jobs:         make(map[string]*job),
+	}
+}
+
+// newInstance returns a name of this instance that is unique between restarts
+func newInstance() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+}
+
+func (s *JobService) Register(name string, work func(ctx context.Context) error) error {
+	j := &job{name: name, work: work}
+	if jobCfg, ok := s.cfg.Jobs[name]; ok && jobCfg.Schedule != "" {
+		schedule, err := cron.ParseStandard(jobCfg.Schedule)
+		if err != nil {
+			return fmt.Errorf("%w: job %s: %w", service.ErrJobScheduleInvalid, name, err)
+		}
+		j.spec = jobCfg.Schedule
+		j.enabled = jobCfg.Enabled
+		j.schedule = schedule
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobs[name] = j
+	return nil
+}
+
+func (s *JobService) GetAll(ctx context.Context) ([]*domain.Job, error) {
+	lastRuns, err := s.lastRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	registered := s.registered()
+	jobs := make([]*domain.Job, 0, len(registered))
+	for _, j := range registered {
+		jobs = append(jobs, j.toDomain(now, lastRuns[j.name]))
+	}
+	return jobs, nil
+}
+
+func (s *JobService) GetByName(ctx context.Context, name string) (*domain.Job, error) {
+	j, err := s.get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRuns, err := s.lastRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.toDomain(time.Now(), lastRuns[name]), nil
+}
+
+// lastRuns returns the latest run of every job by the name of the job
+func (s *JobService) lastRuns(ctx context.Context) (map[string]*domain.JobRun, error) {
+	latestRuns, err := s.jobRepo.GetLatestRuns(ctx)
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to fetch latest job runs", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	lastRuns := make(map[string]*domain.JobRun, len(latestRuns))
+	for _, run := range latestRuns {
+		lastRuns[run.Job] = run
+	}
+	return lastRuns, nil
+}
+
+func (s *JobService) GetRuns(ctx context.Context, name string) ([]*domain.JobRun, int64, error) {
+	if _, err := s.get(ctx, name); err != nil {
+		return nil, 0, err
+	}
+
+	runs, totalCount, err := s.jobRepo.GetRuns(ctx, name)
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to fetch job runs", "error", err, "job", name)
+		return nil, 0, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return runs, totalCount, nil
+}
+
+func (s *JobService) Trigger(ctx context.Context, name string) (*domain.JobRun, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := s.get(ctx, name); err != nil {
+		return nil, err
+	}
+
+	actor, _ := ctx.Value(enums.ContextKeyActor).(string)
+	run, err := s.jobRepo.CreateRun(ctx, &domain.JobRunCreate{
+		Job:         name,
+		Trigger:     domain.JobRunTriggerManual,
+		Status:      domain.JobRunStatusPending,
+		RequestedBy: actor,
+	})
+	if err != nil {
+		log.Debug("failed to queue manual job run", "error", err, "job", name)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("manual job run queued", "job", name, "run_id", run.ID, "requested_by", actor)
+	return run, nil
+}
+
+func (s *JobService) get(ctx context.Context, name string) (*job, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	j, ok := s.jobs[name]
+	if !ok {
+		logger.GetLogger(ctx).Debug("job is not registered", "job", name)
+		return nil, service.ErrJobNotFound
+	}
+	return j, nil
+}
+
+// registered returns the registered jobs sorted by name
+func (s *JobService) registered() []*job {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	jobs := make([]*job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+	slices.SortFunc(jobs, func(a, b *job) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return jobs
+}
+
+func (j *job) toDomain(now time.Time, lastRun *domain.JobRun) *domain.Job {
+	result := &domain.Job{
+		Name:     j.name,
+		Schedule: j.spec,
+		Enabled:  j.enabled,
+		LastRun:  lastRun,
+	}
+	if j.scheduled() {
+		next := j.schedule.Next(now)
+		result.NextRunAt = &next
+	}
+	return result
+}
+
+// scheduled reports whether the job runs by its schedule
+func (j *job) scheduled() bool {
+	return j.enabled && j.schedule != nil
+}
+
+func (s *JobService) Ready() bool {
+	return s.jobRepo != nil
+}
@@ -0,0 +1,368 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = config.SchedulerConfig{
+	Jobs: map[string]config.JobConfig{
+		entities.JobSensorStatus:       {Schedule: "0 */3 * * *", Enabled: true},
+		entities.JobTreeWateringStatus: {Schedule: "30 2 * * *", Enabled: false},
+	},
+}
+
+func noop(context.Context) error { return nil }
+
+func newTestService(t *testing.T, repo storage.JobRepository) *JobService {
+	t.Helper()
+	svc := NewJobService(repo, testConfig)
+	assert.NoError(t, svc.Register(entities.JobSensorStatus, noop))
+	assert.NoError(t, svc.Register(entities.JobTreeWateringStatus, noop))
+	return svc
+}
+
+func TestJobService_Register(t *testing.T) {
+	t.Run("should register job with the schedule of the configuration", func(t *testing.T) {
+		// given
+		svc := NewJobService(storageMock.NewMockJobRepository(t), testConfig)
+
+		// when
+		err := svc.Register(entities.JobSensorStatus, noop)
+
+		// then
+		assert.NoError(t, err)
+		j := svc.jobs[entities.JobSensorStatus]
+		assert.Equal(t, "0 */3 * * *", j.spec)
+		assert.True(t, j.scheduled())
+	})
+
+	t.Run("should register job without configuration as manual job", func(t *testing.T) {
+		// given
+		svc := NewJobService(storageMock.NewMockJobRepository(t), testConfig)
+
+		// when
+		err := svc.Register(entities.JobWateringPlanStatus, noop)
+
+		// then
+		assert.NoError(t, err)
+		assert.False(t, svc.jobs[entities.JobWateringPlanStatus].scheduled())
+	})
+
+	t.Run("should return error for invalid schedule", func(t *testing.T) {
+		// given
+		cfg := config.SchedulerConfig{Jobs: map[string]config.JobConfig{
+			entities.JobSensorStatus: {Schedule: "every three hours", Enabled: true},
+		}}
+		svc := NewJobService(storageMock.NewMockJobRepository(t), cfg)
+
+		// when
+		err := svc.Register(entities.JobSensorStatus, noop)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrJobScheduleInvalid)
+		assert.Empty(t, svc.jobs)
+	})
+}
+
+func TestJobService_GetAll(t *testing.T) {
+	t.Run("should return jobs sorted by name with their last run", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		svc := newTestService(t, repo)
+		lastRun := &entities.JobRun{ID: 1, Job: entities.JobSensorStatus, Status: entities.JobRunStatusSuccess}
+		repo.EXPECT().GetLatestRuns(context.Background()).Return([]*entities.JobRun{lastRun}, nil)
+
+		// when
+		got, err := svc.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, entities.JobSensorStatus, got[0].Name)
+		assert.Equal(t, lastRun, got[0].LastRun)
+		assert.NotNil(t, got[0].NextRunAt)
+		assert.True(t, got[0].NextRunAt.After(time.Now()))
+		assert.Equal(t, entities.JobTreeWateringStatus, got[1].Name)
+		assert.False(t, got[1].Enabled)
+		assert.Nil(t, got[1].NextRunAt)
+		assert.Nil(t, got[1].LastRun)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		svc := newTestService(t, repo)
+		repo.EXPECT().GetLatestRuns(context.Background()).Return(nil, errors.New("database error"))
+
+		// when
+		got, err := svc.GetAll(context.Background())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestJobService_GetByName(t *testing.T) {
+	t.Run("should return job", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		svc := newTestService(t, repo)
+		repo.EXPECT().GetLatestRuns(context.Background()).Return([]*entities.JobRun{}, nil)
+
+		// when
+		got, err := svc.GetByName(context.Background(), entities.JobSensorStatus)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.JobSensorStatus, got.Name)
+		assert.True(t, got.Enabled)
+	})
+
+	t.Run("should return error for unknown job", func(t *testing.T) {
+		// given
+		svc := newTestService(t, storageMock.NewMockJobRepository(t))
+
+		// when
+		got, err := svc.GetByName(context.Background(), "unknown")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrJobNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+func TestJobService_GetRuns(t *testing.T) {
+	t.Run("should return runs of the job", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		svc := newTestService(t, repo)
+		runs := []*entities.JobRun{{ID: 2, Job: entities.JobSensorStatus}, {ID: 1, Job: entities.JobSensorStatus}}
+		repo.EXPECT().GetRuns(context.Background(), entities.JobSensorStatus).Return(runs, int64(2), nil)
+
+		// when
+		got, totalCount, err := svc.GetRuns(context.Background(), entities.JobSensorStatus)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, runs, got)
+		assert.Equal(t, int64(2), totalCount)
+	})
+
+	t.Run("should return error for unknown job", func(t *testing.T) {
+		// given
+		svc := newTestService(t, storageMock.NewMockJobRepository(t))
+
+		// when
+		got, _, err := svc.GetRuns(context.Background(), "unknown")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrJobNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+func TestJobService_Trigger(t *testing.T) {
+	t.Run("should queue manual run requested by the actor", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		svc := newTestService(t, repo)
+		ctx := context.WithValue(context.Background(), enums.ContextKeyActor, "api-key:0a1b2c3d4e5f")
+		expected := &entities.JobRun{ID: 1, Job: entities.JobTreeWateringStatus, Status: entities.JobRunStatusPending}
+		repo.EXPECT().CreateRun(ctx, &entities.JobRunCreate{
+			Job:         entities.JobTreeWateringStatus,
+			Trigger:     entities.JobRunTriggerManual,
+			Status:      entities.JobRunStatusPending,
+			RequestedBy: "api-key:0a1b2c3d4e5f",
+		}).Return(expected, nil)
+
+		// when
+		got, err := svc.Trigger(ctx, entities.JobTreeWateringStatus)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return error for unknown job", func(t *testing.T) {
+		// given
+		svc := newTestService(t, storageMock.NewMockJobRepository(t))
+
+		// when
+		got, err := svc.Trigger(context.Background(), "unknown")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrJobNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+func TestJobService_Tick(t *testing.T) {
+	t.Run("should do nothing if another instance holds the lock", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		svc := newTestService(t, repo)
+		repo.EXPECT().TryLock(mock.Anything).Return(nil, nil)
+
+		// when
+		l := svc.tick(context.Background(), nil)
+
+		// then
+		assert.Nil(t, l)
+	})
+
+	t.Run("should abort previous runs and catch up missed scheduled run when becoming leader", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		lock := storageMock.NewMockLeaderLock(t)
+		svc := NewJobService(repo, testConfig)
+		called := make(chan struct{}, 1)
+		assert.NoError(t, svc.Register(entities.JobSensorStatus, func(context.Context) error {
+			called <- struct{}{}
+			return nil
+		}))
+
+		repo.EXPECT().TryLock(mock.Anything).Return(lock, nil)
+		repo.EXPECT().AbortRunning(mock.Anything, abortReason).Return(int64(1), nil)
+		repo.EXPECT().GetLatestScheduledRun(mock.Anything, entities.JobSensorStatus).
+			Return(&entities.JobRun{ID: 1, CreatedAt: time.Now().Add(-24 * time.Hour)}, nil)
+		repo.EXPECT().CreateRun(mock.Anything, &entities.JobRunCreate{
+			Job:      entities.JobSensorStatus,
+			Trigger:  entities.JobRunTriggerSchedule,
+			Status:   entities.JobRunStatusRunning,
+			Instance: svc.instance,
+		}).Return(&entities.JobRun{ID: 2, Job: entities.JobSensorStatus}, nil)
+		repo.EXPECT().FinishRun(mock.Anything, int64(2), entities.JobRunStatusSuccess, (*string)(nil)).
+			Return(&entities.JobRun{ID: 2, Status: entities.JobRunStatusSuccess}, nil)
+		repo.EXPECT().GetPendingRuns(mock.Anything).Return([]*entities.JobRun{}, nil)
+
+		// when
+		l := svc.tick(context.Background(), nil)
+		l.wg.Wait()
+
+		// then
+		assert.NotNil(t, l)
+		assert.Len(t, called, 1)
+		assert.True(t, l.nextRuns[entities.JobSensorStatus].After(time.Now()))
+	})
+
+	t.Run("should plan first scheduled run without previous run", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		lock := storageMock.NewMockLeaderLock(t)
+		svc := newTestService(t, repo)
+		repo.EXPECT().TryLock(mock.Anything).Return(lock, nil)
+		repo.EXPECT().AbortRunning(mock.Anything, abortReason).Return(int64(0), nil)
+		repo.EXPECT().GetLatestScheduledRun(mock.Anything, entities.JobSensorStatus).
+			Return(nil, storage.ErrEntityNotFound("JobRun"))
+		repo.EXPECT().GetPendingRuns(mock.Anything).Return([]*entities.JobRun{}, nil)
+
+		// when
+		l := svc.tick(context.Background(), nil)
+
+		// then
+		assert.NotNil(t, l)
+		assert.Len(t, l.nextRuns, 1)
+		assert.True(t, l.nextRuns[entities.JobSensorStatus].After(time.Now()))
+	})
+
+	t.Run("should start pending manual run and record the error", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		lock := storageMock.NewMockLeaderLock(t)
+		svc := NewJobService(repo, testConfig)
+		assert.NoError(t, svc.Register(entities.JobTreeWateringStatus, func(context.Context) error {
+			return errors.New("database error")
+		}))
+		l := &leader{lock: lock, ctx: context.Background(), cancel: func() {}, nextRuns: map[string]time.Time{}, running: map[string]struct{}{}}
+
+		lock.EXPECT().Check(mock.Anything).Return(nil)
+		repo.EXPECT().GetPendingRuns(mock.Anything).Return([]*entities.JobRun{
+			{ID: 3, Job: entities.JobTreeWateringStatus, Status: entities.JobRunStatusPending},
+			{ID: 4, Job: "removed_job", Status: entities.JobRunStatusPending},
+		}, nil)
+		repo.EXPECT().StartRun(mock.Anything, int64(3), svc.instance).
+			Return(&entities.JobRun{ID: 3, Job: entities.JobTreeWateringStatus, Trigger: entities.JobRunTriggerManual}, nil)
+		repo.EXPECT().FinishRun(mock.Anything, int64(3), entities.JobRunStatusFailed, utils.P("database error")).
+			Return(&entities.JobRun{ID: 3, Status: entities.JobRunStatusFailed}, nil)
+
+		// when
+		got := svc.tick(context.Background(), l)
+		l.wg.Wait()
+
+		// then
+		assert.Equal(t, l, got)
+		assert.Empty(t, l.running)
+	})
+
+	t.Run("should not start a job that is still running", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		lock := storageMock.NewMockLeaderLock(t)
+		svc := newTestService(t, repo)
+		l := &leader{
+			lock:     lock,
+			ctx:      context.Background(),
+			cancel:   func() {},
+			nextRuns: map[string]time.Time{entities.JobSensorStatus: time.Now().Add(-time.Minute)},
+			running:  map[string]struct{}{entities.JobSensorStatus: {}},
+		}
+
+		lock.EXPECT().Check(mock.Anything).Return(nil)
+		repo.EXPECT().GetPendingRuns(mock.Anything).Return([]*entities.JobRun{
+			{ID: 3, Job: entities.JobSensorStatus, Status: entities.JobRunStatusPending},
+		}, nil)
+
+		// when
+		svc.tick(context.Background(), l)
+
+		// then
+		repo.AssertNotCalled(t, "CreateRun", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "StartRun", mock.Anything, mock.Anything, mock.Anything)
+		assert.True(t, l.nextRuns[entities.JobSensorStatus].After(time.Now()))
+	})
+
+	t.Run("should cancel running jobs and release the lock when the lock is lost", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockJobRepository(t)
+		lock := storageMock.NewMockLeaderLock(t)
+		svc := newTestService(t, repo)
+		ctx, cancel := context.WithCancel(context.Background())
+		l := &leader{lock: lock, ctx: ctx, cancel: cancel, nextRuns: map[string]time.Time{}, running: map[string]struct{}{}}
+
+		lock.EXPECT().Check(mock.Anything).Return(errors.New("connection lost"))
+		lock.EXPECT().Release(mock.Anything).Return(errors.New("connection lost"))
+
+		// when
+		got := svc.tick(context.Background(), l)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, l.ctx.Err(), context.Canceled)
+	})
+}
+
+func TestJobService_Ready(t *testing.T) {
+	t.Run("should be ready", func(t *testing.T) {
+		svc := NewJobService(storageMock.NewMockJobRepository(t), testConfig)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should not be ready without repository", func(t *testing.T) {
+		svc := NewJobService(nil, testConfig)
+		assert.False(t, svc.Ready())
+	})
+}
@@ -0,0 +1,260 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+// abortReason is stored as the error of the runs that were running when the previous leader stopped
+const abortReason = "leader stopped during the run"
+
+// leader is the state of this instance while it holds the lock of the scheduler
+type leader struct {
+	lock   storage.LeaderLock
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// nextRuns are the times of the next scheduled runs by the name of the job
+	nextRuns map[string]time.Time
+
+	mutex   sync.Mutex
+	running map[string]struct{}
+}
+
+// Run polls the lock of the scheduler. The instance that holds the lock runs the due jobs and the
+// manual runs, a job never runs twice at the same time. If the lock is lost, the running jobs are
+// canceled and another instance takes over.
+func (s *JobService) Run(ctx context.Context) {
+	log := logger.GetLogger(ctx)
+	log.Info("starting job scheduler", "instance", s.instance, "poll_interval", s.pollInterval)
+	s.warnUnknownJobs(ctx)
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	var l *leader
+	for {
+		l = s.tick(ctx, l)
+
+		select {
+		case <-ctx.Done():
+			if l != nil {
+				s.resign(l)
+			}
+			log.Debug("stopping job scheduler")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick takes or checks the lock and starts the due runs if this instance is the leader. It returns
+// the state of the leader or nil if this instance is not the leader.
+func (s *JobService) tick(ctx context.Context, l *leader) *leader {
+	log := logger.GetLogger(ctx)
+	if l == nil {
+		lock, err := s.jobRepo.TryLock(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error("failed to take the lock of the job scheduler", "error", err)
+			}
+			return nil
+		}
+		if lock == nil {
+			return nil
+		}
+		l = s.lead(ctx, lock)
+	} else if err := l.lock.Check(ctx); err != nil {
+		if ctx.Err() == nil {
+			log.Error("lost the lock of the job scheduler, cancel running jobs", "error", err)
+		}
+		s.resign(l)
+		return nil
+	}
+
+	s.runScheduled(l)
+	s.runPending(l)
+	return l
+}
+
+// lead aborts the runs of a previous leader and plans the next runs of the scheduled jobs. A job
+// whose scheduled run was missed while no instance was the leader runs once to catch up.
+func (s *JobService) lead(ctx context.Context, lock storage.LeaderLock) *leader {
+	log := logger.GetLogger(ctx)
+	log.Info("became leader of the job scheduler", "instance", s.instance)
+
+	if aborted, err := s.jobRepo.AbortRunning(ctx, abortReason); err != nil {
+		log.Error("failed to abort job runs of the previous leader", "error", err)
+	} else if aborted > 0 {
+		log.Warn("aborted job runs of the previous leader", "count", aborted)
+	}
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	l := &leader{
+		lock:     lock,
+		ctx:      leaderCtx,
+		cancel:   cancel,
+		nextRuns: make(map[string]time.Time),
+		running:  make(map[string]struct{}),
+	}
+
+	now := time.Now()
+	for _, j := range s.registered() {
+		if !j.scheduled() {
+			continue
+		}
+
+		last, err := s.jobRepo.GetLatestScheduledRun(ctx, j.name)
+		if err != nil {
+			var notFound storage.ErrEntityNotFound
+			if !errors.As(err, &notFound) {
+				log.Error("failed to get the latest scheduled job run", "error", err, "job", j.name)
+			}
+			l.nextRuns[j.name] = j.schedule.Next(now)
+			continue
+		}
+		l.nextRuns[j.name] = j.schedule.Next(last.CreatedAt)
+	}
+
+	return l
+}
+
+// resign cancels the running jobs, waits for them and releases the lock
+func (s *JobService) resign(l *leader) {
+	l.cancel()
+	l.wg.Wait()
+
+	ctx := context.WithoutCancel(l.ctx)
+	if err := l.lock.Release(ctx); err != nil {
+		logger.GetLogger(ctx).Debug("failed to release the lock of the job scheduler", "error", err)
+	}
+	logger.GetLogger(ctx).Info("resigned as leader of the job scheduler", "instance", s.instance)
+}
+
+// runScheduled starts the jobs whose next run is due. A due run is skipped if the job is still running.
+func (s *JobService) runScheduled(l *leader) {
+	log := logger.GetLogger(l.ctx)
+	now := time.Now()
+	for _, j := range s.registered() {
+		next, ok := l.nextRuns[j.name]
+		if !ok || now.Before(next) {
+			continue
+		}
+		l.nextRuns[j.name] = j.schedule.Next(now)
+
+		if !l.claim(j.name) {
+			log.Warn("job is still running, skip scheduled run", "job", j.name)
+			continue
+		}
+
+		run, err := s.jobRepo.CreateRun(l.ctx, &domain.JobRunCreate{
+			Job:      j.name,
+			Trigger:  domain.JobRunTriggerSchedule,
+			Status:   domain.JobRunStatusRunning,
+			Instance: s.instance,
+		})
+		if err != nil {
+			log.Error("failed to create job run", "error", err, "job", j.name)
+			l.release(j.name)
+			continue
+		}
+
+		s.start(l, j, run)
+	}
+}
+
+// runPending starts the queued manual runs of the jobs that are not running
+func (s *JobService) runPending(l *leader) {
+	log := logger.GetLogger(l.ctx)
+	pending, err := s.jobRepo.GetPendingRuns(l.ctx)
+	if err != nil {
+		if l.ctx.Err() == nil {
+			log.Error("failed to get pending job runs", "error", err)
+		}
+		return
+	}
+
+	for _, run := range pending {
+		j, err := s.get(l.ctx, run.Job)
+		if err != nil {
+			continue
+		}
+
+		if !l.claim(j.name) {
+			continue
+		}
+
+		started, err := s.jobRepo.StartRun(l.ctx, run.ID, s.instance)
+		if err != nil {
+			log.Error("failed to start manual job run", "error", err, "job", j.name, "run_id", run.ID)
+			l.release(j.name)
+			continue
+		}
+
+		s.start(l, j, started)
+	}
+}
+
+// start runs the claimed job in a separate goroutine and records the outcome of the run
+func (s *JobService) start(l *leader, j *job, run *domain.JobRun) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer l.release(j.name)
+
+		log := logger.GetLogger(l.ctx)
+		log.Info("job started", "job", j.name, "run_id", run.ID, "trigger", run.Trigger)
+
+		status := domain.JobRunStatusSuccess
+		var runErr *string
+		if err := j.work(l.ctx); err != nil {
+			log.Error("job failed", "error", err, "job", j.name, "run_id", run.ID)
+			status = domain.JobRunStatusFailed
+			runErr = utils.P(err.Error())
+		}
+
+		// the outcome is stored even if the job was canceled because the lock was lost
+		finished, err := s.jobRepo.FinishRun(context.WithoutCancel(l.ctx), run.ID, status, runErr)
+		if err != nil {
+			log.Error("failed to finish job run", "error", err, "job", j.name, "run_id", run.ID)
+			return
+		}
+		log.Info("job finished", "job", j.name, "run_id", run.ID, "status", finished.Status, "duration", finished.Duration)
+	}()
+}
+
+// warnUnknownJobs logs the configured jobs that are not registered, e.g. because of a typo
+func (s *JobService) warnUnknownJobs(ctx context.Context) {
+	for name := range s.cfg.Jobs {
+		if _, err := s.get(ctx, name); err != nil {
+			logger.GetLogger(ctx).Warn("configured job is not registered", "job", name)
+		}
+	}
+}
+
+// claim marks the job as running. It returns false if the job is already running.
+func (l *leader) claim(name string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, ok := l.running[name]; ok {
+		return false
+	}
+	l.running[name] = struct{}{}
+	return true
+}
+
+func (l *leader) release(name string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.running, name)
+}
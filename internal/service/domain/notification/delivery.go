@@ -0,0 +1,275 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	deliveryBatchLimit  = 200
+	deliveryLease       = 5 * time.Minute
+	deliveryMaxAttempts = 5
+	deliveryBackoff     = time.Minute
+	deliveryMaxBackoff  = time.Hour
+)
+
+var (
+	ErrNoEmailAddress     = errors.New("user has no email address")
+	ErrNoPushSubscription = errors.New("user has no push subscription")
+	ErrNoNotification     = errors.New("notification of the delivery does not exist")
+)
+
+// deliveryGroup are the due deliveries of a user and channel that are sent as one message
+type deliveryGroup struct {
+	userID     uuid.UUID
+	channel    entities.NotificationChannel
+	deliveries []*entities.NotificationDelivery
+}
+
+func (s *NotificationService) DeliverDue(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	deliveries, err := s.notificationRepo.ClaimDueDeliveries(ctx, s.now(), deliveryLease, deliveryBatchLimit)
+	if err != nil {
+		log.Error("failed to claim due notification deliveries", "error", err)
+		return err
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	groups := groupDeliveries(deliveries)
+	emails, err := s.emailAddresses(ctx, groups)
+	if err != nil {
+		log.Error("failed to fetch users of notification deliveries", "error", err)
+		return err
+	}
+
+	for _, group := range groups {
+		var err error
+		switch group.channel {
+		case entities.NotificationChannelEmail:
+			err = s.sendMail(ctx, emails[group.userID], group.deliveries)
+		case entities.NotificationChannelPush:
+			err = s.sendPush(ctx, group.userID, group.deliveries)
+		default:
+			err = fmt.Errorf("notification channel %s can not be delivered", group.channel)
+		}
+
+		for _, delivery := range group.deliveries {
+			s.finish(ctx, delivery, err)
+			if err := s.notificationRepo.UpdateDelivery(ctx, delivery); err != nil {
+				log.Error("failed to update notification delivery", "error", err, "delivery_id", delivery.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationService) sendMail(ctx context.Context, address string, deliveries []*entities.NotificationDelivery) error {
+	if address == "" {
+		return ErrNoEmailAddress
+	}
+
+	notifications := notificationsOf(deliveries)
+	if len(notifications) == 0 {
+		return ErrNoNotification
+	}
+	subject := notifications[0].Title
+	if len(notifications) > 1 {
+		subject = fmt.Sprintf("%d new notifications", len(notifications))
+	}
+
+	var body strings.Builder
+	for i, n := range notifications {
+		if i > 0 {
+			body.WriteString("\n\n")
+		}
+		if len(notifications) > 1 {
+			body.WriteString(n.Title)
+			body.WriteString("\n")
+		}
+		body.WriteString(n.Body)
+		if n.Link != "" {
+			body.WriteString("\n")
+			body.WriteString(s.appURL + n.Link)
+		}
+	}
+
+	return s.mailSender.Send(ctx, &entities.Mail{
+		To:      []string{address},
+		Subject: subject,
+		Body:    body.String(),
+	})
+}
+
+// sendPush sends the message to every browser of the user. It succeeds if at least one browser received it,
+// subscriptions that are gone are deleted.
+func (s *NotificationService) sendPush(ctx context.Context, userID uuid.UUID, deliveries []*entities.NotificationDelivery) error {
+	log := logger.GetLogger(ctx)
+	subscriptions, err := s.notificationRepo.GetPushSubscriptions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	notifications := notificationsOf(deliveries)
+	if len(notifications) == 0 {
+		return ErrNoNotification
+	}
+
+	msg := entities.PushMessage{
+		Title: notifications[0].Title,
+		Body:  notifications[0].Body,
+		Link:  notifications[0].Link,
+	}
+	if len(notifications) > 1 {
+		msg = entities.PushMessage{
+			Title: fmt.Sprintf("%d new notifications", len(notifications)),
+			Body:  strings.Join(utils.Map(notifications, func(n *entities.Notification) string { return n.Title }), "\n"),
+		}
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	sent := 0
+	var errs []error
+	for _, sub := range subscriptions {
+		err := s.pushSender.Send(ctx, sub, payload)
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, storage.ErrPushSubscriptionGone):
+			if err := s.notificationRepo.DeletePushSubscription(ctx, sub.Endpoint); err != nil {
+				log.Error("failed to delete expired push subscription", "error", err, "push_subscription_id", sub.ID)
+			}
+		default:
+			errs = append(errs, err)
+		}
+	}
+
+	if sent > 0 {
+		return nil
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return ErrNoPushSubscription
+}
+
+// finish updates the state of the delivery according to the result of the attempt
+func (s *NotificationService) finish(ctx context.Context, delivery *entities.NotificationDelivery, err error) {
+	log := logger.GetLogger(ctx)
+	now := s.now()
+	delivery.Attempts++
+	delivery.LastError = nil
+
+	if err == nil {
+		delivery.Status = entities.NotificationDeliveryStatusSent
+		delivery.SentAt = &now
+		log.Debug("notification delivered successfully", "delivery_id", delivery.ID, "channel", delivery.Channel)
+		return
+	}
+
+	errMsg := err.Error()
+	delivery.LastError = &errMsg
+	if delivery.Attempts >= deliveryMaxAttempts || permanent(err) {
+		delivery.Status = entities.NotificationDeliveryStatusFailed
+		log.Warn("notification delivery failed permanently", "error", err, "delivery_id", delivery.ID, "channel", delivery.Channel, "attempts", delivery.Attempts)
+		return
+	}
+
+	delivery.Status = entities.NotificationDeliveryStatusPending
+	delivery.SendAfter = now.Add(nextBackoff(delivery.Attempts))
+	log.Debug("notification delivery failed, retry later", "error", err, "delivery_id", delivery.ID, "send_after", delivery.SendAfter)
+}
+
+// emailAddresses returns the email addresses of the users with email deliveries
+func (s *NotificationService) emailAddresses(ctx context.Context, groups []*deliveryGroup) (map[uuid.UUID]string, error) {
+	var ids []string
+	for _, group := range groups {
+		if group.channel == entities.NotificationChannelEmail {
+			ids = append(ids, group.userID.String())
+		}
+	}
+
+	emails := make(map[uuid.UUID]string, len(ids))
+	if len(ids) == 0 {
+		return emails, nil
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		emails[user.ID] = user.Email
+	}
+	return emails, nil
+}
+
+// groupDeliveries groups the deliveries by user and channel in the order they were claimed
+func groupDeliveries(deliveries []*entities.NotificationDelivery) []*deliveryGroup {
+	type key struct {
+		userID  uuid.UUID
+		channel entities.NotificationChannel
+	}
+
+	var groups []*deliveryGroup
+	index := make(map[key]*deliveryGroup)
+	for _, delivery := range deliveries {
+		k := key{userID: delivery.UserID, channel: delivery.Channel}
+		group, ok := index[k]
+		if !ok {
+			group = &deliveryGroup{userID: delivery.UserID, channel: delivery.Channel}
+			index[k] = group
+			groups = append(groups, group)
+		}
+		group.deliveries = append(group.deliveries, delivery)
+	}
+	return groups
+}
+
+func notificationsOf(deliveries []*entities.NotificationDelivery) []*entities.Notification {
+	notifications := make([]*entities.Notification, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.Notification != nil {
+			notifications = append(notifications, delivery.Notification)
+		}
+	}
+	return notifications
+}
+
+// permanent reports whether a retry of the delivery can not succeed
+func permanent(err error) bool {
+	return errors.Is(err, storage.ErrMailServiceDisabled) ||
+		errors.Is(err, storage.ErrPushServiceDisabled) ||
+		errors.Is(err, ErrNoEmailAddress) ||
+		errors.Is(err, ErrNoPushSubscription) ||
+		errors.Is(err, ErrNoNotification)
+}
+
+// nextBackoff doubles the backoff for every attempt and caps it at deliveryMaxBackoff
+func nextBackoff(attempts int32) time.Duration {
+	backoff := deliveryBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= deliveryMaxBackoff {
+			return deliveryMaxBackoff
+		}
+	}
+	return backoff
+}
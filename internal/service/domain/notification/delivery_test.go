@@ -0,0 +1,173 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testDelivery(id int32, channel entities.NotificationChannel, title string) *entities.NotificationDelivery {
+	return &entities.NotificationDelivery{
+		ID:             id,
+		NotificationID: id,
+		UserID:         testUserID,
+		Channel:        channel,
+		Status:         entities.NotificationDeliveryStatusPending,
+		SendAfter:      testNow,
+		Notification: &entities.Notification{
+			ID:    id,
+			Title: title,
+			Body:  title + " body",
+			Link:  "/treecluster/1",
+		},
+	}
+}
+
+func TestNotificationService_DeliverDue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should bundle email deliveries of user into one mail", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		first := testDelivery(1, entities.NotificationChannelEmail, "First")
+		second := testDelivery(2, entities.NotificationChannelEmail, "Second")
+
+		m.notificationRepo.EXPECT().ClaimDueDeliveries(ctx, testNow, deliveryLease, int32(deliveryBatchLimit)).Return([]*entities.NotificationDelivery{first, second}, nil)
+		m.userRepo.EXPECT().GetByIDs(ctx, []string{testUserID.String()}).Return([]*entities.User{{ID: testUserID, Email: "user@example.com"}}, nil)
+		m.mailSender.EXPECT().Send(ctx, mock.Anything).RunAndReturn(func(_ context.Context, mail *entities.Mail) error {
+			assert.Equal(t, []string{"user@example.com"}, mail.To)
+			assert.Equal(t, "2 new notifications", mail.Subject)
+			assert.Equal(t, "First\nFirst body\nhttps://app.example.com/treecluster/1\n\nSecond\nSecond body\nhttps://app.example.com/treecluster/1", mail.Body)
+			return nil
+		})
+		m.notificationRepo.EXPECT().UpdateDelivery(ctx, first).Return(nil)
+		m.notificationRepo.EXPECT().UpdateDelivery(ctx, second).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		for _, d := range []*entities.NotificationDelivery{first, second} {
+			assert.Equal(t, entities.NotificationDeliveryStatusSent, d.Status)
+			assert.Equal(t, int32(1), d.Attempts)
+			assert.Equal(t, testNow, *d.SentAt)
+			assert.Nil(t, d.LastError)
+		}
+	})
+
+	t.Run("should send push to every subscription and delete gone ones", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		delivery := testDelivery(1, entities.NotificationChannelPush, "First")
+		active := &entities.PushSubscription{ID: 1, Endpoint: "https://push.example.com/1"}
+		gone := &entities.PushSubscription{ID: 2, Endpoint: "https://push.example.com/2"}
+
+		m.notificationRepo.EXPECT().ClaimDueDeliveries(ctx, testNow, deliveryLease, int32(deliveryBatchLimit)).Return([]*entities.NotificationDelivery{delivery}, nil)
+		m.notificationRepo.EXPECT().GetPushSubscriptions(ctx, testUserID).Return([]*entities.PushSubscription{active, gone}, nil)
+		m.pushSender.EXPECT().Send(ctx, active, mock.Anything).RunAndReturn(func(_ context.Context, _ *entities.PushSubscription, payload []byte) error {
+			var msg entities.PushMessage
+			assert.NoError(t, json.Unmarshal(payload, &msg))
+			assert.Equal(t, entities.PushMessage{Title: "First", Body: "First body", Link: "/treecluster/1"}, msg)
+			return nil
+		})
+		m.pushSender.EXPECT().Send(ctx, gone, mock.Anything).Return(storage.ErrPushSubscriptionGone)
+		m.notificationRepo.EXPECT().DeletePushSubscription(ctx, gone.Endpoint).Return(nil)
+		m.notificationRepo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.NotificationDeliveryStatusSent, delivery.Status)
+	})
+
+	t.Run("should reschedule failed delivery with backoff", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		delivery := testDelivery(1, entities.NotificationChannelEmail, "First")
+		delivery.Attempts = 2
+
+		m.notificationRepo.EXPECT().ClaimDueDeliveries(ctx, testNow, deliveryLease, int32(deliveryBatchLimit)).Return([]*entities.NotificationDelivery{delivery}, nil)
+		m.userRepo.EXPECT().GetByIDs(ctx, []string{testUserID.String()}).Return([]*entities.User{{ID: testUserID, Email: "user@example.com"}}, nil)
+		m.mailSender.EXPECT().Send(ctx, mock.Anything).Return(errors.New("connection refused"))
+		m.notificationRepo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.NotificationDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, int32(3), delivery.Attempts)
+		assert.Equal(t, testNow.Add(4*time.Minute), delivery.SendAfter)
+		assert.Equal(t, "connection refused", *delivery.LastError)
+	})
+
+	t.Run("should fail delivery permanently if channel is disabled", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		delivery := testDelivery(1, entities.NotificationChannelEmail, "First")
+
+		m.notificationRepo.EXPECT().ClaimDueDeliveries(ctx, testNow, deliveryLease, int32(deliveryBatchLimit)).Return([]*entities.NotificationDelivery{delivery}, nil)
+		m.userRepo.EXPECT().GetByIDs(ctx, []string{testUserID.String()}).Return([]*entities.User{{ID: testUserID, Email: "user@example.com"}}, nil)
+		m.mailSender.EXPECT().Send(ctx, mock.Anything).Return(storage.ErrMailServiceDisabled)
+		m.notificationRepo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.NotificationDeliveryStatusFailed, delivery.Status)
+	})
+
+	t.Run("should fail push delivery if user has no subscription", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		delivery := testDelivery(1, entities.NotificationChannelPush, "First")
+		delivery.UserID = uuid.New()
+
+		m.notificationRepo.EXPECT().ClaimDueDeliveries(ctx, testNow, deliveryLease, int32(deliveryBatchLimit)).Return([]*entities.NotificationDelivery{delivery}, nil)
+		m.notificationRepo.EXPECT().GetPushSubscriptions(ctx, delivery.UserID).Return(nil, nil)
+		m.notificationRepo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.NotificationDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, ErrNoPushSubscription.Error(), *delivery.LastError)
+	})
+
+	t.Run("should return error when claim fails", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.notificationRepo.EXPECT().ClaimDueDeliveries(ctx, testNow, deliveryLease, int32(deliveryBatchLimit)).Return(nil, errors.New("database error"))
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestNextBackoff(t *testing.T) {
+	t.Run("should double backoff and cap it", func(t *testing.T) {
+		assert.Equal(t, time.Minute, nextBackoff(1))
+		assert.Equal(t, 2*time.Minute, nextBackoff(2))
+		assert.Equal(t, 8*time.Minute, nextBackoff(4))
+		assert.Equal(t, time.Hour, nextBackoff(10))
+	})
+}
@@ -0,0 +1,298 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+var templates = map[entities.NotificationType]messageTemplate{
+	entities.NotificationTypeTreeClusterBad: {
+		title: template.Must(template.New("title").Parse(`Tree cluster {{.Name}} needs water`)),
+		body:  template.Must(template.New("body").Parse(`The watering status of the tree cluster {{.Name}}{{with .Region}} in {{.Name}}{{end}} changed to bad.`)),
+	},
+	entities.NotificationTypeSensorOffline: {
+		title: template.Must(template.New("title").Parse(`Sensor {{.ID}} is offline`)),
+		body:  template.Must(template.New("body").Parse(`The sensor {{.ID}} stopped sending data and was marked as offline.`)),
+	},
+	entities.NotificationTypeWateringPlanAssigned: {
+		title: template.Must(template.New("title").Parse(`Assigned to the watering plan on {{.Date.Format "02.01.2006"}}`)),
+		body:  template.Must(template.New("body").Parse(`You were assigned to the watering plan on {{.Date.Format "02.01.2006"}}{{with .Description}}: {{.}}{{end}}.`)),
+	},
+	entities.NotificationTypeAlert: {
+		title: template.Must(template.New("title").Parse(`Alert {{.Rule.Name}}: {{.Alert.SubjectName}}`)),
+		body:  template.Must(template.New("body").Parse(`{{.Alert.Message}}`)),
+	},
+}
+
+// message is a notification before it is created for the recipients
+type message struct {
+	notificationType entities.NotificationType
+	title            string
+	body             string
+	link             string
+}
+
+func (s *NotificationService) HandleEvent(ctx context.Context, event entities.Event) error {
+	switch e := event.(type) {
+	case entities.EventUpdateTreeCluster:
+		if e.New == nil || e.New.WateringStatus != entities.WateringStatusBad || (e.Prev != nil && e.Prev.WateringStatus == entities.WateringStatusBad) {
+			return nil
+		}
+		return s.broadcast(ctx, entities.NotificationTypeTreeClusterBad, e.New, fmt.Sprintf("/treecluster/%d", e.New.ID))
+	case entities.EventUpdateSensor:
+		if e.New == nil || e.New.Status != entities.SensorStatusOffline || (e.Prev != nil && e.Prev.Status == entities.SensorStatusOffline) {
+			return nil
+		}
+		return s.broadcast(ctx, entities.NotificationTypeSensorOffline, e.New, fmt.Sprintf("/sensors/%s", e.New.ID))
+	case entities.EventCreateWateringPlan:
+		if e.New == nil {
+			return nil
+		}
+		return s.notifyAssigned(ctx, e.New, assignedUsers(nil, e.New))
+	case entities.EventUpdateWateringPlan:
+		if e.New == nil {
+			return nil
+		}
+		return s.notifyAssigned(ctx, e.New, assignedUsers(e.Prev, e.New))
+	case entities.EventFireAlert:
+		if e.Rule == nil || e.Alert == nil || !e.Rule.Notify {
+			return nil
+		}
+		return s.broadcast(ctx, entities.NotificationTypeAlert, e, fmt.Sprintf("/alerts/%d", e.Alert.ID))
+	default:
+		return nil
+	}
+}
+
+// broadcast notifies all users that enabled the notification type
+func (s *NotificationService) broadcast(ctx context.Context, notificationType entities.NotificationType, data any, link string) error {
+	log := logger.GetLogger(ctx)
+	msg, err := render(notificationType, data, link)
+	if err != nil {
+		log.Error("failed to render notification", "error", err, "notification_type", notificationType)
+		return err
+	}
+
+	users, err := s.userRepo.GetAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch users to notify", "error", err, "notification_type", notificationType)
+		return err
+	}
+
+	allSettings, err := s.notificationRepo.GetAllSettings(ctx)
+	if err != nil {
+		log.Error("failed to fetch notification settings", "error", err, "notification_type", notificationType)
+		return err
+	}
+
+	settingsByUser := make(map[uuid.UUID]*entities.NotificationSettings, len(allSettings))
+	for _, settings := range allSettings {
+		settingsByUser[settings.UserID] = settings
+	}
+
+	var errs []error
+	for _, user := range users {
+		settings, ok := settingsByUser[user.ID]
+		if !ok {
+			settings = entities.DefaultNotificationSettings(user.ID)
+		}
+		if err := s.notify(ctx, msg, settings); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// notifyAssigned notifies the users that were newly assigned to the watering plan
+func (s *NotificationService) notifyAssigned(ctx context.Context, wp *entities.WateringPlan, userIDs []uuid.UUID) error {
+	log := logger.GetLogger(ctx)
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	msg, err := render(entities.NotificationTypeWateringPlanAssigned, wp, fmt.Sprintf("/watering-plans/%d", wp.ID))
+	if err != nil {
+		log.Error("failed to render notification", "error", err, "notification_type", entities.NotificationTypeWateringPlanAssigned)
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		settings, err := s.getSettings(ctx, userID)
+		if err != nil {
+			log.Error("failed to fetch notification settings", "error", err, "user_id", userID)
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.notify(ctx, msg, settings); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// notify creates the notification for one user with the deliveries of the enabled channels
+func (s *NotificationService) notify(ctx context.Context, msg *message, settings *entities.NotificationSettings) error {
+	log := logger.GetLogger(ctx)
+	inApp := settings.Enabled(msg.notificationType, entities.NotificationChannelInApp)
+
+	var deliveries []*entities.NotificationDelivery
+	sendAfter := s.sendAfter(settings, s.now())
+	for _, channel := range []entities.NotificationChannel{entities.NotificationChannelEmail, entities.NotificationChannelPush} {
+		if settings.Enabled(msg.notificationType, channel) {
+			deliveries = append(deliveries, &entities.NotificationDelivery{
+				UserID:    settings.UserID,
+				Channel:   channel,
+				SendAfter: sendAfter,
+			})
+		}
+	}
+
+	if !inApp && len(deliveries) == 0 {
+		return nil
+	}
+
+	// the event id makes the notification unique, so a retried event does not notify the user again
+	var eventID *int64
+	if id, ok := ctx.Value(enums.ContextKeyEventID).(int64); ok {
+		eventID = &id
+	}
+
+	created, err := s.notificationRepo.Create(ctx, &entities.Notification{
+		UserID:  settings.UserID,
+		Type:    msg.notificationType,
+		Title:   msg.title,
+		Body:    msg.body,
+		Link:    msg.link,
+		InApp:   inApp,
+		EventID: eventID,
+	}, deliveries)
+	if err != nil {
+		log.Error("failed to create notification", "error", err, "user_id", settings.UserID, "notification_type", msg.notificationType)
+		return err
+	}
+
+	log.Debug("notification created", "notification_id", created.ID, "user_id", settings.UserID, "notification_type", msg.notificationType, "deliveries", len(deliveries))
+	return nil
+}
+
+// sendAfter returns when the email and push deliveries may be sent. With digest they wait for the next digest
+// time, otherwise they are postponed until the end of the quiet hours.
+func (s *NotificationService) sendAfter(settings *entities.NotificationSettings, now time.Time) time.Time {
+	local := now.In(s.location)
+	if settings.Digest {
+		if digest, ok := nextTimeOfDay(local, settings.DigestTime); ok {
+			return digest
+		}
+	}
+
+	if settings.QuietHoursStart == "" || settings.QuietHoursStart == settings.QuietHoursEnd {
+		return now
+	}
+
+	start, okStart := minuteOfDay(settings.QuietHoursStart)
+	end, okEnd := minuteOfDay(settings.QuietHoursEnd)
+	if !okStart || !okEnd {
+		return now
+	}
+
+	current := local.Hour()*60 + local.Minute()
+	quiet := current >= start && current < end
+	if start > end {
+		// the quiet hours span midnight
+		quiet = current >= start || current < end
+	}
+	if !quiet {
+		return now
+	}
+
+	next, _ := nextTimeOfDay(local, settings.QuietHoursEnd)
+	return next
+}
+
+// nextTimeOfDay returns the next occurrence of the HH:MM time of day at or after t in the location of t
+func nextTimeOfDay(t time.Time, value string) (time.Time, bool) {
+	minutes, ok := minuteOfDay(value)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	next := time.Date(t.Year(), t.Month(), t.Day(), minutes/60, minutes%60, 0, 0, t.Location())
+	if next.Before(t) {
+		next = time.Date(t.Year(), t.Month(), t.Day()+1, minutes/60, minutes%60, 0, 0, t.Location())
+	}
+	return next, true
+}
+
+func minuteOfDay(value string) (int, bool) {
+	t, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func render(notificationType entities.NotificationType, data any, link string) (*message, error) {
+	tmpl, ok := templates[notificationType]
+	if !ok {
+		return nil, fmt.Errorf("no template for notification type %s", notificationType)
+	}
+
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return nil, err
+	}
+
+	return &message{
+		notificationType: notificationType,
+		title:            title.String(),
+		body:             body.String(),
+		link:             link,
+	}, nil
+}
+
+// assignedUsers returns the users of the new watering plan that were not assigned to the previous one
+func assignedUsers(prev, updated *entities.WateringPlan) []uuid.UUID {
+	var before []uuid.UUID
+	if prev != nil {
+		before = derefUserIDs(prev.UserIDs)
+	}
+
+	var added []uuid.UUID
+	for _, userID := range derefUserIDs(updated.UserIDs) {
+		if !slices.Contains(before, userID) && !slices.Contains(added, userID) {
+			added = append(added, userID)
+		}
+	}
+	return added
+}
+
+func derefUserIDs(userIDs []*uuid.UUID) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	return ids
+}
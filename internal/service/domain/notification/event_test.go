@@ -0,0 +1,253 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationService_HandleEvent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should notify all users when tree cluster turns bad", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		otherUserID := uuid.New()
+		prev := &entities.TreeCluster{ID: 3, Name: "Cluster A", WateringStatus: entities.WateringStatusModerate}
+		updated := &entities.TreeCluster{ID: 3, Name: "Cluster A", WateringStatus: entities.WateringStatusBad, Region: &entities.Region{Name: "Mürwik"}}
+
+		m.userRepo.EXPECT().GetAll(ctx).Return([]*entities.User{{ID: testUserID}, {ID: otherUserID}}, nil)
+		m.notificationRepo.EXPECT().GetAllSettings(ctx).Return([]*entities.NotificationSettings{{
+			UserID: otherUserID,
+			Channels: map[entities.NotificationType][]entities.NotificationChannel{
+				entities.NotificationTypeTreeClusterBad: {entities.NotificationChannelPush},
+			},
+		}}, nil)
+		m.notificationRepo.EXPECT().Create(ctx, mock.MatchedBy(func(n *entities.Notification) bool {
+			return n.UserID == testUserID && n.InApp
+		}), []*entities.NotificationDelivery(nil)).RunAndReturn(func(_ context.Context, n *entities.Notification, _ []*entities.NotificationDelivery) (*entities.Notification, error) {
+			assert.Equal(t, entities.NotificationTypeTreeClusterBad, n.Type)
+			assert.Equal(t, "Tree cluster Cluster A needs water", n.Title)
+			assert.Equal(t, "The watering status of the tree cluster Cluster A in Mürwik changed to bad.", n.Body)
+			assert.Equal(t, "/treecluster/3", n.Link)
+			return n, nil
+		})
+		m.notificationRepo.EXPECT().Create(ctx, mock.MatchedBy(func(n *entities.Notification) bool {
+			return n.UserID == otherUserID && !n.InApp
+		}), []*entities.NotificationDelivery{{
+			UserID:    otherUserID,
+			Channel:   entities.NotificationChannelPush,
+			SendAfter: testNow,
+		}}).Return(&entities.Notification{ID: 2}, nil)
+
+		// when
+		err := svc.HandleEvent(ctx, entities.NewEventUpdateTreeCluster(prev, updated))
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should create notification with the id of the handled event", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		eventCtx := context.WithValue(ctx, enums.ContextKeyEventID, int64(42))
+		m.userRepo.EXPECT().GetAll(eventCtx).Return([]*entities.User{{ID: testUserID}}, nil)
+		m.notificationRepo.EXPECT().GetAllSettings(eventCtx).Return(nil, nil)
+		m.notificationRepo.EXPECT().Create(eventCtx, mock.MatchedBy(func(n *entities.Notification) bool {
+			return n.EventID != nil && *n.EventID == 42
+		}), mock.Anything).Return(&entities.Notification{ID: 1}, nil)
+
+		// when
+		err := svc.HandleEvent(eventCtx, entities.NewEventUpdateSensor(
+			&entities.Sensor{ID: "sensor-1", Status: entities.SensorStatusOnline},
+			&entities.Sensor{ID: "sensor-1", Status: entities.SensorStatusOffline},
+		))
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not notify when tree cluster was already bad", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		prev := &entities.TreeCluster{ID: 3, WateringStatus: entities.WateringStatusBad}
+		updated := &entities.TreeCluster{ID: 3, WateringStatus: entities.WateringStatusBad}
+
+		// when
+		err := svc.HandleEvent(ctx, entities.NewEventUpdateTreeCluster(prev, updated))
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should notify when sensor goes offline", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.userRepo.EXPECT().GetAll(ctx).Return([]*entities.User{{ID: testUserID}}, nil)
+		m.notificationRepo.EXPECT().GetAllSettings(ctx).Return(nil, nil)
+		m.notificationRepo.EXPECT().Create(ctx, mock.MatchedBy(func(n *entities.Notification) bool {
+			return n.Type == entities.NotificationTypeSensorOffline && n.Title == "Sensor sensor-1 is offline" && n.Link == "/sensors/sensor-1"
+		}), []*entities.NotificationDelivery(nil)).Return(&entities.Notification{ID: 1}, nil)
+
+		// when
+		err := svc.HandleEvent(ctx, entities.NewEventUpdateSensor(
+			&entities.Sensor{ID: "sensor-1", Status: entities.SensorStatusOnline},
+			&entities.Sensor{ID: "sensor-1", Status: entities.SensorStatusOffline},
+		))
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should notify only newly assigned users of watering plan", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		keptUserID := uuid.New()
+		prev := &entities.WateringPlan{ID: 7, UserIDs: []*uuid.UUID{&keptUserID}}
+		updated := &entities.WateringPlan{
+			ID:          7,
+			Date:        time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC),
+			Description: "Innenstadt",
+			UserIDs:     []*uuid.UUID{&keptUserID, &testUserID},
+		}
+
+		m.notificationRepo.EXPECT().GetSettings(ctx, testUserID).Return(nil, storage.ErrEntityNotFound("NotificationSetting"))
+		m.notificationRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, n *entities.Notification, deliveries []*entities.NotificationDelivery) (*entities.Notification, error) {
+			assert.Equal(t, testUserID, n.UserID)
+			assert.Equal(t, "Assigned to the watering plan on 10.04.2025", n.Title)
+			assert.Equal(t, "You were assigned to the watering plan on 10.04.2025: Innenstadt.", n.Body)
+			assert.Equal(t, "/watering-plans/7", n.Link)
+			assert.Len(t, deliveries, 1)
+			assert.Equal(t, entities.NotificationChannelEmail, deliveries[0].Channel)
+			return n, nil
+		})
+
+		// when
+		err := svc.HandleEvent(ctx, entities.NewEventUpdateWateringPlan(prev, updated))
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should notify all users when alert of a rule with notify fires", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		rule := &entities.AlertRule{ID: 1, Name: "Low sensor battery", Notify: true}
+		alert := &entities.Alert{ID: 5, RuleID: 1, SubjectName: "sensor-1", Message: "The battery of the sensor sensor-1 is at 3.10 V, below 3.20 V."}
+
+		m.userRepo.EXPECT().GetAll(ctx).Return([]*entities.User{{ID: testUserID}}, nil)
+		m.notificationRepo.EXPECT().GetAllSettings(ctx).Return(nil, nil)
+		m.notificationRepo.EXPECT().Create(ctx, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, n *entities.Notification, deliveries []*entities.NotificationDelivery) (*entities.Notification, error) {
+			assert.Equal(t, entities.NotificationTypeAlert, n.Type)
+			assert.Equal(t, "Alert Low sensor battery: sensor-1", n.Title)
+			assert.Equal(t, alert.Message, n.Body)
+			assert.Equal(t, "/alerts/5", n.Link)
+			assert.True(t, n.InApp)
+			assert.Len(t, deliveries, 1)
+			assert.Equal(t, entities.NotificationChannelEmail, deliveries[0].Channel)
+			return n, nil
+		})
+
+		// when
+		err := svc.HandleEvent(ctx, entities.NewEventFireAlert(rule, alert))
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not notify when alert rule has notify disabled", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		rule := &entities.AlertRule{ID: 1, Name: "Low sensor battery"}
+
+		// when
+		err := svc.HandleEvent(ctx, entities.NewEventFireAlert(rule, &entities.Alert{ID: 5}))
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should ignore other events", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		err := svc.HandleEvent(ctx, entities.NewEventDeleteTree(nil))
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestNotificationService_sendAfter(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	svc := &NotificationService{location: berlin}
+
+	tests := []struct {
+		name     string
+		settings *entities.NotificationSettings
+		now      time.Time
+		want     time.Time
+	}{
+		{
+			name:     "should send immediately without quiet hours",
+			settings: &entities.NotificationSettings{},
+			now:      time.Date(2025, 4, 2, 23, 0, 0, 0, berlin),
+			want:     time.Date(2025, 4, 2, 23, 0, 0, 0, berlin),
+		},
+		{
+			name:     "should send immediately outside quiet hours",
+			settings: &entities.NotificationSettings{QuietHoursStart: "22:00", QuietHoursEnd: "06:00"},
+			now:      time.Date(2025, 4, 2, 12, 0, 0, 0, berlin),
+			want:     time.Date(2025, 4, 2, 12, 0, 0, 0, berlin),
+		},
+		{
+			name:     "should postpone until end of quiet hours spanning midnight",
+			settings: &entities.NotificationSettings{QuietHoursStart: "22:00", QuietHoursEnd: "06:00"},
+			now:      time.Date(2025, 4, 2, 23, 30, 0, 0, berlin),
+			want:     time.Date(2025, 4, 3, 6, 0, 0, 0, berlin),
+		},
+		{
+			name:     "should postpone until end of quiet hours after midnight",
+			settings: &entities.NotificationSettings{QuietHoursStart: "22:00", QuietHoursEnd: "06:00"},
+			now:      time.Date(2025, 4, 3, 2, 0, 0, 0, berlin),
+			want:     time.Date(2025, 4, 3, 6, 0, 0, 0, berlin),
+		},
+		{
+			name:     "should postpone until end of quiet hours within a day",
+			settings: &entities.NotificationSettings{QuietHoursStart: "12:00", QuietHoursEnd: "14:00"},
+			now:      time.Date(2025, 4, 2, 13, 0, 0, 0, berlin),
+			want:     time.Date(2025, 4, 2, 14, 0, 0, 0, berlin),
+		},
+		{
+			name:     "should postpone until next digest",
+			settings: &entities.NotificationSettings{Digest: true, DigestTime: "07:00"},
+			now:      time.Date(2025, 4, 2, 8, 0, 0, 0, berlin),
+			want:     time.Date(2025, 4, 3, 7, 0, 0, 0, berlin),
+		},
+		{
+			name:     "should use digest time in time zone of notifications",
+			settings: &entities.NotificationSettings{Digest: true, DigestTime: "07:00"},
+			now:      time.Date(2025, 4, 2, 3, 0, 0, 0, time.UTC),
+			want:     time.Date(2025, 4, 2, 7, 0, 0, 0, berlin),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := svc.sendAfter(tt.settings, tt.now)
+
+			// then
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+const timeOfDayLayout = "15:04"
+
+type NotificationService struct {
+	notificationRepo storage.NotificationRepository
+	userRepo         storage.UserRepository
+	mailSender       storage.MailSender
+	pushSender       storage.PushSender
+	location         *time.Location
+	appURL           string
+	validator        *validator.Validate
+	now              func() time.Time
+}
+
+var _ service.NotificationService = (*NotificationService)(nil)
+
+func NewNotificationService(
+	notificationRepo storage.NotificationRepository,
+	userRepo storage.UserRepository,
+	mailSender storage.MailSender,
+	pushSender storage.PushSender,
+	cfg config.NotificationConfig,
+	appURL string,
+) *NotificationService {
+	location, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		slog.Warn("unknown notification time zone, fall back to utc", "error", err, "time_zone", cfg.TimeZone)
+		location = time.UTC
+	}
+
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		mailSender:       mailSender,
+		pushSender:       pushSender,
+		location:         location,
+		appURL:           appURL,
+		validator:        validator.New(),
+		now:              time.Now,
+	}
+}
+
+func (s *NotificationService) GetAll(ctx context.Context, userID uuid.UUID, query entities.NotificationQuery) ([]*entities.Notification, int64, error) {
+	log := logger.GetLogger(ctx)
+	notifications, totalCount, err := s.notificationRepo.GetByUserID(ctx, userID, query)
+	if err != nil {
+		log.Debug("failed to fetch notifications", "error", err, "user_id", userID)
+		return nil, 0, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return notifications, totalCount, nil
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	log := logger.GetLogger(ctx)
+	count, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		log.Debug("failed to count unread notifications", "error", err, "user_id", userID)
+		return 0, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return count, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID uuid.UUID, id int32) error {
+	log := logger.GetLogger(ctx)
+	if err := s.notificationRepo.MarkRead(ctx, userID, id, s.now()); err != nil {
+		log.Debug("failed to mark notification as read", "error", err, "notification_id", id, "user_id", userID)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	log := logger.GetLogger(ctx)
+	if err := s.notificationRepo.MarkAllRead(ctx, userID, s.now()); err != nil {
+		log.Debug("failed to mark all notifications as read", "error", err, "user_id", userID)
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return nil
+}
+
+func (s *NotificationService) GetSettings(ctx context.Context, userID uuid.UUID) (*entities.NotificationSettings, error) {
+	log := logger.GetLogger(ctx)
+	settings, err := s.getSettings(ctx, userID)
+	if err != nil {
+		log.Debug("failed to fetch notification settings", "error", err, "user_id", userID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return settings, nil
+}
+
+func (s *NotificationService) UpdateSettings(ctx context.Context, userID uuid.UUID, updateData *entities.NotificationSettingsUpdate) (*entities.NotificationSettings, error) {
+	log := logger.GetLogger(ctx)
+	if !validSettings(updateData) {
+		log.Debug("notification settings are invalid", "raw_settings", fmt.Sprintf("%+v", updateData))
+		return nil, service.ErrNotificationSettings
+	}
+
+	digestTime := updateData.DigestTime
+	if digestTime == "" {
+		digestTime = entities.DefaultNotificationSettings(userID).DigestTime
+	}
+
+	channels := make(map[entities.NotificationType][]entities.NotificationChannel, len(entities.NotificationTypes))
+	for _, notificationType := range entities.NotificationTypes {
+		channels[notificationType] = []entities.NotificationChannel{}
+	}
+	for notificationType, c := range updateData.Channels {
+		channels[notificationType] = uniqueChannels(c)
+	}
+
+	settings := &entities.NotificationSettings{
+		UserID:          userID,
+		Channels:        channels,
+		QuietHoursStart: updateData.QuietHoursStart,
+		QuietHoursEnd:   updateData.QuietHoursEnd,
+		Digest:          updateData.Digest,
+		DigestTime:      digestTime,
+	}
+	if err := s.notificationRepo.SaveSettings(ctx, settings); err != nil {
+		log.Debug("failed to save notification settings", "error", err, "user_id", userID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("notification settings updated successfully", "user_id", userID)
+	return s.GetSettings(ctx, userID)
+}
+
+func (s *NotificationService) GetPushPublicKey(ctx context.Context) (string, error) {
+	log := logger.GetLogger(ctx)
+	key := s.pushSender.PublicKey()
+	if key == "" {
+		log.Debug("push messages are disabled, there is no public key")
+		return "", service.MapError(ctx, storage.ErrPushServiceDisabled, service.ErrorLogAll)
+	}
+
+	return key, nil
+}
+
+func (s *NotificationService) SubscribePush(ctx context.Context, userID uuid.UUID, createData *entities.PushSubscriptionCreate) (*entities.PushSubscription, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate struct from create push subscription", "error", err)
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	created, err := s.notificationRepo.SavePushSubscription(ctx, &entities.PushSubscription{
+		UserID:   userID,
+		Endpoint: createData.Endpoint,
+		P256dh:   createData.P256dh,
+		Auth:     createData.Auth,
+	})
+	if err != nil {
+		log.Debug("failed to save push subscription", "error", err, "user_id", userID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("push subscription created successfully", "push_subscription_id", created.ID, "user_id", userID)
+	return created, nil
+}
+
+func (s *NotificationService) UnsubscribePush(ctx context.Context, userID uuid.UUID, endpoint string) error {
+	log := logger.GetLogger(ctx)
+	subscriptions, err := s.notificationRepo.GetPushSubscriptions(ctx, userID)
+	if err != nil {
+		log.Debug("failed to fetch push subscriptions", "error", err, "user_id", userID)
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	// the endpoint is only deleted if it belongs to the user
+	if !slices.ContainsFunc(subscriptions, func(sub *entities.PushSubscription) bool { return sub.Endpoint == endpoint }) {
+		log.Debug("push subscription of user not found", "user_id", userID)
+		return service.MapError(ctx, storage.ErrEntityNotFound("push subscription"), service.ErrorLogEntityNotFound)
+	}
+
+	if err := s.notificationRepo.DeletePushSubscription(ctx, endpoint); err != nil {
+		log.Debug("failed to delete push subscription", "error", err, "user_id", userID)
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("push subscription deleted successfully", "user_id", userID)
+	return nil
+}
+
+func (s *NotificationService) Ready() bool {
+	return s.notificationRepo != nil && s.userRepo != nil && s.mailSender != nil && s.pushSender != nil
+}
+
+// getSettings returns the stored settings of the user or the default settings
+func (s *NotificationService) getSettings(ctx context.Context, userID uuid.UUID) (*entities.NotificationSettings, error) {
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if errors.As(err, &entityNotFoundErr) {
+			return entities.DefaultNotificationSettings(userID), nil
+		}
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func validSettings(settings *entities.NotificationSettingsUpdate) bool {
+	for notificationType, channels := range settings.Channels {
+		if !slices.Contains(entities.NotificationTypes, notificationType) {
+			return false
+		}
+		for _, channel := range channels {
+			if !slices.Contains(entities.NotificationChannels, channel) {
+				return false
+			}
+		}
+	}
+
+	// quiet hours are either disabled or have a start and an end
+	if (settings.QuietHoursStart == "") != (settings.QuietHoursEnd == "") {
+		return false
+	}
+
+	for _, value := range []string{settings.QuietHoursStart, settings.QuietHoursEnd, settings.DigestTime} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(timeOfDayLayout, value); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func uniqueChannels(channels []entities.NotificationChannel) []entities.NotificationChannel {
+	unique := make([]entities.NotificationChannel, 0, len(channels))
+	for _, channel := range channels {
+		if !slices.Contains(unique, channel) {
+			unique = append(unique, channel)
+		}
+	}
+	return unique
+}
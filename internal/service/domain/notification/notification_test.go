@@ -0,0 +1,322 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testUserID = uuid.MustParse("6be4c752-4d25-4a5e-9b23-9c2a2d1fa0c1")
+	testNow    = time.Date(2025, 4, 2, 12, 0, 0, 0, time.UTC)
+)
+
+type testMocks struct {
+	notificationRepo *storageMock.MockNotificationRepository
+	userRepo         *storageMock.MockUserRepository
+	mailSender       *storageMock.MockMailSender
+	pushSender       *storageMock.MockPushSender
+}
+
+func newTestService(t *testing.T) (*NotificationService, testMocks) {
+	t.Helper()
+	m := testMocks{
+		notificationRepo: storageMock.NewMockNotificationRepository(t),
+		userRepo:         storageMock.NewMockUserRepository(t),
+		mailSender:       storageMock.NewMockMailSender(t),
+		pushSender:       storageMock.NewMockPushSender(t),
+	}
+	svc := NewNotificationService(m.notificationRepo, m.userRepo, m.mailSender, m.pushSender, config.NotificationConfig{TimeZone: "Europe/Berlin"}, "https://app.example.com")
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+func assertServiceErrorCode(t *testing.T, err error, code service.ErrorCode) {
+	t.Helper()
+	var svcErr service.Error
+	assert.ErrorAs(t, err, &svcErr)
+	assert.Equal(t, code, svcErr.Code)
+}
+
+func TestNewNotificationService(t *testing.T) {
+	t.Run("should fall back to utc for unknown time zone", func(t *testing.T) {
+		// when
+		svc := NewNotificationService(nil, nil, nil, nil, config.NotificationConfig{TimeZone: "Mars/Olympus"}, "")
+
+		// then
+		assert.Equal(t, time.UTC, svc.location)
+	})
+}
+
+func TestNotificationService_GetAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return notifications of user", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		query := entities.NotificationQuery{Unread: true}
+		expected := []*entities.Notification{{ID: 1, UserID: testUserID}}
+		m.notificationRepo.EXPECT().GetByUserID(ctx, testUserID, query).Return(expected, int64(1), nil)
+
+		// when
+		got, totalCount, err := svc.GetAll(ctx, testUserID, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+		assert.Equal(t, int64(1), totalCount)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.notificationRepo.EXPECT().GetByUserID(ctx, testUserID, entities.NotificationQuery{}).Return(nil, int64(0), errors.New("database error"))
+
+		// when
+		got, _, err := svc.GetAll(ctx, testUserID, entities.NotificationQuery{})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should mark notification as read", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.notificationRepo.EXPECT().MarkRead(ctx, testUserID, int32(1), testNow).Return(nil)
+
+		// when
+		err := svc.MarkRead(ctx, testUserID, 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found for notification of other user", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.notificationRepo.EXPECT().MarkRead(ctx, testUserID, int32(1), testNow).Return(storage.ErrEntityNotFound("Notification"))
+
+		// when
+		err := svc.MarkRead(ctx, testUserID, 1)
+
+		// then
+		assertServiceErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestNotificationService_GetSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return stored settings", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		expected := &entities.NotificationSettings{UserID: testUserID, Digest: true, DigestTime: "06:30"}
+		m.notificationRepo.EXPECT().GetSettings(ctx, testUserID).Return(expected, nil)
+
+		// when
+		got, err := svc.GetSettings(ctx, testUserID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return default settings if user has none", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.notificationRepo.EXPECT().GetSettings(ctx, testUserID).Return(nil, storage.ErrEntityNotFound("NotificationSetting"))
+
+		// when
+		got, err := svc.GetSettings(ctx, testUserID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.DefaultNotificationSettings(testUserID), got)
+	})
+}
+
+func TestNotificationService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should save settings with all types", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		update := &entities.NotificationSettingsUpdate{
+			Channels: map[entities.NotificationType][]entities.NotificationChannel{
+				entities.NotificationTypeSensorOffline: {entities.NotificationChannelPush, entities.NotificationChannelPush},
+			},
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "06:00",
+		}
+
+		var saved *entities.NotificationSettings
+		m.notificationRepo.EXPECT().SaveSettings(ctx, mock.Anything).RunAndReturn(func(_ context.Context, s *entities.NotificationSettings) error {
+			saved = s
+			return nil
+		})
+		m.notificationRepo.EXPECT().GetSettings(ctx, testUserID).RunAndReturn(func(context.Context, uuid.UUID) (*entities.NotificationSettings, error) {
+			return saved, nil
+		})
+
+		// when
+		got, err := svc.UpdateSettings(ctx, testUserID, update)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, got.UserID)
+		assert.Equal(t, "07:00", got.DigestTime)
+		assert.Equal(t, []entities.NotificationChannel{entities.NotificationChannelPush}, got.Channels[entities.NotificationTypeSensorOffline])
+		assert.Empty(t, got.Channels[entities.NotificationTypeTreeClusterBad])
+		assert.Len(t, got.Channels, len(entities.NotificationTypes))
+	})
+
+	t.Run("should return error for invalid settings", func(t *testing.T) {
+		tests := map[string]*entities.NotificationSettingsUpdate{
+			"unknown type": {Channels: map[entities.NotificationType][]entities.NotificationChannel{
+				"unknown": {entities.NotificationChannelInApp},
+			}},
+			"unknown channel": {Channels: map[entities.NotificationType][]entities.NotificationChannel{
+				entities.NotificationTypeSensorOffline: {"sms"},
+			}},
+			"quiet hours without end": {QuietHoursStart: "22:00"},
+			"invalid time":            {QuietHoursStart: "25:00", QuietHoursEnd: "06:00"},
+			"invalid digest time":     {Digest: true, DigestTime: "7 am"},
+		}
+
+		for name, update := range tests {
+			t.Run(name, func(t *testing.T) {
+				// given
+				svc, _ := newTestService(t)
+
+				// when
+				got, err := svc.UpdateSettings(ctx, testUserID, update)
+
+				// then
+				assert.ErrorIs(t, err, service.ErrNotificationSettings)
+				assert.Nil(t, got)
+			})
+		}
+	})
+}
+
+func TestNotificationService_GetPushPublicKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return public key", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.pushSender.EXPECT().PublicKey().Return("public-key")
+
+		// when
+		got, err := svc.GetPushPublicKey(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "public-key", got)
+	})
+
+	t.Run("should return gone if push is disabled", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.pushSender.EXPECT().PublicKey().Return("")
+
+		// when
+		_, err := svc.GetPushPublicKey(ctx)
+
+		// then
+		assertServiceErrorCode(t, err, service.Gone)
+	})
+}
+
+func TestNotificationService_SubscribePush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should save push subscription of user", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		createData := &entities.PushSubscriptionCreate{Endpoint: "https://push.example.com/1", P256dh: "key", Auth: "auth"}
+		expected := &entities.PushSubscription{ID: 1, UserID: testUserID, Endpoint: createData.Endpoint, P256dh: "key", Auth: "auth"}
+		m.notificationRepo.EXPECT().SavePushSubscription(ctx, &entities.PushSubscription{
+			UserID:   testUserID,
+			Endpoint: createData.Endpoint,
+			P256dh:   "key",
+			Auth:     "auth",
+		}).Return(expected, nil)
+
+		// when
+		got, err := svc.SubscribePush(ctx, testUserID, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("should return validation error for invalid endpoint", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		got, err := svc.SubscribePush(ctx, testUserID, &entities.PushSubscriptionCreate{Endpoint: "not a url", P256dh: "key", Auth: "auth"})
+
+		// then
+		assertServiceErrorCode(t, err, service.BadRequest)
+		assert.Nil(t, got)
+	})
+}
+
+func TestNotificationService_UnsubscribePush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete push subscription of user", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		endpoint := "https://push.example.com/1"
+		m.notificationRepo.EXPECT().GetPushSubscriptions(ctx, testUserID).Return([]*entities.PushSubscription{{Endpoint: endpoint}}, nil)
+		m.notificationRepo.EXPECT().DeletePushSubscription(ctx, endpoint).Return(nil)
+
+		// when
+		err := svc.UnsubscribePush(ctx, testUserID, endpoint)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found for endpoint of other user", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.notificationRepo.EXPECT().GetPushSubscriptions(ctx, testUserID).Return([]*entities.PushSubscription{{Endpoint: "https://push.example.com/1"}}, nil)
+
+		// when
+		err := svc.UnsubscribePush(ctx, testUserID, "https://push.example.com/2")
+
+		// then
+		assertServiceErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestNotificationService_Ready(t *testing.T) {
+	t.Run("should return true if all dependencies are set", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false if a dependency is missing", func(t *testing.T) {
+		svc := NewNotificationService(nil, nil, nil, nil, config.NotificationConfig{}, "")
+		assert.False(t, svc.Ready())
+	})
+}
@@ -0,0 +1,211 @@
+package ogc
+
+import (
+	"context"
+	"strconv"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+const (
+	defaultLimit int32 = 10
+	maxLimit     int32 = 10000
+)
+
+var collections = []domain.OGCCollection{
+	{
+		ID:          domain.OGCCollectionTrees,
+		Title:       "Trees",
+		Description: "Trees with their species, planting year and watering status",
+		Queryables: []domain.OGCQueryable{
+			{Name: "number", Type: domain.OGCQueryableTypeString},
+			{Name: "species", Type: domain.OGCQueryableTypeString},
+			{Name: "planting_year", Type: domain.OGCQueryableTypeInteger},
+			{Name: "watering_status", Type: domain.OGCQueryableTypeString},
+			{Name: "provider", Type: domain.OGCQueryableTypeString},
+			{Name: "tree_cluster_id", Type: domain.OGCQueryableTypeInteger},
+			{Name: "sensor_id", Type: domain.OGCQueryableTypeString},
+		},
+	},
+	{
+		ID:          domain.OGCCollectionTreeClusters,
+		Title:       "Tree clusters",
+		Description: "Tree cluster areas with their region, soil condition and watering status",
+		Queryables: []domain.OGCQueryable{
+			{Name: "name", Type: domain.OGCQueryableTypeString},
+			{Name: "region", Type: domain.OGCQueryableTypeString},
+			{Name: "watering_status", Type: domain.OGCQueryableTypeString},
+			{Name: "soil_condition", Type: domain.OGCQueryableTypeString},
+			{Name: "archived", Type: domain.OGCQueryableTypeBoolean},
+			{Name: "provider", Type: domain.OGCQueryableTypeString},
+		},
+	},
+	{
+		ID:          domain.OGCCollectionSensors,
+		Title:       "Sensors",
+		Description: "Sensors with their status and latest measurements",
+		Queryables: []domain.OGCQueryable{
+			{Name: "status", Type: domain.OGCQueryableTypeString},
+			{Name: "provider", Type: domain.OGCQueryableTypeString},
+		},
+	},
+	{
+		ID:          domain.OGCCollectionRegions,
+		Title:       "Regions",
+		Description: "Administrative regions the tree clusters are located in",
+		Queryables: []domain.OGCQueryable{
+			{Name: "name", Type: domain.OGCQueryableTypeString},
+		},
+	},
+}
+
+type OGCService struct {
+	featureRepo storage.FeatureRepository
+}
+
+var _ service.OGCService = (*OGCService)(nil)
+
+func NewOGCService(featureRepo storage.FeatureRepository) *OGCService {
+	return &OGCService{
+		featureRepo: featureRepo,
+	}
+}
+
+func (s *OGCService) GetCollections(ctx context.Context) ([]*domain.OGCCollection, error) {
+	result := make([]*domain.OGCCollection, 0, len(collections))
+	for _, c := range collections {
+		collection, err := s.GetCollection(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, collection)
+	}
+
+	return result, nil
+}
+
+func (s *OGCService) GetCollection(ctx context.Context, id domain.OGCCollectionID) (*domain.OGCCollection, error) {
+	log := logger.GetLogger(ctx)
+	collection, err := findCollection(id)
+	if err != nil {
+		return nil, err
+	}
+
+	extent, err := s.featureRepo.GetExtent(ctx, id)
+	if err != nil {
+		log.Debug("failed to get extent of collection", "error", err, "collection", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+	collection.Extent = extent
+
+	return collection, nil
+}
+
+func (s *OGCService) GetFeatures(ctx context.Context, id domain.OGCCollectionID, query *domain.OGCFeatureQuery) ([]*domain.OGCFeature, int64, error) {
+	log := logger.GetLogger(ctx)
+	collection, err := findCollection(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	properties, err := convertProperties(collection.Queryables, query.Properties)
+	if err != nil {
+		log.Debug("failed to convert property filters", "error", err, "collection", id)
+		return nil, 0, err
+	}
+
+	q := *query
+	q.Properties = properties
+	switch {
+	case q.Limit <= 0:
+		q.Limit = defaultLimit
+	case q.Limit > maxLimit:
+		q.Limit = maxLimit
+	}
+	q.Offset = max(q.Offset, 0)
+
+	features, totalCount, err := s.featureRepo.GetAll(ctx, id, &q)
+	if err != nil {
+		log.Debug("failed to get features of collection", "error", err, "collection", id)
+		return nil, 0, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return features, totalCount, nil
+}
+
+func (s *OGCService) GetFeature(ctx context.Context, id domain.OGCCollectionID, featureID string) (*domain.OGCFeature, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := findCollection(id); err != nil {
+		return nil, err
+	}
+
+	feature, err := s.featureRepo.GetByID(ctx, id, featureID)
+	if err != nil {
+		log.Debug("failed to get feature by id", "error", err, "collection", id, "feature_id", featureID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return feature, nil
+}
+
+func (s *OGCService) Ready() bool {
+	return s.featureRepo != nil
+}
+
+// findCollection returns a copy of the collection metadata
+func findCollection(id domain.OGCCollectionID) (*domain.OGCCollection, error) {
+	for _, c := range collections {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+
+	return nil, service.ErrOGCCollectionNotFound
+}
+
+// convertProperties converts the values of the property filters to the type of the queryables.
+// Values that are not strings are expected to have the right type already.
+func convertProperties(queryables []domain.OGCQueryable, properties map[string]any) (map[string]any, error) {
+	if len(properties) == 0 {
+		return nil, nil
+	}
+
+	types := make(map[string]domain.OGCQueryableType, len(queryables))
+	for _, q := range queryables {
+		types[q.Name] = q.Type
+	}
+
+	result := make(map[string]any, len(properties))
+	for name, value := range properties {
+		t, ok := types[name]
+		if !ok {
+			return nil, service.ErrOGCPropertyUnknown
+		}
+
+		str, ok := value.(string)
+		if !ok {
+			result[name] = value
+			continue
+		}
+
+		var err error
+		switch t {
+		case domain.OGCQueryableTypeInteger:
+			result[name], err = strconv.ParseInt(str, 10, 64)
+		case domain.OGCQueryableTypeNumber:
+			result[name], err = strconv.ParseFloat(str, 64)
+		case domain.OGCQueryableTypeBoolean:
+			result[name], err = strconv.ParseBool(str)
+		default:
+			result[name] = str
+		}
+		if err != nil {
+			return nil, service.ErrOGCPropertyInvalid
+		}
+	}
+
+	return result, nil
+}
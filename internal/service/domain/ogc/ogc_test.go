@@ -0,0 +1,271 @@
+package ogc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	updatedAt = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	testExtent = &entities.OGCExtent{
+		BBox:     &entities.BoundingBox{MinLon: 9.4, MinLat: 54.7, MaxLon: 9.5, MaxLat: 54.9},
+		Interval: &entities.TimeInterval{Start: &updatedAt, End: &updatedAt},
+	}
+
+	testFeatures = []*entities.OGCFeature{
+		{
+			ID:         "1",
+			Geometry:   []byte(`{"type":"Point","coordinates":[9.48,54.82]}`),
+			UpdatedAt:  updatedAt,
+			Properties: map[string]any{"species": "Quercus robur", "planting_year": float64(2010)},
+		},
+	}
+)
+
+func TestOGCService_GetCollections(t *testing.T) {
+	t.Run("should return all collections with extent", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetExtent(context.Background(), mock.Anything).Return(testExtent, nil)
+
+		// when
+		got, err := svc.GetCollections(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 4)
+		assert.Equal(t, entities.OGCCollectionTrees, got[0].ID)
+		assert.Equal(t, entities.OGCCollectionRegions, got[3].ID)
+		assert.Equal(t, testExtent, got[0].Extent)
+		assert.NotEmpty(t, got[0].Queryables)
+	})
+
+	t.Run("should return error when extent can not be read", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetExtent(context.Background(), entities.OGCCollectionTrees).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.GetCollections(context.Background())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestOGCService_GetCollection(t *testing.T) {
+	t.Run("should return collection with extent", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetExtent(context.Background(), entities.OGCCollectionSensors).Return(testExtent, nil)
+
+		// when
+		got, err := svc.GetCollection(context.Background(), entities.OGCCollectionSensors)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.OGCCollectionSensors, got.ID)
+		assert.Equal(t, "Sensors", got.Title)
+		assert.Equal(t, testExtent, got.Extent)
+	})
+
+	t.Run("should not modify collection metadata", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetExtent(context.Background(), entities.OGCCollectionSensors).Return(testExtent, nil)
+
+		// when
+		_, err := svc.GetCollection(context.Background(), entities.OGCCollectionSensors)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, collections[2].Extent)
+	})
+
+	t.Run("should return error when collection is unknown", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+
+		// when
+		got, err := svc.GetCollection(context.Background(), "vehicles")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrOGCCollectionNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+func TestOGCService_GetFeatures(t *testing.T) {
+	t.Run("should return features with converted property filters", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		query := &entities.OGCFeatureQuery{
+			BBox:       &entities.BoundingBox{MinLon: 9.4, MinLat: 54.7, MaxLon: 9.5, MaxLat: 54.9},
+			Properties: map[string]any{"species": "Quercus robur", "planting_year": "2010"},
+			Limit:      5,
+			Offset:     10,
+		}
+		expected := &entities.OGCFeatureQuery{
+			BBox:       query.BBox,
+			Properties: map[string]any{"species": "Quercus robur", "planting_year": int64(2010)},
+			Limit:      5,
+			Offset:     10,
+		}
+		repo.EXPECT().GetAll(context.Background(), entities.OGCCollectionTrees, expected).Return(testFeatures, 11, nil)
+
+		// when
+		got, totalCount, err := svc.GetFeatures(context.Background(), entities.OGCCollectionTrees, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testFeatures, got)
+		assert.Equal(t, int64(11), totalCount)
+	})
+
+	t.Run("should convert boolean property filters", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		expected := &entities.OGCFeatureQuery{Properties: map[string]any{"archived": false}, Limit: defaultLimit}
+		repo.EXPECT().GetAll(context.Background(), entities.OGCCollectionTreeClusters, expected).Return(nil, 0, nil)
+
+		// when
+		_, _, err := svc.GetFeatures(context.Background(), entities.OGCCollectionTreeClusters,
+			&entities.OGCFeatureQuery{Properties: map[string]any{"archived": "false"}})
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should use default limit and clamp limit to maximum", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetAll(context.Background(), entities.OGCCollectionRegions, &entities.OGCFeatureQuery{Limit: defaultLimit}).Return(nil, 0, nil)
+		repo.EXPECT().GetAll(context.Background(), entities.OGCCollectionRegions, &entities.OGCFeatureQuery{Limit: maxLimit}).Return(nil, 0, nil)
+
+		// when
+		_, _, errDefault := svc.GetFeatures(context.Background(), entities.OGCCollectionRegions, &entities.OGCFeatureQuery{})
+		_, _, errMax := svc.GetFeatures(context.Background(), entities.OGCCollectionRegions, &entities.OGCFeatureQuery{Limit: 50000, Offset: -1})
+
+		// then
+		assert.NoError(t, errDefault)
+		assert.NoError(t, errMax)
+	})
+
+	t.Run("should return error when property is not queryable", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+
+		// when
+		got, _, err := svc.GetFeatures(context.Background(), entities.OGCCollectionSensors,
+			&entities.OGCFeatureQuery{Properties: map[string]any{"species": "Quercus robur"}})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrOGCPropertyUnknown)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when property value has wrong type", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+
+		// when
+		got, _, err := svc.GetFeatures(context.Background(), entities.OGCCollectionTrees,
+			&entities.OGCFeatureQuery{Properties: map[string]any{"planting_year": "last year"}})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrOGCPropertyInvalid)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when collection is unknown", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+
+		// when
+		got, _, err := svc.GetFeatures(context.Background(), "vehicles", &entities.OGCFeatureQuery{})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrOGCCollectionNotFound)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetAll(context.Background(), entities.OGCCollectionTrees, mock.Anything).Return(nil, 0, errors.New("internal error"))
+
+		// when
+		got, _, err := svc.GetFeatures(context.Background(), entities.OGCCollectionTrees, &entities.OGCFeatureQuery{})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestOGCService_GetFeature(t *testing.T) {
+	t.Run("should return feature by id", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetByID(context.Background(), entities.OGCCollectionTrees, "1").Return(testFeatures[0], nil)
+
+		// when
+		got, err := svc.GetFeature(context.Background(), entities.OGCCollectionTrees, "1")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testFeatures[0], got)
+	})
+
+	t.Run("should return not found error when feature does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewOGCService(repo)
+		repo.EXPECT().GetByID(context.Background(), entities.OGCCollectionTrees, "42").Return(nil, storage.ErrEntityNotFound("ogc_features"))
+
+		// when
+		got, err := svc.GetFeature(context.Background(), entities.OGCCollectionTrees, "42")
+
+		// then
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+		assert.Nil(t, got)
+	})
+}
+
+func TestOGCService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		svc := NewOGCService(storageMock.NewMockFeatureRepository(t))
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false if the service is not ready", func(t *testing.T) {
+		svc := NewOGCService(nil)
+		assert.False(t, svc.Ready())
+	})
+}
@@ -0,0 +1,419 @@
+package planner
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var _ service.PlannerService = (*PlannerService)(nil)
+
+type PlannerService struct {
+	treeClusterRepo     storage.TreeClusterRepository
+	vehicleRepo         storage.VehicleRepository
+	userRepo            storage.UserRepository
+	wateringPlanRepo    storage.WateringPlanRepository
+	routingRepo         storage.RoutingRepository
+	weatherService      service.WeatherService
+	wateringPlanService service.WateringPlanService
+	cfg                 config.PlannerConfig
+	now                 func() time.Time
+}
+
+func NewPlannerService(
+	treeClusterRepo storage.TreeClusterRepository,
+	vehicleRepo storage.VehicleRepository,
+	userRepo storage.UserRepository,
+	wateringPlanRepo storage.WateringPlanRepository,
+	routingRepo storage.RoutingRepository,
+	weatherService service.WeatherService,
+	wateringPlanService service.WateringPlanService,
+	cfg config.PlannerConfig,
+) *PlannerService {
+	return &PlannerService{
+		treeClusterRepo:     treeClusterRepo,
+		vehicleRepo:         vehicleRepo,
+		userRepo:            userRepo,
+		wateringPlanRepo:    wateringPlanRepo,
+		routingRepo:         routingRepo,
+		weatherService:      weatherService,
+		wateringPlanService: wateringPlanService,
+		cfg:                 cfg,
+		now:                 time.Now,
+	}
+}
+
+// schedule holds the tree clusters, vehicles and users that are already part of planned or active watering plans
+type schedule struct {
+	treeClusters map[int32]struct{}
+	vehicles     map[string]map[int32]struct{}
+	users        map[string]map[uuid.UUID]struct{}
+}
+
+// GetProposals fills a watering plan for every usable transporter and crew on each of the upcoming days with the most
+// urgent tree clusters, preferring tree clusters of the same region. A proposal is shortened until its route fits
+// into the maximum duration. Without the route optimizer the proposals have no route.
+func (s *PlannerService) GetProposals(ctx context.Context, query domain.WateringPlanProposalQuery) ([]*domain.WateringPlanProposal, error) {
+	log := logger.GetLogger(ctx)
+	days := query.Days
+	if days == 0 {
+		days = s.cfg.HorizonDays
+	}
+	if days < 1 || days > s.cfg.HorizonDays {
+		log.Debug("requested days of watering plan proposals are out of the planning horizon", "days", query.Days, "horizon_days", s.cfg.HorizonDays)
+		return nil, service.ErrProposalQueryInvalid
+	}
+
+	sched, err := s.schedule(ctx)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	queue, err := s.rank(ctx, sched)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	transporters, _, err := s.vehicleRepo.GetAllByType(ctx, "", domain.VehicleTypeTransporter)
+	if err != nil {
+		log.Debug("failed to fetch transporters", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+	transporters = slices.DeleteFunc(transporters, func(v *domain.Vehicle) bool {
+		return (v.Status != domain.VehicleStatusAvailable && v.Status != domain.VehicleStatusActive) || v.WaterCapacity <= 0
+	})
+	slices.SortStableFunc(transporters, func(a, b *domain.Vehicle) int {
+		return cmp.Compare(b.WaterCapacity, a.WaterCapacity)
+	})
+
+	users, err := s.userRepo.GetAllByRole(ctx, domain.UserRoleTbz)
+	if err != nil {
+		log.Debug("failed to fetch users", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+	users = slices.DeleteFunc(users, func(u *domain.User) bool {
+		return u.Status != domain.UserStatusAvailable
+	})
+
+	proposals := make([]*domain.WateringPlanProposal, 0)
+	tomorrow := utils.ToDate(s.now().Local()).AddDate(0, 0, 1)
+	for day := range days {
+		date := tomorrow.AddDate(0, 0, day)
+		key := dateKey(date)
+		crew := slices.DeleteFunc(slices.Clone(users), func(u *domain.User) bool {
+			_, busy := sched.users[key][u.ID]
+			return busy
+		})
+
+		for _, transporter := range transporters {
+			if len(queue) == 0 {
+				break
+			}
+			if _, busy := sched.vehicles[key][transporter.ID]; busy {
+				continue
+			}
+
+			members, rest := selectCrew(transporter, crew, s.cfg.CrewSize)
+			if len(members) == 0 {
+				log.Debug("no available user with the driving license of the transporter", "transporter_id", transporter.ID, "date", key)
+				continue
+			}
+
+			picked, route := s.fit(ctx, transporter, s.pick(queue))
+			if len(picked) == 0 {
+				continue
+			}
+			crew = rest
+			queue = slices.DeleteFunc(queue, func(u *domain.TreeClusterUrgency) bool {
+				return slices.Contains(picked, u)
+			})
+			proposals = append(proposals, newProposal(date, transporter, members, picked, route))
+		}
+	}
+
+	log.Debug("watering plans proposed", "proposals", len(proposals), "days", days, "unplanned_tree_clusters", len(queue))
+	return proposals, nil
+}
+
+// AcceptProposals creates the watering plans of the accepted proposals one after another. The proposals are checked
+// for conflicts with each other and with the planned and active watering plans first. If a watering plan can not be
+// created, the watering plans created before are kept and returned together with the error.
+func (s *PlannerService) AcceptProposals(ctx context.Context, plans []*domain.WateringPlanCreate) ([]*domain.WateringPlan, error) {
+	log := logger.GetLogger(ctx)
+	if len(plans) == 0 {
+		log.Debug("no watering plan proposals to accept")
+		return nil, service.MapError(ctx, errors.Join(errors.New("at least one proposal is required"), service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	sched, err := s.schedule(ctx)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	if err := checkConflicts(plans, sched); err != nil {
+		log.Debug("accepted watering plan proposals are in conflict", "error", err)
+		return nil, err
+	}
+
+	created := make([]*domain.WateringPlan, 0, len(plans))
+	for _, plan := range plans {
+		wp, err := s.wateringPlanService.Create(ctx, plan)
+		if err != nil {
+			log.Debug("failed to create watering plan of accepted proposal", "error", err, "created", len(created))
+			return created, err // err is already a service error
+		}
+		created = append(created, wp)
+	}
+
+	log.Info("watering plan proposals accepted", "watering_plans", len(created))
+	return created, nil
+}
+
+// schedule collects the tree clusters, vehicles and users of the planned and active watering plans from today on
+func (s *PlannerService) schedule(ctx context.Context) (*schedule, error) {
+	plans, _, err := s.wateringPlanRepo.GetAll(ctx, domain.Query{})
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to fetch watering plans", "error", err)
+		return nil, err
+	}
+
+	sched := &schedule{
+		treeClusters: make(map[int32]struct{}),
+		vehicles:     make(map[string]map[int32]struct{}),
+		users:        make(map[string]map[uuid.UUID]struct{}),
+	}
+	today := utils.ToDate(s.now().Local())
+	for _, wp := range plans {
+		if wp.Status != domain.WateringPlanStatusPlanned && wp.Status != domain.WateringPlanStatusActive {
+			continue
+		}
+		if wp.Date.Before(today) {
+			continue
+		}
+
+		key := dateKey(wp.Date)
+		for _, tc := range wp.TreeClusters {
+			sched.treeClusters[tc.ID] = struct{}{}
+		}
+		if sched.vehicles[key] == nil {
+			sched.vehicles[key] = make(map[int32]struct{})
+			sched.users[key] = make(map[uuid.UUID]struct{})
+		}
+		for _, v := range []*domain.Vehicle{wp.Transporter, wp.Trailer} {
+			if v != nil {
+				sched.vehicles[key][v.ID] = struct{}{}
+			}
+		}
+		for _, id := range wp.UserIDs {
+			if id != nil {
+				sched.users[key][*id] = struct{}{}
+			}
+		}
+	}
+	return sched, nil
+}
+
+// rank scores the tree clusters with trees that are not archived or planned and returns the ones that reach the
+// minimum score, the most urgent first
+func (s *PlannerService) rank(ctx context.Context, sched *schedule) ([]*domain.TreeClusterUrgency, error) {
+	log := logger.GetLogger(ctx)
+	clusters, _, err := s.treeClusterRepo.GetAll(ctx, domain.TreeClusterQuery{})
+	if err != nil {
+		log.Debug("failed to fetch tree clusters", "error", err)
+		return nil, err
+	}
+
+	now := s.now()
+	ranked := make([]*domain.TreeClusterUrgency, 0, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.Archived || len(cluster.Trees) == 0 {
+			continue
+		}
+		if _, planned := sched.treeClusters[cluster.ID]; planned {
+			continue
+		}
+
+		balance, err := s.weatherService.GetWaterBalance(ctx, cluster.ID)
+		if err != nil {
+			log.Debug("failed to get water balance of tree cluster, rank without forecast", "error", err, "cluster_id", cluster.ID)
+			balance = nil
+		}
+
+		score := urgency(cluster, balance, s.cfg.Weights, s.cfg.HorizonDays, now)
+		if score < s.cfg.MinScore {
+			continue
+		}
+
+		u := &domain.TreeClusterUrgency{TreeCluster: cluster, Score: score}
+		if balance != nil {
+			u.BadAt = balance.BadAt
+		}
+		ranked = append(ranked, u)
+	}
+
+	slices.SortStableFunc(ranked, func(a, b *domain.TreeClusterUrgency) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.TreeCluster.ID, b.TreeCluster.ID))
+	})
+	return ranked, nil
+}
+
+// pick starts with the most urgent tree cluster and adds the tree clusters of the same region before the others
+func (s *PlannerService) pick(queue []*domain.TreeClusterUrgency) []*domain.TreeClusterUrgency {
+	seed := queue[0]
+	rest := slices.Clone(queue[1:])
+	slices.SortStableFunc(rest, func(a, b *domain.TreeClusterUrgency) int {
+		return cmp.Compare(regionRank(seed, b), regionRank(seed, a))
+	})
+
+	limit := max(s.cfg.MaxClustersPerPlan, 1)
+	picked := append([]*domain.TreeClusterUrgency{seed}, rest[:min(len(rest), limit-1)]...)
+	slices.SortStableFunc(picked, func(a, b *domain.TreeClusterUrgency) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return picked
+}
+
+func regionRank(seed, u *domain.TreeClusterUrgency) int {
+	if seed.TreeCluster.Region != nil && u.TreeCluster.Region != nil && seed.TreeCluster.Region.ID == u.TreeCluster.Region.ID {
+		return 1
+	}
+	return 0
+}
+
+// fit drops the least urgent tree clusters until the route takes at most the maximum duration. If the route
+// can not be generated the tree clusters are kept without route.
+func (s *PlannerService) fit(ctx context.Context, transporter *domain.Vehicle, picked []*domain.TreeClusterUrgency) ([]*domain.TreeClusterUrgency, *domain.RouteMetadata) {
+	log := logger.GetLogger(ctx)
+	for len(picked) > 0 {
+		clusters := utils.Map(picked, func(u *domain.TreeClusterUrgency) *domain.TreeCluster {
+			return u.TreeCluster
+		})
+
+		route, err := s.routingRepo.GenerateRouteInformation(ctx, transporter, clusters)
+		if err != nil {
+			if !errors.Is(err, storage.ErrRoutingServiceDisabled) {
+				log.Warn("failed to generate route of watering plan proposal, propose without route", "error", err, "transporter_id", transporter.ID)
+			}
+			return picked, nil
+		}
+
+		if s.cfg.MaxDuration <= 0 || route.Time <= s.cfg.MaxDuration {
+			return picked, route
+		}
+		log.Debug("route of watering plan proposal takes too long, drop least urgent tree cluster", "duration", route.Time, "max_duration", s.cfg.MaxDuration)
+		picked = picked[:len(picked)-1]
+	}
+	return nil, nil
+}
+
+// selectCrew returns a driver with the driving license of the transporter and helpers up to the crew size
+// together with the remaining users. It returns no crew if no user can drive the transporter.
+func selectCrew(transporter *domain.Vehicle, users []*domain.User, size int) (crew, rest []*domain.User) {
+	driver := slices.IndexFunc(users, func(u *domain.User) bool {
+		return slices.Contains(u.DrivingLicenses, transporter.DrivingLicense)
+	})
+	if driver < 0 {
+		return nil, users
+	}
+
+	crew = []*domain.User{users[driver]}
+	for i, u := range users {
+		if i == driver {
+			continue
+		}
+		if len(crew) < size {
+			crew = append(crew, u)
+		} else {
+			rest = append(rest, u)
+		}
+	}
+	return crew, rest
+}
+
+func newProposal(date time.Time, transporter *domain.Vehicle, crew []*domain.User, picked []*domain.TreeClusterUrgency, route *domain.RouteMetadata) *domain.WateringPlanProposal {
+	return &domain.WateringPlanProposal{
+		Plan: domain.WateringPlanCreate{
+			Date: date,
+			TreeClusterIDs: utils.Map(picked, func(u *domain.TreeClusterUrgency) *int32 {
+				return &u.TreeCluster.ID
+			}),
+			TransporterID: &transporter.ID,
+			UserIDs: utils.Map(crew, func(u *domain.User) *uuid.UUID {
+				return &u.ID
+			}),
+		},
+		TreeClusters: picked,
+		Transporter:  transporter,
+		Score: utils.Reduce(picked, func(acc float64, u *domain.TreeClusterUrgency) float64 {
+			return acc + u.Score
+		}, 0),
+		Route: route,
+	}
+}
+
+// checkConflicts returns an error if a tree cluster is part of several plans or a vehicle or user of several plans
+// on the same day. The plans of the schedule are taken into account, the schedule is filled with the plans.
+func checkConflicts(plans []*domain.WateringPlanCreate, sched *schedule) error {
+	clusters := sched.treeClusters
+	vehicles := sched.vehicles
+	users := sched.users
+	for _, plan := range plans {
+		key := dateKey(plan.Date)
+		if vehicles[key] == nil {
+			vehicles[key] = make(map[int32]struct{})
+			users[key] = make(map[uuid.UUID]struct{})
+		}
+		for _, id := range plan.TreeClusterIDs {
+			if id == nil {
+				continue
+			}
+			if _, ok := clusters[*id]; ok {
+				return service.ErrProposalConflict
+			}
+			clusters[*id] = struct{}{}
+		}
+		for _, id := range []*int32{plan.TransporterID, plan.TrailerID} {
+			if id == nil {
+				continue
+			}
+			if _, ok := vehicles[key][*id]; ok {
+				return service.ErrProposalConflict
+			}
+			vehicles[key][*id] = struct{}{}
+		}
+		for _, id := range plan.UserIDs {
+			if id == nil {
+				continue
+			}
+			if _, ok := users[key][*id]; ok {
+				return service.ErrProposalConflict
+			}
+			users[key][*id] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func dateKey(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+func (s *PlannerService) Ready() bool {
+	return s.treeClusterRepo != nil &&
+		s.vehicleRepo != nil &&
+		s.userRepo != nil &&
+		s.wateringPlanRepo != nil &&
+		s.routingRepo != nil &&
+		s.weatherService != nil &&
+		s.wateringPlanService != nil
+}
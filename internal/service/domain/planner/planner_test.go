@@ -0,0 +1,376 @@
+package planner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var testConfig = config.PlannerConfig{
+	HorizonDays:        3,
+	MaxClustersPerPlan: 2,
+	MaxDuration:        8 * time.Hour,
+	MinScore:           0.4,
+	CrewSize:           2,
+	Weights:            config.PlannerWeights{WateringStatus: 1, LastWatered: 1, TreeAge: 1, Forecast: 1},
+}
+
+type testMocks struct {
+	treeClusterRepo     *storageMock.MockTreeClusterRepository
+	vehicleRepo         *storageMock.MockVehicleRepository
+	userRepo            *storageMock.MockUserRepository
+	wateringPlanRepo    *storageMock.MockWateringPlanRepository
+	routingRepo         *storageMock.MockRoutingRepository
+	weatherService      *serviceMock.MockWeatherService
+	wateringPlanService *serviceMock.MockWateringPlanService
+}
+
+func newTestService(t *testing.T) (*PlannerService, *testMocks) {
+	t.Helper()
+	m := &testMocks{
+		treeClusterRepo:     storageMock.NewMockTreeClusterRepository(t),
+		vehicleRepo:         storageMock.NewMockVehicleRepository(t),
+		userRepo:            storageMock.NewMockUserRepository(t),
+		wateringPlanRepo:    storageMock.NewMockWateringPlanRepository(t),
+		routingRepo:         storageMock.NewMockRoutingRepository(t),
+		weatherService:      serviceMock.NewMockWeatherService(t),
+		wateringPlanService: serviceMock.NewMockWateringPlanService(t),
+	}
+	svc := NewPlannerService(m.treeClusterRepo, m.vehicleRepo, m.userRepo, m.wateringPlanRepo, m.routingRepo, m.weatherService, m.wateringPlanService, testConfig)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+var (
+	tomorrow = utils.ToDate(testNow.Local()).AddDate(0, 0, 1)
+
+	user1 = &domain.User{ID: uuid.New(), Status: domain.UserStatusAvailable, DrivingLicenses: []domain.DrivingLicense{domain.DrivingLicenseB}}
+	user2 = &domain.User{ID: uuid.New(), Status: domain.UserStatusAvailable}
+	user3 = &domain.User{ID: uuid.New(), Status: domain.UserStatusAvailable, DrivingLicenses: []domain.DrivingLicense{domain.DrivingLicenseC}}
+	user4 = &domain.User{ID: uuid.New(), Status: domain.UserStatusAbsent, DrivingLicenses: []domain.DrivingLicense{domain.DrivingLicenseB}}
+
+	transporter1 = &domain.Vehicle{ID: 1, WaterCapacity: 2000, Status: domain.VehicleStatusAvailable, DrivingLicense: domain.DrivingLicenseB}
+	transporter2 = &domain.Vehicle{ID: 2, WaterCapacity: 3000, Status: domain.VehicleStatusActive, DrivingLicense: domain.DrivingLicenseC}
+	transporter3 = &domain.Vehicle{ID: 3, WaterCapacity: 4000, Status: domain.VehicleStatusNotAvailable, DrivingLicense: domain.DrivingLicenseB}
+)
+
+// testTreeClusters are ranked 1, 3, 2. Tree cluster 4 is not urgent, 5 is archived, 6 has no trees and 7 is planned.
+var testTreeClusters = []*domain.TreeCluster{
+	{ID: 1, Region: &domain.Region{ID: 1}, WateringStatus: domain.WateringStatusBad, Trees: []*domain.Tree{{PlantingYear: 2024}}},
+	{ID: 2, Region: &domain.Region{ID: 2}, WateringStatus: domain.WateringStatusBad, LastWatered: utils.P(testNow.AddDate(0, 0, -7)), Trees: []*domain.Tree{{PlantingYear: 2024}}},
+	{ID: 3, Region: &domain.Region{ID: 1}, WateringStatus: domain.WateringStatusModerate, Trees: []*domain.Tree{{PlantingYear: 2024}}},
+	{ID: 4, Region: &domain.Region{ID: 1}, WateringStatus: domain.WateringStatusGood, LastWatered: utils.P(testNow), Trees: []*domain.Tree{{PlantingYear: 1990}}},
+	{ID: 5, Region: &domain.Region{ID: 1}, WateringStatus: domain.WateringStatusBad, Archived: true, Trees: []*domain.Tree{{PlantingYear: 2024}}},
+	{ID: 6, Region: &domain.Region{ID: 1}, WateringStatus: domain.WateringStatusBad},
+	{ID: 7, Region: &domain.Region{ID: 1}, WateringStatus: domain.WateringStatusBad, Trees: []*domain.Tree{{PlantingYear: 2024}}},
+}
+
+// testWateringPlans use tree cluster 7, transporter 2 and user 3 tomorrow
+var testWateringPlans = []*domain.WateringPlan{
+	{ID: 1, Date: tomorrow, Status: domain.WateringPlanStatusPlanned, TreeClusters: []*domain.TreeCluster{testTreeClusters[6]}, Transporter: transporter2, UserIDs: []*uuid.UUID{&user3.ID}},
+	{ID: 2, Date: tomorrow, Status: domain.WateringPlanStatusCanceled, TreeClusters: []*domain.TreeCluster{testTreeClusters[0]}, Transporter: transporter1, UserIDs: []*uuid.UUID{&user1.ID}},
+}
+
+func expectSchedule(ctx context.Context, m *testMocks) {
+	m.wateringPlanRepo.EXPECT().GetAll(ctx, domain.Query{}).Return(testWateringPlans, int64(len(testWateringPlans)), nil)
+}
+
+func expectCandidates(ctx context.Context, m *testMocks) {
+	expectSchedule(ctx, m)
+	m.treeClusterRepo.EXPECT().GetAll(ctx, domain.TreeClusterQuery{}).Return(testTreeClusters, int64(len(testTreeClusters)), nil)
+	m.weatherService.EXPECT().GetWaterBalance(ctx, int32(1)).Return(&domain.TreeClusterWaterBalance{TreeClusterID: 1, BadAt: utils.P(utils.ToDate(testNow.Local()))}, nil)
+	m.weatherService.EXPECT().GetWaterBalance(ctx, int32(2)).Return(&domain.TreeClusterWaterBalance{TreeClusterID: 2}, nil)
+	m.weatherService.EXPECT().GetWaterBalance(ctx, int32(3)).Return(nil, errors.New("weather error"))
+	m.weatherService.EXPECT().GetWaterBalance(ctx, int32(4)).Return(&domain.TreeClusterWaterBalance{TreeClusterID: 4}, nil)
+	m.vehicleRepo.EXPECT().GetAllByType(ctx, "", domain.VehicleTypeTransporter).Return([]*domain.Vehicle{transporter1, transporter2, transporter3}, 3, nil)
+	m.userRepo.EXPECT().GetAllByRole(ctx, domain.UserRoleTbz).Return([]*domain.User{user1, user2, user3, user4}, nil)
+}
+
+func TestPlannerService_GetProposals(t *testing.T) {
+	t.Run("should propose the most urgent tree clusters of a region with available transporter and crew", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectCandidates(ctx, m)
+
+		route := &domain.RouteMetadata{Distance: 12.5, Refills: 1, Time: time.Hour}
+		m.routingRepo.EXPECT().GenerateRouteInformation(ctx, transporter1, []*domain.TreeCluster{testTreeClusters[0], testTreeClusters[2]}).Return(route, nil)
+
+		// when
+		got, err := svc.GetProposals(ctx, domain.WateringPlanProposalQuery{Days: 1})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, tomorrow, got[0].Plan.Date)
+		assert.Equal(t, []*int32{utils.P(int32(1)), utils.P(int32(3))}, got[0].Plan.TreeClusterIDs)
+		assert.Equal(t, utils.P(int32(1)), got[0].Plan.TransporterID)
+		assert.Nil(t, got[0].Plan.TrailerID)
+		assert.Equal(t, []*uuid.UUID{&user1.ID, &user2.ID}, got[0].Plan.UserIDs)
+		assert.Equal(t, transporter1, got[0].Transporter)
+		assert.Equal(t, route, got[0].Route)
+		assert.InDelta(t, 0.975, got[0].TreeClusters[0].Score, 0.001)
+		assert.Equal(t, utils.P(utils.ToDate(testNow.Local())), got[0].TreeClusters[0].BadAt)
+		assert.InDelta(t, 0.625, got[0].TreeClusters[1].Score, 0.001)
+		assert.Nil(t, got[0].TreeClusters[1].BadAt)
+		assert.InDelta(t, 1.6, got[0].Score, 0.001)
+	})
+
+	t.Run("should drop least urgent tree clusters until the route fits into the maximum duration", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectCandidates(ctx, m)
+
+		m.routingRepo.EXPECT().GenerateRouteInformation(ctx, transporter1, []*domain.TreeCluster{testTreeClusters[0], testTreeClusters[2]}).Return(&domain.RouteMetadata{Time: 9 * time.Hour}, nil)
+		m.routingRepo.EXPECT().GenerateRouteInformation(ctx, transporter1, []*domain.TreeCluster{testTreeClusters[0]}).Return(&domain.RouteMetadata{Time: 2 * time.Hour}, nil)
+
+		// when
+		got, err := svc.GetProposals(ctx, domain.WateringPlanProposalQuery{Days: 1})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, []*int32{utils.P(int32(1))}, got[0].Plan.TreeClusterIDs)
+		assert.Equal(t, 2*time.Hour, got[0].Route.Time)
+	})
+
+	t.Run("should propose without route when routing is disabled", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectCandidates(ctx, m)
+
+		m.routingRepo.EXPECT().GenerateRouteInformation(ctx, transporter1, []*domain.TreeCluster{testTreeClusters[0], testTreeClusters[2]}).Return(nil, storage.ErrRoutingServiceDisabled)
+
+		// when
+		got, err := svc.GetProposals(ctx, domain.WateringPlanProposalQuery{Days: 1})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Len(t, got[0].TreeClusters, 2)
+		assert.Nil(t, got[0].Route)
+	})
+
+	t.Run("should use transporters on the following days when they are not busy", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectCandidates(ctx, m)
+
+		m.routingRepo.EXPECT().GenerateRouteInformation(ctx, transporter1, []*domain.TreeCluster{testTreeClusters[0], testTreeClusters[2]}).Return(nil, storage.ErrRoutingServiceDisabled)
+		m.routingRepo.EXPECT().GenerateRouteInformation(ctx, transporter2, []*domain.TreeCluster{testTreeClusters[1]}).Return(nil, storage.ErrRoutingServiceDisabled)
+
+		// when
+		got, err := svc.GetProposals(ctx, domain.WateringPlanProposalQuery{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, tomorrow.AddDate(0, 0, 1), got[1].Plan.Date)
+		assert.Equal(t, utils.P(int32(2)), got[1].Plan.TransporterID)
+		assert.Equal(t, []*int32{utils.P(int32(2))}, got[1].Plan.TreeClusterIDs)
+		assert.Equal(t, []*uuid.UUID{&user3.ID, &user1.ID}, got[1].Plan.UserIDs)
+	})
+
+	t.Run("should return error when days are out of the planning horizon", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		ctx := context.Background()
+
+		// when
+		got, err := svc.GetProposals(ctx, domain.WateringPlanProposalQuery{Days: 4})
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrProposalQueryInvalid)
+	})
+
+	t.Run("should return error when watering plans can not be fetched", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		m.wateringPlanRepo.EXPECT().GetAll(ctx, domain.Query{}).Return(nil, 0, errors.New("storage error"))
+
+		// when
+		got, err := svc.GetProposals(ctx, domain.WateringPlanProposalQuery{Days: 1})
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestPlannerService_AcceptProposals(t *testing.T) {
+	newPlan := func(date time.Time, transporterID int32, userID uuid.UUID, clusterIDs ...int32) *domain.WateringPlanCreate {
+		return &domain.WateringPlanCreate{
+			Date:           date,
+			TreeClusterIDs: utils.Map(clusterIDs, func(id int32) *int32 { return &id }),
+			TransporterID:  &transporterID,
+			UserIDs:        []*uuid.UUID{&userID},
+		}
+	}
+
+	t.Run("should create the watering plans of the proposals", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectSchedule(ctx, m)
+		plans := []*domain.WateringPlanCreate{
+			newPlan(tomorrow, 1, user1.ID, 1, 3),
+			newPlan(tomorrow.AddDate(0, 0, 1), 1, user1.ID, 2),
+		}
+		m.wateringPlanService.EXPECT().Create(ctx, plans[0]).Return(&domain.WateringPlan{ID: 1}, nil)
+		m.wateringPlanService.EXPECT().Create(ctx, plans[1]).Return(&domain.WateringPlan{ID: 2}, nil)
+
+		// when
+		got, err := svc.AcceptProposals(ctx, plans)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*domain.WateringPlan{{ID: 1}, {ID: 2}}, got)
+	})
+
+	t.Run("should return error when a tree cluster is part of several proposals", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectSchedule(ctx, m)
+		plans := []*domain.WateringPlanCreate{
+			newPlan(tomorrow, 1, user1.ID, 1, 3),
+			newPlan(tomorrow.AddDate(0, 0, 1), 2, user3.ID, 3),
+		}
+
+		// when
+		got, err := svc.AcceptProposals(ctx, plans)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrProposalConflict)
+	})
+
+	t.Run("should return error when a vehicle or user is part of several proposals on the same day", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectSchedule(ctx, m)
+		sameTransporter := []*domain.WateringPlanCreate{
+			newPlan(tomorrow, 1, user1.ID, 1),
+			newPlan(tomorrow, 1, user3.ID, 2),
+		}
+		sameUser := []*domain.WateringPlanCreate{
+			newPlan(tomorrow, 1, user1.ID, 1),
+			newPlan(tomorrow, 3, user1.ID, 2),
+		}
+
+		// when
+		_, transporterErr := svc.AcceptProposals(ctx, sameTransporter)
+		_, userErr := svc.AcceptProposals(ctx, sameUser)
+
+		// then
+		assert.ErrorIs(t, transporterErr, service.ErrProposalConflict)
+		assert.ErrorIs(t, userErr, service.ErrProposalConflict)
+	})
+
+	t.Run("should return error without proposals", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		ctx := context.Background()
+
+		// when
+		got, err := svc.AcceptProposals(ctx, nil)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when a proposal is in conflict with a planned watering plan", func(t *testing.T) {
+		tests := []struct {
+			name string
+			plan *domain.WateringPlanCreate
+		}{
+			{name: "planned tree cluster", plan: newPlan(tomorrow.AddDate(0, 0, 1), 1, user1.ID, 7)},
+			{name: "busy transporter", plan: newPlan(tomorrow, 2, user1.ID, 1)},
+			{name: "busy user", plan: newPlan(tomorrow, 1, user3.ID, 1)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// given
+				svc, m := newTestService(t)
+				ctx := context.Background()
+				expectSchedule(ctx, m)
+
+				// when
+				got, err := svc.AcceptProposals(ctx, []*domain.WateringPlanCreate{tt.plan})
+
+				// then
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, service.ErrProposalConflict)
+			})
+		}
+	})
+
+	t.Run("should accept vehicle and user of a canceled watering plan", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectSchedule(ctx, m)
+		plans := []*domain.WateringPlanCreate{newPlan(tomorrow, 1, user1.ID, 1)}
+		m.wateringPlanService.EXPECT().Create(ctx, plans[0]).Return(&domain.WateringPlan{ID: 3}, nil)
+
+		// when
+		got, err := svc.AcceptProposals(ctx, plans)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*domain.WateringPlan{{ID: 3}}, got)
+	})
+
+	t.Run("should return error when watering plans can not be fetched", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		m.wateringPlanRepo.EXPECT().GetAll(ctx, domain.Query{}).Return(nil, 0, errors.New("storage error"))
+
+		// when
+		got, err := svc.AcceptProposals(ctx, []*domain.WateringPlanCreate{newPlan(tomorrow, 1, user1.ID, 1)})
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return the created watering plans when a watering plan can not be created", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectSchedule(ctx, m)
+		plans := []*domain.WateringPlanCreate{
+			newPlan(tomorrow, 1, user1.ID, 1),
+			newPlan(tomorrow.AddDate(0, 0, 1), 1, user1.ID, 2),
+		}
+		m.wateringPlanService.EXPECT().Create(ctx, plans[0]).Return(&domain.WateringPlan{ID: 3}, nil)
+		m.wateringPlanService.EXPECT().Create(ctx, plans[1]).Return(nil, service.ErrUserNotCorrectRole)
+
+		// when
+		got, err := svc.AcceptProposals(ctx, plans)
+
+		// then
+		assert.Equal(t, []*domain.WateringPlan{{ID: 3}}, got)
+		assert.ErrorIs(t, err, service.ErrUserNotCorrectRole)
+	})
+}
@@ -0,0 +1,77 @@
+package planner
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	// lastWateredDays is the number of days after which a tree cluster is as urgent as one that was never watered
+	lastWateredDays = 14
+	// youngTreeYears is the age of trees until which they need to be watered more often
+	youngTreeYears = 10
+)
+
+// wateringStatusUrgency is the urgency of the watering status, good and just watered tree clusters are not urgent
+var wateringStatusUrgency = map[domain.WateringStatus]float64{
+	domain.WateringStatusBad:      1,
+	domain.WateringStatusModerate: 0.6,
+	domain.WateringStatusUnknown:  0.3,
+}
+
+// urgency scores a tree cluster between 0 and 1 by its watering status, the time since it was last watered, the
+// age of its youngest tree and the forecast day on which it turns bad. A nil balance has no forecast.
+func urgency(cluster *domain.TreeCluster, balance *domain.TreeClusterWaterBalance, weights config.PlannerWeights, horizonDays int, now time.Time) float64 {
+	total := weights.WateringStatus + weights.LastWatered + weights.TreeAge + weights.Forecast
+	if total <= 0 {
+		return 0
+	}
+
+	today := utils.ToDate(now.Local())
+	score := weights.WateringStatus*wateringStatusUrgency[cluster.WateringStatus] +
+		weights.LastWatered*lastWateredUrgency(cluster.LastWatered, today) +
+		weights.TreeAge*treeAgeUrgency(cluster.Trees, today) +
+		weights.Forecast*forecastUrgency(balance, horizonDays, today)
+	return score / total
+}
+
+func lastWateredUrgency(lastWatered *time.Time, today time.Time) float64 {
+	if lastWatered == nil {
+		return 1
+	}
+	days := today.Sub(utils.ToDate(lastWatered.Local())).Hours() / 24
+	return clamp(days / lastWateredDays)
+}
+
+func treeAgeUrgency(trees []*domain.Tree, today time.Time) float64 {
+	var youngest int32
+	for _, tree := range trees {
+		if tree.PlantingYear > youngest {
+			youngest = tree.PlantingYear
+		}
+	}
+	if youngest == 0 {
+		return 0
+	}
+	age := float64(int32(today.Year()) - youngest)
+	return clamp(1 - age/youngTreeYears)
+}
+
+// forecastUrgency is 1 if the tree cluster is bad today and decreases the later it turns bad within the horizon
+func forecastUrgency(balance *domain.TreeClusterWaterBalance, horizonDays int, today time.Time) float64 {
+	if balance == nil || balance.BadAt == nil {
+		return 0
+	}
+	days := balance.BadAt.Sub(today).Hours() / 24
+	if days <= 0 {
+		return 1
+	}
+	return clamp(1 - days/float64(horizonDays+1))
+}
+
+func clamp(v float64) float64 {
+	return min(max(v, 0), 1)
+}
@@ -0,0 +1,89 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2025, 7, 2, 12, 0, 0, 0, time.Local)
+
+func TestUrgency(t *testing.T) {
+	weights := config.PlannerWeights{WateringStatus: 1, LastWatered: 1, TreeAge: 1, Forecast: 1}
+
+	t.Run("should be most urgent for a bad young tree cluster that was never watered", func(t *testing.T) {
+		// given
+		cluster := &domain.TreeCluster{
+			WateringStatus: domain.WateringStatusBad,
+			Trees:          []*domain.Tree{{PlantingYear: 2025}},
+		}
+		balance := &domain.TreeClusterWaterBalance{BadAt: utils.P(utils.ToDate(testNow.Local()))}
+
+		// when
+		got := urgency(cluster, balance, weights, 3, testNow)
+
+		// then
+		assert.InDelta(t, 1.0, got, 0.001)
+	})
+
+	t.Run("should not be urgent for a good old tree cluster that was just watered", func(t *testing.T) {
+		// given
+		cluster := &domain.TreeCluster{
+			WateringStatus: domain.WateringStatusGood,
+			LastWatered:    utils.P(testNow),
+			Trees:          []*domain.Tree{{PlantingYear: 1990}},
+		}
+
+		// when
+		got := urgency(cluster, nil, weights, 3, testNow)
+
+		// then
+		assert.InDelta(t, 0.0, got, 0.001)
+	})
+
+	t.Run("should normalize the factors by the sum of the weights", func(t *testing.T) {
+		// given
+		cluster := &domain.TreeCluster{
+			WateringStatus: domain.WateringStatusModerate,
+			LastWatered:    utils.P(testNow.AddDate(0, 0, -7)),
+			Trees:          []*domain.Tree{{PlantingYear: 2010}, {PlantingYear: 2020}},
+		}
+		balance := &domain.TreeClusterWaterBalance{BadAt: utils.P(utils.ToDate(testNow.Local()).AddDate(0, 0, 2))}
+
+		// when
+		got := urgency(cluster, balance, config.PlannerWeights{WateringStatus: 2, LastWatered: 1, TreeAge: 1, Forecast: 0}, 3, testNow)
+
+		// then
+		assert.InDelta(t, (2*0.6+0.5+0.5)/4, got, 0.001)
+	})
+
+	t.Run("should decrease forecast urgency the later the tree cluster turns bad", func(t *testing.T) {
+		// given
+		today := utils.ToDate(testNow.Local())
+
+		// when
+		soon := forecastUrgency(&domain.TreeClusterWaterBalance{BadAt: utils.P(today.AddDate(0, 0, 1))}, 3, today)
+		late := forecastUrgency(&domain.TreeClusterWaterBalance{BadAt: utils.P(today.AddDate(0, 0, 3))}, 3, today)
+		never := forecastUrgency(&domain.TreeClusterWaterBalance{}, 3, today)
+
+		// then
+		assert.InDelta(t, 0.75, soon, 0.001)
+		assert.InDelta(t, 0.25, late, 0.001)
+		assert.Zero(t, never)
+	})
+
+	t.Run("should be zero without weights", func(t *testing.T) {
+		// given
+		cluster := &domain.TreeCluster{WateringStatus: domain.WateringStatusBad}
+
+		// when
+		got := urgency(cluster, nil, config.PlannerWeights{}, 3, testNow)
+
+		// then
+		assert.Zero(t, got)
+	})
+}
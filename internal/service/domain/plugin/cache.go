@@ -0,0 +1,57 @@
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// scopeEntry is the grant and the registered plugin of a client. A nil grant marks a client that is not bound to a
+// plugin, a nil plugin a granted client without registration.
+type scopeEntry struct {
+	grant   *entities.PluginGrant
+	plugin  *entities.Plugin
+	expires time.Time
+}
+
+// scopeCache caches the lookups of CheckScope, which runs on every request of a plugin token. The cache is
+// cleared when a grant or registration changes on this instance, the ttl bounds how long a change made through
+// another instance stays unnoticed.
+type scopeCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]*scopeEntry
+}
+
+func newScopeCache(ttl time.Duration) *scopeCache {
+	return &scopeCache{
+		ttl:     ttl,
+		entries: make(map[string]*scopeEntry),
+	}
+}
+
+func (c *scopeCache) get(clientID string) (*scopeEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[clientID]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry, true
+}
+
+func (c *scopeCache) set(clientID string, grant *entities.PluginGrant, plugin *entities.Plugin) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[clientID] = &scopeEntry{grant: grant, plugin: plugin, expires: time.Now().Add(c.ttl)}
+}
+
+func (c *scopeCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
@@ -29,15 +29,45 @@ func (s *DummyPluginManager) Get(_ context.Context, _ string) (entities.Plugin,
 	return entities.Plugin{}, service.NewError(service.NotFound, "plugin support is disabled")
 }
 
-func (s *DummyPluginManager) GetAll(_ context.Context) ([]entities.Plugin, []time.Time) {
-	return []entities.Plugin{}, []time.Time{}
+func (s *DummyPluginManager) GetAll(_ context.Context) ([]entities.Plugin, error) {
+	return []entities.Plugin{}, nil
+}
+
+func (s *DummyPluginManager) GetHistory(_ context.Context, _ string) ([]*entities.PluginEvent, error) {
+	return []*entities.PluginEvent{}, nil
 }
 
 func (s *DummyPluginManager) HeartBeat(_ context.Context, _ string) error {
 	return service.NewError(service.Gone, "plugin support is disabled")
 }
 
-func (s *DummyPluginManager) Unregister(_ context.Context, _ string) {}
+func (s *DummyPluginManager) Unregister(_ context.Context, _ string) error {
+	return nil
+}
+
+func (s *DummyPluginManager) GetAllGrants(_ context.Context) ([]*entities.PluginGrant, error) {
+	return []*entities.PluginGrant{}, nil
+}
+
+func (s *DummyPluginManager) SaveGrant(_ context.Context, _ *entities.PluginGrant) (*entities.PluginGrant, error) {
+	return nil, service.NewError(service.Gone, "plugin support is disabled")
+}
+
+func (s *DummyPluginManager) DeleteGrant(_ context.Context, _ string) error {
+	return service.NewError(service.Gone, "plugin support is disabled")
+}
+
+func (s *DummyPluginManager) CheckScope(_ context.Context, _ string, _ entities.PluginScope) error {
+	return nil
+}
+
+func (s *DummyPluginManager) CheckClient(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (s *DummyPluginManager) HeartbeatTimeout() time.Duration {
+	return 0
+}
 
 func (s *DummyPluginManager) StartCleanup(_ context.Context) {}
 
@@ -5,9 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"maps"
 	"slices"
-	"sync"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -15,19 +13,22 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 )
 
 type PluginManagerConfig struct {
-	interval time.Duration
-	timeout  time.Duration
+	interval      time.Duration
+	timeout       time.Duration
+	scopeCacheTTL time.Duration
 }
 
 type PluginManagerOption func(*PluginManagerConfig)
 
 var defaultPluginManagerConfig = PluginManagerConfig{
-	timeout:  5 * time.Minute,
-	interval: 1 * time.Minute,
+	timeout:       5 * time.Minute,
+	interval:      1 * time.Minute,
+	scopeCacheTTL: 30 * time.Second,
 }
 
 func WithTimeout(timeout time.Duration) PluginManagerOption {
@@ -44,28 +45,34 @@ func WithInterval(interval time.Duration) PluginManagerOption {
 	}
 }
 
+// WithScopeCacheTTL sets how long the grants and registrations checked by CheckScope are cached, zero disables the cache
+func WithScopeCacheTTL(ttl time.Duration) PluginManagerOption {
+	return func(cfg *PluginManagerConfig) {
+		cfg.scopeCacheTTL = ttl
+	}
+}
+
 type PluginManager struct {
 	PluginManagerConfig
-	plugins        map[string]entities.Plugin
-	heartbeats     map[string]time.Time
-	mutex          sync.RWMutex
-	validator      *validator.Validate
-	authRepository storage.AuthRepository
+	validator        *validator.Validate
+	authRepository   storage.AuthRepository
+	pluginRepository storage.PluginRepository
+	scopes           *scopeCache
 }
 
 var _ service.PluginService = (*PluginManager)(nil)
 
-func NewPluginManager(authRepo storage.AuthRepository, opts ...PluginManagerOption) *PluginManager {
+func NewPluginManager(authRepo storage.AuthRepository, pluginRepo storage.PluginRepository, opts ...PluginManagerOption) *PluginManager {
 	cfg := defaultPluginManagerConfig
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
 	return &PluginManager{
-		plugins:             make(map[string]entities.Plugin),
-		heartbeats:          make(map[string]time.Time),
 		validator:           validator.New(),
 		authRepository:      authRepo,
+		pluginRepository:    pluginRepo,
+		scopes:              newScopeCache(cfg.scopeCacheTTL),
 		PluginManagerConfig: cfg,
 	}
 }
@@ -77,6 +84,45 @@ func (p *PluginManager) Register(ctx context.Context, plugin *entities.Plugin) (
 		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
 	}
 
+	if !validScopes(plugin.Scopes) {
+		log.Debug("plugin requested invalid scopes", "plugin_slug", plugin.Slug, "scopes", plugin.Scopes)
+		return nil, service.ErrPluginScopeInvalid
+	}
+
+	if !plugin.Manifest.IsValid() {
+		log.Debug("plugin registered an invalid manifest", "plugin_slug", plugin.Slug, "manifest", fmt.Sprintf("%+v", plugin.Manifest))
+		return nil, service.ErrPluginManifestInvalid
+	}
+
+	apiVersion, incompatibility := plugin.Requirements.Negotiate()
+	if incompatibility != nil {
+		log.Warn("the plugin you are trying to register is incompatible with this host", "plugin_slug", plugin.Slug, "reason", incompatibility.Reason, "requirements", fmt.Sprintf("%+v", plugin.Requirements))
+		return nil, service.PluginIncompatibleError{PluginIncompatibility: *incompatibility}
+	}
+	plugin.APIVersion = apiVersion
+	if plugin.Version == "" {
+		plugin.Version = apiVersion
+	}
+
+	grant, err := p.pluginRepository.GetGrantBySlug(ctx, plugin.Slug)
+	if err != nil {
+		if isNotFound(err) {
+			log.Warn("the plugin you are trying to register is not granted", "plugin_slug", plugin.Slug)
+			return nil, service.ErrPluginNotGranted
+		}
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	if grant.ClientID != plugin.Auth.ClientID {
+		log.Warn("the plugin you are trying to register is granted for another client", "plugin_slug", plugin.Slug, "plugin_client_id", plugin.Auth.ClientID)
+		return nil, service.ErrPluginNotGranted
+	}
+
+	if !grant.Allows(plugin.Scopes...) {
+		log.Warn("the plugin requested scopes that are not granted", "plugin_slug", plugin.Slug, "scopes", plugin.Scopes, "granted_scopes", grant.Scopes)
+		return nil, service.ErrPluginScopeNotGranted
+	}
+
 	log.Info("register new plugin", "plugin", plugin.Slug)
 
 	token, err := p.authRepository.GetAccessTokenFromClientCredentials(ctx, plugin.Auth.ClientID, plugin.Auth.ClientSecret)
@@ -85,16 +131,10 @@ func (p *PluginManager) Register(ctx context.Context, plugin *entities.Plugin) (
 		return nil, service.MapError(ctx, errors.Join(err, errors.New("failed to login plugin with credantials")), service.ErrorLogAll)
 	}
 
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-
-	if _, ok := p.plugins[plugin.Slug]; ok {
-		log.Warn("the plugin you are trying to register is already registered in the system.", "plugin_slug", plugin.Slug)
-		return nil, service.ErrPluginRegistered
+	if _, err := p.pluginRepository.Register(ctx, plugin); err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
 	}
-
-	p.plugins[plugin.Slug] = *plugin
-	p.heartbeats[plugin.Slug] = time.Now()
+	p.scopes.clear()
 
 	return token, nil
 }
@@ -103,6 +143,20 @@ func (p *PluginManager) RefreshToken(ctx context.Context, auth *entities.AuthPlu
 	log := logger.GetLogger(ctx)
 	log.Debug("refresh token for plugin", "plugin_slug", slug, "client_id", auth.ClientID, "client_secret", "**********")
 
+	grant, err := p.pluginRepository.GetGrantBySlug(ctx, slug)
+	if err != nil {
+		if isNotFound(err) {
+			log.Warn("the plugin you are trying to refresh the token for is not granted", "plugin_slug", slug)
+			return nil, service.ErrPluginNotGranted
+		}
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	if grant.ClientID != auth.ClientID {
+		log.Warn("the plugin you are trying to refresh the token for is granted for another client", "plugin_slug", slug, "plugin_client_id", auth.ClientID)
+		return nil, service.ErrPluginNotGranted
+	}
+
 	token, err := p.authRepository.GetAccessTokenFromClientCredentials(ctx, auth.ClientID, auth.ClientSecret)
 	if err != nil {
 		log.Debug("failed to refresh plugin token with credantials", "error", err, "plugin_client_id", auth.ClientID, "plugin_client_secret", "*******")
@@ -114,21 +168,40 @@ func (p *PluginManager) RefreshToken(ctx context.Context, auth *entities.AuthPlu
 
 func (p *PluginManager) Get(ctx context.Context, slug string) (entities.Plugin, error) {
 	log := logger.GetLogger(ctx)
-	p.mutex.RLock()
-	defer p.mutex.RUnlock()
-	plugin, ok := p.plugins[slug]
-	if !ok {
-		log.Error("the plugin is not registered in the system", "plugin_slug", slug)
-		return plugin, service.ErrPluginNotRegistered
+	plugin, err := p.pluginRepository.GetBySlug(ctx, slug)
+	if err != nil {
+		if isNotFound(err) {
+			log.Error("the plugin is not registered in the system", "plugin_slug", slug)
+			return entities.Plugin{}, service.ErrPluginNotRegistered
+		}
+		return entities.Plugin{}, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return *plugin, nil
+}
+
+func (p *PluginManager) GetAll(ctx context.Context) ([]entities.Plugin, error) {
+	plugins, err := p.pluginRepository.GetAll(ctx)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
 	}
 
-	return plugin, nil
+	return utils.Map(plugins, func(plugin *entities.Plugin) entities.Plugin {
+		return *plugin
+	}), nil
 }
 
-func (p *PluginManager) GetAll(_ context.Context) ([]entities.Plugin, []time.Time) {
-	p.mutex.RLock()
-	defer p.mutex.RUnlock()
-	return slices.Collect(maps.Values(p.plugins)), slices.Collect(maps.Values(p.heartbeats))
+func (p *PluginManager) GetHistory(ctx context.Context, slug string) ([]*entities.PluginEvent, error) {
+	events, err := p.pluginRepository.GetEventsBySlug(ctx, slug)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return events, nil
+}
+
+func (p *PluginManager) HeartbeatTimeout() time.Duration {
+	return p.timeout
 }
 
 func (p *PluginManager) HeartBeat(ctx context.Context, slug string) error {
@@ -138,67 +211,191 @@ func (p *PluginManager) HeartBeat(ctx context.Context, slug string) error {
 		return service.NewError(service.BadRequest, "slug is empty")
 	}
 
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	if _, ok := p.heartbeats[slug]; !ok {
-		log.Error("the plugin is not registered in the system", "plugin_slug", slug)
-		return service.ErrPluginNotRegistered
+	if err := p.pluginRepository.UpdateHeartbeat(ctx, slug); err != nil {
+		if isNotFound(err) {
+			log.Error("the plugin is not registered in the system", "plugin_slug", slug)
+			return service.ErrPluginNotRegistered
+		}
+		return service.MapError(ctx, err, service.ErrorLogAll)
 	}
 
-	p.heartbeats[slug] = time.Now()
 	return nil
 }
 
-func (p *PluginManager) Unregister(ctx context.Context, slug string) {
+func (p *PluginManager) Unregister(ctx context.Context, slug string) error {
 	log := logger.GetLogger(ctx)
 	log.Info("unregister plugin", "plugin_slug", slug)
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	delete(p.plugins, slug)
-	delete(p.heartbeats, slug)
+	defer p.scopes.clear()
+	if err := p.pluginRepository.Unregister(ctx, slug, entities.PluginEventTypeUnregister); err != nil {
+		if isNotFound(err) {
+			log.Error("the plugin is not registered in the system", "plugin_slug", slug)
+			return service.ErrPluginNotRegistered
+		}
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return nil
 }
 
-func (p *PluginManager) checkHeartbeats() []string {
-	slugsToDelete := make([]string, 0)
+func (p *PluginManager) CheckClient(ctx context.Context, clientID, slug string) error {
+	log := logger.GetLogger(ctx)
+	grant, err := p.pluginRepository.GetGrantBySlug(ctx, slug)
+	if err != nil {
+		if isNotFound(err) {
+			log.Warn("client tried to manage a plugin that is not granted", "plugin_slug", slug, "client_id", clientID)
+			return service.ErrPluginNotGranted
+		}
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	if grant.ClientID != clientID {
+		log.Warn("client tried to manage a plugin that is granted for another client", "plugin_slug", slug, "client_id", clientID)
+		return service.ErrPluginNotGranted
+	}
 
-	p.mutex.RLock()
-	defer p.mutex.RUnlock()
+	return nil
+}
 
-	for slug, heartbeat := range p.heartbeats {
-		if time.Since(heartbeat) > p.timeout {
-			slugsToDelete = append(slugsToDelete, slug)
-		}
+func (p *PluginManager) GetAllGrants(ctx context.Context) ([]*entities.PluginGrant, error) {
+	grants, err := p.pluginRepository.GetAllGrants(ctx)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return grants, nil
+}
+
+func (p *PluginManager) SaveGrant(ctx context.Context, grant *entities.PluginGrant) (*entities.PluginGrant, error) {
+	log := logger.GetLogger(ctx)
+	if err := p.validator.Struct(grant); err != nil {
+		log.Debug("failed to validate plugin grant struct", "error", err, "raw_grant", fmt.Sprintf("%+v", grant))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if !validScopes(grant.Scopes) {
+		log.Debug("plugin grant contains invalid scopes", "plugin_slug", grant.Slug, "scopes", grant.Scopes)
+		return nil, service.ErrPluginScopeInvalid
 	}
 
-	return slugsToDelete
+	saved, err := p.pluginRepository.SaveGrant(ctx, grant)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+	p.scopes.clear()
+
+	log.Info("plugin grant saved", "plugin_slug", saved.Slug, "plugin_client_id", saved.ClientID, "scopes", saved.Scopes)
+	return saved, nil
 }
 
-func (p *PluginManager) batchUnregister(ctx context.Context, slugs []string) {
+// DeleteGrant removes the plugin from the allow-list. A registered plugin is unregistered first
+// so that the event history reflects the removal.
+func (p *PluginManager) DeleteGrant(ctx context.Context, slug string) error {
 	log := logger.GetLogger(ctx)
-	if len(slugs) == 0 {
-		return
+	defer p.scopes.clear()
+	if err := p.pluginRepository.Unregister(ctx, slug, entities.PluginEventTypeUnregister); err != nil && !isNotFound(err) {
+		return service.MapError(ctx, err, service.ErrorLogAll)
 	}
 
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	for _, slug := range slugs {
-		log.Info("unregister plugin due to timeout", "plugin", slug)
-		delete(p.plugins, slug)
-		delete(p.heartbeats, slug)
+	if err := p.pluginRepository.DeleteGrant(ctx, slug); err != nil {
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
 	}
+
+	log.Info("plugin grant deleted", "plugin_slug", slug)
+	return nil
 }
 
-func (p *PluginManager) StartCleanup(ctx context.Context) {
-	scheduler := worker.NewScheduler(p.interval, worker.SchedulerFunc(func(ctx context.Context) error {
-		slugsToDelete := p.checkHeartbeats()
-		p.batchUnregister(ctx, slugsToDelete)
+func (p *PluginManager) CheckScope(ctx context.Context, clientID string, scope entities.PluginScope) error {
+	log := logger.GetLogger(ctx)
+	if clientID == "" {
+		return nil
+	}
 
+	entry, ok := p.scopes.get(clientID)
+	if !ok {
+		var err error
+		if entry, err = p.lookupScopes(ctx, clientID); err != nil {
+			return service.MapError(ctx, err, service.ErrorLogAll)
+		}
+		p.scopes.set(clientID, entry.grant, entry.plugin)
+	}
+
+	if entry.grant == nil {
 		return nil
-	}))
+	}
+
+	if entry.plugin == nil {
+		log.Warn("plugin client is used without a registered plugin", "plugin_slug", entry.grant.Slug, "plugin_client_id", clientID)
+		return service.ErrPluginScopeNotGranted
+	}
+
+	if !entry.grant.Allows(scope) || !slices.Contains(entry.plugin.Scopes, scope) {
+		log.Warn("plugin tried to use a scope that is not granted", "plugin_slug", entry.plugin.Slug, "scope", scope)
+		return service.ErrPluginScopeNotGranted
+	}
+
+	return nil
+}
 
+// lookupScopes fetches the grant and the registered plugin of a client, missing entries are left nil
+func (p *PluginManager) lookupScopes(ctx context.Context, clientID string) (*scopeEntry, error) {
+	grant, err := p.pluginRepository.GetGrantByClientID(ctx, clientID)
+	if err != nil {
+		if isNotFound(err) {
+			return &scopeEntry{}, nil
+		}
+		return nil, err
+	}
+
+	plugin, err := p.pluginRepository.GetByClientID(ctx, clientID)
+	if err != nil {
+		if isNotFound(err) {
+			return &scopeEntry{grant: grant}, nil
+		}
+		return nil, err
+	}
+
+	return &scopeEntry{grant: grant, plugin: plugin}, nil
+}
+
+func (p *PluginManager) cleanup(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	plugins, err := p.pluginRepository.GetAllWithHeartbeatBefore(ctx, time.Now().Add(-p.timeout))
+	if err != nil {
+		return err
+	}
+
+	if len(plugins) > 0 {
+		defer p.scopes.clear()
+	}
+	for _, plugin := range plugins {
+		log.Info("unregister plugin due to timeout", "plugin", plugin.Slug)
+		if err := p.pluginRepository.Unregister(ctx, plugin.Slug, entities.PluginEventTypeTimeout); err != nil {
+			log.Error("failed to unregister plugin due to timeout", "plugin", plugin.Slug, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *PluginManager) StartCleanup(ctx context.Context) {
+	scheduler := worker.NewScheduler(p.interval, worker.SchedulerFunc(p.cleanup))
 	scheduler.Run(ctx)
 }
 
 func (p *PluginManager) Ready() bool {
+	return p.pluginRepository != nil
+}
+
+func validScopes(scopes []entities.PluginScope) bool {
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return false
+		}
+	}
 	return true
 }
+
+func isNotFound(err error) bool {
+	var entityNotFoundErr storage.ErrEntityNotFound
+	return errors.As(err, &entityNotFoundErr)
+}
@@ -0,0 +1,767 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPluginManager_Register(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should register granted plugin and return token", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		token := &entities.ClientToken{AccessToken: "access-token"}
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, plugin.Slug).Return(testGrant(), nil)
+		authRepo.EXPECT().GetAccessTokenFromClientCredentials(ctx, plugin.Auth.ClientID, plugin.Auth.ClientSecret).Return(token, nil)
+		pluginRepo.EXPECT().Register(ctx, plugin).Return(plugin, nil)
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, token, got)
+		assert.Equal(t, "v1", plugin.APIVersion)
+	})
+
+	t.Run("should default missing version to the host api version", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Version = ""
+		token := &entities.ClientToken{AccessToken: "access-token"}
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, plugin.Slug).Return(testGrant(), nil)
+		authRepo.EXPECT().GetAccessTokenFromClientCredentials(ctx, plugin.Auth.ClientID, plugin.Auth.ClientSecret).Return(token, nil)
+		pluginRepo.EXPECT().Register(ctx, plugin).Return(plugin, nil)
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, token, got)
+		assert.Equal(t, "v1", plugin.Version)
+	})
+
+	t.Run("should return error when plugin is not granted", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, plugin.Slug).Return(nil, storage.ErrEntityNotFound("PluginGrant"))
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginNotGranted)
+	})
+
+	t.Run("should return error when plugin is granted for another client", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		grant := testGrant()
+		grant.ClientID = "other-client"
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, plugin.Slug).Return(grant, nil)
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginNotGranted)
+	})
+
+	t.Run("should return error when requested scopes are not granted", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Scopes = append(plugin.Scopes, "vehicle:write")
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, plugin.Slug).Return(testGrant(), nil)
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginScopeNotGranted)
+	})
+
+	t.Run("should return error when requested scope is invalid", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Scopes = []entities.PluginScope{"tree:delete"}
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginScopeInvalid)
+	})
+
+	t.Run("should return error when manifest entry is not a relative path", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Manifest.Entry = "https://evil.example.com/index.js"
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginManifestInvalid)
+	})
+
+	t.Run("should return error when manifest requires unknown role", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Manifest.Roles = []entities.UserRole{"admin"}
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginManifestInvalid)
+	})
+
+	t.Run("should return error when no host api version is in requested range", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Requirements.MinAPIVersion = "v2"
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		var incompatibleErr service.PluginIncompatibleError
+		assert.ErrorAs(t, err, &incompatibleErr)
+		assert.Empty(t, incompatibleErr.MissingFeatures)
+	})
+
+	t.Run("should return error when requested api version is invalid", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Requirements.MaxAPIVersion = "1.0"
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorAs(t, err, new(service.PluginIncompatibleError))
+	})
+
+	t.Run("should return error with missing features", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Requirements.Features = []entities.PluginFeature{entities.PluginFeatureWebhooks, "teleport"}
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		var incompatibleErr service.PluginIncompatibleError
+		assert.ErrorAs(t, err, &incompatibleErr)
+		assert.Equal(t, []entities.PluginFeature{"teleport"}, incompatibleErr.MissingFeatures)
+	})
+
+	t.Run("should return validation error when slug is empty", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Slug = ""
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+
+	t.Run("should return error when login with client credentials fails", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		plugin := testPlugin()
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, plugin.Slug).Return(testGrant(), nil)
+		authRepo.EXPECT().GetAccessTokenFromClientCredentials(ctx, plugin.Auth.ClientID, plugin.Auth.ClientSecret).Return(nil, errors.New("invalid credentials"))
+
+		// when
+		got, err := svc.Register(ctx, plugin)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestPluginManager_RefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return error when client is not granted for slug", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, "csv-import").Return(testGrant(), nil)
+
+		// when
+		got, err := svc.RefreshToken(ctx, &entities.AuthPlugin{ClientID: "other-client", ClientSecret: "secret"}, "csv-import")
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginNotGranted)
+	})
+
+	t.Run("should refresh token of granted client", func(t *testing.T) {
+		// given
+		authRepo := storageMock.NewMockAuthRepository(t)
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(authRepo, pluginRepo)
+
+		token := &entities.ClientToken{AccessToken: "access-token"}
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, "csv-import").Return(testGrant(), nil)
+		authRepo.EXPECT().GetAccessTokenFromClientCredentials(ctx, "csv-import-client", "secret").Return(token, nil)
+
+		// when
+		got, err := svc.RefreshToken(ctx, &entities.AuthPlugin{ClientID: "csv-import-client", ClientSecret: "secret"}, "csv-import")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, token, got)
+	})
+}
+
+func TestPluginManager_Get(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return registered plugin", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		plugin := testPlugin()
+		pluginRepo.EXPECT().GetBySlug(ctx, plugin.Slug).Return(plugin, nil)
+
+		// when
+		got, err := svc.Get(ctx, plugin.Slug)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, *plugin, got)
+	})
+
+	t.Run("should return error when plugin is not registered", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().GetBySlug(ctx, "unknown").Return(nil, storage.ErrEntityNotFound("Plugin"))
+
+		// when
+		_, err := svc.Get(ctx, "unknown")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginNotRegistered)
+	})
+}
+
+func TestPluginManager_HeartBeat(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should update heartbeat", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().UpdateHeartbeat(ctx, "csv-import").Return(nil)
+
+		// when
+		err := svc.HeartBeat(ctx, "csv-import")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when slug is empty", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		// when
+		err := svc.HeartBeat(ctx, "")
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when plugin is not registered", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().UpdateHeartbeat(ctx, "unknown").Return(storage.ErrEntityNotFound("Plugin"))
+
+		// when
+		err := svc.HeartBeat(ctx, "unknown")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginNotRegistered)
+	})
+}
+
+func TestPluginManager_Unregister(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should unregister plugin with unregister event", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().Unregister(ctx, "csv-import", entities.PluginEventTypeUnregister).Return(nil)
+
+		// when
+		err := svc.Unregister(ctx, "csv-import")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when plugin is not registered", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().Unregister(ctx, "unknown", entities.PluginEventTypeUnregister).Return(storage.ErrEntityNotFound("Plugin"))
+
+		// when
+		err := svc.Unregister(ctx, "unknown")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginNotRegistered)
+	})
+}
+
+func TestPluginManager_CheckClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should allow the client the plugin is granted for", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, "csv-import").Return(testGrant(), nil)
+
+		// when
+		err := svc.CheckClient(ctx, "csv-import-client", "csv-import")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject the client of another plugin", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, "csv-import").Return(testGrant(), nil)
+
+		// when
+		err := svc.CheckClient(ctx, "other-client", "csv-import")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginNotGranted)
+	})
+
+	t.Run("should reject a plugin without grant", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+		pluginRepo.EXPECT().GetGrantBySlug(ctx, "unknown").Return(nil, storage.ErrEntityNotFound("PluginGrant"))
+
+		// when
+		err := svc.CheckClient(ctx, "csv-import-client", "unknown")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginNotGranted)
+	})
+}
+
+func TestPluginManager_SaveGrant(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should save valid grant", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		grant := testGrant()
+		pluginRepo.EXPECT().SaveGrant(ctx, grant).Return(grant, nil)
+
+		// when
+		got, err := svc.SaveGrant(ctx, grant)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, grant, got)
+	})
+
+	t.Run("should return error when grant contains invalid scope", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		grant := testGrant()
+		grant.Scopes = []entities.PluginScope{"unknown:read"}
+
+		// when
+		got, err := svc.SaveGrant(ctx, grant)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrPluginScopeInvalid)
+	})
+
+	t.Run("should return validation error when client id is empty", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		grant := testGrant()
+		grant.ClientID = ""
+
+		// when
+		got, err := svc.SaveGrant(ctx, grant)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestPluginManager_DeleteGrant(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should unregister plugin and delete grant", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().Unregister(ctx, "csv-import", entities.PluginEventTypeUnregister).Return(nil)
+		pluginRepo.EXPECT().DeleteGrant(ctx, "csv-import").Return(nil)
+
+		// when
+		err := svc.DeleteGrant(ctx, "csv-import")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should delete grant of not registered plugin", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().Unregister(ctx, "csv-import", entities.PluginEventTypeUnregister).Return(storage.ErrEntityNotFound("Plugin"))
+		pluginRepo.EXPECT().DeleteGrant(ctx, "csv-import").Return(nil)
+
+		// when
+		err := svc.DeleteGrant(ctx, "csv-import")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found when grant does not exist", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().Unregister(ctx, "unknown", entities.PluginEventTypeUnregister).Return(storage.ErrEntityNotFound("Plugin"))
+		pluginRepo.EXPECT().DeleteGrant(ctx, "unknown").Return(storage.ErrEntityNotFound("PluginGrant"))
+
+		// when
+		err := svc.DeleteGrant(ctx, "unknown")
+
+		// then
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestPluginManager_CheckScope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should allow clients that are not bound to a plugin", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "frontend").Return(nil, storage.ErrEntityNotFound("PluginGrant"))
+
+		// when
+		err := svc.CheckScope(ctx, "frontend", "tree:write")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should allow requests without client id", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		// when
+		err := svc.CheckScope(ctx, "", "tree:write")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should allow granted and requested scope", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "csv-import-client").Return(testGrant(), nil)
+		pluginRepo.EXPECT().GetByClientID(ctx, "csv-import-client").Return(testPlugin(), nil)
+
+		// when
+		err := svc.CheckScope(ctx, "csv-import-client", "tree:write")
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should deny scope that was not requested on registration", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		plugin := testPlugin()
+		plugin.Scopes = []entities.PluginScope{"tree:read"}
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "csv-import-client").Return(testGrant(), nil)
+		pluginRepo.EXPECT().GetByClientID(ctx, "csv-import-client").Return(plugin, nil)
+
+		// when
+		err := svc.CheckScope(ctx, "csv-import-client", "tree:write")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginScopeNotGranted)
+	})
+
+	t.Run("should deny scope that is no longer granted", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		grant := testGrant()
+		grant.Scopes = []entities.PluginScope{"tree:read"}
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "csv-import-client").Return(grant, nil)
+		pluginRepo.EXPECT().GetByClientID(ctx, "csv-import-client").Return(testPlugin(), nil)
+
+		// when
+		err := svc.CheckScope(ctx, "csv-import-client", "tree:write")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginScopeNotGranted)
+	})
+
+	t.Run("should deny plugin client without registration", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "csv-import-client").Return(testGrant(), nil)
+		pluginRepo.EXPECT().GetByClientID(ctx, "csv-import-client").Return(nil, storage.ErrEntityNotFound("Plugin"))
+
+		// when
+		err := svc.CheckScope(ctx, "csv-import-client", "tree:read")
+
+		// then
+		assert.ErrorIs(t, err, service.ErrPluginScopeNotGranted)
+	})
+
+	t.Run("should cache grant and registration between requests", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "csv-import-client").Return(testGrant(), nil).Once()
+		pluginRepo.EXPECT().GetByClientID(ctx, "csv-import-client").Return(testPlugin(), nil).Once()
+
+		// when
+		err1 := svc.CheckScope(ctx, "csv-import-client", "tree:write")
+		err2 := svc.CheckScope(ctx, "csv-import-client", "tree:read")
+
+		// then
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+	})
+
+	t.Run("should invalidate cached grants when a grant is saved", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		narrowed := testGrant()
+		narrowed.Scopes = []entities.PluginScope{"tree:read"}
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "csv-import-client").Return(testGrant(), nil).Once()
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "csv-import-client").Return(narrowed, nil).Once()
+		pluginRepo.EXPECT().GetByClientID(ctx, "csv-import-client").Return(testPlugin(), nil).Times(2)
+		pluginRepo.EXPECT().SaveGrant(ctx, narrowed).Return(narrowed, nil)
+
+		// when
+		errBefore := svc.CheckScope(ctx, "csv-import-client", "tree:write")
+		_, saveErr := svc.SaveGrant(ctx, narrowed)
+		errAfter := svc.CheckScope(ctx, "csv-import-client", "tree:write")
+
+		// then
+		assert.NoError(t, errBefore)
+		assert.NoError(t, saveErr)
+		assert.ErrorIs(t, errAfter, service.ErrPluginScopeNotGranted)
+	})
+
+	t.Run("should not cache with zero ttl", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo, WithScopeCacheTTL(0))
+
+		pluginRepo.EXPECT().GetGrantByClientID(ctx, "frontend").Return(nil, storage.ErrEntityNotFound("PluginGrant")).Times(2)
+
+		// when
+		err1 := svc.CheckScope(ctx, "frontend", "tree:write")
+		err2 := svc.CheckScope(ctx, "frontend", "tree:write")
+
+		// then
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+	})
+}
+
+func TestPluginManager_Cleanup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should unregister plugins with outdated heartbeat as timeout", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo, WithTimeout(time.Minute))
+
+		pluginRepo.EXPECT().GetAllWithHeartbeatBefore(ctx, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= time.Minute
+		})).Return([]*entities.Plugin{testPlugin()}, nil)
+		pluginRepo.EXPECT().Unregister(ctx, "csv-import", entities.PluginEventTypeTimeout).Return(nil)
+
+		// when
+		err := svc.cleanup(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when plugins can not be fetched", func(t *testing.T) {
+		// given
+		pluginRepo := storageMock.NewMockPluginRepository(t)
+		svc := NewPluginManager(storageMock.NewMockAuthRepository(t), pluginRepo)
+
+		pluginRepo.EXPECT().GetAllWithHeartbeatBefore(ctx, mock.Anything).Return(nil, errors.New("db error"))
+
+		// when
+		err := svc.cleanup(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func testPlugin() *entities.Plugin {
+	path, _ := url.Parse("http://localhost:8080/plugins/csv-import")
+	return &entities.Plugin{
+		Slug:        "csv-import",
+		Name:        "CSV Import",
+		Path:        *path,
+		Version:     "v1.0.0",
+		Description: "Import trees from csv files",
+		Scopes:      []entities.PluginScope{"tree:read", "tree:write"},
+		Requirements: entities.PluginRequirements{
+			MinAPIVersion: "v1",
+			Features:      []entities.PluginFeature{entities.PluginFeatureScopes},
+		},
+		Auth: entities.AuthPlugin{
+			ClientID:     "csv-import-client",
+			ClientSecret: "secret",
+		},
+	}
+}
+
+func testGrant() *entities.PluginGrant {
+	return &entities.PluginGrant{
+		Slug:     "csv-import",
+		ClientID: "csv-import-client",
+		Scopes:   []entities.PluginScope{"tree:read", "tree:write", "sensor:read"},
+	}
+}
@@ -2,20 +2,26 @@ package region
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/go-playground/validator/v10"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/twpayne/go-geos"
 )
 
 type RegionService struct {
 	regionRepo storage.RegionRepository
+	validator  *validator.Validate
 }
 
 func NewRegionService(regionRepository storage.RegionRepository) service.RegionService {
 	return &RegionService{
 		regionRepo: regionRepository,
+		validator:  validator.New(),
 	}
 }
 
@@ -41,6 +47,98 @@ func (s *RegionService) GetByID(ctx context.Context, id int32) (*domain.Region,
 	return region, nil
 }
 
+func (s *RegionService) Create(ctx context.Context, createData *domain.RegionCreate) (*domain.Region, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate struct from create region", "error", err, "raw_region", fmt.Sprintf("%+v", createData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validateGeometry(ctx, createData.Geometry); err != nil {
+		return nil, err
+	}
+
+	created, err := s.regionRepo.Create(ctx, func(r *domain.Region) {
+		r.Name = createData.Name
+		r.Geometry = createData.Geometry
+	})
+	if err != nil {
+		log.Error("failed to create region", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("region created successfully", "region_id", created.ID)
+	return created, nil
+}
+
+func (s *RegionService) Update(ctx context.Context, id int32, updateData *domain.RegionUpdate) (*domain.Region, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(updateData); err != nil {
+		log.Debug("failed to validate struct from update region", "error", err, "raw_region", fmt.Sprintf("%+v", updateData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if _, err := s.regionRepo.GetByID(ctx, id); err != nil {
+		log.Debug("failed to get already existing region from store", "error", err, "region_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if err := s.validateGeometry(ctx, updateData.Geometry); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.regionRepo.Update(ctx, id, func(r *domain.Region) {
+		r.Name = updateData.Name
+		r.Geometry = updateData.Geometry
+	})
+	if err != nil {
+		log.Debug("failed to update region", "error", err, "region_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("region updated successfully", "region_id", id)
+	return updated, nil
+}
+
+func (s *RegionService) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	if _, err := s.regionRepo.GetByID(ctx, id); err != nil {
+		log.Debug("failed to get region by id in delete request", "error", err, "region_id", id)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if err := s.regionRepo.Delete(ctx, id); err != nil {
+		log.Debug("failed to delete region", "error", err, "region_id", id)
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("region deleted successfully", "region_id", id)
+	return nil
+}
+
 func (s *RegionService) Ready() bool {
 	return s.regionRepo != nil
 }
+
+// validateGeometry checks that the geometry is a valid polygon or multi polygon. The overlap with other
+// regions is checked by the repository in the transaction of the change.
+func (s *RegionService) validateGeometry(ctx context.Context, geometry string) error {
+	log := logger.GetLogger(ctx)
+	g, err := geos.NewGeomFromWKT(geometry)
+	if err != nil {
+		log.Debug("failed to parse region geometry", "error", err)
+		return service.ErrRegionGeometryInvalid
+	}
+
+	if typeID := g.TypeID(); typeID != geos.TypeIDPolygon && typeID != geos.TypeIDMultiPolygon {
+		log.Debug("region geometry is no polygon", "geometry_type", g.Type())
+		return service.ErrRegionGeometryInvalid
+	}
+
+	if g.IsEmpty() || !g.IsValid() {
+		log.Debug("region geometry is empty or self-intersecting", "reason", g.IsValidReason())
+		return service.ErrRegionGeometryInvalid
+	}
+
+	return nil
+}
@@ -7,9 +7,11 @@ import (
 	"testing"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var rootCtx = context.WithValue(context.Background(), "logger", slog.Default())
@@ -85,6 +87,166 @@ func TestRegionService_GetByID(t *testing.T) {
 	})
 }
 
+const (
+	testPolygon              = "POLYGON ((9.4 54.7, 9.5 54.7, 9.5 54.8, 9.4 54.8, 9.4 54.7))"
+	testSelfIntersectPolygon = "POLYGON ((9.4 54.7, 9.5 54.8, 9.5 54.7, 9.4 54.8, 9.4 54.7))"
+)
+
+func TestRegionService_Create(t *testing.T) {
+	t.Run("should create region", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		created := &entities.Region{ID: 3, Name: "Region C", Geometry: testPolygon}
+
+		repo.EXPECT().Create(rootCtx, mock.Anything).Return(created, nil)
+
+		// when
+		got, err := svc.Create(rootCtx, &entities.RegionCreate{Name: "Region C", Geometry: testPolygon})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, created, got)
+	})
+
+	t.Run("should return validation error when name is empty", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		// when
+		got, err := svc.Create(rootCtx, &entities.RegionCreate{Geometry: testPolygon})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		repo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should return error when geometry is invalid", func(t *testing.T) {
+		for name, geometry := range map[string]string{
+			"no WKT":            "abc",
+			"point":             "POINT (9.4 54.7)",
+			"self-intersecting": testSelfIntersectPolygon,
+			"empty":             "POLYGON EMPTY",
+		} {
+			t.Run(name, func(t *testing.T) {
+				// given
+				repo := storageMock.NewMockRegionRepository(t)
+				svc := NewRegionService(repo)
+
+				// when
+				got, err := svc.Create(rootCtx, &entities.RegionCreate{Name: "Region C", Geometry: geometry})
+
+				// then
+				assert.ErrorIs(t, err, service.ErrRegionGeometryInvalid)
+				assert.Nil(t, got)
+			})
+		}
+	})
+
+	t.Run("should return error when region overlaps with other region", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		repo.EXPECT().Create(rootCtx, mock.Anything).Return(nil, storage.ErrRegionOverlapping)
+
+		// when
+		got, err := svc.Create(rootCtx, &entities.RegionCreate{Name: "Region C", Geometry: testPolygon})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrRegionOverlapping)
+		assert.Nil(t, got)
+	})
+}
+
+func TestRegionService_Update(t *testing.T) {
+	t.Run("should update region", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		old := &entities.Region{ID: 1, Name: "Region A"}
+		updated := &entities.Region{ID: 1, Name: "Region A", Geometry: testPolygon}
+
+		repo.EXPECT().GetByID(rootCtx, int32(1)).Return(old, nil)
+		repo.EXPECT().Update(rootCtx, int32(1), mock.Anything).Return(updated, nil)
+
+		// when
+		got, err := svc.Update(rootCtx, 1, &entities.RegionUpdate{Name: "Region A", Geometry: testPolygon})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, updated, got)
+	})
+
+	t.Run("should return error when region not found", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		repo.EXPECT().GetByID(rootCtx, int32(1)).Return(nil, storage.ErrRegionNotFound)
+
+		// when
+		got, err := svc.Update(rootCtx, 1, &entities.RegionUpdate{Name: "Region A", Geometry: testPolygon})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestRegionService_Delete(t *testing.T) {
+	t.Run("should delete region", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		region := &entities.Region{ID: 1, Name: "Region A", Geometry: testPolygon}
+
+		repo.EXPECT().GetByID(rootCtx, int32(1)).Return(region, nil)
+		repo.EXPECT().Delete(rootCtx, int32(1)).Return(nil)
+
+		// when
+		err := svc.Delete(rootCtx, 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when region can not be deleted", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		region := &entities.Region{ID: 1, Name: "Region A"}
+		repo.EXPECT().GetByID(rootCtx, int32(1)).Return(region, nil)
+		repo.EXPECT().Delete(rootCtx, int32(1)).Return(errors.New("internal error"))
+
+		// when
+		err := svc.Delete(rootCtx, 1)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when region not found", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRegionRepository(t)
+		svc := NewRegionService(repo)
+
+		repo.EXPECT().GetByID(rootCtx, int32(1)).Return(nil, storage.ErrRegionNotFound)
+
+		// when
+		err := svc.Delete(rootCtx, 1)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
 func TestReady(t *testing.T) {
 	t.Run("should return true if the service is ready", func(t *testing.T) {
 		// given
@@ -0,0 +1,33 @@
+package report
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// link returns the download url of a report. The signature covers the object name and the expiry,
+// so neither can be changed without invalidating the link.
+func (s *ReportService) link(name string, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	return fmt.Sprintf("%s/api/v1/report/download/%s?expires=%d&signature=%s", s.appURL, url.PathEscape(name), expires, s.sign(name, expires))
+}
+
+func (s *ReportService) sign(name string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(name + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify reports if the signature matches and the link has not expired
+func (s *ReportService) verify(name string, expires int64, signature string) bool {
+	if s.now().Unix() > expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(s.sign(name, expires)), []byte(signature))
+}
@@ -0,0 +1,239 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+const (
+	pageMargin = 15.0
+	lineHeight = 6.0
+)
+
+// document is an A4 page layout shared by all reports. The core fonts only support cp1252, so all text
+// is translated from UTF-8 which keeps the german umlauts.
+type document struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+}
+
+type column struct {
+	title      string
+	width      float64
+	alignRight bool
+}
+
+type mapPoint struct {
+	label string
+	lat   float64
+	lon   float64
+}
+
+func newDocument(title string) *document {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("Green Ecolution", true)
+	pdf.AliasNbPages("")
+
+	d := &document{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pageMargin + 5)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(0, 4, d.tr(fmt.Sprintf("%s - page %d/{nb}", title, pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	return d
+}
+
+func (d *document) heading(text string) {
+	d.pdf.SetFont("Helvetica", "B", 16)
+	d.pdf.MultiCell(0, 8, d.tr(text), "", "L", false)
+	d.pdf.Ln(2)
+}
+
+func (d *document) subheading(text string) {
+	d.pdf.Ln(4)
+	d.pdf.SetFont("Helvetica", "B", 12)
+	d.pdf.CellFormat(0, 7, d.tr(text), "B", 1, "L", false, 0, "")
+	d.pdf.Ln(2)
+}
+
+func (d *document) keyValues(values [][2]string) {
+	for _, kv := range values {
+		d.pdf.SetFont("Helvetica", "B", 10)
+		d.pdf.CellFormat(40, lineHeight, d.tr(kv[0]), "", 0, "L", false, 0, "")
+		d.pdf.SetFont("Helvetica", "", 10)
+		d.pdf.MultiCell(0, lineHeight, d.tr(kv[1]), "", "L", false)
+	}
+}
+
+// table prints the rows below a header, the header is repeated on every new page
+func (d *document) table(columns []column, rows [][]string) {
+	header := func() {
+		d.pdf.SetFont("Helvetica", "B", 9)
+		d.pdf.SetFillColor(225, 235, 220)
+		for _, c := range columns {
+			d.pdf.CellFormat(c.width, lineHeight, d.tr(c.title), "1", 0, align(c), true, 0, "")
+		}
+		d.pdf.Ln(-1)
+		d.pdf.SetFont("Helvetica", "", 9)
+	}
+
+	_, pageHeight := d.pdf.GetPageSize()
+	header()
+	for _, row := range rows {
+		if d.pdf.GetY()+lineHeight > pageHeight-pageMargin {
+			d.pdf.AddPage()
+			header()
+		}
+		for i, c := range columns {
+			d.pdf.CellFormat(c.width, lineHeight, d.fit(row[i], c.width-2), "1", 0, align(c), false, 0, "")
+		}
+		d.pdf.Ln(-1)
+	}
+}
+
+// signatures prints a name, date and signature field for every crew member and two empty ones for substitutes
+func (d *document) signatures(names []string) {
+	names = append(slices.Clone(names), "", "")
+	d.pdf.SetFont("Helvetica", "", 10)
+	for _, name := range names {
+		d.pdf.Ln(8)
+		d.pdf.CellFormat(60, lineHeight, d.tr(name), "B", 0, "L", false, 0, "")
+		d.pdf.CellFormat(10, lineHeight, "", "", 0, "L", false, 0, "")
+		d.pdf.CellFormat(30, lineHeight, "", "B", 0, "L", false, 0, "")
+		d.pdf.CellFormat(10, lineHeight, "", "", 0, "L", false, 0, "")
+		d.pdf.CellFormat(0, lineHeight, "", "B", 1, "L", false, 0, "")
+		d.pdf.SetFont("Helvetica", "", 7)
+		d.pdf.CellFormat(70, 4, "Name", "", 0, "L", false, 0, "")
+		d.pdf.CellFormat(40, 4, "Date", "", 0, "L", false, 0, "")
+		d.pdf.CellFormat(0, 4, "Signature", "", 1, "L", false, 0, "")
+		d.pdf.SetFont("Helvetica", "", 10)
+	}
+}
+
+// routeMap draws the route and the numbered stops into a box of the given height. The coordinates are
+// projected equirectangular around the center, which is accurate enough for the extent of a city.
+func (d *document) routeMap(route *entities.GeoJSON, stops []mapPoint, height float64) {
+	pageWidth, pageHeight := d.pdf.GetPageSize()
+	if d.pdf.GetY()+height > pageHeight-pageMargin {
+		d.pdf.AddPage()
+	}
+	x, y := pageMargin, d.pdf.GetY()
+	width := pageWidth - 2*pageMargin
+	d.pdf.SetDrawColor(160, 160, 160)
+	d.pdf.SetLineWidth(0.2)
+	d.pdf.Rect(x, y, width, height, "D")
+	defer d.pdf.SetXY(pageMargin, y+height+2)
+
+	lines := make([][]mapPoint, 0)
+	if route != nil {
+		for _, f := range route.Features {
+			line := make([]mapPoint, 0, len(f.Geometry.Coordinates))
+			for _, c := range f.Geometry.Coordinates {
+				if len(c) >= 2 {
+					line = append(line, mapPoint{lon: c[0], lat: c[1]})
+				}
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	all := append([]mapPoint{}, stops...)
+	for _, line := range lines {
+		all = append(all, line...)
+	}
+	if len(all) == 0 {
+		d.pdf.SetFont("Helvetica", "I", 10)
+		d.pdf.SetXY(x, y+height/2-lineHeight/2)
+		d.pdf.CellFormat(width, lineHeight, "No map available", "", 0, "C", false, 0, "")
+		return
+	}
+
+	project := projection(all, x, y, width, height, 8)
+	d.pdf.SetDrawColor(40, 110, 200)
+	d.pdf.SetLineWidth(0.8)
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			x1, y1 := project(line[i-1])
+			x2, y2 := project(line[i])
+			d.pdf.Line(x1, y1, x2, y2)
+		}
+	}
+
+	d.pdf.SetLineWidth(0.2)
+	d.pdf.SetDrawColor(255, 255, 255)
+	d.pdf.SetFillColor(60, 140, 60)
+	d.pdf.SetTextColor(255, 255, 255)
+	d.pdf.SetFont("Helvetica", "B", 7)
+	for _, s := range stops {
+		px, py := project(s)
+		d.pdf.Circle(px, py, 2.5, "FD")
+		d.pdf.SetXY(px-2.5, py-2)
+		d.pdf.CellFormat(5, 4, s.label, "", 0, "C", false, 0, "")
+	}
+	d.pdf.SetTextColor(0, 0, 0)
+	d.pdf.SetDrawColor(0, 0, 0)
+}
+
+func (d *document) output(w io.Writer) error {
+	if err := d.pdf.Output(w); err != nil {
+		return errors.Join(errors.New("failed to render pdf"), err)
+	}
+	return nil
+}
+
+// fit translates the text and shortens it to the width
+func (d *document) fit(text string, width float64) string {
+	text = d.tr(text)
+	if d.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && d.pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// projection maps the points into the box keeping the aspect ratio, the longitudes are scaled by the
+// cosine of the mean latitude
+func projection(points []mapPoint, x, y, width, height, padding float64) func(mapPoint) (float64, float64) {
+	minLat, maxLat := math.Inf(1), math.Inf(-1)
+	minLon, maxLon := math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		minLat, maxLat = math.Min(minLat, p.lat), math.Max(maxLat, p.lat)
+		minLon, maxLon = math.Min(minLon, p.lon), math.Max(maxLon, p.lon)
+	}
+
+	scaleLon := math.Cos((minLat + maxLat) / 2 * math.Pi / 180)
+	spanX := (maxLon - minLon) * scaleLon
+	spanY := maxLat - minLat
+	innerWidth, innerHeight := width-2*padding, height-2*padding
+
+	scale := 0.0
+	if spanX > 0 || spanY > 0 {
+		scale = math.Min(innerWidth/math.Max(spanX, 1e-9), innerHeight/math.Max(spanY, 1e-9))
+	}
+	offsetX := x + padding + (innerWidth-spanX*scale)/2
+	offsetY := y + padding + (innerHeight-spanY*scale)/2
+
+	return func(p mapPoint) (float64, float64) {
+		return offsetX + (p.lon-minLon)*scaleLon*scale, offsetY + (maxLat-p.lat)*scale
+	}
+}
+
+func align(c column) string {
+	if c.alignRight {
+		return "R"
+	}
+	return "L"
+}
@@ -0,0 +1,164 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type ReportService struct {
+	wateringPlanRepo  storage.WateringPlanRepository
+	treeClusterRepo   storage.TreeClusterRepository
+	userRepo          storage.UserRepository
+	routingRepo       storage.RoutingRepository
+	reportBucket      storage.S3Repository
+	evaluationService service.EvaluationService
+	signingKey        []byte
+	linkExpiry        time.Duration
+	appURL            string
+	now               func() time.Time
+}
+
+var _ service.ReportService = (*ReportService)(nil)
+
+func NewReportService(
+	wateringPlanRepo storage.WateringPlanRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+	userRepo storage.UserRepository,
+	routingRepo storage.RoutingRepository,
+	reportBucket storage.S3Repository,
+	evaluationService service.EvaluationService,
+	cfg config.ReportConfig,
+	appURL string,
+) *ReportService {
+	signingKey := []byte(cfg.SigningKey)
+	if len(signingKey) == 0 {
+		slog.Warn("no signing key for report download links is configured, the links are only valid until the next restart of this instance")
+		signingKey = make([]byte, 32)
+		_, _ = rand.Read(signingKey)
+	}
+
+	return &ReportService{
+		wateringPlanRepo:  wateringPlanRepo,
+		treeClusterRepo:   treeClusterRepo,
+		userRepo:          userRepo,
+		routingRepo:       routingRepo,
+		reportBucket:      reportBucket,
+		evaluationService: evaluationService,
+		signingKey:        signingKey,
+		linkExpiry:        cfg.LinkExpiry,
+		appURL:            appURL,
+		now:               time.Now,
+	}
+}
+
+func (s *ReportService) CreateWateringPlanReport(ctx context.Context, id int32, format entities.ReportFormat) (*entities.Report, error) {
+	log := logger.GetLogger(ctx)
+	if !format.IsValid() {
+		return nil, service.ErrReportFormatInvalid
+	}
+
+	sheet, err := s.routeSheet(ctx, id)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	var buf bytes.Buffer
+	if format == entities.ReportFormatCSV {
+		err = sheet.writeCSV(&buf)
+	} else {
+		err = sheet.writePDF(&buf)
+	}
+	if err != nil {
+		log.Error("failed to render watering plan report", "error", err, "watering_plan_id", id, "format", format)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return s.store(ctx, entities.ReportKindWateringPlan, fmt.Sprintf("watering-plan-%d", id), format, &buf)
+}
+
+func (s *ReportService) CreateSeasonReport(ctx context.Context, year int, format entities.ReportFormat) (*entities.Report, error) {
+	log := logger.GetLogger(ctx)
+	if !format.IsValid() {
+		return nil, service.ErrReportFormatInvalid
+	}
+	if year < 2000 || year > s.now().Year() {
+		log.Debug("requested season of report is out of range", "year", year)
+		return nil, service.ErrReportYearInvalid
+	}
+
+	season, err := s.season(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if format == entities.ReportFormatCSV {
+		err = season.writeCSV(&buf)
+	} else {
+		err = season.writePDF(&buf)
+	}
+	if err != nil {
+		log.Error("failed to render season report", "error", err, "year", year, "format", format)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return s.store(ctx, entities.ReportKindSeason, fmt.Sprintf("season-%d", year), format, &buf)
+}
+
+func (s *ReportService) Download(ctx context.Context, name string, expires int64, signature string) (io.ReadSeekCloser, error) {
+	log := logger.GetLogger(ctx)
+	if !s.verify(name, expires, signature) {
+		log.Debug("rejected download link of report", "name", name, "expires", expires)
+		return nil, service.ErrReportLinkInvalid
+	}
+
+	r, err := s.reportBucket.GetObject(ctx, name)
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	return r, nil
+}
+
+// store uploads the rendered report with a unique name and returns it with a signed download link
+func (s *ReportService) store(ctx context.Context, kind entities.ReportKind, prefix string, format entities.ReportFormat, buf *bytes.Buffer) (*entities.Report, error) {
+	log := logger.GetLogger(ctx)
+	now := s.now()
+	name := fmt.Sprintf("%s-%s.%s", prefix, now.UTC().Format("20060102T150405Z"), format)
+
+	if err := s.reportBucket.PutObject(ctx, name, format.ContentType(), int64(buf.Len()), buf); err != nil {
+		log.Error("failed to upload report to bucket", "error", err, "obj_name", name)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	expiresAt := now.Add(s.linkExpiry).Truncate(time.Second)
+	log.Info("report successfully uploaded to s3 bucket", "obj_name", name, "kind", kind)
+	return &entities.Report{
+		Name:      name,
+		Kind:      kind,
+		Format:    format,
+		CreatedAt: now,
+		URL:       s.link(name, expiresAt),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *ReportService) Ready() bool {
+	return s.wateringPlanRepo != nil &&
+		s.treeClusterRepo != nil &&
+		s.userRepo != nil &&
+		s.routingRepo != nil &&
+		s.reportBucket != nil &&
+		s.evaluationService != nil
+}
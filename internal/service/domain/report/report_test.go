@@ -0,0 +1,407 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	reportNow = time.Date(2025, time.June, 11, 14, 30, 0, 0, time.UTC)
+	userID    = uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+)
+
+type mocks struct {
+	wateringPlanRepo  *storageMock.MockWateringPlanRepository
+	treeClusterRepo   *storageMock.MockTreeClusterRepository
+	userRepo          *storageMock.MockUserRepository
+	routingRepo       *storageMock.MockRoutingRepository
+	reportBucket      *storageMock.MockS3Repository
+	evaluationService *serviceMock.MockEvaluationService
+}
+
+func newReportService(t *testing.T) (*ReportService, *mocks) {
+	m := &mocks{
+		wateringPlanRepo:  storageMock.NewMockWateringPlanRepository(t),
+		treeClusterRepo:   storageMock.NewMockTreeClusterRepository(t),
+		userRepo:          storageMock.NewMockUserRepository(t),
+		routingRepo:       storageMock.NewMockRoutingRepository(t),
+		reportBucket:      storageMock.NewMockS3Repository(t),
+		evaluationService: serviceMock.NewMockEvaluationService(t),
+	}
+	svc := NewReportService(m.wateringPlanRepo, m.treeClusterRepo, m.userRepo, m.routingRepo, m.reportBucket, m.evaluationService, config.ReportConfig{
+		SigningKey: "secret",
+		LinkExpiry: time.Hour,
+	}, "https://app.green-ecolution.de")
+	svc.now = func() time.Time { return reportNow }
+	return svc, m
+}
+
+func testClusters() []*entities.TreeCluster {
+	return []*entities.TreeCluster{
+		{
+			ID: 2, Name: "Hafen", Address: "Am Hafen 1",
+			Latitude: utils.P(54.79), Longitude: utils.P(9.44),
+			Trees: []*entities.Tree{{ID: 1}, {ID: 2}},
+		},
+		{
+			ID: 1, Name: "Mürwik", Address: "Kielseng 7",
+			Latitude: utils.P(54.81), Longitude: utils.P(9.46),
+			Trees: []*entities.Tree{{ID: 3}},
+		},
+	}
+}
+
+func testWateringPlan() *entities.WateringPlan {
+	return &entities.WateringPlan{
+		ID:                 1,
+		Date:               reportNow,
+		Status:             entities.WateringPlanStatusFinished,
+		Distance:           utils.P(12.5),
+		TotalWaterRequired: utils.P(240.0),
+		Transporter:        &entities.Vehicle{ID: 1, NumberPlate: "FL TBZ 1"},
+		UserIDs:            []*uuid.UUID{&userID},
+		TreeClusters:       []*entities.TreeCluster{{ID: 1}, {ID: 2}},
+		Evaluation:         []*entities.EvaluationValue{{WateringPlanID: 1, TreeClusterID: 2, ConsumedWater: utils.P(150.0)}},
+	}
+}
+
+func testRoute() *entities.GeoJSON {
+	return &entities.GeoJSON{
+		Features: []entities.GeoJSONFeature{{
+			Geometry: entities.GeoJSONGeometry{Coordinates: [][]float64{{9.43, 54.78}, {9.44, 54.79}, {9.46, 54.81}}},
+		}},
+	}
+}
+
+// expectRouteSheet expects the repositories read for the route sheet of the test watering plan, the clusters are
+// returned in a different order than planned
+func expectRouteSheet(ctx context.Context, m *mocks, routeErr error) {
+	m.wateringPlanRepo.EXPECT().GetByID(ctx, int32(1)).Return(testWateringPlan(), nil)
+	m.treeClusterRepo.EXPECT().GetByIDs(ctx, []int32{1, 2}).Return(testClusters(), nil)
+	m.userRepo.EXPECT().GetByIDs(ctx, []string{userID.String()}).Return([]*entities.User{{ID: userID, FirstName: "Toni", LastName: "Tester"}}, nil)
+	if routeErr != nil {
+		m.routingRepo.EXPECT().GenerateRoute(ctx, mock.Anything, mock.Anything).Return(nil, routeErr)
+	} else {
+		m.routingRepo.EXPECT().GenerateRoute(ctx, mock.Anything, mock.Anything).Return(testRoute(), nil)
+	}
+}
+
+// expectUpload captures the uploaded report
+func expectUpload(ctx context.Context, m *mocks, name, contentType string) *bytes.Buffer {
+	var uploaded bytes.Buffer
+	m.reportBucket.EXPECT().PutObject(ctx, name, contentType, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _, _ string, _ int64, r io.Reader) error {
+			_, err := io.Copy(&uploaded, r)
+			return err
+		})
+	return &uploaded
+}
+
+func TestReportService_CreateWateringPlanReport(t *testing.T) {
+	t.Run("should store the route sheet as csv in the order of the watering plan", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		expectRouteSheet(ctx, m, nil)
+		uploaded := expectUpload(ctx, m, "watering-plan-1-20250611T143000Z.csv", "text/csv;charset=UTF-8")
+
+		// when
+		got, err := svc.CreateWateringPlanReport(ctx, 1, entities.ReportFormatCSV)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "watering-plan-1-20250611T143000Z.csv", got.Name)
+		assert.Equal(t, entities.ReportKindWateringPlan, got.Kind)
+		assert.Equal(t, entities.ReportFormatCSV, got.Format)
+		assert.Equal(t, reportNow.Add(time.Hour), got.ExpiresAt)
+		expires := reportNow.Add(time.Hour).Unix()
+		assert.Equal(t, fmt.Sprintf("https://app.green-ecolution.de/api/v1/report/download/watering-plan-1-20250611T143000Z.csv?expires=%d&signature=%s",
+			expires, svc.sign("watering-plan-1-20250611T143000Z.csv", expires)), got.URL)
+		assert.Equal(t, strings.Join([]string{
+			"stop,tree_cluster_id,tree_cluster,address,latitude,longitude,trees,litres_planned,litres_consumed",
+			"1,1,Mürwik,Kielseng 7,54.810000,9.460000,1,80,",
+			"2,2,Hafen,Am Hafen 1,54.790000,9.440000,2,160,150",
+			"",
+		}, "\n"), uploaded.String())
+	})
+
+	t.Run("should store the route sheet as pdf", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		expectRouteSheet(ctx, m, nil)
+		uploaded := expectUpload(ctx, m, "watering-plan-1-20250611T143000Z.pdf", "application/pdf")
+
+		// when
+		got, err := svc.CreateWateringPlanReport(ctx, 1, entities.ReportFormatPDF)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.ReportFormatPDF, got.Format)
+		assert.True(t, bytes.HasPrefix(uploaded.Bytes(), []byte("%PDF")))
+	})
+
+	t.Run("should create the report without map when the route fails", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		expectRouteSheet(ctx, m, errors.New("routing service unavailable"))
+		uploaded := expectUpload(ctx, m, "watering-plan-1-20250611T143000Z.pdf", "application/pdf")
+
+		// when
+		_, err := svc.CreateWateringPlanReport(ctx, 1, entities.ReportFormatPDF)
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(uploaded.Bytes(), []byte("%PDF")))
+	})
+
+	t.Run("should return error when format is invalid", func(t *testing.T) {
+		// given
+		svc, _ := newReportService(t)
+
+		// when
+		got, err := svc.CreateWateringPlanReport(context.Background(), 1, entities.ReportFormat("xlsx"))
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrReportFormatInvalid)
+	})
+
+	t.Run("should return not found when watering plan does not exist", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		m.wateringPlanRepo.EXPECT().GetByID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.CreateWateringPlanReport(ctx, 1, entities.ReportFormatPDF)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, service.NotFound, svcErr.Code)
+		}
+	})
+
+	t.Run("should return error when upload fails", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		expectRouteSheet(ctx, m, nil)
+		m.reportBucket.EXPECT().PutObject(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("bucket unavailable"))
+
+		// when
+		got, err := svc.CreateWateringPlanReport(ctx, 1, entities.ReportFormatCSV)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestReportService_CreateSeasonReport(t *testing.T) {
+	period := func(month time.Month, plans int64) *entities.EvaluationPeriod {
+		return &entities.EvaluationPeriod{
+			Start:             time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC),
+			WateringPlanCount: plans,
+			FinishedCount:     plans,
+			WaterConsumed:     float64(plans) * 100,
+			TreesWatered:      plans * 2,
+			LitresPerTree:     utils.P(50.0),
+		}
+	}
+	group := func(key, name string) *entities.EvaluationGroupSeries {
+		return &entities.EvaluationGroupSeries{
+			Key:     key,
+			Name:    name,
+			Total:   period(time.January, 3),
+			Periods: []*entities.EvaluationPeriod{period(time.May, 1), period(time.June, 2)},
+		}
+	}
+	expectSeries := func(ctx context.Context, m *mocks) {
+		from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)
+		for groupBy, groups := range map[entities.EvaluationGroupBy][]*entities.EvaluationGroupSeries{
+			entities.EvaluationGroupByNone:    {group("", "")},
+			entities.EvaluationGroupByRegion:  {group("1", "Mürwik"), group("", "")},
+			entities.EvaluationGroupByVehicle: {group("1", "FL TBZ 1")},
+		} {
+			m.evaluationService.EXPECT().GetSeries(ctx, &entities.EvaluationSeriesQuery{
+				From: &from, To: &to, Interval: entities.EvaluationIntervalMonth, GroupBy: groupBy,
+			}).Return(&entities.EvaluationSeries{Groups: groups}, nil)
+		}
+	}
+
+	t.Run("should store the season as csv with a row per month and group", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		expectSeries(ctx, m)
+		uploaded := expectUpload(ctx, m, "season-2025-20250611T143000Z.csv", "text/csv;charset=UTF-8")
+
+		// when
+		got, err := svc.CreateSeasonReport(ctx, 2025, entities.ReportFormatCSV)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.ReportKindSeason, got.Kind)
+		lines := strings.Split(strings.TrimSpace(uploaded.String()), "\n")
+		assert.Len(t, lines, 1+4*3)
+		assert.Equal(t, "all,,,2025-05,1,1,0,100.00,0.00,0.00,0.00,2,50.00,", lines[1])
+		assert.Equal(t, "all,,,total,3,3,0,300.00,0.00,0.00,0.00,6,50.00,", lines[3])
+		assert.Equal(t, "region,1,Mürwik,2025-06,2,2,0,200.00,0.00,0.00,0.00,4,50.00,", lines[5])
+		assert.Equal(t, "vehicle,1,FL TBZ 1,total,3,3,0,300.00,0.00,0.00,0.00,6,50.00,", lines[12])
+	})
+
+	t.Run("should store the season as pdf", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		expectSeries(ctx, m)
+		uploaded := expectUpload(ctx, m, "season-2025-20250611T143000Z.pdf", "application/pdf")
+
+		// when
+		_, err := svc.CreateSeasonReport(ctx, 2025, entities.ReportFormatPDF)
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(uploaded.Bytes(), []byte("%PDF")))
+	})
+
+	t.Run("should return error when year is out of range", func(t *testing.T) {
+		for _, year := range []int{1999, 2026} {
+			// given
+			svc, _ := newReportService(t)
+
+			// when
+			got, err := svc.CreateSeasonReport(context.Background(), year, entities.ReportFormatPDF)
+
+			// then
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, service.ErrReportYearInvalid)
+		}
+	})
+
+	t.Run("should return error when evaluation fails", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		m.evaluationService.EXPECT().GetSeries(ctx, mock.Anything).Return(nil, service.ErrEvaluationQueryInvalid)
+
+		// when
+		got, err := svc.CreateSeasonReport(ctx, 2025, entities.ReportFormatCSV)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrEvaluationQueryInvalid)
+	})
+}
+
+func TestReportService_Download(t *testing.T) {
+	name := "season-2025-20250611T143000Z.pdf"
+	parseLink := func(t *testing.T, link string) (int64, string) {
+		u, err := url.Parse(link)
+		assert.NoError(t, err)
+		expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+		assert.NoError(t, err)
+		return expires, u.Query().Get("signature")
+	}
+
+	t.Run("should return the report of a valid link", func(t *testing.T) {
+		// given
+		svc, m := newReportService(t)
+		ctx := context.Background()
+		expires, signature := parseLink(t, svc.link(name, reportNow.Add(time.Hour)))
+		m.reportBucket.EXPECT().GetObject(ctx, name).Return(nil, nil)
+
+		// when
+		_, err := svc.Download(ctx, name, expires, signature)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject a link of another report", func(t *testing.T) {
+		// given
+		svc, _ := newReportService(t)
+		expires, signature := parseLink(t, svc.link(name, reportNow.Add(time.Hour)))
+
+		// when
+		_, err := svc.Download(context.Background(), "season-2024-20250611T143000Z.pdf", expires, signature)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrReportLinkInvalid)
+	})
+
+	t.Run("should reject a link with changed expiry", func(t *testing.T) {
+		// given
+		svc, _ := newReportService(t)
+		expires, signature := parseLink(t, svc.link(name, reportNow.Add(time.Hour)))
+
+		// when
+		_, err := svc.Download(context.Background(), name, expires+3600, signature)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrReportLinkInvalid)
+	})
+
+	t.Run("should reject an expired link", func(t *testing.T) {
+		// given
+		svc, _ := newReportService(t)
+		expires, signature := parseLink(t, svc.link(name, reportNow.Add(-time.Second)))
+
+		// when
+		_, err := svc.Download(context.Background(), name, expires, signature)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrReportLinkInvalid)
+	})
+
+	t.Run("should reject a link signed with another key", func(t *testing.T) {
+		// given
+		svc, _ := newReportService(t)
+		other, _ := newReportService(t)
+		other.signingKey = []byte("other")
+		expires, signature := parseLink(t, other.link(name, reportNow.Add(time.Hour)))
+
+		// when
+		_, err := svc.Download(context.Background(), name, expires, signature)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrReportLinkInvalid)
+	})
+}
+
+func TestReportService_Ready(t *testing.T) {
+	t.Run("should be ready with all dependencies", func(t *testing.T) {
+		svc, _ := newReportService(t)
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should not be ready without report bucket", func(t *testing.T) {
+		svc, _ := newReportService(t)
+		svc.reportBucket = nil
+		assert.False(t, svc.Ready())
+	})
+}
@@ -0,0 +1,200 @@
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// seasonReport holds the evaluation of a year per month for all watering plans, per region and per vehicle
+type seasonReport struct {
+	year     int
+	total    *entities.EvaluationGroupSeries
+	regions  []*entities.EvaluationGroupSeries
+	vehicles []*entities.EvaluationGroupSeries
+}
+
+func (s *ReportService) season(ctx context.Context, year int) (*seasonReport, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	series := func(groupBy entities.EvaluationGroupBy) ([]*entities.EvaluationGroupSeries, error) {
+		got, err := s.evaluationService.GetSeries(ctx, &entities.EvaluationSeriesQuery{
+			From:     &from,
+			To:       &to,
+			Interval: entities.EvaluationIntervalMonth,
+			GroupBy:  groupBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return got.Groups, nil
+	}
+
+	total, err := series(entities.EvaluationGroupByNone)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := series(entities.EvaluationGroupByRegion)
+	if err != nil {
+		return nil, err
+	}
+	vehicles, err := series(entities.EvaluationGroupByVehicle)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &seasonReport{year: year, regions: regions, vehicles: vehicles}
+	if len(total) > 0 {
+		report.total = total[0]
+	}
+	return report, nil
+}
+
+var seasonColumns = []string{
+	"group_by", "key", "name", "period", "watering_plans", "finished", "not_completed", "water_consumed",
+	"refills", "distance", "hours", "trees_watered", "litres_per_tree", "good_tree_percentage",
+}
+
+// writeCSV writes one row per month and a total row per group
+func (r *seasonReport) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(seasonColumns); err != nil {
+		return err
+	}
+
+	write := func(groupBy string, g *entities.EvaluationGroupSeries) error {
+		for _, p := range g.Periods {
+			if err := cw.Write(seasonRow(groupBy, g, p.Start.Format("2006-01"), p)); err != nil {
+				return err
+			}
+		}
+		return cw.Write(seasonRow(groupBy, g, "total", g.Total))
+	}
+
+	if r.total != nil {
+		if err := write("all", r.total); err != nil {
+			return err
+		}
+	}
+	for _, g := range r.regions {
+		if err := write(string(entities.EvaluationGroupByRegion), g); err != nil {
+			return err
+		}
+	}
+	for _, g := range r.vehicles {
+		if err := write(string(entities.EvaluationGroupByVehicle), g); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func seasonRow(groupBy string, g *entities.EvaluationGroupSeries, period string, p *entities.EvaluationPeriod) []string {
+	return []string{
+		groupBy,
+		g.Key,
+		g.Name,
+		period,
+		strconv.FormatInt(p.WateringPlanCount, 10),
+		strconv.FormatInt(p.FinishedCount, 10),
+		strconv.FormatInt(p.NotCompletedCount, 10),
+		strconv.FormatFloat(p.WaterConsumed, 'f', 2, 64),
+		strconv.FormatFloat(p.Refills, 'f', 2, 64),
+		strconv.FormatFloat(p.Distance, 'f', 2, 64),
+		strconv.FormatFloat(p.Hours, 'f', 2, 64),
+		strconv.FormatInt(p.TreesWatered, 10),
+		formatOptionalFloat(p.LitresPerTree, 2),
+		formatOptionalFloat(p.GoodTreePercentage, 2),
+	}
+}
+
+func (r *seasonReport) writePDF(w io.Writer) error {
+	d := newDocument(fmt.Sprintf("Season report %d", r.year))
+	d.heading(fmt.Sprintf("Season report %d", r.year))
+
+	if r.total != nil {
+		t := r.total.Total
+		d.keyValues([][2]string{
+			{"Watering plans", strconv.FormatInt(t.WateringPlanCount, 10)},
+			{"Finished", strconv.FormatInt(t.FinishedCount, 10)},
+			{"Not completed", strconv.FormatInt(t.NotCompletedCount, 10)},
+			{"Water consumed", strconv.FormatFloat(t.WaterConsumed, 'f', 0, 64) + " l"},
+			{"Trees watered", strconv.FormatInt(t.TreesWatered, 10)},
+			{"Litres per tree", formatOptionalFloat(t.LitresPerTree, 1)},
+			{"Trees in good status", percentage(t.GoodTreePercentage)},
+			{"Refills", strconv.FormatFloat(t.Refills, 'f', 0, 64)},
+			{"Hours", strconv.FormatFloat(t.Hours, 'f', 1, 64)},
+		})
+
+		d.subheading("Months")
+		rows := make([][]string, 0, len(r.total.Periods))
+		for _, p := range r.total.Periods {
+			rows = append(rows, periodRow(p.Start.Format("01/2006"), p))
+		}
+		d.table(periodColumns("Month"), rows)
+	}
+
+	d.subheading("Regions")
+	d.table(periodColumns("Region"), groupRows(r.regions, "Without region"))
+
+	d.subheading("Vehicles")
+	d.table(periodColumns("Vehicle"), groupRows(r.vehicles, ""))
+
+	return d.output(w)
+}
+
+func periodColumns(first string) []column {
+	return []column{
+		{title: first, width: 40},
+		{title: "Plans", width: 16, alignRight: true},
+		{title: "Finished", width: 18, alignRight: true},
+		{title: "Not compl.", width: 20, alignRight: true},
+		{title: "Water l", width: 24, alignRight: true},
+		{title: "Trees", width: 16, alignRight: true},
+		{title: "l/tree", width: 16, alignRight: true},
+		{title: "Good", width: 16, alignRight: true},
+		{title: "Hours", width: 14, alignRight: true},
+	}
+}
+
+func periodRow(first string, p *entities.EvaluationPeriod) []string {
+	return []string{
+		first,
+		strconv.FormatInt(p.WateringPlanCount, 10),
+		strconv.FormatInt(p.FinishedCount, 10),
+		strconv.FormatInt(p.NotCompletedCount, 10),
+		strconv.FormatFloat(p.WaterConsumed, 'f', 0, 64),
+		strconv.FormatInt(p.TreesWatered, 10),
+		formatOptionalFloat(p.LitresPerTree, 1),
+		percentage(p.GoodTreePercentage),
+		strconv.FormatFloat(p.Hours, 'f', 1, 64),
+	}
+}
+
+// groupRows returns the total row of every group, groups without key are named by fallback
+func groupRows(groups []*entities.EvaluationGroupSeries, fallback string) [][]string {
+	rows := make([][]string, 0, len(groups))
+	for _, g := range groups {
+		name := g.Name
+		if g.Key == "" {
+			name = fallback
+		}
+		rows = append(rows, periodRow(name, g.Total))
+	}
+	return rows
+}
+
+func percentage(p *float64) string {
+	if p == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*p, 'f', 0, 64) + " %"
+}
@@ -0,0 +1,223 @@
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+)
+
+// litresPerTree is the estimate of the water a tree needs per watering, the same estimate is used for the
+// total water required of a watering plan
+const litresPerTree = 80.0
+
+// routeSheet holds everything printed on the route sheet of a watering plan
+type routeSheet struct {
+	plan  *entities.WateringPlan
+	stops []*stop
+	crew  []string
+	route *entities.GeoJSON
+}
+
+// stop is a tree cluster of the watering plan in the order of the watering plan
+type stop struct {
+	cluster       *entities.TreeCluster
+	trees         int
+	litresPlanned float64
+	litresUsed    *float64
+}
+
+func (s *ReportService) routeSheet(ctx context.Context, id int32) (*routeSheet, error) {
+	log := logger.GetLogger(ctx)
+	plan, err := s.wateringPlanRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get watering plan of report", "error", err, "watering_plan_id", id)
+		return nil, err
+	}
+
+	ids := make([]int32, 0, len(plan.TreeClusters))
+	for _, tc := range plan.TreeClusters {
+		ids = append(ids, tc.ID)
+	}
+	clusters := make([]*entities.TreeCluster, 0)
+	if len(ids) > 0 {
+		if clusters, err = s.treeClusterRepo.GetByIDs(ctx, ids); err != nil {
+			log.Debug("failed to get tree clusters of report", "error", err, "watering_plan_id", id)
+			return nil, err
+		}
+	}
+
+	sheet := &routeSheet{
+		plan:  plan,
+		stops: stops(plan, clusters),
+		crew:  s.crew(ctx, plan),
+	}
+
+	if plan.Transporter != nil && len(clusters) > 0 {
+		route, err := s.routingRepo.GenerateRoute(ctx, plan.Transporter, clusters)
+		if err != nil {
+			// the route sheet is still useful without map, the stops are drawn anyway
+			log.Warn("failed to generate route of watering plan report, the map shows the stops only", "error", err, "watering_plan_id", id)
+		} else {
+			sheet.route = route
+		}
+	}
+
+	return sheet, nil
+}
+
+// stops orders the tree clusters like the watering plan and adds the planned and consumed water
+func stops(plan *entities.WateringPlan, clusters []*entities.TreeCluster) []*stop {
+	byID := make(map[int32]*entities.TreeCluster, len(clusters))
+	for _, tc := range clusters {
+		byID[tc.ID] = tc
+	}
+
+	consumed := make(map[int32]*float64, len(plan.Evaluation))
+	for _, e := range plan.Evaluation {
+		consumed[e.TreeClusterID] = e.ConsumedWater
+	}
+
+	result := make([]*stop, 0, len(plan.TreeClusters))
+	for _, tc := range plan.TreeClusters {
+		cluster, ok := byID[tc.ID]
+		if !ok {
+			cluster = tc
+		}
+		result = append(result, &stop{
+			cluster:       cluster,
+			trees:         len(cluster.Trees),
+			litresPlanned: float64(len(cluster.Trees)) * litresPerTree,
+			litresUsed:    consumed[tc.ID],
+		})
+	}
+	return result
+}
+
+// crew returns the names of the users of the watering plan. The signature fields are printed even if the
+// names can not be resolved, the crew writes them by hand then.
+func (s *ReportService) crew(ctx context.Context, plan *entities.WateringPlan) []string {
+	log := logger.GetLogger(ctx)
+	ids := make([]string, 0, len(plan.UserIDs))
+	for _, id := range plan.UserIDs {
+		if id != nil {
+			ids = append(ids, id.String())
+		}
+	}
+	names := make([]string, len(ids))
+	if len(ids) == 0 {
+		return names
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		log.Warn("failed to get users of watering plan report, the signature fields are printed without names", "error", err, "watering_plan_id", plan.ID)
+		return names
+	}
+
+	byID := make(map[string]*entities.User, len(users))
+	for _, u := range users {
+		byID[u.ID.String()] = u
+	}
+	for i, id := range ids {
+		if u, ok := byID[id]; ok {
+			names[i] = strings.TrimSpace(u.FirstName + " " + u.LastName)
+		}
+	}
+	return names
+}
+
+func (r *routeSheet) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"stop", "tree_cluster_id", "tree_cluster", "address", "latitude", "longitude", "trees", "litres_planned", "litres_consumed"}); err != nil {
+		return err
+	}
+
+	for i, s := range r.stops {
+		if err := cw.Write([]string{
+			strconv.Itoa(i + 1),
+			strconv.Itoa(int(s.cluster.ID)),
+			s.cluster.Name,
+			s.cluster.Address,
+			formatOptionalFloat(s.cluster.Latitude, 6),
+			formatOptionalFloat(s.cluster.Longitude, 6),
+			strconv.Itoa(s.trees),
+			strconv.FormatFloat(s.litresPlanned, 'f', 0, 64),
+			formatOptionalFloat(s.litresUsed, 0),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (r *routeSheet) writePDF(w io.Writer) error {
+	d := newDocument(fmt.Sprintf("Watering plan %d", r.plan.ID))
+	d.heading(fmt.Sprintf("Watering plan #%d - %s", r.plan.ID, r.plan.Date.Format("Monday, 02.01.2006")))
+	d.keyValues([][2]string{
+		{"Status", string(r.plan.Status)},
+		{"Transporter", numberPlate(r.plan.Transporter)},
+		{"Trailer", numberPlate(r.plan.Trailer)},
+		{"Distance", formatOptionalFloat(r.plan.Distance, 1)},
+		{"Water required", formatOptionalFloat(r.plan.TotalWaterRequired, 0) + " l"},
+		{"Description", r.plan.Description},
+	})
+
+	d.subheading("Route")
+	points := make([]mapPoint, 0, len(r.stops))
+	for i, s := range r.stops {
+		if s.cluster.Latitude != nil && s.cluster.Longitude != nil {
+			points = append(points, mapPoint{label: strconv.Itoa(i + 1), lat: *s.cluster.Latitude, lon: *s.cluster.Longitude})
+		}
+	}
+	d.routeMap(r.route, points, 90)
+
+	d.subheading("Stops")
+	rows := make([][]string, 0, len(r.stops))
+	for i, s := range r.stops {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			s.cluster.Name,
+			s.cluster.Address,
+			strconv.Itoa(s.trees),
+			strconv.FormatFloat(s.litresPlanned, 'f', 0, 64),
+			formatOptionalFloat(s.litresUsed, 0),
+			"",
+		})
+	}
+	d.table([]column{
+		{title: "No.", width: 10},
+		{title: "Tree cluster", width: 50},
+		{title: "Address", width: 50},
+		{title: "Trees", width: 14, alignRight: true},
+		{title: "Planned l", width: 20, alignRight: true},
+		{title: "Used l", width: 18, alignRight: true},
+		{title: "Done", width: 18},
+	}, rows)
+
+	d.subheading("Crew")
+	d.signatures(r.crew)
+
+	return d.output(w)
+}
+
+func numberPlate(v *entities.Vehicle) string {
+	if v == nil {
+		return "-"
+	}
+	return v.NumberPlate
+}
+
+func formatOptionalFloat(f *float64, prec int) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', prec, 64)
+}
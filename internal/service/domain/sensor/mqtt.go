@@ -21,6 +21,22 @@ func (s *SensorService) HandleMessage(ctx context.Context, payload *domain.MqttP
 		return nil, err
 	}
 
+	// the sensor data and its event are stored together
+	var sensorData *domain.SensorData
+	err := s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		sensorData, err = s.handleMessage(ctx, payload)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sensorData, nil
+}
+
+func (s *SensorService) handleMessage(ctx context.Context, payload *domain.MqttPayload) (*domain.SensorData, error) {
+	log := logger.GetLogger(ctx)
 	sensor, err := s.sensorRepo.GetByID(ctx, payload.Device)
 	if err != nil {
 		var entityNotFoundErr storage.ErrEntityNotFound
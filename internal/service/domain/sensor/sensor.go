@@ -18,13 +18,13 @@ type SensorService struct {
 	sensorRepo   storage.SensorRepository
 	treeRepo     storage.TreeRepository
 	validator    *validator.Validate
-	eventManager *worker.EventManager
+	eventManager worker.EventBus
 }
 
 func NewSensorService(
 	sensorRepo storage.SensorRepository,
 	treeRepo storage.TreeRepository,
-	eventManager *worker.EventManager,
+	eventManager worker.EventBus,
 ) service.SensorService {
 	return &SensorService{
 		sensorRepo:   sensorRepo,
@@ -43,7 +43,16 @@ func (s *SensorService) publishNewSensorDataEvent(ctx context.Context, data *ent
 	}
 }
 
-func (s *SensorService) GetAll(ctx context.Context, query entities.Query) ([]*entities.Sensor, int64, error) {
+func (s *SensorService) publishUpdateEvent(ctx context.Context, prev, updated *entities.Sensor) {
+	log := logger.GetLogger(ctx)
+	log.Debug("publish new event", "event", entities.EventTypeUpdateSensor, "service", "SensorService")
+	event := entities.NewEventUpdateSensor(prev, updated)
+	if err := s.eventManager.Publish(ctx, event); err != nil {
+		log.Error("error while sending event after updating sensor status", "err", err, "sensor_id", updated.ID)
+	}
+}
+
+func (s *SensorService) GetAll(ctx context.Context, query entities.SensorQuery) ([]*entities.Sensor, int64, error) {
 	log := logger.GetLogger(ctx)
 	sensors, totalCount, err := s.sensorRepo.GetAll(ctx, query)
 
@@ -155,7 +164,7 @@ func (s *SensorService) Delete(ctx context.Context, id string) error {
 
 func (s *SensorService) UpdateStatuses(ctx context.Context) error {
 	log := logger.GetLogger(ctx)
-	sensors, _, err := s.sensorRepo.GetAll(ctx, entities.Query{})
+	sensors, _, err := s.sensorRepo.GetAll(ctx, entities.SensorQuery{})
 	if err != nil {
 		log.Error("failed to fetch sensors", "error", err)
 		return err
@@ -169,9 +178,19 @@ func (s *SensorService) UpdateStatuses(ctx context.Context) error {
 			continue
 		}
 		if sensorData.CreatedAt.Before(cutoffTime) {
-			_, err = s.sensorRepo.Update(ctx, sens.ID, func(s *entities.Sensor, _ storage.SensorRepository) (bool, error) {
-				s.Status = entities.SensorStatusOffline
-				return true, nil
+			err := s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+				updated, err := s.sensorRepo.Update(ctx, sens.ID, func(s *entities.Sensor, _ storage.SensorRepository) (bool, error) {
+					s.Status = entities.SensorStatusOffline
+					return true, nil
+				})
+				if err != nil {
+					return err
+				}
+
+				if sens.Status != entities.SensorStatusOffline {
+					s.publishUpdateEvent(ctx, sens, updated)
+				}
+				return nil
 			})
 
 			if err != nil {
@@ -5,42 +5,76 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/alert"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/apikey"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/auditlog"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/auth"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/evaluation"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/eventstream"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/export"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/info"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/job"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/notification"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/ogc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/planner"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/plugin"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/region"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/report"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/tile"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/treecluster"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/treeimport"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/vehicle"
 	wateringplan "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/watering_plan"
+	wateringplantemplate "github.com/green-ecolution/green-ecolution-backend/internal/service/domain/watering_plan_template"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/weather"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/webhook"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 )
 
-func NewService(cfg *config.Config, repos *storage.Repository, eventMananger *worker.EventManager) *service.Services {
+func NewService(cfg *config.Config, repos *storage.Repository, eventMananger worker.EventBus) *service.Services {
 	var authService service.AuthService
 	var pluginService service.PluginService
 	if cfg.IdentityAuth.Enable {
 		authService = auth.NewAuthService(repos.Auth, repos.User, &cfg.IdentityAuth)
-		pluginService = plugin.NewPluginManager(repos.Auth)
+		pluginService = plugin.NewPluginManager(repos.Auth, repos.Plugin)
 	} else {
 		slog.Warn("the auth service is disabled due to the configuration")
 		authService = auth.NewDummyAuthService(repos.User)
 		pluginService = plugin.NewDummyPluginManager()
 	}
 
+	wateringPlanService := wateringplan.NewWateringPlanService(repos.WateringPlan, repos.TreeCluster, repos.Vehicle, repos.User, eventMananger, repos.Routing, repos.GpxBucket)
+	weatherService := weather.NewWeatherService(repos.Weather, repos.WeatherProvider, repos.TreeCluster, repos.Region, eventMananger, cfg.Weather)
+	evaluationService := evaluation.NewEvaluationService(repos.TreeCluster, repos.Tree, repos.Sensor, repos.WateringPlan, repos.Vehicle, repos.Evaluation, repos.User)
+
 	return &service.Services{
-		InfoService:         info.NewInfoService(repos.Info),
-		TreeService:         tree.NewTreeService(repos.Tree, repos.Sensor, repos.TreeCluster, eventMananger),
-		AuthService:         authService,
-		RegionService:       region.NewRegionService(repos.Region),
-		TreeClusterService:  treecluster.NewTreeClusterService(repos.TreeCluster, repos.Tree, repos.Region, eventMananger),
-		VehicleService:      vehicle.NewVehicleService(repos.Vehicle),
-		SensorService:       sensor.NewSensorService(repos.Sensor, repos.Tree, eventMananger),
-		PluginService:       pluginService,
-		WateringPlanService: wateringplan.NewWateringPlanService(repos.WateringPlan, repos.TreeCluster, repos.Vehicle, repos.User, eventMananger, repos.Routing, repos.GpxBucket),
-		EvaluationService:   evaluation.NewEvaluationService(repos.TreeCluster, repos.Tree, repos.Sensor, repos.WateringPlan, repos.Vehicle),
+		InfoService:                 info.NewInfoService(repos.Info),
+		TreeService:                 tree.NewTreeService(repos.Tree, repos.Sensor, repos.TreeCluster, eventMananger),
+		AuthService:                 authService,
+		RegionService:               region.NewRegionService(repos.Region),
+		TreeClusterService:          treecluster.NewTreeClusterService(repos.TreeCluster, repos.Tree, repos.Region, eventMananger),
+		VehicleService:              vehicle.NewVehicleService(repos.Vehicle),
+		SensorService:               sensor.NewSensorService(repos.Sensor, repos.Tree, eventMananger),
+		PluginService:               pluginService,
+		WateringPlanService:         wateringPlanService,
+		EvaluationService:           evaluationService,
+		WebhookService:              webhook.NewWebhookService(repos.Webhook),
+		APIKeyService:               apikey.NewAPIKeyService(repos.APIKey),
+		TreeImportService:           treeimport.NewTreeImportService(repos.TreeImport, repos.Tree, repos.TreeCluster, repos.Sensor, eventMananger),
+		ExportService:               export.NewExportService(repos.Tree, repos.TreeCluster, repos.WateringPlan),
+		OGCService:                  ogc.NewOGCService(repos.Feature),
+		TileService:                 tile.NewTileService(repos.Feature),
+		AuditLogService:             auditlog.NewAuditLogService(repos.AuditLog),
+		EventStreamService:          eventstream.NewEventStreamService(repos.Event),
+		JobService:                  job.NewJobService(repos.Job, cfg.Scheduler),
+		WeatherService:              weatherService,
+		PlannerService:              planner.NewPlannerService(repos.TreeCluster, repos.Vehicle, repos.User, repos.WateringPlan, repos.Routing, weatherService, wateringPlanService, cfg.Planner),
+		WateringPlanTemplateService: wateringplantemplate.NewWateringPlanTemplateService(repos.WateringPlanTemplate, repos.TreeCluster, repos.Vehicle, wateringPlanService, cfg.WateringPlanTemplate),
+		ReportService:               report.NewReportService(repos.WateringPlan, repos.TreeCluster, repos.User, repos.Routing, repos.ReportBucket, evaluationService, cfg.Report, cfg.Server.AppURL),
+		NotificationService:         notification.NewNotificationService(repos.Notification, repos.User, repos.MailSender, repos.PushSender, cfg.Notification, cfg.Server.AppURL),
+		AlertService:                alert.NewAlertService(repos.Alert, repos.TreeCluster, repos.Sensor, repos.WateringPlan, repos.Region, eventMananger),
 	}
 }
@@ -0,0 +1,94 @@
+package tile
+
+import (
+	"context"
+	"math"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+const (
+	maxZoom int32 = 22
+	// earthCircumference is the width of the web mercator projection in meters
+	earthCircumference = 40075016.686
+	// tileExtent is the size of a tile in its own coordinate space
+	tileExtent = 4096
+)
+
+type layer struct {
+	// minZoom is the lowest zoom level the layer is rendered at, tiles below are empty
+	minZoom    int32
+	attributes []string
+}
+
+var layers = map[domain.OGCCollectionID]layer{
+	domain.OGCCollectionTrees: {
+		minZoom:    13,
+		attributes: []string{"number", "species", "watering_status", "tree_cluster_id", "sensor_id"},
+	},
+	domain.OGCCollectionTreeClusters: {
+		minZoom:    10,
+		attributes: []string{"name", "region", "watering_status", "moisture_level", "archived", "area"},
+	},
+	domain.OGCCollectionSensors: {
+		minZoom:    13,
+		attributes: []string{"status", "battery", "latest_data_at"},
+	},
+	domain.OGCCollectionRegions: {
+		minZoom:    0,
+		attributes: []string{"name"},
+	},
+}
+
+type TileService struct {
+	featureRepo storage.FeatureRepository
+}
+
+var _ service.TileService = (*TileService)(nil)
+
+func NewTileService(featureRepo storage.FeatureRepository) *TileService {
+	return &TileService{
+		featureRepo: featureRepo,
+	}
+}
+
+func (s *TileService) GetTile(ctx context.Context, layerID domain.OGCCollectionID, coord domain.TileCoordinate) ([]byte, error) {
+	log := logger.GetLogger(ctx)
+	l, ok := layers[layerID]
+	if !ok {
+		return nil, service.ErrTileLayerNotFound
+	}
+
+	if !coord.IsValid() || coord.Z > maxZoom {
+		return nil, service.ErrTileCoordinateInvalid
+	}
+
+	if coord.Z < l.minZoom {
+		return []byte{}, nil
+	}
+
+	tile, err := s.featureRepo.GetTile(ctx, layerID, &domain.TileQuery{
+		TileCoordinate: coord,
+		Attributes:     l.attributes,
+		Tolerance:      tolerance(coord.Z),
+	})
+	if err != nil {
+		log.Debug("failed to get tile", "error", err, "layer", layerID, "z", coord.Z, "x", coord.X, "y", coord.Y)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return tile, nil
+}
+
+// tolerance returns the size of one unit of the tile coordinate space in meters at the zoom level.
+// Geometries simplified with it look the same after they are snapped to the tile grid.
+func tolerance(zoom int32) float64 {
+	return earthCircumference / (tileExtent * math.Pow(2, float64(zoom)))
+}
+
+func (s *TileService) Ready() bool {
+	return s.featureRepo != nil
+}
@@ -0,0 +1,114 @@
+package tile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTileService_GetTile(t *testing.T) {
+	t.Run("should return tile with attributes of layer", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewTileService(repo)
+		coord := entities.TileCoordinate{Z: 15, X: 17247, Y: 10305}
+		expected := &entities.TileQuery{
+			TileCoordinate: coord,
+			Attributes:     layers[entities.OGCCollectionTrees].attributes,
+			Tolerance:      tolerance(15),
+		}
+		repo.EXPECT().GetTile(context.Background(), entities.OGCCollectionTrees, expected).Return([]byte{0x1a, 0x02}, nil)
+
+		// when
+		got, err := svc.GetTile(context.Background(), entities.OGCCollectionTrees, coord)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x1a, 0x02}, got)
+	})
+
+	t.Run("should return empty tile below min zoom of layer", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewTileService(repo)
+
+		// when
+		got, err := svc.GetTile(context.Background(), entities.OGCCollectionTrees, entities.TileCoordinate{Z: 5, X: 16, Y: 10})
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+		repo.AssertNotCalled(t, "GetTile")
+	})
+
+	t.Run("should return error when layer is unknown", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewTileService(repo)
+
+		// when
+		got, err := svc.GetTile(context.Background(), "vehicles", entities.TileCoordinate{Z: 15, X: 1, Y: 1})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrTileLayerNotFound)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when tile is out of range", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewTileService(repo)
+
+		for _, coord := range []entities.TileCoordinate{
+			{Z: 2, X: 4, Y: 0},
+			{Z: 2, X: 0, Y: -1},
+			{Z: 23, X: 0, Y: 0},
+		} {
+			// when
+			got, err := svc.GetTile(context.Background(), entities.OGCCollectionRegions, coord)
+
+			// then
+			assert.ErrorIs(t, err, service.ErrTileCoordinateInvalid)
+			assert.Nil(t, got)
+		}
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockFeatureRepository(t)
+		svc := NewTileService(repo)
+		repo.EXPECT().GetTile(context.Background(), entities.OGCCollectionRegions, mock.Anything).Return(nil, errors.New("internal error"))
+
+		// when
+		got, err := svc.GetTile(context.Background(), entities.OGCCollectionRegions, entities.TileCoordinate{Z: 0, X: 0, Y: 0})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTolerance(t *testing.T) {
+	t.Run("should halve tolerance with every zoom level", func(t *testing.T) {
+		assert.InDelta(t, 9783.94, tolerance(0), 0.01)
+		assert.InDelta(t, tolerance(10)/2, tolerance(11), 0.000001)
+	})
+}
+
+func TestTileService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		svc := NewTileService(storageMock.NewMockFeatureRepository(t))
+		assert.True(t, svc.Ready())
+	})
+
+	t.Run("should return false if the service is not ready", func(t *testing.T) {
+		svc := NewTileService(nil)
+		assert.False(t, svc.Ready())
+	})
+}
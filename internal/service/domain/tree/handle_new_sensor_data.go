@@ -35,10 +35,18 @@ func (s *TreeService) HandleNewSensorData(ctx context.Context, event *entities.E
 		log.Debug("sensor status has not changed", "sensor_status", status)
 		return nil
 	}
-	newTree, err := s.treeRepo.Update(ctx, t.ID, func(s *entities.Tree, _ storage.TreeRepository) (bool, error) {
-		log.Debug("updating tree watering status", "prev_status", t.WateringStatus, "new_status", status)
-		s.WateringStatus = status
-		return true, nil
+	err = s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		newTree, err := s.treeRepo.Update(ctx, t.ID, func(s *entities.Tree, _ storage.TreeRepository) (bool, error) {
+			log.Debug("updating tree watering status", "prev_status", t.WateringStatus, "new_status", status)
+			s.WateringStatus = status
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		s.publishUpdateTreeEvent(ctx, t, newTree, nil)
+		return nil
 	})
 
 	if err != nil {
@@ -47,7 +55,5 @@ func (s *TreeService) HandleNewSensorData(ctx context.Context, event *entities.E
 	}
 
 	log.Info("watering status of tree has been successfully updated", "tree_id", t.ID, "prev_status", t.WateringStatus, "new_status", status)
-
-	s.publishUpdateTreeEvent(ctx, t, newTree, nil)
 	return nil
 }
@@ -21,14 +21,14 @@ type TreeService struct {
 	sensorRepo      storage.SensorRepository
 	treeClusterRepo storage.TreeClusterRepository
 	validator       *validator.Validate
-	eventManager    *worker.EventManager
+	eventManager    worker.EventBus
 }
 
 func NewTreeService(
 	repoTree storage.TreeRepository,
 	repoSensor storage.SensorRepository,
 	treeClusterRepo storage.TreeClusterRepository,
-	eventManager *worker.EventManager,
+	eventManager worker.EventBus,
 ) service.TreeService {
 	return &TreeService{
 		treeRepo:        repoTree,
@@ -107,44 +107,54 @@ func (s *TreeService) Create(ctx context.Context, treeCreate *entities.TreeCreat
 	}
 
 	var prevTreeOfSensor *entities.Tree
-	newTree, err := s.treeRepo.Create(ctx, func(tree *entities.Tree, repo storage.TreeRepository) (bool, error) {
-		tree.PlantingYear = treeCreate.PlantingYear
-		tree.Species = treeCreate.Species
-		tree.Number = treeCreate.Number
-		tree.Latitude = treeCreate.Latitude
-		tree.Longitude = treeCreate.Longitude
-		tree.Provider = treeCreate.Provider
-		tree.AdditionalInfo = treeCreate.AdditionalInfo
-
-		if treeCreate.TreeClusterID != nil {
-			var err error
-			treeCluster, err := s.treeClusterRepo.GetByID(ctx, *treeCreate.TreeClusterID)
-			if err != nil {
-				log.Debug("failed to fetch tree cluster by id specified in the tree create request", "tree_cluster_id", treeCreate.TreeClusterID)
-				return false, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	var newTree *entities.Tree
+	err := s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		newTree, err = s.treeRepo.Create(ctx, func(tree *entities.Tree, repo storage.TreeRepository) (bool, error) {
+			tree.PlantingYear = treeCreate.PlantingYear
+			tree.Species = treeCreate.Species
+			tree.Number = treeCreate.Number
+			tree.Latitude = treeCreate.Latitude
+			tree.Longitude = treeCreate.Longitude
+			tree.Provider = treeCreate.Provider
+			tree.AdditionalInfo = treeCreate.AdditionalInfo
+
+			if treeCreate.TreeClusterID != nil {
+				var err error
+				treeCluster, err := s.treeClusterRepo.GetByID(ctx, *treeCreate.TreeClusterID)
+				if err != nil {
+					log.Debug("failed to fetch tree cluster by id specified in the tree create request", "tree_cluster_id", treeCreate.TreeClusterID)
+					return false, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+				}
+				tree.TreeCluster = treeCluster
 			}
-			tree.TreeCluster = treeCluster
-		}
 
-		if treeCreate.SensorID != nil {
-			sensor, err := s.sensorRepo.GetByID(ctx, *treeCreate.SensorID)
-			if err != nil {
-				log.Debug("failed to fetch sensor by id specified in the tree create request", "sensor_id", treeCreate.SensorID)
-				return false, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
-			}
-			tree.Sensor = sensor
-			prevTreeOfSensor, err = repo.GetBySensorID(ctx, sensor.ID)
-			if err != nil {
-				// If the previous tree that was linked to the sensor could not be found, the create process should still be continued.
-				log.Debug("failed to find previous tree linked to sensor specified from create request", "sensor_id", treeCreate.SensorID)
-			}
-			if sensor.LatestData != nil && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
-				status := utils.CalculateWateringStatus(ctx, treeCreate.PlantingYear, sensor.LatestData.Data.Watermarks)
-				tree.WateringStatus = status
+			if treeCreate.SensorID != nil {
+				sensor, err := s.sensorRepo.GetByID(ctx, *treeCreate.SensorID)
+				if err != nil {
+					log.Debug("failed to fetch sensor by id specified in the tree create request", "sensor_id", treeCreate.SensorID)
+					return false, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+				}
+				tree.Sensor = sensor
+				prevTreeOfSensor, err = repo.GetBySensorID(ctx, sensor.ID)
+				if err != nil {
+					// If the previous tree that was linked to the sensor could not be found, the create process should still be continued.
+					log.Debug("failed to find previous tree linked to sensor specified from create request", "sensor_id", treeCreate.SensorID)
+				}
+				if sensor.LatestData != nil && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
+					status := utils.CalculateWateringStatus(ctx, treeCreate.PlantingYear, sensor.LatestData.Data.Watermarks)
+					tree.WateringStatus = status
+				}
 			}
+
+			return true, nil
+		})
+		if err != nil {
+			return err
 		}
 
-		return true, nil
+		s.publishCreateTreeEvent(ctx, newTree, prevTreeOfSensor)
+		return nil
 	})
 
 	if err != nil {
@@ -153,7 +163,6 @@ func (s *TreeService) Create(ctx context.Context, treeCreate *entities.TreeCreat
 	}
 
 	slog.Info("tree created successfully", "tree_id", newTree.ID)
-	s.publishCreateTreeEvent(ctx, newTree, prevTreeOfSensor)
 	return newTree, nil
 }
 
@@ -163,13 +172,20 @@ func (s *TreeService) Delete(ctx context.Context, id int32) error {
 	if err != nil {
 		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
 	}
-	if err := s.treeRepo.Delete(ctx, id); err != nil {
+	err = s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.treeRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		s.publishDeleteTreeEvent(ctx, treeEntity)
+		return nil
+	})
+	if err != nil {
 		log.Debug("failed to delete tree", "error", err, "tree_id", id)
 		return service.MapError(ctx, err, service.ErrorLogAll)
 	}
 
 	slog.Info("tree deleted successfully", "tree_id", id)
-	s.publishDeleteTreeEvent(ctx, treeEntity)
 	return nil
 }
 
@@ -187,50 +203,60 @@ func (s *TreeService) Update(ctx context.Context, id int32, tu *entities.TreeUpd
 	}
 
 	var prevTreeOfSensor *entities.Tree
-	updatedTree, err := s.treeRepo.Update(ctx, id, func(tree *entities.Tree, repo storage.TreeRepository) (bool, error) {
-		tree.PlantingYear = tu.PlantingYear
-		tree.Species = tu.Species
-		tree.Number = tu.Number
-		tree.Latitude = tu.Latitude
-		tree.Longitude = tu.Longitude
-		tree.Description = tu.Description
-		tree.Provider = tu.Provider
-		tree.AdditionalInfo = tu.AdditionalInfo
-
-		if tu.TreeClusterID != nil {
-			treeCluster, err := s.treeClusterRepo.GetByID(ctx, *tu.TreeClusterID)
-			if err != nil {
-				log.Debug("failed to find tree cluster by id specified from update request", "tree_cluster_id", tu.TreeClusterID)
-				return false, service.MapError(ctx, fmt.Errorf("failed to find TreeCluster with ID %d: %w", *tu.TreeClusterID, err), service.ErrorLogEntityNotFound)
+	var updatedTree *entities.Tree
+	err = s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTree, err = s.treeRepo.Update(ctx, id, func(tree *entities.Tree, repo storage.TreeRepository) (bool, error) {
+			tree.PlantingYear = tu.PlantingYear
+			tree.Species = tu.Species
+			tree.Number = tu.Number
+			tree.Latitude = tu.Latitude
+			tree.Longitude = tu.Longitude
+			tree.Description = tu.Description
+			tree.Provider = tu.Provider
+			tree.AdditionalInfo = tu.AdditionalInfo
+
+			if tu.TreeClusterID != nil {
+				treeCluster, err := s.treeClusterRepo.GetByID(ctx, *tu.TreeClusterID)
+				if err != nil {
+					log.Debug("failed to find tree cluster by id specified from update request", "tree_cluster_id", tu.TreeClusterID)
+					return false, service.MapError(ctx, fmt.Errorf("failed to find TreeCluster with ID %d: %w", *tu.TreeClusterID, err), service.ErrorLogEntityNotFound)
+				}
+				tree.TreeCluster = treeCluster
+			} else {
+				tree.TreeCluster = nil
 			}
-			tree.TreeCluster = treeCluster
-		} else {
-			tree.TreeCluster = nil
-		}
 
-		if tu.SensorID != nil {
-			sensor, err := s.sensorRepo.GetByID(ctx, *tu.SensorID)
-			if err != nil {
-				log.Debug("failed to find sensor by id specified from update request", "sensor_id", tu.SensorID)
-				return false, service.MapError(ctx, fmt.Errorf("failed to find Sensor with ID %v: %w", *tu.SensorID, err), service.ErrorLogEntityNotFound)
+			if tu.SensorID != nil {
+				sensor, err := s.sensorRepo.GetByID(ctx, *tu.SensorID)
+				if err != nil {
+					log.Debug("failed to find sensor by id specified from update request", "sensor_id", tu.SensorID)
+					return false, service.MapError(ctx, fmt.Errorf("failed to find Sensor with ID %v: %w", *tu.SensorID, err), service.ErrorLogEntityNotFound)
+				}
+				tree.Sensor = sensor
+
+				prevTreeOfSensor, err = repo.GetBySensorID(ctx, sensor.ID)
+				if err != nil {
+					// If the previous tree that was linked to the sensor could not be found, the update process should still be continued.
+					log.Debug("failed to find previous tree linked to sensor specified from update request", "sensor_id", tu.SensorID)
+				}
+				if sensor.LatestData != nil && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
+					status := utils.CalculateWateringStatus(ctx, tu.PlantingYear, sensor.LatestData.Data.Watermarks)
+					tree.WateringStatus = status
+				}
+			} else {
+				tree.Sensor = nil
+				tree.WateringStatus = entities.WateringStatusUnknown
 			}
-			tree.Sensor = sensor
 
-			prevTreeOfSensor, err = repo.GetBySensorID(ctx, sensor.ID)
-			if err != nil {
-				// If the previous tree that was linked to the sensor could not be found, the update process should still be continued.
-				log.Debug("failed to find previous tree linked to sensor specified from update request", "sensor_id", tu.SensorID)
-			}
-			if sensor.LatestData != nil && sensor.LatestData.Data != nil && len(sensor.LatestData.Data.Watermarks) > 0 {
-				status := utils.CalculateWateringStatus(ctx, tu.PlantingYear, sensor.LatestData.Data.Watermarks)
-				tree.WateringStatus = status
-			}
-		} else {
-			tree.Sensor = nil
-			tree.WateringStatus = entities.WateringStatusUnknown
+			return true, nil
+		})
+		if err != nil {
+			return err
 		}
 
-		return true, nil
+		s.publishUpdateTreeEvent(ctx, prevTree, updatedTree, prevTreeOfSensor)
+		return nil
 	})
 
 	if err != nil {
@@ -239,7 +265,6 @@ func (s *TreeService) Update(ctx context.Context, id int32, tu *entities.TreeUpd
 	}
 
 	slog.Info("tree updated successfully", "tree_id", id)
-	s.publishUpdateTreeEvent(ctx, prevTree, updatedTree, prevTreeOfSensor)
 	return updatedTree, nil
 }
 
@@ -59,11 +59,13 @@ func (s *TreeClusterService) HandleNewSensorData(ctx context.Context, event *ent
 		return true, nil
 	}
 
-	if err := s.treeClusterRepo.Update(ctx, tree.TreeCluster.ID, updateFn); err == nil {
-		return s.publishUpdateEvent(ctx, tree.TreeCluster)
-	}
+	return s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.treeClusterRepo.Update(ctx, tree.TreeCluster.ID, updateFn); err == nil {
+			return s.publishUpdateEvent(ctx, tree.TreeCluster)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (s *TreeClusterService) getWateringStatusOfTreeCluster(ctx context.Context, clusterID int32) (entities.WateringStatus, error) {
@@ -0,0 +1,38 @@
+package treecluster
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+)
+
+// HandleImportTrees processes a tree import event and updates every affected tree cluster once.
+//
+// The event lists all tree clusters that gained or lost trees during the import, so the center point,
+// region and watering status of each cluster are recalculated only once, regardless of how many trees were imported.
+//
+// Parameters:
+//   - ctx: The request context, enabling logging and tracing.
+//   - event: Contains the imported trees and the ids of the affected tree clusters.
+//
+// Returns:
+//   - error: An error if updating one of the tree clusters fails; otherwise, nil.
+func (s *TreeClusterService) HandleImportTrees(ctx context.Context, event *entities.EventImportTrees) error {
+	log := logger.GetLogger(ctx)
+	log.Debug("handle event", "event", event.Type(), "service", "TreeClusterService", "tree_import_id", event.JobID)
+
+	for _, id := range event.TreeClusterIDs {
+		tc, err := s.treeClusterRepo.GetByID(ctx, id)
+		if err != nil {
+			log.Error("failed to fetch tree cluster affected by tree import", "error", err, "cluster_id", id)
+			return err
+		}
+
+		if err := s.handleTreeClusterUpdate(ctx, tc, &entities.Tree{TreeCluster: tc}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package treecluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func TestTreeClusterService_HandleImportTrees(t *testing.T) {
+	t.Run("should update every affected tree cluster once and send treecluster update event", func(t *testing.T) {
+		clusterRepo, _, _, eventManager, svc := setupTest(t)
+
+		// event
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go eventManager.Run(ctx)
+
+		event := entities.NewEventImportTrees(1, []*entities.TreeImportChange{{New: &updatedTree}, {New: &updatedTree}}, []int32{1})
+		clusterRepo.EXPECT().GetByID(mock.Anything, int32(1)).Return(&prevTc, nil).Once()
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, int32(1)).Return(nil, storage.ErrSensorNotFound).Once()
+		clusterRepo.EXPECT().Update(mock.Anything, int32(1), mock.Anything).RunAndReturn(func(ctx context.Context, i int32, f func(*entities.TreeCluster, storage.TreeClusterRepository) (bool, error)) error {
+			cluster := entities.TreeCluster{}
+			_, err := f(&cluster, clusterRepo)
+			assert.NoError(t, err)
+			assert.Equal(t, entities.WateringStatusUnknown, cluster.WateringStatus)
+			return nil
+		}).Once()
+		clusterRepo.EXPECT().GetByID(mock.Anything, int32(1)).Return(&updatedTc, nil).Once()
+
+		// when
+		err := svc.HandleImportTrees(context.Background(), &event)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case recievedEvent, ok := <-ch:
+			assert.True(t, ok)
+			e := recievedEvent.(entities.EventUpdateTreeCluster)
+			assert.Equal(t, e.Prev, &prevTc)
+			assert.Equal(t, e.New, &updatedTc)
+		case <-time.After(1 * time.Second):
+			t.Fatal("event was not received")
+		}
+	})
+
+	t.Run("should do nothing when no tree cluster is affected", func(t *testing.T) {
+		clusterRepo, _, _, _, svc := setupTest(t)
+		event := entities.NewEventImportTrees(1, []*entities.TreeImportChange{{New: &entities.Tree{ID: 1}}}, nil)
+
+		// when
+		err := svc.HandleImportTrees(context.Background(), &event)
+
+		// then
+		assert.NoError(t, err)
+		clusterRepo.AssertNotCalled(t, "GetByID")
+		clusterRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("should return error when tree cluster can not be fetched", func(t *testing.T) {
+		clusterRepo, _, _, _, svc := setupTest(t)
+		event := entities.NewEventImportTrees(1, nil, []int32{1})
+		clusterRepo.EXPECT().GetByID(mock.Anything, int32(1)).Return(nil, errors.New("internal error"))
+
+		// when
+		err := svc.HandleImportTrees(context.Background(), &event)
+
+		// then
+		assert.Error(t, err)
+		clusterRepo.AssertNotCalled(t, "Update")
+	})
+}
@@ -141,12 +141,14 @@ func (s *TreeClusterService) handleTreeClusterUpdate(ctx context.Context, tc *en
 		return true, nil
 	}
 
-	if err := s.treeClusterRepo.Update(ctx, tc.ID, updateFn); err == nil {
-		log.Info("successfully updated new tree cluster", "cluster_id", tc.ID)
-		return s.publishUpdateEvent(ctx, tc)
-	}
+	return s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.treeClusterRepo.Update(ctx, tc.ID, updateFn); err == nil {
+			log.Info("successfully updated new tree cluster", "cluster_id", tc.ID)
+			return s.publishUpdateEvent(ctx, tc)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (s *TreeClusterService) updateWateringStatusOfPrevTreeCluster(ctx context.Context, prevTc *entities.TreeCluster) error {
@@ -165,10 +167,12 @@ func (s *TreeClusterService) updateWateringStatusOfPrevTreeCluster(ctx context.C
 		return true, nil
 	}
 
-	if err := s.treeClusterRepo.Update(ctx, prevTc.ID, updateFn); err == nil {
-		log.Info("successfully updated watering status of previous tree cluster", "cluster_id", prevTc.ID)
-		return s.publishUpdateEvent(ctx, prevTc)
-	}
+	return s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.treeClusterRepo.Update(ctx, prevTc.ID, updateFn); err == nil {
+			log.Info("successfully updated watering status of previous tree cluster", "cluster_id", prevTc.ID)
+			return s.publishUpdateEvent(ctx, prevTc)
+		}
 
-	return nil
+		return nil
+	})
 }
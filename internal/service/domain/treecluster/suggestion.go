@@ -0,0 +1,228 @@
+package treecluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	defaultSuggestionDistance = 30
+	maxSuggestionDistance     = 500
+	defaultSuggestionMinTrees = 2
+	defaultSuggestionMaxTrees = 40
+	maxSuggestionTrees        = 1000
+)
+
+func (s *TreeClusterService) GetSuggestions(ctx context.Context, query domain.TreeClusterSuggestionQuery) ([]*domain.TreeClusterSuggestion, error) {
+	log := logger.GetLogger(ctx)
+	if query.Distance == 0 {
+		query.Distance = defaultSuggestionDistance
+	}
+	if query.MinTrees == 0 {
+		query.MinTrees = defaultSuggestionMinTrees
+	}
+	if query.MaxTrees == 0 {
+		query.MaxTrees = defaultSuggestionMaxTrees
+	}
+
+	if query.Distance < 1 || query.Distance > maxSuggestionDistance ||
+		query.MinTrees < 1 || query.MaxTrees < query.MinTrees || query.MaxTrees > maxSuggestionTrees {
+		log.Debug("tree cluster suggestion query is out of range", "query", fmt.Sprintf("%+v", query))
+		return nil, service.ErrSuggestionQueryInvalid
+	}
+
+	suggestions, err := s.treeClusterRepo.GetSuggestions(ctx, query)
+	if err != nil {
+		log.Debug("failed to get tree cluster suggestions", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	// the repository splits groups with more than max trees, a larger suggestion is never returned
+	suggestions = utils.Filter(suggestions, func(suggestion *domain.TreeClusterSuggestion) bool {
+		if len(suggestion.TreeIDs) > int(query.MaxTrees) {
+			log.Warn("dropping tree cluster suggestion with more trees than allowed", "tree_count", len(suggestion.TreeIDs), "max_trees", query.MaxTrees)
+			return false
+		}
+		return true
+	})
+
+	for _, suggestion := range suggestions {
+		region, err := s.regionRepo.GetByPoint(ctx, suggestion.Latitude, suggestion.Longitude)
+		if err != nil {
+			log.Debug("can't find region by lat and long", "error", err, "latitude", suggestion.Latitude, "longitude", suggestion.Longitude)
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+		suggestion.Region = region
+	}
+
+	return suggestions, nil
+}
+
+// AcceptSuggestions creates or updates the tree clusters of the accepted suggestions in a single transaction.
+// Tree clusters that lose trees to an accepted suggestion get their position updated in the same transaction.
+func (s *TreeClusterService) AcceptSuggestions(ctx context.Context, accepts []*domain.TreeClusterSuggestionAccept) ([]*domain.TreeCluster, error) {
+	log := logger.GetLogger(ctx)
+	if len(accepts) == 0 {
+		return nil, service.MapError(ctx, errors.Join(errors.New("no suggestion accepted"), service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	seenTrees := make(map[int32]bool)
+	for _, accept := range accepts {
+		if err := s.validator.Struct(accept); err != nil {
+			log.Debug("failed to validate struct from accepted tree cluster suggestion", "error", err, "raw_suggestion", fmt.Sprintf("%+v", accept))
+			return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+		}
+
+		for _, id := range accept.TreeIDs {
+			if seenTrees[id] {
+				log.Debug("tree is part of more than one accepted suggestion", "tree_id", id)
+				return nil, service.ErrSuggestionTreeTwice
+			}
+			seenTrees[id] = true
+		}
+	}
+
+	clusters := make([]*domain.TreeCluster, 0, len(accepts))
+	prevClusters := make(map[int32]*domain.TreeCluster)
+	for _, accept := range accepts {
+		tc, err := s.suggestedTreeCluster(ctx, accept, prevClusters)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, tc)
+	}
+
+	// tree clusters that are not part of the suggestions but lose trees to them are moved in the same transaction
+	accepted := make(map[int32]bool)
+	for _, tc := range clusters {
+		if tc.ID != 0 {
+			accepted[tc.ID] = true
+		}
+	}
+	lostIDs := make([]int32, 0, len(prevClusters))
+	for id := range prevClusters {
+		if !accepted[id] {
+			lostIDs = append(lostIDs, id)
+		}
+	}
+	slices.Sort(lostIDs)
+
+	// the tree clusters and their events are committed together
+	var upserted []*domain.TreeCluster
+	err := s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		wateringStatuses := s.getWateringStatuses(ctx, lostIDs)
+		upserted, err = s.treeClusterRepo.Upsert(ctx, clusters, lostIDs, s.updatePositionFn(ctx, wateringStatuses))
+		if err != nil {
+			log.Debug("failed to save accepted tree cluster suggestions", "error", err)
+			return err
+		}
+
+		touched := slices.Clone(upserted)
+		for _, id := range lostIDs {
+			tc, err := s.treeClusterRepo.GetByID(ctx, id)
+			if err != nil {
+				log.Warn("failed to get tree cluster that lost trees to accepted suggestions", "error", err, "cluster_id", id)
+				continue
+			}
+			touched = append(touched, tc)
+		}
+
+		cutoffTime := time.Now().Add(-24 * time.Hour)
+		for _, tc := range touched {
+			if err := s.updateWateringStatus(ctx, tc, cutoffTime); err != nil {
+				log.Warn("failed to update watering status after accepting tree cluster suggestions", "error", err, "cluster_id", tc.ID)
+			}
+		}
+
+		for _, tc := range upserted {
+			if prevTc, ok := prevClusters[tc.ID]; ok {
+				if err := s.publishUpdateEvent(ctx, prevTc); err != nil {
+					return err
+				}
+			}
+		}
+		for _, id := range lostIDs {
+			if err := s.publishUpdateEvent(ctx, prevClusters[id]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("tree cluster suggestions accepted successfully", "count", len(upserted))
+	return upserted, nil
+}
+
+// suggestedTreeCluster returns the tree cluster of an accepted suggestion with the trees, position and region set.
+// The previous state of every existing tree cluster that is changed is added to prevClusters.
+func (s *TreeClusterService) suggestedTreeCluster(ctx context.Context, accept *domain.TreeClusterSuggestionAccept, prevClusters map[int32]*domain.TreeCluster) (*domain.TreeCluster, error) {
+	log := logger.GetLogger(ctx)
+	trees, err := s.treeRepo.GetTreesByIDs(ctx, accept.TreeIDs)
+	if err != nil {
+		log.Debug("failed to get trees of accepted suggestion", "error", err, "tree_ids", accept.TreeIDs)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+	if len(trees) != len(accept.TreeIDs) {
+		log.Debug("not all trees of accepted suggestion exist", "tree_ids", accept.TreeIDs)
+		return nil, service.MapError(ctx, storage.ErrEntityNotFound("tree"), service.ErrorLogEntityNotFound)
+	}
+
+	tc := &domain.TreeCluster{
+		WateringStatus: domain.WateringStatusUnknown,
+		SoilCondition:  domain.TreeSoilConditionUnknown,
+	}
+	if accept.TreeClusterID != nil {
+		existing, err := s.treeClusterRepo.GetByID(ctx, *accept.TreeClusterID)
+		if err != nil {
+			log.Debug("failed to get tree cluster of accepted suggestion", "error", err, "cluster_id", *accept.TreeClusterID)
+			return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+		}
+		prev := *existing
+		prevClusters[existing.ID] = &prev
+		tc = existing
+	}
+
+	if accept.Name != "" {
+		tc.Name = accept.Name
+	}
+	if accept.SoilCondition != "" {
+		tc.SoilCondition = accept.SoilCondition
+	}
+
+	var lat, long float64
+	for _, tree := range trees {
+		lat += tree.Latitude / float64(len(trees))
+		long += tree.Longitude / float64(len(trees))
+
+		if tree.TreeCluster != nil && tree.TreeCluster.ID != tc.ID {
+			if _, ok := prevClusters[tree.TreeCluster.ID]; !ok {
+				prevClusters[tree.TreeCluster.ID] = tree.TreeCluster
+			}
+		}
+	}
+
+	region, err := s.regionRepo.GetByPoint(ctx, lat, long)
+	if err != nil {
+		log.Debug("can't find region by lat and long", "error", err, "latitude", lat, "longitude", long)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	tc.Trees = trees
+	tc.Latitude = &lat
+	tc.Longitude = &long
+	tc.Region = region
+	return tc, nil
+}
@@ -0,0 +1,285 @@
+package treecluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func TestTreeClusterService_GetSuggestions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return suggestions with region using the default query", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		suggestions := []*entities.TreeClusterSuggestion{
+			{TreeIDs: []int32{1, 2}, Latitude: 54.801539, Longitude: 9.446741, SoilCondition: entities.TreeSoilConditionUnknown},
+		}
+		region := &entities.Region{ID: 1, Name: "Mürwik"}
+
+		clusterRepo.EXPECT().GetSuggestions(ctx, entities.TreeClusterSuggestionQuery{
+			Distance: defaultSuggestionDistance,
+			MinTrees: defaultSuggestionMinTrees,
+			MaxTrees: defaultSuggestionMaxTrees,
+		}).Return(suggestions, nil)
+		regionRepo.EXPECT().GetByPoint(ctx, 54.801539, 9.446741).Return(region, nil)
+
+		// when
+		result, err := svc.GetSuggestions(ctx, entities.TreeClusterSuggestionQuery{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, region, result[0].Region)
+	})
+
+	t.Run("should never return a suggestion with more trees than allowed", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		query := entities.TreeClusterSuggestionQuery{Distance: 30, MinTrees: 2, MaxTrees: 3}
+		suggestions := []*entities.TreeClusterSuggestion{
+			{TreeIDs: []int32{1, 2, 3}, Latitude: 54.801539, Longitude: 9.446741},
+			{TreeIDs: []int32{4, 5, 6, 7}, Latitude: 54.802, Longitude: 9.447},
+		}
+
+		clusterRepo.EXPECT().GetSuggestions(ctx, query).Return(suggestions, nil)
+		regionRepo.EXPECT().GetByPoint(ctx, 54.801539, 9.446741).Return(nil, nil)
+
+		// when
+		result, err := svc.GetSuggestions(ctx, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		for _, suggestion := range result {
+			assert.LessOrEqual(t, len(suggestion.TreeIDs), int(query.MaxTrees))
+		}
+	})
+
+	t.Run("should return validation error when query is out of range", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		queries := []entities.TreeClusterSuggestionQuery{
+			{Distance: 1000},
+			{MinTrees: 10, MaxTrees: 5},
+			{MaxTrees: 5000},
+			{MinTrees: -1},
+		}
+
+		for _, query := range queries {
+			// when
+			result, err := svc.GetSuggestions(ctx, query)
+
+			// then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, service.ErrSuggestionQueryInvalid)
+		}
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		clusterRepo.EXPECT().GetSuggestions(ctx, mock.Anything).Return(nil, errors.New("internal error"))
+
+		// when
+		result, err := svc.GetSuggestions(ctx, entities.TreeClusterSuggestionQuery{})
+
+		// then
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "internal error")
+	})
+}
+
+func TestTreeClusterService_AcceptSuggestions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create tree cluster from accepted suggestion", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		region := &entities.Region{ID: 1, Name: "Mürwik"}
+		accepts := []*entities.TreeClusterSuggestionAccept{
+			{Name: "Cluster 1", TreeIDs: []int32{1, 2}, SoilCondition: entities.TreeSoilConditionLehmig},
+		}
+
+		treeRepo.EXPECT().GetTreesByIDs(ctx, []int32{1, 2}).Return(testTrees, nil)
+		regionRepo.EXPECT().GetByPoint(ctx, mock.Anything, mock.Anything).Return(region, nil)
+		clusterRepo.EXPECT().Upsert(ctx, mock.MatchedBy(func(clusters []*entities.TreeCluster) bool {
+			return len(clusters) == 1 &&
+				clusters[0].ID == 0 &&
+				clusters[0].Name == "Cluster 1" &&
+				clusters[0].SoilCondition == entities.TreeSoilConditionLehmig &&
+				clusters[0].Region == region &&
+				len(clusters[0].Trees) == 2
+		}), []int32{}, mock.Anything).Return([]*entities.TreeCluster{testClusters[0]}, nil)
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, accepts)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.TreeCluster{testClusters[0]}, result)
+	})
+
+	t.Run("should update existing tree cluster from accepted suggestion", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		existing := *testClusters[0]
+		accepts := []*entities.TreeClusterSuggestionAccept{
+			{TreeClusterID: utils.P(int32(1)), TreeIDs: []int32{1, 2}},
+		}
+
+		treeRepo.EXPECT().GetTreesByIDs(ctx, []int32{1, 2}).Return(testTrees, nil)
+		clusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&existing, nil)
+		regionRepo.EXPECT().GetByPoint(ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		clusterRepo.EXPECT().Upsert(ctx, mock.MatchedBy(func(clusters []*entities.TreeCluster) bool {
+			return len(clusters) == 1 && clusters[0].ID == 1 && clusters[0].Name == "Cluster 1"
+		}), []int32{}, mock.Anything).Return([]*entities.TreeCluster{testClusters[0]}, nil)
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, accepts)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.TreeCluster{testClusters[0]}, result)
+	})
+
+	t.Run("should move tree clusters that lose trees in the upsert transaction", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		lost := &entities.TreeCluster{ID: 2, Name: "Cluster 2"}
+		trees := []*entities.Tree{
+			{ID: 1, Latitude: 54.82, Longitude: 9.48, TreeCluster: lost},
+			{ID: 2, Latitude: 54.82, Longitude: 9.48, TreeCluster: lost},
+		}
+
+		treeRepo.EXPECT().GetTreesByIDs(ctx, []int32{1, 2}).Return(trees, nil)
+		regionRepo.EXPECT().GetByPoint(ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(2)).Return([]*entities.SensorData{}, nil)
+		clusterRepo.EXPECT().Upsert(ctx, mock.Anything, []int32{2}, mock.Anything).Return([]*entities.TreeCluster{testClusters[0]}, nil)
+		clusterRepo.EXPECT().GetByID(ctx, int32(2)).Return(lost, nil)
+		clusterRepo.EXPECT().Update(ctx, int32(2), mock.Anything).Return(nil)
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, []*entities.TreeClusterSuggestionAccept{
+			{Name: "Cluster 1", TreeIDs: []int32{1, 2}},
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.TreeCluster{testClusters[0]}, result)
+		clusterRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return validation error when no suggestion is accepted", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, []*entities.TreeClusterSuggestionAccept{})
+
+		// then
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("should return validation error when new tree cluster has no name", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, []*entities.TreeClusterSuggestionAccept{
+			{TreeIDs: []int32{1, 2}},
+		})
+
+		// then
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("should return error when tree is part of more than one suggestion", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, []*entities.TreeClusterSuggestionAccept{
+			{Name: "Cluster 1", TreeIDs: []int32{1, 2}},
+			{Name: "Cluster 2", TreeIDs: []int32{2, 3}},
+		})
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, service.ErrSuggestionTreeTwice)
+	})
+
+	t.Run("should return not found error when tree does not exist", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		treeRepo.EXPECT().GetTreesByIDs(ctx, []int32{1, 2, 3}).Return(testTrees, nil)
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, []*entities.TreeClusterSuggestionAccept{
+			{Name: "Cluster 1", TreeIDs: []int32{1, 2, 3}},
+		})
+
+		// then
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "entity not found: tree")
+	})
+
+	t.Run("should return error when upsert fails", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		treeRepo.EXPECT().GetTreesByIDs(ctx, []int32{1, 2}).Return(testTrees, nil)
+		regionRepo.EXPECT().GetByPoint(ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		clusterRepo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("internal error"))
+
+		// when
+		result, err := svc.AcceptSuggestions(ctx, []*entities.TreeClusterSuggestionAccept{
+			{Name: "Cluster 1", TreeIDs: []int32{1, 2}},
+		})
+
+		// then
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "internal error")
+	})
+}
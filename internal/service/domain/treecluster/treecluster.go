@@ -13,7 +13,9 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/twpayne/go-geos"
 )
 
 type TreeClusterService struct {
@@ -21,14 +23,14 @@ type TreeClusterService struct {
 	treeRepo        storage.TreeRepository
 	regionRepo      storage.RegionRepository
 	validator       *validator.Validate
-	eventManager    *worker.EventManager
+	eventManager    worker.EventBus
 }
 
 func NewTreeClusterService(
 	treeClusterRepo storage.TreeClusterRepository,
 	treeRepo storage.TreeRepository,
 	regionRepo storage.RegionRepository,
-	eventManager *worker.EventManager,
+	eventManager worker.EventBus,
 ) service.TreeClusterService {
 	return &TreeClusterService{
 		treeClusterRepo: treeClusterRepo,
@@ -85,52 +87,66 @@ func (s *TreeClusterService) Create(ctx context.Context, createTc *domain.TreeCl
 		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
 	}
 
+	if err := validatePolygon(ctx, createTc.Polygon); err != nil {
+		return nil, err
+	}
+
 	trees, err := s.getTrees(ctx, createTc.TreeIDs)
 	if err != nil {
 		log.Debug("failed to get trees inside the tree cluster", "error", err, "tree_ids", createTc.TreeIDs)
 		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
 	}
 
-	c, err := s.treeClusterRepo.Create(ctx, func(tc *domain.TreeCluster, repo storage.TreeClusterRepository) (bool, error) {
-		if err = s.handlePrevTreeLocation(ctx, trees, repo.Update); err != nil {
-			log.Debug("failed to update prev tree location", "error", err, "trees", trees, "tree_cluster", tc)
-			return false, service.MapError(ctx, err, service.ErrorLogAll)
-		}
+	// the tree cluster and the events of the tree clusters that lost trees to it are committed together
+	var c *domain.TreeCluster
+	err = s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		c, err = s.treeClusterRepo.Create(ctx, func(tc *domain.TreeCluster, repo storage.TreeClusterRepository) (bool, error) {
+			if err := s.handlePrevTreeLocation(ctx, trees, repo.Update); err != nil {
+				log.Debug("failed to update prev tree location", "error", err, "trees", trees, "tree_cluster", tc)
+				return false, service.MapError(ctx, err, service.ErrorLogAll)
+			}
 
-		tc.Trees = trees
-		tc.Name = createTc.Name
-		tc.Address = createTc.Address
-		tc.Description = createTc.Description
-		tc.SoilCondition = createTc.SoilCondition
-		tc.Provider = createTc.Provider
-		tc.AdditionalInfo = createTc.AdditionalInfo
-
-		log.Debug("creating tree cluster with following attributes",
-			"tree_ids", createTc.TreeIDs,
-			"name", createTc.Name,
-			"address", createTc.Address,
-			"description", createTc.Description,
-			"soil_condition", createTc.SoilCondition,
-		)
+			tc.Trees = trees
+			tc.Name = createTc.Name
+			tc.Address = createTc.Address
+			tc.Description = createTc.Description
+			tc.SoilCondition = createTc.SoilCondition
+			tc.Provider = createTc.Provider
+			tc.AdditionalInfo = createTc.AdditionalInfo
+			tc.Polygon = createTc.Polygon
+			tc.PolygonManual = createTc.Polygon != ""
+
+			log.Debug("creating tree cluster with following attributes",
+				"tree_ids", createTc.TreeIDs,
+				"name", createTc.Name,
+				"address", createTc.Address,
+				"description", createTc.Description,
+				"soil_condition", createTc.SoilCondition,
+			)
 
-		return true, nil
-	})
+			return true, nil
+		})
+		if err != nil {
+			log.Debug("failed to create tree cluster", "error", err)
+			return err
+		}
 
+		if err := s.updateTreeClusterPosition(ctx, c.ID); err != nil {
+			log.Debug("error while update the cluster locations", "error", err, "cluster_id", c.ID)
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		log.Debug("failed to create tree cluster", "error", err)
 		return nil, service.MapError(ctx, err, service.ErrorLogAll)
 	}
 
+	log.Info("tree cluster created successfully", "cluster_id", c.ID)
 	if err := s.UpdateWateringStatuses(ctx); err != nil {
 		log.Warn("failed to update watering status after creating tree cluster", "error", err, "cluster_id", c.ID)
 	}
 
-	if err := s.updateTreeClusterPosition(ctx, c.ID); err != nil {
-		log.Debug("error while update the cluster locations", "error", err, "cluster_id", c.ID)
-		return nil, service.MapError(ctx, err, service.ErrorLogAll)
-	}
-
-	log.Info("tree cluster created successfully", "cluster_id", c.ID)
 	return c, nil
 }
 
@@ -141,6 +157,10 @@ func (s *TreeClusterService) Update(ctx context.Context, id int32, tcUpdate *dom
 		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
 	}
 
+	if err := validatePolygon(ctx, tcUpdate.Polygon); err != nil {
+		return nil, err
+	}
+
 	trees, err := s.getTrees(ctx, tcUpdate.TreeIDs)
 	if err != nil {
 		log.Debug("failed to get trees inside the tree cluster", "error", err, "tree_ids", tcUpdate.TreeIDs)
@@ -153,69 +173,90 @@ func (s *TreeClusterService) Update(ctx context.Context, id int32, tcUpdate *dom
 		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
 	}
 
-	err = s.treeClusterRepo.Update(ctx, id, func(tc *domain.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
-		tc.Trees = trees
-		tc.Name = tcUpdate.Name
-		tc.Address = tcUpdate.Address
-		tc.Description = tcUpdate.Description
-		tc.SoilCondition = tcUpdate.SoilCondition
-		tc.Provider = tcUpdate.Provider
-		tc.AdditionalInfo = tcUpdate.AdditionalInfo
-
-		log.Debug("updating tree cluster with following attributes",
-			"cluster_id", id,
-			"name", tcUpdate.Name,
-			"address", tcUpdate.Address,
-			"description", tcUpdate.Description,
-			"soil_condition", tcUpdate.SoilCondition,
-			"provider", tcUpdate.Provider,
-			"additional_info", tcUpdate.AdditionalInfo,
-		)
+	// the tree cluster, the positions of the affected tree clusters and their events are committed together
+	err = s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		err := s.treeClusterRepo.Update(ctx, id, func(tc *domain.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.Trees = trees
+			tc.Name = tcUpdate.Name
+			tc.Address = tcUpdate.Address
+			tc.Description = tcUpdate.Description
+			tc.SoilCondition = tcUpdate.SoilCondition
+			tc.Provider = tcUpdate.Provider
+			tc.AdditionalInfo = tcUpdate.AdditionalInfo
+			// a computed polygon that is sent back unchanged stays computed from the trees
+			tc.PolygonManual = tcUpdate.Polygon != "" && (tc.PolygonManual || !samePolygon(tcUpdate.Polygon, tc.Polygon))
+			tc.Polygon = tcUpdate.Polygon
+
+			log.Debug("updating tree cluster with following attributes",
+				"cluster_id", id,
+				"name", tcUpdate.Name,
+				"address", tcUpdate.Address,
+				"description", tcUpdate.Description,
+				"soil_condition", tcUpdate.SoilCondition,
+				"provider", tcUpdate.Provider,
+				"additional_info", tcUpdate.AdditionalInfo,
+			)
 
-		return true, nil
-	})
+			return true, nil
+		})
+		if err != nil {
+			log.Debug("failed to update tree cluster", "error", err, "cluster_id", id)
+			return err
+		}
+
+		// the If-Match header only applies to the update requested by the client and not to the
+		// following updates of the position
+		ctx = etag.WithoutIfMatch(ctx)
 
+		var eventTreeClusters []*domain.TreeCluster
+		if len(trees) > 0 {
+			eventTreeClusters = utils.Filter(utils.Map(trees, func(t *domain.Tree) *domain.TreeCluster {
+				return t.TreeCluster
+			}), func(treeCluster *domain.TreeCluster) bool {
+				return treeCluster != nil && treeCluster.ID != id
+			})
+		}
+
+		eventTreeClusters = append(eventTreeClusters, prevTc)
+		for _, eTC := range eventTreeClusters {
+			if err := s.updateTreeClusterPosition(ctx, eTC.ID); err != nil {
+				log.Error("error while update the cluster locations", "error", err, "cluster_id", eTC.ID)
+				return err
+			}
+
+			if err := s.publishUpdateEvent(ctx, eTC); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		log.Debug("failed to update tree cluster", "error", err, "cluster_id", id)
 		return nil, service.MapError(ctx, err, service.ErrorLogAll)
 	}
 	log.Info("tree cluster updated successfully", "cluster_id", id)
 
-	if err := s.UpdateWateringStatuses(ctx); err != nil {
+	// the watering status of all tree clusters is updated after the update has been committed, every
+	// changed tree cluster is stored together with its own event
+	if err := s.UpdateWateringStatuses(etag.WithoutIfMatch(ctx)); err != nil {
 		log.Warn("failed to update watering status after updating tree cluster", "error", err, "cluster_id", id)
 	}
 
-	var eventTreeClusters []*domain.TreeCluster
-	if len(trees) > 0 {
-		eventTreeClusters = utils.Filter(utils.Map(trees, func(t *domain.Tree) *domain.TreeCluster {
-			return t.TreeCluster
-		}), func(treeCluster *domain.TreeCluster) bool {
-			return treeCluster != nil && treeCluster.ID != id
-		})
-	}
-
-	eventTreeClusters = append(eventTreeClusters, prevTc)
-	for _, eTC := range eventTreeClusters {
-		if err = s.updateTreeClusterPosition(ctx, eTC.ID); err != nil {
-			log.Error("error while update the cluster locations", "error", err, "cluster_id", eTC.ID)
-			return nil, service.MapError(ctx, err, service.ErrorLogAll)
-		}
-
-		if err = s.publishUpdateEvent(ctx, eTC); err != nil {
-			return nil, service.MapError(ctx, err, service.ErrorLogAll)
-		}
-	}
-
 	return s.GetByID(ctx, id)
 }
 
 func (s *TreeClusterService) Delete(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
-	_, err := s.treeClusterRepo.GetByID(ctx, id)
+	tc, err := s.treeClusterRepo.GetByID(ctx, id)
 	if err != nil {
 		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
 	}
 
+	// the trees are unlinked outside of the delete transaction, so a stale If-Match
+	// header has to be rejected before
+	if err := etag.Check(ctx, tc.Version); err != nil {
+		return service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
 	if err := s.treeRepo.UnlinkTreeClusterID(ctx, id); err != nil {
 		log.Debug("failed to unlink tree from tree cluster", "cluster_id", id, "error", err)
 		return service.MapError(ctx, err, service.ErrorLogAll)
@@ -240,33 +281,58 @@ func (s *TreeClusterService) UpdateWateringStatuses(ctx context.Context) error {
 
 	cutoffTime := time.Now().Add(-24 * time.Hour) // 1 day ago
 	for _, cluster := range treeClusters {
-		var wateringStatus domain.WateringStatus
+		if err := s.updateWateringStatus(ctx, cluster, cutoffTime); err != nil {
+			return err
+		}
+	}
 
-		if cluster.Trees == nil || (cluster.Trees != nil && len(cluster.Trees) == 0) {
-			// tree cluster has no trees
-			wateringStatus = domain.WateringStatusUnknown
-		} else if cluster.LastWatered != nil && cluster.LastWatered.Before(cutoffTime) {
-			wateringStatus, err = s.getWateringStatusOfTreeCluster(ctx, cluster.ID)
-			if err != nil {
-				log.Error("failed to get watering status of cluster", "cluster_id", cluster.ID, "error", err)
-				return err
-			}
+	log.Info("watering status update for tree clusters completed successfully")
+	return nil
+}
+
+// updateWateringStatus sets the watering status of a tree cluster without trees to unknown and recomputes it
+// from the sensor data if the tree cluster was not watered within the last day. A changed watering status is
+// published as update of the tree cluster. Only a failed computation is returned, a failed update is logged.
+func (s *TreeClusterService) updateWateringStatus(ctx context.Context, cluster *domain.TreeCluster, cutoffTime time.Time) error {
+	log := logger.GetLogger(ctx)
+	var wateringStatus domain.WateringStatus
+	var err error
+
+	if len(cluster.Trees) == 0 {
+		// tree cluster has no trees
+		wateringStatus = domain.WateringStatusUnknown
+	} else if cluster.LastWatered != nil && cluster.LastWatered.Before(cutoffTime) {
+		wateringStatus, err = s.getWateringStatusOfTreeCluster(ctx, cluster.ID)
+		if err != nil {
+			log.Error("failed to get watering status of cluster", "cluster_id", cluster.ID, "error", err)
+			return err
 		}
+	}
 
-		if wateringStatus != "" {
-			err = s.treeClusterRepo.Update(ctx, cluster.ID, func(tc *domain.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
-				tc.WateringStatus = wateringStatus
-				return true, nil
-			})
-			if err != nil {
-				log.Error("failed to update watering status of tree cluster", "cluster_id", cluster.ID, "error", err)
-			} else {
-				log.Debug("watering status of tree cluster is updated", "cluster_id", cluster.ID)
-			}
+	if wateringStatus == "" {
+		return nil
+	}
+
+	err = s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		err := s.treeClusterRepo.Update(ctx, cluster.ID, func(tc *domain.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.WateringStatus = wateringStatus
+			return true, nil
+		})
+		if err != nil {
+			return err
 		}
+
+		if cluster.WateringStatus != wateringStatus {
+			return s.publishUpdateEvent(ctx, cluster)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to update watering status of tree cluster", "cluster_id", cluster.ID, "error", err)
+		return nil
 	}
+	log.Debug("watering status of tree cluster is updated", "cluster_id", cluster.ID)
 
-	log.Info("watering status update for tree clusters completed successfully")
 	return nil
 }
 
@@ -277,13 +343,33 @@ func (s *TreeClusterService) Ready() bool {
 // Update the tree cluster only after the trees have been updated to the database,
 // otherwise the center point of the tree cluster cannot be set
 func (s *TreeClusterService) updateTreeClusterPosition(ctx context.Context, id int32) error {
+	wateringStatuses := s.getWateringStatuses(ctx, []int32{id})
+	return s.treeClusterRepo.Update(ctx, id, s.updatePositionFn(ctx, wateringStatuses))
+}
+
+// getWateringStatuses computes the watering status of the tree clusters from their sensor data,
+// the status of a tree cluster that can not be computed is unknown
+func (s *TreeClusterService) getWateringStatuses(ctx context.Context, ids []int32) map[int32]domain.WateringStatus {
 	log := logger.GetLogger(ctx)
-	wateringStatus, err := s.getWateringStatusOfTreeCluster(ctx, id)
-	if err != nil {
-		log.Error("could not update watering status", "error", err)
+	wateringStatuses := make(map[int32]domain.WateringStatus, len(ids))
+	for _, id := range ids {
+		wateringStatus, err := s.getWateringStatusOfTreeCluster(ctx, id)
+		if err != nil {
+			log.Error("could not update watering status", "error", err)
+		}
+		wateringStatuses[id] = wateringStatus
 	}
+	return wateringStatuses
+}
+
+// updatePositionFn returns the update function that moves a tree cluster to the center of its trees and
+// sets the region of the new position and the given watering status
+func (s *TreeClusterService) updatePositionFn(ctx context.Context, wateringStatuses map[int32]domain.WateringStatus) func(*domain.TreeCluster, storage.TreeClusterRepository) (bool, error) {
+	log := logger.GetLogger(ctx)
+	return func(tc *domain.TreeCluster, repo storage.TreeClusterRepository) (bool, error) {
+		id := tc.ID
+		wateringStatus := wateringStatuses[id]
 
-	err = s.treeClusterRepo.Update(ctx, id, func(tc *domain.TreeCluster, repo storage.TreeClusterRepository) (bool, error) {
 		if len(tc.Trees) != 0 {
 			lat, long, err := repo.GetCenterPoint(ctx, tc.ID)
 			if err != nil {
@@ -319,9 +405,7 @@ func (s *TreeClusterService) updateTreeClusterPosition(ctx context.Context, id i
 		}
 
 		return true, nil
-	})
-
-	return err
+	}
 }
 
 // handlePrevTreeLocation updates the locations of clusters associated with the provided trees.
@@ -402,3 +486,45 @@ func (s *TreeClusterService) getTrees(ctx context.Context, ids []*int32) ([]*dom
 
 	return s.treeRepo.GetTreesByIDs(ctx, treeIDs)
 }
+
+// validatePolygon checks that a manually drawn polygon is a valid polygon, an empty polygon is computed from the trees
+func validatePolygon(ctx context.Context, polygon string) error {
+	if polygon == "" {
+		return nil
+	}
+
+	log := logger.GetLogger(ctx)
+	g, err := geos.NewGeomFromWKT(polygon)
+	if err != nil {
+		log.Debug("failed to parse tree cluster polygon", "error", err)
+		return service.ErrClusterPolygonInvalid
+	}
+
+	if g.TypeID() != geos.TypeIDPolygon || g.IsEmpty() || !g.IsValid() {
+		log.Debug("tree cluster polygon is no valid polygon", "geometry_type", g.Type())
+		return service.ErrClusterPolygonInvalid
+	}
+
+	return nil
+}
+
+// polygonTolerance is the tolerance in degrees up to which two polygons are considered the same
+const polygonTolerance = 1e-9
+
+// samePolygon reports whether both WKT polygons describe the same polygon
+func samePolygon(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+
+	ga, err := geos.NewGeomFromWKT(a)
+	if err != nil {
+		return false
+	}
+	gb, err := geos.NewGeomFromWKT(b)
+	if err != nil {
+		return false
+	}
+
+	return ga.EqualsExact(gb, polygonTolerance)
+}
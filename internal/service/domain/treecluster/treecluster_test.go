@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
@@ -303,9 +305,7 @@ func TestTreeClusterService_Create(t *testing.T) {
 			mock.Anything,
 		).Return(expectedCluster, nil)
 
-		// UpdateWateringStatuses
-		clusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).Return(testClusters, int64(len(testClusters)), nil)
-
+		// the watering statuses are not updated after the failed creation
 		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(
 			ctx,
 			int32(1),
@@ -354,6 +354,63 @@ func TestTreeClusterService_Create(t *testing.T) {
 		assert.Nil(t, result)
 		//assert.EqualError(t, err, "400: validation error: Key: 'TreeClusterCreate.Name' Error:Field validation for 'Name' failed on the 'required' tag")
 	})
+
+	t.Run("should create tree cluster with manual polygon", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		polygon := "POLYGON ((9.44 54.80, 9.45 54.80, 9.45 54.81, 9.44 54.80))"
+		newCluster := &entities.TreeClusterCreate{
+			Name:    "Cluster 1",
+			TreeIDs: []*int32{},
+			Polygon: polygon,
+		}
+		expectedCluster := &entities.TreeCluster{ID: 1, Name: "Cluster 1", Polygon: polygon, PolygonManual: true}
+
+		treeRepo.EXPECT().GetTreesByIDs(ctx, []int32{}).Return([]*entities.Tree{}, nil)
+		clusterRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(*entities.TreeCluster, storage.TreeClusterRepository) (bool, error)) (*entities.TreeCluster, error) {
+			tc := &entities.TreeCluster{}
+			_, err := fn(tc, clusterRepo)
+			assert.NoError(t, err)
+			assert.Equal(t, polygon, tc.Polygon)
+			assert.True(t, tc.PolygonManual)
+			return expectedCluster, nil
+		})
+		clusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).Return([]*entities.TreeCluster{}, int64(0), nil)
+		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return([]*entities.SensorData{}, nil)
+		clusterRepo.EXPECT().Update(ctx, int32(1), mock.Anything).Return(nil)
+
+		// when
+		result, err := svc.Create(ctx, newCluster)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCluster, result)
+	})
+
+	t.Run("should return error when polygon is invalid", func(t *testing.T) {
+		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		regionRepo := storageMock.NewMockRegionRepository(t)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+		polygons := []string{
+			"POLYGON ((9.44 54.80",
+			"POINT (9.44 54.80)",
+			"POLYGON ((0 0, 1 1, 1 0, 0 1, 0 0))",
+		}
+
+		for _, polygon := range polygons {
+			// when
+			result, err := svc.Create(ctx, &entities.TreeClusterCreate{Name: "Cluster 1", Polygon: polygon})
+
+			// then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, service.ErrClusterPolygonInvalid)
+		}
+	})
 }
 
 func TestTreeClusterService_Update(t *testing.T) {
@@ -455,6 +512,57 @@ func TestTreeClusterService_Update(t *testing.T) {
 		assert.Equal(t, expectedCluster, result)
 	})
 
+	t.Run("should only mark the polygon as manual when it differs from the computed polygon", func(t *testing.T) {
+		computed := "POLYGON ((9.44 54.8, 9.45 54.8, 9.45 54.81, 9.44 54.8))"
+		// the polygon as the client sends it back after a GET of the tree cluster
+		roundTrip, err := utils.GeoJSONToWKT(utils.WKTToGeoJSON(computed))
+		assert.NoError(t, err)
+
+		tests := []struct {
+			name           string
+			storedManual   bool
+			polygon        string
+			expectedManual bool
+		}{
+			{name: "computed polygon sent back unchanged", polygon: roundTrip, expectedManual: false},
+			{name: "computed polygon changed", polygon: "POLYGON ((9.44 54.8, 9.46 54.8, 9.46 54.81, 9.44 54.8))", expectedManual: true},
+			{name: "manual polygon sent back unchanged", storedManual: true, polygon: computed, expectedManual: true},
+			{name: "polygon removed", storedManual: true, polygon: "", expectedManual: false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				clusterRepo := storageMock.NewMockTreeClusterRepository(t)
+				treeRepo := storageMock.NewMockTreeRepository(t)
+				regionRepo := storageMock.NewMockRegionRepository(t)
+				svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+
+				storedCluster := &entities.TreeCluster{ID: 2, Name: "Cluster 2", Polygon: computed, PolygonManual: tt.storedManual}
+				update := &entities.TreeClusterUpdate{Name: "Cluster 2", TreeIDs: []*int32{}, Polygon: tt.polygon}
+
+				treeRepo.EXPECT().GetTreesByIDs(ctx, []int32{}).Return(nil, nil)
+				clusterRepo.EXPECT().GetByID(ctx, storedCluster.ID).Return(storedCluster, nil)
+				clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, storedCluster.ID).Return(nil, storage.ErrSensorNotFound)
+				clusterRepo.EXPECT().Update(ctx, storedCluster.ID, mock.Anything).RunAndReturn(func(ctx context.Context, id int32, fn func(*entities.TreeCluster, storage.TreeClusterRepository) (bool, error)) error {
+					tc := *storedCluster
+					_, err := fn(&tc, clusterRepo)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.polygon, tc.Polygon)
+					assert.Equal(t, tt.expectedManual, tc.PolygonManual)
+					return nil
+				}).Once()
+				clusterRepo.EXPECT().Update(ctx, storedCluster.ID, mock.Anything).Return(nil)
+				clusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).Return([]*entities.TreeCluster{}, int64(0), nil)
+
+				// when
+				_, err := svc.Update(ctx, storedCluster.ID, update)
+
+				// then
+				assert.NoError(t, err)
+			})
+		}
+	})
+
 	t.Run("should return an error when no trees are found", func(t *testing.T) {
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
@@ -697,16 +805,33 @@ func TestTreeClusterService_Delete(t *testing.T) {
 		assert.Error(t, err)
 		// assert.EqualError(t, err, "500: failed to delete")
 	})
+
+	t.Run("should return error without unlinking trees if the version does not match", func(t *testing.T) {
+		id := int32(5)
+		ifMatchCtx := etag.WithIfMatch(ctx, testClusters[0].Version+1)
+
+		clusterRepo.EXPECT().GetByID(ifMatchCtx, id).Return(testClusters[0], nil)
+
+		// when
+		err := svc.Delete(ifMatchCtx, id)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrVersionMismatch)
+		treeRepo.AssertNotCalled(t, "UnlinkTreeClusterID", ifMatchCtx, id)
+	})
 }
 
 func TestTreeClusterService_UpdateWateringStatuses(t *testing.T) {
 	t.Run("should update »just watered« watering status of tree cluster successfully", func(t *testing.T) {
 		// given
-		ctx := context.Background()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 		clusterRepo := storageMock.NewMockTreeClusterRepository(t)
 		treeRepo := storageMock.NewMockTreeRepository(t)
 		regionRepo := storageMock.NewMockRegionRepository(t)
-		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, globalEventManager)
+		eventManager := worker.NewEventManager(entities.EventTypeUpdateTreeCluster)
+		go eventManager.Run(ctx)
+		svc := NewTreeClusterService(clusterRepo, treeRepo, regionRepo, eventManager)
 
 		staleDate := time.Now().Add(-34 * time.Hour)
 		recentDate := time.Now().Add(-2 * time.Hour)
@@ -730,6 +855,10 @@ func TestTreeClusterService_UpdateWateringStatuses(t *testing.T) {
 		clusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(mock.Anything, staleCluster.ID).Return(allLatestSensorData, nil)
 		treeRepo.EXPECT().GetBySensorIDs(ctx, "sensor-1").Return(testTrees, nil)
 		clusterRepo.EXPECT().Update(mock.Anything, staleCluster.ID, mock.Anything).Return(nil)
+		updatedCluster := *staleCluster
+		updatedCluster.WateringStatus = entities.WateringStatusGood
+		clusterRepo.EXPECT().GetByID(mock.Anything, staleCluster.ID).Return(&updatedCluster, nil)
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeUpdateTreeCluster)
 
 		err := svc.UpdateWateringStatuses(ctx)
 
@@ -740,6 +869,12 @@ func TestTreeClusterService_UpdateWateringStatuses(t *testing.T) {
 		treeRepo.AssertCalled(t, "GetBySensorIDs", mock.Anything, mock.Anything)
 		clusterRepo.AssertCalled(t, "Update", mock.Anything, staleCluster.ID, mock.Anything)
 		clusterRepo.AssertExpectations(t)
+		select {
+		case event := <-ch:
+			assert.Equal(t, entities.NewEventUpdateTreeCluster(staleCluster, &updatedCluster), event)
+		case <-time.After(time.Second):
+			t.Fatal("event was not received")
+		}
 	})
 
 	t.Run("should update watering status to unknown when tree cluster has no trees", func(t *testing.T) {
@@ -771,6 +906,8 @@ func TestTreeClusterService_UpdateWateringStatuses(t *testing.T) {
 		clusterRepo.EXPECT().GetAll(mock.Anything, entities.TreeClusterQuery{}).Return(expectList, int64(len(expectList)), nil)
 		clusterRepo.EXPECT().Update(mock.Anything, staleCluster.ID, mock.Anything).Return(nil)
 		clusterRepo.EXPECT().Update(mock.Anything, recentCluster.ID, mock.Anything).Return(nil)
+		clusterRepo.EXPECT().GetByID(mock.Anything, staleCluster.ID).Return(staleCluster, nil)
+		clusterRepo.EXPECT().GetByID(mock.Anything, recentCluster.ID).Return(recentCluster, nil)
 
 		err := svc.UpdateWateringStatuses(ctx)
 
@@ -0,0 +1,303 @@
+package treeimport
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/twpayne/go-geos"
+
+	// registers the "sqlite" driver used to read geopackage files
+	_ "modernc.org/sqlite"
+)
+
+// wgs84SrsID is the only spatial reference system accepted for geopackage files
+const wgs84SrsID = 4326
+
+var (
+	ErrEmptyFile             = errors.New("file contains no rows")
+	ErrUnsupportedFormat     = errors.New("file format is not supported")
+	ErrNoFeatureTable        = errors.New("geopackage contains no feature table")
+	ErrUnsupportedSrs        = errors.New("geopackage must use the spatial reference system EPSG:4326")
+	ErrInvalidGpkgGeometry   = errors.New("invalid geopackage geometry")
+	ErrNoFeatureCollection   = errors.New("geojson must be a feature collection")
+	ErrGeometryIsNotAPoint   = errors.New("geometry must be a point")
+	ErrGeometryMissingCoords = errors.New("point geometry must have a longitude and a latitude")
+)
+
+// record is one row of an import file. Values are keyed by column name.
+// For files with geometries the point is stored separately.
+type record struct {
+	values      map[string]string
+	point       *point
+	geometryErr error
+}
+
+type point struct {
+	longitude float64
+	latitude  float64
+}
+
+func parseFile(ctx context.Context, format entities.TreeImportFormat, data []byte) ([]*record, error) {
+	switch format {
+	case entities.TreeImportFormatCSV:
+		return parseCSV(data)
+	case entities.TreeImportFormatGeoJSON:
+		return parseGeoJSON(data)
+	case entities.TreeImportFormatGeoPackage:
+		return parseGeoPackage(ctx, data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// parseCSV reads a csv file with a header row. The delimiter is detected from the header,
+// comma and semicolon separated files are supported.
+func parseCSV(data []byte) ([]*record, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+
+	columns, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrEmptyFile
+		}
+		return nil, err
+	}
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+	}
+
+	records := make([]*record, 0)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if i < len(row) {
+				values[column] = strings.TrimSpace(row[i])
+			}
+		}
+		records = append(records, &record{values: values})
+	}
+
+	return records, nil
+}
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Geometry   *geoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// parseGeoJSON reads a feature collection. The properties of each feature are used as columns
+// and the coordinates are taken from its point geometry.
+func parseGeoJSON(data []byte) ([]*record, error) {
+	var fc geoJSONFeatureCollection
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fc); err != nil {
+		return nil, err
+	}
+
+	if fc.Type != "FeatureCollection" {
+		return nil, ErrNoFeatureCollection
+	}
+
+	records := make([]*record, 0, len(fc.Features))
+	for _, feature := range fc.Features {
+		rec := &record{values: make(map[string]string, len(feature.Properties))}
+		for key, value := range feature.Properties {
+			rec.values[key] = stringify(value)
+		}
+		rec.point, rec.geometryErr = parseGeoJSONPoint(feature.Geometry)
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+func parseGeoJSONPoint(geometry *geoJSONGeometry) (*point, error) {
+	if geometry == nil {
+		return nil, nil
+	}
+
+	if geometry.Type != "Point" {
+		return nil, ErrGeometryIsNotAPoint
+	}
+
+	var coords []float64
+	if err := json.Unmarshal(geometry.Coordinates, &coords); err != nil || len(coords) < 2 {
+		return nil, ErrGeometryMissingCoords
+	}
+
+	return &point{longitude: coords[0], latitude: coords[1]}, nil
+}
+
+// parseGeoPackage reads the first feature table of a geopackage. The geometry column must contain
+// points in EPSG:4326, all other columns are used like csv columns.
+func parseGeoPackage(ctx context.Context, data []byte) ([]*record, error) {
+	file, err := os.CreateTemp("", "tree-import-*.gpkg")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", "file:"+file.Name()+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var table, geomColumn string
+	var srsID int32
+	err = db.QueryRowContext(ctx, `
+		SELECT c.table_name, g.column_name, g.srs_id
+		FROM gpkg_contents c
+		JOIN gpkg_geometry_columns g ON g.table_name = c.table_name
+		WHERE c.data_type = 'features'
+		ORDER BY c.table_name
+		LIMIT 1`).Scan(&table, &geomColumn, &srsID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoFeatureTable
+		}
+		return nil, err
+	}
+
+	if srsID != wgs84SrsID {
+		return nil, ErrUnsupportedSrs
+	}
+
+	//nolint:gosec // the table name is read from the geopackage and quoted
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`SELECT * FROM "%s"`, strings.ReplaceAll(table, `"`, `""`)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*record, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		rec := &record{values: make(map[string]string, len(columns))}
+		for i, column := range columns {
+			if column == geomColumn {
+				blob, _ := values[i].([]byte)
+				rec.point, rec.geometryErr = parseGpkgPoint(blob)
+				continue
+			}
+			rec.values[column] = stringify(values[i])
+		}
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+// parseGpkgPoint decodes a geopackage geometry blob, a small header followed by the wkb geometry.
+// The spatial reference system is already checked for the whole geometry column.
+func parseGpkgPoint(blob []byte) (*point, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+
+	if len(blob) < 8 || blob[0] != 'G' || blob[1] != 'P' {
+		return nil, ErrInvalidGpkgGeometry
+	}
+
+	flags := blob[3]
+	if flags&0x10 != 0 {
+		return nil, nil // empty geometry
+	}
+
+	envelopeSizes := map[byte]int{0: 0, 1: 32, 2: 48, 3: 48, 4: 64}
+	envelopeSize, ok := envelopeSizes[(flags>>1)&0x07]
+	if !ok || len(blob) < 8+envelopeSize {
+		return nil, ErrInvalidGpkgGeometry
+	}
+
+	geom, err := geos.NewGeomFromWKB(blob[8+envelopeSize:])
+	if err != nil {
+		return nil, ErrInvalidGpkgGeometry
+	}
+
+	if geom.TypeID() != geos.TypeIDPoint {
+		return nil, ErrGeometryIsNotAPoint
+	}
+
+	if geom.IsEmpty() {
+		return nil, nil
+	}
+
+	return &point{longitude: geom.X(), latitude: geom.Y()}, nil
+}
+
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case []byte:
+		return strings.TrimSpace(string(v))
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
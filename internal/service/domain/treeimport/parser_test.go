@@ -0,0 +1,194 @@
+package treeimport
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Run("should parse comma separated file", func(t *testing.T) {
+		// given
+		data := []byte("number,species,latitude,longitude\nT-1,Quercus robur,54.82,9.48\nT-2, Tilia cordata ,54.83,9.49\n")
+
+		// when
+		records, err := parseCSV(data)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "T-1", records[0].values["number"])
+		assert.Equal(t, "Tilia cordata", records[1].values["species"])
+		assert.Equal(t, "54.83", records[1].values["latitude"])
+	})
+
+	t.Run("should detect semicolon as delimiter and strip byte order mark", func(t *testing.T) {
+		// given
+		data := []byte("\xef\xbb\xbfBaumnummer;Baumart\nT-1;Quercus robur, Stieleiche\n")
+
+		// when
+		records, err := parseCSV(data)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "T-1", records[0].values["Baumnummer"])
+		assert.Equal(t, "Quercus robur, Stieleiche", records[0].values["Baumart"])
+	})
+
+	t.Run("should return error on empty file", func(t *testing.T) {
+		// when
+		records, err := parseCSV([]byte{})
+
+		// then
+		assert.ErrorIs(t, err, ErrEmptyFile)
+		assert.Nil(t, records)
+	})
+}
+
+func TestParseGeoJSON(t *testing.T) {
+	t.Run("should parse properties and point geometry", func(t *testing.T) {
+		// given
+		data := []byte(`{"type":"FeatureCollection","features":[
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[9.48,54.82]},"properties":{"number":"T-1","planting_year":2010,"tree_cluster_id":null}},
+			{"type":"Feature","geometry":{"type":"LineString","coordinates":[[9.48,54.82],[9.49,54.83]]},"properties":{"number":"T-2"}}
+		]}`)
+
+		// when
+		records, err := parseGeoJSON(data)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "T-1", records[0].values["number"])
+		assert.Equal(t, "2010", records[0].values["planting_year"])
+		assert.Equal(t, "", records[0].values["tree_cluster_id"])
+		assert.Equal(t, &point{longitude: 9.48, latitude: 54.82}, records[0].point)
+		assert.NoError(t, records[0].geometryErr)
+		assert.Nil(t, records[1].point)
+		assert.ErrorIs(t, records[1].geometryErr, ErrGeometryIsNotAPoint)
+	})
+
+	t.Run("should return error when file is no feature collection", func(t *testing.T) {
+		// when
+		records, err := parseGeoJSON([]byte(`{"type":"Feature"}`))
+
+		// then
+		assert.ErrorIs(t, err, ErrNoFeatureCollection)
+		assert.Nil(t, records)
+	})
+}
+
+func TestParseGeoPackage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should parse feature table with point geometry", func(t *testing.T) {
+		// given
+		data := newTestGeoPackage(t, wgs84SrsID)
+
+		// when
+		records, err := parseGeoPackage(ctx, data)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "T-1", records[0].values["number"])
+		assert.Equal(t, "2010", records[0].values["planting_year"])
+		assert.NotContains(t, records[0].values, "geom")
+		assert.Equal(t, &point{longitude: 9.48, latitude: 54.82}, records[0].point)
+		assert.Nil(t, records[1].point)
+	})
+
+	t.Run("should return error when srs is not wgs 84", func(t *testing.T) {
+		// given
+		data := newTestGeoPackage(t, 25832)
+
+		// when
+		records, err := parseGeoPackage(ctx, data)
+
+		// then
+		assert.ErrorIs(t, err, ErrUnsupportedSrs)
+		assert.Nil(t, records)
+	})
+
+	t.Run("should return error when file is no geopackage", func(t *testing.T) {
+		// when
+		records, err := parseFile(ctx, entities.TreeImportFormatGeoPackage, []byte("number,species"))
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, records)
+	})
+}
+
+func TestParseGpkgPoint(t *testing.T) {
+	t.Run("should skip envelope of the header", func(t *testing.T) {
+		// given
+		blob := gpkgPoint(9.48, 54.82)
+		withEnvelope := append([]byte{'G', 'P', 0, 0x03}, blob[4:8]...)
+		withEnvelope = append(withEnvelope, make([]byte, 32)...)
+		withEnvelope = append(withEnvelope, blob[8:]...)
+
+		// when
+		p, err := parseGpkgPoint(withEnvelope)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &point{longitude: 9.48, latitude: 54.82}, p)
+	})
+
+	t.Run("should return error on invalid header", func(t *testing.T) {
+		// when
+		p, err := parseGpkgPoint([]byte("not a geometry"))
+
+		// then
+		assert.ErrorIs(t, err, ErrInvalidGpkgGeometry)
+		assert.Nil(t, p)
+	})
+}
+
+func newTestGeoPackage(t *testing.T, srsID int) []byte {
+	path := filepath.Join(t.TempDir(), "trees.gpkg")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+
+	stmts := []string{
+		`CREATE TABLE gpkg_contents (table_name TEXT PRIMARY KEY, data_type TEXT NOT NULL)`,
+		`CREATE TABLE gpkg_geometry_columns (table_name TEXT, column_name TEXT, geometry_type_name TEXT, srs_id INTEGER)`,
+		`CREATE TABLE trees (fid INTEGER PRIMARY KEY, geom BLOB, number TEXT, planting_year INTEGER)`,
+		`INSERT INTO gpkg_contents VALUES ('trees', 'features')`,
+	}
+	for _, stmt := range stmts {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	_, err = db.Exec(`INSERT INTO gpkg_geometry_columns VALUES ('trees', 'geom', 'POINT', ?)`, srsID)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO trees (geom, number, planting_year) VALUES (?, 'T-1', 2010), (NULL, 'T-2', 2012)`, gpkgPoint(9.48, 54.82))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
+
+// gpkgPoint encodes a point as geopackage geometry blob without envelope
+func gpkgPoint(x, y float64) []byte {
+	blob := []byte{'G', 'P', 0, 0x01}
+	blob = binary.LittleEndian.AppendUint32(blob, wgs84SrsID)
+	blob = append(blob, 0x01) // little endian wkb
+	blob = binary.LittleEndian.AppendUint32(blob, 1)
+	blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(x))
+	blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(y))
+	return blob
+}
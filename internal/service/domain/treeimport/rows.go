@@ -0,0 +1,263 @@
+package treeimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+// validationFields maps the fields of TreeCreate to the field of the import mapping
+var validationFields = map[string]entities.TreeImportField{
+	"Number":        entities.TreeImportFieldNumber,
+	"Species":       entities.TreeImportFieldSpecies,
+	"PlantingYear":  entities.TreeImportFieldPlantingYear,
+	"Latitude":      entities.TreeImportFieldLatitude,
+	"Longitude":     entities.TreeImportFieldLongitude,
+	"Description":   entities.TreeImportFieldDescription,
+	"Provider":      entities.TreeImportFieldProvider,
+	"TreeClusterID": entities.TreeImportFieldTreeClusterID,
+	"SensorID":      entities.TreeImportFieldSensorID,
+}
+
+// rowBuilder turns the records of an import file into trees. It caches the lookup
+// of tree clusters and sensors because most files reference the same few ids.
+type rowBuilder struct {
+	svc      *TreeImportService
+	job      *entities.TreeImportJob
+	clusters map[int32]*entities.TreeCluster
+	sensors  map[string]*entities.Sensor
+	numbers  map[string]int32
+	sensorOf map[string]int32
+}
+
+func newRowBuilder(svc *TreeImportService, job *entities.TreeImportJob) *rowBuilder {
+	return &rowBuilder{
+		svc:      svc,
+		job:      job,
+		clusters: make(map[int32]*entities.TreeCluster),
+		sensors:  make(map[string]*entities.Sensor),
+		numbers:  make(map[string]int32),
+		sensorOf: make(map[string]int32),
+	}
+}
+
+// build validates every record and returns the trees of all valid rows together with the errors of all invalid rows.
+func (b *rowBuilder) build(ctx context.Context, records []*record) ([]*entities.Tree, []entities.TreeImportRowError, error) {
+	trees := make([]*entities.Tree, 0, len(records))
+	rowErrors := make([]entities.TreeImportRowError, 0)
+
+	for i, rec := range records {
+		row := int32(i + 1) //nolint:gosec // the number of rows is limited by the upload size
+		tree, errs, err := b.buildRow(ctx, row, rec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(errs) != 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		trees = append(trees, tree)
+	}
+
+	return trees, rowErrors, nil
+}
+
+func (b *rowBuilder) buildRow(ctx context.Context, row int32, rec *record) (*entities.Tree, []entities.TreeImportRowError, error) {
+	mapping := b.job.Mapping
+	rowErrors := make([]entities.TreeImportRowError, 0)
+	addError := func(field entities.TreeImportField, msg string) {
+		rowErrors = append(rowErrors, entities.TreeImportRowError{Row: row, Field: field, Message: msg})
+	}
+	value := func(field entities.TreeImportField) string {
+		return rec.values[mapping.Column(field)]
+	}
+
+	createData := &entities.TreeCreate{
+		Number:      value(entities.TreeImportFieldNumber),
+		Species:     value(entities.TreeImportFieldSpecies),
+		Description: value(entities.TreeImportFieldDescription),
+		Provider:    value(entities.TreeImportFieldProvider),
+	}
+	if createData.Provider == "" {
+		createData.Provider = b.job.Provider
+	}
+
+	if raw := value(entities.TreeImportFieldPlantingYear); raw != "" {
+		year, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			addError(entities.TreeImportFieldPlantingYear, fmt.Sprintf("%q is not a valid year", raw))
+		}
+		createData.PlantingYear = int32(year)
+	}
+
+	b.readCoordinates(rec, createData, addError)
+
+	if raw := value(entities.TreeImportFieldTreeClusterID); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			addError(entities.TreeImportFieldTreeClusterID, fmt.Sprintf("%q is not a valid id", raw))
+		} else {
+			createData.TreeClusterID = utils.P(int32(id))
+		}
+	}
+
+	if raw := value(entities.TreeImportFieldSensorID); raw != "" {
+		createData.SensorID = &raw
+	}
+
+	if err := b.svc.validator.Struct(createData); err != nil {
+		var validationErrs validator.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			return nil, nil, err
+		}
+		for _, fieldErr := range validationErrs {
+			if !hasFieldError(rowErrors, validationFields[fieldErr.Field()]) {
+				addError(validationFields[fieldErr.Field()], fmt.Sprintf("failed on the %q rule", fieldErr.Tag()))
+			}
+		}
+	}
+
+	tree := &entities.Tree{
+		Number:       createData.Number,
+		Species:      createData.Species,
+		PlantingYear: createData.PlantingYear,
+		Latitude:     createData.Latitude,
+		Longitude:    createData.Longitude,
+		Description:  createData.Description,
+		Provider:     createData.Provider,
+	}
+
+	if err := b.resolveReferences(ctx, row, createData, tree, addError); err != nil {
+		return nil, nil, err
+	}
+
+	return tree, rowErrors, nil
+}
+
+// readCoordinates prefers mapped columns and falls back to the point geometry of the record
+func (b *rowBuilder) readCoordinates(rec *record, createData *entities.TreeCreate, addError func(entities.TreeImportField, string)) {
+	mapping := b.job.Mapping
+	for _, field := range []entities.TreeImportField{entities.TreeImportFieldLatitude, entities.TreeImportFieldLongitude} {
+		raw := rec.values[mapping.Column(field)]
+		if raw == "" {
+			continue
+		}
+
+		coord, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			addError(field, fmt.Sprintf("%q is not a valid coordinate", raw))
+			continue
+		}
+
+		if field == entities.TreeImportFieldLatitude {
+			createData.Latitude = coord
+		} else {
+			createData.Longitude = coord
+		}
+	}
+
+	if createData.Latitude != 0 && createData.Longitude != 0 {
+		return
+	}
+
+	if rec.geometryErr != nil {
+		addError(entities.TreeImportFieldLatitude, rec.geometryErr.Error())
+		return
+	}
+
+	if rec.point != nil {
+		createData.Latitude = rec.point.latitude
+		createData.Longitude = rec.point.longitude
+	}
+}
+
+func (b *rowBuilder) resolveReferences(ctx context.Context, row int32, createData *entities.TreeCreate, tree *entities.Tree, addError func(entities.TreeImportField, string)) error {
+	key := createData.Provider + "\x00" + createData.Number
+	if prevRow, ok := b.numbers[key]; ok && createData.Number != "" {
+		addError(entities.TreeImportFieldNumber, fmt.Sprintf("number %q is already used in row %d", createData.Number, prevRow))
+	} else {
+		b.numbers[key] = row
+	}
+
+	if createData.TreeClusterID != nil {
+		tc, err := b.treeCluster(ctx, *createData.TreeClusterID)
+		if err != nil {
+			return err
+		}
+		if tc == nil {
+			addError(entities.TreeImportFieldTreeClusterID, fmt.Sprintf("tree cluster %d does not exist", *createData.TreeClusterID))
+		}
+		tree.TreeCluster = tc
+	}
+
+	if createData.SensorID != nil {
+		if prevRow, ok := b.sensorOf[*createData.SensorID]; ok {
+			addError(entities.TreeImportFieldSensorID, fmt.Sprintf("sensor %q is already used in row %d", *createData.SensorID, prevRow))
+		} else {
+			b.sensorOf[*createData.SensorID] = row
+		}
+
+		sensor, err := b.sensor(ctx, *createData.SensorID)
+		if err != nil {
+			return err
+		}
+		if sensor == nil {
+			addError(entities.TreeImportFieldSensorID, fmt.Sprintf("sensor %q does not exist", *createData.SensorID))
+		}
+		tree.Sensor = sensor
+	}
+
+	return nil
+}
+
+func (b *rowBuilder) treeCluster(ctx context.Context, id int32) (*entities.TreeCluster, error) {
+	if tc, ok := b.clusters[id]; ok {
+		return tc, nil
+	}
+
+	tc, err := b.svc.treeClusterRepo.GetByID(ctx, id)
+	if err != nil && !isNotFound(err) {
+		logger.GetLogger(ctx).Debug("failed to fetch tree cluster referenced by import", "error", err, "cluster_id", id)
+		return nil, err
+	}
+
+	b.clusters[id] = tc
+	return tc, nil
+}
+
+func (b *rowBuilder) sensor(ctx context.Context, id string) (*entities.Sensor, error) {
+	if sensor, ok := b.sensors[id]; ok {
+		return sensor, nil
+	}
+
+	sensor, err := b.svc.sensorRepo.GetByID(ctx, id)
+	if err != nil && !isNotFound(err) {
+		logger.GetLogger(ctx).Debug("failed to fetch sensor referenced by import", "error", err, "sensor_id", id)
+		return nil, err
+	}
+
+	b.sensors[id] = sensor
+	return sensor, nil
+}
+
+func isNotFound(err error) bool {
+	var entityNotFoundErr storage.ErrEntityNotFound
+	return errors.As(err, &entityNotFoundErr)
+}
+
+func hasFieldError(rowErrors []entities.TreeImportRowError, field entities.TreeImportField) bool {
+	for _, rowErr := range rowErrors {
+		if rowErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,373 @@
+package treeimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+)
+
+type TreeImportServiceConfig struct {
+	maxRowErrors int
+	claimLease   time.Duration
+}
+
+type TreeImportServiceOption func(*TreeImportServiceConfig)
+
+var defaultTreeImportServiceConfig = TreeImportServiceConfig{
+	maxRowErrors: 1000,
+	claimLease:   30 * time.Minute,
+}
+
+// WithMaxRowErrors limits the number of row errors stored in the report of an import job
+func WithMaxRowErrors(maxRowErrors int) TreeImportServiceOption {
+	slog.Debug("use tree import service with max row errors", "max_row_errors", maxRowErrors)
+	return func(cfg *TreeImportServiceConfig) {
+		cfg.maxRowErrors = maxRowErrors
+	}
+}
+
+// WithClaimLease sets how long a job may be validated or committed before another instance claims it again.
+// The lease must be longer than the processing of the largest file.
+func WithClaimLease(lease time.Duration) TreeImportServiceOption {
+	slog.Debug("use tree import service with claim lease", "lease", lease)
+	return func(cfg *TreeImportServiceConfig) {
+		cfg.claimLease = lease
+	}
+}
+
+type TreeImportService struct {
+	TreeImportServiceConfig
+	treeImportRepo  storage.TreeImportRepository
+	treeRepo        storage.TreeRepository
+	treeClusterRepo storage.TreeClusterRepository
+	sensorRepo      storage.SensorRepository
+	eventManager    worker.EventBus
+	validator       *validator.Validate
+}
+
+var _ service.TreeImportService = (*TreeImportService)(nil)
+
+func NewTreeImportService(
+	treeImportRepo storage.TreeImportRepository,
+	treeRepo storage.TreeRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+	sensorRepo storage.SensorRepository,
+	eventManager worker.EventBus,
+	opts ...TreeImportServiceOption,
+) *TreeImportService {
+	cfg := defaultTreeImportServiceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &TreeImportService{
+		TreeImportServiceConfig: cfg,
+		treeImportRepo:          treeImportRepo,
+		treeRepo:                treeRepo,
+		treeClusterRepo:         treeClusterRepo,
+		sensorRepo:              sensorRepo,
+		eventManager:            eventManager,
+		validator:               validator.New(),
+	}
+}
+
+func (s *TreeImportService) GetAll(ctx context.Context) ([]*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	jobs, err := s.treeImportRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch tree import jobs", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return jobs, nil
+}
+
+func (s *TreeImportService) GetByID(ctx context.Context, id int32) (*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	job, err := s.treeImportRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch tree import job by id", "error", err, "tree_import_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return job, nil
+}
+
+func (s *TreeImportService) Create(ctx context.Context, createData *entities.TreeImportCreate) (*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate struct from create tree import", "error", err, "file_name", createData.FileName, "format", createData.Format)
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if !createData.Format.IsValid() {
+		log.Debug("unsupported tree import format", "format", createData.Format)
+		return nil, service.ErrTreeImportFormatInvalid
+	}
+
+	for field := range createData.Mapping {
+		if !field.IsValid() {
+			log.Debug("unknown field in tree import mapping", "field", field)
+			return nil, service.ErrTreeImportFieldInvalid
+		}
+	}
+
+	job, err := s.treeImportRepo.Create(ctx, createData.File, func(job *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+		job.FileName = createData.FileName
+		job.Format = createData.Format
+		job.Status = entities.TreeImportStatusPending
+		job.Mapping = createData.Mapping
+		job.Provider = createData.Provider
+		job.CreatedBy = createData.CreatedBy
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create tree import job", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("tree import job created successfully", "tree_import_id", job.ID, "file_name", job.FileName, "format", job.Format)
+	return job, nil
+}
+
+func (s *TreeImportService) Commit(ctx context.Context, id int32) (*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	err := s.treeImportRepo.Update(ctx, id, func(job *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+		if job.Status != entities.TreeImportStatusValidated {
+			return false, service.ErrTreeImportNotValidated
+		}
+
+		job.Status = entities.TreeImportStatusQueued
+		return true, nil
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrTreeImportNotValidated) {
+			log.Debug("tree import job is not validated", "tree_import_id", id)
+			return nil, err
+		}
+		log.Debug("failed to queue tree import job", "error", err, "tree_import_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("tree import job queued for commit", "tree_import_id", id)
+	return s.GetByID(ctx, id)
+}
+
+func (s *TreeImportService) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	job, err := s.treeImportRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch tree import job by id", "error", err, "tree_import_id", id)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	if job.IsProcessing(time.Now(), s.claimLease) {
+		log.Debug("tree import job is in progress and can not be deleted", "tree_import_id", id, "status", job.Status)
+		return service.ErrTreeImportInProgress
+	}
+
+	if err := s.treeImportRepo.Delete(ctx, id); err != nil {
+		log.Debug("failed to delete tree import job", "error", err, "tree_import_id", id)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("tree import job deleted successfully", "tree_import_id", id)
+	return nil
+}
+
+// ProcessPending runs the dry run of every pending job and then imports every queued job.
+// Jobs are claimed one by one, so several instances can process jobs at the same time. Jobs of a crashed
+// instance are claimed again once their lease expired.
+func (s *TreeImportService) ProcessPending(ctx context.Context) error {
+	if err := s.processAll(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, s.validate); err != nil {
+		return err
+	}
+
+	return s.processAll(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, s.commit)
+}
+
+func (s *TreeImportService) processAll(ctx context.Context, from, to entities.TreeImportStatus, fn func(context.Context, *entities.TreeImportJob) error) error {
+	log := logger.GetLogger(ctx)
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		job, err := s.treeImportRepo.Claim(ctx, from, to, time.Now(), s.claimLease)
+		if err != nil {
+			if isNotFound(err) {
+				return nil
+			}
+			log.Error("failed to claim tree import job", "error", err, "status", from)
+			return err
+		}
+
+		if err := fn(ctx, job); err != nil {
+			log.Error("failed to process tree import job", "error", err, "tree_import_id", job.ID, "status", to)
+		}
+	}
+}
+
+// validate is the dry run of an import job. It checks every row without writing any tree.
+func (s *TreeImportService) validate(ctx context.Context, job *entities.TreeImportJob) error {
+	log := logger.GetLogger(ctx)
+	trees, rowErrors, total, err := s.readTrees(ctx, job)
+	if err != nil {
+		return s.finish(ctx, job.ID, entities.TreeImportStatusInvalid, err, func(j *entities.TreeImportJob) {
+			j.TotalRows = 0
+			j.ValidRows = 0
+		})
+	}
+
+	status := entities.TreeImportStatusValidated
+	if len(rowErrors) != 0 {
+		status = entities.TreeImportStatusInvalid
+	}
+
+	log.Info("tree import job validated", "tree_import_id", job.ID, "status", status, "total_rows", total, "valid_rows", len(trees))
+	return s.finish(ctx, job.ID, status, nil, func(j *entities.TreeImportJob) {
+		j.TotalRows = total
+		j.ValidRows = int32(len(trees)) //nolint:gosec // the number of rows is limited by the upload size
+		j.RowErrors = s.limitRowErrors(rowErrors)
+	})
+}
+
+// commit imports all trees of a validated job in a single transaction. Existing trees are matched by provider and number.
+// The audit log entries of the imported trees are attributed to the user that uploaded the file.
+func (s *TreeImportService) commit(ctx context.Context, job *entities.TreeImportJob) error {
+	log := logger.GetLogger(ctx)
+	trees, rowErrors, total, err := s.readTrees(ctx, job)
+	if err != nil {
+		return s.finish(ctx, job.ID, entities.TreeImportStatusFailed, err, nil)
+	}
+
+	// references may have been deleted since the dry run
+	if len(rowErrors) != 0 {
+		return s.finish(ctx, job.ID, entities.TreeImportStatusFailed, errors.New("the file has become invalid since the validation"), func(j *entities.TreeImportJob) {
+			j.TotalRows = total
+			j.ValidRows = int32(len(trees)) //nolint:gosec // the number of rows is limited by the upload size
+			j.RowErrors = s.limitRowErrors(rowErrors)
+		})
+	}
+
+	// the trees, the state of the job and the event are committed together
+	var created, updated int32
+	var upsertErr error
+	err = s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		var changes []*entities.TreeImportChange
+		changes, upsertErr = s.treeRepo.Upsert(context.WithValue(ctx, enums.ContextKeyActor, job.CreatedBy), trees)
+		if upsertErr != nil {
+			return upsertErr
+		}
+
+		for _, change := range changes {
+			if change.Prev == nil {
+				created++
+			} else {
+				updated++
+			}
+		}
+
+		if err := s.finish(ctx, job.ID, entities.TreeImportStatusCommitted, nil, func(j *entities.TreeImportJob) {
+			j.CreatedRows = created
+			j.UpdatedRows = updated
+		}); err != nil {
+			return err
+		}
+
+		s.publishImportTreesEvent(ctx, job.ID, changes)
+		return nil
+	})
+	if upsertErr != nil {
+		return s.finish(ctx, job.ID, entities.TreeImportStatusFailed, upsertErr, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("tree import job committed", "tree_import_id", job.ID, "created_rows", created, "updated_rows", updated)
+	return nil
+}
+
+func (s *TreeImportService) readTrees(ctx context.Context, job *entities.TreeImportJob) (trees []*entities.Tree, rowErrors []entities.TreeImportRowError, total int32, err error) {
+	file, err := s.treeImportRepo.GetFile(ctx, job.ID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	records, err := parseFile(ctx, job.Format, file)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to read %s file: %w", job.Format, err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil, 0, ErrEmptyFile
+	}
+
+	trees, rowErrors, err = newRowBuilder(s, job).build(ctx, records)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return trees, rowErrors, int32(len(records)), nil //nolint:gosec // the number of rows is limited by the upload size
+}
+
+func (s *TreeImportService) finish(ctx context.Context, id int32, status entities.TreeImportStatus, jobErr error, fn func(*entities.TreeImportJob)) error {
+	return s.treeImportRepo.Update(ctx, id, func(job *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+		job.Status = status
+		job.Error = nil
+		if jobErr != nil {
+			msg := jobErr.Error()
+			job.Error = &msg
+		}
+		if fn != nil {
+			fn(job)
+		}
+		return true, nil
+	})
+}
+
+func (s *TreeImportService) limitRowErrors(rowErrors []entities.TreeImportRowError) []entities.TreeImportRowError {
+	if len(rowErrors) > s.maxRowErrors {
+		return rowErrors[:s.maxRowErrors]
+	}
+	return rowErrors
+}
+
+// publishImportTreesEvent publishes one event for the whole import with every tree cluster that gained or lost a tree
+func (s *TreeImportService) publishImportTreesEvent(ctx context.Context, jobID int32, changes []*entities.TreeImportChange) {
+	log := logger.GetLogger(ctx)
+	log.Debug("publish new event", "event", entities.EventTypeImportTrees, "service", "TreeImportService")
+
+	clusterIDs := make([]int32, 0)
+	addCluster := func(tree *entities.Tree) {
+		if tree != nil && tree.TreeCluster != nil && !slices.Contains(clusterIDs, tree.TreeCluster.ID) {
+			clusterIDs = append(clusterIDs, tree.TreeCluster.ID)
+		}
+	}
+	for _, change := range changes {
+		addCluster(change.Prev)
+		addCluster(change.New)
+	}
+	slices.Sort(clusterIDs)
+
+	event := entities.NewEventImportTrees(jobID, changes, clusterIDs)
+	if err := s.eventManager.Publish(ctx, event); err != nil {
+		log.Error("error while sending event after importing trees", "err", err, "tree_import_id", jobID)
+	}
+}
+
+func (s *TreeImportService) Ready() bool {
+	return s.treeImportRepo != nil && s.treeRepo != nil
+}
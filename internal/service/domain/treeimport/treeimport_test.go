@@ -0,0 +1,435 @@
+package treeimport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testRepos struct {
+	importRepo  *storageMock.MockTreeImportRepository
+	treeRepo    *storageMock.MockTreeRepository
+	clusterRepo *storageMock.MockTreeClusterRepository
+	sensorRepo  *storageMock.MockSensorRepository
+}
+
+func setupTest(t *testing.T) (*testRepos, *worker.EventManager, *TreeImportService) {
+	repos := &testRepos{
+		importRepo:  storageMock.NewMockTreeImportRepository(t),
+		treeRepo:    storageMock.NewMockTreeRepository(t),
+		clusterRepo: storageMock.NewMockTreeClusterRepository(t),
+		sensorRepo:  storageMock.NewMockSensorRepository(t),
+	}
+	eventManager := worker.NewEventManager(entities.EventTypeImportTrees)
+	svc := NewTreeImportService(repos.importRepo, repos.treeRepo, repos.clusterRepo, repos.sensorRepo, eventManager)
+	return repos, eventManager, svc
+}
+
+const testCSV = "Baumnummer;species;planting_year;latitude;longitude;tree_cluster_id\n" +
+	"T-1;Quercus robur;2010;54.82;9.48;1\n" +
+	"T-2;Tilia cordata;2012;54.83;9.49;\n"
+
+func testJob(status entities.TreeImportStatus) *entities.TreeImportJob {
+	return &entities.TreeImportJob{
+		ID:       1,
+		FileName: "trees.csv",
+		Format:   entities.TreeImportFormatCSV,
+		Status:   status,
+		Mapping:  entities.TreeImportMapping{entities.TreeImportFieldNumber: "Baumnummer"},
+		Provider: "city",
+	}
+}
+
+// expectUpdate runs the update function of the repository on job and stores the result in job
+func expectUpdate(t *testing.T, repo *storageMock.MockTreeImportRepository, job *entities.TreeImportJob) {
+	repo.EXPECT().Update(mock.Anything, job.ID, mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.TreeImportJob, storage.TreeImportRepository) (bool, error)) error {
+		ok, err := fn(job, repo)
+		assert.True(t, ok)
+		return err
+	})
+}
+
+func TestTreeImportService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create pending import job", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		createData := &entities.TreeImportCreate{
+			FileName:  "trees.csv",
+			Format:    entities.TreeImportFormatCSV,
+			Mapping:   entities.TreeImportMapping{entities.TreeImportFieldNumber: "Baumnummer"},
+			Provider:  "city",
+			File:      []byte(testCSV),
+			CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		}
+
+		repos.importRepo.EXPECT().Create(ctx, createData.File, mock.Anything).RunAndReturn(func(_ context.Context, _ []byte, fn func(*entities.TreeImportJob, storage.TreeImportRepository) (bool, error)) (*entities.TreeImportJob, error) {
+			job := &entities.TreeImportJob{ID: 1}
+			ok, err := fn(job, repos.importRepo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return job, nil
+		})
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusPending, got.Status)
+		assert.Equal(t, createData.Mapping, got.Mapping)
+		assert.Equal(t, createData.Provider, got.Provider)
+		assert.Equal(t, createData.CreatedBy, got.CreatedBy)
+	})
+
+	t.Run("should return error when format is not supported", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+
+		// when
+		got, err := svc.Create(ctx, &entities.TreeImportCreate{FileName: "trees.xlsx", Format: "xlsx", File: []byte("data")})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrTreeImportFormatInvalid)
+		assert.Nil(t, got)
+		repos.importRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should return error when mapping contains unknown field", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+
+		// when
+		got, err := svc.Create(ctx, &entities.TreeImportCreate{
+			FileName: "trees.csv",
+			Format:   entities.TreeImportFormatCSV,
+			Mapping:  entities.TreeImportMapping{"watering_status": "status"},
+			File:     []byte(testCSV),
+		})
+
+		// then
+		assert.ErrorIs(t, err, service.ErrTreeImportFieldInvalid)
+		assert.Nil(t, got)
+		repos.importRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("should return validation error when file is missing", func(t *testing.T) {
+		// given
+		_, _, svc := setupTest(t)
+
+		// when
+		got, err := svc.Create(ctx, &entities.TreeImportCreate{FileName: "trees.csv", Format: entities.TreeImportFormatCSV})
+
+		// then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+		assert.Nil(t, got)
+	})
+}
+
+func TestTreeImportService_Commit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should queue validated import job", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusValidated)
+		expectUpdate(t, repos.importRepo, job)
+		repos.importRepo.EXPECT().GetByID(ctx, int32(1)).Return(job, nil)
+
+		// when
+		got, err := svc.Commit(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusQueued, got.Status)
+	})
+
+	t.Run("should return error when import job is not validated", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusInvalid)
+		repos.importRepo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.TreeImportJob, storage.TreeImportRepository) (bool, error)) error {
+			_, err := fn(job, repos.importRepo)
+			return err
+		})
+
+		// when
+		got, err := svc.Commit(ctx, 1)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrTreeImportNotValidated)
+		assert.Nil(t, got)
+		assert.Equal(t, entities.TreeImportStatusInvalid, job.Status)
+	})
+
+	t.Run("should return not found error when import job does not exist", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		repos.importRepo.EXPECT().Update(ctx, int32(1), mock.Anything).Return(storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Commit(ctx, 1)
+
+		// then
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTreeImportService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete import job", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		repos.importRepo.EXPECT().GetByID(ctx, int32(1)).Return(testJob(entities.TreeImportStatusCommitted), nil)
+		repos.importRepo.EXPECT().Delete(ctx, int32(1)).Return(nil)
+
+		// when
+		err := svc.Delete(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when import job is in progress", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusCommitting)
+		job.ClaimedAt = utils.P(time.Now())
+		repos.importRepo.EXPECT().GetByID(ctx, int32(1)).Return(job, nil)
+
+		// when
+		err := svc.Delete(ctx, 1)
+
+		// then
+		assert.ErrorIs(t, err, service.ErrTreeImportInProgress)
+		repos.importRepo.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("should delete import job whose claim expired", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusCommitting)
+		job.ClaimedAt = utils.P(time.Now().Add(-2 * svc.claimLease))
+		repos.importRepo.EXPECT().GetByID(ctx, int32(1)).Return(job, nil)
+		repos.importRepo.EXPECT().Delete(ctx, int32(1)).Return(nil)
+
+		// when
+		err := svc.Delete(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestTreeImportService_ProcessPending(t *testing.T) {
+	ctx := context.Background()
+	notFound := storage.ErrEntityNotFound("not found")
+
+	t.Run("should validate pending import job", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusValidating)
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(job, nil).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().GetFile(ctx, int32(1)).Return([]byte(testCSV), nil)
+		repos.clusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.TreeCluster{ID: 1}, nil).Once()
+		expectUpdate(t, repos.importRepo, job)
+
+		// when
+		err := svc.ProcessPending(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusValidated, job.Status)
+		assert.Equal(t, int32(2), job.TotalRows)
+		assert.Equal(t, int32(2), job.ValidRows)
+		assert.Empty(t, job.RowErrors)
+		assert.Nil(t, job.Error)
+		repos.treeRepo.AssertNotCalled(t, "Upsert")
+	})
+
+	t.Run("should report row errors of invalid rows", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusValidating)
+		file := "Baumnummer;planting_year;latitude;longitude;tree_cluster_id;sensor_id\n" +
+			"T-1;2010;54.82;9.48;1;\n" +
+			"T-1;abc;54.82;9.48;;\n" +
+			";2010;95;9.48;2;sensor-1\n" +
+			"T-3;2010;54.82;9.48;1;sensor-1\n"
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(job, nil).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().GetFile(ctx, int32(1)).Return([]byte(file), nil)
+		repos.clusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.TreeCluster{ID: 1}, nil).Once()
+		repos.clusterRepo.EXPECT().GetByID(ctx, int32(2)).Return(nil, storage.ErrEntityNotFound("not found")).Once()
+		repos.sensorRepo.EXPECT().GetByID(ctx, "sensor-1").Return(&entities.Sensor{ID: "sensor-1"}, nil).Once()
+		expectUpdate(t, repos.importRepo, job)
+
+		// when
+		err := svc.ProcessPending(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusInvalid, job.Status)
+		assert.Equal(t, int32(4), job.TotalRows)
+		assert.Equal(t, int32(1), job.ValidRows)
+		assert.ElementsMatch(t, []entities.TreeImportRowError{
+			{Row: 2, Field: entities.TreeImportFieldPlantingYear, Message: `"abc" is not a valid year`},
+			{Row: 2, Field: entities.TreeImportFieldNumber, Message: `number "T-1" is already used in row 1`},
+			{Row: 3, Field: entities.TreeImportFieldNumber, Message: `failed on the "required" rule`},
+			{Row: 3, Field: entities.TreeImportFieldLatitude, Message: `failed on the "max" rule`},
+			{Row: 3, Field: entities.TreeImportFieldTreeClusterID, Message: "tree cluster 2 does not exist"},
+			{Row: 4, Field: entities.TreeImportFieldSensorID, Message: `sensor "sensor-1" is already used in row 3`},
+		}, job.RowErrors)
+	})
+
+	t.Run("should mark import job invalid when file can not be read", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusValidating)
+		job.Format = entities.TreeImportFormatGeoJSON
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(job, nil).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().GetFile(ctx, int32(1)).Return([]byte(testCSV), nil)
+		expectUpdate(t, repos.importRepo, job)
+
+		// when
+		err := svc.ProcessPending(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusInvalid, job.Status)
+		assert.NotNil(t, job.Error)
+	})
+
+	t.Run("should commit queued import job and publish one event", func(t *testing.T) {
+		// given
+		repos, eventManager, svc := setupTest(t)
+		_, ch, _ := eventManager.Subscribe(entities.EventTypeImportTrees)
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go eventManager.Run(runCtx)
+
+		job := testJob(entities.TreeImportStatusCommitting)
+		job.CreatedBy = "import-user"
+		cluster := &entities.TreeCluster{ID: 1}
+		prevCluster := &entities.TreeCluster{ID: 3}
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, mock.Anything, mock.Anything).Return(job, nil).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().GetFile(ctx, int32(1)).Return([]byte(testCSV), nil)
+		repos.clusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(cluster, nil).Once()
+		changes := []*entities.TreeImportChange{
+			{New: &entities.Tree{ID: 1, Number: "T-1", TreeCluster: cluster}},
+			{Prev: &entities.Tree{ID: 2, Number: "T-2", TreeCluster: prevCluster}, New: &entities.Tree{ID: 2, Number: "T-2", TreeCluster: prevCluster}},
+		}
+		repos.treeRepo.EXPECT().Upsert(mock.Anything, mock.Anything).RunAndReturn(func(upsertCtx context.Context, trees []*entities.Tree) ([]*entities.TreeImportChange, error) {
+			assert.Equal(t, job.CreatedBy, upsertCtx.Value(enums.ContextKeyActor))
+			assert.Len(t, trees, 2)
+			assert.Equal(t, "T-1", trees[0].Number)
+			assert.Equal(t, "city", trees[0].Provider)
+			assert.Equal(t, int32(2010), trees[0].PlantingYear)
+			assert.Equal(t, 54.82, trees[0].Latitude)
+			assert.Equal(t, cluster, trees[0].TreeCluster)
+			assert.Nil(t, trees[1].TreeCluster)
+			return changes, nil
+		})
+		expectUpdate(t, repos.importRepo, job)
+
+		// when
+		err := svc.ProcessPending(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusCommitted, job.Status)
+		assert.Equal(t, int32(1), job.CreatedRows)
+		assert.Equal(t, int32(1), job.UpdatedRows)
+		select {
+		case receivedEvent := <-ch:
+			e := receivedEvent.(entities.EventImportTrees)
+			assert.Equal(t, int32(1), e.JobID)
+			assert.Equal(t, changes, e.Changes)
+			assert.Equal(t, []int32{1, 3}, e.TreeClusterIDs)
+		case <-time.After(1 * time.Second):
+			t.Fatal("event was not received")
+		}
+	})
+
+	t.Run("should mark import job failed when upsert fails", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		job := testJob(entities.TreeImportStatusCommitting)
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, mock.Anything, mock.Anything).Return(job, nil).Once()
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusQueued, entities.TreeImportStatusCommitting, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+		repos.importRepo.EXPECT().GetFile(ctx, int32(1)).Return([]byte(testCSV), nil)
+		repos.clusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.TreeCluster{ID: 1}, nil).Once()
+		repos.treeRepo.EXPECT().Upsert(mock.Anything, mock.Anything).Return(nil, errors.New("internal error"))
+		expectUpdate(t, repos.importRepo, job)
+
+		// when
+		err := svc.ProcessPending(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusFailed, job.Status)
+		assert.Equal(t, "internal error", *job.Error)
+		assert.Zero(t, job.CreatedRows)
+	})
+
+	t.Run("should return error when import job can not be claimed", func(t *testing.T) {
+		// given
+		repos, _, svc := setupTest(t)
+		repos.importRepo.EXPECT().Claim(ctx, entities.TreeImportStatusPending, entities.TreeImportStatusValidating, mock.Anything, mock.Anything).Return(nil, errors.New("internal error"))
+
+		// when
+		err := svc.ProcessPending(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestTreeImportService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		// given
+		_, _, svc := setupTest(t)
+
+		// when
+		ready := svc.Ready()
+
+		// then
+		assert.True(t, ready)
+	})
+
+	t.Run("should return false if the service is not ready", func(t *testing.T) {
+		// given
+		svc := NewTreeImportService(nil, nil, nil, nil, nil)
+
+		// when
+		ready := svc.Ready()
+
+		// then
+		assert.False(t, ready)
+	})
+}
@@ -28,7 +28,7 @@ type WateringPlanService struct {
 	routingRepo      storage.RoutingRepository
 	gpxBucket        storage.S3Repository
 	validator        *validator.Validate
-	eventManager     *worker.EventManager
+	eventManager     worker.EventBus
 }
 
 func NewWateringPlanService(
@@ -36,7 +36,7 @@ func NewWateringPlanService(
 	clusterRepository storage.TreeClusterRepository,
 	vehicleRepository storage.VehicleRepository,
 	userRepository storage.UserRepository,
-	eventManager *worker.EventManager,
+	eventManager worker.EventBus,
 	routingRepo storage.RoutingRepository,
 	gpxRepo storage.S3Repository,
 ) service.WateringPlanService {
@@ -67,6 +67,15 @@ func (w *WateringPlanService) publishUpdateEvent(ctx context.Context, prevWp *en
 	return nil
 }
 
+func (w *WateringPlanService) publishCreateEvent(ctx context.Context, newWp *entities.WateringPlan) {
+	log := logger.GetLogger(ctx)
+	log.Debug("publish new event", "event", entities.EventTypeCreateWateringPlan, "service", "WateringPlanService")
+	event := entities.NewEventCreateWateringPlan(newWp)
+	if err := w.eventManager.Publish(ctx, event); err != nil {
+		log.Error("error while sending event after creating watering plan", "err", err, "watering_plan_id", newWp.ID)
+	}
+}
+
 func (w *WateringPlanService) PreviewRoute(ctx context.Context, transporterID int32, trailerID *int32, clusterIDs []int32) (*entities.GeoJSON, error) {
 	log := logger.GetLogger(ctx)
 	transporter, err := w.vehicleRepo.GetByID(ctx, transporterID)
@@ -158,18 +167,29 @@ func (w *WateringPlanService) Create(ctx context.Context, createWp *entities.Wat
 	}
 
 	neededWater := w.calculateRequiredWater(treeClusters)
-	created, err := w.wateringPlanRepo.Create(ctx, func(wp *entities.WateringPlan, _ storage.WateringPlanRepository) (bool, error) {
-		wp.Date = createWp.Date
-		wp.Description = createWp.Description
-		wp.Transporter = transporter
-		wp.Trailer = trailer
-		wp.TreeClusters = treeClusters
-		wp.UserIDs = createWp.UserIDs
-		wp.TotalWaterRequired = utils.P(float64(neededWater))
-		wp.Provider = createWp.Provider
-		wp.AdditionalInfo = createWp.AdditionalInfo
+	// the watering plan and its event are committed together, the route is added afterwards
+	var created *entities.WateringPlan
+	err = w.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = w.wateringPlanRepo.Create(ctx, func(wp *entities.WateringPlan, _ storage.WateringPlanRepository) (bool, error) {
+			wp.Date = createWp.Date
+			wp.Description = createWp.Description
+			wp.Transporter = transporter
+			wp.Trailer = trailer
+			wp.TreeClusters = treeClusters
+			wp.UserIDs = createWp.UserIDs
+			wp.TotalWaterRequired = utils.P(float64(neededWater))
+			wp.Provider = createWp.Provider
+			wp.AdditionalInfo = createWp.AdditionalInfo
+
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
 
-		return true, nil
+		w.publishCreateEvent(ctx, created)
+		return nil
 	})
 	if err != nil {
 		log.Debug("failed to create watering plan", "error", err)
@@ -286,51 +306,58 @@ func (w *WateringPlanService) Update(ctx context.Context, id int32, updateWp *en
 	}
 
 	neededWater := w.calculateRequiredWater(treeClusters)
-	err = w.wateringPlanRepo.Update(ctx, id, func(wp *entities.WateringPlan, _ storage.WateringPlanRepository) (bool, error) {
-		wp.Date = updateWp.Date
-		wp.Description = updateWp.Description
-		wp.Transporter = transporter
-		wp.Trailer = trailer
-		wp.TreeClusters = treeClusters
-		wp.Status = updateWp.Status
-		wp.CancellationNote = updateWp.CancellationNote
-		wp.Evaluation = updateWp.Evaluation
-		wp.UserIDs = updateWp.UserIDs
-		wp.TotalWaterRequired = &neededWater
-		wp.Provider = updateWp.Provider
-		wp.AdditionalInfo = updateWp.AdditionalInfo
+	// the watering plan and its event are committed together
+	err = w.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		err := w.wateringPlanRepo.Update(ctx, id, func(wp *entities.WateringPlan, _ storage.WateringPlanRepository) (bool, error) {
+			wp.Date = updateWp.Date
+			wp.Description = updateWp.Description
+			wp.Transporter = transporter
+			wp.Trailer = trailer
+			wp.TreeClusters = treeClusters
+			wp.Status = updateWp.Status
+			wp.CancellationNote = updateWp.CancellationNote
+			wp.Evaluation = updateWp.Evaluation
+			wp.UserIDs = updateWp.UserIDs
+			wp.TotalWaterRequired = &neededWater
+			wp.Provider = updateWp.Provider
+			wp.AdditionalInfo = updateWp.AdditionalInfo
+
+			mergedVehicle := w.mergeVehicle(transporter, trailer)
+			if w.shouldUpdateGpx(prevWp, wp) {
+				gpxURL, err := w.getGpxRouteURL(ctx, id, mergedVehicle, treeClusters)
+				if err != nil {
+					log.Warn("generating route in gpx fomat failed. will not save gpx route", "error", err, "watering_plan_id", id)
+				} else {
+					wp.GpxURL = gpxURL
+				}
+			}
 
-		mergedVehicle := w.mergeVehicle(transporter, trailer)
-		if w.shouldUpdateGpx(prevWp, wp) {
-			gpxURL, err := w.getGpxRouteURL(ctx, id, mergedVehicle, treeClusters)
+			metadata, err := w.routingRepo.GenerateRouteInformation(ctx, mergedVehicle, treeClusters)
 			if err != nil {
-				log.Warn("generating route in gpx fomat failed. will not save gpx route", "error", err, "watering_plan_id", id)
+				log.Warn("generating route information failed. will not route metadata", "error", err, "watering_plan_id", id)
 			} else {
-				wp.GpxURL = gpxURL
+				wp.Distance = utils.P(metadata.Distance)
+				wp.Duration = metadata.Time
+				wp.RefillCount = metadata.Refills
 			}
-		}
 
-		metadata, err := w.routingRepo.GenerateRouteInformation(ctx, mergedVehicle, treeClusters)
+			return true, nil
+		})
 		if err != nil {
-			log.Warn("generating route information failed. will not route metadata", "error", err, "watering_plan_id", id)
-		} else {
-			wp.Distance = utils.P(metadata.Distance)
-			wp.Duration = metadata.Time
-			wp.RefillCount = metadata.Refills
+			return err
 		}
 
-		return true, nil
+		if err := w.publishUpdateEvent(ctx, prevWp); err != nil {
+			log.Warn("failed to publish update event", "error", err)
+		}
+		return nil
 	})
-
 	if err != nil {
 		log.Debug("failed to update watering plan", "error", err, "watering_plan_id", id)
 		return nil, service.MapError(ctx, err, service.ErrorLogAll)
 	}
 
 	log.Info("watering plan updated successfully", "watering_plan_id", id)
-	if err := w.publishUpdateEvent(ctx, prevWp); err != nil {
-		log.Warn("failed to publish update event", "error", err)
-	}
 	return w.GetByID(ctx, id)
 }
 
@@ -0,0 +1,85 @@
+package wateringplantemplate
+
+import (
+	"log/slog"
+	"time"
+)
+
+// holidays holds the configured regional holidays in addition to the nationwide public holidays of Germany
+type holidays struct {
+	yearly map[string]struct{}
+	once   map[string]struct{}
+}
+
+// newHolidays parses the configured holidays, yearly ones as MM-DD and single ones as YYYY-MM-DD.
+// Invalid entries are skipped.
+func newHolidays(entries []string) holidays {
+	h := holidays{
+		yearly: make(map[string]struct{}),
+		once:   make(map[string]struct{}),
+	}
+
+	for _, entry := range entries {
+		if _, err := time.Parse("01-02", entry); err == nil {
+			h.yearly[entry] = struct{}{}
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, entry); err == nil {
+			h.once[entry] = struct{}{}
+			continue
+		}
+		slog.Warn("skipping invalid holiday of watering plan templates, expected MM-DD or YYYY-MM-DD", "holiday", entry)
+	}
+
+	return h
+}
+
+// isHoliday reports whether the date is a nationwide public holiday or one of the configured holidays
+func (h holidays) isHoliday(date time.Time) bool {
+	if _, ok := h.yearly[date.Format("01-02")]; ok {
+		return true
+	}
+	if _, ok := h.once[date.Format(time.DateOnly)]; ok {
+		return true
+	}
+	return isPublicHoliday(date)
+}
+
+// isPublicHoliday reports whether the date is one of the nationwide public holidays of Germany
+func isPublicHoliday(date time.Time) bool {
+	switch date.Format("01-02") {
+	case "01-01", "05-01", "10-03", "12-25", "12-26":
+		return true
+	}
+
+	easter := easterSunday(date.Year())
+	for _, offset := range []int{-2, 1, 39, 50} { // good friday, easter monday, ascension day, whit monday
+		if sameDate(date, easter.AddDate(0, 0, offset)) {
+			return true
+		}
+	}
+	return false
+}
+
+// easterSunday computes the date of easter sunday in the gregorian calendar (anonymous gregorian algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
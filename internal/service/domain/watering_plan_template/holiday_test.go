@@ -0,0 +1,55 @@
+package wateringplantemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEasterSunday(t *testing.T) {
+	t.Run("should compute easter sunday of the gregorian calendar", func(t *testing.T) {
+		assert.Equal(t, date(2024, time.March, 31), easterSunday(2024))
+		assert.Equal(t, date(2025, time.April, 20), easterSunday(2025))
+		assert.Equal(t, date(2038, time.April, 25), easterSunday(2038))
+	})
+}
+
+func TestHolidays(t *testing.T) {
+	t.Run("should recognize the nationwide public holidays", func(t *testing.T) {
+		// given
+		h := newHolidays(nil)
+
+		// then
+		for _, d := range []time.Time{
+			date(2025, time.January, 1),
+			date(2025, time.April, 18),
+			date(2025, time.April, 21),
+			date(2025, time.May, 1),
+			date(2025, time.May, 29),
+			date(2025, time.June, 9),
+			date(2025, time.October, 3),
+			date(2025, time.December, 25),
+			date(2025, time.December, 26),
+		} {
+			assert.True(t, h.isHoliday(d), d.Format(time.DateOnly))
+		}
+		assert.False(t, h.isHoliday(date(2025, time.April, 20)))
+		assert.False(t, h.isHoliday(date(2025, time.October, 31)))
+	})
+
+	t.Run("should recognize the configured yearly and single holidays", func(t *testing.T) {
+		// given
+		h := newHolidays([]string{"10-31", "2025-06-24", "invalid"})
+
+		// then
+		assert.True(t, h.isHoliday(date(2025, time.October, 31)))
+		assert.True(t, h.isHoliday(date(2026, time.October, 31)))
+		assert.True(t, h.isHoliday(date(2025, time.June, 24)))
+		assert.False(t, h.isHoliday(date(2026, time.June, 24)))
+	})
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,65 @@
+package wateringplantemplate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/teambition/rrule-go"
+)
+
+// parseRRule parses the recurrence rule of a template starting at its start date. Only rules that
+// repeat at most daily are allowed, a watering plan is planned for a whole day.
+func parseRRule(rule string, startDate time.Time) (*rrule.RRule, error) {
+	opt, err := rrule.StrToROption(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"))
+	if err != nil {
+		return nil, err
+	}
+	if opt.Freq > rrule.DAILY {
+		return nil, errFrequencyTooHigh
+	}
+
+	opt.Dtstart = utils.ToDate(startDate)
+	return rrule.NewRRule(*opt)
+}
+
+// occurrences returns the dates of the template from from to until including both, leaving out the dates
+// outside of the season
+func occurrences(template *entities.WateringPlanTemplate, from, until time.Time) ([]time.Time, error) {
+	rule, err := parseRRule(template.RRule, template.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make([]time.Time, 0)
+	seen := make(map[string]struct{})
+	for _, t := range rule.Between(from, until.AddDate(0, 0, 1), true) {
+		date := utils.ToDate(t)
+		key := date.Format(time.DateOnly)
+		if _, ok := seen[key]; ok || date.After(until) {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if inSeason(date, template.SeasonStart, template.SeasonEnd) {
+			dates = append(dates, date)
+		}
+	}
+
+	return dates, nil
+}
+
+// inSeason reports whether the date lies within the season from start to end as MM-DD including both.
+// A season may span the turn of the year, an empty season lasts the whole year.
+func inSeason(date time.Time, start, end string) bool {
+	if start == "" || end == "" {
+		return true
+	}
+
+	day := date.Format("01-02")
+	if start <= end {
+		return day >= start && day <= end
+	}
+	return day >= start || day <= end
+}
@@ -0,0 +1,96 @@
+package wateringplantemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOccurrences(t *testing.T) {
+	t.Run("should return the dates of the rule between from and until", func(t *testing.T) {
+		// given
+		template := &entities.WateringPlanTemplate{
+			RRule:     "FREQ=WEEKLY;BYDAY=TU,FR",
+			StartDate: date(2025, time.June, 1),
+		}
+
+		// when
+		got, err := occurrences(template, date(2025, time.July, 1), date(2025, time.July, 11))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			date(2025, time.July, 1),
+			date(2025, time.July, 4),
+			date(2025, time.July, 8),
+			date(2025, time.July, 11),
+		}, got)
+	})
+
+	t.Run("should accept the rrule prefix and return nothing before the start date", func(t *testing.T) {
+		// given
+		template := &entities.WateringPlanTemplate{
+			RRule:     "RRULE:FREQ=DAILY;INTERVAL=2",
+			StartDate: date(2025, time.July, 5),
+		}
+
+		// when
+		got, err := occurrences(template, date(2025, time.July, 1), date(2025, time.July, 9))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date(2025, time.July, 5), date(2025, time.July, 7), date(2025, time.July, 9)}, got)
+	})
+
+	t.Run("should leave out the dates outside of the season", func(t *testing.T) {
+		// given
+		template := &entities.WateringPlanTemplate{
+			RRule:       "FREQ=DAILY",
+			StartDate:   date(2025, time.January, 1),
+			SeasonStart: "09-29",
+			SeasonEnd:   "10-01",
+		}
+
+		// when
+		got, err := occurrences(template, date(2025, time.September, 27), date(2025, time.October, 3))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date(2025, time.September, 29), date(2025, time.September, 30), date(2025, time.October, 1)}, got)
+	})
+
+	t.Run("should reject rules that repeat more often than daily", func(t *testing.T) {
+		// given
+		template := &entities.WateringPlanTemplate{
+			RRule:     "FREQ=HOURLY",
+			StartDate: date(2025, time.July, 1),
+		}
+
+		// when
+		got, err := occurrences(template, date(2025, time.July, 1), date(2025, time.July, 2))
+
+		// then
+		assert.ErrorIs(t, err, errFrequencyTooHigh)
+		assert.Nil(t, got)
+	})
+}
+
+func TestInSeason(t *testing.T) {
+	t.Run("should last the whole year without season", func(t *testing.T) {
+		assert.True(t, inSeason(date(2025, time.January, 1), "", ""))
+	})
+
+	t.Run("should include the first and last day of the season", func(t *testing.T) {
+		assert.True(t, inSeason(date(2025, time.April, 1), "04-01", "09-30"))
+		assert.True(t, inSeason(date(2025, time.September, 30), "04-01", "09-30"))
+		assert.False(t, inSeason(date(2025, time.October, 1), "04-01", "09-30"))
+	})
+
+	t.Run("should support seasons over the turn of the year", func(t *testing.T) {
+		assert.True(t, inSeason(date(2025, time.December, 24), "11-01", "02-28"))
+		assert.True(t, inSeason(date(2026, time.January, 15), "11-01", "02-28"))
+		assert.False(t, inSeason(date(2026, time.July, 1), "11-01", "02-28"))
+	})
+}
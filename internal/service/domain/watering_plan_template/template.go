@@ -0,0 +1,304 @@
+package wateringplantemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var errFrequencyTooHigh = errors.New("recurrence rule repeats more often than daily")
+
+var _ service.WateringPlanTemplateService = (*WateringPlanTemplateService)(nil)
+
+type WateringPlanTemplateService struct {
+	templateRepo        storage.WateringPlanTemplateRepository
+	treeClusterRepo     storage.TreeClusterRepository
+	vehicleRepo         storage.VehicleRepository
+	wateringPlanService service.WateringPlanService
+	lookaheadDays       int
+	holidays            holidays
+	validator           *validator.Validate
+	now                 func() time.Time
+}
+
+func NewWateringPlanTemplateService(
+	templateRepo storage.WateringPlanTemplateRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+	vehicleRepo storage.VehicleRepository,
+	wateringPlanService service.WateringPlanService,
+	cfg config.WateringPlanTemplateConfig,
+) *WateringPlanTemplateService {
+	return &WateringPlanTemplateService{
+		templateRepo:        templateRepo,
+		treeClusterRepo:     treeClusterRepo,
+		vehicleRepo:         vehicleRepo,
+		wateringPlanService: wateringPlanService,
+		lookaheadDays:       cfg.LookaheadDays,
+		holidays:            newHolidays(cfg.Holidays),
+		validator:           validator.New(),
+		now:                 time.Now,
+	}
+}
+
+func (s *WateringPlanTemplateService) GetAll(ctx context.Context) ([]*entities.WateringPlanTemplate, error) {
+	log := logger.GetLogger(ctx)
+	templates, err := s.templateRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch watering plan templates", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return templates, nil
+}
+
+func (s *WateringPlanTemplateService) GetByID(ctx context.Context, id int32) (*entities.WateringPlanTemplate, error) {
+	log := logger.GetLogger(ctx)
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch watering plan template by id", "error", err, "template_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return template, nil
+}
+
+func (s *WateringPlanTemplateService) Create(ctx context.Context, createData *entities.WateringPlanTemplateCreate) (*entities.WateringPlanTemplate, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate struct from create watering plan template", "error", err, "raw_template", fmt.Sprintf("%+v", createData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validateReferences(ctx, createData.RRule, createData.StartDate, createData.TreeClusterIDs, createData.TransporterID, createData.TrailerID); err != nil {
+		return nil, err
+	}
+
+	created, err := s.templateRepo.Create(ctx, func(t *entities.WateringPlanTemplate, _ storage.WateringPlanTemplateRepository) (bool, error) {
+		t.Name = createData.Name
+		t.Description = createData.Description
+		t.RRule = createData.RRule
+		t.StartDate = utils.ToDate(createData.StartDate)
+		t.SeasonStart = createData.SeasonStart
+		t.SeasonEnd = createData.SeasonEnd
+		t.TreeClusterIDs = createData.TreeClusterIDs
+		t.TransporterID = createData.TransporterID
+		t.TrailerID = createData.TrailerID
+		t.UserIDs = createData.UserIDs
+		t.Enabled = createData.Enabled
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create watering plan template", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("watering plan template created successfully", "template_id", created.ID)
+	return created, nil
+}
+
+func (s *WateringPlanTemplateService) Update(ctx context.Context, id int32, updateData *entities.WateringPlanTemplateUpdate) (*entities.WateringPlanTemplate, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(updateData); err != nil {
+		log.Debug("failed to validate struct from update watering plan template", "error", err, "raw_template", fmt.Sprintf("%+v", updateData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if err := s.validateReferences(ctx, updateData.RRule, updateData.StartDate, updateData.TreeClusterIDs, updateData.TransporterID, updateData.TrailerID); err != nil {
+		return nil, err
+	}
+
+	err := s.templateRepo.Update(ctx, id, func(t *entities.WateringPlanTemplate, _ storage.WateringPlanTemplateRepository) (bool, error) {
+		t.Name = updateData.Name
+		t.Description = updateData.Description
+		t.RRule = updateData.RRule
+		t.StartDate = utils.ToDate(updateData.StartDate)
+		t.SeasonStart = updateData.SeasonStart
+		t.SeasonEnd = updateData.SeasonEnd
+		t.TreeClusterIDs = updateData.TreeClusterIDs
+		t.TransporterID = updateData.TransporterID
+		t.TrailerID = updateData.TrailerID
+		t.UserIDs = updateData.UserIDs
+		t.Enabled = updateData.Enabled
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update watering plan template", "error", err, "template_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("watering plan template updated successfully", "template_id", id)
+	return s.GetByID(ctx, id)
+}
+
+func (s *WateringPlanTemplateService) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		log.Debug("failed to delete watering plan template", "error", err, "template_id", id)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("watering plan template deleted successfully", "template_id", id)
+	return nil
+}
+
+func (s *WateringPlanTemplateService) GetOccurrences(ctx context.Context, id int32) ([]*entities.WateringPlanTemplateOccurrence, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := s.templateRepo.GetByID(ctx, id); err != nil {
+		log.Debug("failed to fetch watering plan template by id", "error", err, "template_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	occurrences, err := s.templateRepo.GetOccurrences(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch watering plan template occurrences", "error", err, "template_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return occurrences, nil
+}
+
+// Materialize creates the watering plans of the enabled templates from today until the end of the lookahead.
+// Holidays are recorded without a watering plan so they are skipped for good.
+func (s *WateringPlanTemplateService) Materialize(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	templates, err := s.templateRepo.GetAll(ctx)
+	if err != nil {
+		log.Error("failed to fetch watering plan templates", "error", err)
+		return err
+	}
+
+	today := utils.ToDate(s.now().Local())
+	until := today.AddDate(0, 0, s.lookaheadDays)
+
+	var failed, total int
+	for _, template := range templates {
+		if !template.Enabled {
+			continue
+		}
+
+		total++
+		if err := s.materialize(ctx, template, today, until); err != nil {
+			log.Error("failed to create the watering plans of a template", "error", err, "template_id", template.ID)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to create the watering plans of %d of %d templates", failed, total)
+	}
+
+	log.Info("created watering plans of templates", "templates", total, "until", until.Format(time.DateOnly))
+	return nil
+}
+
+func (s *WateringPlanTemplateService) materialize(ctx context.Context, template *entities.WateringPlanTemplate, from, until time.Time) error {
+	log := logger.GetLogger(ctx)
+	if len(template.TreeClusterIDs) == 0 {
+		log.Warn("watering plan template has no tree clusters left, no watering plans are created", "template_id", template.ID)
+		return nil
+	}
+
+	dates, err := occurrences(template, from, until)
+	if err != nil {
+		return err
+	}
+	if len(dates) == 0 {
+		return nil
+	}
+
+	existing, err := s.templateRepo.GetOccurrences(ctx, template.ID)
+	if err != nil {
+		return err
+	}
+	done := make(map[string]struct{}, len(existing))
+	for _, occ := range existing {
+		done[occ.Date.Format(time.DateOnly)] = struct{}{}
+	}
+
+	for _, date := range dates {
+		if _, ok := done[date.Format(time.DateOnly)]; ok {
+			continue
+		}
+
+		occurrence := &entities.WateringPlanTemplateOccurrence{
+			TemplateID: template.ID,
+			Date:       date,
+		}
+
+		// the watering plan is created in the transaction of the occurrence, so neither is stored without the other
+		var createFn func(context.Context, *entities.WateringPlanTemplateOccurrence) error
+		if s.holidays.isHoliday(date) {
+			log.Debug("skipping watering plan of template on holiday", "template_id", template.ID, "date", date.Format(time.DateOnly))
+			occurrence.Holiday = true
+		} else {
+			createFn = func(ctx context.Context, occurrence *entities.WateringPlanTemplateOccurrence) error {
+				wp, err := s.wateringPlanService.Create(ctx, &entities.WateringPlanCreate{
+					Date:           date,
+					Description:    template.Name,
+					TreeClusterIDs: utils.Map(template.TreeClusterIDs, func(id int32) *int32 { return &id }),
+					TransporterID:  template.TransporterID,
+					TrailerID:      template.TrailerID,
+					UserIDs:        utils.Map(template.UserIDs, func(id uuid.UUID) *uuid.UUID { return &id }),
+				})
+				if err != nil {
+					return err
+				}
+				occurrence.WateringPlanID = &wp.ID
+				return nil
+			}
+		}
+
+		if err := s.templateRepo.CreateOccurrence(ctx, occurrence, createFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *WateringPlanTemplateService) validateReferences(ctx context.Context, rule string, startDate time.Time, treeClusterIDs []int32, transporterID, trailerID *int32) error {
+	log := logger.GetLogger(ctx)
+	if _, err := parseRRule(rule, startDate); err != nil {
+		log.Debug("watering plan template has an invalid recurrence rule", "error", err, "rrule", rule)
+		return service.ErrTemplateRRuleInvalid
+	}
+
+	clusters, err := s.treeClusterRepo.GetByIDs(ctx, treeClusterIDs)
+	if err != nil {
+		log.Debug("failed to fetch tree clusters of watering plan template", "error", err, "cluster_ids", treeClusterIDs)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+	for _, id := range treeClusterIDs {
+		if !slices.ContainsFunc(clusters, func(c *entities.TreeCluster) bool { return c.ID == id }) {
+			log.Debug("tree cluster of watering plan template not found", "cluster_id", id)
+			return service.MapError(ctx, storage.ErrEntityNotFound("treecluster"), service.ErrorLogEntityNotFound)
+		}
+	}
+
+	for _, vehicleID := range []*int32{transporterID, trailerID} {
+		if vehicleID == nil {
+			continue
+		}
+		if _, err := s.vehicleRepo.GetByID(ctx, *vehicleID); err != nil {
+			log.Debug("failed to get vehicle of watering plan template", "error", err, "vehicle_id", *vehicleID)
+			return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+		}
+	}
+
+	return nil
+}
+
+func (s *WateringPlanTemplateService) Ready() bool {
+	return s.templateRepo != nil && s.wateringPlanService != nil
+}
@@ -0,0 +1,278 @@
+package wateringplantemplate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testUserID = uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+
+type testMocks struct {
+	templateRepo        *storageMock.MockWateringPlanTemplateRepository
+	treeClusterRepo     *storageMock.MockTreeClusterRepository
+	vehicleRepo         *storageMock.MockVehicleRepository
+	wateringPlanService *serviceMock.MockWateringPlanService
+}
+
+func newTestService(t *testing.T, holidays ...string) (*WateringPlanTemplateService, testMocks) {
+	m := testMocks{
+		templateRepo:        storageMock.NewMockWateringPlanTemplateRepository(t),
+		treeClusterRepo:     storageMock.NewMockTreeClusterRepository(t),
+		vehicleRepo:         storageMock.NewMockVehicleRepository(t),
+		wateringPlanService: serviceMock.NewMockWateringPlanService(t),
+	}
+	svc := NewWateringPlanTemplateService(m.templateRepo, m.treeClusterRepo, m.vehicleRepo, m.wateringPlanService, config.WateringPlanTemplateConfig{
+		LookaheadDays: 3,
+		Holidays:      holidays,
+	})
+	svc.now = func() time.Time { return time.Date(2025, time.July, 1, 12, 0, 0, 0, time.Local) }
+	return svc, m
+}
+
+func testTemplate() *entities.WateringPlanTemplate {
+	return &entities.WateringPlanTemplate{
+		ID:             1,
+		Name:           "Tuesday and friday round",
+		RRule:          "FREQ=WEEKLY;BYDAY=TU,FR",
+		StartDate:      date(2025, time.June, 1),
+		TreeClusterIDs: []int32{1, 2},
+		TransporterID:  utils.P(int32(1)),
+		UserIDs:        []uuid.UUID{testUserID},
+		Enabled:        true,
+	}
+}
+
+func testCreate() *entities.WateringPlanTemplateCreate {
+	return &entities.WateringPlanTemplateCreate{
+		Name:           "Tuesday and friday round",
+		RRule:          "FREQ=WEEKLY;BYDAY=TU,FR",
+		StartDate:      date(2025, time.June, 1),
+		SeasonStart:    "04-01",
+		SeasonEnd:      "09-30",
+		TreeClusterIDs: []int32{1, 2},
+		TransporterID:  utils.P(int32(1)),
+		UserIDs:        []uuid.UUID{testUserID},
+		Enabled:        true,
+	}
+}
+
+func TestWateringPlanTemplateService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create template", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		createData := testCreate()
+		m.treeClusterRepo.EXPECT().GetByIDs(ctx, []int32{1, 2}).Return([]*entities.TreeCluster{{ID: 1}, {ID: 2}}, nil)
+		m.vehicleRepo.EXPECT().GetByID(ctx, int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		m.templateRepo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*entities.WateringPlanTemplate, storage.WateringPlanTemplateRepository) (bool, error)) (*entities.WateringPlanTemplate, error) {
+			template := &entities.WateringPlanTemplate{ID: 1}
+			ok, err := fn(template, m.templateRepo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			return template, nil
+		})
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, createData.Name, got.Name)
+		assert.Equal(t, createData.RRule, got.RRule)
+		assert.Equal(t, createData.SeasonStart, got.SeasonStart)
+		assert.Equal(t, createData.SeasonEnd, got.SeasonEnd)
+		assert.Equal(t, createData.TreeClusterIDs, got.TreeClusterIDs)
+		assert.Equal(t, createData.UserIDs, got.UserIDs)
+	})
+
+	t.Run("should return validation error for invalid season", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		createData := testCreate()
+		createData.SeasonEnd = "13-01"
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.Nil(t, got)
+		var svcErr service.Error
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, service.BadRequest, svcErr.Code)
+		}
+	})
+
+	t.Run("should return error for invalid recurrence rule", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		createData := testCreate()
+		createData.RRule = "FREQ=MINUTELY"
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrTemplateRRuleInvalid)
+	})
+
+	t.Run("should return not found error when a tree cluster does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.treeClusterRepo.EXPECT().GetByIDs(ctx, []int32{1, 2}).Return([]*entities.TreeCluster{{ID: 1}}, nil)
+
+		// when
+		got, err := svc.Create(ctx, testCreate())
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+}
+
+func TestWateringPlanTemplateService_GetOccurrences(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return not found error when template does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.templateRepo.EXPECT().GetByID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetOccurrences(ctx, 1)
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+}
+
+func TestWateringPlanTemplateService_Materialize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create the watering plans of dates that were not created before", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		disabled := testTemplate()
+		disabled.ID = 2
+		disabled.Enabled = false
+		m.templateRepo.EXPECT().GetAll(ctx).Return([]*entities.WateringPlanTemplate{testTemplate(), disabled}, nil)
+		m.templateRepo.EXPECT().GetOccurrences(ctx, int32(1)).Return([]*entities.WateringPlanTemplateOccurrence{
+			{TemplateID: 1, Date: date(2025, time.July, 1)},
+		}, nil)
+		m.wateringPlanService.EXPECT().Create(ctx, mock.MatchedBy(func(wp *entities.WateringPlanCreate) bool {
+			return wp.Date.Equal(date(2025, time.July, 4)) &&
+				wp.Description == "Tuesday and friday round" &&
+				*wp.TreeClusterIDs[0] == 1 && *wp.TreeClusterIDs[1] == 2 &&
+				*wp.TransporterID == 1 &&
+				*wp.UserIDs[0] == testUserID
+		})).Return(&entities.WateringPlan{ID: 7}, nil)
+		var stored *entities.WateringPlanTemplateOccurrence
+		m.templateRepo.EXPECT().CreateOccurrence(ctx, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, occurrence *entities.WateringPlanTemplateOccurrence, createFn func(context.Context, *entities.WateringPlanTemplateOccurrence) error) error {
+			if err := createFn(ctx, occurrence); err != nil {
+				return err
+			}
+			stored = occurrence
+			return nil
+		})
+
+		// when
+		err := svc.Materialize(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &entities.WateringPlanTemplateOccurrence{
+			TemplateID:     1,
+			Date:           date(2025, time.July, 4),
+			WateringPlanID: utils.P(int32(7)),
+		}, stored)
+	})
+
+	t.Run("should skip holidays without creating a watering plan", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t, "07-01", "2025-07-04")
+		m.templateRepo.EXPECT().GetAll(ctx).Return([]*entities.WateringPlanTemplate{testTemplate()}, nil)
+		m.templateRepo.EXPECT().GetOccurrences(ctx, int32(1)).Return([]*entities.WateringPlanTemplateOccurrence{}, nil)
+		m.templateRepo.EXPECT().CreateOccurrence(ctx, &entities.WateringPlanTemplateOccurrence{TemplateID: 1, Date: date(2025, time.July, 1), Holiday: true}, mock.AnythingOfType("func(context.Context, *entities.WateringPlanTemplateOccurrence) error")).Return(nil)
+		m.templateRepo.EXPECT().CreateOccurrence(ctx, &entities.WateringPlanTemplateOccurrence{TemplateID: 1, Date: date(2025, time.July, 4), Holiday: true}, mock.AnythingOfType("func(context.Context, *entities.WateringPlanTemplateOccurrence) error")).Return(nil)
+
+		// when
+		err := svc.Materialize(ctx)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should continue with the other templates when a template fails", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		invalid := testTemplate()
+		invalid.ID = 2
+		invalid.RRule = "FREQ=HOURLY"
+		m.templateRepo.EXPECT().GetAll(ctx).Return([]*entities.WateringPlanTemplate{invalid, testTemplate()}, nil)
+		m.templateRepo.EXPECT().GetOccurrences(ctx, int32(1)).Return([]*entities.WateringPlanTemplateOccurrence{
+			{TemplateID: 1, Date: date(2025, time.July, 1)},
+			{TemplateID: 1, Date: date(2025, time.July, 4)},
+		}, nil)
+
+		// when
+		err := svc.Materialize(ctx)
+
+		// then
+		assert.EqualError(t, err, "failed to create the watering plans of 1 of 2 templates")
+	})
+
+	t.Run("should not record the occurrence when the watering plan could not be created", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		m.templateRepo.EXPECT().GetAll(ctx).Return([]*entities.WateringPlanTemplate{testTemplate()}, nil)
+		m.templateRepo.EXPECT().GetOccurrences(ctx, int32(1)).Return([]*entities.WateringPlanTemplateOccurrence{}, nil)
+		m.wateringPlanService.EXPECT().Create(ctx, mock.Anything).Return(nil, errors.New("vehicle not found"))
+		m.templateRepo.EXPECT().CreateOccurrence(ctx, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, occurrence *entities.WateringPlanTemplateOccurrence, createFn func(context.Context, *entities.WateringPlanTemplateOccurrence) error) error {
+			return createFn(ctx, occurrence)
+		}).Once()
+
+		// when
+		err := svc.Materialize(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should skip template without tree clusters", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		template := testTemplate()
+		template.TreeClusterIDs = []int32{}
+		m.templateRepo.EXPECT().GetAll(ctx).Return([]*entities.WateringPlanTemplate{template}, nil)
+
+		// when
+		err := svc.Materialize(ctx)
+
+		// then
+		assert.NoError(t, err)
+		m.templateRepo.AssertNotCalled(t, "GetOccurrences", mock.Anything, mock.Anything)
+	})
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+	var svcErr service.Error
+	if assert.ErrorAs(t, err, &svcErr) {
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	}
+}
@@ -0,0 +1,101 @@
+package weather
+
+import (
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	// moderateDepletion is the share of the available water at which a tree cluster turns moderate
+	moderateDepletion = 0.3
+	// badDepletion is the share of the available water at which the water stress of trees begins,
+	// the depletion fraction of FAO-56 for trees
+	badDepletion = 0.5
+)
+
+// totalAvailableWater is the water in millimeters the root zone holds between field capacity and
+// wilting point by the soil condition of the tree cluster
+var totalAvailableWater = map[domain.TreeSoilCondition]float64{
+	domain.TreeSoilConditionSandig:    70,
+	domain.TreeSoilConditionSchluffig: 180,
+	domain.TreeSoilConditionLehmig:    160,
+	domain.TreeSoilConditionTonig:     130,
+}
+
+// defaultTotalAvailableWater is used for tree clusters with an unknown soil condition
+const defaultTotalAvailableWater = 120
+
+// computeWaterBalance computes the daily soil water deficit of the tree cluster with the bucket model
+// of FAO-56. The deficit grows by the evapotranspiration of the trees, shrinks by the precipitation and
+// stays between 0 and the available water of the soil. The soil is assumed at field capacity before the
+// first day and after the day the tree cluster was last watered.
+func computeWaterBalance(cluster *domain.TreeCluster, days []*domain.WeatherDay, cropCoefficient float64) []*domain.WaterBalance {
+	taw := availableWater(cluster.SoilCondition)
+
+	var wateredAt time.Time
+	if cluster.LastWatered != nil {
+		wateredAt = utils.ToDate(cluster.LastWatered.Local())
+	}
+
+	balances := make([]*domain.WaterBalance, 0, len(days))
+	deficit := 0.0
+	for _, day := range days {
+		deficit += cropCoefficient*day.ET0 - day.Precipitation
+		if day.Date.Equal(wateredAt) {
+			deficit = 0
+		}
+		deficit = min(max(deficit, 0), taw)
+
+		balances = append(balances, &domain.WaterBalance{
+			TreeClusterID:  cluster.ID,
+			Date:           day.Date,
+			Deficit:        deficit,
+			WateringStatus: wateringStatus(deficit, taw),
+			Forecast:       day.Forecast,
+		})
+	}
+	return balances
+}
+
+// summarize returns the estimate of today and the first forecast day on which the tree cluster is bad.
+// The status is unknown if there is no balance until today.
+func summarize(treeClusterID int32, balances []*domain.WaterBalance, now time.Time) *domain.TreeClusterWaterBalance {
+	today := utils.ToDate(now.Local())
+	result := &domain.TreeClusterWaterBalance{
+		TreeClusterID:  treeClusterID,
+		WateringStatus: domain.WateringStatusUnknown,
+		Days:           balances,
+	}
+
+	for _, balance := range balances {
+		if !balance.Date.After(today) {
+			result.WateringStatus = balance.WateringStatus
+			result.Deficit = balance.Deficit
+		}
+		if result.BadAt == nil && balance.Forecast && !balance.Date.Before(today) && balance.WateringStatus == domain.WateringStatusBad {
+			result.BadAt = utils.P(balance.Date)
+		}
+	}
+	return result
+}
+
+func availableWater(soil domain.TreeSoilCondition) float64 {
+	if taw, ok := totalAvailableWater[soil]; ok {
+		return taw
+	}
+	return defaultTotalAvailableWater
+}
+
+func wateringStatus(deficit, taw float64) domain.WateringStatus {
+	depletion := deficit / taw
+	switch {
+	case depletion >= badDepletion:
+		return domain.WateringStatusBad
+	case depletion >= moderateDepletion:
+		return domain.WateringStatusModerate
+	default:
+		return domain.WateringStatusGood
+	}
+}
@@ -0,0 +1,168 @@
+package weather
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(day int) time.Time {
+	return time.Date(2025, 7, day, 0, 0, 0, 0, time.UTC)
+}
+
+// dryDays returns days without precipitation, the days from the forecast day on are forecast
+func dryDays(n, forecastFrom int, et0 float64) []*domain.WeatherDay {
+	days := make([]*domain.WeatherDay, 0, n)
+	for i := 1; i <= n; i++ {
+		days = append(days, &domain.WeatherDay{RegionID: 1, Date: date(i), ET0: et0, Forecast: i >= forecastFrom})
+	}
+	return days
+}
+
+func TestComputeWaterBalance(t *testing.T) {
+	t.Run("should accumulate deficit by evapotranspiration and precipitation", func(t *testing.T) {
+		// given
+		cluster := &domain.TreeCluster{ID: 1, SoilCondition: domain.TreeSoilConditionSandig}
+		days := []*domain.WeatherDay{
+			{Date: date(1), ET0: 5},
+			{Date: date(2), ET0: 5},
+			{Date: date(3), ET0: 5, Precipitation: 2},
+		}
+
+		// when
+		got := computeWaterBalance(cluster, days, 0.7)
+
+		// then
+		assert.Len(t, got, 3)
+		assert.InDelta(t, 3.5, got[0].Deficit, 0.001)
+		assert.InDelta(t, 7.0, got[1].Deficit, 0.001)
+		assert.InDelta(t, 8.5, got[2].Deficit, 0.001)
+		for i, balance := range got {
+			assert.Equal(t, int32(1), balance.TreeClusterID)
+			assert.Equal(t, days[i].Date, balance.Date)
+			assert.Equal(t, domain.WateringStatusGood, balance.WateringStatus)
+		}
+	})
+
+	t.Run("should keep deficit between field capacity and available water", func(t *testing.T) {
+		// given
+		cluster := &domain.TreeCluster{ID: 1, SoilCondition: domain.TreeSoilConditionSandig}
+		days := []*domain.WeatherDay{
+			{Date: date(1), ET0: 1, Precipitation: 30},
+			{Date: date(2), ET0: 200},
+		}
+
+		// when
+		got := computeWaterBalance(cluster, days, 1)
+
+		// then
+		assert.Equal(t, 0.0, got[0].Deficit)
+		assert.Equal(t, 70.0, got[1].Deficit)
+		assert.Equal(t, domain.WateringStatusBad, got[1].WateringStatus)
+	})
+
+	t.Run("should reset deficit on the day of the last watering", func(t *testing.T) {
+		// given
+		cluster := &domain.TreeCluster{
+			ID:            1,
+			SoilCondition: domain.TreeSoilConditionSandig,
+			LastWatered:   utils.P(time.Date(2025, 7, 2, 10, 0, 0, 0, time.Local)),
+		}
+
+		// when
+		got := computeWaterBalance(cluster, dryDays(3, 4, 10), 1)
+
+		// then
+		assert.Equal(t, 10.0, got[0].Deficit)
+		assert.Equal(t, 0.0, got[1].Deficit)
+		assert.Equal(t, 10.0, got[2].Deficit)
+	})
+
+	t.Run("should turn bad earlier on soil with less available water", func(t *testing.T) {
+		// given
+		sandy := &domain.TreeCluster{ID: 1, SoilCondition: domain.TreeSoilConditionSandig}
+		silty := &domain.TreeCluster{ID: 2, SoilCondition: domain.TreeSoilConditionSchluffig}
+		days := dryDays(10, 11, 5)
+
+		// when
+		gotSandy := computeWaterBalance(sandy, days, 1)
+		gotSilty := computeWaterBalance(silty, days, 1)
+
+		// then
+		assert.Equal(t, domain.WateringStatusBad, gotSandy[6].WateringStatus)
+		assert.Equal(t, domain.WateringStatusGood, gotSilty[6].WateringStatus)
+	})
+}
+
+func TestWateringStatus(t *testing.T) {
+	tests := []struct {
+		deficit  float64
+		expected domain.WateringStatus
+	}{
+		{deficit: 0, expected: domain.WateringStatusGood},
+		{deficit: 29, expected: domain.WateringStatusGood},
+		{deficit: 30, expected: domain.WateringStatusModerate},
+		{deficit: 49, expected: domain.WateringStatusModerate},
+		{deficit: 50, expected: domain.WateringStatusBad},
+		{deficit: 100, expected: domain.WateringStatusBad},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.expected), func(t *testing.T) {
+			assert.Equal(t, tt.expected, wateringStatus(tt.deficit, 100))
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("should return the estimate of today and the first bad forecast day", func(t *testing.T) {
+		// given
+		balances := []*domain.WaterBalance{
+			{Date: date(1), Deficit: 10, WateringStatus: domain.WateringStatusGood},
+			{Date: date(2), Deficit: 20, WateringStatus: domain.WateringStatusModerate, Forecast: true},
+			{Date: date(3), Deficit: 30, WateringStatus: domain.WateringStatusModerate, Forecast: true},
+			{Date: date(4), Deficit: 40, WateringStatus: domain.WateringStatusBad, Forecast: true},
+			{Date: date(5), Deficit: 50, WateringStatus: domain.WateringStatusBad, Forecast: true},
+		}
+		now := time.Date(2025, 7, 2, 12, 0, 0, 0, time.Local)
+
+		// when
+		got := summarize(1, balances, now)
+
+		// then
+		assert.Equal(t, int32(1), got.TreeClusterID)
+		assert.Equal(t, domain.WateringStatusModerate, got.WateringStatus)
+		assert.Equal(t, 20.0, got.Deficit)
+		assert.Equal(t, date(4), *got.BadAt)
+		assert.Len(t, got.Days, 5)
+	})
+
+	t.Run("should not forecast bad day if the tree cluster stays above the threshold", func(t *testing.T) {
+		// given
+		balances := []*domain.WaterBalance{
+			{Date: date(1), Deficit: 10, WateringStatus: domain.WateringStatusGood},
+			{Date: date(2), Deficit: 15, WateringStatus: domain.WateringStatusGood, Forecast: true},
+		}
+		now := time.Date(2025, 7, 2, 12, 0, 0, 0, time.Local)
+
+		// when
+		got := summarize(1, balances, now)
+
+		// then
+		assert.Equal(t, domain.WateringStatusGood, got.WateringStatus)
+		assert.Nil(t, got.BadAt)
+	})
+
+	t.Run("should return unknown status without balance", func(t *testing.T) {
+		// when
+		got := summarize(1, nil, time.Now())
+
+		// then
+		assert.Equal(t, domain.WateringStatusUnknown, got.WateringStatus)
+		assert.Nil(t, got.BadAt)
+		assert.Empty(t, got.Days)
+	})
+}
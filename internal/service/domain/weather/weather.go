@@ -0,0 +1,211 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+)
+
+var _ service.WeatherService = (*WeatherService)(nil)
+
+type WeatherService struct {
+	weatherRepo     storage.WeatherRepository
+	weatherProvider storage.WeatherProvider
+	treeClusterRepo storage.TreeClusterRepository
+	regionRepo      storage.RegionRepository
+	eventManager    worker.EventBus
+	cfg             config.WeatherConfig
+	now             func() time.Time
+}
+
+func NewWeatherService(
+	weatherRepo storage.WeatherRepository,
+	weatherProvider storage.WeatherProvider,
+	treeClusterRepo storage.TreeClusterRepository,
+	regionRepo storage.RegionRepository,
+	eventManager worker.EventBus,
+	cfg config.WeatherConfig,
+) *WeatherService {
+	return &WeatherService{
+		weatherRepo:     weatherRepo,
+		weatherProvider: weatherProvider,
+		treeClusterRepo: treeClusterRepo,
+		regionRepo:      regionRepo,
+		eventManager:    eventManager,
+		cfg:             cfg,
+		now:             time.Now,
+	}
+}
+
+func (s *WeatherService) GetByRegion(ctx context.Context, regionID int32) ([]*domain.WeatherDay, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := s.regionRepo.GetByID(ctx, regionID); err != nil {
+		log.Debug("failed to fetch region by id", "error", err, "region_id", regionID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	from, to := s.period()
+	days, err := s.weatherRepo.GetDays(ctx, regionID, from, to)
+	if err != nil {
+		log.Debug("failed to fetch weather days of region", "error", err, "region_id", regionID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+	return days, nil
+}
+
+func (s *WeatherService) GetWaterBalance(ctx context.Context, treeClusterID int32) (*domain.TreeClusterWaterBalance, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := s.treeClusterRepo.GetByID(ctx, treeClusterID); err != nil {
+		log.Debug("failed to fetch tree cluster by id", "error", err, "cluster_id", treeClusterID)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	balances, err := s.weatherRepo.GetWaterBalances(ctx, treeClusterID)
+	if err != nil {
+		log.Debug("failed to fetch water balance of tree cluster", "error", err, "cluster_id", treeClusterID)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+	return summarize(treeClusterID, balances, s.now()), nil
+}
+
+// Update fetches the weather of the regions and recomputes the water balance of the tree clusters. The water balance
+// is computed from the stored weather if the weather provider is disabled or fails. It returns an error if the
+// weather of a region could not be fetched, the other regions are updated anyway.
+func (s *WeatherService) Update(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	fetchErr := s.fetch(ctx)
+
+	treeClusters, _, err := s.treeClusterRepo.GetAll(ctx, domain.TreeClusterQuery{})
+	if err != nil {
+		log.Error("failed to fetch tree clusters", "error", err)
+		return err
+	}
+
+	from, to := s.period()
+	daysByRegion := make(map[int32][]*domain.WeatherDay)
+	for _, cluster := range treeClusters {
+		if cluster.Region == nil || cluster.Archived {
+			continue
+		}
+
+		days, ok := daysByRegion[cluster.Region.ID]
+		if !ok {
+			days, err = s.weatherRepo.GetDays(ctx, cluster.Region.ID, from, to)
+			if err != nil {
+				log.Error("failed to fetch weather days of region", "error", err, "region_id", cluster.Region.ID)
+				return err
+			}
+			daysByRegion[cluster.Region.ID] = days
+		}
+
+		balances := computeWaterBalance(cluster, days, s.cfg.CropCoefficient)
+		if err := s.weatherRepo.ReplaceWaterBalances(ctx, cluster.ID, balances); err != nil {
+			log.Error("failed to store water balance of tree cluster", "error", err, "cluster_id", cluster.ID)
+			return err
+		}
+
+		if err := s.estimateWateringStatus(ctx, cluster, summarize(cluster.ID, balances, s.now())); err != nil {
+			return err
+		}
+	}
+
+	log.Info("weather and water balance of tree clusters updated", "tree_clusters", len(treeClusters))
+	return fetchErr
+}
+
+// fetch stores the weather of every region from the weather provider. A disabled weather provider is not an error.
+func (s *WeatherService) fetch(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	locations, err := s.weatherRepo.GetLocations(ctx)
+	if err != nil {
+		log.Error("failed to fetch weather locations", "error", err)
+		return err
+	}
+
+	var failed int
+	for _, location := range locations {
+		days, err := s.weatherProvider.GetDailyWeather(ctx, location.Latitude, location.Longitude, s.cfg.PastDays, s.cfg.ForecastDays)
+		if err != nil {
+			if errors.Is(err, storage.ErrWeatherServiceDisabled) {
+				log.Debug("weather provider is disabled, water balance is computed from the stored weather")
+				return nil
+			}
+			log.Error("failed to fetch weather of region", "error", err, "region_id", location.RegionID)
+			failed++
+			continue
+		}
+
+		if err := s.weatherRepo.UpsertDays(ctx, location.RegionID, days); err != nil {
+			log.Error("failed to store weather of region", "error", err, "region_id", location.RegionID)
+			failed++
+			continue
+		}
+		log.Debug("weather of region updated", "region_id", location.RegionID, "days", len(days))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to update the weather of %d of %d regions", failed, len(locations))
+	}
+	return nil
+}
+
+// estimateWateringStatus sets the watering status of a tree cluster without sensor data to the estimate
+// of the water balance and publishes the update of the tree cluster. The status of tree clusters with sensor
+// data is measured instead.
+func (s *WeatherService) estimateWateringStatus(ctx context.Context, cluster *domain.TreeCluster, balance *domain.TreeClusterWaterBalance) error {
+	log := logger.GetLogger(ctx)
+	if len(cluster.Trees) == 0 || balance.WateringStatus == domain.WateringStatusUnknown || cluster.WateringStatus == balance.WateringStatus {
+		return nil
+	}
+
+	sensorData, err := s.treeClusterRepo.GetAllLatestSensorDataByClusterID(ctx, cluster.ID)
+	if err != nil {
+		log.Error("failed to get latest sensor data", "error", err, "cluster_id", cluster.ID)
+		return err
+	}
+	if len(sensorData) > 0 {
+		return nil
+	}
+
+	// the watering status and its event are committed together
+	return s.eventManager.WithTx(ctx, func(ctx context.Context) error {
+		err := s.treeClusterRepo.Update(ctx, cluster.ID, func(tc *domain.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.WateringStatus = balance.WateringStatus
+			return true, nil
+		})
+		if err != nil {
+			log.Error("failed to update watering status of tree cluster", "error", err, "cluster_id", cluster.ID)
+			return err
+		}
+		log.Debug("watering status of tree cluster estimated from the water balance", "cluster_id", cluster.ID, "watering_status", balance.WateringStatus)
+
+		updated, err := s.treeClusterRepo.GetByID(ctx, cluster.ID)
+		if err != nil {
+			log.Error("failed to fetch updated tree cluster", "error", err, "cluster_id", cluster.ID)
+			return err
+		}
+		if err := s.eventManager.Publish(ctx, domain.NewEventUpdateTreeCluster(cluster, updated)); err != nil {
+			log.Error("error while sending event after estimating watering status of tree cluster", "error", err, "cluster_id", cluster.ID)
+		}
+		return nil
+	})
+}
+
+// period returns the first and the last day of the weather used for the water balance
+func (s *WeatherService) period() (from, to time.Time) {
+	today := utils.ToDate(s.now().Local())
+	return today.AddDate(0, 0, -s.cfg.PastDays), today.AddDate(0, 0, s.cfg.ForecastDays)
+}
+
+func (s *WeatherService) Ready() bool {
+	return s.weatherRepo != nil && s.weatherProvider != nil
+}
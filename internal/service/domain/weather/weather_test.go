@@ -0,0 +1,252 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = config.WeatherConfig{
+	PastDays:        30,
+	ForecastDays:    7,
+	CropCoefficient: 1,
+}
+
+type testMocks struct {
+	weatherRepo     *storageMock.MockWeatherRepository
+	weatherProvider *storageMock.MockWeatherProvider
+	treeClusterRepo *storageMock.MockTreeClusterRepository
+	regionRepo      *storageMock.MockRegionRepository
+	eventManager    *worker.EventManager
+}
+
+func newTestService(t *testing.T) (*WeatherService, *testMocks) {
+	t.Helper()
+	m := &testMocks{
+		weatherRepo:     storageMock.NewMockWeatherRepository(t),
+		weatherProvider: storageMock.NewMockWeatherProvider(t),
+		treeClusterRepo: storageMock.NewMockTreeClusterRepository(t),
+		regionRepo:      storageMock.NewMockRegionRepository(t),
+		eventManager:    worker.NewEventManager(domain.EventTypeUpdateTreeCluster),
+	}
+	go m.eventManager.Run(t.Context())
+	svc := NewWeatherService(m.weatherRepo, m.weatherProvider, m.treeClusterRepo, m.regionRepo, m.eventManager, testConfig)
+	svc.now = func() time.Time { return time.Date(2025, 7, 2, 12, 0, 0, 0, time.Local) }
+	return svc, m
+}
+
+var testLocations = []*domain.WeatherLocation{
+	{RegionID: 1, Latitude: 54.78, Longitude: 9.44},
+	{RegionID: 2, Latitude: 54.80, Longitude: 9.42},
+}
+
+var testTreeClusters = []*domain.TreeCluster{
+	{
+		ID:             1,
+		Region:         &domain.Region{ID: 1},
+		SoilCondition:  domain.TreeSoilConditionSandig,
+		WateringStatus: domain.WateringStatusUnknown,
+		Trees:          []*domain.Tree{{ID: 1}},
+	},
+	{
+		ID:             2,
+		Region:         &domain.Region{ID: 1},
+		SoilCondition:  domain.TreeSoilConditionSandig,
+		WateringStatus: domain.WateringStatusGood,
+		Trees:          []*domain.Tree{{ID: 2}},
+	},
+	{ID: 3, Region: nil, Trees: []*domain.Tree{{ID: 3}}},
+}
+
+// testDays has a deficit of 30 mm today on sandy soil, the tree clusters turn bad on the 3rd
+var testDays = []*domain.WeatherDay{
+	{RegionID: 1, Date: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), ET0: 15},
+	{RegionID: 1, Date: time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC), ET0: 15, Forecast: true},
+	{RegionID: 1, Date: time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC), ET0: 15, Forecast: true},
+}
+
+func TestWeatherService_Update(t *testing.T) {
+	t.Run("should fetch weather and estimate watering status of tree clusters without sensor data", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+
+		m.weatherRepo.EXPECT().GetLocations(ctx).Return(testLocations, nil)
+		for _, location := range testLocations {
+			m.weatherProvider.EXPECT().GetDailyWeather(ctx, location.Latitude, location.Longitude, 30, 7).Return(testDays, nil)
+			m.weatherRepo.EXPECT().UpsertDays(ctx, location.RegionID, testDays).Return(nil)
+		}
+		m.treeClusterRepo.EXPECT().GetAll(ctx, domain.TreeClusterQuery{}).Return(testTreeClusters, int64(3), nil)
+		m.weatherRepo.EXPECT().GetDays(ctx, int32(1), time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 9, 0, 0, 0, 0, time.UTC)).Return(testDays, nil).Once()
+		m.weatherRepo.EXPECT().ReplaceWaterBalances(ctx, int32(1), mock.Anything).Return(nil)
+		m.weatherRepo.EXPECT().ReplaceWaterBalances(ctx, int32(2), mock.Anything).Return(nil)
+		m.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(1)).Return([]*domain.SensorData{}, nil)
+		m.treeClusterRepo.EXPECT().GetAllLatestSensorDataByClusterID(ctx, int32(2)).Return([]*domain.SensorData{{SensorID: "sensor-1"}}, nil)
+		m.treeClusterRepo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(
+			func(_ context.Context, _ int32, fn func(*domain.TreeCluster, storage.TreeClusterRepository) (bool, error)) error {
+				tc := &domain.TreeCluster{ID: 1}
+				_, err := fn(tc, nil)
+				assert.Equal(t, domain.WateringStatusModerate, tc.WateringStatus)
+				return err
+			})
+		updated := &domain.TreeCluster{ID: 1, WateringStatus: domain.WateringStatusModerate}
+		m.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(updated, nil)
+		_, ch, _ := m.eventManager.Subscribe(domain.EventTypeUpdateTreeCluster)
+
+		// when
+		err := svc.Update(ctx)
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case event := <-ch:
+			assert.Equal(t, domain.NewEventUpdateTreeCluster(testTreeClusters[0], updated), event)
+		case <-time.After(time.Second):
+			t.Fatal("event was not received")
+		}
+	})
+
+	t.Run("should compute water balance from stored weather if weather provider is disabled", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+
+		m.weatherRepo.EXPECT().GetLocations(ctx).Return(testLocations, nil)
+		m.weatherProvider.EXPECT().GetDailyWeather(ctx, mock.Anything, mock.Anything, 30, 7).Return(nil, storage.ErrWeatherServiceDisabled).Once()
+		m.treeClusterRepo.EXPECT().GetAll(ctx, domain.TreeClusterQuery{}).Return(testTreeClusters[2:], int64(1), nil)
+
+		// when
+		err := svc.Update(ctx)
+
+		// then
+		assert.NoError(t, err)
+		m.weatherRepo.AssertNotCalled(t, "UpsertDays")
+	})
+
+	t.Run("should update other regions and return error if weather of a region fails", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+
+		m.weatherRepo.EXPECT().GetLocations(ctx).Return(testLocations, nil)
+		m.weatherProvider.EXPECT().GetDailyWeather(ctx, 54.78, 9.44, 30, 7).Return(nil, errors.New("timeout"))
+		m.weatherProvider.EXPECT().GetDailyWeather(ctx, 54.80, 9.42, 30, 7).Return(testDays, nil)
+		m.weatherRepo.EXPECT().UpsertDays(ctx, int32(2), testDays).Return(nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, domain.TreeClusterQuery{}).Return([]*domain.TreeCluster{}, int64(0), nil)
+
+		// when
+		err := svc.Update(ctx)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when tree clusters can not be fetched", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		expectedErr := errors.New("database error")
+
+		m.weatherRepo.EXPECT().GetLocations(ctx).Return([]*domain.WeatherLocation{}, nil)
+		m.treeClusterRepo.EXPECT().GetAll(ctx, domain.TreeClusterQuery{}).Return(nil, int64(0), expectedErr)
+
+		// when
+		err := svc.Update(ctx)
+
+		// then
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
+
+func TestWeatherService_GetByRegion(t *testing.T) {
+	t.Run("should return weather days of the region", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+
+		m.regionRepo.EXPECT().GetByID(ctx, int32(1)).Return(&domain.Region{ID: 1}, nil)
+		m.weatherRepo.EXPECT().GetDays(ctx, int32(1), time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 9, 0, 0, 0, 0, time.UTC)).Return(testDays, nil)
+
+		// when
+		got, err := svc.GetByRegion(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testDays, got)
+	})
+
+	t.Run("should return error when region is not found", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+
+		m.regionRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetByRegion(ctx, 99)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherService_GetWaterBalance(t *testing.T) {
+	t.Run("should return water balance of the tree cluster", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+		balances := computeWaterBalance(testTreeClusters[0], testDays, 1)
+
+		m.treeClusterRepo.EXPECT().GetByID(ctx, int32(1)).Return(testTreeClusters[0], nil)
+		m.weatherRepo.EXPECT().GetWaterBalances(ctx, int32(1)).Return(balances, nil)
+
+		// when
+		got, err := svc.GetWaterBalance(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.TreeClusterID)
+		assert.Equal(t, domain.WateringStatusModerate, got.WateringStatus)
+		assert.Equal(t, 30.0, got.Deficit)
+		assert.Equal(t, time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC), *got.BadAt)
+		assert.Len(t, got.Days, 3)
+	})
+
+	t.Run("should return error when tree cluster is not found", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		ctx := context.Background()
+
+		m.treeClusterRepo.EXPECT().GetByID(ctx, int32(99)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetWaterBalance(ctx, 99)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		ready := svc.Ready()
+
+		// then
+		assert.True(t, ready)
+	})
+}
@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+)
+
+const (
+	HeaderEvent     = "X-GE-Event"
+	HeaderDelivery  = "X-GE-Delivery"
+	HeaderTimestamp = "X-GE-Timestamp"
+	HeaderSignature = "X-GE-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+var ErrWebhookDisabled = errors.New("webhook is disabled")
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>" using the webhook secret.
+// The timestamp is part of the signature so that receivers can reject replayed requests.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeliverDue sends up to deliveryBatchLimit due deliveries. Every delivery is claimed right before it is sent,
+// so the lease of a delivery does not run out while the deliveries before it are sent.
+func (s *WebhookService) DeliverDue(ctx context.Context) error {
+	log := logger.GetLogger(ctx)
+	webhooks := make(map[int32]*entities.Webhook)
+	for range deliveryBatchLimit {
+		deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, time.Now(), s.lease, 1)
+		if err != nil {
+			log.Error("failed to fetch due webhook deliveries", "error", err)
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		delivery := deliveries[0]
+		wh, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			wh, err = s.webhookRepo.GetByID(ctx, delivery.WebhookID)
+			if err != nil {
+				log.Error("failed to fetch webhook of delivery", "error", err, "webhook_id", delivery.WebhookID, "delivery_id", delivery.ID)
+				continue
+			}
+			webhooks[delivery.WebhookID] = wh
+		}
+
+		s.deliver(ctx, wh, delivery)
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			log.Error("failed to update webhook delivery", "error", err, "delivery_id", delivery.ID)
+		}
+	}
+
+	return nil
+}
+
+// deliver sends the delivery once and updates its state according to the result
+func (s *WebhookService) deliver(ctx context.Context, wh *entities.Webhook, delivery *entities.WebhookDelivery) {
+	log := logger.GetLogger(ctx)
+	now := time.Now()
+	delivery.Attempts++
+
+	var statusCode int
+	var err error
+	if wh.Enabled {
+		statusCode, err = s.send(ctx, wh, delivery, now)
+	} else {
+		err = ErrWebhookDisabled
+	}
+
+	delivery.LastStatusCode = nil
+	delivery.LastError = nil
+	if statusCode != 0 {
+		code := int32(statusCode)
+		delivery.LastStatusCode = &code
+	}
+
+	if err == nil {
+		delivery.Status = entities.WebhookDeliveryStatusSuccess
+		delivery.DeliveredAt = &now
+		log.Debug("webhook delivered successfully", "webhook_id", wh.ID, "delivery_id", delivery.ID, "status_code", statusCode)
+		return
+	}
+
+	errMsg := err.Error()
+	delivery.LastError = &errMsg
+	if delivery.Attempts >= s.maxAttempts || errors.Is(err, ErrWebhookDisabled) {
+		delivery.Status = entities.WebhookDeliveryStatusFailed
+		log.Warn("webhook delivery failed permanently", "error", err, "webhook_id", wh.ID, "delivery_id", delivery.ID, "attempts", delivery.Attempts)
+		return
+	}
+
+	delivery.Status = entities.WebhookDeliveryStatusRetrying
+	delivery.NextAttemptAt = now.Add(s.nextBackoff(delivery.Attempts))
+	log.Debug("webhook delivery failed, retry later", "error", err, "webhook_id", wh.ID, "delivery_id", delivery.ID, "next_attempt_at", delivery.NextAttemptAt)
+}
+
+func (s *WebhookService) send(ctx context.Context, wh *entities.Webhook, delivery *entities.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "green-ecolution-webhook")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, delivery.EventID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, signaturePrefix+Sign(wh.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// nextBackoff doubles the backoff for every attempt and caps it at maxBackoff
+func (s *WebhookService) nextBackoff(attempts int32) time.Duration {
+	backoff := s.backoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= s.maxBackoff {
+			return s.maxBackoff
+		}
+	}
+	return backoff
+}
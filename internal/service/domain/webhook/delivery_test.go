@@ -0,0 +1,229 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("should sign timestamp and body with secret", func(t *testing.T) {
+		// given
+		secret := "secret"
+		body := []byte(`{"id":"1"}`)
+
+		// when
+		got := Sign(secret, "1700000000", body)
+
+		// then
+		assert.Len(t, got, 64)
+		assert.Equal(t, got, Sign(secret, "1700000000", body))
+		assert.NotEqual(t, got, Sign(secret, "1700000001", body))
+		assert.NotEqual(t, got, Sign("other", "1700000000", body))
+	})
+}
+
+func TestWebhookService_DeliverDue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send signed payload and mark delivery as successful", func(t *testing.T) {
+		// given
+		delivery := testDelivery()
+		var gotReq *http.Request
+		var gotBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotReq = r
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		wh := testWebhook()
+		wh.URL = server.URL
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo, WithHTTPClient(server.Client()))
+
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return([]*entities.WebhookDelivery{delivery}, nil).Once()
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return(nil, nil).Once()
+		repo.EXPECT().GetByID(ctx, wh.ID).Return(wh, nil)
+		repo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, delivery.Payload, gotBody)
+		assert.Equal(t, string(delivery.EventType), gotReq.Header.Get(HeaderEvent))
+		assert.Equal(t, delivery.EventID, gotReq.Header.Get(HeaderDelivery))
+		timestamp := gotReq.Header.Get(HeaderTimestamp)
+		assert.Equal(t, signaturePrefix+Sign(wh.Secret, timestamp, delivery.Payload), gotReq.Header.Get(HeaderSignature))
+
+		assert.Equal(t, entities.WebhookDeliveryStatusSuccess, delivery.Status)
+		assert.Equal(t, int32(1), delivery.Attempts)
+		assert.Equal(t, int32(http.StatusNoContent), *delivery.LastStatusCode)
+		assert.Nil(t, delivery.LastError)
+		assert.NotNil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("should schedule retry with backoff when receiver fails", func(t *testing.T) {
+		// given
+		delivery := testDelivery()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		wh := testWebhook()
+		wh.URL = server.URL
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo, WithHTTPClient(server.Client()), WithBackoff(time.Minute, time.Hour))
+
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return([]*entities.WebhookDelivery{delivery}, nil).Once()
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return(nil, nil).Once()
+		repo.EXPECT().GetByID(ctx, wh.ID).Return(wh, nil)
+		repo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		before := time.Now()
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WebhookDeliveryStatusRetrying, delivery.Status)
+		assert.Equal(t, int32(http.StatusInternalServerError), *delivery.LastStatusCode)
+		assert.NotNil(t, delivery.LastError)
+		assert.Nil(t, delivery.DeliveredAt)
+		assert.WithinDuration(t, before.Add(time.Minute), delivery.NextAttemptAt, 5*time.Second)
+	})
+
+	t.Run("should mark delivery as failed after max attempts", func(t *testing.T) {
+		// given
+		delivery := testDelivery()
+		delivery.Attempts = 2
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		wh := testWebhook()
+		wh.URL = server.URL
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo, WithHTTPClient(server.Client()), WithMaxAttempts(3))
+
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return([]*entities.WebhookDelivery{delivery}, nil).Once()
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return(nil, nil).Once()
+		repo.EXPECT().GetByID(ctx, wh.ID).Return(wh, nil)
+		repo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, int32(3), delivery.Attempts)
+	})
+
+	t.Run("should claim every delivery right before it is sent when the lease is shorter than the batch", func(t *testing.T) {
+		// given
+		const sendDuration = 20 * time.Millisecond
+		lease := 2 * sendDuration
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			time.Sleep(sendDuration)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		wh := testWebhook()
+		wh.URL = server.URL
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo, WithHTTPClient(server.Client()), WithDeliveryLease(lease))
+
+		pending := make([]*entities.WebhookDelivery, 0, 3)
+		for i := range 3 {
+			delivery := testDelivery()
+			delivery.ID = int32(i + 1)
+			pending = append(pending, delivery)
+		}
+		claimedAt := make(map[int32]time.Time)
+		updatedAt := make(map[int32]time.Time)
+
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, lease, int32(1)).RunAndReturn(func(_ context.Context, now time.Time, _ time.Duration, _ int32) ([]*entities.WebhookDelivery, error) {
+			if len(pending) == 0 {
+				return nil, nil
+			}
+			delivery := pending[0]
+			pending = pending[1:]
+			claimedAt[delivery.ID] = now
+			return []*entities.WebhookDelivery{delivery}, nil
+		})
+		repo.EXPECT().GetByID(ctx, wh.ID).Return(wh, nil).Once()
+		repo.EXPECT().UpdateDelivery(ctx, mock.Anything).RunAndReturn(func(_ context.Context, delivery *entities.WebhookDelivery) error {
+			updatedAt[delivery.ID] = time.Now()
+			return nil
+		})
+
+		// when
+		start := time.Now()
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Greater(t, time.Since(start), lease)
+		assert.Len(t, updatedAt, 3)
+		for id, updated := range updatedAt {
+			assert.Less(t, updated.Sub(claimedAt[id]), lease, "delivery %d was sent after its lease expired", id)
+		}
+	})
+
+	t.Run("should mark delivery as failed when webhook is disabled", func(t *testing.T) {
+		// given
+		delivery := testDelivery()
+		wh := testWebhook()
+		wh.Enabled = false
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return([]*entities.WebhookDelivery{delivery}, nil).Once()
+		repo.EXPECT().ClaimDueDeliveries(ctx, mock.Anything, deliveryLease, int32(1)).Return(nil, nil).Once()
+		repo.EXPECT().GetByID(ctx, wh.ID).Return(wh, nil)
+		repo.EXPECT().UpdateDelivery(ctx, delivery).Return(nil)
+
+		// when
+		err := svc.DeliverDue(ctx)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, ErrWebhookDisabled.Error(), *delivery.LastError)
+	})
+}
+
+func TestWebhookService_nextBackoff(t *testing.T) {
+	svc := NewWebhookService(nil, WithBackoff(30*time.Second, 5*time.Minute))
+
+	tests := []struct {
+		attempts int32
+		expected time.Duration
+	}{
+		{attempts: 1, expected: 30 * time.Second},
+		{attempts: 2, expected: time.Minute},
+		{attempts: 3, expected: 2 * time.Minute},
+		{attempts: 4, expected: 4 * time.Minute},
+		{attempts: 5, expected: 5 * time.Minute},
+		{attempts: 20, expected: 5 * time.Minute},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, svc.nextBackoff(test.attempts))
+	}
+}
@@ -0,0 +1,262 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+var ErrUnsupportedEvent = errors.New("event is not supported by webhooks")
+
+// payload is the envelope that is sent to the webhook url. The entities of the
+// event are flattened because the domain entities contain cyclic references.
+type payload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type treePayload struct {
+	ID             int32                  `json:"id"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	TreeClusterID  *int32                 `json:"tree_cluster_id,omitempty"`
+	SensorID       *string                `json:"sensor_id,omitempty"`
+	PlantingYear   int32                  `json:"planting_year"`
+	Species        string                 `json:"species"`
+	Number         string                 `json:"number"`
+	Latitude       float64                `json:"latitude"`
+	Longitude      float64                `json:"longitude"`
+	WateringStatus string                 `json:"watering_status"`
+	Description    string                 `json:"description"`
+	LastWatered    *time.Time             `json:"last_watered,omitempty"`
+	Provider       string                 `json:"provider,omitempty"`
+	AdditionalInfo map[string]interface{} `json:"additional_information,omitempty"`
+}
+
+type treeClusterPayload struct {
+	ID             int32                  `json:"id"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	Name           string                 `json:"name"`
+	Address        string                 `json:"address"`
+	Description    string                 `json:"description"`
+	RegionID       *int32                 `json:"region_id,omitempty"`
+	WateringStatus string                 `json:"watering_status"`
+	MoistureLevel  float64                `json:"moisture_level"`
+	SoilCondition  string                 `json:"soil_condition"`
+	LastWatered    *time.Time             `json:"last_watered,omitempty"`
+	Latitude       *float64               `json:"latitude,omitempty"`
+	Longitude      *float64               `json:"longitude,omitempty"`
+	Archived       bool                   `json:"archived"`
+	TreeIDs        []int32                `json:"tree_ids"`
+	Provider       string                 `json:"provider,omitempty"`
+	AdditionalInfo map[string]interface{} `json:"additional_information,omitempty"`
+}
+
+type watermarkPayload struct {
+	Centibar   int `json:"centibar"`
+	Resistance int `json:"resistance"`
+	Depth      int `json:"depth"`
+}
+
+type sensorDataPayload struct {
+	ID          int32              `json:"id"`
+	SensorID    string             `json:"sensor_id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	Battery     float64            `json:"battery"`
+	Humidity    float64            `json:"humidity"`
+	Temperature float64            `json:"temperature"`
+	Latitude    float64            `json:"latitude"`
+	Longitude   float64            `json:"longitude"`
+	Watermarks  []watermarkPayload `json:"watermarks"`
+}
+
+type wateringPlanPayload struct {
+	ID                 int32                  `json:"id"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	Date               time.Time              `json:"date"`
+	Description        string                 `json:"description"`
+	Status             string                 `json:"status"`
+	Distance           *float64               `json:"distance,omitempty"`
+	TotalWaterRequired *float64               `json:"total_water_required,omitempty"`
+	TreeClusterIDs     []int32                `json:"tree_cluster_ids"`
+	TransporterID      *int32                 `json:"transporter_id,omitempty"`
+	TrailerID          *int32                 `json:"trailer_id,omitempty"`
+	CancellationNote   string                 `json:"cancellation_note,omitempty"`
+	Provider           string                 `json:"provider,omitempty"`
+	AdditionalInfo     map[string]interface{} `json:"additional_information,omitempty"`
+}
+
+type changePayload[T any] struct {
+	Prev *T `json:"prev,omitempty"`
+	New  *T `json:"new,omitempty"`
+}
+
+// NewPayload builds the json body that is delivered to every webhook subscribing the event.
+// The event stream sends the same body to its clients.
+func NewPayload(id string, createdAt time.Time, event entities.Event) ([]byte, error) {
+	var data any
+	switch e := event.(type) {
+	case entities.EventCreateTree:
+		data = changePayload[treePayload]{New: mapTree(e.New)}
+	case entities.EventUpdateTree:
+		data = changePayload[treePayload]{Prev: mapTree(e.Prev), New: mapTree(e.New)}
+	case entities.EventDeleteTree:
+		data = changePayload[treePayload]{Prev: mapTree(e.Prev)}
+	case entities.EventUpdateTreeCluster:
+		data = changePayload[treeClusterPayload]{Prev: mapTreeCluster(e.Prev), New: mapTreeCluster(e.New)}
+	case entities.EventNewSensorData:
+		data = changePayload[sensorDataPayload]{New: mapSensorData(e.New)}
+	case entities.EventUpdateWateringPlan:
+		data = changePayload[wateringPlanPayload]{Prev: mapWateringPlan(e.Prev), New: mapWateringPlan(e.New)}
+	default:
+		return nil, ErrUnsupportedEvent
+	}
+
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(payload{
+		ID:        id,
+		Type:      string(event.Type()),
+		CreatedAt: createdAt,
+		Data:      rawData,
+	})
+}
+
+func mapTree(t *entities.Tree) *treePayload {
+	if t == nil {
+		return nil
+	}
+
+	p := &treePayload{
+		ID:             t.ID,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+		PlantingYear:   t.PlantingYear,
+		Species:        t.Species,
+		Number:         t.Number,
+		Latitude:       t.Latitude,
+		Longitude:      t.Longitude,
+		WateringStatus: string(t.WateringStatus),
+		Description:    t.Description,
+		LastWatered:    t.LastWatered,
+		Provider:       t.Provider,
+		AdditionalInfo: t.AdditionalInfo,
+	}
+
+	if t.TreeCluster != nil {
+		p.TreeClusterID = &t.TreeCluster.ID
+	}
+
+	if t.Sensor != nil {
+		p.SensorID = &t.Sensor.ID
+	}
+
+	return p
+}
+
+func mapTreeCluster(tc *entities.TreeCluster) *treeClusterPayload {
+	if tc == nil {
+		return nil
+	}
+
+	p := &treeClusterPayload{
+		ID:             tc.ID,
+		CreatedAt:      tc.CreatedAt,
+		UpdatedAt:      tc.UpdatedAt,
+		Name:           tc.Name,
+		Address:        tc.Address,
+		Description:    tc.Description,
+		WateringStatus: string(tc.WateringStatus),
+		MoistureLevel:  tc.MoistureLevel,
+		SoilCondition:  string(tc.SoilCondition),
+		LastWatered:    tc.LastWatered,
+		Latitude:       tc.Latitude,
+		Longitude:      tc.Longitude,
+		Archived:       tc.Archived,
+		TreeIDs:        make([]int32, 0, len(tc.Trees)),
+		Provider:       tc.Provider,
+		AdditionalInfo: tc.AdditionalInfo,
+	}
+
+	if tc.Region != nil {
+		p.RegionID = &tc.Region.ID
+	}
+
+	for _, t := range tc.Trees {
+		p.TreeIDs = append(p.TreeIDs, t.ID)
+	}
+
+	return p
+}
+
+func mapSensorData(sd *entities.SensorData) *sensorDataPayload {
+	if sd == nil {
+		return nil
+	}
+
+	p := &sensorDataPayload{
+		ID:         sd.ID,
+		SensorID:   sd.SensorID,
+		CreatedAt:  sd.CreatedAt,
+		UpdatedAt:  sd.UpdatedAt,
+		Watermarks: []watermarkPayload{},
+	}
+
+	if sd.Data != nil {
+		p.Battery = sd.Data.Battery
+		p.Humidity = sd.Data.Humidity
+		p.Temperature = sd.Data.Temperature
+		p.Latitude = sd.Data.Latitude
+		p.Longitude = sd.Data.Longitude
+		for _, w := range sd.Data.Watermarks {
+			p.Watermarks = append(p.Watermarks, watermarkPayload(w))
+		}
+	}
+
+	return p
+}
+
+func mapWateringPlan(wp *entities.WateringPlan) *wateringPlanPayload {
+	if wp == nil {
+		return nil
+	}
+
+	p := &wateringPlanPayload{
+		ID:                 wp.ID,
+		CreatedAt:          wp.CreatedAt,
+		UpdatedAt:          wp.UpdatedAt,
+		Date:               wp.Date,
+		Description:        wp.Description,
+		Status:             string(wp.Status),
+		Distance:           wp.Distance,
+		TotalWaterRequired: wp.TotalWaterRequired,
+		TreeClusterIDs:     make([]int32, 0, len(wp.TreeClusters)),
+		CancellationNote:   wp.CancellationNote,
+		Provider:           wp.Provider,
+		AdditionalInfo:     wp.AdditionalInfo,
+	}
+
+	for _, tc := range wp.TreeClusters {
+		p.TreeClusterIDs = append(p.TreeClusterIDs, tc.ID)
+	}
+
+	if wp.Transporter != nil {
+		p.TransporterID = &wp.Transporter.ID
+	}
+
+	if wp.Trailer != nil {
+		p.TrailerID = &wp.Trailer.ID
+	}
+
+	return p
+}
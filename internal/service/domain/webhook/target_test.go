@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{name: "public host", url: "https://example.com/hook", want: true},
+		{name: "public address", url: "http://93.184.216.34/hook", want: true},
+		{name: "unsupported scheme", url: "ftp://example.com/hook", want: false},
+		{name: "localhost", url: "http://localhost:8080/hook", want: false},
+		{name: "subdomain of localhost", url: "http://api.localhost/hook", want: false},
+		{name: "loopback address", url: "http://127.0.0.1/hook", want: false},
+		{name: "ipv6 loopback address", url: "http://[::1]/hook", want: false},
+		{name: "private address", url: "http://192.168.1.10/hook", want: false},
+		{name: "link local address", url: "http://169.254.169.254/latest", want: false},
+		{name: "unspecified address", url: "http://0.0.0.0/hook", want: false},
+		{name: "ipv4 mapped private address", url: "http://[::ffff:10.0.0.1]/hook", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateTarget(tt.url))
+		})
+	}
+}
+
+func TestNewPublicHTTPClient(t *testing.T) {
+	t.Run("should refuse to connect to a loopback address", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		client := newPublicHTTPClient(time.Second)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, http.NoBody)
+
+		// when
+		resp, err := client.Do(req)
+
+		// then
+		if resp != nil {
+			resp.Body.Close()
+		}
+		assert.ErrorIs(t, err, errPrivateTarget)
+	})
+}
@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+const (
+	secretLength       = 32
+	deliveryLogLimit   = 100
+	deliveryBatchLimit = 50
+	// deliveryLease is how long a claimed delivery is hidden from other instances. A delivery of a crashed
+	// instance is picked up again once the lease expired. The deliveries are claimed one at a time, so the
+	// lease only has to cover a single request.
+	deliveryLease = 5 * time.Minute
+)
+
+type WebhookServiceConfig struct {
+	maxAttempts int32
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	client      *http.Client
+	lease       time.Duration
+	// allowPrivateTargets permits webhook urls on loopback and private addresses
+	allowPrivateTargets bool
+}
+
+type WebhookServiceOption func(*WebhookServiceConfig)
+
+var defaultWebhookServiceConfig = WebhookServiceConfig{
+	maxAttempts: 8,
+	backoff:     30 * time.Second,
+	maxBackoff:  6 * time.Hour,
+	client:      newPublicHTTPClient(10 * time.Second),
+	lease:       deliveryLease,
+}
+
+// WithMaxAttempts sets the number of attempts after which a delivery is marked as failed
+func WithMaxAttempts(attempts int32) WebhookServiceOption {
+	slog.Debug("use webhook service with max attempts", "attempts", attempts)
+	return func(cfg *WebhookServiceConfig) {
+		cfg.maxAttempts = attempts
+	}
+}
+
+// WithBackoff sets the delay before the first retry. The delay is doubled after every failed attempt up to maxBackoff.
+func WithBackoff(backoff, maxBackoff time.Duration) WebhookServiceOption {
+	slog.Debug("use webhook service with backoff", "backoff", backoff, "max_backoff", maxBackoff)
+	return func(cfg *WebhookServiceConfig) {
+		cfg.backoff = backoff
+		cfg.maxBackoff = maxBackoff
+	}
+}
+
+// WithDeliveryLease sets how long a claimed delivery is hidden from other instances. It must be longer than
+// the timeout of the client, otherwise a delivery is sent again by another instance.
+func WithDeliveryLease(lease time.Duration) WebhookServiceOption {
+	slog.Debug("use webhook service with delivery lease", "lease", lease)
+	return func(cfg *WebhookServiceConfig) {
+		cfg.lease = lease
+	}
+}
+
+// WithHTTPClient replaces the client used for deliveries. The default client refuses to connect to private
+// addresses, a replacement client is used as is.
+func WithHTTPClient(client *http.Client) WebhookServiceOption {
+	return func(cfg *WebhookServiceConfig) {
+		cfg.client = client
+	}
+}
+
+// WithAllowPrivateTargets permits webhooks on loopback and private addresses, e.g. for receivers in the same network
+func WithAllowPrivateTargets() WebhookServiceOption {
+	slog.Debug("use webhook service with private targets allowed")
+	return func(cfg *WebhookServiceConfig) {
+		cfg.allowPrivateTargets = true
+	}
+}
+
+type WebhookService struct {
+	WebhookServiceConfig
+	webhookRepo storage.WebhookRepository
+	validator   *validator.Validate
+}
+
+var _ service.WebhookService = (*WebhookService)(nil)
+
+func NewWebhookService(webhookRepo storage.WebhookRepository, opts ...WebhookServiceOption) *WebhookService {
+	cfg := defaultWebhookServiceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &WebhookService{
+		WebhookServiceConfig: cfg,
+		webhookRepo:          webhookRepo,
+		validator:            validator.New(),
+	}
+}
+
+func (s *WebhookService) GetAll(ctx context.Context) ([]*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	webhooks, err := s.webhookRepo.GetAll(ctx)
+	if err != nil {
+		log.Debug("failed to fetch webhooks", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return webhooks, nil
+}
+
+func (s *WebhookService) GetByID(ctx context.Context, id int32) (*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	wh, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to fetch webhook by id", "error", err, "webhook_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return wh, nil
+}
+
+func (s *WebhookService) Create(ctx context.Context, createData *entities.WebhookCreate) (*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(createData); err != nil {
+		log.Debug("failed to validate struct from create webhook", "error", err, "raw_webhook", fmt.Sprintf("%+v", createData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if !validEventTypes(createData.EventTypes) {
+		log.Debug("webhook subscribes unsupported event types", "event_types", createData.EventTypes)
+		return nil, service.ErrWebhookEventTypeInvalid
+	}
+
+	if !s.allowPrivateTargets && !validateTarget(createData.URL) {
+		log.Debug("webhook url points to a private address", "url", createData.URL)
+		return nil, service.ErrWebhookTargetForbidden
+	}
+
+	secret := createData.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			log.Error("failed to generate webhook secret", "error", err)
+			return nil, service.MapError(ctx, err, service.ErrorLogAll)
+		}
+	}
+
+	created, err := s.webhookRepo.Create(ctx, func(wh *entities.Webhook, _ storage.WebhookRepository) (bool, error) {
+		wh.Name = createData.Name
+		wh.URL = createData.URL
+		wh.Secret = secret
+		wh.EventTypes = createData.EventTypes
+		wh.Enabled = createData.Enabled
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to create webhook", "error", err)
+		return nil, service.MapError(ctx, err, service.ErrorLogAll)
+	}
+
+	log.Info("webhook created successfully", "webhook_id", created.ID, "event_types", created.EventTypes)
+	return created, nil
+}
+
+func (s *WebhookService) Update(ctx context.Context, id int32, updateData *entities.WebhookUpdate) (*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	if err := s.validator.Struct(updateData); err != nil {
+		log.Debug("failed to validate struct from update webhook", "error", err, "raw_webhook", fmt.Sprintf("%+v", updateData))
+		return nil, service.MapError(ctx, errors.Join(err, service.ErrValidation), service.ErrorLogValidation)
+	}
+
+	if !validEventTypes(updateData.EventTypes) {
+		log.Debug("webhook subscribes unsupported event types", "event_types", updateData.EventTypes)
+		return nil, service.ErrWebhookEventTypeInvalid
+	}
+
+	if !s.allowPrivateTargets && !validateTarget(updateData.URL) {
+		log.Debug("webhook url points to a private address", "url", updateData.URL)
+		return nil, service.ErrWebhookTargetForbidden
+	}
+
+	err := s.webhookRepo.Update(ctx, id, func(wh *entities.Webhook, _ storage.WebhookRepository) (bool, error) {
+		wh.Name = updateData.Name
+		wh.URL = updateData.URL
+		wh.EventTypes = updateData.EventTypes
+		wh.Enabled = updateData.Enabled
+		return true, nil
+	})
+	if err != nil {
+		log.Debug("failed to update webhook", "error", err, "webhook_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("webhook updated successfully", "webhook_id", id)
+	return s.GetByID(ctx, id)
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		log.Debug("failed to delete webhook", "error", err, "webhook_id", id)
+		return service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	log.Info("webhook deleted successfully", "webhook_id", id)
+	return nil
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, id int32) ([]*entities.WebhookDelivery, error) {
+	log := logger.GetLogger(ctx)
+	if _, err := s.webhookRepo.GetByID(ctx, id); err != nil {
+		log.Debug("failed to fetch webhook by id", "error", err, "webhook_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveriesByWebhookID(ctx, id, deliveryLogLimit)
+	if err != nil {
+		log.Debug("failed to fetch webhook deliveries", "error", err, "webhook_id", id)
+		return nil, service.MapError(ctx, err, service.ErrorLogEntityNotFound)
+	}
+
+	return deliveries, nil
+}
+
+func (s *WebhookService) HandleEvent(ctx context.Context, event entities.Event) error {
+	log := logger.GetLogger(ctx)
+	webhooks, err := s.webhookRepo.GetAllEnabledByEventType(ctx, event.Type())
+	if err != nil {
+		log.Error("failed to fetch webhooks subscribing the event", "error", err, "event_type", event.Type())
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	eventID := uuid.NewString()
+	body, err := NewPayload(eventID, time.Now(), event)
+	if err != nil {
+		log.Error("failed to build webhook payload", "error", err, "event_type", event.Type())
+		return err
+	}
+
+	var errs []error
+	for _, wh := range webhooks {
+		_, err := s.webhookRepo.CreateDelivery(ctx, &entities.WebhookDelivery{
+			WebhookID: wh.ID,
+			EventID:   eventID,
+			EventType: event.Type(),
+			Payload:   body,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		log.Debug("queued webhook delivery", "webhook_id", wh.ID, "event_id", eventID, "event_type", event.Type())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *WebhookService) Ready() bool {
+	return s.webhookRepo != nil
+}
+
+func validEventTypes(eventTypes []entities.EventType) bool {
+	for _, eventType := range eventTypes {
+		if !slices.Contains(entities.WebhookEventTypes, eventType) {
+			return false
+		}
+	}
+	return true
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
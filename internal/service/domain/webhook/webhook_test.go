@@ -0,0 +1,445 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookService_GetByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return webhook when found", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(testWebhook(), nil)
+
+		// when
+		got, err := svc.GetByID(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, testWebhook(), got)
+	})
+
+	t.Run("should return not found error when webhook does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetByID(ctx, 1)
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create webhook and generate secret", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		createData := &entities.WebhookCreate{
+			Name:       "Irrigation system",
+			URL:        "https://example.com/hook",
+			EventTypes: []entities.EventType{entities.EventTypeUpdateTree},
+			Enabled:    true,
+		}
+
+		var secret string
+		repo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*entities.Webhook, storage.WebhookRepository) (bool, error)) (*entities.Webhook, error) {
+			wh := &entities.Webhook{}
+			ok, err := fn(wh, repo)
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			secret = wh.Secret
+			return wh, nil
+		})
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, createData.Name, got.Name)
+		assert.Equal(t, createData.URL, got.URL)
+		assert.Equal(t, createData.EventTypes, got.EventTypes)
+		assert.Len(t, secret, secretLength*2)
+	})
+
+	t.Run("should keep given secret", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		createData := &entities.WebhookCreate{
+			Name:       "Irrigation system",
+			URL:        "https://example.com/hook",
+			Secret:     "a-very-secret-value",
+			EventTypes: []entities.EventType{entities.EventTypeUpdateTree},
+		}
+
+		repo.EXPECT().Create(ctx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*entities.Webhook, storage.WebhookRepository) (bool, error)) (*entities.Webhook, error) {
+			wh := &entities.Webhook{}
+			_, err := fn(wh, repo)
+			return wh, err
+		})
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, createData.Secret, got.Secret)
+	})
+
+	t.Run("should return validation error on invalid url", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		createData := &entities.WebhookCreate{
+			Name:       "Irrigation system",
+			URL:        "not an url",
+			EventTypes: []entities.EventType{entities.EventTypeUpdateTree},
+		}
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorContains(t, err, "validation error")
+	})
+
+	t.Run("should reject url on a private address", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		createData := &entities.WebhookCreate{
+			Name:       "Irrigation system",
+			URL:        "http://169.254.169.254/latest/meta-data",
+			EventTypes: []entities.EventType{entities.EventTypeUpdateTree},
+		}
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrWebhookTargetForbidden)
+	})
+
+	t.Run("should create webhook on a private address when allowed", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo, WithAllowPrivateTargets())
+		createData := &entities.WebhookCreate{
+			Name:       "Irrigation system",
+			URL:        "http://10.0.0.5/hook",
+			EventTypes: []entities.EventType{entities.EventTypeUpdateTree},
+		}
+
+		repo.EXPECT().Create(ctx, mock.Anything).Return(&entities.Webhook{ID: 1, URL: createData.URL}, nil)
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, createData.URL, got.URL)
+	})
+
+	t.Run("should return error on unsupported event type", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		createData := &entities.WebhookCreate{
+			Name:       "Irrigation system",
+			URL:        "https://example.com/hook",
+			EventTypes: []entities.EventType{"unknown"},
+		}
+
+		// when
+		got, err := svc.Create(ctx, createData)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrWebhookEventTypeInvalid)
+	})
+}
+
+func TestWebhookService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should update webhook", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		updateData := &entities.WebhookUpdate{
+			Name:       "Renamed",
+			URL:        "https://example.com/other",
+			EventTypes: []entities.EventType{entities.EventTypeDeleteTree},
+			Enabled:    false,
+		}
+
+		updated := testWebhook()
+		repo.EXPECT().Update(ctx, int32(1), mock.Anything).RunAndReturn(func(_ context.Context, _ int32, fn func(*entities.Webhook, storage.WebhookRepository) (bool, error)) error {
+			_, err := fn(updated, repo)
+			return err
+		})
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(updated, nil)
+
+		// when
+		got, err := svc.Update(ctx, 1, updateData)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, updateData.Name, got.Name)
+		assert.Equal(t, updateData.URL, got.URL)
+		assert.Equal(t, updateData.EventTypes, got.EventTypes)
+		assert.False(t, got.Enabled)
+		assert.Equal(t, testWebhook().Secret, got.Secret)
+	})
+
+	t.Run("should return not found error when webhook does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		updateData := &entities.WebhookUpdate{
+			Name:       "Renamed",
+			URL:        "https://example.com/other",
+			EventTypes: []entities.EventType{entities.EventTypeDeleteTree},
+		}
+		repo.EXPECT().Update(ctx, int32(1), mock.Anything).Return(storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.Update(ctx, 1, updateData)
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+
+	t.Run("should reject url on localhost", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		updateData := &entities.WebhookUpdate{
+			Name:       "Renamed",
+			URL:        "http://localhost:3000/hook",
+			EventTypes: []entities.EventType{entities.EventTypeDeleteTree},
+		}
+
+		// when
+		got, err := svc.Update(ctx, 1, updateData)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, service.ErrWebhookTargetForbidden)
+	})
+}
+
+func TestWebhookService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete webhook", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		repo.EXPECT().Delete(ctx, int32(1)).Return(nil)
+
+		// when
+		err := svc.Delete(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found error when webhook does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		repo.EXPECT().Delete(ctx, int32(1)).Return(storage.ErrEntityNotFound("not found"))
+
+		// when
+		err := svc.Delete(ctx, 1)
+
+		// then
+		assertNotFound(t, err)
+	})
+}
+
+func TestWebhookService_GetDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return deliveries of webhook", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		deliveries := []*entities.WebhookDelivery{testDelivery()}
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(testWebhook(), nil)
+		repo.EXPECT().GetDeliveriesByWebhookID(ctx, int32(1), int32(deliveryLogLimit)).Return(deliveries, nil)
+
+		// when
+		got, err := svc.GetDeliveries(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, deliveries, got)
+	})
+
+	t.Run("should return not found error when webhook does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		repo.EXPECT().GetByID(ctx, int32(1)).Return(nil, storage.ErrEntityNotFound("not found"))
+
+		// when
+		got, err := svc.GetDeliveries(ctx, 1)
+
+		// then
+		assert.Nil(t, got)
+		assertNotFound(t, err)
+	})
+}
+
+func TestWebhookService_HandleEvent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should queue delivery for every subscribed webhook", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		tree := &entities.Tree{ID: 1, Number: "T-1", TreeCluster: &entities.TreeCluster{ID: 2}}
+		event := entities.NewEventCreateTree(tree, nil)
+
+		other := testWebhook()
+		other.ID = 2
+		repo.EXPECT().GetAllEnabledByEventType(ctx, entities.EventTypeCreateTree).Return([]*entities.Webhook{testWebhook(), other}, nil)
+
+		var queued []*entities.WebhookDelivery
+		repo.EXPECT().CreateDelivery(ctx, mock.Anything).RunAndReturn(func(_ context.Context, d *entities.WebhookDelivery) (*entities.WebhookDelivery, error) {
+			queued = append(queued, d)
+			return d, nil
+		}).Times(2)
+
+		// when
+		err := svc.HandleEvent(ctx, event)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, queued, 2)
+		assert.Equal(t, int32(1), queued[0].WebhookID)
+		assert.Equal(t, int32(2), queued[1].WebhookID)
+		assert.Equal(t, queued[0].EventID, queued[1].EventID)
+
+		var body payload
+		assert.NoError(t, json.Unmarshal(queued[0].Payload, &body))
+		assert.Equal(t, queued[0].EventID, body.ID)
+		assert.Equal(t, string(entities.EventTypeCreateTree), body.Type)
+
+		var data changePayload[treePayload]
+		assert.NoError(t, json.Unmarshal(body.Data, &data))
+		assert.Nil(t, data.Prev)
+		assert.Equal(t, int32(1), data.New.ID)
+		assert.Equal(t, int32(2), *data.New.TreeClusterID)
+	})
+
+	t.Run("should do nothing when no webhook subscribed the event", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		event := entities.NewEventDeleteTree(&entities.Tree{ID: 1})
+		repo.EXPECT().GetAllEnabledByEventType(ctx, entities.EventTypeDeleteTree).Return([]*entities.Webhook{}, nil)
+
+		// when
+		err := svc.HandleEvent(ctx, event)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when delivery can not be queued", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockWebhookRepository(t)
+		svc := NewWebhookService(repo)
+		event := entities.NewEventDeleteTree(&entities.Tree{ID: 1})
+		repo.EXPECT().GetAllEnabledByEventType(ctx, entities.EventTypeDeleteTree).Return([]*entities.Webhook{testWebhook()}, nil)
+		repo.EXPECT().CreateDelivery(ctx, mock.Anything).Return(nil, errors.New("database error"))
+
+		// when
+		err := svc.HandleEvent(ctx, event)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookService_Ready(t *testing.T) {
+	t.Run("should return true if the service is ready", func(t *testing.T) {
+		// given
+		svc := NewWebhookService(storageMock.NewMockWebhookRepository(t))
+
+		// when
+		ready := svc.Ready()
+
+		// then
+		assert.True(t, ready)
+	})
+
+	t.Run("should return false if the service is not ready", func(t *testing.T) {
+		// given
+		svc := NewWebhookService(nil)
+
+		// when
+		ready := svc.Ready()
+
+		// then
+		assert.False(t, ready)
+	})
+}
+
+func testWebhook() *entities.Webhook {
+	return &entities.Webhook{
+		ID:         1,
+		Name:       "Irrigation system",
+		URL:        "https://example.com/hook",
+		Secret:     "0123456789abcdef0123456789abcdef",
+		EventTypes: []entities.EventType{entities.EventTypeCreateTree, entities.EventTypeDeleteTree},
+		Enabled:    true,
+	}
+}
+
+func testDelivery() *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:            1,
+		WebhookID:     1,
+		EventID:       "3f1c5a52-2a4a-4c4e-9b9e-4d2f3f1c5a52",
+		EventType:     entities.EventTypeCreateTree,
+		Payload:       []byte(`{"id":"3f1c5a52-2a4a-4c4e-9b9e-4d2f3f1c5a52"}`),
+		Status:        entities.WebhookDeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+func assertNotFound(t *testing.T, err error) {
+	t.Helper()
+	var svcErr service.Error
+	if assert.ErrorAs(t, err, &svcErr) {
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/google/uuid"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -20,11 +21,55 @@ var (
 	ErrHostnameNotFound      = errors.New("cant get hostname")
 	ErrValidation            = errors.New("validation error")
 
-	ErrPluginRegistered       = NewError(BadRequest, "plugin already registered")
-	ErrPluginNotRegistered    = NewError(BadRequest, "plugin not registered")
-	ErrVehiclePlateTaken      = NewError(BadRequest, "number plate is already taken")
-	ErrVehicleUnsupportedType = NewError(BadRequest, "vehicle type is not supported")
-	ErrUserNotCorrectRole     = NewError(BadRequest, "user has an incorrect role")
+	ErrPluginNotRegistered     = NewError(BadRequest, "plugin not registered")
+	ErrPluginNotGranted        = NewError(Forbidden, "plugin is not granted for this client")
+	ErrPluginScopeNotGranted   = NewError(Forbidden, "plugin scope is not granted")
+	ErrPluginScopeInvalid      = NewError(BadRequest, "plugin scope is invalid")
+	ErrPluginManifestInvalid   = NewError(BadRequest, "plugin manifest must have a relative entry and known roles")
+	ErrPluginRoleRequired      = NewError(Forbidden, "a role of the plugin manifest is required")
+	ErrWebhookEventTypeInvalid = NewError(BadRequest, "webhook event type is not supported")
+	ErrWebhookTargetForbidden  = NewError(BadRequest, "webhook url must point to a public host")
+	ErrAPIKeyInvalid           = NewError(Unauthorized, "api key is invalid, expired or revoked")
+	ErrAPIKeyScopeInvalid      = NewError(BadRequest, "api key scope is invalid")
+	ErrAPIKeyExpiryInPast      = NewError(BadRequest, "api key expiry must be in the future")
+	ErrAPIKeyScopeNotGranted   = NewError(Forbidden, "api key scope is not granted")
+	ErrAPIKeyUpdateSelf        = NewError(Forbidden, "an api key can not update itself")
+	ErrAPIKeyExpiryNotBounded  = NewError(Forbidden, "an api key can only hand out keys that expire no later than itself")
+	ErrTreeImportFormatInvalid = NewError(BadRequest, "tree import format is not supported")
+	ErrTreeImportFieldInvalid  = NewError(BadRequest, "tree import mapping contains an unknown field")
+	ErrTreeImportNotValidated  = NewError(Conflict, "tree import must be validated without errors before it can be committed")
+	ErrTreeImportInProgress    = NewError(Conflict, "tree import is in progress")
+	ErrExportFormatInvalid     = NewError(BadRequest, "export format is not supported")
+	ErrExportResourceInvalid   = NewError(BadRequest, "export resource is not supported")
+	ErrOGCCollectionNotFound   = NewError(NotFound, "collection not found")
+	ErrOGCPropertyUnknown      = NewError(BadRequest, "property is not queryable")
+	ErrOGCPropertyInvalid      = NewError(BadRequest, "property value does not match the type of the queryable")
+	ErrTileLayerNotFound       = NewError(NotFound, "tile layer not found")
+	ErrTileCoordinateInvalid   = NewError(BadRequest, "tile coordinate is out of range")
+	ErrRegionGeometryInvalid   = NewError(BadRequest, "region geometry must be a valid polygon or multi polygon")
+	ErrRegionOverlapping       = NewError(Conflict, "region overlaps with another region")
+	ErrSuggestionQueryInvalid  = NewError(BadRequest, "distance must be between 1 and 500 meters and the number of trees between 1 and 1000")
+	ErrSuggestionTreeTwice     = NewError(BadRequest, "a tree can only be part of one accepted tree cluster suggestion")
+	ErrClusterPolygonInvalid   = NewError(BadRequest, "tree cluster polygon must be a valid polygon")
+	ErrAuditLogQueryInvalid    = NewError(BadRequest, "audit log filter is invalid")
+	ErrEventStreamTypeInvalid  = NewError(BadRequest, "event type is not supported by the event stream")
+	ErrJobNotFound             = NewError(NotFound, "job not found")
+	ErrJobScheduleInvalid      = NewError(BadRequest, "job schedule is not a valid cron expression")
+	ErrProposalQueryInvalid    = NewError(BadRequest, "days must be between 1 and the planning horizon")
+	ErrProposalConflict        = NewError(BadRequest, "a tree cluster can only be part of one accepted proposal and a vehicle or user of one per day")
+	ErrTemplateRRuleInvalid    = NewError(BadRequest, "recurrence rule is invalid or repeats more often than daily")
+	ErrEvaluationQueryInvalid  = NewError(BadRequest, "evaluation time range, interval or grouping is invalid")
+	ErrReportFormatInvalid     = NewError(BadRequest, "report format is not supported")
+	ErrReportYearInvalid       = NewError(BadRequest, "season year is invalid")
+	ErrReportLinkInvalid       = NewError(Forbidden, "download link is invalid or expired")
+	ErrNotificationSettings    = NewError(BadRequest, "notification settings contain an unknown type or channel or an invalid time")
+	ErrNotificationUserMissing = NewError(Forbidden, "notifications are only available for users")
+	ErrAlertRuleInvalid        = NewError(BadRequest, "alert rule is missing the watering statuses, threshold or window of its type")
+	ErrAdminRoleRequired       = NewError(Forbidden, "admin role is required")
+	ErrVersionMismatch         = NewError(PreconditionFailed, "entity has been modified, the If-Match header does not match the current ETag")
+	ErrVehiclePlateTaken       = NewError(BadRequest, "number plate is already taken")
+	ErrVehicleUnsupportedType  = NewError(BadRequest, "vehicle type is not supported")
+	ErrUserNotCorrectRole      = NewError(BadRequest, "user has an incorrect role")
 )
 
 type Error struct {
@@ -50,6 +95,16 @@ func (e Error) Error() string {
 	return e.Message
 }
 
+// PluginIncompatibleError is returned on registration if the plugin host can not
+// satisfy the api version range or the features required by the plugin.
+type PluginIncompatibleError struct {
+	domain.PluginIncompatibility
+}
+
+func (e PluginIncompatibleError) Error() string {
+	return e.Reason
+}
+
 func MapError(ctx context.Context, err error, errorMask ErrorLogMask) error {
 	log := logger.GetLogger(ctx)
 	var entityNotFoundErr storage.ErrEntityNotFound
@@ -67,6 +122,16 @@ func MapError(ctx context.Context, err error, errorMask ErrorLogMask) error {
 		return NewError(BadRequest, err.Error())
 	}
 
+	if errors.Is(err, storage.ErrVersionMismatch) {
+		log.Debug("entity version does not match", "error", err)
+		return ErrVersionMismatch
+	}
+
+	if errors.Is(err, storage.ErrRegionOverlapping) {
+		log.Debug("region overlaps with another region", "error", err)
+		return ErrRegionOverlapping
+	}
+
 	if errors.Is(err, storage.ErrS3ServiceDisabled) {
 		log.Warn("s3 service is disabled")
 		return NewError(Gone, err.Error())
@@ -82,6 +147,11 @@ func MapError(ctx context.Context, err error, errorMask ErrorLogMask) error {
 		return NewError(Gone, err.Error())
 	}
 
+	if errors.Is(err, storage.ErrPushServiceDisabled) {
+		log.Warn("push service is disabled")
+		return NewError(Gone, err.Error())
+	}
+
 	log.Error("an error has occurred", "error", err)
 	return NewError(InternalError, err.Error())
 }
@@ -89,13 +159,14 @@ func MapError(ctx context.Context, err error, errorMask ErrorLogMask) error {
 type ErrorCode int
 
 const (
-	BadRequest    ErrorCode = 400
-	Unauthorized  ErrorCode = 401
-	Forbidden     ErrorCode = 403
-	NotFound      ErrorCode = 404
-	Conflict      ErrorCode = 409
-	Gone          ErrorCode = 410
-	InternalError ErrorCode = 500
+	BadRequest         ErrorCode = 400
+	Unauthorized       ErrorCode = 401
+	Forbidden          ErrorCode = 403
+	NotFound           ErrorCode = 404
+	Conflict           ErrorCode = 409
+	Gone               ErrorCode = 410
+	PreconditionFailed ErrorCode = 412
+	InternalError      ErrorCode = 500
 )
 
 type BasicCrudService[T any, CreateType any, UpdateType any] interface {
@@ -128,6 +199,10 @@ type TreeService interface {
 type EvaluationService interface {
 	Service
 	GetEvaluation(ctx context.Context) (*domain.Evaluation, error)
+	// GetSeries returns the evaluation of the watering plans between the dates of the query split into periods and groups
+	GetSeries(ctx context.Context, query *domain.EvaluationSeriesQuery) (*domain.EvaluationSeries, error)
+	// SnapshotTreeStatuses records the number of trees per tree cluster and watering status of today
+	SnapshotTreeStatuses(ctx context.Context) error
 }
 
 type AuthService interface {
@@ -147,6 +222,9 @@ type RegionService interface {
 	Service
 	GetAll(ctx context.Context) ([]*domain.Region, int64, error)
 	GetByID(ctx context.Context, id int32) (*domain.Region, error)
+	Create(ctx context.Context, createData *domain.RegionCreate) (*domain.Region, error)
+	Update(ctx context.Context, id int32, updateData *domain.RegionUpdate) (*domain.Region, error)
+	Delete(ctx context.Context, id int32) error
 }
 
 type TreeClusterService interface {
@@ -159,17 +237,23 @@ type TreeClusterService interface {
 	Update(ctx context.Context, id int32, updateData *domain.TreeClusterUpdate) (*domain.TreeCluster, error)
 	Delete(ctx context.Context, id int32) error
 
+	// GetSuggestions groups trees by spatial proximity and suggests a tree cluster for every group
+	GetSuggestions(ctx context.Context, query domain.TreeClusterSuggestionQuery) ([]*domain.TreeClusterSuggestion, error)
+	// AcceptSuggestions creates or updates the tree clusters of the accepted suggestions in a single transaction
+	AcceptSuggestions(ctx context.Context, accepts []*domain.TreeClusterSuggestionAccept) ([]*domain.TreeCluster, error)
+
 	HandleUpdateTree(context.Context, *domain.EventUpdateTree) error
 	HandleCreateTree(context.Context, *domain.EventCreateTree) error
 	HandleDeleteTree(context.Context, *domain.EventDeleteTree) error
 	HandleNewSensorData(context.Context, *domain.EventNewSensorData) error
 	HandleUpdateWateringPlan(context.Context, *domain.EventUpdateWateringPlan) error
+	HandleImportTrees(context.Context, *domain.EventImportTrees) error
 	UpdateWateringStatuses(ctx context.Context) error
 }
 
 type SensorService interface {
 	Service
-	GetAll(ctx context.Context, query domain.Query) ([]*domain.Sensor, int64, error)
+	GetAll(ctx context.Context, query domain.SensorQuery) ([]*domain.Sensor, int64, error)
 	GetByID(ctx context.Context, id string) (*domain.Sensor, error)
 	Create(ctx context.Context, createData *domain.SensorCreate) (*domain.Sensor, error)
 	Update(ctx context.Context, id string, updateData *domain.SensorUpdate) (*domain.Sensor, error)
@@ -216,27 +300,261 @@ type PluginService interface {
 	Register(ctx context.Context, plugin *domain.Plugin) (*domain.ClientToken, error)
 	RefreshToken(ctx context.Context, auth *domain.AuthPlugin, slug string) (*domain.ClientToken, error)
 	Get(ctx context.Context, slug string) (domain.Plugin, error)
-	GetAll(ctx context.Context) ([]domain.Plugin, []time.Time)
+	GetAll(ctx context.Context) ([]domain.Plugin, error)
+	GetHistory(ctx context.Context, slug string) ([]*domain.PluginEvent, error)
 	HeartBeat(ctx context.Context, slug string) error
-	Unregister(ctx context.Context, slug string)
+	Unregister(ctx context.Context, slug string) error
+	GetAllGrants(ctx context.Context) ([]*domain.PluginGrant, error)
+	SaveGrant(ctx context.Context, grant *domain.PluginGrant) (*domain.PluginGrant, error)
+	DeleteGrant(ctx context.Context, slug string) error
+	// CheckScope returns an error if the client is a plugin that is not allowed to use the scope.
+	// Clients that are not bound to a plugin are not restricted.
+	CheckScope(ctx context.Context, clientID string, scope domain.PluginScope) error
+	// CheckClient returns ErrPluginNotGranted if the plugin of the slug is not granted for the client
+	CheckClient(ctx context.Context, clientID, slug string) error
+	// HeartbeatTimeout is the duration after which a plugin without heartbeat is considered dead and cleaned up
+	HeartbeatTimeout() time.Duration
 	StartCleanup(ctx context.Context)
 }
 
+type WebhookService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.Webhook, error)
+	GetByID(ctx context.Context, id int32) (*domain.Webhook, error)
+	Create(ctx context.Context, createData *domain.WebhookCreate) (*domain.Webhook, error)
+	Update(ctx context.Context, id int32, updateData *domain.WebhookUpdate) (*domain.Webhook, error)
+	Delete(ctx context.Context, id int32) error
+	GetDeliveries(ctx context.Context, id int32) ([]*domain.WebhookDelivery, error)
+
+	// HandleEvent queues a delivery of the event for every enabled webhook that subscribed the event type
+	HandleEvent(ctx context.Context, event domain.Event) error
+	// DeliverDue sends all queued deliveries that are due and reschedules failed attempts
+	DeliverDue(ctx context.Context) error
+}
+
 type Service interface {
 	Ready() bool
 }
 
+type APIKeyService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.APIKey, error)
+	GetByID(ctx context.Context, id int32) (*domain.APIKey, error)
+	// Create creates a new api key and returns it together with the plain key. The plain key can not be retrieved again.
+	Create(ctx context.Context, createData *domain.APIKeyCreate) (*domain.APIKey, string, error)
+	Update(ctx context.Context, id int32, updateData *domain.APIKeyUpdate) (*domain.APIKey, error)
+	// Revoke disables the api key permanently but keeps it for auditing
+	Revoke(ctx context.Context, id int32) (*domain.APIKey, error)
+	Delete(ctx context.Context, id int32) error
+
+	// Authenticate returns the active api key matching the plain key and records its usage
+	Authenticate(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+type TreeImportService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.TreeImportJob, error)
+	GetByID(ctx context.Context, id int32) (*domain.TreeImportJob, error)
+	// Create stores the uploaded file and queues the import job for the dry run validation
+	Create(ctx context.Context, createData *domain.TreeImportCreate) (*domain.TreeImportJob, error)
+	// Commit queues a validated import job for the import of its trees
+	Commit(ctx context.Context, id int32) (*domain.TreeImportJob, error)
+	Delete(ctx context.Context, id int32) error
+
+	// ProcessPending validates all pending import jobs and imports all queued import jobs
+	ProcessPending(ctx context.Context) error
+}
+
+// ExportService writes entities to a file format used by gis tools. All methods read
+// the entities page by page and write them to w, so exports never have to fit into memory.
+type ExportService interface {
+	Service
+	ExportTrees(ctx context.Context, w io.Writer, format domain.ExportFormat, query domain.TreeQuery) error
+	ExportTreeClusters(ctx context.Context, w io.Writer, format domain.ExportFormat, query domain.TreeClusterQuery) error
+	ExportWateringPlans(ctx context.Context, w io.Writer, format domain.ExportFormat, query domain.Query) error
+}
+
+// OGCService serves trees, tree clusters, sensors and regions as collections of an OGC API Features service
+type OGCService interface {
+	Service
+	GetCollections(ctx context.Context) ([]*domain.OGCCollection, error)
+	GetCollection(ctx context.Context, id domain.OGCCollectionID) (*domain.OGCCollection, error)
+	// GetFeatures returns the features of a collection matching the query and the number of all matching features.
+	// The property filters must be queryables of the collection.
+	GetFeatures(ctx context.Context, id domain.OGCCollectionID, query *domain.OGCFeatureQuery) ([]*domain.OGCFeature, int64, error)
+	GetFeature(ctx context.Context, id domain.OGCCollectionID, featureID string) (*domain.OGCFeature, error)
+}
+
+// TileService renders trees, tree clusters, sensors and regions as Mapbox Vector Tiles
+type TileService interface {
+	Service
+	// GetTile returns the tile of a layer, the tile is empty if the layer is not shown at the zoom level
+	GetTile(ctx context.Context, layer domain.OGCCollectionID, coord domain.TileCoordinate) ([]byte, error)
+}
+
+// AuditLogService reads the change history of trees, tree clusters, vehicles and watering plans
+type AuditLogService interface {
+	Service
+	// GetAll returns the audit log entries of all entities matching the query, newest first, and the number of all matching entries
+	GetAll(ctx context.Context, query *domain.AuditLogQuery) ([]*domain.AuditLog, int64, error)
+	// GetHistory returns the audit log entries of one entity, newest first. The history is kept after the entity is deleted.
+	GetHistory(ctx context.Context, entityType domain.AuditEntityType, id int32) ([]*domain.AuditLog, int64, error)
+}
+
+// EventStreamService forwards the domain events to the clients connected to this instance
+type EventStreamService interface {
+	Service
+	// HandleEvent encodes the event and sends it to all clients whose query matches the event
+	HandleEvent(ctx context.Context, event domain.Event) error
+	// Subscribe returns the events matching the query until the context is canceled. If lastEventID is set, the missed events
+	// after it are read from the outbox and sent first or a reset event if they can not be resumed. The channel is closed when
+	// the context is canceled or the client does not read the events fast enough.
+	Subscribe(ctx context.Context, query *domain.EventStreamQuery, lastEventID string) (<-chan *domain.StreamEvent, error)
+}
+
+// JobService runs the registered jobs by the schedules of the configuration. Only the instance holding the lock
+// of the scheduler runs jobs, every run is recorded with its duration and error.
+type JobService interface {
+	Service
+	// Register adds a job under its configured name. It returns ErrJobScheduleInvalid if the schedule of the job
+	// can not be parsed and must be called before Run.
+	Register(name string, work func(ctx context.Context) error) error
+	GetAll(ctx context.Context) ([]*domain.Job, error)
+	GetByName(ctx context.Context, name string) (*domain.Job, error)
+	// GetRuns returns the runs of the job, newest first, and the number of all runs of the job
+	GetRuns(ctx context.Context, name string) ([]*domain.JobRun, int64, error)
+	// Trigger queues a manual run of the job. The run is started by the leader instance within the poll interval.
+	Trigger(ctx context.Context, name string) (*domain.JobRun, error)
+	// Run tries to become the leader instance and runs the due jobs and the manual runs while it holds the lock.
+	// This is a blocking method and should be run in a separate goroutine.
+	Run(ctx context.Context)
+}
+
+// WeatherService fetches the daily weather of the regions and estimates the soil water balance of the tree
+// clusters from the precipitation and the reference evapotranspiration ET0
+type WeatherService interface {
+	Service
+	// Update fetches the weather of all regions, computes the water balance of the tree clusters and sets the
+	// estimated watering status of the tree clusters without sensor data
+	Update(ctx context.Context) error
+	// GetByRegion returns the stored weather days of the region, oldest first
+	GetByRegion(ctx context.Context, regionID int32) ([]*domain.WeatherDay, error)
+	// GetWaterBalance returns the estimated water balance of the tree cluster with the forecast of when it turns bad
+	GetWaterBalance(ctx context.Context, treeClusterID int32) (*domain.TreeClusterWaterBalance, error)
+}
+
+// PlannerService proposes watering plans for the upcoming days. The tree clusters are ranked by urgency and
+// assigned to the available transporters and crews, the routes are checked by the route optimizer.
+type PlannerService interface {
+	Service
+	// GetProposals returns draft watering plans for the upcoming days ordered by day. Tree clusters,
+	// vehicles and users of planned or active watering plans are left out.
+	GetProposals(ctx context.Context, query domain.WateringPlanProposalQuery) ([]*domain.WateringPlanProposal, error)
+	// AcceptProposals creates the watering plans of the accepted proposals. If one can not be created, the watering plans created
+	// before are returned together with the error.
+	AcceptProposals(ctx context.Context, plans []*domain.WateringPlanCreate) ([]*domain.WateringPlan, error)
+}
+
+// WateringPlanTemplateService manages the recurring watering plan templates. The watering plans of a template are
+// created ahead of time, every created watering plan can be edited or canceled like any other watering plan.
+type WateringPlanTemplateService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.WateringPlanTemplate, error)
+	GetByID(ctx context.Context, id int32) (*domain.WateringPlanTemplate, error)
+	Create(ctx context.Context, createData *domain.WateringPlanTemplateCreate) (*domain.WateringPlanTemplate, error)
+	// Update changes the template, the changes apply to the watering plans that are not created yet
+	Update(ctx context.Context, id int32, updateData *domain.WateringPlanTemplateUpdate) (*domain.WateringPlanTemplate, error)
+	Delete(ctx context.Context, id int32) error
+	// GetOccurrences returns the dates of the template that were created or skipped as holiday, oldest first
+	GetOccurrences(ctx context.Context, id int32) ([]*domain.WateringPlanTemplateOccurrence, error)
+	// Materialize creates the watering plans of all enabled templates within the lookahead. Dates that were
+	// already created once are not created again, even if the watering plan was moved or deleted.
+	Materialize(ctx context.Context) error
+}
+
+// ReportService renders printable reports and stores them in the report bucket. The reports are downloaded
+// with a signed link that does not need authentication, so it can be opened by a printer or shared with a crew.
+type ReportService interface {
+	Service
+	// CreateWateringPlanReport renders the route sheet of a watering plan with stop list, route map and signature fields
+	CreateWateringPlanReport(ctx context.Context, id int32, format domain.ReportFormat) (*domain.Report, error)
+	// CreateSeasonReport renders the evaluation of the watering plans of a year per month and region
+	CreateSeasonReport(ctx context.Context, year int, format domain.ReportFormat) (*domain.Report, error)
+	// Download checks the signature and expiry of a download link and returns the stored report
+	Download(ctx context.Context, name string, expires int64, signature string) (io.ReadSeekCloser, error)
+}
+
+// NotificationService notifies the users about tree clusters that need water, sensors that went offline and
+// their assignment to watering plans. The notifications are shown in the inbox of the user and sent by email
+// and push as configured in the settings of the user.
+type NotificationService interface {
+	Service
+	// GetAll returns the notifications in the inbox of the user, newest first, and the number of all matching notifications
+	GetAll(ctx context.Context, userID uuid.UUID, query domain.NotificationQuery) ([]*domain.Notification, int64, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int64, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, id int32) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+	// GetSettings returns the settings of the user or the default settings if the user has not saved any
+	GetSettings(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error)
+	UpdateSettings(ctx context.Context, userID uuid.UUID, updateData *domain.NotificationSettingsUpdate) (*domain.NotificationSettings, error)
+	// GetPushPublicKey returns the VAPID public key the browsers need to subscribe to push messages
+	GetPushPublicKey(ctx context.Context) (string, error)
+	// SubscribePush stores the push subscription of a browser, an existing subscription of the endpoint is replaced
+	SubscribePush(ctx context.Context, userID uuid.UUID, createData *domain.PushSubscriptionCreate) (*domain.PushSubscription, error)
+	UnsubscribePush(ctx context.Context, userID uuid.UUID, endpoint string) error
+
+	// HandleEvent creates the notifications of a domain event for all users that enabled its type
+	HandleEvent(ctx context.Context, event domain.Event) error
+	// DeliverDue sends the due email and push deliveries bundled per user and reschedules failed attempts
+	DeliverDue(ctx context.Context) error
+}
+
+// AlertService manages the user defined alert rules and the alerts they raise. The rules are evaluated by a
+// scheduled job, an alert fires once its condition held for the duration of the rule and is resolved as soon
+// as the condition no longer holds.
+type AlertService interface {
+	Service
+	GetAllRules(ctx context.Context) ([]*domain.AlertRule, error)
+	GetRuleByID(ctx context.Context, id int32) (*domain.AlertRule, error)
+	CreateRule(ctx context.Context, createData *domain.AlertRuleCreate) (*domain.AlertRule, error)
+	UpdateRule(ctx context.Context, id int32, updateData *domain.AlertRuleUpdate) (*domain.AlertRule, error)
+	// DeleteRule deletes the rule together with its alerts
+	DeleteRule(ctx context.Context, id int32) error
+	// GetAll returns the alerts matching the query, newest first, and the number of all matching alerts
+	GetAll(ctx context.Context, query domain.AlertQuery) ([]*domain.Alert, int64, error)
+	GetByID(ctx context.Context, id int32) (*domain.Alert, error)
+	// Acknowledge marks the alert as seen by the user or api key of the request
+	Acknowledge(ctx context.Context, id int32) (*domain.Alert, error)
+	// Evaluate checks all rules against the tree clusters, sensors and watering plans and updates their alerts
+	Evaluate(ctx context.Context) error
+}
+
 type Services struct {
-	InfoService         InfoService
-	TreeService         TreeService
-	AuthService         AuthService
-	RegionService       RegionService
-	TreeClusterService  TreeClusterService
-	SensorService       SensorService
-	VehicleService      VehicleService
-	PluginService       PluginService
-	WateringPlanService WateringPlanService
-	EvaluationService   EvaluationService
+	InfoService                 InfoService
+	TreeService                 TreeService
+	AuthService                 AuthService
+	RegionService               RegionService
+	TreeClusterService          TreeClusterService
+	SensorService               SensorService
+	VehicleService              VehicleService
+	PluginService               PluginService
+	WateringPlanService         WateringPlanService
+	EvaluationService           EvaluationService
+	WebhookService              WebhookService
+	APIKeyService               APIKeyService
+	TreeImportService           TreeImportService
+	ExportService               ExportService
+	OGCService                  OGCService
+	TileService                 TileService
+	AuditLogService             AuditLogService
+	EventStreamService          EventStreamService
+	JobService                  JobService
+	WeatherService              WeatherService
+	PlannerService              PlannerService
+	WateringPlanTemplateService WateringPlanTemplateService
+	ReportService               ReportService
+	NotificationService         NotificationService
+	AlertService                AlertService
 }
 
 type ServicesInterface interface {
@@ -20,17 +20,47 @@ func TestAllServiceReady(t *testing.T) {
 		pluginSvc := serviceMock.NewMockPluginService(t)
 		wateringPlanSvc := serviceMock.NewMockWateringPlanService(t)
 		evaluationSvc := serviceMock.NewMockEvaluationService(t)
+		webhookSvc := serviceMock.NewMockWebhookService(t)
+		apiKeySvc := serviceMock.NewMockAPIKeyService(t)
+		treeImportSvc := serviceMock.NewMockTreeImportService(t)
+		exportSvc := serviceMock.NewMockExportService(t)
+		ogcSvc := serviceMock.NewMockOGCService(t)
+		tileSvc := serviceMock.NewMockTileService(t)
+		auditLogSvc := serviceMock.NewMockAuditLogService(t)
+		eventStreamSvc := serviceMock.NewMockEventStreamService(t)
+		jobSvc := serviceMock.NewMockJobService(t)
+		weatherSvc := serviceMock.NewMockWeatherService(t)
+		plannerSvc := serviceMock.NewMockPlannerService(t)
+		wateringPlanTemplateSvc := serviceMock.NewMockWateringPlanTemplateService(t)
+		reportSvc := serviceMock.NewMockReportService(t)
+		notificationSvc := serviceMock.NewMockNotificationService(t)
+		alertSvc := serviceMock.NewMockAlertService(t)
 		svc := Services{
-			InfoService:         infoSvc,
-			TreeService:         treeSvc,
-			AuthService:         authSvc,
-			RegionService:       regionSvc,
-			TreeClusterService:  treeClusterSvc,
-			SensorService:       sensorSvc,
-			VehicleService:      vehicleSvc,
-			PluginService:       pluginSvc,
-			WateringPlanService: wateringPlanSvc,
-			EvaluationService:   evaluationSvc,
+			InfoService:                 infoSvc,
+			TreeService:                 treeSvc,
+			AuthService:                 authSvc,
+			RegionService:               regionSvc,
+			TreeClusterService:          treeClusterSvc,
+			SensorService:               sensorSvc,
+			VehicleService:              vehicleSvc,
+			PluginService:               pluginSvc,
+			WateringPlanService:         wateringPlanSvc,
+			EvaluationService:           evaluationSvc,
+			WebhookService:              webhookSvc,
+			APIKeyService:               apiKeySvc,
+			TreeImportService:           treeImportSvc,
+			ExportService:               exportSvc,
+			OGCService:                  ogcSvc,
+			TileService:                 tileSvc,
+			AuditLogService:             auditLogSvc,
+			EventStreamService:          eventStreamSvc,
+			JobService:                  jobSvc,
+			WeatherService:              weatherSvc,
+			PlannerService:              plannerSvc,
+			WateringPlanTemplateService: wateringPlanTemplateSvc,
+			ReportService:               reportSvc,
+			NotificationService:         notificationSvc,
+			AlertService:                alertSvc,
 		}
 
 		// when
@@ -44,6 +74,21 @@ func TestAllServiceReady(t *testing.T) {
 		pluginSvc.EXPECT().Ready().Return(true)
 		wateringPlanSvc.EXPECT().Ready().Return(true)
 		evaluationSvc.EXPECT().Ready().Return(true)
+		webhookSvc.EXPECT().Ready().Return(true)
+		apiKeySvc.EXPECT().Ready().Return(true)
+		treeImportSvc.EXPECT().Ready().Return(true)
+		exportSvc.EXPECT().Ready().Return(true)
+		ogcSvc.EXPECT().Ready().Return(true)
+		tileSvc.EXPECT().Ready().Return(true)
+		auditLogSvc.EXPECT().Ready().Return(true)
+		eventStreamSvc.EXPECT().Ready().Return(true)
+		jobSvc.EXPECT().Ready().Return(true)
+		weatherSvc.EXPECT().Ready().Return(true)
+		plannerSvc.EXPECT().Ready().Return(true)
+		wateringPlanTemplateSvc.EXPECT().Ready().Return(true)
+		reportSvc.EXPECT().Ready().Return(true)
+		notificationSvc.EXPECT().Ready().Return(true)
+		alertSvc.EXPECT().Ready().Return(true)
 
 		ready := svc.AllServicesReady()
 
@@ -0,0 +1,19 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// DummyMailSender is used to disable the emails by configuration
+type DummyMailSender struct{}
+
+func NewDummyMailSender() *DummyMailSender {
+	return &DummyMailSender{}
+}
+
+func (s *DummyMailSender) Send(_ context.Context, _ *entities.Mail) error {
+	return storage.ErrMailServiceDisabled
+}
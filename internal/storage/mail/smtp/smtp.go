@@ -0,0 +1,137 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+var _ storage.MailSender = (*SMTPSender)(nil)
+
+type SMTPSenderConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	TLS      bool
+}
+
+// SMTPSender sends plain text emails by SMTP. With TLS enabled the connection is upgraded by STARTTLS,
+// otherwise the mails are sent unencrypted, e.g. to a local relay.
+type SMTPSender struct {
+	cfg  SMTPSenderConfig
+	from *mail.Address
+	now  func() time.Time
+}
+
+func NewSMTPSender(cfg SMTPSenderConfig) (*SMTPSender, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	return &SMTPSender{
+		cfg:  cfg,
+		from: from,
+		now:  time.Now,
+	}, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, m *entities.Mail) error {
+	log := logger.GetLogger(ctx)
+	if len(m.To) == 0 {
+		return errors.New("mail has no recipients")
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		log.Error("failed to connect to smtp server", "error", err, "addr", addr)
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		log.Error("failed to start smtp session", "error", err, "addr", addr)
+		return err
+	}
+	defer client.Close()
+
+	if err := s.send(client, m); err != nil {
+		log.Error("failed to send mail", "error", err, "addr", addr, "recipients", len(m.To))
+		return err
+	}
+
+	log.Debug("mail sent successfully", "recipients", len(m.To))
+	return client.Quit()
+}
+
+func (s *SMTPSender) send(client *smtp.Client, m *entities.Mail) error {
+	if s.cfg.TLS {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+	for _, to := range m.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.message(m)); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// message builds the mail with its headers, the body is sent as UTF-8 text with CRLF line endings
+func (s *SMTPSender) message(m *entities.Mail) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+
+	body := strings.ReplaceAll(m.Body, "\r\n", "\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
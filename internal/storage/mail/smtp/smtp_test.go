@@ -0,0 +1,56 @@
+package smtp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSMTPSender(t *testing.T) {
+	t.Run("should parse sender address", func(t *testing.T) {
+		// when
+		sender, err := NewSMTPSender(SMTPSenderConfig{From: "Green Ecolution <notifications@example.com>"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "notifications@example.com", sender.from.Address)
+	})
+
+	t.Run("should return error for invalid sender address", func(t *testing.T) {
+		// when
+		sender, err := NewSMTPSender(SMTPSenderConfig{From: "not an address"})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, sender)
+	})
+}
+
+func TestSMTPSender_message(t *testing.T) {
+	t.Run("should build message with headers and crlf line endings", func(t *testing.T) {
+		// given
+		sender, err := NewSMTPSender(SMTPSenderConfig{From: "notifications@example.com"})
+		assert.NoError(t, err)
+		sender.now = func() time.Time { return time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC) }
+
+		// when
+		got := string(sender.message(&entities.Mail{
+			To:      []string{"a@example.com", "b@example.com"},
+			Subject: "Bewässerung geplant",
+			Body:    "Line 1\nLine 2",
+		}))
+
+		// then
+		header, body, found := strings.Cut(got, "\r\n\r\n")
+		assert.True(t, found)
+		assert.Contains(t, header, "From: <notifications@example.com>")
+		assert.Contains(t, header, "To: a@example.com, b@example.com")
+		assert.Contains(t, header, "Subject: =?utf-8?q?Bew=C3=A4sserung_geplant?=")
+		assert.Contains(t, header, "Date: Wed, 02 Apr 2025 09:00:00 +0000")
+		assert.Contains(t, header, "Content-Type: text/plain; charset=utf-8")
+		assert.Equal(t, "Line 1\r\nLine 2\r\n", body)
+	})
+}
@@ -0,0 +1,29 @@
+package smtp
+
+import (
+	"log/slog"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+func NewRepository(cfg *config.Config) (*storage.Repository, error) {
+	smtpCfg := cfg.Notification.SMTP
+	sender, err := NewSMTPSender(SMTPSenderConfig{
+		Host:     smtpCfg.Host,
+		Port:     smtpCfg.Port,
+		Username: smtpCfg.Username,
+		Password: smtpCfg.Password,
+		From:     smtpCfg.From,
+		TLS:      smtpCfg.TLS,
+	})
+	if err != nil {
+		slog.Error("failed to setup mail sender", "error", err, "service", "smtp")
+		return nil, err
+	}
+
+	slog.Info("successfully initialized mail sender", "service", "smtp")
+	return &storage.Repository{
+		MailSender: sender,
+	}, nil
+}
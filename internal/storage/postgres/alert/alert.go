@@ -0,0 +1,182 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+var _ storage.AlertRepository = (*AlertRepository)(nil)
+
+type AlertRepository struct {
+	store *store.Store
+	AlertRepositoryMappers
+}
+
+type AlertRepositoryMappers struct {
+	mapper mapper.InternalAlertRepoMapper
+}
+
+func NewAlertRepositoryMappers(aMapper mapper.InternalAlertRepoMapper) AlertRepositoryMappers {
+	return AlertRepositoryMappers{
+		mapper: aMapper,
+	}
+}
+
+func NewAlertRepository(s *store.Store, mappers AlertRepositoryMappers) *AlertRepository {
+	return &AlertRepository{
+		store:                  s,
+		AlertRepositoryMappers: mappers,
+	}
+}
+
+func (r *AlertRepository) GetAll(ctx context.Context, query entities.AlertQuery) ([]*entities.Alert, int64, error) {
+	log := logger.GetLogger(ctx)
+	page, limit, err := pagination.GetValues(ctx)
+	if err != nil {
+		return nil, 0, r.store.MapError(err, sqlc.Alert{})
+	}
+
+	statuses := utils.Map(query.Statuses, func(s entities.AlertStatus) string { return string(s) })
+	totalCount, err := r.store.GetAllAlertsCount(ctx, &sqlc.GetAllAlertsCountParams{
+		Statuses:       statuses,
+		RuleID:         query.RuleID,
+		Unacknowledged: query.Unacknowledged,
+	})
+	if err != nil {
+		log.Debug("failed to get total alert count in db", "error", err)
+		return nil, 0, r.store.MapError(err, sqlc.Alert{})
+	}
+
+	if totalCount == 0 {
+		return []*entities.Alert{}, 0, nil
+	}
+
+	if limit == -1 {
+		limit = int32(totalCount)
+		page = 1
+	}
+
+	rows, err := r.store.GetAllAlerts(ctx, &sqlc.GetAllAlertsParams{
+		Statuses:       statuses,
+		RuleID:         query.RuleID,
+		Unacknowledged: query.Unacknowledged,
+		Limit:          limit,
+		Offset:         (page - 1) * limit,
+	})
+	if err != nil {
+		log.Debug("failed to get alerts in db", "error", err)
+		return nil, 0, r.store.MapError(err, sqlc.Alert{})
+	}
+
+	return r.mapper.FromSqlList(rows), totalCount, nil
+}
+
+func (r *AlertRepository) GetByID(ctx context.Context, id int32) (*entities.Alert, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetAlertByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get alert by id in db", "error", err, "alert_id", id)
+		return nil, r.store.MapError(err, sqlc.Alert{})
+	}
+
+	return r.mapper.FromSql(row), nil
+}
+
+func (r *AlertRepository) GetOpenByRuleID(ctx context.Context, ruleID int32) ([]*entities.Alert, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetOpenAlertsByRuleID(ctx, ruleID)
+	if err != nil {
+		log.Debug("failed to get open alerts of rule in db", "error", err, "rule_id", ruleID)
+		return nil, r.store.MapError(err, sqlc.Alert{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *AlertRepository) Create(ctx context.Context, alert *entities.Alert) (*entities.Alert, error) {
+	log := logger.GetLogger(ctx)
+	// the columns have no time zone, so the times of the alerts are stored in utc
+	createdAt := alert.CreatedAt.UTC()
+	id, err := r.store.CreateAlert(ctx, &sqlc.CreateAlertParams{
+		CreatedAt:   utils.TimeToPgTimestamp(&createdAt),
+		RuleID:      alert.RuleID,
+		SubjectType: string(alert.SubjectType),
+		SubjectID:   alert.SubjectID,
+		SubjectName: alert.SubjectName,
+		Status:      sqlc.AlertStatus(alert.Status),
+		Message:     alert.Message,
+		Value:       alert.Value,
+		FiredAt:     utils.TimeToPgTimestamp(toUTC(alert.FiredAt)),
+	})
+	if err != nil {
+		log.Error("failed to create alert in db", "error", err, "rule_id", alert.RuleID, "subject_id", alert.SubjectID)
+		return nil, r.store.MapError(err, sqlc.Alert{})
+	}
+
+	log.Debug("alert created successfully in db", "alert_id", id, "rule_id", alert.RuleID)
+	return r.GetByID(ctx, id)
+}
+
+func (r *AlertRepository) Update(ctx context.Context, alert *entities.Alert) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.UpdateAlert(ctx, &sqlc.UpdateAlertParams{
+		ID:          alert.ID,
+		SubjectName: alert.SubjectName,
+		Status:      sqlc.AlertStatus(alert.Status),
+		Message:     alert.Message,
+		Value:       alert.Value,
+		FiredAt:     utils.TimeToPgTimestamp(toUTC(alert.FiredAt)),
+		ResolvedAt:  utils.TimeToPgTimestamp(toUTC(alert.ResolvedAt)),
+	})
+	if err != nil {
+		log.Error("failed to update alert in db", "error", err, "alert_id", alert.ID)
+		return r.store.MapError(err, sqlc.Alert{})
+	}
+
+	log.Debug("alert updated successfully in db", "alert_id", alert.ID, "status", alert.Status)
+	return nil
+}
+
+func (r *AlertRepository) Acknowledge(ctx context.Context, id int32, acknowledgedBy string, acknowledgedAt time.Time) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.AcknowledgeAlert(ctx, &sqlc.AcknowledgeAlertParams{
+		ID:             id,
+		AcknowledgedAt: utils.TimeToPgTimestamp(toUTC(&acknowledgedAt)),
+		AcknowledgedBy: &acknowledgedBy,
+	})
+	if err != nil {
+		log.Debug("failed to acknowledge alert in db", "error", err, "alert_id", id)
+		return r.store.MapError(err, sqlc.Alert{})
+	}
+
+	log.Debug("alert acknowledged successfully in db", "alert_id", id)
+	return nil
+}
+
+func (r *AlertRepository) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	if err := r.store.DeleteAlert(ctx, id); err != nil {
+		log.Error("failed to delete alert in db", "error", err, "alert_id", id)
+		return r.store.MapError(err, sqlc.Alert{})
+	}
+
+	log.Debug("alert deleted successfully in db", "alert_id", id)
+	return nil
+}
+
+func toUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,235 @@
+package alert
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultAlertMappers() AlertRepositoryMappers {
+	return NewAlertRepositoryMappers(&generated.InternalAlertRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func createRule(t *testing.T, r *AlertRepository) *entities.AlertRule {
+	t.Helper()
+	got, err := r.CreateRule(context.Background(), func(rule *entities.AlertRule, _ storage.AlertRepository) (bool, error) {
+		rule.Name = "Bad tree clusters in Mürwik"
+		rule.Type = entities.AlertRuleTypeTreeClusterStatus
+		rule.RegionIDs = []int32{1}
+		rule.WateringStatuses = []entities.WateringStatus{entities.WateringStatusBad, entities.WateringStatusJustWatered}
+		rule.For = 48 * time.Hour
+		return true, nil
+	})
+	assert.NoError(t, err)
+	return got
+}
+
+func createAlert(t *testing.T, r *AlertRepository, ruleID int32, subjectID string, status entities.AlertStatus) *entities.Alert {
+	t.Helper()
+	got, err := r.Create(context.Background(), &entities.Alert{
+		CreatedAt:   time.Now(),
+		RuleID:      ruleID,
+		SubjectType: entities.AlertSubjectTypeTreeCluster,
+		SubjectID:   subjectID,
+		SubjectName: "Cluster " + subjectID,
+		Status:      status,
+		Message:     "The watering status of the tree cluster is bad.",
+	})
+	assert.NoError(t, err)
+	return got
+}
+
+func TestAlertRepository_Rules(t *testing.T) {
+	t.Run("should create rule with defaults", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+
+		// when
+		got := createRule(t, r)
+
+		// then
+		assert.NotZero(t, got.ID)
+		assert.Equal(t, entities.AlertSeverityWarning, got.Severity)
+		assert.Equal(t, []int32{1}, got.RegionIDs)
+		assert.Equal(t, []entities.WateringStatus{entities.WateringStatusBad, entities.WateringStatusJustWatered}, got.WateringStatuses)
+		assert.Equal(t, 48*time.Hour, got.For)
+		assert.Nil(t, got.Threshold)
+		assert.True(t, got.Notify)
+		assert.True(t, got.Enabled)
+	})
+
+	t.Run("should update rule", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+		created := createRule(t, r)
+
+		// when
+		err := r.UpdateRule(context.Background(), created.ID, func(rule *entities.AlertRule, _ storage.AlertRepository) (bool, error) {
+			rule.Type = entities.AlertRuleTypeSensorBattery
+			rule.Threshold = utils.P(3.2)
+			rule.RegionIDs = nil
+			rule.WateringStatuses = nil
+			rule.Enabled = false
+			return true, nil
+		})
+		got, getErr := r.GetRuleByID(context.Background(), created.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, entities.AlertRuleTypeSensorBattery, got.Type)
+		assert.Equal(t, utils.P(3.2), got.Threshold)
+		assert.Empty(t, got.RegionIDs)
+		assert.False(t, got.Enabled)
+	})
+
+	t.Run("should return error when rule not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+
+		// when
+		got, err := r.GetRuleByID(context.Background(), 99)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+
+	t.Run("should delete rule together with its alerts", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+		rule := createRule(t, r)
+		alert := createAlert(t, r, rule.ID, "1", entities.AlertStatusFiring)
+
+		// when
+		err := r.DeleteRule(context.Background(), rule.ID)
+		got, getErr := r.GetByID(context.Background(), alert.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+		assert.Error(t, getErr)
+	})
+}
+
+func TestAlertRepository_Alerts(t *testing.T) {
+	t.Run("should return open alerts of rule", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+		rule := createRule(t, r)
+		pending := createAlert(t, r, rule.ID, "1", entities.AlertStatusPending)
+		resolved := createAlert(t, r, rule.ID, "2", entities.AlertStatusFiring)
+		resolved.Status = entities.AlertStatusResolved
+		resolved.ResolvedAt = utils.P(time.Now())
+		assert.NoError(t, r.Update(context.Background(), resolved))
+
+		// when
+		got, err := r.GetOpenByRuleID(context.Background(), rule.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, pending.ID, got[0].ID)
+	})
+
+	t.Run("should not create a second open alert for the same subject", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+		rule := createRule(t, r)
+		createAlert(t, r, rule.ID, "1", entities.AlertStatusPending)
+
+		// when
+		got, err := r.Create(context.Background(), &entities.Alert{
+			CreatedAt:   time.Now(),
+			RuleID:      rule.ID,
+			SubjectType: entities.AlertSubjectTypeTreeCluster,
+			SubjectID:   "1",
+			Status:      entities.AlertStatusFiring,
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+
+	t.Run("should filter alerts", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+		rule := createRule(t, r)
+		createAlert(t, r, rule.ID, "1", entities.AlertStatusPending)
+		firing := createAlert(t, r, rule.ID, "2", entities.AlertStatusFiring)
+		acknowledged := createAlert(t, r, rule.ID, "3", entities.AlertStatusFiring)
+		assert.NoError(t, r.Acknowledge(context.Background(), acknowledged.ID, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab", time.Now()))
+
+		// when
+		got, totalCount, err := r.GetAll(context.Background(), entities.AlertQuery{
+			Statuses:       []entities.AlertStatus{entities.AlertStatusFiring},
+			RuleID:         &rule.ID,
+			Unacknowledged: true,
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totalCount)
+		assert.Len(t, got, 1)
+		assert.Equal(t, firing.ID, got[0].ID)
+	})
+
+	t.Run("should keep the first acknowledgement", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+		rule := createRule(t, r)
+		alert := createAlert(t, r, rule.ID, "1", entities.AlertStatusFiring)
+		assert.NoError(t, r.Acknowledge(context.Background(), alert.ID, "first", time.Now()))
+
+		// when
+		err := r.Acknowledge(context.Background(), alert.ID, "second", time.Now())
+		got, getErr := r.GetByID(context.Background(), alert.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, utils.P("first"), got.AcknowledgedBy)
+		assert.NotNil(t, got.AcknowledgedAt)
+	})
+
+	t.Run("should return error when acknowledging unknown alert", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAlertRepository(suite.Store, defaultAlertMappers())
+
+		// when
+		err := r.Acknowledge(context.Background(), 99, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab", time.Now())
+
+		// then
+		assert.Error(t, err)
+	})
+}
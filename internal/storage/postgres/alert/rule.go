@@ -0,0 +1,187 @@
+package alert
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func defaultAlertRule() *entities.AlertRule {
+	return &entities.AlertRule{
+		Severity:         entities.AlertSeverityWarning,
+		RegionIDs:        make([]int32, 0),
+		WateringStatuses: make([]entities.WateringStatus, 0),
+		Notify:           true,
+		Enabled:          true,
+	}
+}
+
+func (r *AlertRepository) GetAllRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllAlertRules(ctx)
+	if err != nil {
+		log.Debug("failed to get alert rules in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.AlertRule{})
+	}
+
+	return r.mapper.FromSqlRuleList(rows), nil
+}
+
+func (r *AlertRepository) GetRuleByID(ctx context.Context, id int32) (*entities.AlertRule, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetAlertRuleByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get alert rule by id in db", "error", err, "rule_id", id)
+		return nil, r.store.MapError(err, sqlc.AlertRule{})
+	}
+
+	return r.mapper.FromSqlRule(row), nil
+}
+
+func (r *AlertRepository) CreateRule(ctx context.Context, createFn func(*entities.AlertRule, storage.AlertRepository) (bool, error)) (*entities.AlertRule, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdRule *entities.AlertRule
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewAlertRepository(s, r.AlertRepositoryMappers)
+		entity := defaultAlertRule()
+		created, err := createFn(entity, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if err := validateAlertRule(entity); err != nil {
+			return err
+		}
+
+		id, err := s.CreateAlertRule(ctx, &sqlc.CreateAlertRuleParams{
+			Name:             entity.Name,
+			Description:      entity.Description,
+			Type:             string(entity.Type),
+			Severity:         string(entity.Severity),
+			RegionIds:        mapRegionIDs(entity.RegionIDs),
+			WateringStatuses: mapWateringStatuses(entity.WateringStatuses),
+			Threshold:        entity.Threshold,
+			WindowSeconds:    int64(entity.Window.Seconds()),
+			ForSeconds:       int64(entity.For.Seconds()),
+			Notify:           entity.Notify,
+			Enabled:          entity.Enabled,
+		})
+		if err != nil {
+			return err
+		}
+
+		createdRule, err = newRepo.GetRuleByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create alert rule entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdRule != nil {
+		log.Debug("alert rule entity created successfully in db", "rule_id", createdRule.ID)
+	}
+
+	return createdRule, nil
+}
+
+func (r *AlertRepository) UpdateRule(ctx context.Context, id int32, updateFn func(*entities.AlertRule, storage.AlertRepository) (bool, error)) error {
+	log := logger.GetLogger(ctx)
+	return r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewAlertRepository(s, r.AlertRepositoryMappers)
+		entity, err := newRepo.GetRuleByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if updateFn == nil {
+			return errors.New("updateFn is nil")
+		}
+
+		updated, err := updateFn(entity, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return nil
+		}
+
+		if err := validateAlertRule(entity); err != nil {
+			return err
+		}
+
+		err = s.UpdateAlertRule(ctx, &sqlc.UpdateAlertRuleParams{
+			ID:               entity.ID,
+			Name:             entity.Name,
+			Description:      entity.Description,
+			Type:             string(entity.Type),
+			Severity:         string(entity.Severity),
+			RegionIds:        mapRegionIDs(entity.RegionIDs),
+			WateringStatuses: mapWateringStatuses(entity.WateringStatuses),
+			Threshold:        entity.Threshold,
+			WindowSeconds:    int64(entity.Window.Seconds()),
+			ForSeconds:       int64(entity.For.Seconds()),
+			Notify:           entity.Notify,
+			Enabled:          entity.Enabled,
+		})
+		if err != nil {
+			log.Error("failed to update alert rule entity in db", "error", err, "rule_id", id)
+			return err
+		}
+
+		log.Debug("alert rule entity updated successfully in db", "rule_id", id)
+		return nil
+	})
+}
+
+func (r *AlertRepository) DeleteRule(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.DeleteAlertRule(ctx, id)
+	if err != nil {
+		log.Error("failed to delete alert rule entity in db", "error", err, "rule_id", id)
+		return r.store.MapError(err, sqlc.AlertRule{})
+	}
+
+	log.Debug("alert rule entity deleted successfully in db", "rule_id", id)
+	return nil
+}
+
+func validateAlertRule(entity *entities.AlertRule) error {
+	if entity.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if entity.Type == "" {
+		return errors.New("type is required")
+	}
+
+	return nil
+}
+
+func mapWateringStatuses(statuses []entities.WateringStatus) []string {
+	return utils.Map(statuses, func(s entities.WateringStatus) string { return string(s) })
+}
+
+// mapRegionIDs stores a rule without regions as empty array, nil would be stored as null
+func mapRegionIDs(ids []int32) []int32 {
+	if ids == nil {
+		return []int32{}
+	}
+	return ids
+}
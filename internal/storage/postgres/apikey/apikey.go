@@ -0,0 +1,63 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var _ storage.APIKeyRepository = (*APIKeyRepository)(nil)
+
+type APIKeyRepository struct {
+	store *store.Store
+	APIKeyRepositoryMappers
+}
+
+type APIKeyRepositoryMappers struct {
+	mapper mapper.InternalAPIKeyRepoMapper
+}
+
+func NewAPIKeyRepositoryMappers(kMapper mapper.InternalAPIKeyRepoMapper) APIKeyRepositoryMappers {
+	return APIKeyRepositoryMappers{
+		mapper: kMapper,
+	}
+}
+
+func NewAPIKeyRepository(s *store.Store, mappers APIKeyRepositoryMappers) *APIKeyRepository {
+	return &APIKeyRepository{
+		store:                   s,
+		APIKeyRepositoryMappers: mappers,
+	}
+}
+
+func (r *APIKeyRepository) UpdateLastUsed(ctx context.Context, id int32, lastUsed time.Time) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.UpdateAPIKeyLastUsed(ctx, &sqlc.UpdateAPIKeyLastUsedParams{
+		ID:         id,
+		LastUsedAt: utils.TimeToPgTimestamp(&lastUsed),
+	})
+	if err != nil {
+		log.Error("failed to update last used of api key in db", "error", err, "api_key_id", id)
+		return err
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.DeleteAPIKey(ctx, id)
+	if err != nil {
+		log.Error("failed to delete api key entity in db", "error", err, "api_key_id", id)
+		return r.store.MapError(err, sqlc.ApiKey{})
+	}
+
+	log.Debug("api key entity deleted successfully in db", "api_key_id", id)
+	return nil
+}
@@ -0,0 +1,176 @@
+package apikey
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultAPIKeyMappers() APIKeyRepositoryMappers {
+	return NewAPIKeyRepositoryMappers(&generated.InternalAPIKeyRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestAPIKeyRepository_Get(t *testing.T) {
+	t.Run("should return all api keys", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/apikey")
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, "Irrigation controller", got[0].Name)
+		assert.Equal(t, []entities.PluginScope{"tree:read", "sensor:read"}, got[0].Scopes)
+		assert.NotNil(t, got[0].ExpiresAt)
+		assert.NotNil(t, got[1].RevokedAt)
+	})
+
+	t.Run("should return api key by prefix", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/apikey")
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+
+		// when
+		got, err := r.GetByPrefix(context.Background(), "f5e4d3c2b1a0")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), got.ID)
+		assert.Equal(t, "Legacy importer", got.Name)
+	})
+
+	t.Run("should return error when api key not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+}
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	t.Run("should create api key", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(k *entities.APIKey, _ storage.APIKeyRepository) (bool, error) {
+			k.Name = "Weather station"
+			k.Prefix = "aabbccddeeff"
+			k.KeyHash = "hash"
+			k.Scopes = []entities.PluginScope{"sensor:write"}
+			k.CreatedBy = "6a1078e8-80fd-458f-b74e-e388fe2dd6ab"
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotZero(t, got.ID)
+		assert.Equal(t, "aabbccddeeff", got.Prefix)
+		assert.Equal(t, []entities.PluginScope{"sensor:write"}, got.Scopes)
+		assert.Nil(t, got.ExpiresAt)
+	})
+
+	t.Run("should return error when prefix is missing", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(k *entities.APIKey, _ storage.APIKeyRepository) (bool, error) {
+			k.Name = "Weather station"
+			k.KeyHash = "hash"
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestAPIKeyRepository_Update(t *testing.T) {
+	t.Run("should revoke api key", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/apikey")
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+		now := time.Now()
+
+		// when
+		err := r.Update(context.Background(), 1, func(k *entities.APIKey, _ storage.APIKeyRepository) (bool, error) {
+			k.RevokedAt = &now
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetByID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.NotNil(t, got.RevokedAt)
+		assert.Equal(t, "Irrigation controller", got.Name)
+	})
+
+	t.Run("should update last used", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/apikey")
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+
+		// when
+		err := r.UpdateLastUsed(context.Background(), 1, time.Now())
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetByID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.NotNil(t, got.LastUsedAt)
+	})
+}
+
+func TestAPIKeyRepository_Delete(t *testing.T) {
+	t.Run("should delete api key", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/apikey")
+		r := NewAPIKeyRepository(suite.Store, defaultAPIKeyMappers())
+
+		// when
+		err := r.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		_, err = r.GetByID(context.Background(), 1)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+}
@@ -0,0 +1,92 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func defaultAPIKey() *entities.APIKey {
+	return &entities.APIKey{
+		Name:      "",
+		Prefix:    "",
+		KeyHash:   "",
+		Scopes:    []entities.PluginScope{},
+		ExpiresAt: nil,
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, createFn func(*entities.APIKey, storage.APIKeyRepository) (bool, error)) (*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdKey *entities.APIKey
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewAPIKeyRepository(s, r.APIKeyRepositoryMappers)
+		entity := defaultAPIKey()
+		created, err := createFn(entity, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if err := validateAPIKey(entity); err != nil {
+			return err
+		}
+
+		id, err := s.CreateAPIKey(ctx, &sqlc.CreateAPIKeyParams{
+			Name:      entity.Name,
+			Prefix:    entity.Prefix,
+			KeyHash:   entity.KeyHash,
+			Scopes:    mapScopes(entity.Scopes),
+			CreatedBy: entity.CreatedBy,
+			ExpiresAt: utils.TimeToPgTimestamp(entity.ExpiresAt),
+		})
+		if err != nil {
+			return err
+		}
+
+		createdKey, err = newRepo.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create api key entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdKey != nil {
+		log.Debug("api key entity created successfully in db", "api_key_id", createdKey.ID)
+	}
+
+	return createdKey, nil
+}
+
+func validateAPIKey(entity *entities.APIKey) error {
+	if entity.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if entity.Prefix == "" || entity.KeyHash == "" {
+		return errors.New("prefix and key hash are required")
+	}
+
+	return nil
+}
+
+func mapScopes(scopes []entities.PluginScope) []string {
+	return utils.Map(scopes, func(s entities.PluginScope) string {
+		return string(s)
+	})
+}
@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *APIKeyRepository) GetAll(ctx context.Context) ([]*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllAPIKeys(ctx)
+	if err != nil {
+		log.Debug("failed to get api keys in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.ApiKey{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, id int32) (*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get api key by id in db", "error", err, "api_key_id", id)
+		return nil, r.store.MapError(err, sqlc.ApiKey{})
+	}
+
+	return r.mapper.FromSql(row), nil
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		log.Debug("failed to get api key by prefix in db", "error", err, "api_key_prefix", prefix)
+		return nil, r.store.MapError(err, sqlc.ApiKey{})
+	}
+
+	return r.mapper.FromSql(row), nil
+}
@@ -0,0 +1,56 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *APIKeyRepository) Update(ctx context.Context, id int32, updateFn func(*entities.APIKey, storage.APIKeyRepository) (bool, error)) error {
+	log := logger.GetLogger(ctx)
+	return r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewAPIKeyRepository(s, r.APIKeyRepositoryMappers)
+		key, err := newRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if updateFn == nil {
+			return errors.New("updateFn is nil")
+		}
+
+		updated, err := updateFn(key, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return nil
+		}
+
+		if err := validateAPIKey(key); err != nil {
+			return err
+		}
+
+		err = s.UpdateAPIKey(ctx, &sqlc.UpdateAPIKeyParams{
+			ID:        key.ID,
+			Name:      key.Name,
+			Scopes:    mapScopes(key.Scopes),
+			ExpiresAt: utils.TimeToPgTimestamp(key.ExpiresAt),
+			RevokedAt: utils.TimeToPgTimestamp(key.RevokedAt),
+		})
+		if err != nil {
+			log.Error("failed to update api key entity in db", "error", err, "api_key_id", id)
+			return err
+		}
+
+		log.Debug("api key entity updated successfully in db", "api_key_id", id)
+		return nil
+	})
+}
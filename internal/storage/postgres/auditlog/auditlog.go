@@ -0,0 +1,31 @@
+package auditlog
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+var _ storage.AuditLogRepository = (*AuditLogRepository)(nil)
+
+type AuditLogRepository struct {
+	store *store.Store
+	AuditLogRepositoryMappers
+}
+
+type AuditLogRepositoryMappers struct {
+	mapper mapper.InternalAuditLogRepoMapper
+}
+
+func NewAuditLogRepositoryMappers(aMapper mapper.InternalAuditLogRepoMapper) AuditLogRepositoryMappers {
+	return AuditLogRepositoryMappers{
+		mapper: aMapper,
+	}
+}
+
+func NewAuditLogRepository(s *store.Store, mappers AuditLogRepositoryMappers) *AuditLogRepository {
+	return &AuditLogRepository{
+		store:                     s,
+		AuditLogRepositoryMappers: mappers,
+	}
+}
@@ -0,0 +1,129 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultAuditLogMappers() AuditLogRepositoryMappers {
+	return NewAuditLogRepositoryMappers(&generated.InternalAuditLogRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestAuditLogRepository_GetAll(t *testing.T) {
+	t.Run("should return all audit logs, newest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/auditlog")
+		r := NewAuditLogRepository(suite.Store, defaultAuditLogMappers())
+
+		// when
+		got, totalCount, err := r.GetAll(context.Background(), &entities.AuditLogQuery{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), totalCount)
+		assert.Len(t, got, 3)
+		assert.Equal(t, int64(3), got[0].ID)
+		assert.Equal(t, entities.AuditActionDelete, got[0].Action)
+		assert.Equal(t, entities.AuditEntityTypeTree, got[2].EntityType)
+		assert.Equal(t, map[string]entities.AuditChange{
+			"species": {Old: json.RawMessage(`null`), New: json.RawMessage(`"Quercus robur"`)},
+		}, got[2].Changes)
+	})
+
+	t.Run("should return audit logs of one entity", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/auditlog")
+		r := NewAuditLogRepository(suite.Store, defaultAuditLogMappers())
+
+		// when
+		got, totalCount, err := r.GetAll(context.Background(), &entities.AuditLogQuery{
+			EntityType: entities.AuditEntityTypeTree,
+			EntityID:   utils.P(int32(1)),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), totalCount)
+		assert.Equal(t, int64(2), got[0].ID)
+		assert.Equal(t, int64(1), got[1].ID)
+	})
+
+	t.Run("should filter by user, action and time", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/auditlog")
+		r := NewAuditLogRepository(suite.Store, defaultAuditLogMappers())
+		from := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+
+		// when
+		byUser, _, errUser := r.GetAll(context.Background(), &entities.AuditLogQuery{UserID: "api-key:0a1b2c3d4e5f"})
+		byAction, _, errAction := r.GetAll(context.Background(), &entities.AuditLogQuery{Action: entities.AuditActionCreate})
+		byTime, _, errTime := r.GetAll(context.Background(), &entities.AuditLogQuery{From: &from, To: utils.P(from.Add(24 * time.Hour))})
+
+		// then
+		assert.NoError(t, errUser)
+		assert.NoError(t, errAction)
+		assert.NoError(t, errTime)
+		assert.Len(t, byUser, 1)
+		assert.Equal(t, int64(2), byUser[0].ID)
+		assert.Len(t, byAction, 1)
+		assert.Equal(t, int64(1), byAction[0].ID)
+		assert.Len(t, byTime, 1)
+		assert.Equal(t, int64(2), byTime[0].ID)
+	})
+
+	t.Run("should return page of audit logs", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/auditlog")
+		r := NewAuditLogRepository(suite.Store, defaultAuditLogMappers())
+		ctx := pagination.WithValues(context.Background(), 2, 2)
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, &entities.AuditLogQuery{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), totalCount)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int64(1), got[0].ID)
+	})
+
+	t.Run("should return empty list when no audit log matches", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewAuditLogRepository(suite.Store, defaultAuditLogMappers())
+
+		// when
+		got, totalCount, err := r.GetAll(context.Background(), &entities.AuditLogQuery{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), totalCount)
+		assert.Empty(t, got)
+	})
+}
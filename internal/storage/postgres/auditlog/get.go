@@ -0,0 +1,64 @@
+package auditlog
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+func (r *AuditLogRepository) GetAll(ctx context.Context, query *entities.AuditLogQuery) ([]*entities.AuditLog, int64, error) {
+	log := logger.GetLogger(ctx)
+	page, limit, err := pagination.GetValues(ctx)
+	if err != nil {
+		return nil, 0, r.store.MapError(err, sqlc.AuditLog{})
+	}
+
+	totalCount, err := r.store.GetAllAuditLogsCount(ctx, &sqlc.GetAllAuditLogsCountParams{
+		EntityType: string(query.EntityType),
+		EntityID:   query.EntityID,
+		UserID:     query.UserID,
+		Action:     string(query.Action),
+		From:       utils.TimeToPgTimestamp(query.From),
+		To:         utils.TimeToPgTimestamp(query.To),
+	})
+	if err != nil {
+		log.Debug("failed to count audit logs in db", "error", err)
+		return nil, 0, r.store.MapError(err, sqlc.AuditLog{})
+	}
+
+	if totalCount == 0 {
+		return []*entities.AuditLog{}, 0, nil
+	}
+
+	if limit == -1 {
+		limit = int32(totalCount)
+		page = 1
+	}
+
+	rows, err := r.store.GetAllAuditLogs(ctx, &sqlc.GetAllAuditLogsParams{
+		EntityType: string(query.EntityType),
+		EntityID:   query.EntityID,
+		UserID:     query.UserID,
+		Action:     string(query.Action),
+		From:       utils.TimeToPgTimestamp(query.From),
+		To:         utils.TimeToPgTimestamp(query.To),
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	})
+	if err != nil {
+		log.Debug("failed to get audit logs in db", "error", err)
+		return nil, 0, r.store.MapError(err, sqlc.AuditLog{})
+	}
+
+	logs, err := r.mapper.FromSqlList(rows)
+	if err != nil {
+		log.Debug("failed to convert entity", "error", err)
+		return nil, 0, err
+	}
+
+	return logs, totalCount, nil
+}
@@ -0,0 +1,115 @@
+package evaluation
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var _ storage.EvaluationRepository = (*EvaluationRepository)(nil)
+
+type EvaluationRepository struct {
+	store *store.Store
+	EvaluationRepositoryMappers
+}
+
+type EvaluationRepositoryMappers struct {
+	mapper mapper.InternalEvaluationRepoMapper
+}
+
+func NewEvaluationRepositoryMappers(eMapper mapper.InternalEvaluationRepoMapper) EvaluationRepositoryMappers {
+	return EvaluationRepositoryMappers{
+		mapper: eMapper,
+	}
+}
+
+func NewEvaluationRepository(s *store.Store, mappers EvaluationRepositoryMappers) *EvaluationRepository {
+	return &EvaluationRepository{
+		store:                       s,
+		EvaluationRepositoryMappers: mappers,
+	}
+}
+
+func (r *EvaluationRepository) GetWateringPlanFacts(ctx context.Context, from, to time.Time) ([]*entities.EvaluationWateringPlanFact, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetEvaluationWateringPlanFacts(ctx, &sqlc.GetEvaluationWateringPlanFactsParams{
+		From: pgDate(from),
+		To:   pgDate(to),
+	})
+	if err != nil {
+		log.Debug("failed to get watering plan facts in db", "error", err, "from", from, "to", to)
+		return nil, r.store.MapError(err, sqlc.WateringPlan{})
+	}
+
+	return r.mapper.FromSqlWateringPlanFactList(rows), nil
+}
+
+func (r *EvaluationRepository) GetWateringPlanVehicles(ctx context.Context, from, to time.Time) ([]*entities.EvaluationWateringPlanVehicle, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetEvaluationWateringPlanVehicles(ctx, &sqlc.GetEvaluationWateringPlanVehiclesParams{
+		From: pgDate(from),
+		To:   pgDate(to),
+	})
+	if err != nil {
+		log.Debug("failed to get vehicles of watering plans in db", "error", err, "from", from, "to", to)
+		return nil, r.store.MapError(err, sqlc.Vehicle{})
+	}
+
+	return r.mapper.FromSqlWateringPlanVehicleList(rows), nil
+}
+
+func (r *EvaluationRepository) GetWateringPlanUsers(ctx context.Context, from, to time.Time) ([]*entities.EvaluationWateringPlanUser, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetEvaluationWateringPlanUsers(ctx, &sqlc.GetEvaluationWateringPlanUsersParams{
+		From: pgDate(from),
+		To:   pgDate(to),
+	})
+	if err != nil {
+		log.Debug("failed to get users of watering plans in db", "error", err, "from", from, "to", to)
+		return nil, r.store.MapError(err, sqlc.UserWateringPlan{})
+	}
+
+	return r.mapper.FromSqlWateringPlanUserList(rows), nil
+}
+
+func (r *EvaluationRepository) CreateTreeStatusSnapshot(ctx context.Context, date time.Time) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		if err := s.DeleteTreeStatusSnapshot(ctx, pgDate(date)); err != nil {
+			return err
+		}
+		return s.CreateTreeStatusSnapshot(ctx, pgDate(date))
+	})
+	if err != nil {
+		log.Error("failed to create tree status snapshot in db", "error", err, "date", date)
+		return r.store.MapError(err, sqlc.TreeStatusSnapshot{})
+	}
+
+	log.Debug("tree status snapshot created successfully in db", "date", date)
+	return nil
+}
+
+func (r *EvaluationRepository) GetTreeStatusSnapshots(ctx context.Context, from, to time.Time) ([]*entities.TreeStatusSnapshot, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetTreeStatusSnapshots(ctx, &sqlc.GetTreeStatusSnapshotsParams{
+		From: pgDate(from),
+		To:   pgDate(to),
+	})
+	if err != nil {
+		log.Debug("failed to get tree status snapshots in db", "error", err, "from", from, "to", to)
+		return nil, r.store.MapError(err, sqlc.TreeStatusSnapshot{})
+	}
+
+	return r.mapper.FromSqlTreeStatusSnapshotList(rows), nil
+}
+
+func pgDate(date time.Time) pgtype.Date {
+	return pgtype.Date{Time: date, Valid: true}
+}
@@ -0,0 +1,178 @@
+package evaluation
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultEvaluationMappers() EvaluationRepositoryMappers {
+	return NewEvaluationRepositoryMappers(&generated.InternalEvaluationRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEvaluationRepository_GetWateringPlanFacts(t *testing.T) {
+	t.Run("should return one fact per tree cluster of the watering plans in the time range", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan")
+		r := NewEvaluationRepository(suite.Store, defaultEvaluationMappers())
+
+		// when
+		got, err := r.GetWateringPlanFacts(context.Background(), date(2024, time.June, 10), date(2024, time.June, 12))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 4)
+		assert.Equal(t, int32(4), got[0].WateringPlanID)
+		assert.Equal(t, date(2024, time.June, 10), got[0].Date)
+		assert.Equal(t, entities.WateringPlanStatusNotCompeted, got[0].Status)
+		assert.Equal(t, int64(1), got[0].ClusterCount)
+
+		assert.Equal(t, int32(3), got[1].WateringPlanID)
+		assert.Equal(t, entities.WateringPlanStatusFinished, got[1].Status)
+		assert.Equal(t, 63.0, got[1].Distance)
+		assert.Equal(t, int64(3), got[1].ClusterCount)
+		assert.Equal(t, utils.P(int32(1)), got[1].TreeClusterID)
+		assert.Equal(t, "Flensburger Stadion", got[1].TreeClusterName)
+		assert.Equal(t, utils.P(int32(1)), got[1].RegionID)
+		assert.Equal(t, "Mürwik", got[1].RegionName)
+		assert.Equal(t, 10.0, got[1].ConsumedWater)
+		assert.Equal(t, int64(3), got[1].TreeCount)
+	})
+
+	t.Run("should return empty list when no watering plan is in the time range", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan")
+		r := NewEvaluationRepository(suite.Store, defaultEvaluationMappers())
+
+		// when
+		got, err := r.GetWateringPlanFacts(context.Background(), date(2023, time.January, 1), date(2023, time.December, 31))
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestEvaluationRepository_GetWateringPlanAssignments(t *testing.T) {
+	t.Run("should return the vehicles of the watering plans in the time range", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan")
+		r := NewEvaluationRepository(suite.Store, defaultEvaluationMappers())
+
+		// when
+		got, err := r.GetWateringPlanVehicles(context.Background(), date(2024, time.September, 1), date(2024, time.September, 30))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.EvaluationWateringPlanVehicle{
+			{WateringPlanID: 1, VehicleID: 1, NumberPlate: "B-1234"},
+			{WateringPlanID: 1, VehicleID: 2, NumberPlate: "B-5678"},
+		}, got)
+	})
+
+	t.Run("should return the users of the watering plans in the time range", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan")
+		r := NewEvaluationRepository(suite.Store, defaultEvaluationMappers())
+
+		// when
+		got, err := r.GetWateringPlanUsers(context.Background(), date(2024, time.June, 12), date(2024, time.June, 12))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.EvaluationWateringPlanUser{
+			{WateringPlanID: 3, UserID: uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")},
+		}, got)
+	})
+}
+
+func TestEvaluationRepository_TreeStatusSnapshot(t *testing.T) {
+	t.Run("should count the trees per tree cluster and watering status", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan")
+		r := NewEvaluationRepository(suite.Store, defaultEvaluationMappers())
+		day := date(2025, time.March, 31)
+
+		// when
+		err := r.CreateTreeStatusSnapshot(context.Background(), day)
+		got, getErr := r.GetTreeStatusSnapshots(context.Background(), day, day)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Len(t, got, 6)
+
+		var count int32
+		for _, s := range got {
+			assert.Equal(t, day, s.Date)
+			assert.Equal(t, utils.P(int32(1)), s.RegionID)
+			assert.Equal(t, "Mürwik", s.RegionName)
+			count += s.TreeCount
+		}
+		assert.Equal(t, int32(6), count)
+	})
+
+	t.Run("should replace the snapshot of the same day", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan")
+		r := NewEvaluationRepository(suite.Store, defaultEvaluationMappers())
+		day := date(2025, time.March, 31)
+
+		// when
+		err := r.CreateTreeStatusSnapshot(context.Background(), day)
+		assert.NoError(t, err)
+		err = r.CreateTreeStatusSnapshot(context.Background(), day)
+		got, getErr := r.GetTreeStatusSnapshots(context.Background(), day, day)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Len(t, got, 6)
+	})
+
+	t.Run("should return empty list outside of the snapshot days", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan")
+		r := NewEvaluationRepository(suite.Store, defaultEvaluationMappers())
+		err := r.CreateTreeStatusSnapshot(context.Background(), date(2025, time.March, 31))
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.GetTreeStatusSnapshots(context.Background(), date(2025, time.April, 1), date(2025, time.April, 30))
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
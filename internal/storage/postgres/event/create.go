@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func (r *EventRepository) Append(ctx context.Context, eventType entities.EventType, payload []byte) (*entities.OutboxEvent, error) {
+	log := logger.GetLogger(ctx)
+	var created *sqlc.EventOutbox
+	err := r.store.WithTxContext(ctx, func(ctx context.Context, s *store.Store) error {
+		var err error
+		created, err = s.CreateOutboxEvent(ctx, &sqlc.CreateOutboxEventParams{
+			EventType: string(eventType),
+			Payload:   payload,
+		})
+		if err != nil {
+			return err
+		}
+
+		// the notification is sent when the transaction is committed
+		return s.NotifyOutboxEvent(ctx, string(eventType))
+	})
+	if err != nil {
+		log.Error("failed to append event to outbox", "error", err, "event_type", eventType)
+		return nil, err
+	}
+
+	log.Debug("event appended to outbox", "event_id", created.ID, "event_type", eventType)
+	return r.mapper.FromSqlEvent(created), nil
+}
+
+func (r *EventRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.store.WithTxContext(ctx, func(ctx context.Context, _ *store.Store) error {
+		return fn(ctx)
+	})
+}
+
+func (r *EventRepository) Subscribe(ctx context.Context, name string, eventType entities.EventType) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.CreateEventSubscription(ctx, &sqlc.CreateEventSubscriptionParams{
+		Name:      name,
+		EventType: string(eventType),
+	})
+	if err != nil {
+		log.Error("failed to create event subscription in db", "error", err, "subscription", name)
+		return err
+	}
+
+	return nil
+}
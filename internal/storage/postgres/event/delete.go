@@ -0,0 +1,21 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *EventRepository) DeleteProcessed(ctx context.Context, before time.Time) (int64, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.DeleteProcessedOutboxEvents(ctx, utils.TimeToPgTimestamp(&before))
+	if err != nil {
+		log.Error("failed to delete processed events in db", "error", err)
+		return 0, err
+	}
+
+	log.Debug("processed events deleted from outbox", "count", rows)
+	return rows, nil
+}
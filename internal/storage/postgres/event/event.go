@@ -0,0 +1,35 @@
+package event
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+// notifyChannel is the postgres channel the appended events are announced on. The payload of a
+// notification is the event type.
+const notifyChannel = "event_outbox"
+
+var _ storage.EventRepository = (*EventRepository)(nil)
+
+type EventRepository struct {
+	store *store.Store
+	EventRepositoryMappers
+}
+
+type EventRepositoryMappers struct {
+	mapper mapper.InternalEventRepoMapper
+}
+
+func NewEventRepositoryMappers(eMapper mapper.InternalEventRepoMapper) EventRepositoryMappers {
+	return EventRepositoryMappers{
+		mapper: eMapper,
+	}
+}
+
+func NewEventRepository(s *store.Store, mappers EventRepositoryMappers) *EventRepository {
+	return &EventRepository{
+		store:                  s,
+		EventRepositoryMappers: mappers,
+	}
+}
@@ -0,0 +1,498 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+const testSubscription = "subscriber.DeleteTreeSubscriber/delete tree"
+
+func defaultEventMappers() EventRepositoryMappers {
+	return NewEventRepositoryMappers(&generated.InternalEventRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+	code = m.Run()
+}
+
+func TestEventRepository_Append(t *testing.T) {
+	t.Run("should store event in the outbox", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+
+		// when
+		got, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{"Prev":null}`))
+
+		// then
+		assert.NoError(t, err)
+		assert.NotZero(t, got.ID)
+		assert.Equal(t, entities.EventTypeDeleteTree, got.EventType)
+		assert.JSONEq(t, `{"Prev":null}`, string(got.Payload))
+		assert.NotZero(t, got.CreatedAt)
+	})
+
+	t.Run("should notify listeners", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		notified := make(chan entities.EventType, 1)
+		go func() {
+			_ = r.Listen(ctx, func(eventType entities.EventType) {
+				notified <- eventType
+				cancel()
+			})
+		}()
+		time.Sleep(200 * time.Millisecond)
+
+		// when
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+
+		// then
+		assert.NoError(t, err)
+		select {
+		case eventType := <-notified:
+			assert.Equal(t, entities.EventTypeDeleteTree, eventType)
+		case <-ctx.Done():
+			t.Fatal("listener was not notified")
+		}
+	})
+}
+
+func TestEventRepository_WithTx(t *testing.T) {
+	createSensor := func(ctx context.Context) error {
+		_, err := suite.Store.CreateSensor(ctx, &sqlc.CreateSensorParams{
+			ID:        "sensor-1",
+			Status:    sqlc.SensorStatusOnline,
+			Latitude:  54.801539,
+			Longitude: 9.446741,
+		})
+		return err
+	}
+
+	t.Run("should commit the change and the event together", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		var appended *entities.OutboxEvent
+
+		// when
+		err := r.WithTx(context.Background(), func(ctx context.Context) error {
+			if err := createSensor(ctx); err != nil {
+				return err
+			}
+			var err error
+			appended, err = r.Append(ctx, entities.EventTypeUpdateSensor, []byte(`{}`))
+			return err
+		})
+
+		// then
+		assert.NoError(t, err)
+		latestID, err := r.GetLatestID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, appended.ID, latestID)
+		_, err = suite.Store.GetSensorByID(context.Background(), "sensor-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should roll back the change and the event together", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+
+		// when
+		err := r.WithTx(context.Background(), func(ctx context.Context) error {
+			if err := createSensor(ctx); err != nil {
+				return err
+			}
+			if _, err := r.Append(ctx, entities.EventTypeUpdateSensor, []byte(`{}`)); err != nil {
+				return err
+			}
+			return errors.New("failed after the event was appended")
+		})
+
+		// then
+		assert.Error(t, err)
+		latestID, err := r.GetLatestID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), latestID)
+		_, err = suite.Store.GetSensorByID(context.Background(), "sensor-1")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestEventRepository_Subscribe(t *testing.T) {
+	t.Run("should start new subscription after the latest event", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		event, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		err = r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree)
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetSubscription(context.Background(), testSubscription)
+		assert.NoError(t, err)
+		assert.Equal(t, event.ID, got.LastEventID)
+		assert.Equal(t, entities.EventTypeDeleteTree, got.EventType)
+	})
+
+	t.Run("should keep offset of existing subscription", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		err = r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree)
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetSubscription(context.Background(), testSubscription)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), got.LastEventID)
+	})
+}
+
+func TestEventRepository_GetLatestID(t *testing.T) {
+	t.Run("should return 0 for empty outbox", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+
+		// when
+		got, err := r.GetLatestID(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), got)
+	})
+
+	t.Run("should return id of the latest event", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		event, err := r.Append(context.Background(), entities.EventTypeUpdateTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.GetLatestID(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, event.ID, got)
+	})
+}
+
+func TestEventRepository_GetAfter(t *testing.T) {
+	t.Run("should return events of the type after the id", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		first, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, err = r.Append(context.Background(), entities.EventTypeUpdateTree, []byte(`{}`))
+		assert.NoError(t, err)
+		second, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		third, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.GetAfter(context.Background(), entities.EventTypeDeleteTree, first.ID, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, second.ID, got[0].ID)
+		assert.Equal(t, third.ID, got[1].ID)
+	})
+
+	t.Run("should limit the number of events", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		first, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, err = r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.GetAfter(context.Background(), entities.EventTypeDeleteTree, 0, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, first.ID, got[0].ID)
+	})
+
+	t.Run("should return empty list without newer events", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		event, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.GetAfter(context.Background(), entities.EventTypeDeleteTree, event.ID, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestEventRepository_Claim(t *testing.T) {
+	t.Run("should return next event of the subscribed type", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		_, err := r.Append(context.Background(), entities.EventTypeUpdateTree, []byte(`{}`))
+		assert.NoError(t, err)
+		event, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		sub, got, err := r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, event.ID, got.ID)
+		assert.Equal(t, "owner-1", *sub.LockedBy)
+		assert.Equal(t, int32(0), sub.Attempts)
+	})
+
+	t.Run("should not claim subscription of another owner", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, _, err = r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		assert.NoError(t, err)
+
+		// when
+		_, _, err = r.Claim(context.Background(), testSubscription, "owner-2", time.Minute)
+
+		// then
+		var notFound storage.ErrEntityNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should claim subscription after the lease has passed", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, _, err = r.Claim(context.Background(), testSubscription, "owner-1", time.Millisecond)
+		assert.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		// when
+		sub, _, err := r.Claim(context.Background(), testSubscription, "owner-2", time.Minute)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "owner-2", *sub.LockedBy)
+	})
+
+	t.Run("should return error without pending event", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+
+		// when
+		_, _, err := r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+
+		// then
+		var notFound storage.ErrEntityNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should not skip event of a transaction that commits late", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		tx, err := suite.Store.DB().Begin(context.Background())
+		assert.NoError(t, err)
+		defer func() { _ = tx.Rollback(context.Background()) }()
+		var lateID int64
+		err = tx.QueryRow(context.Background(), "INSERT INTO event_outbox (event_type, payload) VALUES ($1, '{}') RETURNING id", entities.EventTypeDeleteTree).Scan(&lateID)
+		assert.NoError(t, err)
+		event, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+
+		// when
+		_, _, errRunning := r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		assert.NoError(t, tx.Commit(context.Background()))
+		_, first, errFirst := r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		assert.NoError(t, r.Ack(context.Background(), testSubscription, "owner-1", first))
+		_, second, errSecond := r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+
+		// then
+		var notFound storage.ErrEntityNotFound
+		assert.ErrorAs(t, errRunning, &notFound)
+		assert.NoError(t, errFirst)
+		assert.Equal(t, lateID, first.ID)
+		assert.NoError(t, errSecond)
+		assert.Equal(t, event.ID, second.ID)
+	})
+}
+
+func TestEventRepository_Ack(t *testing.T) {
+	t.Run("should move the offset and release the claim", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		event, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, _, err = r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		assert.NoError(t, err)
+
+		// when
+		err = r.Ack(context.Background(), testSubscription, "owner-1", event)
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetSubscription(context.Background(), testSubscription)
+		assert.NoError(t, err)
+		assert.Equal(t, event.ID, got.LastEventID)
+		assert.Nil(t, got.LockedBy)
+		_, _, err = r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		var notFound storage.ErrEntityNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should return error if the subscription is not claimed by the owner", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+
+		// when
+		err := r.Ack(context.Background(), testSubscription, "owner-1", &entities.OutboxEvent{ID: 1})
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrSubscriptionNotClaimed)
+	})
+}
+
+func TestEventRepository_Retry(t *testing.T) {
+	t.Run("should count the attempt and delay the next claim", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, _, err = r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		assert.NoError(t, err)
+
+		// when
+		err = r.Retry(context.Background(), testSubscription, "owner-1", time.Hour, "failed to handle event")
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetSubscription(context.Background(), testSubscription)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.Attempts)
+		assert.Equal(t, "failed to handle event", *got.LastError)
+		assert.Equal(t, int64(0), got.LastEventID)
+		_, _, err = r.Claim(context.Background(), testSubscription, "owner-2", time.Minute)
+		var notFound storage.ErrEntityNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+}
+
+func TestEventRepository_DeadLetter(t *testing.T) {
+	t.Run("should store dead letter and move the offset", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, event, err := r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		assert.NoError(t, err)
+
+		// when
+		err = r.DeadLetter(context.Background(), testSubscription, "owner-1", event, 5, "failed to handle event")
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetDeadLetters(context.Background(), testSubscription)
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, event.ID, got[0].EventID)
+		assert.Equal(t, int32(5), got[0].Attempts)
+		assert.Equal(t, "failed to handle event", got[0].Error)
+		sub, err := r.GetSubscription(context.Background(), testSubscription)
+		assert.NoError(t, err)
+		assert.Equal(t, event.ID, sub.LastEventID)
+	})
+}
+
+func TestEventRepository_DeleteProcessed(t *testing.T) {
+	t.Run("should only delete events handled by all subscriptions", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewEventRepository(suite.Store, defaultEventMappers())
+		assert.NoError(t, r.Subscribe(context.Background(), testSubscription, entities.EventTypeDeleteTree))
+		assert.NoError(t, r.Subscribe(context.Background(), "other", entities.EventTypeDeleteTree))
+		_, err := r.Append(context.Background(), entities.EventTypeDeleteTree, []byte(`{}`))
+		assert.NoError(t, err)
+		_, event, err := r.Claim(context.Background(), testSubscription, "owner-1", time.Minute)
+		assert.NoError(t, err)
+		assert.NoError(t, r.Ack(context.Background(), testSubscription, "owner-1", event))
+		rows, err := suite.ExecQuery(t, "UPDATE event_outbox SET created_at = created_at - INTERVAL '2 days'")
+		assert.NoError(t, err)
+		rows.Close()
+
+		// when
+		deletedPending, errPending := r.DeleteProcessed(context.Background(), time.Now().Add(-24*time.Hour))
+		_, _, err = r.Claim(context.Background(), "other", "owner-1", time.Minute)
+		assert.NoError(t, err)
+		assert.NoError(t, r.Ack(context.Background(), "other", "owner-1", event))
+		deletedHandled, errHandled := r.DeleteProcessed(context.Background(), time.Now().Add(-24*time.Hour))
+
+		// then
+		assert.NoError(t, errPending)
+		assert.Equal(t, int64(0), deletedPending)
+		assert.NoError(t, errHandled)
+		assert.Equal(t, int64(1), deletedHandled)
+	})
+}
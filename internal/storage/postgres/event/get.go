@@ -0,0 +1,48 @@
+package event
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *EventRepository) GetSubscription(ctx context.Context, name string) (*entities.EventSubscription, error) {
+	row, err := r.store.GetEventSubscriptionByName(ctx, name)
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.EventSubscription{})
+	}
+
+	return r.mapper.FromSqlSubscription(row), nil
+}
+
+func (r *EventRepository) GetDeadLetters(ctx context.Context, name string) ([]*entities.EventDeadLetter, error) {
+	rows, err := r.store.GetAllEventDeadLettersBySubscription(ctx, name)
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.EventDeadLetter{})
+	}
+
+	return r.mapper.FromSqlDeadLetterList(rows), nil
+}
+
+func (r *EventRepository) GetLatestID(ctx context.Context) (int64, error) {
+	id, err := r.store.GetLatestOutboxEventID(ctx)
+	if err != nil {
+		return 0, r.store.MapError(err, sqlc.EventOutbox{})
+	}
+
+	return id, nil
+}
+
+func (r *EventRepository) GetAfter(ctx context.Context, eventType entities.EventType, afterID int64, limit int32) ([]*entities.OutboxEvent, error) {
+	rows, err := r.store.GetOutboxEventsAfter(ctx, &sqlc.GetOutboxEventsAfterParams{
+		EventType: string(eventType),
+		AfterID:   afterID,
+		MaxEvents: limit,
+	})
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.EventOutbox{})
+	}
+
+	return r.mapper.FromSqlEventList(rows), nil
+}
@@ -0,0 +1,37 @@
+package event
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *EventRepository) Listen(ctx context.Context, fn func(eventType entities.EventType)) error {
+	log := logger.GetLogger(ctx)
+	poolConn, err := r.store.DB().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// the connection keeps listening on the channel, so it must not be returned to the pool
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{notifyChannel}.Sanitize()); err != nil {
+		return err
+	}
+
+	log.Debug("listening for appended events", "channel", notifyChannel)
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		fn(entities.EventType(notification.Payload))
+	}
+}
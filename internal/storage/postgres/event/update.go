@@ -0,0 +1,106 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func (r *EventRepository) Claim(ctx context.Context, name, owner string, lease time.Duration) (*entities.EventSubscription, *entities.OutboxEvent, error) {
+	var sub *sqlc.EventSubscription
+	var event *sqlc.EventOutbox
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		var err error
+		sub, err = s.ClaimEventSubscription(ctx, &sqlc.ClaimEventSubscriptionParams{
+			Name:         name,
+			Owner:        owner,
+			LeaseSeconds: lease.Seconds(),
+		})
+		if err != nil {
+			return r.store.MapError(err, sqlc.EventSubscription{})
+		}
+
+		event, err = s.GetNextOutboxEvent(ctx, &sqlc.GetNextOutboxEventParams{
+			EventType: sub.EventType,
+			AfterTxID: sub.LastEventTxID,
+			AfterID:   sub.LastEventID,
+		})
+		return r.store.MapError(err, sqlc.EventOutbox{})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.mapper.FromSqlSubscription(sub), r.mapper.FromSqlEvent(event), nil
+}
+
+func (r *EventRepository) Ack(ctx context.Context, name, owner string, event *entities.OutboxEvent) error {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.AckEventSubscription(ctx, &sqlc.AckEventSubscriptionParams{
+		Name:      name,
+		Owner:     owner,
+		EventTxID: event.TxID,
+		EventID:   event.ID,
+	})
+	if err != nil {
+		log.Error("failed to ack event subscription in db", "error", err, "subscription", name, "event_id", event.ID)
+		return err
+	}
+
+	if rows == 0 {
+		return storage.ErrSubscriptionNotClaimed
+	}
+
+	return nil
+}
+
+func (r *EventRepository) Retry(ctx context.Context, name, owner string, delay time.Duration, eventErr string) error {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.RetryEventSubscription(ctx, &sqlc.RetryEventSubscriptionParams{
+		Name:         name,
+		Owner:        owner,
+		DelaySeconds: delay.Seconds(),
+		LastError:    eventErr,
+	})
+	if err != nil {
+		log.Error("failed to update event subscription in db", "error", err, "subscription", name)
+		return err
+	}
+
+	if rows == 0 {
+		return storage.ErrSubscriptionNotClaimed
+	}
+
+	return nil
+}
+
+func (r *EventRepository) DeadLetter(ctx context.Context, name, owner string, event *entities.OutboxEvent, attempts int32, eventErr string) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		err := s.CreateEventDeadLetter(ctx, &sqlc.CreateEventDeadLetterParams{
+			Subscription: name,
+			EventID:      event.ID,
+			EventType:    string(event.EventType),
+			Payload:      event.Payload,
+			Attempts:     attempts,
+			Error:        eventErr,
+		})
+		if err != nil {
+			return err
+		}
+
+		return NewEventRepository(s, r.EventRepositoryMappers).Ack(ctx, name, owner, event)
+	})
+	if err != nil {
+		log.Error("failed to store dead letter in db", "error", err, "subscription", name, "event_id", event.ID)
+		return err
+	}
+
+	log.Debug("event stored as dead letter", "subscription", name, "event_id", event.ID)
+	return nil
+}
@@ -0,0 +1,31 @@
+package feature
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+var _ storage.FeatureRepository = (*FeatureRepository)(nil)
+
+type FeatureRepository struct {
+	store *store.Store
+	FeatureRepositoryMappers
+}
+
+type FeatureRepositoryMappers struct {
+	mapper mapper.InternalFeatureRepoMapper
+}
+
+func NewFeatureRepositoryMappers(fMapper mapper.InternalFeatureRepoMapper) FeatureRepositoryMappers {
+	return FeatureRepositoryMappers{
+		mapper: fMapper,
+	}
+}
+
+func NewFeatureRepository(s *store.Store, mappers FeatureRepositoryMappers) *FeatureRepository {
+	return &FeatureRepository{
+		store:                    s,
+		FeatureRepositoryMappers: mappers,
+	}
+}
@@ -0,0 +1,218 @@
+package feature
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultFeatureMappers() FeatureRepositoryMappers {
+	return NewFeatureRepositoryMappers(&generated.InternalFeatureRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestFeatureRepository_GetAll(t *testing.T) {
+	suite.ResetDB(t)
+	suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+	r := NewFeatureRepository(suite.Store, defaultFeatureMappers())
+	ctx := context.Background()
+
+	t.Run("should return features of collection ordered by id", func(t *testing.T) {
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.OGCCollectionTrees, &entities.OGCFeatureQuery{Limit: 2})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), totalCount)
+		assert.Len(t, got, 2)
+		assert.Equal(t, "1", got[0].ID)
+		assert.Equal(t, "2", got[1].ID)
+		assert.Equal(t, "Quercus robur", got[0].Properties["species"])
+		assert.JSONEq(t, `{"type":"Point","coordinates":[9.485702121,54.821245181]}`, string(got[0].Geometry))
+	})
+
+	t.Run("should return next page with offset", func(t *testing.T) {
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.OGCCollectionTrees, &entities.OGCFeatureQuery{Limit: 2, Offset: 4})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), totalCount)
+		assert.Len(t, got, 1)
+		assert.Equal(t, "5", got[0].ID)
+	})
+
+	t.Run("should filter by bbox in longitude and latitude", func(t *testing.T) {
+		// given
+		query := &entities.OGCFeatureQuery{
+			BBox:  &entities.BoundingBox{MinLon: 9.48, MinLat: 54.82, MaxLon: 9.49, MaxLat: 54.83},
+			Limit: 10,
+		}
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.OGCCollectionTrees, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), totalCount)
+		assert.Equal(t, "1", got[0].ID)
+		assert.Equal(t, "2", got[1].ID)
+	})
+
+	t.Run("should filter by properties", func(t *testing.T) {
+		// given
+		query := &entities.OGCFeatureQuery{
+			Properties: map[string]any{"species": "Betula pendula", "planting_year": int64(2022)},
+			Limit:      10,
+		}
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.OGCCollectionTrees, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, "5", got[0].ID)
+	})
+
+	t.Run("should filter by datetime", func(t *testing.T) {
+		// given
+		start := time.Now().Add(24 * time.Hour)
+		query := &entities.OGCFeatureQuery{Datetime: &entities.TimeInterval{Start: &start}, Limit: 10}
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.OGCCollectionTrees, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), totalCount)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return sensors with text ids", func(t *testing.T) {
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.OGCCollectionSensors, &entities.OGCFeatureQuery{Limit: 10})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), totalCount)
+		assert.Equal(t, "sensor-1", got[0].ID)
+		assert.Equal(t, "online", got[0].Properties["status"])
+	})
+}
+
+func TestFeatureRepository_GetByID(t *testing.T) {
+	suite.ResetDB(t)
+	suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+	r := NewFeatureRepository(suite.Store, defaultFeatureMappers())
+	ctx := context.Background()
+
+	t.Run("should return feature by id", func(t *testing.T) {
+		// when
+		got, err := r.GetByID(ctx, entities.OGCCollectionTreeClusters, "1")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "1", got.ID)
+		assert.Equal(t, "Solitüde Strand", got.Properties["name"])
+	})
+
+	t.Run("should return error when feature is in another collection", func(t *testing.T) {
+		// when
+		got, err := r.GetByID(ctx, entities.OGCCollectionTrees, "sensor-1")
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrEntityNotFound("ogc_features"))
+		assert.Nil(t, got)
+	})
+}
+
+func TestFeatureRepository_GetExtent(t *testing.T) {
+	t.Run("should return extent of collection", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewFeatureRepository(suite.Store, defaultFeatureMappers())
+
+		// when
+		got, err := r.GetExtent(context.Background(), entities.OGCCollectionTrees)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got.BBox)
+		assert.InDelta(t, 9.11, got.BBox.MinLon, 0.0001)
+		assert.InDelta(t, 54.1, got.BBox.MinLat, 0.0001)
+		assert.NotNil(t, got.Interval.Start)
+	})
+
+	t.Run("should return extent without bbox for empty collection", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewFeatureRepository(suite.Store, defaultFeatureMappers())
+
+		// when
+		got, err := r.GetExtent(context.Background(), entities.OGCCollectionTrees)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got.BBox)
+		assert.Nil(t, got.Interval.Start)
+	})
+}
+
+func TestFeatureRepository_GetTile(t *testing.T) {
+	suite.ResetDB(t)
+	suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+	r := NewFeatureRepository(suite.Store, defaultFeatureMappers())
+	ctx := context.Background()
+
+	t.Run("should return tile with features of collection", func(t *testing.T) {
+		// given
+		query := &entities.TileQuery{
+			// tile containing the tree with id 1
+			TileCoordinate: entities.TileCoordinate{Z: 14, X: 8623, Y: 5196},
+			Attributes:     []string{"species", "watering_status"},
+			Tolerance:      2.4,
+		}
+
+		// when
+		got, err := r.GetTile(ctx, entities.OGCCollectionTrees, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got)
+		assert.Contains(t, string(got), "trees")
+		assert.Contains(t, string(got), "Quercus robur")
+		assert.NotContains(t, string(got), "provider")
+	})
+
+	t.Run("should return empty tile when no feature intersects it", func(t *testing.T) {
+		// given
+		query := &entities.TileQuery{TileCoordinate: entities.TileCoordinate{Z: 14, X: 0, Y: 0}, Tolerance: 2.4}
+
+		// when
+		got, err := r.GetTile(ctx, entities.OGCCollectionTrees, query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
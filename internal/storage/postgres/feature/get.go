@@ -0,0 +1,123 @@
+package feature
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+// featureEntity is the name of the view used in not found errors
+const featureEntity = "ogc_features"
+
+func (r *FeatureRepository) GetAll(ctx context.Context, collection entities.OGCCollectionID, query *entities.OGCFeatureQuery) ([]*entities.OGCFeature, int64, error) {
+	log := logger.GetLogger(ctx)
+
+	properties := []byte("{}")
+	if len(query.Properties) > 0 {
+		var err error
+		if properties, err = json.Marshal(query.Properties); err != nil {
+			log.Debug("failed to encode property filter", "error", err, "collection", collection)
+			return nil, 0, err
+		}
+	}
+
+	countParams := &sqlc.GetAllFeaturesCountParams{
+		Collection: string(collection),
+		Properties: properties,
+	}
+	if query.BBox != nil {
+		countParams.MinLon = &query.BBox.MinLon
+		countParams.MinLat = &query.BBox.MinLat
+		countParams.MaxLon = &query.BBox.MaxLon
+		countParams.MaxLat = &query.BBox.MaxLat
+	}
+	if query.Datetime != nil {
+		countParams.StartTime = utils.TimeToPgTimestamp(query.Datetime.Start)
+		countParams.EndTime = utils.TimeToPgTimestamp(query.Datetime.End)
+	}
+
+	totalCount, err := r.store.GetAllFeaturesCount(ctx, countParams)
+	if err != nil {
+		log.Debug("failed to get feature count in db", "error", err, "collection", collection)
+		return nil, 0, r.store.MapError(err, featureEntity)
+	}
+
+	if totalCount == 0 {
+		return []*entities.OGCFeature{}, 0, nil
+	}
+
+	rows, err := r.store.GetAllFeatures(ctx, &sqlc.GetAllFeaturesParams{
+		Collection:  countParams.Collection,
+		MinLon:      countParams.MinLon,
+		MinLat:      countParams.MinLat,
+		MaxLon:      countParams.MaxLon,
+		MaxLat:      countParams.MaxLat,
+		StartTime:   countParams.StartTime,
+		EndTime:     countParams.EndTime,
+		Properties:  countParams.Properties,
+		LimitCount:  query.Limit,
+		OffsetCount: query.Offset,
+	})
+	if err != nil {
+		log.Debug("failed to get features in db", "error", err, "collection", collection)
+		return nil, 0, r.store.MapError(err, featureEntity)
+	}
+
+	data, err := r.mapper.FromSqlList(rows)
+	if err != nil {
+		log.Debug("failed to convert entity", "error", err)
+		return nil, 0, err
+	}
+
+	return data, totalCount, nil
+}
+
+func (r *FeatureRepository) GetByID(ctx context.Context, collection entities.OGCCollectionID, id string) (*entities.OGCFeature, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetFeatureByID(ctx, &sqlc.GetFeatureByIDParams{
+		Collection: string(collection),
+		ID:         id,
+	})
+	if err != nil {
+		log.Debug("failed to get feature by id in db", "error", err, "collection", collection, "feature_id", id)
+		return nil, r.store.MapError(err, featureEntity)
+	}
+
+	data, err := r.mapper.FromSqlByID(row)
+	if err != nil {
+		log.Debug("failed to convert entity", "error", err)
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *FeatureRepository) GetExtent(ctx context.Context, collection entities.OGCCollectionID) (*entities.OGCExtent, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetFeatureExtent(ctx, string(collection))
+	if err != nil {
+		log.Debug("failed to get feature extent in db", "error", err, "collection", collection)
+		return nil, r.store.MapError(err, featureEntity)
+	}
+
+	extent := &entities.OGCExtent{
+		Interval: &entities.TimeInterval{
+			Start: utils.PgTimestampToTimePtr(row.StartTime),
+			End:   utils.PgTimestampToTimePtr(row.EndTime),
+		},
+	}
+	if row.GeometryCount > 0 {
+		extent.BBox = &entities.BoundingBox{
+			MinLon: row.MinLon,
+			MinLat: row.MinLat,
+			MaxLon: row.MaxLon,
+			MaxLat: row.MaxLat,
+		}
+	}
+
+	return extent, nil
+}
@@ -0,0 +1,32 @@
+package feature
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *FeatureRepository) GetTile(ctx context.Context, collection entities.OGCCollectionID, query *entities.TileQuery) ([]byte, error) {
+	log := logger.GetLogger(ctx)
+	attributes := query.Attributes
+	if attributes == nil {
+		attributes = []string{}
+	}
+
+	tile, err := r.store.GetFeatureTile(ctx, &sqlc.GetFeatureTileParams{
+		Collection: string(collection),
+		Z:          query.Z,
+		X:          query.X,
+		Y:          query.Y,
+		Attributes: attributes,
+		Tolerance:  query.Tolerance,
+	})
+	if err != nil {
+		log.Debug("failed to get feature tile in db", "error", err, "collection", collection, "z", query.Z, "x", query.X, "y", query.Y)
+		return nil, r.store.MapError(err, featureEntity)
+	}
+
+	return tile, nil
+}
@@ -0,0 +1,23 @@
+package job
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *JobRepository) CreateRun(ctx context.Context, createData *entities.JobRunCreate) (*entities.JobRun, error) {
+	row, err := r.store.CreateJobRun(ctx, &sqlc.CreateJobRunParams{
+		Job:         createData.Job,
+		Trigger:     sqlc.JobRunTrigger(createData.Trigger),
+		Status:      sqlc.JobRunStatus(createData.Status),
+		Instance:    createData.Instance,
+		RequestedBy: createData.RequestedBy,
+	})
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRun(row), nil
+}
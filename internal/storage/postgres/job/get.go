@@ -0,0 +1,81 @@
+package job
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+)
+
+func (r *JobRepository) GetRunByID(ctx context.Context, id int64) (*entities.JobRun, error) {
+	row, err := r.store.GetJobRunByID(ctx, id)
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRun(row), nil
+}
+
+func (r *JobRepository) GetPendingRuns(ctx context.Context) ([]*entities.JobRun, error) {
+	rows, err := r.store.GetPendingJobRuns(ctx)
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRunList(rows), nil
+}
+
+func (r *JobRepository) GetRuns(ctx context.Context, job string) ([]*entities.JobRun, int64, error) {
+	log := logger.GetLogger(ctx)
+	page, limit, err := pagination.GetValues(ctx)
+	if err != nil {
+		return nil, 0, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	totalCount, err := r.store.GetAllJobRunsByJobCount(ctx, job)
+	if err != nil {
+		log.Debug("failed to count job runs in db", "error", err, "job", job)
+		return nil, 0, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	if totalCount == 0 {
+		return []*entities.JobRun{}, 0, nil
+	}
+
+	if limit == -1 {
+		limit = int32(totalCount)
+		page = 1
+	}
+
+	rows, err := r.store.GetAllJobRunsByJob(ctx, &sqlc.GetAllJobRunsByJobParams{
+		Job:    job,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		log.Debug("failed to get job runs in db", "error", err, "job", job)
+		return nil, 0, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRunList(rows), totalCount, nil
+}
+
+func (r *JobRepository) GetLatestRuns(ctx context.Context) ([]*entities.JobRun, error) {
+	rows, err := r.store.GetLatestJobRuns(ctx)
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRunList(rows), nil
+}
+
+func (r *JobRepository) GetLatestScheduledRun(ctx context.Context, job string) (*entities.JobRun, error) {
+	row, err := r.store.GetLatestScheduledJobRun(ctx, job)
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRun(row), nil
+}
@@ -0,0 +1,34 @@
+package job
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+// lockName is the name of the advisory lock held by the instance that runs the scheduled jobs
+const lockName = "job_scheduler"
+
+var _ storage.JobRepository = (*JobRepository)(nil)
+
+type JobRepository struct {
+	store *store.Store
+	JobRepositoryMappers
+}
+
+type JobRepositoryMappers struct {
+	mapper mapper.InternalJobRepoMapper
+}
+
+func NewJobRepositoryMappers(jMapper mapper.InternalJobRepoMapper) JobRepositoryMappers {
+	return JobRepositoryMappers{
+		mapper: jMapper,
+	}
+}
+
+func NewJobRepository(s *store.Store, mappers JobRepositoryMappers) *JobRepository {
+	return &JobRepository{
+		store:                s,
+		JobRepositoryMappers: mappers,
+	}
+}
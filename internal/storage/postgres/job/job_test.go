@@ -0,0 +1,312 @@
+package job
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultJobMappers() JobRepositoryMappers {
+	return NewJobRepositoryMappers(&generated.InternalJobRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+	code = m.Run()
+}
+
+func createRun(t *testing.T, r *JobRepository, job string, trigger entities.JobRunTrigger, status entities.JobRunStatus) *entities.JobRun {
+	t.Helper()
+	run, err := r.CreateRun(context.Background(), &entities.JobRunCreate{
+		Job:      job,
+		Trigger:  trigger,
+		Status:   status,
+		Instance: "backend-1",
+	})
+	assert.NoError(t, err)
+	return run
+}
+
+func TestJobRepository_TryLock(t *testing.T) {
+	t.Run("should grant the lock to one holder at a time", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+
+		// when
+		first, errFirst := r.TryLock(context.Background())
+		second, errSecond := r.TryLock(context.Background())
+
+		// then
+		assert.NoError(t, errFirst)
+		assert.NotNil(t, first)
+		assert.NoError(t, errSecond)
+		assert.Nil(t, second)
+		assert.NoError(t, first.Check(context.Background()))
+
+		assert.NoError(t, first.Release(context.Background()))
+		third, err := r.TryLock(context.Background())
+		assert.NoError(t, err)
+		assert.NotNil(t, third)
+		assert.NoError(t, third.Release(context.Background()))
+	})
+}
+
+func TestJobRepository_CreateRun(t *testing.T) {
+	t.Run("should start running run immediately", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+
+		// when
+		got := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+
+		// then
+		assert.NotZero(t, got.ID)
+		assert.Equal(t, entities.JobSensorStatus, got.Job)
+		assert.Equal(t, entities.JobRunTriggerSchedule, got.Trigger)
+		assert.Equal(t, entities.JobRunStatusRunning, got.Status)
+		assert.Equal(t, "backend-1", got.Instance)
+		assert.NotNil(t, got.StartedAt)
+		assert.Nil(t, got.FinishedAt)
+	})
+
+	t.Run("should keep pending run unstarted", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+
+		// when
+		got := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+
+		// then
+		assert.Equal(t, entities.JobRunStatusPending, got.Status)
+		assert.Nil(t, got.StartedAt)
+	})
+}
+
+func TestJobRepository_StartRun(t *testing.T) {
+	t.Run("should start pending run", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		run := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+
+		// when
+		got, err := r.StartRun(context.Background(), run.ID, "backend-2")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.JobRunStatusRunning, got.Status)
+		assert.Equal(t, "backend-2", got.Instance)
+		assert.NotNil(t, got.StartedAt)
+	})
+
+	t.Run("should return not found for run that is not pending", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		run := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+
+		// when
+		got, err := r.StartRun(context.Background(), run.ID, "backend-2")
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, storage.ErrEntityNotFound("JobRun"))
+	})
+}
+
+func TestJobRepository_FinishRun(t *testing.T) {
+	t.Run("should store status, error and duration", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		run := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+
+		// when
+		got, err := r.FinishRun(context.Background(), run.ID, entities.JobRunStatusFailed, utils.P("database error"))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.JobRunStatusFailed, got.Status)
+		assert.Equal(t, "database error", *got.Error)
+		assert.NotNil(t, got.FinishedAt)
+		assert.NotNil(t, got.Duration)
+		assert.GreaterOrEqual(t, *got.Duration, time.Duration(0))
+	})
+
+	t.Run("should return not found for run that is not running", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		run := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+
+		// when
+		got, err := r.FinishRun(context.Background(), run.ID, entities.JobRunStatusSuccess, nil)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, storage.ErrEntityNotFound("JobRun"))
+	})
+}
+
+func TestJobRepository_AbortRunning(t *testing.T) {
+	t.Run("should fail running runs only", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		running := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		pending := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+
+		// when
+		aborted, err := r.AbortRunning(context.Background(), "leader stopped")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), aborted)
+		gotRunning, _ := r.GetRunByID(context.Background(), running.ID)
+		assert.Equal(t, entities.JobRunStatusFailed, gotRunning.Status)
+		assert.Equal(t, "leader stopped", *gotRunning.Error)
+		gotPending, _ := r.GetRunByID(context.Background(), pending.ID)
+		assert.Equal(t, entities.JobRunStatusPending, gotPending.Status)
+	})
+}
+
+func TestJobRepository_GetPendingRuns(t *testing.T) {
+	t.Run("should return pending runs, oldest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		first := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+		createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		second := createRun(t, r, entities.JobTreeWateringStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+
+		// when
+		got, err := r.GetPendingRuns(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, first.ID, got[0].ID)
+		assert.Equal(t, second.ID, got[1].ID)
+	})
+}
+
+func TestJobRepository_GetRuns(t *testing.T) {
+	t.Run("should return runs of the job, newest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		first := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		createRun(t, r, entities.JobTreeWateringStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		second := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+
+		// when
+		got, totalCount, err := r.GetRuns(context.Background(), entities.JobSensorStatus)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), totalCount)
+		assert.Len(t, got, 2)
+		assert.Equal(t, second.ID, got[0].ID)
+		assert.Equal(t, first.ID, got[1].ID)
+	})
+
+	t.Run("should return runs paginated", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		first := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		ctx := pagination.WithValues(context.Background(), 2, 1)
+
+		// when
+		got, totalCount, err := r.GetRuns(ctx, entities.JobSensorStatus)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), totalCount)
+		assert.Len(t, got, 1)
+		assert.Equal(t, first.ID, got[0].ID)
+	})
+
+	t.Run("should return empty list for job without runs", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+
+		// when
+		got, totalCount, err := r.GetRuns(context.Background(), entities.JobSensorStatus)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, totalCount)
+		assert.Empty(t, got)
+	})
+}
+
+func TestJobRepository_GetLatestRuns(t *testing.T) {
+	t.Run("should return latest run of every job", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		latestSensor := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+		latestTree := createRun(t, r, entities.JobTreeWateringStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+
+		// when
+		got, err := r.GetLatestRuns(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, latestSensor.ID, got[0].ID)
+		assert.Equal(t, latestTree.ID, got[1].ID)
+	})
+}
+
+func TestJobRepository_GetLatestScheduledRun(t *testing.T) {
+	t.Run("should ignore manual runs", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+		scheduled := createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerSchedule, entities.JobRunStatusRunning)
+		createRun(t, r, entities.JobSensorStatus, entities.JobRunTriggerManual, entities.JobRunStatusPending)
+
+		// when
+		got, err := r.GetLatestScheduledRun(context.Background(), entities.JobSensorStatus)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, scheduled.ID, got.ID)
+	})
+
+	t.Run("should return not found for job without scheduled run", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewJobRepository(suite.Store, defaultJobMappers())
+
+		// when
+		got, err := r.GetLatestScheduledRun(context.Background(), entities.JobSensorStatus)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, storage.ErrEntityNotFound("JobRun"))
+	})
+}
@@ -0,0 +1,46 @@
+package job
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ storage.LeaderLock = (*leaderLock)(nil)
+
+// leaderLock holds a session advisory lock on a dedicated connection. Postgres releases the
+// lock when the connection is closed, so a stopped leader never blocks the other instances.
+type leaderLock struct {
+	conn *pgx.Conn
+}
+
+func (r *JobRepository) TryLock(ctx context.Context) (storage.LeaderLock, error) {
+	poolConn, err := r.store.DB().Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the lock belongs to the session, so the connection must not be returned to the pool
+	conn := poolConn.Hijack()
+
+	locked, err := sqlc.New(conn).TryAdvisoryLock(ctx, lockName)
+	if err != nil || !locked {
+		conn.Close(context.Background())
+		return nil, err
+	}
+
+	logger.GetLogger(ctx).Debug("acquired advisory lock", "lock", lockName)
+	return &leaderLock{conn: conn}, nil
+}
+
+func (l *leaderLock) Check(ctx context.Context) error {
+	return l.conn.Ping(ctx)
+}
+
+func (l *leaderLock) Release(ctx context.Context) error {
+	defer l.conn.Close(context.Background())
+	_, err := sqlc.New(l.conn).AdvisoryUnlock(ctx, lockName)
+	return err
+}
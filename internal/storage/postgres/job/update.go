@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *JobRepository) StartRun(ctx context.Context, id int64, instance string) (*entities.JobRun, error) {
+	row, err := r.store.StartJobRun(ctx, &sqlc.StartJobRunParams{
+		ID:       id,
+		Instance: instance,
+	})
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRun(row), nil
+}
+
+func (r *JobRepository) FinishRun(ctx context.Context, id int64, status entities.JobRunStatus, runErr *string) (*entities.JobRun, error) {
+	row, err := r.store.FinishJobRun(ctx, &sqlc.FinishJobRunParams{
+		ID:     id,
+		Status: sqlc.JobRunStatus(status),
+		Error:  runErr,
+	})
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return r.mapper.FromSqlRun(row), nil
+}
+
+func (r *JobRepository) AbortRunning(ctx context.Context, reason string) (int64, error) {
+	rows, err := r.store.AbortRunningJobRuns(ctx, reason)
+	if err != nil {
+		return 0, r.store.MapError(err, sqlc.JobRun{})
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapAlertRuleType MapAlertSeverity MapAlertStatus MapAlertSubjectType MapAlertWateringStatus MapSeconds
+type InternalAlertRepoMapper interface {
+	// goverter:map RegionIds RegionIDs
+	// goverter:map WindowSeconds Window
+	// goverter:map ForSeconds For
+	FromSqlRule(src *sqlc.AlertRule) *entities.AlertRule
+	FromSqlRuleList(src []*sqlc.AlertRule) []*entities.AlertRule
+
+	FromSql(src *sqlc.Alert) *entities.Alert
+	FromSqlList(src []*sqlc.Alert) []*entities.Alert
+}
+
+func MapAlertRuleType(src string) entities.AlertRuleType {
+	return entities.AlertRuleType(src)
+}
+
+func MapAlertSeverity(src string) entities.AlertSeverity {
+	return entities.AlertSeverity(src)
+}
+
+func MapAlertStatus(src sqlc.AlertStatus) entities.AlertStatus {
+	return entities.AlertStatus(src)
+}
+
+func MapAlertSubjectType(src string) entities.AlertSubjectType {
+	return entities.AlertSubjectType(src)
+}
+
+func MapAlertWateringStatus(src string) entities.WateringStatus {
+	return entities.WateringStatus(src)
+}
+
+// MapSeconds converts a duration that is stored in whole seconds
+func MapSeconds(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,112 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertMapper_FromSqlRule(t *testing.T) {
+	alertMapper := &generated.InternalAlertRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := &sqlc.AlertRule{
+			ID:               1,
+			CreatedAt:        pgtype.Timestamp{Time: time.Now()},
+			UpdatedAt:        pgtype.Timestamp{Time: time.Now()},
+			Name:             "Bad tree clusters without watering plan",
+			Type:             "tree_cluster_unplanned",
+			Severity:         "critical",
+			RegionIds:        []int32{1, 2},
+			WateringStatuses: []string{"bad", "just watered"},
+			WindowSeconds:    48 * 60 * 60,
+			ForSeconds:       60 * 60,
+			Notify:           true,
+			Enabled:          true,
+		}
+
+		// when
+		got := alertMapper.FromSqlRule(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.Name, got.Name)
+		assert.Equal(t, entities.AlertRuleTypeTreeClusterUnplanned, got.Type)
+		assert.Equal(t, entities.AlertSeverityCritical, got.Severity)
+		assert.Equal(t, src.RegionIds, got.RegionIDs)
+		assert.Equal(t, []entities.WateringStatus{entities.WateringStatusBad, entities.WateringStatusJustWatered}, got.WateringStatuses)
+		assert.Nil(t, got.Threshold)
+		assert.Equal(t, 48*time.Hour, got.Window)
+		assert.Equal(t, time.Hour, got.For)
+		assert.True(t, got.Notify)
+		assert.True(t, got.Enabled)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.AlertRule = nil
+
+		// when
+		got := alertMapper.FromSqlRule(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestAlertMapper_FromSql(t *testing.T) {
+	alertMapper := &generated.InternalAlertRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		firedAt := time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC)
+		src := &sqlc.Alert{
+			ID:          1,
+			CreatedAt:   pgtype.Timestamp{Time: firedAt, Valid: true},
+			UpdatedAt:   pgtype.Timestamp{Time: firedAt, Valid: true},
+			RuleID:      2,
+			SubjectType: "sensor",
+			SubjectID:   "sensor-1",
+			SubjectName: "sensor-1",
+			Status:      sqlc.AlertStatusFiring,
+			Message:     "The battery of the sensor sensor-1 is at 3.10 V, below 3.20 V.",
+			Value:       utils.P(3.1),
+			FiredAt:     pgtype.Timestamp{Time: firedAt, Valid: true},
+		}
+
+		// when
+		got := alertMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.RuleID, got.RuleID)
+		assert.Equal(t, entities.AlertSubjectTypeSensor, got.SubjectType)
+		assert.Equal(t, src.SubjectID, got.SubjectID)
+		assert.Equal(t, entities.AlertStatusFiring, got.Status)
+		assert.Equal(t, src.Value, got.Value)
+		assert.Equal(t, &firedAt, got.FiredAt)
+		assert.Nil(t, got.ResolvedAt)
+		assert.Nil(t, got.AcknowledgedAt)
+		assert.Nil(t, got.AcknowledgedBy)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.Alert = nil
+
+		// when
+		got := alertMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,15 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapPluginScope
+type InternalAPIKeyRepoMapper interface {
+	FromSql(src *sqlc.ApiKey) *entities.APIKey
+	FromSqlList(src []*sqlc.ApiKey) []*entities.APIKey
+}
@@ -0,0 +1,106 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyMapper_FromSql(t *testing.T) {
+	apiKeyMapper := &generated.InternalAPIKeyRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestAPIKeys[0]
+
+		// when
+		got := apiKeyMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
+		assert.Equal(t, src.Name, got.Name)
+		assert.Equal(t, src.Prefix, got.Prefix)
+		assert.Equal(t, src.KeyHash, got.KeyHash)
+		assert.Equal(t, src.CreatedBy, got.CreatedBy)
+		assert.Equal(t, []entities.PluginScope{"tree:read", "sensor:read"}, got.Scopes)
+		assert.Equal(t, src.ExpiresAt.Time, *got.ExpiresAt)
+		assert.Nil(t, got.LastUsedAt)
+		assert.Nil(t, got.RevokedAt)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.ApiKey = nil
+
+		// when
+		got := apiKeyMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestAPIKeyMapper_FromSqlList(t *testing.T) {
+	apiKeyMapper := &generated.InternalAPIKeyRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestAPIKeys
+
+		// when
+		got := apiKeyMapper.FromSqlList(src)
+
+		// then
+		assert.Len(t, got, 2)
+		for i, src := range src {
+			assert.Equal(t, src.ID, got[i].ID)
+			assert.Equal(t, src.Name, got[i].Name)
+			assert.Equal(t, src.Prefix, got[i].Prefix)
+		}
+		assert.Equal(t, allTestAPIKeys[1].RevokedAt.Time, *got[1].RevokedAt)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src []*sqlc.ApiKey = nil
+
+		// when
+		got := apiKeyMapper.FromSqlList(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+var allTestAPIKeys = []*sqlc.ApiKey{
+	{
+		ID:        1,
+		CreatedAt: pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt: pgtype.Timestamp{Time: time.Now()},
+		Name:      "Irrigation controller",
+		Prefix:    "0a1b2c3d4e5f",
+		KeyHash:   "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		Scopes:    []string{"tree:read", "sensor:read"},
+		CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		ExpiresAt: pgtype.Timestamp{Time: time.Now().Add(24 * time.Hour), Valid: true},
+	},
+	{
+		ID:        2,
+		CreatedAt: pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt: pgtype.Timestamp{Time: time.Now()},
+		Name:      "Legacy importer",
+		Prefix:    "f5e4d3c2b1a0",
+		KeyHash:   "6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b",
+		Scopes:    []string{"tree:write"},
+		CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		RevokedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	},
+}
@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend MapAuditEntityType MapAuditAction MapAuditChanges
+type InternalAuditLogRepoMapper interface {
+	FromSql(src *sqlc.AuditLog) (*entities.AuditLog, error)
+	FromSqlList(src []*sqlc.AuditLog) ([]*entities.AuditLog, error)
+}
+
+// auditChange is the json representation of a changed field in the database
+type auditChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+func MapAuditEntityType(src string) entities.AuditEntityType {
+	return entities.AuditEntityType(src)
+}
+
+func MapAuditAction(src sqlc.AuditAction) entities.AuditAction {
+	return entities.AuditAction(src)
+}
+
+func MapAuditChanges(src []byte) (map[string]entities.AuditChange, error) {
+	changes := make(map[string]entities.AuditChange)
+	if len(src) == 0 {
+		return changes, nil
+	}
+
+	var raw map[string]auditChange
+	if err := json.Unmarshal(src, &raw); err != nil {
+		return nil, err
+	}
+
+	for field, c := range raw {
+		changes[field] = entities.AuditChange{Old: c.Old, New: c.New}
+	}
+	return changes, nil
+}
+
+func MapAuditChangesToByte(src map[string]entities.AuditChange) ([]byte, error) {
+	raw := make(map[string]auditChange, len(src))
+	for field, c := range src {
+		raw[field] = auditChange{Old: nullIfEmpty(c.Old), New: nullIfEmpty(c.New)}
+	}
+	return json.Marshal(raw)
+}
+
+func nullIfEmpty(src json.RawMessage) json.RawMessage {
+	if len(src) == 0 {
+		return json.RawMessage("null")
+	}
+	return src
+}
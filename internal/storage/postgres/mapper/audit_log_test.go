@@ -0,0 +1,140 @@
+package mapper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogMapper_FromSql(t *testing.T) {
+	auditLogMapper := &generated.InternalAuditLogRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestAuditLogs[0]
+
+		// when
+		got, err := auditLogMapper.FromSql(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, entities.AuditEntityTypeTree, got.EntityType)
+		assert.Equal(t, src.EntityID, got.EntityID)
+		assert.Equal(t, entities.AuditActionUpdate, got.Action)
+		assert.Equal(t, src.UserID, got.UserID)
+		assert.Equal(t, map[string]entities.AuditChange{
+			"species": {Old: json.RawMessage(`"Quercus robur"`), New: json.RawMessage(`"Tilia cordata"`)},
+		}, got.Changes)
+	})
+
+	t.Run("should return error on invalid json", func(t *testing.T) {
+		// given
+		src := *allTestAuditLogs[0]
+		src.Changes = []byte("not json")
+
+		// when
+		got, err := auditLogMapper.FromSql(&src)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.AuditLog = nil
+
+		// when
+		got, err := auditLogMapper.FromSql(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestAuditLogMapper_FromSqlList(t *testing.T) {
+	auditLogMapper := &generated.InternalAuditLogRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestAuditLogs
+
+		// when
+		got, err := auditLogMapper.FromSqlList(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		for i, src := range src {
+			assert.Equal(t, src.ID, got[i].ID)
+			assert.Equal(t, src.EntityID, got[i].EntityID)
+		}
+		assert.Equal(t, entities.AuditActionDelete, got[1].Action)
+		assert.Empty(t, got[1].Changes)
+	})
+}
+
+func TestAuditLogMapper_ChangesToByte(t *testing.T) {
+	t.Run("should convert changes back and forth", func(t *testing.T) {
+		// given
+		src := map[string]entities.AuditChange{
+			"name":      {Old: json.RawMessage(`"Cluster A"`), New: json.RawMessage(`"Cluster B"`)},
+			"region_id": {Old: json.RawMessage(`null`), New: json.RawMessage(`1`)},
+		}
+
+		// when
+		data, err := mapper.MapAuditChangesToByte(src)
+		assert.NoError(t, err)
+		got, err := mapper.MapAuditChanges(data)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, src, got)
+	})
+
+	t.Run("should store missing values as null", func(t *testing.T) {
+		// given
+		src := map[string]entities.AuditChange{
+			"name": {New: json.RawMessage(`"Cluster A"`)},
+		}
+
+		// when
+		data, err := mapper.MapAuditChangesToByte(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":{"old":null,"new":"Cluster A"}}`, string(data))
+	})
+}
+
+var allTestAuditLogs = []*sqlc.AuditLog{
+	{
+		ID:         1,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now()},
+		EntityType: "tree",
+		EntityID:   1,
+		Action:     sqlc.AuditActionUpdate,
+		UserID:     "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+		Changes:    []byte(`{"species":{"old":"Quercus robur","new":"Tilia cordata"}}`),
+	},
+	{
+		ID:         2,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now()},
+		EntityType: "vehicle",
+		EntityID:   2,
+		Action:     sqlc.AuditActionDelete,
+		UserID:     "api-key:gek_1a2b3c",
+		Changes:    []byte(`{}`),
+	},
+}
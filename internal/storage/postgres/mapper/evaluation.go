@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgDateToTime
+// goverter:extend MapWateringPlanStatus MapWateringStatus MapPgUUID MapDurationSeconds
+type InternalEvaluationRepoMapper interface {
+	FromSqlWateringPlanFact(src *sqlc.GetEvaluationWateringPlanFactsRow) *entities.EvaluationWateringPlanFact
+	FromSqlWateringPlanFactList(src []*sqlc.GetEvaluationWateringPlanFactsRow) []*entities.EvaluationWateringPlanFact
+
+	FromSqlWateringPlanVehicle(src *sqlc.GetEvaluationWateringPlanVehiclesRow) *entities.EvaluationWateringPlanVehicle
+	FromSqlWateringPlanVehicleList(src []*sqlc.GetEvaluationWateringPlanVehiclesRow) []*entities.EvaluationWateringPlanVehicle
+
+	FromSqlWateringPlanUser(src *sqlc.GetEvaluationWateringPlanUsersRow) *entities.EvaluationWateringPlanUser
+	FromSqlWateringPlanUserList(src []*sqlc.GetEvaluationWateringPlanUsersRow) []*entities.EvaluationWateringPlanUser
+
+	FromSqlTreeStatusSnapshot(src *sqlc.GetTreeStatusSnapshotsRow) *entities.TreeStatusSnapshot
+	FromSqlTreeStatusSnapshotList(src []*sqlc.GetTreeStatusSnapshotsRow) []*entities.TreeStatusSnapshot
+}
+
+// MapDurationSeconds converts the duration of a watering plan, which is stored in seconds
+func MapDurationSeconds(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,118 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationMapper_FromSqlWateringPlanFact(t *testing.T) {
+	evaluationMapper := &generated.InternalEvaluationRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := &sqlc.GetEvaluationWateringPlanFactsRow{
+			WateringPlanID:  3,
+			Date:            pgtype.Date{Time: time.Date(2024, time.June, 12, 0, 0, 0, 0, time.UTC), Valid: true},
+			Status:          sqlc.WateringPlanStatusFinished,
+			Distance:        63.0,
+			Duration:        5400,
+			RefillCount:     2,
+			ClusterCount:    3,
+			TreeClusterID:   utils.P(int32(1)),
+			TreeClusterName: "Flensburger Stadion",
+			RegionID:        utils.P(int32(1)),
+			RegionName:      "Mürwik",
+			ConsumedWater:   10.0,
+			TreeCount:       3,
+		}
+
+		// when
+		got := evaluationMapper.FromSqlWateringPlanFact(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.WateringPlanID, got.WateringPlanID)
+		assert.Equal(t, src.Date.Time, got.Date)
+		assert.Equal(t, entities.WateringPlanStatusFinished, got.Status)
+		assert.Equal(t, src.Distance, got.Distance)
+		assert.Equal(t, 90*time.Minute, got.Duration)
+		assert.Equal(t, src.RefillCount, got.RefillCount)
+		assert.Equal(t, src.ClusterCount, got.ClusterCount)
+		assert.Equal(t, src.TreeClusterID, got.TreeClusterID)
+		assert.Equal(t, src.TreeClusterName, got.TreeClusterName)
+		assert.Equal(t, src.RegionID, got.RegionID)
+		assert.Equal(t, src.RegionName, got.RegionName)
+		assert.Equal(t, src.ConsumedWater, got.ConsumedWater)
+		assert.Equal(t, src.TreeCount, got.TreeCount)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.GetEvaluationWateringPlanFactsRow = nil
+
+		// when
+		got := evaluationMapper.FromSqlWateringPlanFact(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestEvaluationMapper_FromSqlWateringPlanUser(t *testing.T) {
+	evaluationMapper := &generated.InternalEvaluationRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		userID := uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+		src := &sqlc.GetEvaluationWateringPlanUsersRow{
+			WateringPlanID: 3,
+			UserID:         utils.UUIDToPGUUID(userID),
+		}
+
+		// when
+		got := evaluationMapper.FromSqlWateringPlanUser(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.WateringPlanID, got.WateringPlanID)
+		assert.Equal(t, userID, got.UserID)
+	})
+}
+
+func TestEvaluationMapper_FromSqlTreeStatusSnapshot(t *testing.T) {
+	evaluationMapper := &generated.InternalEvaluationRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := &sqlc.GetTreeStatusSnapshotsRow{
+			Date:            pgtype.Date{Time: time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+			TreeClusterID:   utils.P(int32(1)),
+			TreeClusterName: "Flensburger Stadion",
+			RegionID:        utils.P(int32(1)),
+			RegionName:      "Mürwik",
+			WateringStatus:  sqlc.WateringStatusGood,
+			TreeCount:       4,
+		}
+
+		// when
+		got := evaluationMapper.FromSqlTreeStatusSnapshot(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.Date.Time, got.Date)
+		assert.Equal(t, src.TreeClusterID, got.TreeClusterID)
+		assert.Equal(t, src.TreeClusterName, got.TreeClusterName)
+		assert.Equal(t, src.RegionID, got.RegionID)
+		assert.Equal(t, src.RegionName, got.RegionName)
+		assert.Equal(t, entities.WateringStatusGood, got.WateringStatus)
+		assert.Equal(t, src.TreeCount, got.TreeCount)
+	})
+}
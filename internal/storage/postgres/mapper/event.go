@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapEventType
+type InternalEventRepoMapper interface {
+	FromSqlEvent(src *sqlc.EventOutbox) *entities.OutboxEvent
+	FromSqlEventList(src []*sqlc.EventOutbox) []*entities.OutboxEvent
+	FromSqlSubscription(src *sqlc.EventSubscription) *entities.EventSubscription
+	FromSqlDeadLetter(src *sqlc.EventDeadLetter) *entities.EventDeadLetter
+	FromSqlDeadLetterList(src []*sqlc.EventDeadLetter) []*entities.EventDeadLetter
+}
@@ -0,0 +1,126 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventMapper_FromSqlEvent(t *testing.T) {
+	eventMapper := &generated.InternalEventRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := &sqlc.EventOutbox{
+			ID:        1,
+			CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+			EventType: "delete tree",
+			Payload:   []byte(`{"Prev":null}`),
+		}
+
+		// when
+		got := eventMapper.FromSqlEvent(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, entities.EventTypeDeleteTree, got.EventType)
+		assert.Equal(t, src.Payload, got.Payload)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.EventOutbox = nil
+
+		// when
+		got := eventMapper.FromSqlEvent(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestEventMapper_FromSqlSubscription(t *testing.T) {
+	eventMapper := &generated.InternalEventRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		lockedUntil := time.Now().Add(time.Minute)
+		src := &sqlc.EventSubscription{
+			Name:          "subscriber.UpdateTreeSubscriber/update tree",
+			CreatedAt:     pgtype.Timestamp{Time: time.Now(), Valid: true},
+			UpdatedAt:     pgtype.Timestamp{Time: time.Now(), Valid: true},
+			EventType:     "update tree",
+			LastEventID:   12,
+			Attempts:      2,
+			LastError:     utils.P("failed to handle event"),
+			NextAttemptAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+			LockedBy:      utils.P("backend-1a2b3c4d"),
+			LockedUntil:   pgtype.Timestamp{Time: lockedUntil, Valid: true},
+		}
+
+		// when
+		got := eventMapper.FromSqlSubscription(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.Name, got.Name)
+		assert.Equal(t, entities.EventTypeUpdateTree, got.EventType)
+		assert.Equal(t, src.LastEventID, got.LastEventID)
+		assert.Equal(t, src.Attempts, got.Attempts)
+		assert.Equal(t, src.LastError, got.LastError)
+		assert.Equal(t, src.NextAttemptAt.Time, got.NextAttemptAt)
+		assert.Equal(t, src.LockedBy, got.LockedBy)
+		assert.Equal(t, &lockedUntil, got.LockedUntil)
+	})
+
+	t.Run("should convert unlocked subscription", func(t *testing.T) {
+		// given
+		src := &sqlc.EventSubscription{
+			Name:      "subscriber.UpdateTreeSubscriber/update tree",
+			EventType: "update tree",
+		}
+
+		// when
+		got := eventMapper.FromSqlSubscription(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Nil(t, got.LastError)
+		assert.Nil(t, got.LockedBy)
+		assert.Nil(t, got.LockedUntil)
+	})
+}
+
+func TestEventMapper_FromSqlDeadLetterList(t *testing.T) {
+	eventMapper := &generated.InternalEventRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := []*sqlc.EventDeadLetter{
+			{ID: 1, Subscription: "a", EventID: 3, EventType: "update tree", Payload: []byte(`{}`), Attempts: 5, Error: "failed"},
+			{ID: 2, Subscription: "a", EventID: 4, EventType: "delete tree", Payload: []byte(`{}`), Attempts: 1, Error: "invalid payload"},
+		}
+
+		// when
+		got := eventMapper.FromSqlDeadLetterList(src)
+
+		// then
+		assert.Len(t, got, 2)
+		for i, src := range src {
+			assert.Equal(t, src.ID, got[i].ID)
+			assert.Equal(t, src.Subscription, got[i].Subscription)
+			assert.Equal(t, src.EventID, got[i].EventID)
+			assert.Equal(t, entities.EventType(src.EventType), got[i].EventType)
+			assert.Equal(t, src.Attempts, got[i].Attempts)
+			assert.Equal(t, src.Error, got[i].Error)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:MapAdditionalInfo
+// goverter:extend MapGeoJSONGeometry
+type InternalFeatureRepoMapper interface {
+	FromSql(src *sqlc.GetAllFeaturesRow) (*entities.OGCFeature, error)
+	FromSqlList(src []*sqlc.GetAllFeaturesRow) ([]*entities.OGCFeature, error)
+	FromSqlByID(src *sqlc.GetFeatureByIDRow) (*entities.OGCFeature, error)
+}
+
+// MapGeoJSONGeometry maps the geojson encoded geometry of the database, an empty string is a missing geometry
+func MapGeoJSONGeometry(src string) []byte {
+	if src == "" {
+		return nil
+	}
+	return []byte(src)
+}
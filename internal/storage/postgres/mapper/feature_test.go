@@ -0,0 +1,25 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapGeoJSONGeometry(t *testing.T) {
+	t.Run("should return geojson geometry", func(t *testing.T) {
+		// when
+		result := MapGeoJSONGeometry(`{"type":"Point","coordinates":[9.48,54.82]}`)
+
+		// then
+		assert.Equal(t, []byte(`{"type":"Point","coordinates":[9.48,54.82]}`), result)
+	})
+
+	t.Run("should return nil for missing geometry", func(t *testing.T) {
+		// when
+		result := MapGeoJSONGeometry("")
+
+		// then
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapJobRunTrigger MapJobRunStatus MapJobRunDuration
+type InternalJobRepoMapper interface {
+	// goverter:map DurationMs Duration
+	FromSqlRun(src *sqlc.JobRun) *entities.JobRun
+	FromSqlRunList(src []*sqlc.JobRun) []*entities.JobRun
+}
+
+func MapJobRunTrigger(src sqlc.JobRunTrigger) entities.JobRunTrigger {
+	return entities.JobRunTrigger(src)
+}
+
+func MapJobRunStatus(src sqlc.JobRunStatus) entities.JobRunStatus {
+	return entities.JobRunStatus(src)
+}
+
+func MapJobRunDuration(src *int64) *time.Duration {
+	if src == nil {
+		return nil
+	}
+	duration := time.Duration(*src) * time.Millisecond
+	return &duration
+}
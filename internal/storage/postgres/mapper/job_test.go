@@ -0,0 +1,83 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobMapper_FromSqlRun(t *testing.T) {
+	jobMapper := &generated.InternalJobRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		now := time.Now()
+		src := &sqlc.JobRun{
+			ID:          1,
+			CreatedAt:   pgtype.Timestamp{Time: now, Valid: true},
+			Job:         entities.JobSensorStatus,
+			Trigger:     sqlc.JobRunTriggerManual,
+			Status:      sqlc.JobRunStatusFailed,
+			Instance:    "backend-1",
+			RequestedBy: "api-key:1a2b3c",
+			StartedAt:   pgtype.Timestamp{Time: now, Valid: true},
+			FinishedAt:  pgtype.Timestamp{Time: now.Add(1500 * time.Millisecond), Valid: true},
+			DurationMs:  utils.P(int64(1500)),
+			Error:       utils.P("database error"),
+		}
+
+		// when
+		got := jobMapper.FromSqlRun(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, now, got.CreatedAt)
+		assert.Equal(t, entities.JobSensorStatus, got.Job)
+		assert.Equal(t, entities.JobRunTriggerManual, got.Trigger)
+		assert.Equal(t, entities.JobRunStatusFailed, got.Status)
+		assert.Equal(t, "backend-1", got.Instance)
+		assert.Equal(t, "api-key:1a2b3c", got.RequestedBy)
+		assert.Equal(t, now, *got.StartedAt)
+		assert.Equal(t, now.Add(1500*time.Millisecond), *got.FinishedAt)
+		assert.Equal(t, 1500*time.Millisecond, *got.Duration)
+		assert.Equal(t, "database error", *got.Error)
+	})
+
+	t.Run("should keep unfinished run without duration", func(t *testing.T) {
+		// given
+		src := &sqlc.JobRun{
+			ID:      2,
+			Job:     entities.JobTreeWateringStatus,
+			Trigger: sqlc.JobRunTriggerManual,
+			Status:  sqlc.JobRunStatusPending,
+		}
+
+		// when
+		got := jobMapper.FromSqlRun(src)
+
+		// then
+		assert.Equal(t, entities.JobRunStatusPending, got.Status)
+		assert.Nil(t, got.StartedAt)
+		assert.Nil(t, got.FinishedAt)
+		assert.Nil(t, got.Duration)
+		assert.Nil(t, got.Error)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.JobRun = nil
+
+		// when
+		got := jobMapper.FromSqlRun(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapPgUUID MapNotificationType MapNotificationChannel MapNotificationDeliveryStatus
+type InternalNotificationRepoMapper interface {
+	FromSql(src *sqlc.Notification) *entities.Notification
+	FromSqlList(src []*sqlc.Notification) []*entities.Notification
+
+	// goverter:ignore Notification
+	FromSqlDelivery(src *sqlc.NotificationDelivery) *entities.NotificationDelivery
+	FromSqlDeliveryList(src []*sqlc.NotificationDelivery) []*entities.NotificationDelivery
+
+	FromSqlPushSubscription(src *sqlc.PushSubscription) *entities.PushSubscription
+	FromSqlPushSubscriptionList(src []*sqlc.PushSubscription) []*entities.PushSubscription
+}
+
+func MapNotificationType(src string) entities.NotificationType {
+	return entities.NotificationType(src)
+}
+
+func MapNotificationChannel(src string) entities.NotificationChannel {
+	return entities.NotificationChannel(src)
+}
+
+func MapNotificationDeliveryStatus(src sqlc.NotificationDeliveryStatus) entities.NotificationDeliveryStatus {
+	return entities.NotificationDeliveryStatus(src)
+}
@@ -0,0 +1,85 @@
+package mapper
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend MapPluginScope MapPluginEventType
+type InternalPluginRepoMapper interface {
+	// goverter:map Path | MapPluginPath
+	// goverter:map . Auth | MapPluginAuth
+	// goverter:map . Manifest | MapPluginManifest
+	// goverter:map . Proxy | MapPluginProxy
+	// goverter:map . Requirements | MapPluginRequirements
+	// goverter:map ApiVersion APIVersion
+	FromSql(src *sqlc.Plugin) (*entities.Plugin, error)
+	FromSqlList(src []*sqlc.Plugin) ([]*entities.Plugin, error)
+
+	FromSqlGrant(src *sqlc.PluginGrant) *entities.PluginGrant
+	FromSqlGrantList(src []*sqlc.PluginGrant) []*entities.PluginGrant
+
+	FromSqlEvent(src *sqlc.PluginEvent) *entities.PluginEvent
+	FromSqlEventList(src []*sqlc.PluginEvent) []*entities.PluginEvent
+}
+
+func MapPluginPath(src string) (url.URL, error) {
+	path, err := url.Parse(src)
+	if err != nil {
+		return url.URL{}, err
+	}
+	return *path, nil
+}
+
+func MapPluginAuth(src *sqlc.Plugin) entities.AuthPlugin {
+	return entities.AuthPlugin{
+		ClientID: src.ClientID,
+	}
+}
+
+func MapPluginManifest(src *sqlc.Plugin) entities.PluginManifest {
+	roles := make([]entities.UserRole, len(src.RequiredRoles))
+	for i, role := range src.RequiredRoles {
+		roles[i] = entities.UserRole(role)
+	}
+
+	return entities.PluginManifest{
+		Entry:     src.UiEntry,
+		MenuLabel: src.MenuLabel,
+		Icon:      src.Icon,
+		Roles:     roles,
+	}
+}
+
+func MapPluginProxy(src *sqlc.Plugin) entities.PluginProxy {
+	return entities.PluginProxy{
+		Timeout:     time.Duration(src.ProxyTimeoutMs) * time.Millisecond,
+		MaxBodySize: src.ProxyMaxBodySize,
+	}
+}
+
+func MapPluginRequirements(src *sqlc.Plugin) entities.PluginRequirements {
+	features := make([]entities.PluginFeature, len(src.Features))
+	for i, feature := range src.Features {
+		features[i] = entities.PluginFeature(feature)
+	}
+
+	return entities.PluginRequirements{
+		MinAPIVersion: src.MinApiVersion,
+		MaxAPIVersion: src.MaxApiVersion,
+		Features:      features,
+	}
+}
+
+func MapPluginScope(src string) entities.PluginScope {
+	return entities.PluginScope(src)
+}
+
+func MapPluginEventType(src sqlc.PluginEventType) entities.PluginEventType {
+	return entities.PluginEventType(src)
+}
@@ -0,0 +1,240 @@
+package mapper_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginMapper_FromSql(t *testing.T) {
+	pluginMapper := &generated.InternalPluginRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestPlugins[0]
+
+		// when
+		got, err := pluginMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.NoError(t, err)
+		assert.Equal(t, src.Slug, got.Slug)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
+		assert.Equal(t, src.LastHeartbeat.Time, got.LastHeartbeat)
+		assert.Equal(t, src.Name, got.Name)
+		assert.Equal(t, src.Description, got.Description)
+		assert.Equal(t, src.Version, got.Version)
+		assert.Equal(t, src.Path, got.Path.String())
+		assert.Equal(t, src.ClientID, got.Auth.ClientID)
+		assert.Empty(t, got.Auth.ClientSecret)
+		assert.Len(t, got.Scopes, len(src.Scopes))
+		for i, scope := range src.Scopes {
+			assert.Equal(t, scope, string(got.Scopes[i]))
+		}
+		assert.Equal(t, src.UiEntry, got.Manifest.Entry)
+		assert.Equal(t, src.MenuLabel, got.Manifest.MenuLabel)
+		assert.Equal(t, src.Icon, got.Manifest.Icon)
+		assert.Equal(t, []entities.UserRole{entities.UserRoleTbz}, got.Manifest.Roles)
+		assert.Equal(t, 5*time.Second, got.Proxy.Timeout)
+		assert.Equal(t, src.ProxyMaxBodySize, got.Proxy.MaxBodySize)
+		assert.Equal(t, src.ApiVersion, got.APIVersion)
+		assert.Equal(t, src.MinApiVersion, got.Requirements.MinAPIVersion)
+		assert.Equal(t, []entities.PluginFeature{entities.PluginFeatureWebhooks}, got.Requirements.Features)
+	})
+
+	t.Run("should return error for invalid path", func(t *testing.T) {
+		// given
+		src := &sqlc.Plugin{Slug: "broken", Path: "://invalid"}
+
+		// when
+		got, err := pluginMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.Plugin = nil
+
+		// when
+		got, err := pluginMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPluginMapper_FromSqlList(t *testing.T) {
+	pluginMapper := &generated.InternalPluginRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestPlugins
+
+		// when
+		got, err := pluginMapper.FromSqlList(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+
+		for i, src := range src {
+			assert.Equal(t, src.Slug, got[i].Slug)
+			assert.Equal(t, src.Name, got[i].Name)
+			assert.Equal(t, src.Path, got[i].Path.String())
+			assert.Equal(t, src.ClientID, got[i].Auth.ClientID)
+		}
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src []*sqlc.Plugin = nil
+
+		// when
+		got, err := pluginMapper.FromSqlList(src)
+
+		// then
+		assert.Nil(t, got)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPluginMapper_FromSqlGrant(t *testing.T) {
+	pluginMapper := &generated.InternalPluginRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := &sqlc.PluginGrant{
+			Slug:      "csv-import",
+			CreatedAt: pgtype.Timestamp{Time: time.Now()},
+			UpdatedAt: pgtype.Timestamp{Time: time.Now()},
+			ClientID:  "csv-import-client",
+			Scopes:    []string{"tree:read", "tree:write"},
+		}
+
+		// when
+		got := pluginMapper.FromSqlGrant(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.Slug, got.Slug)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
+		assert.Equal(t, src.ClientID, got.ClientID)
+		assert.Equal(t, []entities.PluginScope{"tree:read", "tree:write"}, got.Scopes)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.PluginGrant = nil
+
+		// when
+		got := pluginMapper.FromSqlGrant(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestPluginMapper_FromSqlEvent(t *testing.T) {
+	pluginMapper := &generated.InternalPluginRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := &sqlc.PluginEvent{
+			ID:         1,
+			CreatedAt:  pgtype.Timestamp{Time: time.Now()},
+			PluginSlug: "csv-import",
+			Type:       sqlc.PluginEventTypeTimeout,
+			Version:    "v1.0.0",
+		}
+
+		// when
+		got := pluginMapper.FromSqlEvent(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.PluginSlug, got.PluginSlug)
+		assert.Equal(t, entities.PluginEventTypeTimeout, got.Type)
+		assert.Equal(t, src.Version, got.Version)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.PluginEvent = nil
+
+		// when
+		got := pluginMapper.FromSqlEvent(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestMapPluginEventType(t *testing.T) {
+	tests := []struct {
+		input    sqlc.PluginEventType
+		expected entities.PluginEventType
+	}{
+		{input: sqlc.PluginEventTypeRegister, expected: entities.PluginEventTypeRegister},
+		{input: sqlc.PluginEventTypeUnregister, expected: entities.PluginEventTypeUnregister},
+		{input: sqlc.PluginEventTypeTimeout, expected: entities.PluginEventTypeTimeout},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("should return %v for input %v", test.expected, test.input), func(t *testing.T) {
+			result := mapper.MapPluginEventType(test.input)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+var allTestPlugins = []*sqlc.Plugin{
+	{
+		Slug:             "csv-import",
+		CreatedAt:        pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:        pgtype.Timestamp{Time: time.Now()},
+		Name:             "CSV Import",
+		Description:      "Import trees from csv files",
+		Version:          "v1.0.0",
+		Path:             "http://localhost:8080/plugins/csv-import",
+		ClientID:         "csv-import-client",
+		Scopes:           []string{"tree:read", "tree:write"},
+		LastHeartbeat:    pgtype.Timestamp{Time: time.Now()},
+		UiEntry:          "index.js",
+		MenuLabel:        "CSV Import",
+		Icon:             "file-up",
+		RequiredRoles:    []string{"tbz"},
+		ProxyTimeoutMs:   5000,
+		ProxyMaxBodySize: 1 << 20,
+		ApiVersion:       "v1",
+		MinApiVersion:    "v1",
+		Features:         []string{"webhooks"},
+	},
+	{
+		Slug:          "dashboard",
+		CreatedAt:     pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:     pgtype.Timestamp{Time: time.Now()},
+		Name:          "Dashboard",
+		Description:   "Shows statistics",
+		Version:       "v0.2.1",
+		Path:          "http://localhost:8081/",
+		ClientID:      "dashboard-client",
+		Scopes:        []string{"info:read"},
+		LastHeartbeat: pgtype.Timestamp{Time: time.Now()},
+	},
+}
@@ -0,0 +1,84 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapTreeImportFormat MapTreeImportStatus MapTreeImportMapping MapTreeImportRowErrors
+type InternalTreeImportRepoMapper interface {
+	FromSql(src *sqlc.TreeImportJob) (*entities.TreeImportJob, error)
+	FromSqlList(src []*sqlc.TreeImportJob) ([]*entities.TreeImportJob, error)
+}
+
+// treeImportRowError is the json representation of a row error in the database
+type treeImportRowError struct {
+	Row     int32  `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func MapTreeImportFormat(src string) entities.TreeImportFormat {
+	return entities.TreeImportFormat(src)
+}
+
+func MapTreeImportStatus(src sqlc.TreeImportStatus) entities.TreeImportStatus {
+	return entities.TreeImportStatus(src)
+}
+
+func MapTreeImportMapping(src []byte) (entities.TreeImportMapping, error) {
+	mapping := make(entities.TreeImportMapping)
+	if len(src) == 0 {
+		return mapping, nil
+	}
+
+	if err := json.Unmarshal(src, &mapping); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+func MapTreeImportMappingToByte(src entities.TreeImportMapping) ([]byte, error) {
+	if src == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(src)
+}
+
+func MapTreeImportRowErrors(src []byte) ([]entities.TreeImportRowError, error) {
+	if len(src) == 0 {
+		return []entities.TreeImportRowError{}, nil
+	}
+
+	var rowErrors []treeImportRowError
+	if err := json.Unmarshal(src, &rowErrors); err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.TreeImportRowError, len(rowErrors))
+	for i, e := range rowErrors {
+		result[i] = entities.TreeImportRowError{
+			Row:     e.Row,
+			Field:   entities.TreeImportField(e.Field),
+			Message: e.Message,
+		}
+	}
+	return result, nil
+}
+
+func MapTreeImportRowErrorsToByte(src []entities.TreeImportRowError) ([]byte, error) {
+	rowErrors := make([]treeImportRowError, len(src))
+	for i, e := range src {
+		rowErrors[i] = treeImportRowError{
+			Row:     e.Row,
+			Field:   string(e.Field),
+			Message: e.Message,
+		}
+	}
+	return json.Marshal(rowErrors)
+}
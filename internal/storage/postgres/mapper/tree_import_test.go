@@ -0,0 +1,147 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeImportMapper_FromSql(t *testing.T) {
+	treeImportMapper := &generated.InternalTreeImportRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestTreeImportJobs[0]
+
+		// when
+		got, err := treeImportMapper.FromSql(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.FileName, got.FileName)
+		assert.Equal(t, entities.TreeImportFormatCSV, got.Format)
+		assert.Equal(t, entities.TreeImportStatusInvalid, got.Status)
+		assert.Equal(t, entities.TreeImportMapping{entities.TreeImportFieldNumber: "Baumnummer"}, got.Mapping)
+		assert.Equal(t, []entities.TreeImportRowError{
+			{Row: 2, Field: entities.TreeImportFieldPlantingYear, Message: `"abc" is not a valid year`},
+		}, got.RowErrors)
+		assert.Equal(t, src.TotalRows, got.TotalRows)
+		assert.Equal(t, src.ValidRows, got.ValidRows)
+		assert.Nil(t, got.Error)
+	})
+
+	t.Run("should return error on invalid json", func(t *testing.T) {
+		// given
+		src := *allTestTreeImportJobs[0]
+		src.RowErrors = []byte("not json")
+
+		// when
+		got, err := treeImportMapper.FromSql(&src)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.TreeImportJob = nil
+
+		// when
+		got, err := treeImportMapper.FromSql(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTreeImportMapper_FromSqlList(t *testing.T) {
+	treeImportMapper := &generated.InternalTreeImportRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestTreeImportJobs
+
+		// when
+		got, err := treeImportMapper.FromSqlList(src)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		for i, src := range src {
+			assert.Equal(t, src.ID, got[i].ID)
+			assert.Equal(t, src.FileName, got[i].FileName)
+		}
+		assert.Empty(t, got[1].Mapping)
+		assert.Empty(t, got[1].RowErrors)
+		assert.Equal(t, "file is broken", *got[1].Error)
+	})
+}
+
+func TestTreeImportMapper_RowErrorsToByte(t *testing.T) {
+	t.Run("should convert row errors back and forth", func(t *testing.T) {
+		// given
+		src := []entities.TreeImportRowError{
+			{Row: 1, Field: entities.TreeImportFieldNumber, Message: "failed on the \"required\" rule"},
+			{Row: 3, Field: entities.TreeImportFieldSensorID, Message: "sensor \"sensor-1\" does not exist"},
+		}
+
+		// when
+		data, err := mapper.MapTreeImportRowErrorsToByte(src)
+		assert.NoError(t, err)
+		got, err := mapper.MapTreeImportRowErrors(data)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, src, got)
+	})
+
+	t.Run("should convert empty row errors to empty json array", func(t *testing.T) {
+		// when
+		data, err := mapper.MapTreeImportRowErrorsToByte(nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(data))
+	})
+}
+
+var allTestTreeImportJobs = []*sqlc.TreeImportJob{
+	{
+		ID:        1,
+		CreatedAt: pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt: pgtype.Timestamp{Time: time.Now()},
+		FileName:  "trees.csv",
+		Format:    "csv",
+		Status:    sqlc.TreeImportStatusInvalid,
+		Mapping:   []byte(`{"number":"Baumnummer"}`),
+		Provider:  "city",
+		TotalRows: 2,
+		ValidRows: 1,
+		RowErrors: []byte(`[{"row":2,"field":"planting_year","message":"\"abc\" is not a valid year"}]`),
+		CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+	},
+	{
+		ID:        2,
+		CreatedAt: pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt: pgtype.Timestamp{Time: time.Now()},
+		FileName:  "trees.gpkg",
+		Format:    "gpkg",
+		Status:    sqlc.TreeImportStatusFailed,
+		Mapping:   []byte(`{}`),
+		RowErrors: []byte(`[]`),
+		Error:     utils.P("file is broken"),
+		CreatedBy: "6a1078e8-80fd-458f-b74e-e388fe2dd6ab",
+	},
+}
@@ -9,6 +9,7 @@ import (
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:StringPtrToString
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:GeomToWKT
 // goverter:extend MapWateringStatus MapSoilCondition
 // goverter:ignoreMissing
 type InternalTreeClusterRepoMapper interface {
@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgDateToTime
+// goverter:extend MapPgUUID
+type InternalWateringPlanTemplateRepoMapper interface {
+	// goverter:map Rrule RRule
+	// goverter:ignore TreeClusterIDs
+	// goverter:map UserIds UserIDs
+	FromSql(src *sqlc.WateringPlanTemplate) *entities.WateringPlanTemplate
+	FromSqlList(src []*sqlc.WateringPlanTemplate) []*entities.WateringPlanTemplate
+
+	FromSqlOccurrence(src *sqlc.WateringPlanTemplateOccurrence) *entities.WateringPlanTemplateOccurrence
+	FromSqlOccurrenceList(src []*sqlc.WateringPlanTemplateOccurrence) []*entities.WateringPlanTemplateOccurrence
+}
+
+func MapPgUUID(src pgtype.UUID) uuid.UUID {
+	return uuid.UUID(src.Bytes)
+}
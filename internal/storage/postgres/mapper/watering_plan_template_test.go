@@ -0,0 +1,88 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWateringPlanTemplateMapper_FromSql(t *testing.T) {
+	templateMapper := &generated.InternalWateringPlanTemplateRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		userID := uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+		src := &sqlc.WateringPlanTemplate{
+			ID:            1,
+			CreatedAt:     pgtype.Timestamp{Time: time.Now()},
+			UpdatedAt:     pgtype.Timestamp{Time: time.Now()},
+			Name:          "Tuesday and friday round",
+			Rrule:         "FREQ=WEEKLY;BYDAY=TU,FR",
+			StartDate:     pgtype.Date{Time: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			SeasonStart:   "04-01",
+			SeasonEnd:     "09-30",
+			TransporterID: utils.P(int32(2)),
+			UserIds:       []pgtype.UUID{utils.UUIDToPGUUID(userID)},
+			Enabled:       true,
+		}
+
+		// when
+		got := templateMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.Name, got.Name)
+		assert.Equal(t, src.Rrule, got.RRule)
+		assert.Equal(t, src.StartDate.Time, got.StartDate)
+		assert.Equal(t, src.SeasonStart, got.SeasonStart)
+		assert.Equal(t, src.SeasonEnd, got.SeasonEnd)
+		assert.Empty(t, got.TreeClusterIDs)
+		assert.Equal(t, src.TransporterID, got.TransporterID)
+		assert.Nil(t, got.TrailerID)
+		assert.Equal(t, []uuid.UUID{userID}, got.UserIDs)
+		assert.True(t, got.Enabled)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.WateringPlanTemplate = nil
+
+		// when
+		got := templateMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestWateringPlanTemplateMapper_FromSqlOccurrence(t *testing.T) {
+	templateMapper := &generated.InternalWateringPlanTemplateRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := &sqlc.WateringPlanTemplateOccurrence{
+			TemplateID:     1,
+			Date:           pgtype.Date{Time: time.Date(2025, time.April, 18, 0, 0, 0, 0, time.UTC), Valid: true},
+			CreatedAt:      pgtype.Timestamp{Time: time.Now()},
+			WateringPlanID: utils.P(int32(7)),
+		}
+
+		// when
+		got := templateMapper.FromSqlOccurrence(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.TemplateID, got.TemplateID)
+		assert.Equal(t, src.Date.Time, got.Date)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.WateringPlanID, got.WateringPlanID)
+		assert.False(t, got.Holiday)
+	})
+}
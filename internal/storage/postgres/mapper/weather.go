@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgDateToTime
+// goverter:extend MapWateringStatus
+type InternalWeatherRepoMapper interface {
+	FromSqlLocation(src *sqlc.GetWeatherLocationsRow) *entities.WeatherLocation
+	FromSqlLocationList(src []*sqlc.GetWeatherLocationsRow) []*entities.WeatherLocation
+
+	// goverter:map PrecipitationMm Precipitation
+	// goverter:map Et0Mm ET0
+	FromSqlDay(src *sqlc.WeatherDay) *entities.WeatherDay
+	FromSqlDayList(src []*sqlc.WeatherDay) []*entities.WeatherDay
+
+	// goverter:map DeficitMm Deficit
+	FromSqlWaterBalance(src *sqlc.TreeClusterWaterBalance) *entities.WaterBalance
+	FromSqlWaterBalanceList(src []*sqlc.TreeClusterWaterBalance) []*entities.WaterBalance
+}
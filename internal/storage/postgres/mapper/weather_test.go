@@ -0,0 +1,85 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeatherMapper_FromSqlDay(t *testing.T) {
+	weatherMapper := &generated.InternalWeatherRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		now := time.Now()
+		src := &sqlc.WeatherDay{
+			RegionID:        1,
+			Date:            pgtype.Date{Time: date, Valid: true},
+			UpdatedAt:       pgtype.Timestamp{Time: now, Valid: true},
+			PrecipitationMm: 4.2,
+			TemperatureMin:  1.2,
+			TemperatureMax:  8.4,
+			TemperatureMean: 4.9,
+			Et0Mm:           0.8,
+			Forecast:        true,
+		}
+
+		// when
+		got := weatherMapper.FromSqlDay(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, int32(1), got.RegionID)
+		assert.Equal(t, date, got.Date)
+		assert.Equal(t, now, got.UpdatedAt)
+		assert.Equal(t, 4.2, got.Precipitation)
+		assert.Equal(t, 1.2, got.TemperatureMin)
+		assert.Equal(t, 8.4, got.TemperatureMax)
+		assert.Equal(t, 4.9, got.TemperatureMean)
+		assert.Equal(t, 0.8, got.ET0)
+		assert.True(t, got.Forecast)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.WeatherDay
+
+		// when
+		got := weatherMapper.FromSqlDay(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestWeatherMapper_FromSqlWaterBalanceList(t *testing.T) {
+	weatherMapper := &generated.InternalWeatherRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		src := []*sqlc.TreeClusterWaterBalance{
+			{TreeClusterID: 1, Date: pgtype.Date{Time: date, Valid: true}, DeficitMm: 12.5, WateringStatus: sqlc.WateringStatusGood},
+			{TreeClusterID: 1, Date: pgtype.Date{Time: date.AddDate(0, 0, 1), Valid: true}, DeficitMm: 40, WateringStatus: sqlc.WateringStatusBad, Forecast: true},
+		}
+
+		// when
+		got := weatherMapper.FromSqlWaterBalanceList(src)
+
+		// then
+		assert.Len(t, got, 2)
+		for i, src := range src {
+			assert.Equal(t, src.TreeClusterID, got[i].TreeClusterID)
+			assert.Equal(t, src.Date.Time, got[i].Date)
+			assert.Equal(t, src.DeficitMm, got[i].Deficit)
+			assert.Equal(t, entities.WateringStatus(src.WateringStatus), got[i].WateringStatus)
+			assert.Equal(t, src.Forecast, got[i].Forecast)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapEventType MapWebhookDeliveryStatus
+type InternalWebhookRepoMapper interface {
+	// goverter:map Url URL
+	FromSql(src *sqlc.Webhook) *entities.Webhook
+	FromSqlList(src []*sqlc.Webhook) []*entities.Webhook
+
+	FromSqlDelivery(src *sqlc.WebhookDelivery) *entities.WebhookDelivery
+	FromSqlDeliveryList(src []*sqlc.WebhookDelivery) []*entities.WebhookDelivery
+}
+
+func MapEventType(src string) entities.EventType {
+	return entities.EventType(src)
+}
+
+func MapWebhookDeliveryStatus(src sqlc.WebhookDeliveryStatus) entities.WebhookDeliveryStatus {
+	return entities.WebhookDeliveryStatus(src)
+}
@@ -0,0 +1,174 @@
+package mapper_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookMapper_FromSql(t *testing.T) {
+	webhookMapper := &generated.InternalWebhookRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		src := allTestWebhooks[0]
+
+		// when
+		got := webhookMapper.FromSql(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.CreatedAt.Time, got.CreatedAt)
+		assert.Equal(t, src.UpdatedAt.Time, got.UpdatedAt)
+		assert.Equal(t, src.Name, got.Name)
+		assert.Equal(t, src.Url, got.URL)
+		assert.Equal(t, src.Secret, got.Secret)
+		assert.Equal(t, src.Enabled, got.Enabled)
+		assert.Equal(t, []entities.EventType{entities.EventTypeCreateTree, entities.EventTypeUpdateTree}, got.EventTypes)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.Webhook = nil
+
+		// when
+		got := webhookMapper.FromSql(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestWebhookMapper_FromSqlList(t *testing.T) {
+	webhookMapper := &generated.InternalWebhookRepoMapperImpl{}
+
+	t.Run("should convert from sql slice to entity slice", func(t *testing.T) {
+		// given
+		src := allTestWebhooks
+
+		// when
+		got := webhookMapper.FromSqlList(src)
+
+		// then
+		assert.Len(t, got, 2)
+		for i, src := range src {
+			assert.Equal(t, src.ID, got[i].ID)
+			assert.Equal(t, src.Name, got[i].Name)
+			assert.Equal(t, src.Url, got[i].URL)
+		}
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src []*sqlc.Webhook = nil
+
+		// when
+		got := webhookMapper.FromSqlList(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestWebhookMapper_FromSqlDelivery(t *testing.T) {
+	webhookMapper := &generated.InternalWebhookRepoMapperImpl{}
+
+	t.Run("should convert from sql to entity", func(t *testing.T) {
+		// given
+		statusCode := int32(500)
+		lastError := "receiver responded with status 500"
+		src := &sqlc.WebhookDelivery{
+			ID:             1,
+			CreatedAt:      pgtype.Timestamp{Time: time.Now()},
+			UpdatedAt:      pgtype.Timestamp{Time: time.Now()},
+			WebhookID:      1,
+			EventID:        "3f1c5a52-2a4a-4c4e-9b9e-4d2f3f1c5a52",
+			EventType:      "update tree",
+			Payload:        []byte(`{"id":"3f1c5a52-2a4a-4c4e-9b9e-4d2f3f1c5a52"}`),
+			Status:         sqlc.WebhookDeliveryStatusRetrying,
+			Attempts:       2,
+			LastStatusCode: &statusCode,
+			LastError:      &lastError,
+			NextAttemptAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+			DeliveredAt:    pgtype.Timestamp{Valid: false},
+		}
+
+		// when
+		got := webhookMapper.FromSqlDelivery(src)
+
+		// then
+		assert.NotNil(t, got)
+		assert.Equal(t, src.ID, got.ID)
+		assert.Equal(t, src.WebhookID, got.WebhookID)
+		assert.Equal(t, src.EventID, got.EventID)
+		assert.Equal(t, entities.EventTypeUpdateTree, got.EventType)
+		assert.Equal(t, src.Payload, got.Payload)
+		assert.Equal(t, entities.WebhookDeliveryStatusRetrying, got.Status)
+		assert.Equal(t, src.Attempts, got.Attempts)
+		assert.Equal(t, statusCode, *got.LastStatusCode)
+		assert.Equal(t, lastError, *got.LastError)
+		assert.Equal(t, src.NextAttemptAt.Time, got.NextAttemptAt)
+		assert.Nil(t, got.DeliveredAt)
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		// given
+		var src *sqlc.WebhookDelivery = nil
+
+		// when
+		got := webhookMapper.FromSqlDelivery(src)
+
+		// then
+		assert.Nil(t, got)
+	})
+}
+
+func TestMapWebhookDeliveryStatus(t *testing.T) {
+	tests := []struct {
+		input    sqlc.WebhookDeliveryStatus
+		expected entities.WebhookDeliveryStatus
+	}{
+		{input: sqlc.WebhookDeliveryStatusPending, expected: entities.WebhookDeliveryStatusPending},
+		{input: sqlc.WebhookDeliveryStatusRetrying, expected: entities.WebhookDeliveryStatusRetrying},
+		{input: sqlc.WebhookDeliveryStatusSuccess, expected: entities.WebhookDeliveryStatusSuccess},
+		{input: sqlc.WebhookDeliveryStatusFailed, expected: entities.WebhookDeliveryStatusFailed},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("should return %v for input %v", test.expected, test.input), func(t *testing.T) {
+			result := mapper.MapWebhookDeliveryStatus(test.input)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+var allTestWebhooks = []*sqlc.Webhook{
+	{
+		ID:         1,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:  pgtype.Timestamp{Time: time.Now()},
+		Name:       "Irrigation system",
+		Url:        "https://example.com/hook",
+		Secret:     "0123456789abcdef0123456789abcdef",
+		EventTypes: []string{"create tree", "update tree"},
+		Enabled:    true,
+	},
+	{
+		ID:         2,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now()},
+		UpdatedAt:  pgtype.Timestamp{Time: time.Now()},
+		Name:       "Dashboard",
+		Url:        "http://localhost:8081/webhook",
+		Secret:     "fedcba9876543210fedcba9876543210",
+		EventTypes: []string{"receive sensor data"},
+		Enabled:    false,
+	},
+}
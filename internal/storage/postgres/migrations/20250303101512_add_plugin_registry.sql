@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE plugin_event_type AS ENUM ('register', 'unregister', 'timeout');
+
+CREATE TABLE IF NOT EXISTS plugin_grants (
+  slug TEXT PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  client_id TEXT NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE IF NOT EXISTS plugins (
+  slug TEXT PRIMARY KEY REFERENCES plugin_grants(slug) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  version TEXT NOT NULL DEFAULT '',
+  path TEXT NOT NULL,
+  client_id TEXT NOT NULL,
+  scopes TEXT[] NOT NULL DEFAULT '{}',
+  last_heartbeat TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS plugin_events (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  plugin_slug TEXT NOT NULL,
+  type plugin_event_type NOT NULL,
+  version TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_plugins_client_id ON plugins(client_id);
+CREATE INDEX IF NOT EXISTS idx_plugin_events_plugin_slug ON plugin_events(plugin_slug);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_plugin_grants_updated_at
+BEFORE UPDATE ON plugin_grants
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_plugins_updated_at
+BEFORE UPDATE ON plugins
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_plugins_updated_at ON plugins;
+DROP TRIGGER IF EXISTS update_plugin_grants_updated_at ON plugin_grants;
+DROP TABLE IF EXISTS plugin_events;
+DROP TABLE IF EXISTS plugins;
+DROP TABLE IF EXISTS plugin_grants;
+DROP TYPE IF EXISTS plugin_event_type;
+-- +goose StatementEnd
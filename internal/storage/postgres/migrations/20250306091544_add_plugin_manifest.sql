@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE plugins ADD COLUMN ui_entry TEXT NOT NULL DEFAULT '';
+ALTER TABLE plugins ADD COLUMN menu_label TEXT NOT NULL DEFAULT '';
+ALTER TABLE plugins ADD COLUMN icon TEXT NOT NULL DEFAULT '';
+ALTER TABLE plugins ADD COLUMN required_roles TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE plugins ADD COLUMN proxy_timeout_ms INT NOT NULL DEFAULT 0;
+ALTER TABLE plugins ADD COLUMN proxy_max_body_size BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE plugins DROP COLUMN IF EXISTS proxy_max_body_size;
+ALTER TABLE plugins DROP COLUMN IF EXISTS proxy_timeout_ms;
+ALTER TABLE plugins DROP COLUMN IF EXISTS required_roles;
+ALTER TABLE plugins DROP COLUMN IF EXISTS icon;
+ALTER TABLE plugins DROP COLUMN IF EXISTS menu_label;
+ALTER TABLE plugins DROP COLUMN IF EXISTS ui_entry;
+-- +goose StatementEnd
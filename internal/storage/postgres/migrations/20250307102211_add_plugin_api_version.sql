@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE plugins ADD COLUMN api_version TEXT NOT NULL DEFAULT 'v1';
+ALTER TABLE plugins ADD COLUMN min_api_version TEXT NOT NULL DEFAULT '';
+ALTER TABLE plugins ADD COLUMN max_api_version TEXT NOT NULL DEFAULT '';
+ALTER TABLE plugins ADD COLUMN features TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE plugins DROP COLUMN IF EXISTS features;
+ALTER TABLE plugins DROP COLUMN IF EXISTS max_api_version;
+ALTER TABLE plugins DROP COLUMN IF EXISTS min_api_version;
+ALTER TABLE plugins DROP COLUMN IF EXISTS api_version;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE tree_import_status AS ENUM ('pending', 'validating', 'validated', 'invalid', 'queued', 'committing', 'committed', 'failed');
+
+CREATE TABLE IF NOT EXISTS tree_import_jobs (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  file_name TEXT NOT NULL,
+  format TEXT NOT NULL,
+  status tree_import_status NOT NULL DEFAULT 'pending',
+  mapping JSONB NOT NULL DEFAULT '{}',
+  provider TEXT NOT NULL DEFAULT '',
+  total_rows INT NOT NULL DEFAULT 0,
+  valid_rows INT NOT NULL DEFAULT 0,
+  created_rows INT NOT NULL DEFAULT 0,
+  updated_rows INT NOT NULL DEFAULT 0,
+  row_errors JSONB NOT NULL DEFAULT '[]',
+  error TEXT,
+  created_by TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS tree_import_files (
+  job_id INT PRIMARY KEY REFERENCES tree_import_jobs(id) ON DELETE CASCADE,
+  data BYTEA NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tree_import_jobs_status ON tree_import_jobs(status) WHERE status IN ('pending', 'queued');
+CREATE INDEX IF NOT EXISTS idx_trees_provider_number ON trees(COALESCE(provider, ''), number);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_tree_import_jobs_updated_at
+BEFORE UPDATE ON tree_import_jobs
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_tree_import_jobs_updated_at ON tree_import_jobs;
+DROP INDEX IF EXISTS idx_trees_provider_number;
+DROP TABLE IF EXISTS tree_import_files;
+DROP TABLE IF EXISTS tree_import_jobs;
+DROP TYPE IF EXISTS tree_import_status;
+-- +goose StatementEnd
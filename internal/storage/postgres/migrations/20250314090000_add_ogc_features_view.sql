@@ -0,0 +1,86 @@
+-- +goose Up
+-- The point geometries of trees, tree clusters and sensors are stored as (latitude, longitude).
+-- The view flips them to (longitude, latitude) like the polygons of the regions, the indexes
+-- use the same expression so bbox filters on the view can use them.
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_trees_geometry_lon_lat ON trees USING GIST (ST_FlipCoordinates(geometry));
+CREATE INDEX IF NOT EXISTS idx_tree_clusters_geometry_lon_lat ON tree_clusters USING GIST (ST_FlipCoordinates(geometry));
+CREATE INDEX IF NOT EXISTS idx_sensors_geometry_lon_lat ON sensors USING GIST (ST_FlipCoordinates(geometry));
+CREATE INDEX IF NOT EXISTS idx_regions_geometry ON regions USING GIST (geometry);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW ogc_features AS
+SELECT
+  'trees'::TEXT AS collection,
+  t.id::TEXT AS id,
+  ST_FlipCoordinates(t.geometry) AS geometry,
+  t.updated_at,
+  jsonb_build_object(
+    'number', t.number,
+    'species', t.species,
+    'planting_year', t.planting_year,
+    'watering_status', t.watering_status,
+    'last_watered', t.last_watered,
+    'description', t.description,
+    'provider', t.provider,
+    'tree_cluster_id', t.tree_cluster_id,
+    'sensor_id', t.sensor_id
+  ) AS properties
+FROM trees t
+UNION ALL
+SELECT
+  'tree-clusters'::TEXT,
+  tc.id::TEXT,
+  ST_FlipCoordinates(tc.geometry),
+  tc.updated_at,
+  jsonb_build_object(
+    'name', tc.name,
+    'address', tc.address,
+    'description', tc.description,
+    'region', r.name,
+    'watering_status', tc.watering_status,
+    'moisture_level', tc.moisture_level,
+    'last_watered', tc.last_watered,
+    'soil_condition', tc.soil_condition,
+    'archived', tc.archived,
+    'provider', tc.provider
+  )
+FROM tree_clusters tc
+LEFT JOIN regions r ON r.id = tc.region_id
+UNION ALL
+SELECT
+  'sensors'::TEXT,
+  s.id,
+  ST_FlipCoordinates(s.geometry),
+  s.updated_at,
+  jsonb_build_object(
+    'status', s.status,
+    'provider', s.provider,
+    'latest_data_at', sd.created_at,
+    'battery', sd.data->'Battery',
+    'humidity', sd.data->'Humidity',
+    'temperature', sd.data->'Temperature'
+  )
+FROM sensors s
+LEFT JOIN LATERAL (
+  SELECT created_at, data FROM sensor_data WHERE sensor_id = s.id ORDER BY created_at DESC LIMIT 1
+) sd ON TRUE
+UNION ALL
+SELECT
+  'regions'::TEXT,
+  r.id::TEXT,
+  r.geometry,
+  r.updated_at,
+  jsonb_build_object('name', r.name)
+FROM regions r;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS ogc_features;
+DROP INDEX IF EXISTS idx_regions_geometry;
+DROP INDEX IF EXISTS idx_sensors_geometry_lon_lat;
+DROP INDEX IF EXISTS idx_tree_clusters_geometry_lon_lat;
+DROP INDEX IF EXISTS idx_trees_geometry_lon_lat;
+-- +goose StatementEnd
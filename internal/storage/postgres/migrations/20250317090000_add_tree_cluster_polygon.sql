@@ -0,0 +1,173 @@
+-- +goose Up
+-- The polygon is stored as (longitude, latitude) like the polygons of the regions. Unless it is
+-- drawn manually it is the concave hull of the trees of the cluster buffered by five meters.
+-- +goose StatementBegin
+ALTER TABLE tree_clusters ADD COLUMN polygon GEOMETRY(Polygon, 4326);
+ALTER TABLE tree_clusters ADD COLUMN polygon_manual BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tree_clusters ADD COLUMN area DOUBLE PRECISION;
+ALTER TABLE tree_clusters ADD COLUMN perimeter DOUBLE PRECISION;
+CREATE INDEX IF NOT EXISTS idx_tree_clusters_polygon ON tree_clusters USING GIST (polygon);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE tree_clusters tc SET polygon = hull.polygon
+FROM (
+  SELECT
+    t.tree_cluster_id,
+    ST_Buffer(ST_ConcaveHull(ST_Collect(ST_FlipCoordinates(t.geometry)), 0.8)::geography, 5)::geometry AS polygon
+  FROM trees t
+  WHERE t.tree_cluster_id IS NOT NULL AND t.geometry IS NOT NULL
+  GROUP BY t.tree_cluster_id
+) hull
+WHERE hull.tree_cluster_id = tc.id;
+
+UPDATE tree_clusters SET
+  area = ST_Area(polygon::geography),
+  perimeter = ST_Perimeter(polygon::geography)
+WHERE polygon IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW ogc_features AS
+SELECT
+  'trees'::TEXT AS collection,
+  t.id::TEXT AS id,
+  ST_FlipCoordinates(t.geometry) AS geometry,
+  t.updated_at,
+  jsonb_build_object(
+    'number', t.number,
+    'species', t.species,
+    'planting_year', t.planting_year,
+    'watering_status', t.watering_status,
+    'last_watered', t.last_watered,
+    'description', t.description,
+    'provider', t.provider,
+    'tree_cluster_id', t.tree_cluster_id,
+    'sensor_id', t.sensor_id
+  ) AS properties
+FROM trees t
+UNION ALL
+SELECT
+  'tree-clusters'::TEXT,
+  tc.id::TEXT,
+  COALESCE(tc.polygon, ST_FlipCoordinates(tc.geometry)),
+  tc.updated_at,
+  jsonb_build_object(
+    'name', tc.name,
+    'address', tc.address,
+    'description', tc.description,
+    'region', r.name,
+    'watering_status', tc.watering_status,
+    'moisture_level', tc.moisture_level,
+    'last_watered', tc.last_watered,
+    'soil_condition', tc.soil_condition,
+    'archived', tc.archived,
+    'provider', tc.provider,
+    'area', tc.area,
+    'perimeter', tc.perimeter
+  )
+FROM tree_clusters tc
+LEFT JOIN regions r ON r.id = tc.region_id
+UNION ALL
+SELECT
+  'sensors'::TEXT,
+  s.id,
+  ST_FlipCoordinates(s.geometry),
+  s.updated_at,
+  jsonb_build_object(
+    'status', s.status,
+    'provider', s.provider,
+    'latest_data_at', sd.created_at,
+    'battery', sd.data->'Battery',
+    'humidity', sd.data->'Humidity',
+    'temperature', sd.data->'Temperature'
+  )
+FROM sensors s
+LEFT JOIN LATERAL (
+  SELECT created_at, data FROM sensor_data WHERE sensor_id = s.id ORDER BY created_at DESC LIMIT 1
+) sd ON TRUE
+UNION ALL
+SELECT
+  'regions'::TEXT,
+  r.id::TEXT,
+  r.geometry,
+  r.updated_at,
+  jsonb_build_object('name', r.name)
+FROM regions r;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW ogc_features AS
+SELECT
+  'trees'::TEXT AS collection,
+  t.id::TEXT AS id,
+  ST_FlipCoordinates(t.geometry) AS geometry,
+  t.updated_at,
+  jsonb_build_object(
+    'number', t.number,
+    'species', t.species,
+    'planting_year', t.planting_year,
+    'watering_status', t.watering_status,
+    'last_watered', t.last_watered,
+    'description', t.description,
+    'provider', t.provider,
+    'tree_cluster_id', t.tree_cluster_id,
+    'sensor_id', t.sensor_id
+  ) AS properties
+FROM trees t
+UNION ALL
+SELECT
+  'tree-clusters'::TEXT,
+  tc.id::TEXT,
+  ST_FlipCoordinates(tc.geometry),
+  tc.updated_at,
+  jsonb_build_object(
+    'name', tc.name,
+    'address', tc.address,
+    'description', tc.description,
+    'region', r.name,
+    'watering_status', tc.watering_status,
+    'moisture_level', tc.moisture_level,
+    'last_watered', tc.last_watered,
+    'soil_condition', tc.soil_condition,
+    'archived', tc.archived,
+    'provider', tc.provider
+  )
+FROM tree_clusters tc
+LEFT JOIN regions r ON r.id = tc.region_id
+UNION ALL
+SELECT
+  'sensors'::TEXT,
+  s.id,
+  ST_FlipCoordinates(s.geometry),
+  s.updated_at,
+  jsonb_build_object(
+    'status', s.status,
+    'provider', s.provider,
+    'latest_data_at', sd.created_at,
+    'battery', sd.data->'Battery',
+    'humidity', sd.data->'Humidity',
+    'temperature', sd.data->'Temperature'
+  )
+FROM sensors s
+LEFT JOIN LATERAL (
+  SELECT created_at, data FROM sensor_data WHERE sensor_id = s.id ORDER BY created_at DESC LIMIT 1
+) sd ON TRUE
+UNION ALL
+SELECT
+  'regions'::TEXT,
+  r.id::TEXT,
+  r.geometry,
+  r.updated_at,
+  jsonb_build_object('name', r.name)
+FROM regions r;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tree_clusters_polygon;
+ALTER TABLE tree_clusters DROP COLUMN IF EXISTS perimeter;
+ALTER TABLE tree_clusters DROP COLUMN IF EXISTS area;
+ALTER TABLE tree_clusters DROP COLUMN IF EXISTS polygon_manual;
+ALTER TABLE tree_clusters DROP COLUMN IF EXISTS polygon;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- The version is increased with every update and is sent as the ETag of the entity. Updates and
+-- deletes with an If-Match header are rejected when the version does not match.
+-- +goose StatementBegin
+ALTER TABLE trees ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE tree_clusters ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE vehicles ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE watering_plans ADD COLUMN version INT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE watering_plans DROP COLUMN IF EXISTS version;
+ALTER TABLE vehicles DROP COLUMN IF EXISTS version;
+ALTER TABLE tree_clusters DROP COLUMN IF EXISTS version;
+ALTER TABLE trees DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- The published events are stored in the outbox and consumed by durable subscriptions. Every
+-- subscription keeps the id of the last handled event, so several instances of the backend can
+-- share the subscriptions and no event is lost on restart.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS event_outbox (
+  id BIGSERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS event_subscriptions (
+  name TEXT PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  event_type TEXT NOT NULL,
+  last_event_id BIGINT NOT NULL DEFAULT 0,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  locked_by TEXT,
+  locked_until TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS event_dead_letters (
+  id BIGSERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  subscription TEXT NOT NULL REFERENCES event_subscriptions(name) ON DELETE CASCADE,
+  event_id BIGINT NOT NULL,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  attempts INT NOT NULL,
+  error TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_event_type_id ON event_outbox(event_type, id);
+CREATE INDEX IF NOT EXISTS idx_event_dead_letters_subscription ON event_dead_letters(subscription);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_event_subscriptions_updated_at
+BEFORE UPDATE ON event_subscriptions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_event_subscriptions_updated_at ON event_subscriptions;
+DROP TABLE IF EXISTS event_dead_letters;
+DROP TABLE IF EXISTS event_subscriptions;
+DROP TABLE IF EXISTS event_outbox;
+-- +goose StatementEnd
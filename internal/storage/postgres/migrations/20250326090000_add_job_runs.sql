@@ -0,0 +1,31 @@
+-- +goose Up
+-- Every run of a scheduled job is recorded. Scheduled runs are started by the leader instance,
+-- manual runs are pending until the leader picks them up.
+-- +goose StatementBegin
+CREATE TYPE job_run_status AS ENUM ('pending', 'running', 'success', 'failed');
+CREATE TYPE job_run_trigger AS ENUM ('schedule', 'manual');
+
+CREATE TABLE IF NOT EXISTS job_runs (
+  id BIGSERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  job TEXT NOT NULL,
+  trigger job_run_trigger NOT NULL,
+  status job_run_status NOT NULL DEFAULT 'pending',
+  instance TEXT NOT NULL DEFAULT '',
+  requested_by TEXT NOT NULL DEFAULT '',
+  started_at TIMESTAMP,
+  finished_at TIMESTAMP,
+  duration_ms BIGINT,
+  error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_id ON job_runs(job, id DESC);
+CREATE INDEX IF NOT EXISTS idx_job_runs_status ON job_runs(status) WHERE status IN ('pending', 'running');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_runs;
+DROP TYPE IF EXISTS job_run_trigger;
+DROP TYPE IF EXISTS job_run_status;
+-- +goose StatementEnd
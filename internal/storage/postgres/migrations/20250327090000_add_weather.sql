@@ -0,0 +1,34 @@
+-- +goose Up
+-- The daily weather of a region is fetched from the weather provider at a point of the region.
+-- Forecast days are overwritten by the observed values once they have passed.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS weather_days (
+  region_id INT NOT NULL REFERENCES regions(id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  precipitation_mm FLOAT NOT NULL,
+  temperature_min FLOAT NOT NULL,
+  temperature_max FLOAT NOT NULL,
+  temperature_mean FLOAT NOT NULL,
+  et0_mm FLOAT NOT NULL,
+  forecast BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (region_id, date)
+);
+
+-- The soil water balance of a tree cluster is recomputed from the weather of its region every day.
+-- The deficit is the water missing in the root zone, 0 means the soil is at field capacity.
+CREATE TABLE IF NOT EXISTS tree_cluster_water_balances (
+  tree_cluster_id INT NOT NULL REFERENCES tree_clusters(id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  deficit_mm FLOAT NOT NULL,
+  watering_status watering_status NOT NULL,
+  forecast BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (tree_cluster_id, date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tree_cluster_water_balances;
+DROP TABLE IF EXISTS weather_days;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- A watering plan template creates a watering plan for every occurrence of its recurrence rule within the season.
+-- The season is a window of month and day, e.g. 05-01 to 09-30, an empty season lasts the whole year.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS watering_plan_templates (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  rrule TEXT NOT NULL,
+  start_date DATE NOT NULL,
+  season_start TEXT NOT NULL DEFAULT '',
+  season_end TEXT NOT NULL DEFAULT '',
+  tree_cluster_ids INT[] NOT NULL DEFAULT '{}',
+  transporter_id INT REFERENCES vehicles(id) ON DELETE SET NULL,
+  trailer_id INT REFERENCES vehicles(id) ON DELETE SET NULL,
+  user_ids UUID[] NOT NULL DEFAULT '{}',
+  enabled BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- Every materialized occurrence of a template is kept, so that it is not created again after its watering plan
+-- was moved, canceled or deleted. Occurrences on holidays are kept without watering plan.
+CREATE TABLE IF NOT EXISTS watering_plan_template_occurrences (
+  template_id INT NOT NULL REFERENCES watering_plan_templates(id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  watering_plan_id INT REFERENCES watering_plans(id) ON DELETE SET NULL,
+  holiday BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (template_id, date)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_watering_plan_templates_updated_at
+BEFORE UPDATE ON watering_plan_templates
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_watering_plan_templates_updated_at ON watering_plan_templates;
+DROP TABLE IF EXISTS watering_plan_template_occurrences;
+DROP TABLE IF EXISTS watering_plan_templates;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- The number of trees per tree cluster and watering status is recorded every day to evaluate the watering
+-- status over time. Trees without tree cluster are recorded without tree cluster.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tree_status_snapshots (
+  date DATE NOT NULL,
+  tree_cluster_id INT REFERENCES tree_clusters(id) ON DELETE CASCADE,
+  watering_status watering_status NOT NULL,
+  tree_count INT NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tree_status_snapshots_date_cluster_status_idx
+ON tree_status_snapshots (date, COALESCE(tree_cluster_id, 0), watering_status);
+
+CREATE INDEX IF NOT EXISTS watering_plans_date_idx ON watering_plans (date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS watering_plans_date_idx;
+DROP TABLE IF EXISTS tree_status_snapshots;
+-- +goose StatementEnd
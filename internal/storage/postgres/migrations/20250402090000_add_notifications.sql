@@ -0,0 +1,88 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE notification_delivery_status AS ENUM ('pending', 'sent', 'failed');
+
+CREATE TABLE IF NOT EXISTS notifications (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  user_id UUID NOT NULL,
+  type TEXT NOT NULL,
+  title TEXT NOT NULL,
+  body TEXT NOT NULL,
+  link TEXT NOT NULL DEFAULT '',
+  in_app BOOLEAN NOT NULL DEFAULT TRUE,
+  read_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC) WHERE in_app;
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  notification_id INT NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL,
+  channel TEXT NOT NULL,
+  status notification_delivery_status NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  send_after TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  locked_until TIMESTAMP,
+  sent_at TIMESTAMP,
+  last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due ON notification_deliveries(send_after) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS notification_settings (
+  user_id UUID PRIMARY KEY,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  quiet_hours_start TEXT NOT NULL DEFAULT '',
+  quiet_hours_end TEXT NOT NULL DEFAULT '',
+  digest BOOLEAN NOT NULL DEFAULT FALSE,
+  digest_time TEXT NOT NULL DEFAULT '07:00'
+);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+  user_id UUID NOT NULL REFERENCES notification_settings(user_id) ON DELETE CASCADE,
+  type TEXT NOT NULL,
+  channels TEXT[] NOT NULL DEFAULT '{}',
+  PRIMARY KEY (user_id, type)
+);
+
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  user_id UUID NOT NULL,
+  endpoint TEXT NOT NULL UNIQUE,
+  p256dh TEXT NOT NULL,
+  auth TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user_id ON push_subscriptions(user_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_notification_deliveries_updated_at
+BEFORE UPDATE ON notification_deliveries
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_notification_settings_updated_at
+BEFORE UPDATE ON notification_settings
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_notification_settings_updated_at ON notification_settings;
+DROP TRIGGER IF EXISTS update_notification_deliveries_updated_at ON notification_deliveries;
+DROP TABLE IF EXISTS push_subscriptions;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notification_settings;
+DROP TABLE IF EXISTS notification_deliveries;
+DROP TABLE IF EXISTS notifications;
+DROP TYPE IF EXISTS notification_delivery_status;
+-- +goose StatementEnd
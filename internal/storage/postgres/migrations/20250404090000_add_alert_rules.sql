@@ -0,0 +1,68 @@
+-- +goose Up
+-- An alert rule is evaluated by a scheduled job against the tree clusters or sensors. The condition of a rule has to
+-- hold for for_seconds before an alert fires, window_seconds is the planning horizon of tree_cluster_unplanned rules.
+-- +goose StatementBegin
+CREATE TYPE alert_status AS ENUM ('pending', 'firing', 'resolved');
+
+CREATE TABLE IF NOT EXISTS alert_rules (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  type TEXT NOT NULL,
+  severity TEXT NOT NULL DEFAULT 'warning',
+  region_ids INT[] NOT NULL DEFAULT '{}',
+  watering_statuses TEXT[] NOT NULL DEFAULT '{}',
+  threshold DOUBLE PRECISION,
+  window_seconds BIGINT NOT NULL DEFAULT 0,
+  for_seconds BIGINT NOT NULL DEFAULT 0,
+  notify BOOLEAN NOT NULL DEFAULT TRUE,
+  enabled BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- An alert tracks the condition of a rule for one tree cluster or sensor. There is at most one open alert per rule
+-- and subject, resolved alerts are kept as history.
+CREATE TABLE IF NOT EXISTS alerts (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  rule_id INT NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+  subject_type TEXT NOT NULL,
+  subject_id TEXT NOT NULL,
+  subject_name TEXT NOT NULL DEFAULT '',
+  status alert_status NOT NULL DEFAULT 'pending',
+  message TEXT NOT NULL DEFAULT '',
+  value DOUBLE PRECISION,
+  fired_at TIMESTAMP,
+  resolved_at TIMESTAMP,
+  acknowledged_at TIMESTAMP,
+  acknowledged_by TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_alerts_open ON alerts(rule_id, subject_type, subject_id) WHERE status <> 'resolved';
+CREATE INDEX IF NOT EXISTS idx_alerts_created_at ON alerts(created_at DESC);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_alert_rules_updated_at
+BEFORE UPDATE ON alert_rules
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER update_alerts_updated_at
+BEFORE UPDATE ON alerts
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_alerts_updated_at ON alerts;
+DROP TRIGGER IF EXISTS update_alert_rules_updated_at ON alert_rules;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS alert_rules;
+DROP TYPE IF EXISTS alert_status;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tree_import_jobs ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_tree_import_jobs_status;
+CREATE INDEX IF NOT EXISTS idx_tree_import_jobs_status ON tree_import_jobs(status) WHERE status IN ('pending', 'validating', 'queued', 'committing');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tree_import_jobs_status;
+CREATE INDEX IF NOT EXISTS idx_tree_import_jobs_status ON tree_import_jobs(status) WHERE status IN ('pending', 'queued');
+
+ALTER TABLE tree_import_jobs DROP COLUMN IF EXISTS claimed_at;
+-- +goose StatementEnd
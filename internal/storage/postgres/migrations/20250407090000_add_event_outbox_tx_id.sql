@@ -0,0 +1,23 @@
+-- +goose Up
+-- The id of an event is taken from the sequence before its transaction commits, so an event with a
+-- lower id can become visible after one with a higher id. The events are therefore consumed in the
+-- order of the transactions that appended them, and only once every older transaction has ended.
+-- +goose StatementBegin
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS tx_id BIGINT NOT NULL DEFAULT pg_current_xact_id()::text::bigint;
+ALTER TABLE event_subscriptions ADD COLUMN IF NOT EXISTS last_event_tx_id BIGINT NOT NULL DEFAULT 0;
+
+-- the stored events belong to the transaction of this migration now, the offsets are moved along
+UPDATE event_subscriptions SET last_event_tx_id = pg_current_xact_id()::text::bigint;
+
+DROP INDEX IF EXISTS idx_event_outbox_event_type_id;
+CREATE INDEX IF NOT EXISTS idx_event_outbox_event_type_position ON event_outbox(event_type, tx_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_event_outbox_event_type_position;
+CREATE INDEX IF NOT EXISTS idx_event_outbox_event_type_id ON event_outbox(event_type, id);
+
+ALTER TABLE event_subscriptions DROP COLUMN IF EXISTS last_event_tx_id;
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS tx_id;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- The tree clusters of a template are linked by a join table, so a deleted tree cluster is removed from the templates.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS watering_plan_template_tree_clusters (
+  template_id INT NOT NULL REFERENCES watering_plan_templates(id) ON DELETE CASCADE,
+  tree_cluster_id INT NOT NULL REFERENCES tree_clusters(id) ON DELETE CASCADE,
+  PRIMARY KEY (template_id, tree_cluster_id)
+);
+
+INSERT INTO watering_plan_template_tree_clusters (template_id, tree_cluster_id)
+SELECT t.id, tc.id
+FROM watering_plan_templates t
+JOIN tree_clusters tc ON tc.id = ANY(t.tree_cluster_ids)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE watering_plan_templates DROP COLUMN IF EXISTS tree_cluster_ids;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE watering_plan_templates ADD COLUMN IF NOT EXISTS tree_cluster_ids INT[] NOT NULL DEFAULT '{}';
+
+UPDATE watering_plan_templates t SET tree_cluster_ids = ARRAY(
+  SELECT tree_cluster_id FROM watering_plan_template_tree_clusters WHERE template_id = t.id ORDER BY tree_cluster_id
+);
+
+DROP TABLE IF EXISTS watering_plan_template_tree_clusters;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- A failed event is handled again, the notifications that were created for it before are kept.
+-- Notifications without an event, e.g. of the in-memory event bus, are never skipped.
+-- +goose StatementBegin
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS event_id BIGINT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_event_id_user_id_type ON notifications(event_id, user_id, type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_notifications_event_id_user_id_type;
+ALTER TABLE notifications DROP COLUMN IF EXISTS event_id;
+-- +goose StatementEnd
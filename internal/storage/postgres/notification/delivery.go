@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *NotificationRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int32) ([]*entities.NotificationDelivery, error) {
+	log := logger.GetLogger(ctx)
+	// the columns have no time zone, so all times of the deliveries are stored in utc
+	now = now.UTC()
+	lockedUntil := now.Add(lease)
+	rows, err := r.store.ClaimDueNotificationDeliveries(ctx, &sqlc.ClaimDueNotificationDeliveriesParams{
+		LockedUntil:   utils.TimeToPgTimestamp(&lockedUntil),
+		Now:           utils.TimeToPgTimestamp(&now),
+		MaxDeliveries: limit,
+	})
+	if err != nil {
+		log.Debug("failed to claim due notification deliveries in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.NotificationDelivery{})
+	}
+
+	deliveries := r.mapper.FromSqlDeliveryList(rows)
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	ids := utils.Map(deliveries, func(d *entities.NotificationDelivery) int32 { return d.NotificationID })
+	notifications, err := r.store.GetNotificationsByIDs(ctx, ids)
+	if err != nil {
+		log.Debug("failed to get notifications of deliveries in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.Notification{})
+	}
+
+	byID := make(map[int32]*entities.Notification, len(notifications))
+	for _, n := range r.mapper.FromSqlList(notifications) {
+		byID[n.ID] = n
+	}
+	for _, d := range deliveries {
+		d.Notification = byID[d.NotificationID]
+	}
+
+	return deliveries, nil
+}
+
+func (r *NotificationRepository) UpdateDelivery(ctx context.Context, delivery *entities.NotificationDelivery) error {
+	log := logger.GetLogger(ctx)
+	sendAfter := delivery.SendAfter.UTC()
+	var sentAt *time.Time
+	if delivery.SentAt != nil {
+		t := delivery.SentAt.UTC()
+		sentAt = &t
+	}
+
+	err := r.store.UpdateNotificationDelivery(ctx, &sqlc.UpdateNotificationDeliveryParams{
+		ID:        delivery.ID,
+		Status:    sqlc.NotificationDeliveryStatus(delivery.Status),
+		Attempts:  delivery.Attempts,
+		SendAfter: utils.TimeToPgTimestamp(&sendAfter),
+		SentAt:    utils.TimeToPgTimestamp(sentAt),
+		LastError: delivery.LastError,
+	})
+	if err != nil {
+		log.Error("failed to update notification delivery in db", "error", err, "delivery_id", delivery.ID)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
+	"github.com/jackc/pgx/v5"
+)
+
+var _ storage.NotificationRepository = (*NotificationRepository)(nil)
+
+type NotificationRepository struct {
+	store *store.Store
+	NotificationRepositoryMappers
+}
+
+type NotificationRepositoryMappers struct {
+	mapper mapper.InternalNotificationRepoMapper
+}
+
+func NewNotificationRepositoryMappers(nMapper mapper.InternalNotificationRepoMapper) NotificationRepositoryMappers {
+	return NotificationRepositoryMappers{
+		mapper: nMapper,
+	}
+}
+
+func NewNotificationRepository(s *store.Store, mappers NotificationRepositoryMappers) *NotificationRepository {
+	return &NotificationRepository{
+		store:                         s,
+		NotificationRepositoryMappers: mappers,
+	}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *entities.Notification, deliveries []*entities.NotificationDelivery) (*entities.Notification, error) {
+	log := logger.GetLogger(ctx)
+	var created *entities.Notification
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		id, err := s.CreateNotification(ctx, &sqlc.CreateNotificationParams{
+			UserID:  utils.UUIDToPGUUID(notification.UserID),
+			Type:    string(notification.Type),
+			Title:   notification.Title,
+			Body:    notification.Body,
+			Link:    notification.Link,
+			InApp:   notification.InApp,
+			EventID: notification.EventID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the notification was created when the event was handled before, its deliveries are already stored
+			row, err := s.GetNotificationByEventID(ctx, &sqlc.GetNotificationByEventIDParams{
+				EventID: notification.EventID,
+				UserID:  utils.UUIDToPGUUID(notification.UserID),
+				Type:    string(notification.Type),
+			})
+			if err != nil {
+				return err
+			}
+			log.Debug("notification of event already exists", "notification_id", row.ID, "event_id", *notification.EventID)
+			created = r.mapper.FromSql(row)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, d := range deliveries {
+			// the quiet hours are computed in the time zone of the notifications, the column has no time zone
+			sendAfter := d.SendAfter.UTC()
+			err := s.CreateNotificationDelivery(ctx, &sqlc.CreateNotificationDeliveryParams{
+				NotificationID: id,
+				UserID:         utils.UUIDToPGUUID(notification.UserID),
+				Channel:        string(d.Channel),
+				SendAfter:      utils.TimeToPgTimestamp(&sendAfter),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		row, err := s.GetNotificationByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		created = r.mapper.FromSql(row)
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to create notification in db", "error", err, "user_id", notification.UserID, "notification_type", notification.Type)
+		return nil, err
+	}
+
+	log.Debug("notification created successfully in db", "notification_id", created.ID, "deliveries", len(deliveries))
+	return created, nil
+}
+
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, query entities.NotificationQuery) ([]*entities.Notification, int64, error) {
+	log := logger.GetLogger(ctx)
+	page, limit, err := pagination.GetValues(ctx)
+	if err != nil {
+		return nil, 0, r.store.MapError(err, sqlc.Notification{})
+	}
+
+	totalCount, err := r.store.GetAllNotificationsByUserIDCount(ctx, &sqlc.GetAllNotificationsByUserIDCountParams{
+		UserID: utils.UUIDToPGUUID(userID),
+		Unread: query.Unread,
+	})
+	if err != nil {
+		log.Debug("failed to count notifications in db", "error", err, "user_id", userID)
+		return nil, 0, r.store.MapError(err, sqlc.Notification{})
+	}
+
+	if totalCount == 0 {
+		return []*entities.Notification{}, 0, nil
+	}
+
+	if limit == -1 {
+		limit = int32(totalCount)
+		page = 1
+	}
+
+	rows, err := r.store.GetAllNotificationsByUserID(ctx, &sqlc.GetAllNotificationsByUserIDParams{
+		UserID: utils.UUIDToPGUUID(userID),
+		Unread: query.Unread,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		log.Debug("failed to get notifications in db", "error", err, "user_id", userID)
+		return nil, 0, r.store.MapError(err, sqlc.Notification{})
+	}
+
+	return r.mapper.FromSqlList(rows), totalCount, nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	log := logger.GetLogger(ctx)
+	count, err := r.store.GetAllNotificationsByUserIDCount(ctx, &sqlc.GetAllNotificationsByUserIDCountParams{
+		UserID: utils.UUIDToPGUUID(userID),
+		Unread: true,
+	})
+	if err != nil {
+		log.Debug("failed to count unread notifications in db", "error", err, "user_id", userID)
+		return 0, r.store.MapError(err, sqlc.Notification{})
+	}
+
+	return count, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID uuid.UUID, id int32, readAt time.Time) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.MarkNotificationRead(ctx, &sqlc.MarkNotificationReadParams{
+		ID:     id,
+		UserID: utils.UUIDToPGUUID(userID),
+		ReadAt: utils.TimeToPgTimestamp(&readAt),
+	})
+	if err != nil {
+		log.Debug("failed to mark notification as read in db", "error", err, "notification_id", id, "user_id", userID)
+		return r.store.MapError(err, sqlc.Notification{})
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.MarkAllNotificationsRead(ctx, &sqlc.MarkAllNotificationsReadParams{
+		UserID: utils.UUIDToPGUUID(userID),
+		ReadAt: utils.TimeToPgTimestamp(&readAt),
+	})
+	if err != nil {
+		log.Debug("failed to mark all notifications as read in db", "error", err, "user_id", userID)
+		return r.store.MapError(err, sqlc.Notification{})
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package notification
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	suite      *testutils.PostgresTestSuite
+	testUserID = uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+)
+
+func defaultNotificationMappers() NotificationRepositoryMappers {
+	return NewNotificationRepositoryMappers(&generated.InternalNotificationRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func createNotification(t *testing.T, r *NotificationRepository, inApp bool, deliveries ...*entities.NotificationDelivery) *entities.Notification {
+	t.Helper()
+	got, err := r.Create(context.Background(), &entities.Notification{
+		UserID: testUserID,
+		Type:   entities.NotificationTypeTreeClusterBad,
+		Title:  "Tree cluster Cluster A needs water",
+		Body:   "The watering status of the tree cluster Cluster A changed to bad.",
+		Link:   "/treecluster/1",
+		InApp:  inApp,
+	}, deliveries)
+	assert.NoError(t, err)
+	return got
+}
+
+func TestNotificationRepository_Create(t *testing.T) {
+	t.Run("should create notification of an event only once per user", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		notification := &entities.Notification{
+			UserID:  testUserID,
+			Type:    entities.NotificationTypeTreeClusterBad,
+			Title:   "Tree cluster Cluster A needs water",
+			InApp:   true,
+			EventID: utils.P(int64(42)),
+		}
+		delivery := &entities.NotificationDelivery{Channel: entities.NotificationChannelEmail, SendAfter: time.Now()}
+
+		// when
+		first, err := r.Create(context.Background(), notification, []*entities.NotificationDelivery{delivery})
+		assert.NoError(t, err)
+		second, err := r.Create(context.Background(), notification, []*entities.NotificationDelivery{delivery})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, utils.P(int64(42)), second.EventID)
+		got, totalCount, err := r.GetByUserID(context.Background(), testUserID, entities.NotificationQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totalCount)
+		assert.Len(t, got, 1)
+		claimed, err := r.ClaimDueDeliveries(context.Background(), time.Now().Add(time.Minute), 5*time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+	})
+}
+
+func TestNotificationRepository_Inbox(t *testing.T) {
+	t.Run("should return only in-app notifications of user", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		created := createNotification(t, r, true)
+		createNotification(t, r, false, &entities.NotificationDelivery{Channel: entities.NotificationChannelEmail, SendAfter: time.Now()})
+
+		// when
+		got, totalCount, err := r.GetByUserID(context.Background(), testUserID, entities.NotificationQuery{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totalCount)
+		assert.Len(t, got, 1)
+		assert.Equal(t, created.ID, got[0].ID)
+		assert.Equal(t, entities.NotificationTypeTreeClusterBad, got[0].Type)
+		assert.Nil(t, got[0].ReadAt)
+	})
+
+	t.Run("should mark notification as read", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		created := createNotification(t, r, true)
+		createNotification(t, r, true)
+
+		// when
+		err := r.MarkRead(context.Background(), testUserID, created.ID, time.Now())
+		unread, countErr := r.CountUnread(context.Background(), testUserID)
+		got, _, getErr := r.GetByUserID(context.Background(), testUserID, entities.NotificationQuery{Unread: true})
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, countErr)
+		assert.NoError(t, getErr)
+		assert.Equal(t, int64(1), unread)
+		assert.Len(t, got, 1)
+		assert.NotEqual(t, created.ID, got[0].ID)
+	})
+
+	t.Run("should return error when notification belongs to other user", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		created := createNotification(t, r, true)
+
+		// when
+		err := r.MarkRead(context.Background(), uuid.New(), created.ID, time.Now())
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+
+	t.Run("should mark all notifications as read", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		createNotification(t, r, true)
+		createNotification(t, r, true)
+
+		// when
+		err := r.MarkAllRead(context.Background(), testUserID, time.Now())
+		unread, countErr := r.CountUnread(context.Background(), testUserID)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, countErr)
+		assert.Zero(t, unread)
+	})
+}
+
+func TestNotificationRepository_Deliveries(t *testing.T) {
+	t.Run("should claim only due deliveries with their notification", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		now := time.Now()
+		created := createNotification(t, r, true,
+			&entities.NotificationDelivery{Channel: entities.NotificationChannelEmail, SendAfter: now.Add(-time.Minute)},
+			&entities.NotificationDelivery{Channel: entities.NotificationChannelPush, SendAfter: now.Add(time.Hour)},
+		)
+
+		// when
+		got, err := r.ClaimDueDeliveries(context.Background(), now, 5*time.Minute, 10)
+		again, againErr := r.ClaimDueDeliveries(context.Background(), now, 5*time.Minute, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, againErr)
+		assert.Len(t, got, 1)
+		assert.Equal(t, entities.NotificationChannelEmail, got[0].Channel)
+		assert.Equal(t, entities.NotificationDeliveryStatusPending, got[0].Status)
+		assert.Equal(t, testUserID, got[0].UserID)
+		assert.Equal(t, created.ID, got[0].Notification.ID)
+		assert.Empty(t, again, "claimed deliveries are locked")
+	})
+
+	t.Run("should release delivery after update", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		now := time.Now()
+		createNotification(t, r, false, &entities.NotificationDelivery{Channel: entities.NotificationChannelEmail, SendAfter: now.Add(-time.Minute)})
+		claimed, err := r.ClaimDueDeliveries(context.Background(), now, 5*time.Minute, 10)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+
+		// when
+		delivery := claimed[0]
+		delivery.Attempts = 1
+		delivery.SendAfter = now.Add(-time.Second)
+		errMsg := "connection refused"
+		delivery.LastError = &errMsg
+		err = r.UpdateDelivery(context.Background(), delivery)
+		got, claimErr := r.ClaimDueDeliveries(context.Background(), now, 5*time.Minute, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, claimErr)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(1), got[0].Attempts)
+		assert.Equal(t, errMsg, *got[0].LastError)
+	})
+}
+
+func TestNotificationRepository_Settings(t *testing.T) {
+	t.Run("should return error when user has no settings", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+
+		// when
+		got, err := r.GetSettings(context.Background(), testUserID)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+
+	t.Run("should save and replace settings", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		settings := entities.DefaultNotificationSettings(testUserID)
+		assert.NoError(t, r.SaveSettings(context.Background(), settings))
+
+		// when
+		settings.Channels = map[entities.NotificationType][]entities.NotificationChannel{
+			entities.NotificationTypeSensorOffline: {entities.NotificationChannelPush},
+		}
+		settings.QuietHoursStart = "22:00"
+		settings.QuietHoursEnd = "06:00"
+		settings.Digest = true
+		err := r.SaveSettings(context.Background(), settings)
+		got, getErr := r.GetSettings(context.Background(), testUserID)
+		all, allErr := r.GetAllSettings(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.NoError(t, allErr)
+		assert.Equal(t, settings.Channels, got.Channels)
+		assert.Equal(t, "22:00", got.QuietHoursStart)
+		assert.Equal(t, "06:00", got.QuietHoursEnd)
+		assert.True(t, got.Digest)
+		assert.Equal(t, "07:00", got.DigestTime)
+		assert.Len(t, all, 1)
+		assert.Equal(t, settings.Channels, all[0].Channels)
+	})
+}
+
+func TestNotificationRepository_PushSubscriptions(t *testing.T) {
+	t.Run("should replace subscription of endpoint and delete it", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewNotificationRepository(suite.Store, defaultNotificationMappers())
+		sub := &entities.PushSubscription{UserID: testUserID, Endpoint: "https://push.example.com/1", P256dh: "key", Auth: "auth"}
+		_, err := r.SavePushSubscription(context.Background(), sub)
+		assert.NoError(t, err)
+
+		// when
+		sub.Auth = "new-auth"
+		saved, err := r.SavePushSubscription(context.Background(), sub)
+		got, getErr := r.GetPushSubscriptions(context.Background(), testUserID)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Len(t, got, 1)
+		assert.Equal(t, saved.ID, got[0].ID)
+		assert.Equal(t, "new-auth", got[0].Auth)
+
+		// when
+		err = r.DeletePushSubscription(context.Background(), sub.Endpoint)
+		got, getErr = r.GetPushSubscriptions(context.Background(), testUserID)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Empty(t, got)
+	})
+}
@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *NotificationRepository) GetPushSubscriptions(ctx context.Context, userID uuid.UUID) ([]*entities.PushSubscription, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetPushSubscriptionsByUserID(ctx, utils.UUIDToPGUUID(userID))
+	if err != nil {
+		log.Debug("failed to get push subscriptions in db", "error", err, "user_id", userID)
+		return nil, r.store.MapError(err, sqlc.PushSubscription{})
+	}
+
+	return r.mapper.FromSqlPushSubscriptionList(rows), nil
+}
+
+func (r *NotificationRepository) SavePushSubscription(ctx context.Context, subscription *entities.PushSubscription) (*entities.PushSubscription, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.UpsertPushSubscription(ctx, &sqlc.UpsertPushSubscriptionParams{
+		UserID:   utils.UUIDToPGUUID(subscription.UserID),
+		Endpoint: subscription.Endpoint,
+		P256dh:   subscription.P256dh,
+		Auth:     subscription.Auth,
+	})
+	if err != nil {
+		log.Error("failed to save push subscription in db", "error", err, "user_id", subscription.UserID)
+		return nil, r.store.MapError(err, sqlc.PushSubscription{})
+	}
+
+	log.Debug("push subscription saved successfully in db", "push_subscription_id", row.ID, "user_id", subscription.UserID)
+	return r.mapper.FromSqlPushSubscription(row), nil
+}
+
+func (r *NotificationRepository) DeletePushSubscription(ctx context.Context, endpoint string) error {
+	log := logger.GetLogger(ctx)
+	if err := r.store.DeletePushSubscription(ctx, endpoint); err != nil {
+		log.Error("failed to delete push subscription in db", "error", err)
+		return r.store.MapError(err, sqlc.PushSubscription{})
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *NotificationRepository) GetSettings(ctx context.Context, userID uuid.UUID) (*entities.NotificationSettings, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetNotificationSettingsByUserID(ctx, utils.UUIDToPGUUID(userID))
+	if err != nil {
+		log.Debug("failed to get notification settings in db", "error", err, "user_id", userID)
+		return nil, r.store.MapError(err, sqlc.NotificationSetting{})
+	}
+
+	preferences, err := r.store.GetNotificationPreferencesByUserID(ctx, utils.UUIDToPGUUID(userID))
+	if err != nil {
+		log.Debug("failed to get notification preferences in db", "error", err, "user_id", userID)
+		return nil, r.store.MapError(err, sqlc.NotificationPreference{})
+	}
+
+	return mapSettings(row, preferences), nil
+}
+
+func (r *NotificationRepository) GetAllSettings(ctx context.Context) ([]*entities.NotificationSettings, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllNotificationSettings(ctx)
+	if err != nil {
+		log.Debug("failed to get all notification settings in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.NotificationSetting{})
+	}
+
+	preferences, err := r.store.GetAllNotificationPreferences(ctx)
+	if err != nil {
+		log.Debug("failed to get all notification preferences in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.NotificationPreference{})
+	}
+
+	byUser := make(map[uuid.UUID][]*sqlc.NotificationPreference)
+	for _, p := range preferences {
+		userID := uuid.UUID(p.UserID.Bytes)
+		byUser[userID] = append(byUser[userID], p)
+	}
+
+	return utils.Map(rows, func(row *sqlc.NotificationSetting) *entities.NotificationSettings {
+		return mapSettings(row, byUser[uuid.UUID(row.UserID.Bytes)])
+	}), nil
+}
+
+func (r *NotificationRepository) SaveSettings(ctx context.Context, settings *entities.NotificationSettings) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		userID := utils.UUIDToPGUUID(settings.UserID)
+		err := s.UpsertNotificationSettings(ctx, &sqlc.UpsertNotificationSettingsParams{
+			UserID:          userID,
+			QuietHoursStart: settings.QuietHoursStart,
+			QuietHoursEnd:   settings.QuietHoursEnd,
+			Digest:          settings.Digest,
+			DigestTime:      settings.DigestTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.DeleteNotificationPreferencesByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		for notificationType, channels := range settings.Channels {
+			err := s.CreateNotificationPreference(ctx, &sqlc.CreateNotificationPreferenceParams{
+				UserID: userID,
+				Type:   string(notificationType),
+				Channels: utils.Map(channels, func(c entities.NotificationChannel) string {
+					return string(c)
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to save notification settings in db", "error", err, "user_id", settings.UserID)
+		return err
+	}
+
+	log.Debug("notification settings saved successfully in db", "user_id", settings.UserID)
+	return nil
+}
+
+func mapSettings(row *sqlc.NotificationSetting, preferences []*sqlc.NotificationPreference) *entities.NotificationSettings {
+	channels := make(map[entities.NotificationType][]entities.NotificationChannel, len(preferences))
+	for _, p := range preferences {
+		channels[entities.NotificationType(p.Type)] = utils.Map(p.Channels, func(c string) entities.NotificationChannel {
+			return entities.NotificationChannel(c)
+		})
+	}
+
+	return &entities.NotificationSettings{
+		UserID:          uuid.UUID(row.UserID.Bytes),
+		UpdatedAt:       utils.PgTimestampToTime(row.UpdatedAt),
+		Channels:        channels,
+		QuietHoursStart: row.QuietHoursStart,
+		QuietHoursEnd:   row.QuietHoursEnd,
+		Digest:          row.Digest,
+		DigestTime:      row.DigestTime,
+	}
+}
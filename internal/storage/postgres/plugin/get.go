@@ -0,0 +1,99 @@
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (r *PluginRepository) GetAll(ctx context.Context) ([]*entities.Plugin, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllPlugins(ctx)
+	if err != nil {
+		log.Debug("failed to get plugins in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.Plugin{})
+	}
+
+	return r.mapper.FromSqlList(rows)
+}
+
+func (r *PluginRepository) GetBySlug(ctx context.Context, slug string) (*entities.Plugin, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetPluginBySlug(ctx, slug)
+	if err != nil {
+		log.Debug("failed to get plugin by slug in db", "error", err, "plugin_slug", slug)
+		return nil, r.store.MapError(err, sqlc.Plugin{})
+	}
+
+	return r.mapper.FromSql(row)
+}
+
+func (r *PluginRepository) GetByClientID(ctx context.Context, clientID string) (*entities.Plugin, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetPluginByClientID(ctx, clientID)
+	if err != nil {
+		log.Debug("failed to get plugin by client id in db", "error", err, "client_id", clientID)
+		return nil, r.store.MapError(err, sqlc.Plugin{})
+	}
+
+	return r.mapper.FromSql(row)
+}
+
+func (r *PluginRepository) GetAllWithHeartbeatBefore(ctx context.Context, before time.Time) ([]*entities.Plugin, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllPluginsWithHeartbeatBefore(ctx, pgtype.Timestamp{Time: before, Valid: true})
+	if err != nil {
+		log.Debug("failed to get plugins with outdated heartbeat in db", "error", err, "before", before)
+		return nil, r.store.MapError(err, sqlc.Plugin{})
+	}
+
+	return r.mapper.FromSqlList(rows)
+}
+
+func (r *PluginRepository) GetAllGrants(ctx context.Context) ([]*entities.PluginGrant, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllPluginGrants(ctx)
+	if err != nil {
+		log.Debug("failed to get plugin grants in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.PluginGrant{})
+	}
+
+	return r.mapper.FromSqlGrantList(rows), nil
+}
+
+func (r *PluginRepository) GetGrantBySlug(ctx context.Context, slug string) (*entities.PluginGrant, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetPluginGrantBySlug(ctx, slug)
+	if err != nil {
+		log.Debug("failed to get plugin grant by slug in db", "error", err, "plugin_slug", slug)
+		return nil, r.store.MapError(err, sqlc.PluginGrant{})
+	}
+
+	return r.mapper.FromSqlGrant(row), nil
+}
+
+func (r *PluginRepository) GetGrantByClientID(ctx context.Context, clientID string) (*entities.PluginGrant, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetPluginGrantByClientID(ctx, clientID)
+	if err != nil {
+		log.Debug("failed to get plugin grant by client id in db", "error", err, "client_id", clientID)
+		return nil, r.store.MapError(err, sqlc.PluginGrant{})
+	}
+
+	return r.mapper.FromSqlGrant(row), nil
+}
+
+func (r *PluginRepository) GetEventsBySlug(ctx context.Context, slug string) ([]*entities.PluginEvent, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllPluginEventsBySlug(ctx, slug)
+	if err != nil {
+		log.Debug("failed to get plugin events by slug in db", "error", err, "plugin_slug", slug)
+		return nil, r.store.MapError(err, sqlc.PluginEvent{})
+	}
+
+	return r.mapper.FromSqlEventList(rows), nil
+}
@@ -0,0 +1,172 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+var _ storage.PluginRepository = (*PluginRepository)(nil)
+
+type PluginRepository struct {
+	store *store.Store
+	PluginRepositoryMappers
+}
+
+type PluginRepositoryMappers struct {
+	mapper mapper.InternalPluginRepoMapper
+}
+
+func NewPluginRepositoryMappers(pMapper mapper.InternalPluginRepoMapper) PluginRepositoryMappers {
+	return PluginRepositoryMappers{
+		mapper: pMapper,
+	}
+}
+
+func NewPluginRepository(s *store.Store, mappers PluginRepositoryMappers) *PluginRepository {
+	return &PluginRepository{
+		store:                   s,
+		PluginRepositoryMappers: mappers,
+	}
+}
+
+func (r *PluginRepository) Register(ctx context.Context, plugin *entities.Plugin) (*entities.Plugin, error) {
+	log := logger.GetLogger(ctx)
+	var registered *entities.Plugin
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewPluginRepository(s, r.PluginRepositoryMappers)
+		slug, err := s.UpsertPlugin(ctx, &sqlc.UpsertPluginParams{
+			Slug:             plugin.Slug,
+			Name:             plugin.Name,
+			Description:      plugin.Description,
+			Version:          plugin.Version,
+			Path:             plugin.Path.String(),
+			ClientID:         plugin.Auth.ClientID,
+			Scopes:           mapScopes(plugin.Scopes),
+			UiEntry:          plugin.Manifest.Entry,
+			MenuLabel:        plugin.Manifest.MenuLabel,
+			Icon:             plugin.Manifest.Icon,
+			RequiredRoles:    mapRoles(plugin.Manifest.Roles),
+			ProxyTimeoutMs:   int32(plugin.Proxy.Timeout.Milliseconds()),
+			ProxyMaxBodySize: plugin.Proxy.MaxBodySize,
+			ApiVersion:       plugin.APIVersion,
+			MinApiVersion:    plugin.Requirements.MinAPIVersion,
+			MaxApiVersion:    plugin.Requirements.MaxAPIVersion,
+			Features:         mapFeatures(plugin.Requirements.Features),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := newRepo.createEvent(ctx, slug, entities.PluginEventTypeRegister, plugin.Version); err != nil {
+			return err
+		}
+
+		registered, err = newRepo.GetBySlug(ctx, slug)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to register plugin in db", "error", err, "plugin_slug", plugin.Slug)
+		return nil, err
+	}
+
+	log.Debug("plugin registered successfully in db", "plugin_slug", plugin.Slug)
+	return registered, nil
+}
+
+func (r *PluginRepository) Unregister(ctx context.Context, slug string, eventType entities.PluginEventType) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewPluginRepository(s, r.PluginRepositoryMappers)
+		plugin, err := newRepo.GetBySlug(ctx, slug)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.DeletePlugin(ctx, slug); err != nil {
+			return err
+		}
+
+		return newRepo.createEvent(ctx, slug, eventType, plugin.Version)
+	})
+
+	if err != nil {
+		log.Error("failed to unregister plugin in db", "error", err, "plugin_slug", slug)
+		return err
+	}
+
+	log.Debug("plugin unregistered successfully in db", "plugin_slug", slug, "event_type", eventType)
+	return nil
+}
+
+func (r *PluginRepository) UpdateHeartbeat(ctx context.Context, slug string) error {
+	log := logger.GetLogger(ctx)
+	if _, err := r.store.UpdatePluginHeartbeat(ctx, slug); err != nil {
+		log.Debug("failed to update plugin heartbeat in db", "error", err, "plugin_slug", slug)
+		return r.store.MapError(err, sqlc.Plugin{})
+	}
+
+	return nil
+}
+
+func (r *PluginRepository) SaveGrant(ctx context.Context, grant *entities.PluginGrant) (*entities.PluginGrant, error) {
+	log := logger.GetLogger(ctx)
+	slug, err := r.store.UpsertPluginGrant(ctx, &sqlc.UpsertPluginGrantParams{
+		Slug:     grant.Slug,
+		ClientID: grant.ClientID,
+		Scopes:   mapScopes(grant.Scopes),
+	})
+	if err != nil {
+		log.Error("failed to save plugin grant in db", "error", err, "plugin_slug", grant.Slug)
+		return nil, err
+	}
+
+	log.Debug("plugin grant saved successfully in db", "plugin_slug", slug)
+	return r.GetGrantBySlug(ctx, slug)
+}
+
+func (r *PluginRepository) DeleteGrant(ctx context.Context, slug string) error {
+	log := logger.GetLogger(ctx)
+	if _, err := r.store.DeletePluginGrant(ctx, slug); err != nil {
+		log.Error("failed to delete plugin grant in db", "error", err, "plugin_slug", slug)
+		return r.store.MapError(err, sqlc.PluginGrant{})
+	}
+
+	log.Debug("plugin grant deleted successfully in db", "plugin_slug", slug)
+	return nil
+}
+
+func (r *PluginRepository) createEvent(ctx context.Context, slug string, eventType entities.PluginEventType, version string) error {
+	_, err := r.store.CreatePluginEvent(ctx, &sqlc.CreatePluginEventParams{
+		PluginSlug: slug,
+		Type:       sqlc.PluginEventType(eventType),
+		Version:    version,
+	})
+	return err
+}
+
+func mapScopes(scopes []entities.PluginScope) []string {
+	return utils.Map(scopes, func(s entities.PluginScope) string {
+		return string(s)
+	})
+}
+
+func mapRoles(roles []entities.UserRole) []string {
+	return utils.Map(roles, func(r entities.UserRole) string {
+		return string(r)
+	})
+}
+
+func mapFeatures(features []entities.PluginFeature) []string {
+	return utils.Map(features, func(f entities.PluginFeature) string {
+		return string(f)
+	})
+}
@@ -0,0 +1,229 @@
+package plugin
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultPluginMappers() PluginRepositoryMappers {
+	return NewPluginRepositoryMappers(&generated.InternalPluginRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestPluginRepository_Register(t *testing.T) {
+	t.Run("should register plugin and record register event", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+		path, _ := url.Parse("http://localhost:8082/")
+		plugin := &entities.Plugin{
+			Slug:        "unused",
+			Name:        "Unused",
+			Description: "Unused plugin",
+			Version:     "v1.0.0",
+			Path:        *path,
+			Scopes:      []entities.PluginScope{},
+			Auth:        entities.AuthPlugin{ClientID: "unused-client"},
+			Manifest: entities.PluginManifest{
+				Entry:     "index.js",
+				MenuLabel: "Unused",
+				Roles:     []entities.UserRole{entities.UserRoleTbz},
+			},
+			Proxy:      entities.PluginProxy{Timeout: 5 * time.Second},
+			APIVersion: "v1",
+			Requirements: entities.PluginRequirements{
+				MinAPIVersion: "v1",
+				Features:      []entities.PluginFeature{entities.PluginFeatureWebhooks},
+			},
+		}
+
+		// when
+		got, err := r.Register(context.Background(), plugin)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, plugin.Slug, got.Slug)
+		assert.Equal(t, plugin.Name, got.Name)
+		assert.Equal(t, plugin.Path.String(), got.Path.String())
+		assert.Equal(t, plugin.Auth.ClientID, got.Auth.ClientID)
+		assert.NotZero(t, got.LastHeartbeat)
+		assert.Equal(t, plugin.Manifest, got.Manifest)
+		assert.Equal(t, plugin.Proxy, got.Proxy)
+		assert.Equal(t, plugin.APIVersion, got.APIVersion)
+		assert.Equal(t, plugin.Requirements, got.Requirements)
+
+		events, err := r.GetEventsBySlug(context.Background(), plugin.Slug)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, entities.PluginEventTypeRegister, events[0].Type)
+		assert.Equal(t, plugin.Version, events[0].Version)
+	})
+
+	t.Run("should replace existing registration", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+		existing, err := r.GetBySlug(context.Background(), "csv-import")
+		assert.NoError(t, err)
+		existing.Version = "v1.1.0"
+
+		// when
+		got, err := r.Register(context.Background(), existing)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.1.0", got.Version)
+	})
+
+	t.Run("should return error when plugin is not granted", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		got, err := r.Register(context.Background(), &entities.Plugin{Slug: "unknown", Name: "Unknown"})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestPluginRepository_Unregister(t *testing.T) {
+	t.Run("should unregister plugin and record event", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		err := r.Unregister(context.Background(), "dashboard", entities.PluginEventTypeTimeout)
+
+		// then
+		assert.NoError(t, err)
+		_, err = r.GetBySlug(context.Background(), "dashboard")
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+
+		events, err := r.GetEventsBySlug(context.Background(), "dashboard")
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, entities.PluginEventTypeTimeout, events[0].Type)
+		assert.Equal(t, "v0.2.1", events[0].Version)
+	})
+
+	t.Run("should return error when plugin is not registered", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		err := r.Unregister(context.Background(), "unused", entities.PluginEventTypeUnregister)
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+}
+
+func TestPluginRepository_GetAllWithHeartbeatBefore(t *testing.T) {
+	t.Run("should return plugins with outdated heartbeat", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		got, err := r.GetAllWithHeartbeatBefore(context.Background(), time.Now().Add(-5*time.Minute))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, "dashboard", got[0].Slug)
+	})
+}
+
+func TestPluginRepository_Grants(t *testing.T) {
+	t.Run("should get grant by client id", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		got, err := r.GetGrantByClientID(context.Background(), "csv-import-client")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "csv-import", got.Slug)
+		assert.Equal(t, []entities.PluginScope{"tree:read", "tree:write"}, got.Scopes)
+	})
+
+	t.Run("should save grant", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		got, err := r.SaveGrant(context.Background(), &entities.PluginGrant{
+			Slug:     "dashboard",
+			ClientID: "dashboard-client",
+			Scopes:   []entities.PluginScope{"info:read", "tree:read"},
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.PluginScope{"info:read", "tree:read"}, got.Scopes)
+	})
+
+	t.Run("should delete grant and registration", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		err := r.DeleteGrant(context.Background(), "csv-import")
+
+		// then
+		assert.NoError(t, err)
+		_, err = r.GetBySlug(context.Background(), "csv-import")
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+
+	t.Run("should return error when grant not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/plugin")
+		r := NewPluginRepository(suite.Store, defaultPluginMappers())
+
+		// when
+		err := r.DeleteGrant(context.Background(), "unknown")
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+}
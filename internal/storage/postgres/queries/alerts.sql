@@ -0,0 +1,78 @@
+-- name: GetAllAlertRules :many
+SELECT * FROM alert_rules ORDER BY id;
+
+-- name: GetAlertRuleByID :one
+SELECT * FROM alert_rules WHERE id = $1;
+
+-- name: CreateAlertRule :one
+INSERT INTO alert_rules (
+  name, description, type, severity, region_ids, watering_statuses, threshold, window_seconds, for_seconds, notify, enabled
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING id;
+
+-- name: UpdateAlertRule :exec
+UPDATE alert_rules SET
+  name = $2,
+  description = $3,
+  type = $4,
+  severity = $5,
+  region_ids = $6,
+  watering_statuses = $7,
+  threshold = $8,
+  window_seconds = $9,
+  for_seconds = $10,
+  notify = $11,
+  enabled = $12
+WHERE id = $1;
+
+-- name: DeleteAlertRule :one
+DELETE FROM alert_rules WHERE id = $1 RETURNING id;
+
+-- name: GetAllAlerts :many
+SELECT * FROM alerts
+WHERE
+  (cardinality(@statuses::TEXT[]) = 0 OR status::TEXT = ANY(@statuses::TEXT[]))
+  AND (sqlc.narg('rule_id')::INT IS NULL OR rule_id = sqlc.narg('rule_id')::INT)
+  AND (NOT @unacknowledged::BOOLEAN OR acknowledged_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetAllAlertsCount :one
+SELECT COUNT(*) FROM alerts
+WHERE
+  (cardinality(@statuses::TEXT[]) = 0 OR status::TEXT = ANY(@statuses::TEXT[]))
+  AND (sqlc.narg('rule_id')::INT IS NULL OR rule_id = sqlc.narg('rule_id')::INT)
+  AND (NOT @unacknowledged::BOOLEAN OR acknowledged_at IS NULL);
+
+-- name: GetAlertByID :one
+SELECT * FROM alerts WHERE id = $1;
+
+-- name: GetOpenAlertsByRuleID :many
+SELECT * FROM alerts WHERE rule_id = $1 AND status <> 'resolved' ORDER BY id;
+
+-- name: CreateAlert :one
+INSERT INTO alerts (
+  created_at, rule_id, subject_type, subject_id, subject_name, status, message, value, fired_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id;
+
+-- name: UpdateAlert :exec
+UPDATE alerts SET
+  subject_name = $2,
+  status = $3,
+  message = $4,
+  value = $5,
+  fired_at = $6,
+  resolved_at = $7
+WHERE id = $1;
+
+-- name: AcknowledgeAlert :one
+UPDATE alerts SET
+  acknowledged_at = COALESCE(acknowledged_at, $2),
+  acknowledged_by = COALESCE(acknowledged_by, $3)
+WHERE id = $1 RETURNING id;
+
+-- name: DeleteAlert :exec
+DELETE FROM alerts WHERE id = $1;
@@ -0,0 +1,29 @@
+-- name: GetAllAPIKeys :many
+SELECT * FROM api_keys ORDER BY id;
+
+-- name: GetAPIKeyByID :one
+SELECT * FROM api_keys WHERE id = $1;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT * FROM api_keys WHERE prefix = $1;
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+  name, prefix, key_hash, scopes, created_by, expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id;
+
+-- name: UpdateAPIKey :exec
+UPDATE api_keys SET
+  name = $2,
+  scopes = $3,
+  expires_at = $4,
+  revoked_at = $5
+WHERE id = $1;
+
+-- name: UpdateAPIKeyLastUsed :exec
+UPDATE api_keys SET last_used_at = $2 WHERE id = $1;
+
+-- name: DeleteAPIKey :one
+DELETE FROM api_keys WHERE id = $1 RETURNING id;
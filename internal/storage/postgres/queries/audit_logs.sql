@@ -0,0 +1,28 @@
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (
+  entity_type, entity_id, action, user_id, changes
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: GetAllAuditLogs :many
+SELECT * FROM audit_logs
+WHERE
+  (@entity_type::TEXT = '' OR entity_type = @entity_type::TEXT)
+  AND (sqlc.narg('entity_id')::INT IS NULL OR entity_id = sqlc.narg('entity_id')::INT)
+  AND (@user_id::TEXT = '' OR user_id = @user_id::TEXT)
+  AND (@action::TEXT = '' OR action::TEXT = @action::TEXT)
+  AND (sqlc.narg('from')::TIMESTAMP IS NULL OR created_at >= sqlc.narg('from')::TIMESTAMP)
+  AND (sqlc.narg('to')::TIMESTAMP IS NULL OR created_at <= sqlc.narg('to')::TIMESTAMP)
+ORDER BY created_at DESC, id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetAllAuditLogsCount :one
+SELECT COUNT(*) FROM audit_logs
+WHERE
+  (@entity_type::TEXT = '' OR entity_type = @entity_type::TEXT)
+  AND (sqlc.narg('entity_id')::INT IS NULL OR entity_id = sqlc.narg('entity_id')::INT)
+  AND (@user_id::TEXT = '' OR user_id = @user_id::TEXT)
+  AND (@action::TEXT = '' OR action::TEXT = @action::TEXT)
+  AND (sqlc.narg('from')::TIMESTAMP IS NULL OR created_at >= sqlc.narg('from')::TIMESTAMP)
+  AND (sqlc.narg('to')::TIMESTAMP IS NULL OR created_at <= sqlc.narg('to')::TIMESTAMP);
@@ -0,0 +1,60 @@
+-- name: GetEvaluationWateringPlanFacts :many
+SELECT
+  wp.id AS watering_plan_id,
+  wp.date,
+  wp.status,
+  COALESCE(wp.distance, 0)::FLOAT AS distance,
+  wp.duration,
+  wp.refill_count,
+  (SELECT COUNT(*) FROM tree_cluster_watering_plans c WHERE c.watering_plan_id = wp.id) AS cluster_count,
+  tc.id AS tree_cluster_id,
+  COALESCE(tc.name, '')::TEXT AS tree_cluster_name,
+  r.id AS region_id,
+  COALESCE(r.name, '')::TEXT AS region_name,
+  COALESCE(tcwp.consumed_water, 0)::FLOAT AS consumed_water,
+  (SELECT COUNT(*) FROM trees t WHERE t.tree_cluster_id = tc.id) AS tree_count
+FROM watering_plans wp
+LEFT JOIN tree_cluster_watering_plans tcwp ON tcwp.watering_plan_id = wp.id
+LEFT JOIN tree_clusters tc ON tc.id = tcwp.tree_cluster_id
+LEFT JOIN regions r ON r.id = tc.region_id
+WHERE wp.date BETWEEN sqlc.arg('from') AND sqlc.arg('to')
+ORDER BY wp.date, wp.id, tc.id;
+
+-- name: GetEvaluationWateringPlanVehicles :many
+SELECT vwp.watering_plan_id, v.id AS vehicle_id, v.number_plate
+FROM vehicle_watering_plans vwp
+JOIN watering_plans wp ON wp.id = vwp.watering_plan_id
+JOIN vehicles v ON v.id = vwp.vehicle_id
+WHERE wp.date BETWEEN sqlc.arg('from') AND sqlc.arg('to')
+ORDER BY vwp.watering_plan_id, v.id;
+
+-- name: GetEvaluationWateringPlanUsers :many
+SELECT uwp.watering_plan_id, uwp.user_id
+FROM user_watering_plans uwp
+JOIN watering_plans wp ON wp.id = uwp.watering_plan_id
+WHERE wp.date BETWEEN sqlc.arg('from') AND sqlc.arg('to')
+ORDER BY uwp.watering_plan_id, uwp.user_id;
+
+-- name: DeleteTreeStatusSnapshot :exec
+DELETE FROM tree_status_snapshots WHERE date = $1;
+
+-- name: CreateTreeStatusSnapshot :exec
+INSERT INTO tree_status_snapshots (date, tree_cluster_id, watering_status, tree_count)
+SELECT sqlc.arg('date')::DATE, t.tree_cluster_id, t.watering_status, COUNT(*)
+FROM trees t
+GROUP BY t.tree_cluster_id, t.watering_status;
+
+-- name: GetTreeStatusSnapshots :many
+SELECT
+  s.date,
+  s.tree_cluster_id,
+  COALESCE(tc.name, '')::TEXT AS tree_cluster_name,
+  r.id AS region_id,
+  COALESCE(r.name, '')::TEXT AS region_name,
+  s.watering_status,
+  s.tree_count
+FROM tree_status_snapshots s
+LEFT JOIN tree_clusters tc ON tc.id = s.tree_cluster_id
+LEFT JOIN regions r ON r.id = tc.region_id
+WHERE s.date BETWEEN sqlc.arg('from') AND sqlc.arg('to')
+ORDER BY s.date;
@@ -0,0 +1,117 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO event_outbox (
+  event_type, payload
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: NotifyOutboxEvent :exec
+SELECT pg_notify('event_outbox', @event_type::text);
+
+-- name: GetNextOutboxEvent :one
+-- The events are ordered by the transaction that appended them. Only the events of transactions
+-- older than the oldest running transaction are returned, no event can be appended before them anymore.
+SELECT * FROM event_outbox
+WHERE event_type = @event_type
+  AND (tx_id, id) > (@after_tx_id::bigint, @after_id::bigint)
+  AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+ORDER BY tx_id, id
+LIMIT 1;
+
+-- name: GetLatestOutboxEventID :one
+SELECT COALESCE((
+  SELECT id FROM event_outbox
+  WHERE tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+  ORDER BY tx_id DESC, id DESC
+  LIMIT 1
+), 0)::bigint;
+
+-- name: GetOutboxEventsAfter :many
+-- Returns the events after the position of the event with after_id, or with a higher id if the
+-- event has been deleted
+SELECT e.* FROM event_outbox e
+WHERE e.event_type = @event_type
+  AND e.tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+  AND COALESCE(
+    (SELECT (e.tx_id, e.id) > (a.tx_id, a.id) FROM event_outbox a WHERE a.id = @after_id::bigint),
+    e.id > @after_id::bigint
+  )
+ORDER BY e.tx_id, e.id
+LIMIT @max_events;
+
+-- name: DeleteProcessedOutboxEvents :execrows
+-- Deletes the events older than the given time that every subscription of the event type has
+-- handled. Subscriptions that have not been active since then do not hold back the cleanup.
+DELETE FROM event_outbox e
+WHERE e.created_at < @before
+  AND NOT EXISTS (
+    SELECT 1 FROM event_subscriptions s
+    WHERE s.event_type = e.event_type
+      AND (s.last_event_tx_id, s.last_event_id) < (e.tx_id, e.id)
+      AND s.updated_at >= @before
+  );
+
+-- name: CreateEventSubscription :exec
+-- A new subscription starts after the latest event, it does not replay the history of the outbox
+INSERT INTO event_subscriptions (
+  name, event_type, last_event_tx_id, last_event_id
+)
+SELECT @name::text, @event_type::text, COALESCE(latest.tx_id, 0), COALESCE(latest.id, 0)
+FROM (SELECT 1) AS one
+LEFT JOIN LATERAL (
+  SELECT tx_id, id FROM event_outbox
+  WHERE tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+  ORDER BY tx_id DESC, id DESC
+  LIMIT 1
+) AS latest ON true
+ON CONFLICT (name) DO NOTHING;
+
+-- name: GetEventSubscriptionByName :one
+SELECT * FROM event_subscriptions WHERE name = $1;
+
+-- name: ClaimEventSubscription :one
+-- Locks the subscription for the owner if it is not locked by another owner, is not waiting for
+-- a retry and has pending events
+UPDATE event_subscriptions s SET
+  locked_by = @owner::text,
+  locked_until = now() + make_interval(secs => @lease_seconds::float8)
+WHERE s.name = @name
+  AND (s.locked_until IS NULL OR s.locked_until < now() OR s.locked_by = @owner::text)
+  AND s.next_attempt_at <= now()
+  AND EXISTS (
+    SELECT 1 FROM event_outbox e
+    WHERE e.event_type = s.event_type
+      AND (e.tx_id, e.id) > (s.last_event_tx_id, s.last_event_id)
+      AND e.tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+  )
+RETURNING *;
+
+-- name: AckEventSubscription :execrows
+UPDATE event_subscriptions SET
+  last_event_tx_id = @event_tx_id,
+  last_event_id = @event_id,
+  attempts = 0,
+  last_error = NULL,
+  next_attempt_at = now(),
+  locked_by = NULL,
+  locked_until = NULL
+WHERE name = @name AND locked_by = @owner::text;
+
+-- name: RetryEventSubscription :execrows
+UPDATE event_subscriptions SET
+  attempts = attempts + 1,
+  last_error = @last_error::text,
+  next_attempt_at = now() + make_interval(secs => @delay_seconds::float8),
+  locked_by = NULL,
+  locked_until = NULL
+WHERE name = @name AND locked_by = @owner::text;
+
+-- name: CreateEventDeadLetter :exec
+INSERT INTO event_dead_letters (
+  subscription, event_id, event_type, payload, attempts, error
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+);
+
+-- name: GetAllEventDeadLettersBySubscription :many
+SELECT * FROM event_dead_letters WHERE subscription = $1 ORDER BY id;
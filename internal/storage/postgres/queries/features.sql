@@ -0,0 +1,66 @@
+-- name: GetAllFeatures :many
+SELECT id, COALESCE(ST_AsGeoJSON(geometry), '')::TEXT AS geometry, updated_at, properties
+FROM ogc_features
+WHERE collection = @collection
+  AND (
+    sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(geometry, ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326))
+  )
+  AND (sqlc.narg('start_time')::TIMESTAMP IS NULL OR updated_at >= sqlc.narg('start_time')::TIMESTAMP)
+  AND (sqlc.narg('end_time')::TIMESTAMP IS NULL OR updated_at <= sqlc.narg('end_time')::TIMESTAMP)
+  AND properties @> @properties::JSONB
+-- the ids of all collections except sensors are numbers
+ORDER BY length(id), id
+LIMIT @limit_count OFFSET @offset_count;
+
+-- name: GetAllFeaturesCount :one
+SELECT COUNT(*)
+FROM ogc_features
+WHERE collection = @collection
+  AND (
+    sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(geometry, ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326))
+  )
+  AND (sqlc.narg('start_time')::TIMESTAMP IS NULL OR updated_at >= sqlc.narg('start_time')::TIMESTAMP)
+  AND (sqlc.narg('end_time')::TIMESTAMP IS NULL OR updated_at <= sqlc.narg('end_time')::TIMESTAMP)
+  AND properties @> @properties::JSONB;
+
+-- name: GetFeatureByID :one
+SELECT id, COALESCE(ST_AsGeoJSON(geometry), '')::TEXT AS geometry, updated_at, properties
+FROM ogc_features
+WHERE collection = $1 AND id = $2;
+
+-- name: GetFeatureExtent :one
+SELECT
+  COUNT(geometry) AS geometry_count,
+  COALESCE(ST_XMin(ST_Extent(geometry)), 0)::FLOAT AS min_lon,
+  COALESCE(ST_YMin(ST_Extent(geometry)), 0)::FLOAT AS min_lat,
+  COALESCE(ST_XMax(ST_Extent(geometry)), 0)::FLOAT AS max_lon,
+  COALESCE(ST_YMax(ST_Extent(geometry)), 0)::FLOAT AS max_lat,
+  MIN(updated_at)::TIMESTAMP AS start_time,
+  MAX(updated_at)::TIMESTAMP AS end_time
+FROM ogc_features
+WHERE collection = $1;
+
+-- name: GetFeatureTile :one
+-- The tile is empty if no feature intersects it. The feature ids are written as attribute
+-- because ST_AsMVT only supports integer feature ids.
+WITH bounds AS (
+  SELECT ST_TileEnvelope(@z::INTEGER, @x::INTEGER, @y::INTEGER) AS geom
+),
+mvt AS (
+  SELECT
+    f.id,
+    (
+      SELECT COALESCE(jsonb_object_agg(p.key, p.value), '{}'::JSONB)
+      FROM jsonb_each(f.properties) p
+      WHERE p.key = ANY(@attributes::TEXT[]) AND p.value <> 'null'::JSONB
+    ) AS attributes,
+    ST_AsMVTGeom(ST_Simplify(ST_Transform(f.geometry, 3857), @tolerance::FLOAT, true), bounds.geom, 4096, 64, true) AS geom
+  FROM ogc_features f, bounds
+  WHERE f.collection = @collection::TEXT
+    AND f.geometry && ST_Transform(bounds.geom, 4326)
+)
+SELECT COALESCE(ST_AsMVT(mvt, @collection::TEXT, 4096, 'geom'), ''::BYTEA)::BYTEA AS tile
+FROM mvt
+WHERE geom IS NOT NULL;
@@ -0,0 +1,62 @@
+-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock(hashtext(@name::text));
+
+-- name: AdvisoryUnlock :one
+SELECT pg_advisory_unlock(hashtext(@name::text));
+
+-- name: CreateJobRun :one
+INSERT INTO job_runs (
+  job, trigger, status, instance, requested_by, started_at
+) VALUES (
+  $1, $2, $3, $4, $5, CASE WHEN $3 = 'running'::job_run_status THEN CURRENT_TIMESTAMP END
+) RETURNING *;
+
+-- name: StartJobRun :one
+UPDATE job_runs SET
+  status = 'running',
+  instance = $2,
+  started_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: FinishJobRun :one
+UPDATE job_runs SET
+  status = $2,
+  error = $3,
+  finished_at = CURRENT_TIMESTAMP,
+  duration_ms = (EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - started_at) * 1000)::BIGINT
+WHERE id = $1 AND status = 'running'
+RETURNING *;
+
+-- name: AbortRunningJobRuns :execrows
+-- Fails the runs of a previous leader that stopped while the job was running
+UPDATE job_runs SET
+  status = 'failed',
+  error = @reason::text,
+  finished_at = CURRENT_TIMESTAMP,
+  duration_ms = (EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - started_at) * 1000)::BIGINT
+WHERE status = 'running';
+
+-- name: GetJobRunByID :one
+SELECT * FROM job_runs WHERE id = $1;
+
+-- name: GetPendingJobRuns :many
+SELECT * FROM job_runs WHERE status = 'pending' ORDER BY id;
+
+-- name: GetAllJobRunsByJob :many
+SELECT * FROM job_runs
+WHERE job = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetAllJobRunsByJobCount :one
+SELECT COUNT(*) FROM job_runs WHERE job = $1;
+
+-- name: GetLatestJobRuns :many
+SELECT DISTINCT ON (job) * FROM job_runs ORDER BY job, id DESC;
+
+-- name: GetLatestScheduledJobRun :one
+SELECT * FROM job_runs
+WHERE job = $1 AND trigger = 'schedule'
+ORDER BY id DESC
+LIMIT 1;
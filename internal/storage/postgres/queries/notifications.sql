@@ -0,0 +1,110 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+  user_id, type, title, body, link, in_app, event_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (event_id, user_id, type) DO NOTHING
+RETURNING id;
+
+-- name: GetNotificationByEventID :one
+SELECT * FROM notifications WHERE event_id = $1 AND user_id = $2 AND type = $3;
+
+-- name: GetNotificationByID :one
+SELECT * FROM notifications WHERE id = $1;
+
+-- name: GetNotificationsByIDs :many
+SELECT * FROM notifications WHERE id = ANY(@ids::INT[]);
+
+-- name: GetAllNotificationsByUserID :many
+SELECT * FROM notifications
+WHERE user_id = $1 AND in_app AND (NOT @unread::BOOLEAN OR read_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetAllNotificationsByUserIDCount :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = $1 AND in_app AND (NOT @unread::BOOLEAN OR read_at IS NULL);
+
+-- name: MarkNotificationRead :one
+UPDATE notifications SET read_at = COALESCE(read_at, @read_at::TIMESTAMP)
+WHERE id = $1 AND user_id = $2 AND in_app
+RETURNING id;
+
+-- name: MarkAllNotificationsRead :exec
+UPDATE notifications SET read_at = @read_at::TIMESTAMP
+WHERE user_id = $1 AND in_app AND read_at IS NULL;
+
+-- name: CreateNotificationDelivery :exec
+INSERT INTO notification_deliveries (
+  notification_id, user_id, channel, send_after
+) VALUES (
+  $1, $2, $3, $4
+);
+
+-- name: ClaimDueNotificationDeliveries :many
+UPDATE notification_deliveries SET locked_until = @locked_until::TIMESTAMP
+WHERE id IN (
+  SELECT d.id FROM notification_deliveries d
+  WHERE d.status = 'pending' AND d.send_after <= @now::TIMESTAMP
+    AND (d.locked_until IS NULL OR d.locked_until <= @now::TIMESTAMP)
+  ORDER BY d.send_after, d.id
+  LIMIT @max_deliveries
+  FOR UPDATE SKIP LOCKED
+) RETURNING *;
+
+-- name: UpdateNotificationDelivery :exec
+UPDATE notification_deliveries SET
+  status = $2,
+  attempts = $3,
+  send_after = $4,
+  sent_at = $5,
+  last_error = $6,
+  locked_until = NULL
+WHERE id = $1;
+
+-- name: GetNotificationSettingsByUserID :one
+SELECT * FROM notification_settings WHERE user_id = $1;
+
+-- name: GetAllNotificationSettings :many
+SELECT * FROM notification_settings ORDER BY user_id;
+
+-- name: UpsertNotificationSettings :exec
+INSERT INTO notification_settings (
+  user_id, quiet_hours_start, quiet_hours_end, digest, digest_time
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (user_id) DO UPDATE SET
+  quiet_hours_start = EXCLUDED.quiet_hours_start,
+  quiet_hours_end = EXCLUDED.quiet_hours_end,
+  digest = EXCLUDED.digest,
+  digest_time = EXCLUDED.digest_time;
+
+-- name: GetNotificationPreferencesByUserID :many
+SELECT * FROM notification_preferences WHERE user_id = $1 ORDER BY type;
+
+-- name: GetAllNotificationPreferences :many
+SELECT * FROM notification_preferences ORDER BY user_id, type;
+
+-- name: DeleteNotificationPreferencesByUserID :exec
+DELETE FROM notification_preferences WHERE user_id = $1;
+
+-- name: CreateNotificationPreference :exec
+INSERT INTO notification_preferences (user_id, type, channels) VALUES ($1, $2, $3);
+
+-- name: GetPushSubscriptionsByUserID :many
+SELECT * FROM push_subscriptions WHERE user_id = $1 ORDER BY id;
+
+-- name: UpsertPushSubscription :one
+INSERT INTO push_subscriptions (
+  user_id, endpoint, p256dh, auth
+) VALUES (
+  $1, $2, $3, $4
+) ON CONFLICT (endpoint) DO UPDATE SET
+  user_id = EXCLUDED.user_id,
+  p256dh = EXCLUDED.p256dh,
+  auth = EXCLUDED.auth
+RETURNING *;
+
+-- name: DeletePushSubscription :exec
+DELETE FROM push_subscriptions WHERE endpoint = $1;
@@ -0,0 +1,78 @@
+-- name: GetAllPlugins :many
+SELECT * FROM plugins ORDER BY slug;
+
+-- name: GetPluginBySlug :one
+SELECT * FROM plugins WHERE slug = $1;
+
+-- name: GetPluginByClientID :one
+SELECT * FROM plugins WHERE client_id = $1;
+
+-- name: GetAllPluginsWithHeartbeatBefore :many
+SELECT * FROM plugins WHERE last_heartbeat < $1 ORDER BY slug;
+
+-- name: UpsertPlugin :one
+INSERT INTO plugins (
+  slug, name, description, version, path, client_id, scopes,
+  ui_entry, menu_label, icon, required_roles, proxy_timeout_ms, proxy_max_body_size,
+  api_version, min_api_version, max_api_version, features, last_heartbeat
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, CURRENT_TIMESTAMP
+)
+ON CONFLICT (slug) DO UPDATE SET
+  name = EXCLUDED.name,
+  description = EXCLUDED.description,
+  version = EXCLUDED.version,
+  path = EXCLUDED.path,
+  client_id = EXCLUDED.client_id,
+  scopes = EXCLUDED.scopes,
+  ui_entry = EXCLUDED.ui_entry,
+  menu_label = EXCLUDED.menu_label,
+  icon = EXCLUDED.icon,
+  required_roles = EXCLUDED.required_roles,
+  proxy_timeout_ms = EXCLUDED.proxy_timeout_ms,
+  proxy_max_body_size = EXCLUDED.proxy_max_body_size,
+  api_version = EXCLUDED.api_version,
+  min_api_version = EXCLUDED.min_api_version,
+  max_api_version = EXCLUDED.max_api_version,
+  features = EXCLUDED.features,
+  last_heartbeat = CURRENT_TIMESTAMP
+RETURNING slug;
+
+-- name: UpdatePluginHeartbeat :one
+UPDATE plugins SET last_heartbeat = CURRENT_TIMESTAMP WHERE slug = $1 RETURNING slug;
+
+-- name: DeletePlugin :one
+DELETE FROM plugins WHERE slug = $1 RETURNING slug;
+
+-- name: GetAllPluginGrants :many
+SELECT * FROM plugin_grants ORDER BY slug;
+
+-- name: GetPluginGrantBySlug :one
+SELECT * FROM plugin_grants WHERE slug = $1;
+
+-- name: GetPluginGrantByClientID :one
+SELECT * FROM plugin_grants WHERE client_id = $1;
+
+-- name: UpsertPluginGrant :one
+INSERT INTO plugin_grants (
+  slug, client_id, scopes
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (slug) DO UPDATE SET
+  client_id = EXCLUDED.client_id,
+  scopes = EXCLUDED.scopes
+RETURNING slug;
+
+-- name: DeletePluginGrant :one
+DELETE FROM plugin_grants WHERE slug = $1 RETURNING slug;
+
+-- name: CreatePluginEvent :one
+INSERT INTO plugin_events (
+  plugin_slug, type, version
+) VALUES (
+  $1, $2, $3
+) RETURNING id;
+
+-- name: GetAllPluginEventsBySlug :many
+SELECT * FROM plugin_events WHERE plugin_slug = $1 ORDER BY created_at DESC, id DESC;
@@ -13,10 +13,10 @@ SELECT * FROM regions WHERE id = $1;
 SELECT * FROM regions WHERE name = $1;
 
 -- name: CreateRegion :one
-INSERT INTO regions (name, geometry) VALUES ($1, ST_GeomFromText($2, 4326)) RETURNING id;
+INSERT INTO regions (name, geometry) VALUES (@name, ST_Multi(ST_GeomFromText(sqlc.narg('geometry')::TEXT, 4326))) RETURNING id;
 
 -- name: UpdateRegion :exec
-UPDATE regions SET name = $2, geometry = ST_GeomFromText($3, 4326) WHERE id = $1;
+UPDATE regions SET name = @name, geometry = ST_Multi(ST_GeomFromText(sqlc.narg('geometry')::TEXT, 4326)) WHERE id = @id;
 
 -- name: DeleteRegion :exec
 DELETE FROM regions WHERE id = $1;
@@ -24,3 +24,35 @@ DELETE FROM regions WHERE id = $1;
 -- name: GetRegionByPoint :one
 SELECT * FROM regions WHERE ST_Contains(geometry, ST_GeomFromText($1, 4326));
 
+-- name: LockRegions :exec
+-- blocks concurrent changes of the regions until the end of the transaction, so the overlap check
+-- and the change of a region can not interleave with the change of another region
+LOCK TABLE regions IN SHARE ROW EXCLUSIVE MODE;
+
+-- name: ExistsOverlappingRegion :one
+SELECT EXISTS (
+  SELECT 1 FROM regions
+  WHERE id <> @exclude_id
+    AND ST_Relate(geometry, ST_GeomFromText(@geometry::TEXT, 4326), '2********')
+);
+
+-- name: ReassignTreeClusterRegions :many
+-- sets the region of the tree clusters that are assigned to or located inside the region to the
+-- region containing their position, the region with exclude_id is not considered
+WITH affected AS (
+  SELECT tc.id, tc.region_id AS old_region_id, nr.id AS new_region_id
+  FROM tree_clusters tc
+  LEFT JOIN regions nr ON nr.id = (
+    SELECT r.id FROM regions r
+    WHERE r.id <> @exclude_id::INT
+      AND ST_Contains(r.geometry, ST_SetSRID(ST_MakePoint(tc.longitude, tc.latitude), 4326))
+    ORDER BY r.id
+    LIMIT 1
+  )
+  WHERE tc.region_id = @region_id::INT
+    OR ST_Contains((SELECT geometry FROM regions WHERE id = @region_id::INT), ST_SetSRID(ST_MakePoint(tc.longitude, tc.latitude), 4326))
+)
+UPDATE tree_clusters SET region_id = affected.new_region_id, version = tree_clusters.version + 1
+FROM affected
+WHERE tree_clusters.id = affected.id AND affected.new_region_id IS DISTINCT FROM affected.old_region_id
+RETURNING tree_clusters.id, affected.old_region_id, affected.new_region_id;
@@ -1,12 +1,28 @@
 -- name: GetAllSensors :many
 SELECT * FROM sensors 
 WHERE (COALESCE(@provider, '') = '' OR provider = @provider)
-ORDER BY id 
+  AND (sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(geometry), ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326)))
+  AND (sqlc.narg('radius')::FLOAT IS NULL
+    OR ST_DWithin(ST_FlipCoordinates(geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography, sqlc.narg('radius')::FLOAT))
+  AND (sqlc.narg('polygon')::TEXT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(geometry), ST_GeomFromText(sqlc.narg('polygon')::TEXT, 4326)))
+ORDER BY
+  CASE WHEN sqlc.narg('near_lon')::FLOAT IS NULL THEN 0
+    ELSE ST_Distance(ST_FlipCoordinates(geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography)
+  END,
+  id
 LIMIT $1 OFFSET $2;
 
 -- name: GetAllSensorsCount :one
 SELECT COUNT(*) FROM sensors
-WHERE (COALESCE(@provider, '') = '' OR provider = @provider);
+WHERE (COALESCE(@provider, '') = '' OR provider = @provider)
+  AND (sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(geometry), ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326)))
+  AND (sqlc.narg('radius')::FLOAT IS NULL
+    OR ST_DWithin(ST_FlipCoordinates(geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography, sqlc.narg('radius')::FLOAT))
+  AND (sqlc.narg('polygon')::TEXT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(geometry), ST_GeomFromText(sqlc.narg('polygon')::TEXT, 4326)));
 
 -- name: GetSensorByID :one
 SELECT * FROM sensors WHERE id = $1;
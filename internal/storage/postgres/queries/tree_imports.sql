@@ -0,0 +1,43 @@
+-- name: GetAllTreeImportJobs :many
+SELECT * FROM tree_import_jobs ORDER BY created_at DESC, id DESC;
+
+-- name: GetTreeImportJobByID :one
+SELECT * FROM tree_import_jobs WHERE id = $1;
+
+-- name: CreateTreeImportJob :one
+INSERT INTO tree_import_jobs (
+  file_name, format, status, mapping, provider, created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id;
+
+-- name: UpdateTreeImportJob :exec
+UPDATE tree_import_jobs SET
+  status = $2,
+  total_rows = $3,
+  valid_rows = $4,
+  created_rows = $5,
+  updated_rows = $6,
+  row_errors = $7,
+  error = $8
+WHERE id = $1;
+
+-- name: ClaimTreeImportJob :one
+UPDATE tree_import_jobs SET status = @to_status, claimed_at = @now
+WHERE id = (
+  SELECT j.id FROM tree_import_jobs j
+  WHERE j.status = @from_status
+    OR (j.status = @to_status AND COALESCE(j.claimed_at, j.updated_at) < @claim_expired_before)
+  ORDER BY j.updated_at, j.id
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+) RETURNING id;
+
+-- name: DeleteTreeImportJob :one
+DELETE FROM tree_import_jobs WHERE id = $1 RETURNING id;
+
+-- name: CreateTreeImportFile :exec
+INSERT INTO tree_import_files (job_id, data) VALUES ($1, $2);
+
+-- name: GetTreeImportFile :one
+SELECT data FROM tree_import_files WHERE job_id = $1;
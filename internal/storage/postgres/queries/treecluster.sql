@@ -1,110 +1,201 @@
--- name: GetAllTreeClusters :many
-SELECT tc.*
-FROM tree_clusters tc
-         LEFT JOIN regions r ON r.id = tc.region_id
-WHERE
-    (COALESCE(array_length(@watering_status::TEXT[], 1), 0) = 0
-        OR watering_status = ANY((@watering_status::TEXT[])::watering_status[]))
-  AND (COALESCE(array_length(@region::TEXT[], 1), 0) = 0
-    OR r.name = ANY(@region::TEXT[]))
-  AND (COALESCE(@provider, '') = '' OR provider = @provider)
-ORDER BY tc.name ASC
-    LIMIT $1 OFFSET $2;
-
--- name: GetTreeClustersCount :one
-SELECT COUNT(*)
-FROM tree_clusters tc
-         LEFT JOIN regions r ON r.id = tc.region_id
-WHERE
-    (COALESCE(array_length(@watering_status::TEXT[], 1), 0) = 0
-        OR watering_status = ANY((@watering_status::TEXT[])::watering_status[]))
-  AND (COALESCE(array_length(@region::TEXT[], 1), 0) = 0
-    OR r.name = ANY(@region::TEXT[]))
-  AND (COALESCE(@provider, '') = '' OR provider = @provider);
-
--- name: GetTreeClusterByID :one
-SELECT * FROM tree_clusters WHERE id = $1;
-
--- name: GetTreesClustersByIDs :many
-SELECT * FROM tree_clusters WHERE id = ANY($1::int[]);
-
--- name: GetRegionByTreeClusterID :one
-SELECT regions.* FROM regions JOIN tree_clusters ON regions.id = tree_clusters.region_id WHERE tree_clusters.id = $1;
-
--- name: GetLinkedTreesByTreeClusterID :many
-SELECT trees.* FROM trees JOIN tree_clusters ON trees.tree_cluster_id = tree_clusters.id WHERE tree_clusters.id = $1;
-
--- name: CreateTreeCluster :one
-INSERT INTO tree_clusters (
-  name, region_id, address, description, moisture_level, watering_status, soil_condition, provider, additional_informations
-) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id;
-
--- name: LinkTreesToTreeCluster :exec
-UPDATE trees SET tree_cluster_id = $2 WHERE id = ANY($1::int[]);
-
--- name: SetTreeClusterLocation :exec
-UPDATE tree_clusters SET
-  latitude = $2,
-  longitude = $3,
-  geometry = ST_SetSRID(ST_MakePoint($2, $3), 4326)
-WHERE id = $1;
-
--- name: RemoveTreeClusterLocation :exec
-UPDATE tree_clusters SET
-  latitude = NULL,
-  longitude = NULL,
-  geometry = NULL
-WHERE id = $1;
-
--- name: UpdateTreeCluster :exec
-UPDATE tree_clusters SET
-  name = $2,
-  region_id = $3,
-  address = $4,
-  description = $5,
-  moisture_level = $6,
-  watering_status = $7,
-  soil_condition = $8,
-  last_watered = $9,
-  archived = $10,
-  provider = $11,
-  additional_informations = $12
-WHERE id = $1;
-
--- name: ArchiveTreeCluster :one
-UPDATE tree_clusters SET
-  archived = TRUE
-WHERE id = $1 RETURNING id;
-
--- name: DeleteTreeCluster :one
-DELETE FROM tree_clusters WHERE id = $1 RETURNING id;
-
--- name: CalculateTreesCentroid :one
-SELECT ST_AsText(ST_Centroid(ST_Collect(geometry)))::text AS centroid FROM trees WHERE trees.tree_cluster_id = $1;
-
--- name: GetAllLatestSensorDataByTreeClusterID :many
-SELECT sd.*
-FROM sensor_data sd
-JOIN sensors s ON sd.sensor_id = s.id
-JOIN trees t ON t.sensor_id = s.id
-JOIN tree_clusters tc ON t.tree_cluster_id = tc.id
-WHERE tc.id = $1
-  AND sd.id = (
-    SELECT id
-    FROM sensor_data
-    WHERE sensor_id = s.id
-    ORDER BY created_at DESC
-    LIMIT 1
-  );
-
--- name: GetAllTreeClusterRegionsWithWateringPlanCount :many
-SELECT 
-    r.name AS name,
-    COUNT(DISTINCT twp.watering_plan_id) AS watering_plan_count
-FROM regions r
-INNER JOIN tree_clusters tc ON r.id = tc.region_id
-INNER JOIN tree_cluster_watering_plans twp ON tc.id = twp.tree_cluster_id
-GROUP BY r.name
-ORDER BY watering_plan_count DESC;
+-- name: GetAllTreeClusters :many
+SELECT tc.*
+FROM tree_clusters tc
+         LEFT JOIN regions r ON r.id = tc.region_id
+WHERE
+    (COALESCE(array_length(@watering_status::TEXT[], 1), 0) = 0
+        OR watering_status = ANY((@watering_status::TEXT[])::watering_status[]))
+  AND (COALESCE(array_length(@region::TEXT[], 1), 0) = 0
+    OR r.name = ANY(@region::TEXT[]))
+  AND (COALESCE(@provider, '') = '' OR provider = @provider)
+  AND (sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(tc.geometry), ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326)))
+  AND (sqlc.narg('radius')::FLOAT IS NULL
+    OR ST_DWithin(ST_FlipCoordinates(tc.geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography, sqlc.narg('radius')::FLOAT))
+  AND (sqlc.narg('polygon')::TEXT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(tc.geometry), ST_GeomFromText(sqlc.narg('polygon')::TEXT, 4326)))
+ORDER BY
+  CASE WHEN sqlc.narg('near_lon')::FLOAT IS NULL THEN 0
+    ELSE ST_Distance(ST_FlipCoordinates(tc.geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography)
+  END,
+  tc.name ASC
+LIMIT $1 OFFSET $2;
+
+-- name: GetTreeClustersCount :one
+SELECT COUNT(*)
+FROM tree_clusters tc
+         LEFT JOIN regions r ON r.id = tc.region_id
+WHERE
+    (COALESCE(array_length(@watering_status::TEXT[], 1), 0) = 0
+        OR watering_status = ANY((@watering_status::TEXT[])::watering_status[]))
+  AND (COALESCE(array_length(@region::TEXT[], 1), 0) = 0
+    OR r.name = ANY(@region::TEXT[]))
+  AND (COALESCE(@provider, '') = '' OR provider = @provider)
+  AND (sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(tc.geometry), ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326)))
+  AND (sqlc.narg('radius')::FLOAT IS NULL
+    OR ST_DWithin(ST_FlipCoordinates(tc.geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography, sqlc.narg('radius')::FLOAT))
+  AND (sqlc.narg('polygon')::TEXT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(tc.geometry), ST_GeomFromText(sqlc.narg('polygon')::TEXT, 4326)));
+
+-- name: GetTreeClusterByID :one
+SELECT * FROM tree_clusters WHERE id = $1;
+
+-- name: GetTreesClustersByIDs :many
+SELECT * FROM tree_clusters WHERE id = ANY($1::int[]);
+
+-- name: GetRegionByTreeClusterID :one
+SELECT regions.* FROM regions JOIN tree_clusters ON regions.id = tree_clusters.region_id WHERE tree_clusters.id = $1;
+
+-- name: GetLinkedTreesByTreeClusterID :many
+SELECT trees.* FROM trees JOIN tree_clusters ON trees.tree_cluster_id = tree_clusters.id WHERE tree_clusters.id = $1;
+
+-- name: CreateTreeCluster :one
+INSERT INTO tree_clusters (
+  name, region_id, address, description, moisture_level, watering_status, soil_condition, provider, additional_informations
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id;
+
+-- name: LinkTreesToTreeCluster :exec
+UPDATE trees SET tree_cluster_id = $2 WHERE id = ANY($1::int[]);
+
+-- name: SetTreeClusterLocation :exec
+UPDATE tree_clusters SET
+  latitude = $2,
+  longitude = $3,
+  geometry = ST_SetSRID(ST_MakePoint($2, $3), 4326)
+WHERE id = $1;
+
+-- name: RemoveTreeClusterLocation :exec
+UPDATE tree_clusters SET
+  latitude = NULL,
+  longitude = NULL,
+  geometry = NULL
+WHERE id = $1;
+
+-- name: UpdateTreeCluster :exec
+UPDATE tree_clusters SET
+  name = $2,
+  region_id = $3,
+  address = $4,
+  description = $5,
+  moisture_level = $6,
+  watering_status = $7,
+  soil_condition = $8,
+  last_watered = $9,
+  archived = $10,
+  provider = $11,
+  additional_informations = $12,
+  version = version + 1
+WHERE id = $1;
+
+-- name: SetTreeClusterPolygon :exec
+-- Without a manual polygon the polygon is the concave hull of the trees buffered by the given meters.
+UPDATE tree_clusters tc SET
+  polygon = p.polygon,
+  polygon_manual = sqlc.narg('polygon')::TEXT IS NOT NULL,
+  area = ST_Area(p.polygon::geography),
+  perimeter = ST_Perimeter(p.polygon::geography)
+FROM (
+  SELECT COALESCE(
+    ST_GeomFromText(sqlc.narg('polygon')::TEXT, 4326),
+    (
+      SELECT ST_Buffer(ST_ConcaveHull(ST_Collect(ST_FlipCoordinates(t.geometry)), 0.8)::geography, @buffer::FLOAT)::geometry
+      FROM trees t
+      WHERE t.tree_cluster_id = @id::INT AND t.geometry IS NOT NULL
+    )
+  ) AS polygon
+) p
+WHERE tc.id = @id::INT;
+
+-- name: ArchiveTreeCluster :one
+UPDATE tree_clusters SET
+  archived = TRUE,
+  version = version + 1
+WHERE id = $1 RETURNING id;
+
+-- name: DeleteTreeCluster :one
+DELETE FROM tree_clusters WHERE id = $1 RETURNING id;
+
+-- name: LockTreeCluster :one
+-- Locks the row until the end of the transaction
+SELECT version FROM tree_clusters WHERE id = $1 FOR UPDATE;
+
+-- name: CalculateTreesCentroid :one
+SELECT ST_AsText(ST_Centroid(ST_Collect(geometry)))::text AS centroid FROM trees WHERE trees.tree_cluster_id = $1;
+
+-- name: GetAllLatestSensorDataByTreeClusterID :many
+SELECT sd.*
+FROM sensor_data sd
+JOIN sensors s ON sd.sensor_id = s.id
+JOIN trees t ON t.sensor_id = s.id
+JOIN tree_clusters tc ON t.tree_cluster_id = tc.id
+WHERE tc.id = $1
+  AND sd.id = (
+    SELECT id
+    FROM sensor_data
+    WHERE sensor_id = s.id
+    ORDER BY created_at DESC
+    LIMIT 1
+  );
+
+-- name: GetAllTreeClusterRegionsWithWateringPlanCount :many
+SELECT 
+    r.name AS name,
+    COUNT(DISTINCT twp.watering_plan_id) AS watering_plan_count
+FROM regions r
+INNER JOIN tree_clusters tc ON r.id = tc.region_id
+INNER JOIN tree_cluster_watering_plans twp ON tc.id = twp.tree_cluster_id
+GROUP BY r.name
+ORDER BY watering_plan_count DESC;
+
+-- name: GetTreeClusterSuggestions :many
+-- Groups trees with ST_ClusterDBSCAN in web mercator. Its units are only meters at the equator, so the
+-- distance is scaled by the mean latitude of the trees. Groups with more than max_trees trees are split
+-- with ST_ClusterKMeans into groups of about max_trees trees. K-means does not bound the size of its
+-- parts, so parts that are still too large are split evenly along their geohash order.
+WITH candidates AS (
+  SELECT t.id, t.tree_cluster_id, ST_Transform(ST_FlipCoordinates(t.geometry), 3857) AS geom
+  FROM trees t
+  WHERE t.geometry IS NOT NULL
+    AND (sqlc.arg('include_clustered')::BOOLEAN OR t.tree_cluster_id IS NULL)
+),
+grouped AS (
+  SELECT c.*, ST_ClusterDBSCAN(
+    c.geom,
+    (SELECT sqlc.arg('distance')::FLOAT / COS(RADIANS(COALESCE(AVG(latitude), 0))) FROM trees),
+    sqlc.arg('min_trees')::INT
+  ) OVER () AS group_id
+  FROM candidates c
+),
+sized AS (
+  SELECT g.*, COUNT(*) OVER (PARTITION BY g.group_id) AS size
+  FROM grouped g
+  WHERE g.group_id IS NOT NULL
+),
+split AS (
+  SELECT s.*, ST_ClusterKMeans(s.geom, CEIL(s.size::FLOAT / sqlc.arg('max_trees')::INT)::INT) OVER (PARTITION BY s.group_id) AS part_id
+  FROM sized s
+),
+parts AS (
+  SELECT sp.*, COUNT(*) OVER (PARTITION BY sp.group_id, sp.part_id) AS part_size
+  FROM split sp
+),
+bounded AS (
+  SELECT p.*, NTILE(CEIL(p.part_size::FLOAT / sqlc.arg('max_trees')::INT)::INT) OVER (
+    PARTITION BY p.group_id, p.part_id
+    ORDER BY ST_GeoHash(ST_Transform(p.geom, 4326)), p.id
+  ) AS chunk_id
+  FROM parts p
+)
+SELECT
+  ARRAY_AGG(sp.id ORDER BY sp.id)::INT[] AS tree_ids,
+  ST_Y(ST_Transform(ST_Centroid(ST_Collect(sp.geom)), 4326))::FLOAT AS latitude,
+  ST_X(ST_Transform(ST_Centroid(ST_Collect(sp.geom)), 4326))::FLOAT AS longitude,
+  COALESCE(MODE() WITHIN GROUP (ORDER BY sp.tree_cluster_id), 0)::INT AS tree_cluster_id,
+  COALESCE(MODE() WITHIN GROUP (ORDER BY tc.soil_condition), 'unknown')::TEXT AS soil_condition
+FROM bounded sp
+LEFT JOIN tree_clusters tc ON tc.id = sp.tree_cluster_id
+GROUP BY sp.group_id, sp.part_id, sp.chunk_id
+ORDER BY MIN(sp.id);
@@ -10,8 +10,18 @@ WHERE
     sqlc.narg('hasCluster')::BOOLEAN IS NULL
     OR (t.tree_cluster_id IS NOT NULL) = sqlc.narg('hasCluster')::BOOLEAN
       )
-  ORDER BY t.number ASC
-    LIMIT $1 OFFSET $2;
+  AND (sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(t.geometry), ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326)))
+  AND (sqlc.narg('radius')::FLOAT IS NULL
+    OR ST_DWithin(ST_FlipCoordinates(t.geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography, sqlc.narg('radius')::FLOAT))
+  AND (sqlc.narg('polygon')::TEXT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(t.geometry), ST_GeomFromText(sqlc.narg('polygon')::TEXT, 4326)))
+ORDER BY
+  CASE WHEN sqlc.narg('near_lon')::FLOAT IS NULL THEN 0
+    ELSE ST_Distance(ST_FlipCoordinates(t.geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography)
+  END,
+  t.number ASC
+LIMIT $1 OFFSET $2;
 
 -- name: GetAllTreesCount :one
 SELECT COUNT(*)
@@ -24,7 +34,13 @@ WHERE
   AND (
     sqlc.narg('hasCluster')::BOOLEAN IS NULL
     OR (t.tree_cluster_id IS NOT NULL) = sqlc.narg('hasCluster')::BOOLEAN
-      );
+      )
+  AND (sqlc.narg('min_lon')::FLOAT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(t.geometry), ST_MakeEnvelope(sqlc.narg('min_lon')::FLOAT, sqlc.narg('min_lat')::FLOAT, sqlc.narg('max_lon')::FLOAT, sqlc.narg('max_lat')::FLOAT, 4326)))
+  AND (sqlc.narg('radius')::FLOAT IS NULL
+    OR ST_DWithin(ST_FlipCoordinates(t.geometry)::geography, ST_SetSRID(ST_MakePoint(sqlc.narg('near_lon')::FLOAT, sqlc.narg('near_lat')::FLOAT), 4326)::geography, sqlc.narg('radius')::FLOAT))
+  AND (sqlc.narg('polygon')::TEXT IS NULL
+    OR ST_Intersects(ST_FlipCoordinates(t.geometry), ST_GeomFromText(sqlc.narg('polygon')::TEXT, 4326)));
 
 -- name: GetTreeByID :one
 SELECT * FROM trees WHERE id = $1;
@@ -68,7 +84,8 @@ UPDATE trees SET
   description = $8,
   provider = $9,
   additional_informations = $10,
-  last_watered = $11
+  last_watered = $11,
+  version = version + 1
 WHERE id = $1;
 
 -- name: SetTreeLocation :exec
@@ -89,6 +106,10 @@ WHERE id = $1;
 -- name: DeleteTree :one
 DELETE FROM trees WHERE id = $1 RETURNING id;
 
+-- name: LockTree :one
+-- Locks the row until the end of the transaction
+SELECT version FROM trees WHERE id = $1 FOR UPDATE;
+
 -- name: UnlinkTreeClusterID :many
 UPDATE trees SET tree_cluster_id = NULL WHERE tree_cluster_id = $1 RETURNING id;
 
@@ -104,4 +125,7 @@ SELECT ST_AsText(ST_Centroid(ST_Collect(geometry)))::text AS centroid FROM trees
 SELECT * FROM trees
 WHERE ST_Distance(geometry::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography) <= 3
 ORDER BY ST_Distance(geometry::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography) ASC
-    LIMIT 1;
\ No newline at end of file
+    LIMIT 1;
+
+-- name: GetTreeByProviderAndNumber :one
+SELECT * FROM trees WHERE COALESCE(provider, '') = @provider::TEXT AND number = @number LIMIT 1;
\ No newline at end of file
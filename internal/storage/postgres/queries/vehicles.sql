@@ -109,15 +109,20 @@ UPDATE vehicles SET
   width = $11,
   weight = $12,
   provider = $13,
-  additional_informations = $14
+  additional_informations = $14,
+  version = version + 1
 WHERE id = $1;
 
 -- name: ArchiveVehicle :one
-UPDATE vehicles SET archived_at = $2 WHERE id = $1 RETURNING id;
+UPDATE vehicles SET archived_at = $2, version = version + 1 WHERE id = $1 RETURNING id;
 
 -- name: DeleteVehicle :one
 DELETE FROM vehicles WHERE id = $1 RETURNING id;
 
+-- name: LockVehicle :one
+-- Locks the row until the end of the transaction
+SELECT version FROM vehicles WHERE id = $1 FOR UPDATE;
+
 -- name: GetAllVehiclesWithWateringPlanCount :many
 SELECT 
     v.number_plate,
@@ -0,0 +1,50 @@
+-- name: GetAllWateringPlanTemplates :many
+SELECT * FROM watering_plan_templates ORDER BY id;
+
+-- name: GetWateringPlanTemplateByID :one
+SELECT * FROM watering_plan_templates WHERE id = $1;
+
+-- name: CreateWateringPlanTemplate :one
+INSERT INTO watering_plan_templates (
+  name, description, rrule, start_date, season_start, season_end, transporter_id, trailer_id, user_ids, enabled
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id;
+
+-- name: UpdateWateringPlanTemplate :exec
+UPDATE watering_plan_templates SET
+  name = $2,
+  description = $3,
+  rrule = $4,
+  start_date = $5,
+  season_start = $6,
+  season_end = $7,
+  transporter_id = $8,
+  trailer_id = $9,
+  user_ids = $10,
+  enabled = $11
+WHERE id = $1;
+
+-- name: DeleteWateringPlanTemplate :one
+DELETE FROM watering_plan_templates WHERE id = $1 RETURNING id;
+
+-- name: GetWateringPlanTemplateTreeClusterIDs :many
+SELECT tree_cluster_id FROM watering_plan_template_tree_clusters WHERE template_id = $1 ORDER BY tree_cluster_id;
+
+-- name: SetWateringPlanTemplateTreeClusters :exec
+INSERT INTO watering_plan_template_tree_clusters (template_id, tree_cluster_id)
+SELECT @template_id::int, unnest(@tree_cluster_ids::int[])
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteWateringPlanTemplateTreeClusters :exec
+DELETE FROM watering_plan_template_tree_clusters WHERE template_id = $1;
+
+-- name: GetWateringPlanTemplateOccurrences :many
+SELECT * FROM watering_plan_template_occurrences WHERE template_id = $1 ORDER BY date;
+
+-- name: CreateWateringPlanTemplateOccurrence :exec
+INSERT INTO watering_plan_template_occurrences (
+  template_id, date, watering_plan_id, holiday
+) VALUES (
+  $1, $2, $3, $4
+);
@@ -30,12 +30,17 @@ UPDATE watering_plans SET
   duration = $9,
   refill_count = $10,
   provider = $11,
-  additional_informations = $12
+  additional_informations = $12,
+  version = version + 1
 WHERE id = $1;
 
 -- name: DeleteWateringPlan :one
 DELETE FROM watering_plans WHERE id = $1 RETURNING id;
 
+-- name: LockWateringPlan :one
+-- Locks the row until the end of the transaction
+SELECT version FROM watering_plans WHERE id = $1 FOR UPDATE;
+
 -- name: SetUserToWateringPlan :exec
 INSERT INTO user_watering_plans (user_id, watering_plan_id)
 VALUES ($1, $2);
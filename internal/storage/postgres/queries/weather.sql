@@ -0,0 +1,38 @@
+-- name: GetWeatherLocations :many
+SELECT id AS region_id,
+  ST_Y(ST_PointOnSurface(geometry))::float8 AS latitude,
+  ST_X(ST_PointOnSurface(geometry))::float8 AS longitude
+FROM regions
+WHERE geometry IS NOT NULL
+ORDER BY id;
+
+-- name: UpsertWeatherDays :exec
+INSERT INTO weather_days (region_id, date, precipitation_mm, temperature_min, temperature_max, temperature_mean, et0_mm, forecast)
+SELECT @region_id::int, unnest(@dates::date[]), unnest(@precipitation::float8[]), unnest(@temperature_min::float8[]),
+  unnest(@temperature_max::float8[]), unnest(@temperature_mean::float8[]), unnest(@et0::float8[]), unnest(@forecast::bool[])
+ON CONFLICT (region_id, date) DO UPDATE SET
+  updated_at = CURRENT_TIMESTAMP,
+  precipitation_mm = EXCLUDED.precipitation_mm,
+  temperature_min = EXCLUDED.temperature_min,
+  temperature_max = EXCLUDED.temperature_max,
+  temperature_mean = EXCLUDED.temperature_mean,
+  et0_mm = EXCLUDED.et0_mm,
+  forecast = EXCLUDED.forecast;
+
+-- name: GetWeatherDaysByRegion :many
+SELECT * FROM weather_days
+WHERE region_id = @region_id AND date BETWEEN @from_date::date AND @to_date::date
+ORDER BY date;
+
+-- name: DeleteWaterBalancesByTreeCluster :exec
+DELETE FROM tree_cluster_water_balances WHERE tree_cluster_id = $1;
+
+-- name: CreateWaterBalances :exec
+INSERT INTO tree_cluster_water_balances (tree_cluster_id, date, deficit_mm, watering_status, forecast)
+SELECT @tree_cluster_id::int, unnest(@dates::date[]), unnest(@deficits::float8[]),
+  unnest(@watering_statuses::text[])::watering_status, unnest(@forecast::bool[]);
+
+-- name: GetWaterBalancesByTreeCluster :many
+SELECT * FROM tree_cluster_water_balances
+WHERE tree_cluster_id = $1
+ORDER BY date;
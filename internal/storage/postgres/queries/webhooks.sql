@@ -0,0 +1,61 @@
+-- name: GetAllWebhooks :many
+SELECT * FROM webhooks ORDER BY id;
+
+-- name: GetWebhookByID :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: GetAllEnabledWebhooksByEventType :many
+SELECT * FROM webhooks WHERE enabled = TRUE AND @event_type::TEXT = ANY(event_types) ORDER BY id;
+
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+  name, url, secret, event_types, enabled
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id;
+
+-- name: UpdateWebhook :exec
+UPDATE webhooks SET
+  name = $2,
+  url = $3,
+  event_types = $4,
+  enabled = $5
+WHERE id = $1;
+
+-- name: DeleteWebhook :one
+DELETE FROM webhooks WHERE id = $1 RETURNING id;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+  webhook_id, event_id, event_type, payload
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id;
+
+-- name: GetWebhookDeliveryByID :one
+SELECT * FROM webhook_deliveries WHERE id = $1;
+
+-- name: GetAllWebhookDeliveriesByWebhookID :many
+SELECT * FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries SET locked_until = @locked_until::TIMESTAMP
+WHERE id IN (
+  SELECT d.id FROM webhook_deliveries d
+  WHERE d.status IN ('pending', 'retrying') AND d.next_attempt_at <= @now::TIMESTAMP
+    AND (d.locked_until IS NULL OR d.locked_until <= @now::TIMESTAMP)
+  ORDER BY d.next_attempt_at, d.id
+  LIMIT @max_deliveries
+  FOR UPDATE SKIP LOCKED
+) RETURNING *;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries SET
+  status = $2,
+  attempts = $3,
+  last_status_code = $4,
+  last_error = $5,
+  next_attempt_at = $6,
+  delivered_at = $7,
+  locked_until = NULL
+WHERE id = $1;
@@ -7,6 +7,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 )
 
 func defaultRegion() *entities.Region {
@@ -26,21 +27,33 @@ func (r *RegionRepository) Create(ctx context.Context, vFn ...entities.EntityFun
 		return nil, errors.New("name is required")
 	}
 
-	id, err := r.createEntity(ctx, entity)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := &RegionRepository{store: s, RegionMappers: r.RegionMappers}
+		if err := newRepo.checkOverlap(ctx, entity); err != nil {
+			return err
+		}
+
+		id, err := newRepo.createEntity(ctx, entity)
+		if err != nil {
+			log.Error("failed to create region in db", "error", err)
+			return err
+		}
+		entity.ID = *id
+
+		return newRepo.reassignTreeClusters(ctx, entity.ID, 0)
+	})
 	if err != nil {
-		log.Error("failed to create region in db", "error", err)
 		return nil, err
 	}
 
-	entity.ID = *id
-
-	log.Debug("region entity created successfully in db", "region_id", *id)
-	return r.GetByID(ctx, *id)
+	log.Debug("region entity created successfully in db", "region_id", entity.ID)
+	return r.GetByID(ctx, entity.ID)
 }
 
 func (r *RegionRepository) createEntity(ctx context.Context, entity *entities.Region) (*int32, error) {
 	args := sqlc.CreateRegionParams{
-		Name: entity.Name,
+		Name:     entity.Name,
+		Geometry: geometryParam(entity.Geometry),
 	}
 
 	id, err := r.store.CreateRegion(ctx, &args)
@@ -2,8 +2,10 @@ package region
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,6 +24,33 @@ func TestRegionRepository_Create(t *testing.T) {
 		assert.Equal(t, "test", got.Name)
 	})
 
+	t.Run("should create region with polygon stored as multi polygon", func(t *testing.T) {
+		// given
+		r := NewRegionRepository(defaultFields.store, defaultFields.RegionMappers)
+
+		// when
+		got, err := r.Create(context.Background(), WithName("test"), WithGeometry("POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))"))
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.True(t, strings.HasPrefix(got.Geometry, "MULTIPOLYGON"))
+	})
+
+	t.Run("should return ErrRegionOverlapping when geometry overlaps with another region", func(t *testing.T) {
+		// given
+		r := NewRegionRepository(defaultFields.store, defaultFields.RegionMappers)
+		_, err := r.Create(context.Background(), WithName("first"), WithGeometry("POLYGON ((10 10, 12 10, 12 12, 10 12, 10 10))"))
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.Create(context.Background(), WithName("second"), WithGeometry("POLYGON ((11 11, 13 11, 13 13, 11 13, 11 11))"))
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrRegionOverlapping)
+		assert.Nil(t, got)
+	})
+
 	t.Run("should return error when create region with empty name", func(t *testing.T) {
 		// given
 		r := NewRegionRepository(defaultFields.store, defaultFields.RegionMappers)
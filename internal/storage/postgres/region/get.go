@@ -73,3 +73,17 @@ func (r *RegionRepository) GetByPoint(ctx context.Context, latitude, longitude f
 
 	return r.mapper.FromSql(region), nil
 }
+
+func (r *RegionRepository) HasOverlap(ctx context.Context, geometry string, excludeID int32) (bool, error) {
+	log := logger.GetLogger(ctx)
+	overlaps, err := r.store.ExistsOverlappingRegion(ctx, &sqlc.ExistsOverlappingRegionParams{
+		ExcludeID: excludeID,
+		Geometry:  geometry,
+	})
+	if err != nil {
+		log.Debug("failed to check if region overlaps with other regions", "error", err, "exclude_region_id", excludeID)
+		return false, r.store.MapError(err, sqlc.Region{})
+	}
+
+	return overlaps, nil
+}
@@ -304,3 +304,50 @@ var allTestRegions = []*entities.Region{
 		Name: "Nordstadt",
 	},
 }
+
+func TestRegionRepository_HasOverlap(t *testing.T) {
+	// small square around a point inside the first test region
+	polygon := "POLYGON ((9.484 54.811, 9.486 54.811, 9.486 54.813, 9.484 54.813, 9.484 54.811))"
+
+	t.Run("should return true when geometry overlaps with region", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/region")
+		r := NewRegionRepository(suite.Store, defaultRegionMappers())
+
+		// when
+		got, err := r.HasOverlap(context.Background(), polygon, 0)
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, got)
+	})
+
+	t.Run("should ignore overlap with excluded region", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/region")
+		r := NewRegionRepository(suite.Store, defaultRegionMappers())
+
+		// when
+		got, err := r.HasOverlap(context.Background(), polygon, allTestRegions[0].ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.False(t, got)
+	})
+
+	t.Run("should return false when geometry is outside of all regions", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/region")
+		r := NewRegionRepository(suite.Store, defaultRegionMappers())
+
+		// when
+		got, err := r.HasOverlap(context.Background(), "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", 0)
+
+		// then
+		assert.NoError(t, err)
+		assert.False(t, got)
+	})
+}
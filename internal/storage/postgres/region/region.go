@@ -2,9 +2,12 @@ package region
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -39,13 +42,97 @@ func WithName(name string) entities.EntityFunc[entities.Region] {
 	}
 }
 
+func WithGeometry(geometry string) entities.EntityFunc[entities.Region] {
+	return func(v *entities.Region) {
+		v.Geometry = geometry
+	}
+}
+
+// checkOverlap locks the regions for the rest of the transaction and returns ErrRegionOverlapping if the
+// geometry of the region overlaps with another region. A region without geometry never overlaps.
+func (r *RegionRepository) checkOverlap(ctx context.Context, region *entities.Region) error {
+	if region.Geometry == "" {
+		return nil
+	}
+
+	if err := r.store.LockRegions(ctx); err != nil {
+		return err
+	}
+
+	overlaps, err := r.HasOverlap(ctx, region.Geometry, region.ID)
+	if err != nil {
+		return err
+	}
+	if overlaps {
+		return storage.ErrRegionOverlapping
+	}
+
+	return nil
+}
+
+// geometryParam stores regions without a geometry as NULL
+func geometryParam(geometry string) *string {
+	if geometry == "" {
+		return nil
+	}
+
+	return &geometry
+}
+
 func (r *RegionRepository) Delete(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
-	if err := r.store.DeleteRegion(ctx, id); err != nil {
-		log.Error("failed to delete region entity in db", "error", err, "region_id", id)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := &RegionRepository{store: s, RegionMappers: r.RegionMappers}
+		// the tree clusters reference the region, so they have to be moved before it can be deleted
+		if err := newRepo.reassignTreeClusters(ctx, id, id); err != nil {
+			return err
+		}
+
+		if err := s.DeleteRegion(ctx, id); err != nil {
+			log.Error("failed to delete region entity in db", "error", err, "region_id", id)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
 	log.Debug("region entity deleted successfully in db", "region_id", id)
 	return nil
 }
+
+// reassignTreeClusters sets the region of every tree cluster that is assigned to or located inside the
+// region to the region containing its position. The region with excludeID is not considered, which
+// removes the tree clusters from a region that is about to be deleted.
+func (r *RegionRepository) reassignTreeClusters(ctx context.Context, id, excludeID int32) error {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.ReassignTreeClusterRegions(ctx, &sqlc.ReassignTreeClusterRegionsParams{
+		RegionID:  id,
+		ExcludeID: excludeID,
+	})
+	if err != nil {
+		log.Error("failed to reassign tree clusters of region", "error", err, "region_id", id)
+		return err
+	}
+
+	for _, row := range rows {
+		prev := store.AuditSnapshot{"region_id": regionIDSnapshot(row.OldRegionID)}
+		curr := store.AuditSnapshot{"region_id": regionIDSnapshot(row.NewRegionID)}
+		if err := r.store.WriteAuditLog(ctx, entities.AuditEntityTypeTreeCluster, row.ID, entities.AuditActionUpdate, prev, curr); err != nil {
+			log.Error("failed to write audit log for reassigned tree cluster", "error", err, "cluster_id", row.ID)
+			return err
+		}
+		log.Debug("updated region of tree cluster", "cluster_id", row.ID, "region_id", row.NewRegionID)
+	}
+
+	return nil
+}
+
+func regionIDSnapshot(id *int32) json.RawMessage {
+	if id == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(strconv.Itoa(int(*id)))
+}
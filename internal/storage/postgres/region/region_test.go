@@ -53,6 +53,24 @@ func TestRegionRepository_Delete(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should remove tree clusters from deleted region", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewRegionRepository(defaultFields.store, defaultFields.RegionMappers)
+
+		// when
+		err := r.Delete(context.Background(), 1)
+		cluster, clusterErr := suite.Store.GetTreeClusterByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, clusterErr)
+		if cluster.RegionID != nil {
+			assert.NotEqual(t, int32(1), *cluster.RegionID)
+		}
+	})
+
 	t.Run("should return error when region not found", func(t *testing.T) {
 		// given
 		suite.ResetDB(t)
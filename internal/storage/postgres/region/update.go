@@ -8,6 +8,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 )
 
 func (r *RegionRepository) Update(ctx context.Context, id int32, vFn ...entities.EntityFunc[entities.Region]) (*entities.Region, error) {
@@ -17,6 +18,7 @@ func (r *RegionRepository) Update(ctx context.Context, id int32, vFn ...entities
 		return nil, err
 	}
 
+	prevGeometry := entity.Geometry
 	for _, fn := range vFn {
 		fn(entity)
 	}
@@ -25,8 +27,22 @@ func (r *RegionRepository) Update(ctx context.Context, id int32, vFn ...entities
 		return nil, errors.New("name is required")
 	}
 
-	if err := r.updateEntity(ctx, entity); err != nil {
-		log.Error("failed to update region entity in db", "error", err, "region_id", id)
+	err = r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := &RegionRepository{store: s, RegionMappers: r.RegionMappers}
+		if entity.Geometry != prevGeometry {
+			if err := newRepo.checkOverlap(ctx, entity); err != nil {
+				return err
+			}
+		}
+
+		if err := newRepo.updateEntity(ctx, entity); err != nil {
+			log.Error("failed to update region entity in db", "error", err, "region_id", id)
+			return err
+		}
+
+		return newRepo.reassignTreeClusters(ctx, id, 0)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -34,10 +50,11 @@ func (r *RegionRepository) Update(ctx context.Context, id int32, vFn ...entities
 	return r.GetByID(ctx, entity.ID)
 }
 
-func (r *RegionRepository) updateEntity(ctx context.Context, vehicle *entities.Region) error {
+func (r *RegionRepository) updateEntity(ctx context.Context, region *entities.Region) error {
 	params := sqlc.UpdateRegionParams{
-		ID:   vehicle.ID,
-		Name: vehicle.Name,
+		ID:       region.ID,
+		Name:     region.Name,
+		Geometry: geometryParam(region.Geometry),
 	}
 
 	return r.store.UpdateRegion(ctx, &params)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,6 +27,34 @@ func TestRegionRepository_Update(t *testing.T) {
 		assert.Equal(t, "test", gotByID.Name)
 	})
 
+	t.Run("should keep geometry when only name is updated", func(t *testing.T) {
+		// given
+		r := NewRegionRepository(defaultFields.store, defaultFields.RegionMappers)
+		before, _ := r.GetByID(context.Background(), 2)
+
+		// when
+		got, err := r.Update(context.Background(), 2, WithName("test"))
+
+		// then
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got.Geometry)
+		assert.Equal(t, before.Geometry, got.Geometry)
+	})
+
+	t.Run("should return ErrRegionOverlapping when geometry overlaps with another region", func(t *testing.T) {
+		// given
+		r := NewRegionRepository(defaultFields.store, defaultFields.RegionMappers)
+		_, err := r.Update(context.Background(), 3, WithGeometry("POLYGON ((10 10, 12 10, 12 12, 10 12, 10 10))"))
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.Update(context.Background(), 4, WithGeometry("POLYGON ((11 11, 13 11, 13 13, 11 13, 11 11))"))
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrRegionOverlapping)
+		assert.Nil(t, got)
+	})
+
 	t.Run("should return error when update region with empty name", func(t *testing.T) {
 		// given
 		r := NewRegionRepository(defaultFields.store, defaultFields.RegionMappers)
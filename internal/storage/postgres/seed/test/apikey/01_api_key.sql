@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_by, expires_at, revoked_at) VALUES
+  (1, 'Irrigation controller', '0a1b2c3d4e5f', '5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8', '{"tree:read","sensor:read"}', '6a1078e8-80fd-458f-b74e-e388fe2dd6ab', CURRENT_TIMESTAMP + INTERVAL '30 days', NULL),
+  (2, 'Legacy importer', 'f5e4d3c2b1a0', '6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b', '{"tree:write"}', '6a1078e8-80fd-458f-b74e-e388fe2dd6ab', NULL, CURRENT_TIMESTAMP - INTERVAL '1 day');
+
+ALTER SEQUENCE api_keys_id_seq RESTART WITH 3;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM api_keys;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO audit_logs (id, created_at, entity_type, entity_id, action, user_id, changes) VALUES
+  (1, '2025-03-01 08:00:00', 'tree', 1, 'create', '6a1078e8-80fd-458f-b74e-e388fe2dd6ab', '{"species":{"old":null,"new":"Quercus robur"}}'),
+  (2, '2025-03-02 08:00:00', 'tree', 1, 'update', 'api-key:0a1b2c3d4e5f', '{"species":{"old":"Quercus robur","new":"Tilia cordata"}}'),
+  (3, '2025-03-03 08:00:00', 'vehicle', 1, 'delete', '6a1078e8-80fd-458f-b74e-e388fe2dd6ab', '{}');
+
+ALTER SEQUENCE audit_logs_id_seq RESTART WITH 4;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_logs;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO plugin_grants (slug, client_id, scopes) VALUES
+  ('csv-import', 'csv-import-client', '{"tree:read","tree:write"}'),
+  ('dashboard', 'dashboard-client', '{"info:read"}'),
+  ('unused', 'unused-client', '{}');
+
+INSERT INTO plugins (slug, name, description, version, path, client_id, scopes, last_heartbeat) VALUES
+  ('csv-import', 'CSV Import', 'Import trees from csv files', 'v1.0.0', 'http://localhost:8080/plugins/csv-import', 'csv-import-client', '{"tree:read","tree:write"}', CURRENT_TIMESTAMP),
+  ('dashboard', 'Dashboard', 'Shows statistics', 'v0.2.1', 'http://localhost:8081/', 'dashboard-client', '{"info:read"}', CURRENT_TIMESTAMP - INTERVAL '1 hour');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM plugin_events;
+DELETE FROM plugins;
+DELETE FROM plugin_grants;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO tree_import_jobs (id, file_name, format, status, mapping, provider, total_rows, valid_rows, row_errors, created_by) VALUES
+  (1, 'trees.csv', 'csv', 'invalid', '{"number":"Baumnummer"}', 'city', 2, 1, '[{"row":2,"field":"planting_year","message":"\"abc\" is not a valid year"}]', '6a1078e8-80fd-458f-b74e-e388fe2dd6ab'),
+  (2, 'trees.geojson', 'geojson', 'pending', '{}', '', 0, 0, '[]', '6a1078e8-80fd-458f-b74e-e388fe2dd6ab');
+
+INSERT INTO tree_import_files (job_id, data) VALUES
+  (1, convert_to(E'Baumnummer;planting_year\nT-1;2010\nT-2;abc\n', 'UTF8')),
+  (2, convert_to('{"type":"FeatureCollection","features":[]}', 'UTF8'));
+
+ALTER SEQUENCE tree_import_jobs_id_seq RESTART WITH 3;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM tree_import_files;
+DELETE FROM tree_import_jobs;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO tree_clusters (id, name, moisture_level, address, description) VALUES
+  (1, 'Flensburger Stadion', 0.75, 'Am Stadion', ''),
+  (2, 'Sankt-Jürgen-Platz', 0.5, 'Ulmenstraße', ''),
+  (3, 'Solitüde Strand', 0.7, 'Solitüde Strand', ''),
+  (4, 'Nordstadt', 0.6, 'Nordstraße', '');
+
+ALTER SEQUENCE tree_clusters_id_seq RESTART WITH 5;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM tree_clusters;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO watering_plan_templates (id, name, description, rrule, start_date, season_start, season_end, transporter_id, trailer_id, user_ids, enabled) VALUES
+  (1, 'Tuesday and friday round', 'Young trees of the city center', 'FREQ=WEEKLY;BYDAY=TU,FR', '2025-04-01', '04-01', '09-30', 2, 1, '{"6a1078e8-80fd-458f-b74e-e388fe2dd6ab"}', TRUE),
+  (2, 'Monthly round', '', 'FREQ=MONTHLY;BYMONTHDAY=1', '2025-01-01', '', '', 2, NULL, '{"05c028d1-f0b0-4a2b-8b2d-2b8c5f2b0e8e"}', FALSE);
+
+ALTER SEQUENCE watering_plan_templates_id_seq RESTART WITH 3;
+
+INSERT INTO watering_plan_template_tree_clusters (template_id, tree_cluster_id) VALUES
+  (1, 1),
+  (1, 2),
+  (2, 3);
+
+INSERT INTO watering_plan_template_occurrences (template_id, date, watering_plan_id, holiday) VALUES
+  (1, '2025-04-18', NULL, TRUE),
+  (1, '2025-04-15', NULL, FALSE);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM watering_plan_template_occurrences;
+DELETE FROM watering_plan_template_tree_clusters;
+DELETE FROM watering_plan_templates;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO webhooks (id, name, url, secret, event_types, enabled) VALUES
+  (1, 'Irrigation system', 'https://example.com/hook', '0123456789abcdef0123456789abcdef', '{"create tree","update tree"}', TRUE),
+  (2, 'Dashboard', 'http://localhost:8081/webhook', 'fedcba9876543210fedcba9876543210', '{"update tree","receive sensor data"}', FALSE);
+
+ALTER SEQUENCE webhooks_id_seq RESTART WITH 3;
+
+INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at) VALUES
+  (1, 1, '3f1c5a52-2a4a-4c4e-9b9e-4d2f3f1c5a52', 'create tree', '{"id":"3f1c5a52-2a4a-4c4e-9b9e-4d2f3f1c5a52"}', 'pending', 0, CURRENT_TIMESTAMP - INTERVAL '1 minute'),
+  (2, 1, '8a7d2e10-6b1f-4f0e-8c3d-1e2f3a4b5c6d', 'update tree', '{"id":"8a7d2e10-6b1f-4f0e-8c3d-1e2f3a4b5c6d"}', 'retrying', 2, CURRENT_TIMESTAMP + INTERVAL '1 hour'),
+  (3, 1, 'c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f', 'create tree', '{"id":"c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f"}', 'success', 1, CURRENT_TIMESTAMP - INTERVAL '1 hour');
+
+ALTER SEQUENCE webhook_deliveries_id_seq RESTART WITH 4;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM webhook_deliveries;
+DELETE FROM webhooks;
+-- +goose StatementEnd
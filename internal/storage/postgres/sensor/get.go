@@ -6,17 +6,18 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 )
 
-func (r *SensorRepository) GetAll(ctx context.Context, query entities.Query) ([]*entities.Sensor, int64, error) {
+func (r *SensorRepository) GetAll(ctx context.Context, query entities.SensorQuery) ([]*entities.Sensor, int64, error) {
 	log := logger.GetLogger(ctx)
 	page, limit, err := pagination.GetValues(ctx)
 	if err != nil {
 		return nil, 0, r.store.MapError(err, sqlc.Sensor{})
 	}
 
-	totalCount, err := r.GetCount(ctx, query)
+	totalCount, err := r.count(ctx, query)
 	if err != nil {
 		return nil, 0, r.store.MapError(err, sqlc.Sensor{})
 	}
@@ -30,8 +31,17 @@ func (r *SensorRepository) GetAll(ctx context.Context, query entities.Query) ([]
 		page = 1
 	}
 
+	sp := store.NewSpatialParams(&query.SpatialQuery)
 	rows, err := r.store.GetAllSensors(ctx, &sqlc.GetAllSensorsParams{
 		Provider: query.Provider,
+		MinLon:   sp.MinLon,
+		MinLat:   sp.MinLat,
+		MaxLon:   sp.MaxLon,
+		MaxLat:   sp.MaxLat,
+		Radius:   sp.Radius,
+		NearLon:  sp.NearLon,
+		NearLat:  sp.NearLat,
+		Polygon:  sp.Polygon,
 		Limit:    limit,
 		Offset:   (page - 1) * limit,
 	})
@@ -56,8 +66,23 @@ func (r *SensorRepository) GetAll(ctx context.Context, query entities.Query) ([]
 }
 
 func (r *SensorRepository) GetCount(ctx context.Context, query entities.Query) (int64, error) {
+	return r.count(ctx, entities.SensorQuery{Query: query})
+}
+
+func (r *SensorRepository) count(ctx context.Context, query entities.SensorQuery) (int64, error) {
 	log := logger.GetLogger(ctx)
-	totalCount, err := r.store.GetAllSensorsCount(ctx, query.Provider)
+	sp := store.NewSpatialParams(&query.SpatialQuery)
+	totalCount, err := r.store.GetAllSensorsCount(ctx, &sqlc.GetAllSensorsCountParams{
+		Provider: query.Provider,
+		MinLon:   sp.MinLon,
+		MinLat:   sp.MinLat,
+		MaxLon:   sp.MaxLon,
+		MaxLat:   sp.MaxLat,
+		Radius:   sp.Radius,
+		NearLon:  sp.NearLon,
+		NearLat:  sp.NearLat,
+		Polygon:  sp.Polygon,
+	})
 	if err != nil {
 		log.Debug("failed to get total sensor count in db", "error", err)
 		return 0, err
@@ -20,7 +20,7 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(-1))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx, entities.Query{})
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -44,6 +44,48 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		}
 	})
 
+	t.Run("should return sensors in bounding box", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		ctx := context.WithValue(context.Background(), "page", int32(1))
+		ctx = context.WithValue(ctx, "limit", int32(-1))
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{SpatialQuery: entities.SpatialQuery{
+			BBox: &entities.BoundingBox{MinLon: 9.44, MinLat: 54.78, MaxLon: 9.45, MaxLat: 54.79},
+		}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totalCount)
+		assert.Len(t, got, 1)
+		assert.Equal(t, "sensor-2", got[0].ID)
+	})
+
+	t.Run("should return sensors in radius sorted by distance", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/sensor")
+		r := NewSensorRepository(suite.Store, defaultSensorMappers())
+
+		ctx := context.WithValue(context.Background(), "page", int32(1))
+		ctx = context.WithValue(ctx, "limit", int32(-1))
+
+		// when
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{SpatialQuery: entities.SpatialQuery{
+			Near:   &entities.Coordinate{Latitude: 54.8210, Longitude: 9.4880},
+			Radius: 300,
+		}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), totalCount)
+		assert.Equal(t, []string{"sensor-4", "sensor-provider", "sensor-1"}, []string{got[0].ID, got[1].ID, got[2].ID})
+	})
+
 	t.Run("should return all sensors without limitation with provider", func(t *testing.T) {
 		// given
 		suite.ResetDB(t)
@@ -56,7 +98,7 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(-1))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx, entities.Query{Provider: "test-provider"})
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{Query: entities.Query{Provider: "test-provider"}})
 
 		// then
 		assert.NoError(t, err)
@@ -85,7 +127,7 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(2))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx, entities.Query{})
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -109,7 +151,7 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(2))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx, entities.Query{})
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{})
 
 		// then
 		assert.Error(t, err)
@@ -127,7 +169,7 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(0))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx, entities.Query{})
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{})
 
 		// then
 		assert.Error(t, err)
@@ -144,7 +186,7 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		ctx = context.WithValue(ctx, "limit", int32(2))
 
 		// when
-		got, totalCount, err := r.GetAll(ctx, entities.Query{})
+		got, totalCount, err := r.GetAll(ctx, entities.SensorQuery{})
 
 		// then
 		assert.NoError(t, err)
@@ -159,7 +201,7 @@ func TestSensorRepository_GetAll(t *testing.T) {
 		cancel()
 
 		// when
-		got, _, err := r.GetAll(ctx, entities.Query{})
+		got, _, err := r.GetAll(ctx, entities.SensorQuery{})
 
 		// then
 		assert.Error(t, err)
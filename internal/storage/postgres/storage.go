@@ -5,24 +5,39 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/alert"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/apikey"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/auditlog"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/evaluation"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/event"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/feature"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/job"
 	mapper "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/notification"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/plugin"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/region"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensor"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treeimport"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/vehicle"
 	wateringplan "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/watering_plan"
+	wateringplantemplate "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/watering_plan_template"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/weather"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/webhook"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func NewRepository(conn *pgxpool.Pool) *storage.Repository {
+	// the repositories share the transaction of the context, see store.WithTxContext
+	db := store.NewTxDB(conn)
 	treeMappers := tree.NewTreeRepositoryMappers(
 		&mapper.InternalTreeRepoMapperImpl{},
 		&mapper.InternalSensorRepoMapperImpl{},
 		&mapper.InternalTreeClusterRepoMapperImpl{},
 	)
-	treeRepo := tree.NewTreeRepository(store.NewStore(conn, sqlc.New(conn)), treeMappers)
+	treeRepo := tree.NewTreeRepository(store.NewStore(conn, sqlc.New(db)), treeMappers)
 	slog.Info("successfully initialized tree repository", "service", "postgres")
 
 	tcMappers := treecluster.NewTreeClusterRepositoryMappers(
@@ -31,25 +46,25 @@ func NewRepository(conn *pgxpool.Pool) *storage.Repository {
 		&mapper.InternalRegionRepoMapperImpl{},
 		&mapper.InternalTreeRepoMapperImpl{},
 	)
-	treeClusterRepo := treecluster.NewTreeClusterRepository(store.NewStore(conn, sqlc.New(conn)), tcMappers)
+	treeClusterRepo := treecluster.NewTreeClusterRepository(store.NewStore(conn, sqlc.New(db)), tcMappers)
 	slog.Info("successfully initialized treecluster repository", "service", "postgres")
 
 	vehicleMappers := vehicle.NewVehicleRepositoryMappers(
 		&mapper.InternalVehicleRepoMapperImpl{},
 	)
-	vehicleRepo := vehicle.NewVehicleRepository(store.NewStore(conn, sqlc.New(conn)), vehicleMappers)
+	vehicleRepo := vehicle.NewVehicleRepository(store.NewStore(conn, sqlc.New(db)), vehicleMappers)
 	slog.Info("successfully initialized vehicle repository", "service", "postgres")
 
 	sensorMappers := sensor.NewSensorRepositoryMappers(
 		&mapper.InternalSensorRepoMapperImpl{},
 	)
-	sensorRepo := sensor.NewSensorRepository(store.NewStore(conn, sqlc.New(conn)), sensorMappers)
+	sensorRepo := sensor.NewSensorRepository(store.NewStore(conn, sqlc.New(db)), sensorMappers)
 	slog.Info("successfully initialized sensor repository", "service", "postgres")
 
 	regionMappers := region.NewRegionMappers(
 		&mapper.InternalRegionRepoMapperImpl{},
 	)
-	regionRepo := region.NewRegionRepository(store.NewStore(conn, sqlc.New(conn)), regionMappers)
+	regionRepo := region.NewRegionRepository(store.NewStore(conn, sqlc.New(db)), regionMappers)
 	slog.Info("successfully initialized region repository", "service", "postgres")
 
 	wateringPlanMappers := wateringplan.NewWateringPlanRepositoryMappers(
@@ -57,15 +72,106 @@ func NewRepository(conn *pgxpool.Pool) *storage.Repository {
 		&mapper.InternalVehicleRepoMapperImpl{},
 		&mapper.InternalTreeClusterRepoMapperImpl{},
 	)
-	wateringPlanRepo := wateringplan.NewWateringPlanRepository(store.NewStore(conn, sqlc.New(conn)), wateringPlanMappers)
+	wateringPlanRepo := wateringplan.NewWateringPlanRepository(store.NewStore(conn, sqlc.New(db)), wateringPlanMappers)
 	slog.Info("successfully initialized wateringplan repository", "service", "postgres")
 
+	pluginMappers := plugin.NewPluginRepositoryMappers(
+		&mapper.InternalPluginRepoMapperImpl{},
+	)
+	pluginRepo := plugin.NewPluginRepository(store.NewStore(conn, sqlc.New(db)), pluginMappers)
+	slog.Info("successfully initialized plugin repository", "service", "postgres")
+
+	webhookMappers := webhook.NewWebhookRepositoryMappers(
+		&mapper.InternalWebhookRepoMapperImpl{},
+	)
+	webhookRepo := webhook.NewWebhookRepository(store.NewStore(conn, sqlc.New(db)), webhookMappers)
+	slog.Info("successfully initialized webhook repository", "service", "postgres")
+
+	apiKeyMappers := apikey.NewAPIKeyRepositoryMappers(
+		&mapper.InternalAPIKeyRepoMapperImpl{},
+	)
+	apiKeyRepo := apikey.NewAPIKeyRepository(store.NewStore(conn, sqlc.New(db)), apiKeyMappers)
+	slog.Info("successfully initialized api key repository", "service", "postgres")
+
+	treeImportMappers := treeimport.NewTreeImportRepositoryMappers(
+		&mapper.InternalTreeImportRepoMapperImpl{},
+	)
+	treeImportRepo := treeimport.NewTreeImportRepository(store.NewStore(conn, sqlc.New(db)), treeImportMappers)
+	slog.Info("successfully initialized tree import repository", "service", "postgres")
+
+	featureMappers := feature.NewFeatureRepositoryMappers(
+		&mapper.InternalFeatureRepoMapperImpl{},
+	)
+	featureRepo := feature.NewFeatureRepository(store.NewStore(conn, sqlc.New(db)), featureMappers)
+	slog.Info("successfully initialized feature repository", "service", "postgres")
+
+	auditLogMappers := auditlog.NewAuditLogRepositoryMappers(
+		&mapper.InternalAuditLogRepoMapperImpl{},
+	)
+	auditLogRepo := auditlog.NewAuditLogRepository(store.NewStore(conn, sqlc.New(db)), auditLogMappers)
+	slog.Info("successfully initialized audit log repository", "service", "postgres")
+
+	eventMappers := event.NewEventRepositoryMappers(
+		&mapper.InternalEventRepoMapperImpl{},
+	)
+	eventRepo := event.NewEventRepository(store.NewStore(conn, sqlc.New(db)), eventMappers)
+	slog.Info("successfully initialized event repository", "service", "postgres")
+
+	jobMappers := job.NewJobRepositoryMappers(
+		&mapper.InternalJobRepoMapperImpl{},
+	)
+	jobRepo := job.NewJobRepository(store.NewStore(conn, sqlc.New(db)), jobMappers)
+	slog.Info("successfully initialized job repository", "service", "postgres")
+
+	weatherMappers := weather.NewWeatherRepositoryMappers(
+		&mapper.InternalWeatherRepoMapperImpl{},
+	)
+	weatherRepo := weather.NewWeatherRepository(store.NewStore(conn, sqlc.New(db)), weatherMappers)
+	slog.Info("successfully initialized weather repository", "service", "postgres")
+
+	wateringPlanTemplateMappers := wateringplantemplate.NewWateringPlanTemplateRepositoryMappers(
+		&mapper.InternalWateringPlanTemplateRepoMapperImpl{},
+	)
+	wateringPlanTemplateRepo := wateringplantemplate.NewWateringPlanTemplateRepository(store.NewStore(conn, sqlc.New(db)), wateringPlanTemplateMappers)
+	slog.Info("successfully initialized watering plan template repository", "service", "postgres")
+
+	evaluationMappers := evaluation.NewEvaluationRepositoryMappers(
+		&mapper.InternalEvaluationRepoMapperImpl{},
+	)
+	evaluationRepo := evaluation.NewEvaluationRepository(store.NewStore(conn, sqlc.New(db)), evaluationMappers)
+	slog.Info("successfully initialized evaluation repository", "service", "postgres")
+
+	notificationMappers := notification.NewNotificationRepositoryMappers(
+		&mapper.InternalNotificationRepoMapperImpl{},
+	)
+	notificationRepo := notification.NewNotificationRepository(store.NewStore(conn, sqlc.New(db)), notificationMappers)
+	slog.Info("successfully initialized notification repository", "service", "postgres")
+
+	alertMappers := alert.NewAlertRepositoryMappers(
+		&mapper.InternalAlertRepoMapperImpl{},
+	)
+	alertRepo := alert.NewAlertRepository(store.NewStore(conn, sqlc.New(db)), alertMappers)
+	slog.Info("successfully initialized alert repository", "service", "postgres")
+
 	return &storage.Repository{
-		Tree:         treeRepo,
-		TreeCluster:  treeClusterRepo,
-		Vehicle:      vehicleRepo,
-		Sensor:       sensorRepo,
-		Region:       regionRepo,
-		WateringPlan: wateringPlanRepo,
+		Tree:                 treeRepo,
+		TreeCluster:          treeClusterRepo,
+		Vehicle:              vehicleRepo,
+		Sensor:               sensorRepo,
+		Region:               regionRepo,
+		WateringPlan:         wateringPlanRepo,
+		Plugin:               pluginRepo,
+		Webhook:              webhookRepo,
+		APIKey:               apiKeyRepo,
+		TreeImport:           treeImportRepo,
+		Feature:              featureRepo,
+		AuditLog:             auditLogRepo,
+		Event:                eventRepo,
+		Job:                  jobRepo,
+		Weather:              weatherRepo,
+		WateringPlanTemplate: wateringPlanTemplateRepo,
+		Evaluation:           evaluationRepo,
+		Notification:         notificationRepo,
+		Alert:                alertRepo,
 	}
 }
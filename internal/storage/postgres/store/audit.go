@@ -0,0 +1,146 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+// AuditSnapshot is the json encoded state of an entity keyed by the snake case field name
+type AuditSnapshot map[string]json.RawMessage
+
+var auditIgnoredFields = []string{"ID", "CreatedAt", "UpdatedAt", "Version"}
+
+var auditNameReplacer = strings.NewReplacer("IDs", "Ids", "ID", "Id", "URL", "Url")
+
+// NewAuditSnapshot captures the exported fields of an entity. Linked entities are reduced
+// to their ids, e.g. TreeCluster becomes tree_cluster_id and Trees becomes tree_ids.
+// The id, the version and the timestamps are left out, the id is stored with the audit log
+// entry and the others change with every write.
+func NewAuditSnapshot(entity any) AuditSnapshot {
+	snapshot := make(AuditSnapshot)
+	v := reflect.Indirect(reflect.ValueOf(entity))
+	if v.Kind() != reflect.Struct {
+		return snapshot
+	}
+
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() || slices.Contains(auditIgnoredFields, field.Name) {
+			continue
+		}
+
+		name, value := auditField(toSnakeCase(field.Name), v.Field(i))
+		raw, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		snapshot[name] = raw
+	}
+
+	return snapshot
+}
+
+func auditField(name string, v reflect.Value) (string, any) {
+	if isLinkedEntity(v.Type()) {
+		if v.IsNil() {
+			return name + "_id", nil
+		}
+		return name + "_id", v.Elem().FieldByName("ID").Interface()
+	}
+
+	if v.Kind() == reflect.Slice && isLinkedEntity(v.Type().Elem()) {
+		ids := make([]any, 0, v.Len())
+		for i := range v.Len() {
+			if !v.Index(i).IsNil() {
+				ids = append(ids, v.Index(i).Elem().FieldByName("ID").Interface())
+			}
+		}
+		return strings.TrimSuffix(name, "s") + "_ids", ids
+	}
+
+	// nil and empty collections are the same for the audit log
+	switch {
+	case v.Kind() == reflect.Slice && v.IsNil():
+		return name, []any{}
+	case v.Kind() == reflect.Map && v.IsNil():
+		return name, map[string]any{}
+	}
+
+	return name, v.Interface()
+}
+
+func isLinkedEntity(t reflect.Type) bool {
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := t.Elem().FieldByName("ID")
+	return ok
+}
+
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range auditNameReplacer.Replace(name) {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Diff returns the fields that differ between the snapshots. Fields that are missing in
+// one of the snapshots are reported with a null value on that side.
+func (prev AuditSnapshot) Diff(curr AuditSnapshot) map[string]entities.AuditChange {
+	changes := make(map[string]entities.AuditChange)
+	for field, oldValue := range prev {
+		newValue, ok := curr[field]
+		if !ok || !bytes.Equal(oldValue, newValue) {
+			changes[field] = entities.AuditChange{Old: oldValue, New: newValue}
+		}
+	}
+
+	for field, newValue := range curr {
+		if _, ok := prev[field]; !ok {
+			changes[field] = entities.AuditChange{New: newValue}
+		}
+	}
+
+	return changes
+}
+
+// WriteAuditLog stores the changes between the two snapshots together with the actor
+// of the request. Updates that did not change any field are not logged.
+func (s *Store) WriteAuditLog(ctx context.Context, entityType entities.AuditEntityType, entityID int32, action entities.AuditAction, prev, curr AuditSnapshot) error {
+	changes := prev.Diff(curr)
+	if action == entities.AuditActionUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	data, err := mapper.MapAuditChangesToByte(changes)
+	if err != nil {
+		return err
+	}
+
+	actor, _ := ctx.Value(enums.ContextKeyActor).(string)
+	return s.CreateAuditLog(ctx, &sqlc.CreateAuditLogParams{
+		EntityType: string(entityType),
+		EntityID:   entityID,
+		Action:     sqlc.AuditAction(action),
+		UserID:     actor,
+		Changes:    data,
+	})
+}
@@ -0,0 +1,142 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_NewAuditSnapshot(t *testing.T) {
+	t.Run("should reduce linked entities to their ids", func(t *testing.T) {
+		// given
+		tree := &entities.Tree{
+			ID:          1,
+			CreatedAt:   time.Now(),
+			Species:     "Quercus robur",
+			TreeCluster: &entities.TreeCluster{ID: 2, Name: "Cluster"},
+			LastWatered: nil,
+		}
+
+		// when
+		got := store.NewAuditSnapshot(tree)
+
+		// then
+		assert.JSONEq(t, `2`, string(got["tree_cluster_id"]))
+		assert.JSONEq(t, `null`, string(got["sensor_id"]))
+		assert.JSONEq(t, `"Quercus robur"`, string(got["species"]))
+		assert.JSONEq(t, `{}`, string(got["additional_info"]))
+		assert.NotContains(t, got, "id")
+		assert.NotContains(t, got, "created_at")
+		assert.NotContains(t, got, "tree_cluster")
+	})
+
+	t.Run("should reduce linked entity lists to their ids", func(t *testing.T) {
+		// given
+		wp := &entities.WateringPlan{
+			TreeClusters: []*entities.TreeCluster{{ID: 1}, {ID: 3}},
+			GpxURL:       "/route/gpx/plan.gpx",
+		}
+
+		// when
+		got := store.NewAuditSnapshot(wp)
+
+		// then
+		assert.JSONEq(t, `[1,3]`, string(got["tree_cluster_ids"]))
+		assert.JSONEq(t, `[]`, string(got["user_ids"]))
+		assert.JSONEq(t, `"/route/gpx/plan.gpx"`, string(got["gpx_url"]))
+	})
+}
+
+func TestStore_AuditSnapshotDiff(t *testing.T) {
+	t.Run("should return changed fields only", func(t *testing.T) {
+		// given
+		prev := store.NewAuditSnapshot(&entities.Vehicle{NumberPlate: "FL ZB 123", WaterCapacity: 1000})
+		curr := store.NewAuditSnapshot(&entities.Vehicle{NumberPlate: "FL ZB 123", WaterCapacity: 2000})
+
+		// when
+		got := prev.Diff(curr)
+
+		// then
+		assert.Equal(t, map[string]entities.AuditChange{
+			"water_capacity": {Old: json.RawMessage(`1000`), New: json.RawMessage(`2000`)},
+		}, got)
+	})
+
+	t.Run("should return all fields as new without previous snapshot", func(t *testing.T) {
+		// given
+		curr := store.NewAuditSnapshot(&entities.Vehicle{NumberPlate: "FL ZB 123"})
+
+		// when
+		got := store.AuditSnapshot(nil).Diff(curr)
+
+		// then
+		assert.Len(t, got, len(curr))
+		assert.Nil(t, got["number_plate"].Old)
+		assert.JSONEq(t, `"FL ZB 123"`, string(got["number_plate"].New))
+	})
+
+	t.Run("should return no changes for equal snapshots", func(t *testing.T) {
+		// given
+		snapshot := store.NewAuditSnapshot(&entities.Vehicle{NumberPlate: "FL ZB 123"})
+
+		// when
+		got := snapshot.Diff(snapshot)
+
+		// then
+		assert.Empty(t, got)
+	})
+}
+
+func TestStore_WriteAuditLog(t *testing.T) {
+	t.Run("should store changes with actor of the context", func(t *testing.T) {
+		// given
+		pool := poolConn(t)
+		s := store.NewStore(pool, sqlc.New(pool))
+		ctx := context.WithValue(context.Background(), enums.ContextKeyActor, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+		prev := store.AuditSnapshot{"name": json.RawMessage(`"old"`)}
+		curr := store.AuditSnapshot{"name": json.RawMessage(`"new"`)}
+
+		// when
+		err := s.WriteAuditLog(ctx, entities.AuditEntityTypeVehicle, 1, entities.AuditActionUpdate, prev, curr)
+
+		// then
+		assert.NoError(t, err)
+		rows, err := s.GetAllAuditLogs(context.Background(), &sqlc.GetAllAuditLogsParams{
+			EntityType: string(entities.AuditEntityTypeVehicle),
+			EntityID:   utils.P(int32(1)),
+			Limit:      10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab", rows[0].UserID)
+		assert.Equal(t, sqlc.AuditActionUpdate, rows[0].Action)
+		assert.JSONEq(t, `{"name":{"old":"old","new":"new"}}`, string(rows[0].Changes))
+	})
+
+	t.Run("should skip updates without changes", func(t *testing.T) {
+		// given
+		pool := poolConn(t)
+		s := store.NewStore(pool, sqlc.New(pool))
+		snapshot := store.AuditSnapshot{"name": json.RawMessage(`"same"`)}
+
+		// when
+		err := s.WriteAuditLog(context.Background(), entities.AuditEntityTypeVehicle, 2, entities.AuditActionUpdate, snapshot, snapshot)
+
+		// then
+		assert.NoError(t, err)
+		count, err := s.GetAllAuditLogsCount(context.Background(), &sqlc.GetAllAuditLogsCountParams{
+			EntityType: string(entities.AuditEntityTypeVehicle),
+			EntityID:   utils.P(int32(2)),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
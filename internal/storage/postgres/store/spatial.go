@@ -0,0 +1,38 @@
+package store
+
+import "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+
+// SpatialParams are the nullable parameters of the spatial filters of the list queries.
+// A nil parameter disables its filter.
+type SpatialParams struct {
+	MinLon  *float64
+	MinLat  *float64
+	MaxLon  *float64
+	MaxLat  *float64
+	Radius  *float64
+	NearLon *float64
+	NearLat *float64
+	Polygon *string
+}
+
+func NewSpatialParams(query *entities.SpatialQuery) SpatialParams {
+	var params SpatialParams
+	if query.BBox != nil {
+		params.MinLon = &query.BBox.MinLon
+		params.MinLat = &query.BBox.MinLat
+		params.MaxLon = &query.BBox.MaxLon
+		params.MaxLat = &query.BBox.MaxLat
+	}
+	if query.Near != nil {
+		params.NearLon = &query.Near.Longitude
+		params.NearLat = &query.Near.Latitude
+		if query.Radius > 0 {
+			params.Radius = &query.Radius
+		}
+	}
+	if query.Polygon != "" {
+		params.Polygon = &query.Polygon
+	}
+
+	return params
+}
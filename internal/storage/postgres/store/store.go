@@ -65,13 +65,34 @@ func (s *Store) MapError(err error, dbType any) error {
 	return err
 }
 
+// txContextKey holds the transaction of WithTxContext in the context
+type txContextKey struct{}
+
 func (s *Store) WithTx(ctx context.Context, fn func(*Store) error) error {
+	if fn == nil {
+		return errors.New("txFn is nil")
+	}
+
+	return s.WithTxContext(ctx, func(_ context.Context, s *Store) error {
+		return fn(s)
+	})
+}
+
+// WithTxContext runs fn in a transaction like WithTx and passes a context that holds the transaction. Transactions
+// started with this context, also by other repositories, are savepoints of it and are committed together with it.
+func (s *Store) WithTxContext(ctx context.Context, fn func(context.Context, *Store) error) error {
 	log := logger.GetLogger(ctx)
 	if fn == nil {
 		return errors.New("txFn is nil")
 	}
 
-	tx, err := s.db.Begin(ctx)
+	var tx pgx.Tx
+	var err error
+	if outer, ok := ctx.Value(txContextKey{}).(pgx.Tx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = s.db.Begin(ctx)
+	}
 	if err != nil {
 		return err
 	}
@@ -80,7 +101,7 @@ func (s *Store) WithTx(ctx context.Context, fn func(*Store) error) error {
 
 	store := *s
 	store.Queries = qtx
-	err = fn(&store)
+	err = fn(context.WithValue(ctx, txContextKey{}, tx), &store)
 	if err == nil {
 		log.Debug("committing transaction")
 		return tx.Commit(ctx)
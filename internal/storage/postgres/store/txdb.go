@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ sqlc.DBTX = (*TxDB)(nil)
+
+// TxDB runs the queries in the transaction of the context if there is one, see WithTxContext. Otherwise
+// the queries run on the pool. Repositories built on it take part in a transaction started by another
+// repository, so several changes can be committed together.
+type TxDB struct {
+	pool *pgxpool.Pool
+}
+
+func NewTxDB(pool *pgxpool.Pool) *TxDB {
+	return &TxDB{pool: pool}
+}
+
+func (d *TxDB) db(ctx context.Context) sqlc.DBTX {
+	if tx, ok := ctx.Value(txContextKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return d.pool
+}
+
+func (d *TxDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return d.db(ctx).Exec(ctx, sql, args...)
+}
+
+func (d *TxDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return d.db(ctx).Query(ctx, sql, args...)
+}
+
+func (d *TxDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return d.db(ctx).QueryRow(ctx, sql, args...)
+}
@@ -97,7 +97,7 @@ func initStore(dbURL string) *store.Store {
 		log.Fatalf("Error while connecting to PostgreSQL: %s", err)
 	}
 
-	s := store.NewStore(pool, sqlc.New(pool))
+	s := store.NewStore(pool, sqlc.New(store.NewTxDB(pool)))
 
 	return s
 }
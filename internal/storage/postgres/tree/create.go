@@ -66,7 +66,7 @@ func (r *TreeRepository) Create(ctx context.Context, createFn func(*entities.Tre
 			return err
 		}
 
-		return nil
+		return s.WriteAuditLog(ctx, entities.AuditEntityTypeTree, id, entities.AuditActionCreate, nil, store.NewAuditSnapshot(createdTree))
 	})
 
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils/pagination"
 	"github.com/jackc/pgx/v5"
 
@@ -40,11 +41,20 @@ func (r *TreeRepository) GetAll(ctx context.Context, query entities.TreeQuery) (
 		wateringStatuses = append(wateringStatuses, string(ws))
 	}
 
+	sp := store.NewSpatialParams(&query.SpatialQuery)
 	rows, err := r.store.GetAllTrees(ctx, &sqlc.GetAllTreesParams{
 		WateringStatus: wateringStatuses,
 		Provider:       query.Provider,
 		Years:          query.PlantingYears,
 		HasCluster:     query.HasCluster,
+		MinLon:         sp.MinLon,
+		MinLat:         sp.MinLat,
+		MaxLon:         sp.MaxLon,
+		MaxLat:         sp.MaxLat,
+		Radius:         sp.Radius,
+		NearLon:        sp.NearLon,
+		NearLat:        sp.NearLat,
+		Polygon:        sp.Polygon,
 		Limit:          limit,
 		Offset:         (page - 1) * limit,
 	})
@@ -77,11 +87,20 @@ func (r *TreeRepository) GetCount(ctx context.Context, query entities.TreeQuery)
 		wateringStatuses = append(wateringStatuses, string(ws))
 	}
 
+	sp := store.NewSpatialParams(&query.SpatialQuery)
 	totalCount, err := r.store.GetAllTreesCount(ctx, &sqlc.GetAllTreesCountParams{
 		WateringStatus: wateringStatuses,
 		Provider:       query.Provider,
 		Years:          query.PlantingYears,
 		HasCluster:     query.HasCluster,
+		MinLon:         sp.MinLon,
+		MinLat:         sp.MinLat,
+		MaxLon:         sp.MaxLon,
+		MaxLat:         sp.MaxLat,
+		Radius:         sp.Radius,
+		NearLon:        sp.NearLon,
+		NearLat:        sp.NearLat,
+		Polygon:        sp.Polygon,
 	})
 
 	if err != nil {
@@ -225,6 +244,27 @@ func (r *TreeRepository) GetByCoordinates(ctx context.Context, latitude, longitu
 	return tree, nil
 }
 
+func (r *TreeRepository) GetByProviderAndNumber(ctx context.Context, provider, number string) (*entities.Tree, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetTreeByProviderAndNumber(ctx, &sqlc.GetTreeByProviderAndNumberParams{
+		Provider: provider,
+		Number:   number,
+	})
+	if err != nil {
+		log.Debug("failed to get tree by provider and number in db", "error", err, "provider", provider, "number", number)
+		return nil, r.store.MapError(err, sqlc.Tree{})
+	}
+	tree, err := r.mapper.FromSql(row)
+	if err != nil {
+		log.Debug("failed to convert entity", "error", err)
+		return nil, err
+	}
+	if err := r.mapFields(ctx, tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
 func (r *TreeRepository) GetSensorByTreeID(ctx context.Context, treeID int32) (*entities.Sensor, error) {
 	log := logger.GetLogger(ctx)
 	row, err := r.store.GetSensorByTreeID(ctx, treeID)
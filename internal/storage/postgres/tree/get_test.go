@@ -871,3 +871,64 @@ var (
 		},
 	}
 )
+
+func TestTreeRepository_GetAllWithSpatialQuery(t *testing.T) {
+	suite.ResetDB(t)
+	suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+	r := NewTreeRepository(suite.Store, mappers)
+
+	ctx := context.WithValue(context.Background(), "page", int32(1))
+	ctx = context.WithValue(ctx, "limit", int32(-1))
+
+	t.Run("should return trees in bounding box", func(t *testing.T) {
+		// when
+		trees, totalCount, err := r.GetAll(ctx, entities.TreeQuery{SpatialQuery: entities.SpatialQuery{
+			BBox: &entities.BoundingBox{MinLon: 9.48, MinLat: 54.82, MaxLon: 9.49, MaxLat: 54.83},
+		}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), totalCount)
+		assert.Equal(t, int32(1), trees[0].ID)
+		assert.Equal(t, int32(2), trees[1].ID)
+	})
+
+	t.Run("should return trees in radius sorted by distance", func(t *testing.T) {
+		// when
+		trees, totalCount, err := r.GetAll(ctx, entities.TreeQuery{SpatialQuery: entities.SpatialQuery{
+			Near:   &entities.Coordinate{Latitude: 54.8215, Longitude: 9.4872},
+			Radius: 500,
+		}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), totalCount)
+		assert.Equal(t, int32(2), trees[0].ID)
+		assert.Equal(t, int32(1), trees[1].ID)
+	})
+
+	t.Run("should sort all trees by distance without radius", func(t *testing.T) {
+		// when
+		trees, totalCount, err := r.GetAll(ctx, entities.TreeQuery{SpatialQuery: entities.SpatialQuery{
+			Near: &entities.Coordinate{Latitude: 54.1, Longitude: 9.2},
+		}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(testTrees)), totalCount)
+		assert.Equal(t, int32(4), trees[0].ID)
+		assert.Equal(t, int32(5), trees[1].ID)
+	})
+
+	t.Run("should return trees in polygon", func(t *testing.T) {
+		// when
+		trees, totalCount, err := r.GetAll(ctx, entities.TreeQuery{SpatialQuery: entities.SpatialQuery{
+			Polygon: "POLYGON((9.44 54.78, 9.45 54.78, 9.45 54.79, 9.44 54.79, 9.44 54.78))",
+		}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int32(3), trees[0].ID)
+	})
+}
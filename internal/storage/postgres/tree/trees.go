@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	imgMapper "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 )
 
 type TreeRepository struct {
@@ -44,10 +47,26 @@ func NewTreeRepository(s *store.Store, mappers TreeMappers) *TreeRepository {
 
 func (r *TreeRepository) Delete(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewTreeRepository(s, r.TreeMappers)
+		if err := newRepo.checkVersion(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.DeleteTree(ctx, id); err != nil {
+			log.Debug("failed to delete tree in db", "error", err, "tree_id", id)
+			return err
+		}
+
+		if err := s.WriteAuditLog(ctx, entities.AuditEntityTypeTree, id, entities.AuditActionDelete, nil, nil); err != nil {
+			log.Error("failed to write audit log for deleted tree", "error", err, "tree_id", id)
+			return err
+		}
+
+		return nil
+	})
 
-	_, err := r.store.DeleteTree(ctx, id)
 	if err != nil {
-		log.Debug("failed to delete tree in db", "error", err, "tree_id", id)
 		return err
 	}
 
@@ -55,6 +74,17 @@ func (r *TreeRepository) Delete(ctx context.Context, id int32) error {
 	return nil
 }
 
+// checkVersion locks the tree until the end of the transaction and compares its version
+// with the If-Match header of the request
+func (r *TreeRepository) checkVersion(ctx context.Context, id int32) error {
+	version, err := r.store.LockTree(ctx, id)
+	if err != nil {
+		return r.store.MapError(err, sqlc.Tree{})
+	}
+
+	return etag.Check(ctx, version)
+}
+
 func (r *TreeRepository) UnlinkTreeClusterID(ctx context.Context, treeClusterID int32) error {
 	log := logger.GetLogger(ctx)
 
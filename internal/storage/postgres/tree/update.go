@@ -20,6 +20,10 @@ func (r *TreeRepository) Update(ctx context.Context, id int32, updateFn func(*en
 	err := r.store.WithTx(ctx, func(s *store.Store) error {
 		newRepo := NewTreeRepository(s, r.TreeMappers)
 
+		if err := newRepo.checkVersion(ctx, id); err != nil {
+			return err
+		}
+
 		tree, err := newRepo.GetByID(ctx, id)
 		if err != nil {
 			log.Error("failed to get tree entity from db", "error", err, "tree_id", id)
@@ -30,6 +34,7 @@ func (r *TreeRepository) Update(ctx context.Context, id int32, updateFn func(*en
 			return errors.New("updateFn is nil")
 		}
 
+		prev := store.NewAuditSnapshot(tree)
 		updated, err := updateFn(tree, newRepo)
 		if err != nil {
 			return err
@@ -51,7 +56,7 @@ func (r *TreeRepository) Update(ctx context.Context, id int32, updateFn func(*en
 			return err
 		}
 
-		return nil
+		return s.WriteAuditLog(ctx, entities.AuditEntityTypeTree, id, entities.AuditActionUpdate, prev, store.NewAuditSnapshot(updatedTree))
 	})
 
 	if err != nil {
@@ -0,0 +1,104 @@
+package tree
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func (r *TreeRepository) Upsert(ctx context.Context, trees []*entities.Tree) ([]*entities.TreeImportChange, error) {
+	log := logger.GetLogger(ctx)
+	changes := make([]*entities.TreeImportChange, 0, len(trees))
+
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewTreeRepository(s, r.TreeMappers)
+		for _, tree := range trees {
+			change, err := newRepo.upsertEntity(ctx, tree)
+			if err != nil {
+				log.Error("failed to upsert tree entity in db", "error", err, "provider", tree.Provider, "number", tree.Number)
+				return err
+			}
+			changes = append(changes, change)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("tree entities upserted successfully in db", "count", len(changes))
+	return changes, nil
+}
+
+func (r *TreeRepository) upsertEntity(ctx context.Context, tree *entities.Tree) (*entities.TreeImportChange, error) {
+	if err := r.validateTreeEntity(tree); err != nil {
+		return nil, err
+	}
+
+	prev, err := r.GetByProviderAndNumber(ctx, tree.Provider, tree.Number)
+	if err != nil {
+		var entityNotFoundErr storage.ErrEntityNotFound
+		if !errors.As(err, &entityNotFoundErr) {
+			return nil, err
+		}
+
+		entity := defaultTree()
+		entity.Number = tree.Number
+		entity.Provider = tree.Provider
+		mergeImportedTree(&entity, tree)
+
+		id, err := r.createEntity(ctx, &entity)
+		if err != nil {
+			return nil, err
+		}
+
+		created, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := r.store.WriteAuditLog(ctx, entities.AuditEntityTypeTree, id, entities.AuditActionCreate, nil, store.NewAuditSnapshot(created)); err != nil {
+			return nil, err
+		}
+		return &entities.TreeImportChange{New: created}, nil
+	}
+
+	entity := *prev
+	mergeImportedTree(&entity, tree)
+	if err := r.updateEntity(ctx, &entity); err != nil {
+		return nil, err
+	}
+
+	updated, err := r.GetByID(ctx, prev.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.store.WriteAuditLog(ctx, entities.AuditEntityTypeTree, prev.ID, entities.AuditActionUpdate, store.NewAuditSnapshot(prev), store.NewAuditSnapshot(updated)); err != nil {
+		return nil, err
+	}
+	return &entities.TreeImportChange{Prev: prev, New: updated}, nil
+}
+
+func mergeImportedTree(dst, src *entities.Tree) {
+	dst.PlantingYear = src.PlantingYear
+	dst.Species = src.Species
+	dst.Latitude = src.Latitude
+	dst.Longitude = src.Longitude
+	dst.Description = src.Description
+
+	if src.AdditionalInfo != nil {
+		dst.AdditionalInfo = src.AdditionalInfo
+	}
+	if src.TreeCluster != nil {
+		dst.TreeCluster = src.TreeCluster
+	}
+	if src.Sensor != nil {
+		dst.Sensor = src.Sensor
+	}
+}
@@ -0,0 +1,138 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeRepository_GetByProviderAndNumber(t *testing.T) {
+	t.Run("should return tree of provider", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.GetByProviderAndNumber(context.Background(), "test-provider", "1009")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(5), got.ID)
+	})
+
+	t.Run("should match trees without provider with empty provider", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.GetByProviderAndNumber(context.Background(), "", "1005")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), got.ID)
+		assert.NotNil(t, got.TreeCluster)
+	})
+
+	t.Run("should return error when number belongs to another provider", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.GetByProviderAndNumber(context.Background(), "", "1009")
+
+		// then
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
+
+func TestTreeRepository_Upsert(t *testing.T) {
+	t.Run("should create new and update existing trees", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+		trees := []*entities.Tree{
+			{Number: "1009", Provider: "test-provider", PlantingYear: 2015, Species: "Tilia cordata", Latitude: 54.23, Longitude: 9.12},
+			{Number: "2001", Provider: "test-provider", PlantingYear: 2024, Species: "Acer platanoides", Latitude: 54.81, Longitude: 9.48, TreeCluster: &entities.TreeCluster{ID: 1}},
+		}
+
+		// when
+		changes, err := r.Upsert(context.Background(), trees)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+
+		assert.NotNil(t, changes[0].Prev)
+		assert.Equal(t, int32(5), changes[0].New.ID)
+		assert.Equal(t, "Betula pendula", changes[0].Prev.Species)
+		assert.Equal(t, "Tilia cordata", changes[0].New.Species)
+		assert.Equal(t, int32(2015), changes[0].New.PlantingYear)
+		assert.Equal(t, changes[0].Prev.WateringStatus, changes[0].New.WateringStatus)
+		assert.Equal(t, changes[0].Prev.Sensor.ID, changes[0].New.Sensor.ID)
+
+		assert.Nil(t, changes[1].Prev)
+		assert.Equal(t, "2001", changes[1].New.Number)
+		assert.Equal(t, "test-provider", changes[1].New.Provider)
+		assert.Equal(t, int32(1), changes[1].New.TreeCluster.ID)
+		assert.Equal(t, entities.WateringStatusUnknown, changes[1].New.WateringStatus)
+	})
+
+	t.Run("should write audit log for created and updated trees", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+		ctx := context.WithValue(context.Background(), enums.ContextKeyActor, "import-user")
+		trees := []*entities.Tree{
+			{Number: "1009", Provider: "test-provider", PlantingYear: 2015, Species: "Tilia cordata", Latitude: 54.23, Longitude: 9.12},
+			{Number: "2001", Provider: "test-provider", PlantingYear: 2024, Species: "Acer platanoides", Latitude: 54.81, Longitude: 9.48},
+		}
+
+		// when
+		changes, err := r.Upsert(ctx, trees)
+		logs, logErr := suite.Store.GetAllAuditLogs(ctx, &sqlc.GetAllAuditLogsParams{Limit: 10, EntityType: string(entities.AuditEntityTypeTree)})
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, logErr)
+		assert.Len(t, logs, 2)
+		assert.Equal(t, changes[1].New.ID, logs[0].EntityID)
+		assert.Equal(t, sqlc.AuditAction(entities.AuditActionCreate), logs[0].Action)
+		assert.Equal(t, changes[0].New.ID, logs[1].EntityID)
+		assert.Equal(t, sqlc.AuditAction(entities.AuditActionUpdate), logs[1].Action)
+		assert.Equal(t, "import-user", logs[0].UserID)
+	})
+
+	t.Run("should not write any tree when one tree is invalid", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/tree")
+		r := NewTreeRepository(suite.Store, mappers)
+		trees := []*entities.Tree{
+			{Number: "2001", PlantingYear: 2024, Latitude: 54.81, Longitude: 9.48},
+			{Number: "2002", PlantingYear: 2024, Latitude: 95, Longitude: 9.48},
+		}
+
+		// when
+		changes, err := r.Upsert(context.Background(), trees)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrInvalidLatitude)
+		assert.Nil(t, changes)
+		_, err = r.GetByProviderAndNumber(context.Background(), "", "2001")
+		assert.Error(t, err)
+	})
+}
@@ -63,7 +63,7 @@ func (r *TreeClusterRepository) Create(ctx context.Context, createFn func(*entit
 			return err
 		}
 
-		return nil
+		return s.WriteAuditLog(ctx, entities.AuditEntityTypeTreeCluster, id, entities.AuditActionCreate, nil, store.NewAuditSnapshot(createdTc))
 	})
 
 	if err != nil {
@@ -132,6 +132,10 @@ func (r *TreeClusterRepository) createEntity(ctx context.Context, entity *entiti
 		}
 	}
 
+	if err := r.setPolygon(ctx, id, entity); err != nil {
+		return -1, err
+	}
+
 	return id, nil
 }
 
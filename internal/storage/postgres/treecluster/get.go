@@ -10,6 +10,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 	"github.com/twpayne/go-geos"
 )
 
@@ -41,10 +42,19 @@ func (r *TreeClusterRepository) GetAll(ctx context.Context, filter entities.Tree
 		page = 1
 	}
 
+	sp := store.NewSpatialParams(&filter.SpatialQuery)
 	rows, err := r.store.GetAllTreeClusters(ctx, &sqlc.GetAllTreeClustersParams{
 		WateringStatus: wateringStatuses,
 		Region:         filter.Regions,
 		Provider:       filter.Provider,
+		MinLon:         sp.MinLon,
+		MinLat:         sp.MinLat,
+		MaxLon:         sp.MaxLon,
+		MaxLat:         sp.MaxLat,
+		Radius:         sp.Radius,
+		NearLon:        sp.NearLon,
+		NearLat:        sp.NearLat,
+		Polygon:        sp.Polygon,
 		Limit:          limit,
 		Offset:         (page - 1) * limit,
 	})
@@ -76,10 +86,19 @@ func (r *TreeClusterRepository) GetCount(ctx context.Context, filter entities.Tr
 		wateringStatuses = append(wateringStatuses, string(ws))
 	}
 
+	sp := store.NewSpatialParams(&filter.SpatialQuery)
 	totalCount, err := r.store.GetTreeClustersCount(ctx, &sqlc.GetTreeClustersCountParams{
 		WateringStatus: wateringStatuses,
 		Region:         filter.Regions,
 		Provider:       filter.Provider,
+		MinLon:         sp.MinLon,
+		MinLat:         sp.MinLat,
+		MaxLon:         sp.MaxLon,
+		MaxLat:         sp.MaxLat,
+		Radius:         sp.Radius,
+		NearLon:        sp.NearLon,
+		NearLat:        sp.NearLat,
+		Polygon:        sp.Polygon,
 	})
 	if err != nil {
 		log.Debug("failed to get total tree cluster count in db", "error", err)
@@ -0,0 +1,90 @@
+package treecluster
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func (r *TreeClusterRepository) GetSuggestions(ctx context.Context, query entities.TreeClusterSuggestionQuery) ([]*entities.TreeClusterSuggestion, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetTreeClusterSuggestions(ctx, &sqlc.GetTreeClusterSuggestionsParams{
+		IncludeClustered: query.IncludeClustered,
+		Distance:         query.Distance,
+		MinTrees:         query.MinTrees,
+		MaxTrees:         query.MaxTrees,
+	})
+	if err != nil {
+		log.Debug("failed to get tree cluster suggestions in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.TreeCluster{})
+	}
+
+	suggestions := make([]*entities.TreeClusterSuggestion, len(rows))
+	for i, row := range rows {
+		suggestions[i] = &entities.TreeClusterSuggestion{
+			TreeIDs:       row.TreeIds,
+			Latitude:      row.Latitude,
+			Longitude:     row.Longitude,
+			SoilCondition: entities.TreeSoilCondition(row.SoilCondition),
+		}
+		if row.TreeClusterID != 0 {
+			suggestions[i].TreeClusterID = &row.TreeClusterID
+		}
+	}
+
+	return suggestions, nil
+}
+
+func (r *TreeClusterRepository) Upsert(ctx context.Context, clusters []*entities.TreeCluster, updateIDs []int32, updateFn func(*entities.TreeCluster, storage.TreeClusterRepository) (bool, error)) ([]*entities.TreeCluster, error) {
+	log := logger.GetLogger(ctx)
+	result := make([]*entities.TreeCluster, 0, len(clusters))
+
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewTreeClusterRepository(s, r.TreeClusterMappers)
+		for _, tc := range clusters {
+			if err := newRepo.validateTreeClusterEntity(tc); err != nil {
+				return err
+			}
+
+			if tc.ID == 0 {
+				id, err := newRepo.createEntity(ctx, tc)
+				if err != nil {
+					log.Error("failed to create tree cluster entity in db", "error", err, "name", tc.Name)
+					return err
+				}
+				tc.ID = id
+			}
+
+			// the location is only stored on update, it also relinks the trees for new tree clusters
+			if err := newRepo.updateEntity(ctx, tc); err != nil {
+				log.Error("failed to update tree cluster entity in db", "error", err, "cluster_id", tc.ID)
+				return err
+			}
+
+			upserted, err := newRepo.GetByID(ctx, tc.ID)
+			if err != nil {
+				return err
+			}
+			result = append(result, upserted)
+		}
+
+		for _, id := range updateIDs {
+			if err := newRepo.update(ctx, id, updateFn); err != nil {
+				log.Error("failed to update tree cluster after upsert", "error", err, "cluster_id", id)
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("tree cluster entities upserted successfully in db", "count", len(result))
+	return result, nil
+}
@@ -0,0 +1,211 @@
+package treecluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeClusterRepository_GetSuggestions(t *testing.T) {
+	t.Run("should return no suggestions when every tree has a tree cluster", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.GetSuggestions(context.Background(), entities.TreeClusterSuggestionQuery{
+			Distance: 100,
+			MinTrees: 2,
+			MaxTrees: 40,
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should group close trees when re-evaluating existing tree clusters", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+
+		// when
+		got, err := r.GetSuggestions(context.Background(), entities.TreeClusterSuggestionQuery{
+			Distance:         100,
+			MinTrees:         3,
+			MaxTrees:         40,
+			IncludeClustered: true,
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got)
+
+		var found *entities.TreeClusterSuggestion
+		for _, s := range got {
+			assert.GreaterOrEqual(t, len(s.TreeIDs), 3)
+			if assert.ObjectsAreEqual([]int32{4, 5, 6}, s.TreeIDs) {
+				found = s
+			}
+		}
+
+		if assert.NotNil(t, found) {
+			assert.Equal(t, int32(2), *found.TreeClusterID)
+			assert.Equal(t, entities.TreeSoilConditionSchluffig, found.SoilCondition)
+			assert.InDelta(t, 54.788, found.Latitude, 0.001)
+			assert.InDelta(t, 9.444, found.Longitude, 0.001)
+		}
+	})
+
+	t.Run("should split groups with more trees than allowed", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+
+		for _, maxTrees := range []int32{1, 2, 3} {
+			// when
+			got, err := r.GetSuggestions(context.Background(), entities.TreeClusterSuggestionQuery{
+				Distance:         100,
+				MinTrees:         1,
+				MaxTrees:         maxTrees,
+				IncludeClustered: true,
+			})
+
+			// then
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got)
+			for _, s := range got {
+				assert.LessOrEqual(t, len(s.TreeIDs), int(maxTrees))
+			}
+		}
+	})
+}
+
+func TestTreeClusterRepository_Upsert(t *testing.T) {
+	t.Run("should create and update tree clusters", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		ctx := context.Background()
+
+		existing, err := r.GetByID(ctx, 1)
+		assert.NoError(t, err)
+		existing.Name = "updated"
+		existing.Trees = []*entities.Tree{{ID: 1}}
+
+		created := &entities.TreeCluster{
+			Name:           "suggested",
+			WateringStatus: entities.WateringStatusUnknown,
+			SoilCondition:  entities.TreeSoilConditionSandig,
+			Latitude:       utils.P(54.8214),
+			Longitude:      utils.P(9.4884),
+			Trees:          []*entities.Tree{{ID: 2}, {ID: 3}},
+		}
+
+		// when
+		got, err := r.Upsert(ctx, []*entities.TreeCluster{existing, created}, nil, nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(1), got[0].ID)
+		assert.Equal(t, "updated", got[0].Name)
+		assert.Len(t, got[0].Trees, 1)
+		assert.NotZero(t, got[1].ID)
+		assert.Equal(t, "suggested", got[1].Name)
+		assert.Equal(t, entities.TreeSoilConditionSandig, got[1].SoilCondition)
+		assert.Len(t, got[1].Trees, 2)
+		assert.Equal(t, 54.8214, *got[1].Latitude)
+		assert.Equal(t, 9.4884, *got[1].Longitude)
+	})
+
+	t.Run("should roll back every tree cluster when one is invalid", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		ctx := context.WithValue(context.Background(), "page", int32(1))
+		ctx = context.WithValue(ctx, "limit", int32(-1))
+
+		_, totalBefore, err := r.GetAll(ctx, entities.TreeClusterQuery{})
+		assert.NoError(t, err)
+
+		clusters := []*entities.TreeCluster{
+			{Name: "suggested", Trees: []*entities.Tree{{ID: 2}, {ID: 3}}},
+			{Name: "", Trees: []*entities.Tree{{ID: 4}}},
+		}
+
+		// when
+		got, err := r.Upsert(ctx, clusters, nil, nil)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+
+		_, totalAfter, err := r.GetAll(ctx, entities.TreeClusterQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, totalBefore, totalAfter)
+
+		tc, err := r.GetByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.Len(t, tc.Trees, 3)
+	})
+
+	t.Run("should apply update function to tree clusters in the same transaction", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		ctx := context.Background()
+
+		created := &entities.TreeCluster{Name: "suggested", Trees: []*entities.Tree{{ID: 2}}}
+		updateFn := func(tc *entities.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.Name = "lost trees"
+			return true, nil
+		}
+
+		// when
+		got, err := r.Upsert(ctx, []*entities.TreeCluster{created}, []int32{2}, updateFn)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		tc, err := r.GetByID(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "lost trees", tc.Name)
+	})
+
+	t.Run("should roll back upserted tree clusters when update function fails", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		ctx := context.Background()
+
+		existing, err := r.GetByID(ctx, 1)
+		assert.NoError(t, err)
+		existing.Name = "updated"
+		updateFn := func(_ *entities.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			return false, errors.New("internal error")
+		}
+
+		// when
+		got, err := r.Upsert(ctx, []*entities.TreeCluster{existing}, []int32{2}, updateFn)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		tc, err := r.GetByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.NotEqual(t, "updated", tc.Name)
+	})
+}
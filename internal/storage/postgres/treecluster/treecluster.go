@@ -2,12 +2,19 @@ package treecluster
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 )
 
+// polygonBuffer is the distance in meters the computed polygon reaches beyond the outer trees
+const polygonBuffer = 5
+
 type TreeClusterRepository struct {
 	store *store.Store
 	TreeClusterMappers
@@ -51,18 +58,53 @@ func (r *TreeClusterRepository) Archive(ctx context.Context, id int32) error {
 		return err
 	}
 
+	prev := store.AuditSnapshot{"archived": json.RawMessage("false")}
+	curr := store.AuditSnapshot{"archived": json.RawMessage("true")}
+	if err := r.store.WriteAuditLog(ctx, entities.AuditEntityTypeTreeCluster, id, entities.AuditActionUpdate, prev, curr); err != nil {
+		log.Error("failed to write audit log for archived tree cluster", "error", err, "cluster_id", id)
+		return err
+	}
+
 	log.Debug("tree cluster entity archived successfully", "cluster_id", id)
 	return nil
 }
 
 func (r *TreeClusterRepository) Delete(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
-	_, err := r.store.DeleteTreeCluster(ctx, id)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewTreeClusterRepository(s, r.TreeClusterMappers)
+		if err := newRepo.checkVersion(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.DeleteTreeCluster(ctx, id); err != nil {
+			log.Error("failed to delete tree cluster entity in db", "error", err, "cluster_id", id)
+			return err
+		}
+
+		if err := s.WriteAuditLog(ctx, entities.AuditEntityTypeTreeCluster, id, entities.AuditActionDelete, nil, nil); err != nil {
+			log.Error("failed to write audit log for deleted tree cluster", "error", err, "cluster_id", id)
+			return err
+		}
+
+		return nil
+	})
+
 	if err != nil {
-		log.Error("failed to delete tree cluster entity in db", "error", err, "cluster_id", id)
 		return err
 	}
 
 	log.Debug("tree cluster entity deleted successfully", "cluster_id", id)
 	return nil
 }
+
+// checkVersion locks the tree cluster until the end of the transaction and compares its version
+// with the If-Match header of the request
+func (r *TreeClusterRepository) checkVersion(ctx context.Context, id int32) error {
+	version, err := r.store.LockTreeCluster(ctx, id)
+	if err != nil {
+		return r.store.MapError(err, sqlc.TreeCluster{})
+	}
+
+	return etag.Check(ctx, version)
+}
@@ -13,34 +13,52 @@ import (
 )
 
 func (r *TreeClusterRepository) Update(ctx context.Context, id int32, updateFn func(*entities.TreeCluster, storage.TreeClusterRepository) (bool, error)) error {
-	log := logger.GetLogger(ctx)
 	return r.store.WithTx(ctx, func(s *store.Store) error {
-		newRepo := NewTreeClusterRepository(s, r.TreeClusterMappers)
-		tc, err := newRepo.GetByID(ctx, id)
-		if err != nil {
-			return err
-		}
+		return NewTreeClusterRepository(s, r.TreeClusterMappers).update(ctx, id, updateFn)
+	})
+}
 
-		if updateFn == nil {
-			return errors.New("updateFn is nil")
-		}
+// update applies the update function to the tree cluster, it has to be called inside of a transaction
+func (r *TreeClusterRepository) update(ctx context.Context, id int32, updateFn func(*entities.TreeCluster, storage.TreeClusterRepository) (bool, error)) error {
+	log := logger.GetLogger(ctx)
+	if err := r.checkVersion(ctx, id); err != nil {
+		return err
+	}
 
-		updated, err := updateFn(tc, newRepo)
-		if err != nil {
-			return err
-		}
+	tc, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
 
-		if !updated {
-			return nil
-		}
+	if updateFn == nil {
+		return errors.New("updateFn is nil")
+	}
 
-		if err := newRepo.updateEntity(ctx, tc); err != nil {
-			log.Error("failed to update tree cluster entity in db", "error", err, "cluster_id", id)
-		}
+	prev := store.NewAuditSnapshot(tc)
+	updated, err := updateFn(tc, r)
+	if err != nil {
+		return err
+	}
 
-		log.Debug("tree cluster updated successfully in db", "cluster_id", id)
+	if !updated {
 		return nil
-	})
+	}
+
+	if err := r.updateEntity(ctx, tc); err != nil {
+		log.Error("failed to update tree cluster entity in db", "error", err, "cluster_id", id)
+	}
+
+	updatedTc, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.store.WriteAuditLog(ctx, entities.AuditEntityTypeTreeCluster, id, entities.AuditActionUpdate, prev, store.NewAuditSnapshot(updatedTc)); err != nil {
+		return err
+	}
+
+	log.Debug("tree cluster updated successfully in db", "cluster_id", id)
+	return nil
 }
 
 func (r *TreeClusterRepository) updateEntity(ctx context.Context, tc *entities.TreeCluster) error {
@@ -100,5 +118,24 @@ func (r *TreeClusterRepository) updateEntity(ctx context.Context, tc *entities.T
 		}
 	}
 
+	if err := r.setPolygon(ctx, tc.ID, tc); err != nil {
+		log.Error("failed to set polygon of tree cluster", "error", err, "cluster_id", tc.ID)
+		return err
+	}
+
 	return r.store.UpdateTreeCluster(ctx, &args)
 }
+
+// setPolygon stores the manual polygon of the tree cluster or recomputes it from the linked trees
+func (r *TreeClusterRepository) setPolygon(ctx context.Context, id int32, tc *entities.TreeCluster) error {
+	var polygon *string
+	if tc.PolygonManual && tc.Polygon != "" {
+		polygon = &tc.Polygon
+	}
+
+	return r.store.SetTreeClusterPolygon(ctx, &sqlc.SetTreeClusterPolygonParams{
+		ID:      id,
+		Polygon: polygon,
+		Buffer:  polygonBuffer,
+	})
+}
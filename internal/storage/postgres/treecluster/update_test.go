@@ -10,6 +10,7 @@ import (
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geos"
 )
 
 func TestTreeClusterRepository_Update(t *testing.T) {
@@ -272,6 +273,96 @@ func TestTreeClusterRepository_Update(t *testing.T) {
 		assert.Nil(t, got.Longitude)
 	})
 
+	t.Run("should compute polygon from linked trees", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		updateFn := func(tc *entities.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.Name = "updated"
+			return true, nil
+		}
+
+		// when
+		updateErr := r.Update(context.Background(), 1, updateFn)
+		got, getErr := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, updateErr)
+		assert.NoError(t, getErr)
+		assert.NotEmpty(t, got.Polygon)
+		assert.False(t, got.PolygonManual)
+		assert.NotNil(t, got.Area)
+		assert.NotNil(t, got.Perimeter)
+		assert.Greater(t, *got.Area, 0.0)
+		assert.Greater(t, *got.Perimeter, 0.0)
+		assert.Greater(t, got.TreeDensity(), 0.0)
+
+		// every tree lies inside the buffered hull
+		polygon, err := geos.NewGeomFromWKT(got.Polygon)
+		assert.NoError(t, err)
+		for _, tree := range got.Trees {
+			assert.True(t, polygon.Contains(geos.NewPointFromXY(tree.Longitude, tree.Latitude)))
+		}
+	})
+
+	t.Run("should keep manual polygon when trees change", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		polygon := "POLYGON ((9.48 54.82, 9.49 54.82, 9.49 54.83, 9.48 54.83, 9.48 54.82))"
+
+		// when
+		updateErr := r.Update(context.Background(), 1, func(tc *entities.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.Polygon = polygon
+			tc.PolygonManual = true
+			return true, nil
+		})
+		assert.NoError(t, updateErr)
+
+		updateErr = r.Update(context.Background(), 1, func(tc *entities.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.Trees = tc.Trees[:1]
+			return true, nil
+		})
+		got, getErr := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, updateErr)
+		assert.NoError(t, getErr)
+		assert.True(t, got.PolygonManual)
+		assert.Len(t, got.Trees, 1)
+
+		expected, err := geos.NewGeomFromWKT(polygon)
+		assert.NoError(t, err)
+		actual, err := geos.NewGeomFromWKT(got.Polygon)
+		assert.NoError(t, err)
+		assert.True(t, expected.Equals(actual))
+		assert.InDelta(t, 711_000, *got.Area, 10_000)
+	})
+
+	t.Run("should remove polygon when tree cluster has no trees", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewTreeClusterRepository(suite.Store, mappers)
+		updateFn := func(tc *entities.TreeCluster, _ storage.TreeClusterRepository) (bool, error) {
+			tc.Trees = nil
+			return true, nil
+		}
+
+		// when
+		updateErr := r.Update(context.Background(), 1, updateFn)
+		got, getErr := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, updateErr)
+		assert.NoError(t, getErr)
+		assert.Empty(t, got.Polygon)
+		assert.Nil(t, got.Area)
+		assert.Equal(t, 0.0, got.TreeDensity())
+	})
+
 	t.Run("should return error when updateFn is nil", func(t *testing.T) {
 		// given
 		r := NewTreeClusterRepository(suite.Store, mappers)
@@ -0,0 +1,86 @@
+package treeimport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func defaultTreeImportJob() *entities.TreeImportJob {
+	return &entities.TreeImportJob{
+		FileName:  "",
+		Format:    entities.TreeImportFormatCSV,
+		Status:    entities.TreeImportStatusPending,
+		Mapping:   entities.TreeImportMapping{},
+		RowErrors: []entities.TreeImportRowError{},
+	}
+}
+
+func (r *TreeImportRepository) Create(ctx context.Context, file []byte, createFn func(*entities.TreeImportJob, storage.TreeImportRepository) (bool, error)) (*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdJob *entities.TreeImportJob
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewTreeImportRepository(s, r.TreeImportRepositoryMappers)
+		entity := defaultTreeImportJob()
+		created, err := createFn(entity, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if entity.FileName == "" || !entity.Format.IsValid() {
+			return errors.New("file name and a valid format are required")
+		}
+
+		mapping, err := mapper.MapTreeImportMappingToByte(entity.Mapping)
+		if err != nil {
+			return err
+		}
+
+		id, err := s.CreateTreeImportJob(ctx, &sqlc.CreateTreeImportJobParams{
+			FileName:  entity.FileName,
+			Format:    string(entity.Format),
+			Status:    sqlc.TreeImportStatus(entity.Status),
+			Mapping:   mapping,
+			Provider:  entity.Provider,
+			CreatedBy: entity.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.CreateTreeImportFile(ctx, &sqlc.CreateTreeImportFileParams{
+			JobID: id,
+			Data:  file,
+		}); err != nil {
+			return err
+		}
+
+		createdJob, err = newRepo.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create tree import job in db", "error", err)
+		return nil, err
+	}
+
+	if createdJob != nil {
+		log.Debug("tree import job created successfully in db", "tree_import_id", createdJob.ID)
+	}
+
+	return createdJob, nil
+}
@@ -0,0 +1,54 @@
+package treeimport
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *TreeImportRepository) GetAll(ctx context.Context) ([]*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllTreeImportJobs(ctx)
+	if err != nil {
+		log.Debug("failed to get tree import jobs in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.TreeImportJob{})
+	}
+
+	jobs, err := r.mapper.FromSqlList(rows)
+	if err != nil {
+		log.Debug("failed to convert entity", "error", err)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *TreeImportRepository) GetByID(ctx context.Context, id int32) (*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetTreeImportJobByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get tree import job by id in db", "error", err, "tree_import_id", id)
+		return nil, r.store.MapError(err, sqlc.TreeImportJob{})
+	}
+
+	job, err := r.mapper.FromSql(row)
+	if err != nil {
+		log.Debug("failed to convert entity", "error", err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *TreeImportRepository) GetFile(ctx context.Context, id int32) ([]byte, error) {
+	log := logger.GetLogger(ctx)
+	data, err := r.store.GetTreeImportFile(ctx, id)
+	if err != nil {
+		log.Debug("failed to get tree import file in db", "error", err, "tree_import_id", id)
+		return nil, r.store.MapError(err, "tree_import_files")
+	}
+
+	return data, nil
+}
@@ -0,0 +1,68 @@
+package treeimport
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+var _ storage.TreeImportRepository = (*TreeImportRepository)(nil)
+
+type TreeImportRepository struct {
+	store *store.Store
+	TreeImportRepositoryMappers
+}
+
+type TreeImportRepositoryMappers struct {
+	mapper mapper.InternalTreeImportRepoMapper
+}
+
+func NewTreeImportRepositoryMappers(tiMapper mapper.InternalTreeImportRepoMapper) TreeImportRepositoryMappers {
+	return TreeImportRepositoryMappers{
+		mapper: tiMapper,
+	}
+}
+
+func NewTreeImportRepository(s *store.Store, mappers TreeImportRepositoryMappers) *TreeImportRepository {
+	return &TreeImportRepository{
+		store:                       s,
+		TreeImportRepositoryMappers: mappers,
+	}
+}
+
+func (r *TreeImportRepository) Claim(ctx context.Context, from, to entities.TreeImportStatus, now time.Time, lease time.Duration) (*entities.TreeImportJob, error) {
+	log := logger.GetLogger(ctx)
+	now = now.UTC()
+	expiredBefore := now.Add(-lease)
+	id, err := r.store.ClaimTreeImportJob(ctx, &sqlc.ClaimTreeImportJobParams{
+		FromStatus:         sqlc.TreeImportStatus(from),
+		ToStatus:           sqlc.TreeImportStatus(to),
+		Now:                utils.TimeToPgTimestamp(&now),
+		ClaimExpiredBefore: utils.TimeToPgTimestamp(&expiredBefore),
+	})
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.TreeImportJob{})
+	}
+
+	log.Debug("tree import job claimed", "tree_import_id", id, "from_status", from, "to_status", to)
+	return r.GetByID(ctx, id)
+}
+
+func (r *TreeImportRepository) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.DeleteTreeImportJob(ctx, id)
+	if err != nil {
+		log.Error("failed to delete tree import job in db", "error", err, "tree_import_id", id)
+		return r.store.MapError(err, sqlc.TreeImportJob{})
+	}
+
+	log.Debug("tree import job deleted successfully in db", "tree_import_id", id)
+	return nil
+}
@@ -0,0 +1,270 @@
+package treeimport
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultTreeImportMappers() TreeImportRepositoryMappers {
+	return NewTreeImportRepositoryMappers(&generated.InternalTreeImportRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestTreeImportRepository_Get(t *testing.T) {
+	t.Run("should return all import jobs, newest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treeimport")
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(2), got[0].ID)
+		assert.Equal(t, int32(1), got[1].ID)
+	})
+
+	t.Run("should return import job by id", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treeimport")
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusInvalid, got.Status)
+		assert.Equal(t, entities.TreeImportFormatCSV, got.Format)
+		assert.Equal(t, "Baumnummer", got.Mapping[entities.TreeImportFieldNumber])
+		assert.Equal(t, []entities.TreeImportRowError{
+			{Row: 2, Field: entities.TreeImportFieldPlantingYear, Message: `"abc" is not a valid year`},
+		}, got.RowErrors)
+	})
+
+	t.Run("should return file of import job", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treeimport")
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		got, err := r.GetFile(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "Baumnummer;planting_year\nT-1;2010\nT-2;abc\n", string(got))
+	})
+
+	t.Run("should return error when import job does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		assert.Nil(t, got)
+	})
+}
+
+func TestTreeImportRepository_Create(t *testing.T) {
+	t.Run("should create import job with file", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+		file := []byte("number;planting_year\nT-1;2010\n")
+
+		// when
+		got, err := r.Create(context.Background(), file, func(job *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+			job.FileName = "trees.csv"
+			job.Format = entities.TreeImportFormatCSV
+			job.Mapping = entities.TreeImportMapping{entities.TreeImportFieldNumber: "number"}
+			job.Provider = "city"
+			job.CreatedBy = "6a1078e8-80fd-458f-b74e-e388fe2dd6ab"
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusPending, got.Status)
+		assert.Equal(t, "city", got.Provider)
+		assert.Empty(t, got.RowErrors)
+
+		stored, err := r.GetFile(context.Background(), got.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, file, stored)
+	})
+
+	t.Run("should not create import job when function returns false", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		got, err := r.Create(context.Background(), []byte("data"), func(_ *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+			return false, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return error when format is invalid", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		got, err := r.Create(context.Background(), []byte("data"), func(job *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+			job.FileName = "trees.xlsx"
+			job.Format = "xlsx"
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestTreeImportRepository_Update(t *testing.T) {
+	t.Run("should update status, counts and row errors", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treeimport")
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+		msg := "failed to read geojson file"
+
+		// when
+		err := r.Update(context.Background(), 2, func(job *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+			job.Status = entities.TreeImportStatusInvalid
+			job.TotalRows = 3
+			job.ValidRows = 2
+			job.RowErrors = []entities.TreeImportRowError{{Row: 3, Field: entities.TreeImportFieldLatitude, Message: "geometry must be a point"}}
+			job.Error = &msg
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetByID(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TreeImportStatusInvalid, got.Status)
+		assert.Equal(t, int32(3), got.TotalRows)
+		assert.Equal(t, int32(2), got.ValidRows)
+		assert.Len(t, got.RowErrors, 1)
+		assert.Equal(t, msg, *got.Error)
+	})
+
+	t.Run("should return error when import job does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		err := r.Update(context.Background(), 99, func(_ *entities.TreeImportJob, _ storage.TreeImportRepository) (bool, error) {
+			return true, nil
+		})
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+}
+
+func TestTreeImportRepository_Claim(t *testing.T) {
+	t.Run("should claim pending import job once", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treeimport")
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		now := time.Now()
+
+		// when
+		got, err := r.Claim(context.Background(), entities.TreeImportStatusPending, entities.TreeImportStatusValidating, now, time.Hour)
+		next, nextErr := r.Claim(context.Background(), entities.TreeImportStatusPending, entities.TreeImportStatusValidating, now, time.Hour)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), got.ID)
+		assert.Equal(t, entities.TreeImportStatusValidating, got.Status)
+		assert.NotNil(t, got.ClaimedAt)
+		assert.ErrorAs(t, nextErr, new(storage.ErrEntityNotFound))
+		assert.Nil(t, next)
+	})
+
+	t.Run("should claim job again when the lease expired", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treeimport")
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+		claimed, err := r.Claim(context.Background(), entities.TreeImportStatusPending, entities.TreeImportStatusValidating, time.Now().Add(-2*time.Hour), time.Hour)
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.Claim(context.Background(), entities.TreeImportStatusPending, entities.TreeImportStatusValidating, time.Now(), time.Hour)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, claimed.ID, got.ID)
+		assert.Equal(t, entities.TreeImportStatusValidating, got.Status)
+	})
+}
+
+func TestTreeImportRepository_Delete(t *testing.T) {
+	t.Run("should delete import job and its file", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treeimport")
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		err := r.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		_, err = r.GetByID(context.Background(), 1)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+		_, err = r.GetFile(context.Background(), 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when import job does not exist", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewTreeImportRepository(suite.Store, defaultTreeImportMappers())
+
+		// when
+		err := r.Delete(context.Background(), 99)
+
+		// then
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+}
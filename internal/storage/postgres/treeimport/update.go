@@ -0,0 +1,60 @@
+package treeimport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+func (r *TreeImportRepository) Update(ctx context.Context, id int32, updateFn func(*entities.TreeImportJob, storage.TreeImportRepository) (bool, error)) error {
+	log := logger.GetLogger(ctx)
+	return r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewTreeImportRepository(s, r.TreeImportRepositoryMappers)
+		job, err := newRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if updateFn == nil {
+			return errors.New("updateFn is nil")
+		}
+
+		updated, err := updateFn(job, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return nil
+		}
+
+		rowErrors, err := mapper.MapTreeImportRowErrorsToByte(job.RowErrors)
+		if err != nil {
+			return err
+		}
+
+		err = s.UpdateTreeImportJob(ctx, &sqlc.UpdateTreeImportJobParams{
+			ID:          job.ID,
+			Status:      sqlc.TreeImportStatus(job.Status),
+			TotalRows:   job.TotalRows,
+			ValidRows:   job.ValidRows,
+			CreatedRows: job.CreatedRows,
+			UpdatedRows: job.UpdatedRows,
+			RowErrors:   rowErrors,
+			Error:       job.Error,
+		})
+		if err != nil {
+			log.Error("failed to update tree import job in db", "error", err, "tree_import_id", id)
+			return err
+		}
+
+		log.Debug("tree import job updated successfully in db", "tree_import_id", id)
+		return nil
+	})
+}
@@ -62,7 +62,7 @@ func (r *VehicleRepository) Create(ctx context.Context, createFn func(*entities.
 			return err
 		}
 
-		return nil
+		return s.WriteAuditLog(ctx, entities.AuditEntityTypeVehicle, *id, entities.AuditActionCreate, nil, store.NewAuditSnapshot(createdVh))
 	})
 
 	if err != nil {
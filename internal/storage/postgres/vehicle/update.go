@@ -16,6 +16,10 @@ func (r *VehicleRepository) Update(ctx context.Context, id int32, updateFn func(
 	log := logger.GetLogger(ctx)
 	return r.store.WithTx(ctx, func(s *store.Store) error {
 		newRepo := NewVehicleRepository(s, r.VehicleRepositoryMappers)
+		if err := newRepo.checkVersion(ctx, id); err != nil {
+			return err
+		}
+
 		vh, err := newRepo.GetByID(ctx, id)
 		if err != nil {
 			return err
@@ -25,6 +29,7 @@ func (r *VehicleRepository) Update(ctx context.Context, id int32, updateFn func(
 			return errors.New("updateFn is nil")
 		}
 
+		prev := store.NewAuditSnapshot(vh)
 		updated, err := updateFn(vh, newRepo)
 		if err != nil {
 			return err
@@ -43,6 +48,10 @@ func (r *VehicleRepository) Update(ctx context.Context, id int32, updateFn func(
 			return err
 		}
 
+		if err := s.WriteAuditLog(ctx, entities.AuditEntityTypeVehicle, id, entities.AuditActionUpdate, prev, store.NewAuditSnapshot(vh)); err != nil {
+			return err
+		}
+
 		log.Debug("vehicle entity updated successfully in db", "vehicle_id", id)
 		return nil
 	})
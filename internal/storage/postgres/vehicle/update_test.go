@@ -6,6 +6,10 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,6 +72,74 @@ func TestVehicleRepository_UpdateSuite(t *testing.T) {
 		assert.Equal(t, input.Weight, got.Weight)
 	})
 
+	t.Run("should write audit log with changed fields and actor", func(t *testing.T) {
+		// given
+		r := NewVehicleRepository(defaultFields.store, defaultFields.VehicleMappers)
+		ctx := context.WithValue(context.Background(), enums.ContextKeyActor, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab")
+
+		updateFn := func(vh *entities.Vehicle, _ storage.VehicleRepository) (bool, error) {
+			vh.Description = "Audited description"
+			return true, nil
+		}
+
+		// when
+		err := r.Update(ctx, 2, updateFn)
+		logs, logErr := defaultFields.store.GetAllAuditLogs(context.Background(), &sqlc.GetAllAuditLogsParams{
+			EntityType: string(entities.AuditEntityTypeVehicle),
+			EntityID:   utils.P(int32(2)),
+			Limit:      10,
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, logErr)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, sqlc.AuditActionUpdate, logs[0].Action)
+		assert.Equal(t, "6a1078e8-80fd-458f-b74e-e388fe2dd6ab", logs[0].UserID)
+		assert.Contains(t, string(logs[0].Changes), `"description"`)
+		assert.Contains(t, string(logs[0].Changes), `"Audited description"`)
+		assert.NotContains(t, string(logs[0].Changes), `"number_plate"`)
+	})
+
+	t.Run("should increase version on update", func(t *testing.T) {
+		// given
+		r := NewVehicleRepository(defaultFields.store, defaultFields.VehicleMappers)
+		prev, _ := r.GetByID(context.Background(), 2)
+
+		updateFn := func(vh *entities.Vehicle, _ storage.VehicleRepository) (bool, error) {
+			vh.Description = "Versioned description"
+			return true, nil
+		}
+
+		// when
+		err := r.Update(etag.WithIfMatch(context.Background(), prev.Version), 2, updateFn)
+		got, _ := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, prev.Version+1, got.Version)
+	})
+
+	t.Run("should return error when version does not match if-match", func(t *testing.T) {
+		// given
+		r := NewVehicleRepository(defaultFields.store, defaultFields.VehicleMappers)
+		prev, _ := r.GetByID(context.Background(), 2)
+
+		updateFn := func(vh *entities.Vehicle, _ storage.VehicleRepository) (bool, error) {
+			vh.Description = "Stale description"
+			return true, nil
+		}
+
+		// when
+		err := r.Update(etag.WithIfMatch(context.Background(), prev.Version-1), 2, updateFn)
+		got, _ := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrVersionMismatch)
+		assert.Equal(t, prev.Version, got.Version)
+		assert.Equal(t, prev.Description, got.Description)
+	})
+
 	t.Run("should return error when update vehicle with duplicate plate", func(t *testing.T) {
 		// given
 		r := NewVehicleRepository(defaultFields.store, defaultFields.VehicleMappers)
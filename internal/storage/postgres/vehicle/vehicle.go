@@ -4,9 +4,11 @@ import (
 	"context"
 	"time"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -39,9 +41,26 @@ func NewVehicleRepository(s *store.Store, mappers VehicleRepositoryMappers) *Veh
 
 func (r *VehicleRepository) Delete(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
-	_, err := r.store.DeleteVehicle(ctx, id)
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewVehicleRepository(s, r.VehicleRepositoryMappers)
+		if err := newRepo.checkVersion(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.DeleteVehicle(ctx, id); err != nil {
+			log.Error("failed to delete vehicle entity in db", "error", err, "vehicle_id", id)
+			return err
+		}
+
+		if err := s.WriteAuditLog(ctx, entities.AuditEntityTypeVehicle, id, entities.AuditActionDelete, nil, nil); err != nil {
+			log.Error("failed to write audit log for deleted vehicle", "error", err, "vehicle_id", id)
+			return err
+		}
+
+		return nil
+	})
+
 	if err != nil {
-		log.Error("failed to delete vehicle entity in db", "error", err, "vehicle_id", id)
 		return err
 	}
 
@@ -49,17 +68,35 @@ func (r *VehicleRepository) Delete(ctx context.Context, id int32) error {
 	return nil
 }
 
+// checkVersion locks the vehicle until the end of the transaction and compares its version
+// with the If-Match header of the request
+func (r *VehicleRepository) checkVersion(ctx context.Context, id int32) error {
+	version, err := r.store.LockVehicle(ctx, id)
+	if err != nil {
+		return r.store.MapError(err, sqlc.Vehicle{})
+	}
+
+	return etag.Check(ctx, version)
+}
+
 func (r *VehicleRepository) Archive(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
+	archivedAt := time.Now()
 	_, err := r.store.ArchiveVehicle(ctx, &sqlc.ArchiveVehicleParams{
 		ID:         id,
-		ArchivedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		ArchivedAt: pgtype.Timestamp{Time: archivedAt, Valid: true},
 	})
 	if err != nil {
 		log.Error("failed to archive vehicle entity in db", "error", err, "vehicle_id", id)
 		return err
 	}
 
+	curr := store.NewAuditSnapshot(struct{ ArchivedAt time.Time }{archivedAt})
+	if err := r.store.WriteAuditLog(ctx, entities.AuditEntityTypeVehicle, id, entities.AuditActionUpdate, nil, curr); err != nil {
+		log.Error("failed to write audit log for archived vehicle", "error", err, "vehicle_id", id)
+		return err
+	}
+
 	log.Debug("vehicle entity archived successfully in db", "vehicle_id", id)
 	return nil
 }
@@ -6,9 +6,11 @@ import (
 	"os"
 	"testing"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
 	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,6 +68,21 @@ func TestVehicleRepository_Delete(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("should not delete vehicle when version does not match if-match", func(t *testing.T) {
+		// given
+		r := NewVehicleRepository(suite.Store, defaultVehicleMappers())
+		prev, _ := r.GetByID(context.Background(), 2)
+
+		// when
+		err := r.Delete(etag.WithIfMatch(context.Background(), prev.Version+1), 2)
+		got, getErr := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.ErrorIs(t, err, storage.ErrVersionMismatch)
+		assert.NoError(t, getErr)
+		assert.NotNil(t, got)
+	})
+
 	t.Run("should return error when tree cluster with negative id", func(t *testing.T) {
 		// given
 		r := NewVehicleRepository(suite.Store, defaultVehicleMappers())
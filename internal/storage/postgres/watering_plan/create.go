@@ -68,7 +68,7 @@ func (w *WateringPlanRepository) Create(ctx context.Context, createFn func(*enti
 			return err
 		}
 
-		return nil
+		return s.WriteAuditLog(ctx, entities.AuditEntityTypeWateringPlan, *id, entities.AuditActionCreate, nil, store.NewAuditSnapshot(createdWp))
 	})
 
 	if err != nil {
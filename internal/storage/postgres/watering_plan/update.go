@@ -17,6 +17,10 @@ func (w *WateringPlanRepository) Update(ctx context.Context, id int32, updateFn
 	log := logger.GetLogger(ctx)
 	return w.store.WithTx(ctx, func(s *store.Store) error {
 		newRepo := NewWateringPlanRepository(s, w.WateringPlanMappers)
+		if err := newRepo.checkVersion(ctx, id); err != nil {
+			return err
+		}
+
 		entity, err := newRepo.GetByID(ctx, id)
 		if err != nil {
 			return err
@@ -26,6 +30,7 @@ func (w *WateringPlanRepository) Update(ctx context.Context, id int32, updateFn
 			return errors.New("updateFn is nil")
 		}
 
+		prev := store.NewAuditSnapshot(entity)
 		updated, err := updateFn(entity, newRepo)
 		if err != nil {
 			return err
@@ -44,6 +49,10 @@ func (w *WateringPlanRepository) Update(ctx context.Context, id int32, updateFn
 			return err
 		}
 
+		if err := s.WriteAuditLog(ctx, entities.AuditEntityTypeWateringPlan, id, entities.AuditActionUpdate, prev, store.NewAuditSnapshot(entity)); err != nil {
+			return err
+		}
+
 		log.Debug("watering plan entity updated successfully", "watering_plan_id", id)
 		return nil
 	})
@@ -3,10 +3,13 @@ package wateringplan
 import (
 	"context"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/etag"
 )
 
 var _ storage.WateringPlanRepository = (*WateringPlanRepository)(nil)
@@ -43,12 +46,40 @@ func NewWateringPlanRepository(s *store.Store, mappers WateringPlanMappers) *Wat
 
 func (w *WateringPlanRepository) Delete(ctx context.Context, id int32) error {
 	log := logger.GetLogger(ctx)
-	_, err := w.store.DeleteWateringPlan(ctx, id)
+	err := w.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewWateringPlanRepository(s, w.WateringPlanMappers)
+		if err := newRepo.checkVersion(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.DeleteWateringPlan(ctx, id); err != nil {
+			log.Error("failed to delete watering plan entity in db", "error", err, "watering_plan_id", id)
+			return err
+		}
+
+		if err := s.WriteAuditLog(ctx, entities.AuditEntityTypeWateringPlan, id, entities.AuditActionDelete, nil, nil); err != nil {
+			log.Error("failed to write audit log for deleted watering plan", "error", err, "watering_plan_id", id)
+			return err
+		}
+
+		return nil
+	})
+
 	if err != nil {
-		log.Error("failed to delete watering plan entity in db", "error", err, "watering_plan_id", id)
 		return err
 	}
 
 	log.Debug("watering plan entity deleted successfully", "watering_plan_id", id)
 	return nil
 }
+
+// checkVersion locks the watering plan until the end of the transaction and compares its version
+// with the If-Match header of the request
+func (w *WateringPlanRepository) checkVersion(ctx context.Context, id int32) error {
+	version, err := w.store.LockWateringPlan(ctx, id)
+	if err != nil {
+		return w.store.MapError(err, sqlc.WateringPlan{})
+	}
+
+	return etag.Check(ctx, version)
+}
@@ -0,0 +1,158 @@
+package wateringplantemplate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func defaultWateringPlanTemplate() *entities.WateringPlanTemplate {
+	return &entities.WateringPlanTemplate{
+		TreeClusterIDs: make([]int32, 0),
+		UserIDs:        make([]uuid.UUID, 0),
+		Enabled:        true,
+	}
+}
+
+func (r *WateringPlanTemplateRepository) Create(ctx context.Context, createFn func(*entities.WateringPlanTemplate, storage.WateringPlanTemplateRepository) (bool, error)) (*entities.WateringPlanTemplate, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdTemplate *entities.WateringPlanTemplate
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewWateringPlanTemplateRepository(s, r.WateringPlanTemplateRepositoryMappers)
+		entity := defaultWateringPlanTemplate()
+		created, err := createFn(entity, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if err := validateWateringPlanTemplate(entity); err != nil {
+			return err
+		}
+
+		startDate, err := utils.TimeToPgDate(entity.StartDate)
+		if err != nil {
+			return err
+		}
+
+		id, err := s.CreateWateringPlanTemplate(ctx, &sqlc.CreateWateringPlanTemplateParams{
+			Name:          entity.Name,
+			Description:   entity.Description,
+			Rrule:         entity.RRule,
+			StartDate:     startDate,
+			SeasonStart:   entity.SeasonStart,
+			SeasonEnd:     entity.SeasonEnd,
+			TransporterID: entity.TransporterID,
+			TrailerID:     entity.TrailerID,
+			UserIds:       mapUserIDs(entity.UserIDs),
+			Enabled:       entity.Enabled,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := newRepo.setTreeClusters(ctx, id, entity.TreeClusterIDs); err != nil {
+			return err
+		}
+
+		createdTemplate, err = newRepo.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create watering plan template entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdTemplate != nil {
+		log.Debug("watering plan template entity created successfully in db", "template_id", createdTemplate.ID)
+	}
+
+	return createdTemplate, nil
+}
+
+func (r *WateringPlanTemplateRepository) CreateOccurrence(ctx context.Context, occurrence *entities.WateringPlanTemplateOccurrence, createFn func(context.Context, *entities.WateringPlanTemplateOccurrence) error) error {
+	log := logger.GetLogger(ctx)
+	date, err := utils.TimeToPgDate(occurrence.Date)
+	if err != nil {
+		return err
+	}
+
+	err = r.store.WithTxContext(ctx, func(ctx context.Context, s *store.Store) error {
+		if createFn != nil {
+			if err := createFn(ctx, occurrence); err != nil {
+				return err
+			}
+		}
+
+		return s.CreateWateringPlanTemplateOccurrence(ctx, &sqlc.CreateWateringPlanTemplateOccurrenceParams{
+			TemplateID:     occurrence.TemplateID,
+			Date:           date,
+			WateringPlanID: occurrence.WateringPlanID,
+			Holiday:        occurrence.Holiday,
+		})
+	})
+	if err != nil {
+		log.Error("failed to create watering plan template occurrence in db", "error", err, "template_id", occurrence.TemplateID, "date", occurrence.Date)
+		return r.store.MapError(err, sqlc.WateringPlanTemplateOccurrence{})
+	}
+
+	log.Debug("watering plan template occurrence created successfully in db", "template_id", occurrence.TemplateID, "date", occurrence.Date)
+	return nil
+}
+
+// setTreeClusters replaces the tree clusters of the template
+func (r *WateringPlanTemplateRepository) setTreeClusters(ctx context.Context, id int32, treeClusterIDs []int32) error {
+	if err := r.store.DeleteWateringPlanTemplateTreeClusters(ctx, id); err != nil {
+		return err
+	}
+
+	err := r.store.SetWateringPlanTemplateTreeClusters(ctx, &sqlc.SetWateringPlanTemplateTreeClustersParams{
+		TemplateID:     id,
+		TreeClusterIds: treeClusterIDs,
+	})
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to link tree clusters to watering plan template", "error", err, "template_id", id, "cluster_ids", treeClusterIDs)
+		return err
+	}
+	return nil
+}
+
+func validateWateringPlanTemplate(entity *entities.WateringPlanTemplate) error {
+	if entity.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if entity.RRule == "" {
+		return errors.New("rrule is required")
+	}
+
+	if len(entity.TreeClusterIDs) == 0 {
+		return errors.New("tree clusters are required")
+	}
+
+	if len(entity.UserIDs) == 0 {
+		return errors.New("users are required")
+	}
+
+	return nil
+}
+
+func mapUserIDs(userIDs []uuid.UUID) []pgtype.UUID {
+	return utils.Map(userIDs, utils.UUIDToPGUUID)
+}
@@ -0,0 +1,66 @@
+package wateringplantemplate
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+func (r *WateringPlanTemplateRepository) GetAll(ctx context.Context) ([]*entities.WateringPlanTemplate, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllWateringPlanTemplates(ctx)
+	if err != nil {
+		log.Debug("failed to get watering plan templates in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.WateringPlanTemplate{})
+	}
+
+	templates := r.mapper.FromSqlList(rows)
+	for _, t := range templates {
+		if err := r.mapFields(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+func (r *WateringPlanTemplateRepository) GetByID(ctx context.Context, id int32) (*entities.WateringPlanTemplate, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetWateringPlanTemplateByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get watering plan template by id in db", "error", err, "template_id", id)
+		return nil, r.store.MapError(err, sqlc.WateringPlanTemplate{})
+	}
+
+	template := r.mapper.FromSql(row)
+	if err := r.mapFields(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (r *WateringPlanTemplateRepository) GetOccurrences(ctx context.Context, templateID int32) ([]*entities.WateringPlanTemplateOccurrence, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetWateringPlanTemplateOccurrences(ctx, templateID)
+	if err != nil {
+		log.Debug("failed to get watering plan template occurrences in db", "error", err, "template_id", templateID)
+		return nil, r.store.MapError(err, sqlc.WateringPlanTemplateOccurrence{})
+	}
+
+	return r.mapper.FromSqlOccurrenceList(rows), nil
+}
+
+func (r *WateringPlanTemplateRepository) mapFields(ctx context.Context, t *entities.WateringPlanTemplate) error {
+	ids, err := r.store.GetWateringPlanTemplateTreeClusterIDs(ctx, t.ID)
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to get tree clusters of watering plan template in db", "error", err, "template_id", t.ID)
+		return r.store.MapError(err, sqlc.WateringPlanTemplate{})
+	}
+
+	t.TreeClusterIDs = make([]int32, 0, len(ids))
+	t.TreeClusterIDs = append(t.TreeClusterIDs, ids...)
+	return nil
+}
@@ -0,0 +1,47 @@
+package wateringplantemplate
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+var _ storage.WateringPlanTemplateRepository = (*WateringPlanTemplateRepository)(nil)
+
+type WateringPlanTemplateRepository struct {
+	store *store.Store
+	WateringPlanTemplateRepositoryMappers
+}
+
+type WateringPlanTemplateRepositoryMappers struct {
+	mapper mapper.InternalWateringPlanTemplateRepoMapper
+}
+
+func NewWateringPlanTemplateRepositoryMappers(tMapper mapper.InternalWateringPlanTemplateRepoMapper) WateringPlanTemplateRepositoryMappers {
+	return WateringPlanTemplateRepositoryMappers{
+		mapper: tMapper,
+	}
+}
+
+func NewWateringPlanTemplateRepository(s *store.Store, mappers WateringPlanTemplateRepositoryMappers) *WateringPlanTemplateRepository {
+	return &WateringPlanTemplateRepository{
+		store:                                 s,
+		WateringPlanTemplateRepositoryMappers: mappers,
+	}
+}
+
+func (r *WateringPlanTemplateRepository) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.DeleteWateringPlanTemplate(ctx, id)
+	if err != nil {
+		log.Error("failed to delete watering plan template entity in db", "error", err, "template_id", id)
+		return r.store.MapError(err, sqlc.WateringPlanTemplate{})
+	}
+
+	log.Debug("watering plan template entity deleted successfully in db", "template_id", id)
+	return nil
+}
@@ -0,0 +1,250 @@
+package wateringplantemplate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultWateringPlanTemplateMappers() WateringPlanTemplateRepositoryMappers {
+	return NewWateringPlanTemplateRepositoryMappers(&generated.InternalWateringPlanTemplateRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestWateringPlanTemplateRepository_Get(t *testing.T) {
+	t.Run("should return all templates", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, "Tuesday and friday round", got[0].Name)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=TU,FR", got[0].RRule)
+		assert.Equal(t, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), got[0].StartDate)
+		assert.Equal(t, "04-01", got[0].SeasonStart)
+		assert.Equal(t, "09-30", got[0].SeasonEnd)
+		assert.Equal(t, []int32{1, 2}, got[0].TreeClusterIDs)
+		assert.Equal(t, utils.P(int32(2)), got[0].TransporterID)
+		assert.Equal(t, utils.P(int32(1)), got[0].TrailerID)
+		assert.Equal(t, []uuid.UUID{uuid.MustParse("6a1078e8-80fd-458f-b74e-e388fe2dd6ab")}, got[0].UserIDs)
+		assert.True(t, got[0].Enabled)
+		assert.Nil(t, got[1].TrailerID)
+		assert.False(t, got[1].Enabled)
+	})
+
+	t.Run("should return error when template not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should return occurrences oldest first", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		got, err := r.GetOccurrences(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, time.Date(2025, time.April, 15, 0, 0, 0, 0, time.UTC), got[0].Date)
+		assert.False(t, got[0].Holiday)
+		assert.True(t, got[1].Holiday)
+		assert.Nil(t, got[1].WateringPlanID)
+	})
+}
+
+func TestWateringPlanTemplateRepository_Create(t *testing.T) {
+	t.Run("should create template", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+		userID := uuid.New()
+
+		// when
+		got, err := r.Create(context.Background(), func(tpl *entities.WateringPlanTemplate, _ storage.WateringPlanTemplateRepository) (bool, error) {
+			tpl.Name = "Daily round"
+			tpl.RRule = "FREQ=DAILY"
+			tpl.StartDate = time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+			tpl.TreeClusterIDs = []int32{4}
+			tpl.TransporterID = utils.P(int32(2))
+			tpl.UserIDs = []uuid.UUID{userID}
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), got.ID)
+		assert.Equal(t, "Daily round", got.Name)
+		assert.Equal(t, []int32{4}, got.TreeClusterIDs)
+		assert.Equal(t, []uuid.UUID{userID}, got.UserIDs)
+		assert.True(t, got.Enabled)
+	})
+
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(tpl *entities.WateringPlanTemplate, _ storage.WateringPlanTemplateRepository) (bool, error) {
+			tpl.RRule = "FREQ=DAILY"
+			tpl.TreeClusterIDs = []int32{4}
+			tpl.UserIDs = []uuid.UUID{uuid.New()}
+			return true, nil
+		})
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should create occurrence", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+		date := time.Date(2025, time.April, 22, 0, 0, 0, 0, time.UTC)
+
+		// when
+		err := r.CreateOccurrence(context.Background(), &entities.WateringPlanTemplateOccurrence{TemplateID: 1, Date: date}, nil)
+		errTwice := r.CreateOccurrence(context.Background(), &entities.WateringPlanTemplateOccurrence{TemplateID: 1, Date: date}, nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.Error(t, errTwice)
+		got, _ := r.GetOccurrences(context.Background(), 1)
+		assert.Len(t, got, 3)
+		assert.Equal(t, date, got[2].Date)
+	})
+
+	t.Run("should not store occurrence when create function fails", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+		date := time.Date(2025, time.April, 22, 0, 0, 0, 0, time.UTC)
+
+		// when
+		err := r.CreateOccurrence(context.Background(), &entities.WateringPlanTemplateOccurrence{TemplateID: 1, Date: date}, func(_ context.Context, _ *entities.WateringPlanTemplateOccurrence) error {
+			return errors.New("failed to create watering plan")
+		})
+
+		// then
+		assert.Error(t, err)
+		got, _ := r.GetOccurrences(context.Background(), 1)
+		assert.Len(t, got, 2)
+	})
+}
+
+func TestWateringPlanTemplateRepository_Update(t *testing.T) {
+	t.Run("should update template", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		err := r.Update(context.Background(), 1, func(tpl *entities.WateringPlanTemplate, _ storage.WateringPlanTemplateRepository) (bool, error) {
+			tpl.RRule = "FREQ=WEEKLY;BYDAY=WE"
+			tpl.TrailerID = nil
+			tpl.Enabled = false
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetByID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=WE", got.RRule)
+		assert.Nil(t, got.TrailerID)
+		assert.False(t, got.Enabled)
+		assert.Equal(t, "Tuesday and friday round", got.Name)
+	})
+}
+
+func TestWateringPlanTemplateRepository_Delete(t *testing.T) {
+	t.Run("should remove deleted tree cluster from template", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		_, err := suite.Store.DeleteTreeCluster(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetByID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{1}, got.TreeClusterIDs)
+	})
+
+	t.Run("should delete template and its occurrences", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/watering_plan_template")
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		err := r.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		_, err = r.GetByID(context.Background(), 1)
+		assert.Error(t, err)
+		got, err := r.GetOccurrences(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should return error when template not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWateringPlanTemplateRepository(suite.Store, defaultWateringPlanTemplateMappers())
+
+		// when
+		err := r.Delete(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,72 @@
+package wateringplantemplate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *WateringPlanTemplateRepository) Update(ctx context.Context, id int32, updateFn func(*entities.WateringPlanTemplate, storage.WateringPlanTemplateRepository) (bool, error)) error {
+	log := logger.GetLogger(ctx)
+	return r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewWateringPlanTemplateRepository(s, r.WateringPlanTemplateRepositoryMappers)
+		entity, err := newRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if updateFn == nil {
+			return errors.New("updateFn is nil")
+		}
+
+		updated, err := updateFn(entity, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return nil
+		}
+
+		if err := validateWateringPlanTemplate(entity); err != nil {
+			return err
+		}
+
+		startDate, err := utils.TimeToPgDate(entity.StartDate)
+		if err != nil {
+			return err
+		}
+
+		err = s.UpdateWateringPlanTemplate(ctx, &sqlc.UpdateWateringPlanTemplateParams{
+			ID:            entity.ID,
+			Name:          entity.Name,
+			Description:   entity.Description,
+			Rrule:         entity.RRule,
+			StartDate:     startDate,
+			SeasonStart:   entity.SeasonStart,
+			SeasonEnd:     entity.SeasonEnd,
+			TransporterID: entity.TransporterID,
+			TrailerID:     entity.TrailerID,
+			UserIds:       mapUserIDs(entity.UserIDs),
+			Enabled:       entity.Enabled,
+		})
+		if err != nil {
+			log.Error("failed to update watering plan template entity in db", "error", err, "template_id", id)
+			return err
+		}
+
+		if err := newRepo.setTreeClusters(ctx, id, entity.TreeClusterIDs); err != nil {
+			log.Error("failed to update tree clusters of watering plan template in db", "error", err, "template_id", id)
+			return err
+		}
+
+		log.Debug("watering plan template entity updated successfully in db", "template_id", id)
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+package weather
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *WeatherRepository) GetLocations(ctx context.Context) ([]*entities.WeatherLocation, error) {
+	rows, err := r.store.GetWeatherLocations(ctx)
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to get weather locations in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.Region{})
+	}
+
+	return r.mapper.FromSqlLocationList(rows), nil
+}
+
+func (r *WeatherRepository) GetDays(ctx context.Context, regionID int32, from, to time.Time) ([]*entities.WeatherDay, error) {
+	fromDate, err := utils.TimeToPgDate(from)
+	if err != nil {
+		return nil, err
+	}
+	toDate, err := utils.TimeToPgDate(to)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.store.GetWeatherDaysByRegion(ctx, &sqlc.GetWeatherDaysByRegionParams{
+		RegionID: regionID,
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to get weather days in db", "error", err, "region_id", regionID)
+		return nil, r.store.MapError(err, sqlc.WeatherDay{})
+	}
+
+	return r.mapper.FromSqlDayList(rows), nil
+}
+
+func (r *WeatherRepository) GetWaterBalances(ctx context.Context, treeClusterID int32) ([]*entities.WaterBalance, error) {
+	rows, err := r.store.GetWaterBalancesByTreeCluster(ctx, treeClusterID)
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to get water balances in db", "error", err, "cluster_id", treeClusterID)
+		return nil, r.store.MapError(err, sqlc.TreeClusterWaterBalance{})
+	}
+
+	return r.mapper.FromSqlWaterBalanceList(rows), nil
+}
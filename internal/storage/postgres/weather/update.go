@@ -0,0 +1,83 @@
+package weather
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (r *WeatherRepository) UpsertDays(ctx context.Context, regionID int32, days []*entities.WeatherDay) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	args := &sqlc.UpsertWeatherDaysParams{
+		RegionID:        regionID,
+		Dates:           make([]pgtype.Date, 0, len(days)),
+		Precipitation:   make([]float64, 0, len(days)),
+		TemperatureMin:  make([]float64, 0, len(days)),
+		TemperatureMax:  make([]float64, 0, len(days)),
+		TemperatureMean: make([]float64, 0, len(days)),
+		Et0:             make([]float64, 0, len(days)),
+		Forecast:        make([]bool, 0, len(days)),
+	}
+	for _, day := range days {
+		date, err := utils.TimeToPgDate(day.Date)
+		if err != nil {
+			return err
+		}
+		args.Dates = append(args.Dates, date)
+		args.Precipitation = append(args.Precipitation, day.Precipitation)
+		args.TemperatureMin = append(args.TemperatureMin, day.TemperatureMin)
+		args.TemperatureMax = append(args.TemperatureMax, day.TemperatureMax)
+		args.TemperatureMean = append(args.TemperatureMean, day.TemperatureMean)
+		args.Et0 = append(args.Et0, day.ET0)
+		args.Forecast = append(args.Forecast, day.Forecast)
+	}
+
+	if err := r.store.UpsertWeatherDays(ctx, args); err != nil {
+		logger.GetLogger(ctx).Debug("failed to upsert weather days in db", "error", err, "region_id", regionID)
+		return r.store.MapError(err, sqlc.WeatherDay{})
+	}
+	return nil
+}
+
+func (r *WeatherRepository) ReplaceWaterBalances(ctx context.Context, treeClusterID int32, balances []*entities.WaterBalance) error {
+	args := &sqlc.CreateWaterBalancesParams{
+		TreeClusterID:    treeClusterID,
+		Dates:            make([]pgtype.Date, 0, len(balances)),
+		Deficits:         make([]float64, 0, len(balances)),
+		WateringStatuses: make([]string, 0, len(balances)),
+		Forecast:         make([]bool, 0, len(balances)),
+	}
+	for _, balance := range balances {
+		date, err := utils.TimeToPgDate(balance.Date)
+		if err != nil {
+			return err
+		}
+		args.Dates = append(args.Dates, date)
+		args.Deficits = append(args.Deficits, balance.Deficit)
+		args.WateringStatuses = append(args.WateringStatuses, string(balance.WateringStatus))
+		args.Forecast = append(args.Forecast, balance.Forecast)
+	}
+
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		if err := s.DeleteWaterBalancesByTreeCluster(ctx, treeClusterID); err != nil {
+			return err
+		}
+		if len(balances) == 0 {
+			return nil
+		}
+		return s.CreateWaterBalances(ctx, args)
+	})
+	if err != nil {
+		logger.GetLogger(ctx).Debug("failed to replace water balances in db", "error", err, "cluster_id", treeClusterID)
+		return r.store.MapError(err, sqlc.TreeClusterWaterBalance{})
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package weather
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultWeatherMappers() WeatherRepositoryMappers {
+	return NewWeatherRepositoryMappers(&generated.InternalWeatherRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+	code = m.Run()
+}
+
+func date(day int) time.Time {
+	return time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestWeatherRepository_GetLocations(t *testing.T) {
+	t.Run("should return a point inside every region with a geometry", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/region")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetLocations(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got)
+		for _, location := range got {
+			assert.NotZero(t, location.RegionID)
+			assert.InDelta(t, 54.8, location.Latitude, 0.5)
+			assert.InDelta(t, 9.4, location.Longitude, 0.5)
+		}
+	})
+
+	t.Run("should return empty list without regions", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		got, err := r.GetLocations(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestWeatherRepository_UpsertDays(t *testing.T) {
+	t.Run("should store days and overwrite days with the same date", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/region")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		ctx := context.Background()
+
+		err := r.UpsertDays(ctx, 1, []*entities.WeatherDay{
+			{Date: date(1), Precipitation: 4.2, TemperatureMin: 1.2, TemperatureMax: 8.4, TemperatureMean: 4.9, ET0: 0.8},
+			{Date: date(2), Precipitation: 0, TemperatureMin: -0.4, TemperatureMax: 10.2, TemperatureMean: 4.6, ET0: 1.3, Forecast: true},
+		})
+		assert.NoError(t, err)
+
+		// when
+		err = r.UpsertDays(ctx, 1, []*entities.WeatherDay{
+			{Date: date(2), Precipitation: 2.5, TemperatureMin: 0.1, TemperatureMax: 9.8, TemperatureMean: 5.0, ET0: 1.1},
+		})
+		got, errGet := r.GetDays(ctx, 1, date(1), date(31))
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGet)
+		assert.Len(t, got, 2)
+		assert.Equal(t, date(1), got[0].Date)
+		assert.Equal(t, 4.2, got[0].Precipitation)
+		assert.Equal(t, 0.8, got[0].ET0)
+		assert.Equal(t, date(2), got[1].Date)
+		assert.Equal(t, 2.5, got[1].Precipitation)
+		assert.Equal(t, 1.1, got[1].ET0)
+		assert.False(t, got[1].Forecast)
+	})
+
+	t.Run("should do nothing without days", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		err := r.UpsertDays(context.Background(), 1, nil)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error for unknown region", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+
+		// when
+		err := r.UpsertDays(context.Background(), 99, []*entities.WeatherDay{{Date: date(1)}})
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestWeatherRepository_GetDays(t *testing.T) {
+	t.Run("should return only the days in the range", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/region")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		ctx := context.Background()
+		assert.NoError(t, r.UpsertDays(ctx, 1, []*entities.WeatherDay{{Date: date(1)}, {Date: date(2)}, {Date: date(3)}}))
+
+		// when
+		got, err := r.GetDays(ctx, 1, date(2), date(3))
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, date(2), got[0].Date)
+		assert.Equal(t, date(3), got[1].Date)
+	})
+}
+
+func TestWeatherRepository_ReplaceWaterBalances(t *testing.T) {
+	t.Run("should replace the water balance of the tree cluster", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		ctx := context.Background()
+		assert.NoError(t, r.ReplaceWaterBalances(ctx, 1, []*entities.WaterBalance{
+			{Date: date(1), Deficit: 10, WateringStatus: entities.WateringStatusGood},
+			{Date: date(2), Deficit: 20, WateringStatus: entities.WateringStatusModerate},
+		}))
+
+		// when
+		err := r.ReplaceWaterBalances(ctx, 1, []*entities.WaterBalance{
+			{Date: date(2), Deficit: 25, WateringStatus: entities.WateringStatusModerate},
+			{Date: date(3), Deficit: 40, WateringStatus: entities.WateringStatusBad, Forecast: true},
+		})
+		got, errGet := r.GetWaterBalances(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGet)
+		assert.Len(t, got, 2)
+		assert.Equal(t, int32(1), got[0].TreeClusterID)
+		assert.Equal(t, date(2), got[0].Date)
+		assert.Equal(t, 25.0, got[0].Deficit)
+		assert.Equal(t, entities.WateringStatusModerate, got[0].WateringStatus)
+		assert.Equal(t, date(3), got[1].Date)
+		assert.Equal(t, entities.WateringStatusBad, got[1].WateringStatus)
+		assert.True(t, got[1].Forecast)
+	})
+
+	t.Run("should remove the water balance without balances", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/treecluster")
+		r := NewWeatherRepository(suite.Store, defaultWeatherMappers())
+		ctx := context.Background()
+		assert.NoError(t, r.ReplaceWaterBalances(ctx, 1, []*entities.WaterBalance{
+			{Date: date(1), Deficit: 10, WateringStatus: entities.WateringStatusGood},
+		}))
+
+		// when
+		err := r.ReplaceWaterBalances(ctx, 1, nil)
+		got, errGet := r.GetWaterBalances(ctx, 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.NoError(t, errGet)
+		assert.Empty(t, got)
+	})
+}
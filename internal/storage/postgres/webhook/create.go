@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func defaultWebhook() *entities.Webhook {
+	return &entities.Webhook{
+		Name:       "",
+		URL:        "",
+		Secret:     "",
+		EventTypes: []entities.EventType{},
+		Enabled:    true,
+	}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, createFn func(*entities.Webhook, storage.WebhookRepository) (bool, error)) (*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	if createFn == nil {
+		return nil, errors.New("createFn is nil")
+	}
+
+	var createdWh *entities.Webhook
+	err := r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewWebhookRepository(s, r.WebhookRepositoryMappers)
+		entity := defaultWebhook()
+		created, err := createFn(entity, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if err := validateWebhook(entity); err != nil {
+			return err
+		}
+
+		id, err := s.CreateWebhook(ctx, &sqlc.CreateWebhookParams{
+			Name:       entity.Name,
+			Url:        entity.URL,
+			Secret:     entity.Secret,
+			EventTypes: mapEventTypes(entity.EventTypes),
+			Enabled:    entity.Enabled,
+		})
+		if err != nil {
+			return err
+		}
+
+		createdWh, err = newRepo.GetByID(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		log.Error("failed to create webhook entity in db", "error", err)
+		return nil, err
+	}
+
+	if createdWh != nil {
+		log.Debug("webhook entity created successfully in db", "webhook_id", createdWh.ID)
+	}
+
+	return createdWh, nil
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) (*entities.WebhookDelivery, error) {
+	log := logger.GetLogger(ctx)
+	id, err := r.store.CreateWebhookDelivery(ctx, &sqlc.CreateWebhookDeliveryParams{
+		WebhookID: delivery.WebhookID,
+		EventID:   delivery.EventID,
+		EventType: string(delivery.EventType),
+		Payload:   delivery.Payload,
+	})
+	if err != nil {
+		log.Error("failed to create webhook delivery in db", "error", err, "webhook_id", delivery.WebhookID)
+		return nil, err
+	}
+
+	row, err := r.store.GetWebhookDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, r.store.MapError(err, sqlc.WebhookDelivery{})
+	}
+
+	log.Debug("webhook delivery created successfully in db", "webhook_id", delivery.WebhookID, "delivery_id", id)
+	return r.mapper.FromSqlDelivery(row), nil
+}
+
+func validateWebhook(entity *entities.Webhook) error {
+	if entity.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if entity.URL == "" {
+		return errors.New("url is required")
+	}
+
+	if entity.Secret == "" {
+		return errors.New("secret is required")
+	}
+
+	return nil
+}
+
+func mapEventTypes(eventTypes []entities.EventType) []string {
+	return utils.Map(eventTypes, func(e entities.EventType) string {
+		return string(e)
+	})
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *WebhookRepository) GetAll(ctx context.Context) ([]*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllWebhooks(ctx)
+	if err != nil {
+		log.Debug("failed to get webhooks in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.Webhook{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id int32) (*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	row, err := r.store.GetWebhookByID(ctx, id)
+	if err != nil {
+		log.Debug("failed to get webhook by id in db", "error", err, "webhook_id", id)
+		return nil, r.store.MapError(err, sqlc.Webhook{})
+	}
+
+	return r.mapper.FromSql(row), nil
+}
+
+func (r *WebhookRepository) GetAllEnabledByEventType(ctx context.Context, eventType entities.EventType) ([]*entities.Webhook, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllEnabledWebhooksByEventType(ctx, string(eventType))
+	if err != nil {
+		log.Debug("failed to get webhooks by event type in db", "error", err, "event_type", eventType)
+		return nil, r.store.MapError(err, sqlc.Webhook{})
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *WebhookRepository) GetDeliveriesByWebhookID(ctx context.Context, webhookID, limit int32) ([]*entities.WebhookDelivery, error) {
+	log := logger.GetLogger(ctx)
+	rows, err := r.store.GetAllWebhookDeliveriesByWebhookID(ctx, &sqlc.GetAllWebhookDeliveriesByWebhookIDParams{
+		WebhookID: webhookID,
+		Limit:     limit,
+	})
+	if err != nil {
+		log.Debug("failed to get webhook deliveries in db", "error", err, "webhook_id", webhookID)
+		return nil, r.store.MapError(err, sqlc.WebhookDelivery{})
+	}
+
+	return r.mapper.FromSqlDeliveryList(rows), nil
+}
+
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int32) ([]*entities.WebhookDelivery, error) {
+	log := logger.GetLogger(ctx)
+	lockedUntil := now.Add(lease)
+	rows, err := r.store.ClaimDueWebhookDeliveries(ctx, &sqlc.ClaimDueWebhookDeliveriesParams{
+		LockedUntil:   utils.TimeToPgTimestamp(&lockedUntil),
+		Now:           utils.TimeToPgTimestamp(&now),
+		MaxDeliveries: limit,
+	})
+	if err != nil {
+		log.Debug("failed to claim due webhook deliveries in db", "error", err)
+		return nil, r.store.MapError(err, sqlc.WebhookDelivery{})
+	}
+
+	return r.mapper.FromSqlDeliveryList(rows), nil
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *WebhookRepository) Update(ctx context.Context, id int32, updateFn func(*entities.Webhook, storage.WebhookRepository) (bool, error)) error {
+	log := logger.GetLogger(ctx)
+	return r.store.WithTx(ctx, func(s *store.Store) error {
+		newRepo := NewWebhookRepository(s, r.WebhookRepositoryMappers)
+		wh, err := newRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if updateFn == nil {
+			return errors.New("updateFn is nil")
+		}
+
+		updated, err := updateFn(wh, newRepo)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return nil
+		}
+
+		if err := validateWebhook(wh); err != nil {
+			return err
+		}
+
+		err = s.UpdateWebhook(ctx, &sqlc.UpdateWebhookParams{
+			ID:         wh.ID,
+			Name:       wh.Name,
+			Url:        wh.URL,
+			EventTypes: mapEventTypes(wh.EventTypes),
+			Enabled:    wh.Enabled,
+		})
+		if err != nil {
+			log.Error("failed to update webhook entity in db", "error", err, "webhook_id", id)
+			return err
+		}
+
+		log.Debug("webhook entity updated successfully in db", "webhook_id", id)
+		return nil
+	})
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	log := logger.GetLogger(ctx)
+	err := r.store.UpdateWebhookDelivery(ctx, &sqlc.UpdateWebhookDeliveryParams{
+		ID:             delivery.ID,
+		Status:         sqlc.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  utils.TimeToPgTimestamp(&delivery.NextAttemptAt),
+		DeliveredAt:    utils.TimeToPgTimestamp(delivery.DeliveredAt),
+	})
+	if err != nil {
+		log.Error("failed to update webhook delivery in db", "error", err, "delivery_id", delivery.ID)
+		return err
+	}
+
+	return nil
+}
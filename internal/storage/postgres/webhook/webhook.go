@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+var _ storage.WebhookRepository = (*WebhookRepository)(nil)
+
+type WebhookRepository struct {
+	store *store.Store
+	WebhookRepositoryMappers
+}
+
+type WebhookRepositoryMappers struct {
+	mapper mapper.InternalWebhookRepoMapper
+}
+
+func NewWebhookRepositoryMappers(wMapper mapper.InternalWebhookRepoMapper) WebhookRepositoryMappers {
+	return WebhookRepositoryMappers{
+		mapper: wMapper,
+	}
+}
+
+func NewWebhookRepository(s *store.Store, mappers WebhookRepositoryMappers) *WebhookRepository {
+	return &WebhookRepository{
+		store:                    s,
+		WebhookRepositoryMappers: mappers,
+	}
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id int32) error {
+	log := logger.GetLogger(ctx)
+	_, err := r.store.DeleteWebhook(ctx, id)
+	if err != nil {
+		log.Error("failed to delete webhook entity in db", "error", err, "webhook_id", id)
+		return r.store.MapError(err, sqlc.Webhook{})
+	}
+
+	log.Debug("webhook entity deleted successfully in db", "webhook_id", id)
+	return nil
+}
@@ -0,0 +1,273 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var suite *testutils.PostgresTestSuite
+
+func defaultWebhookMappers() WebhookRepositoryMappers {
+	return NewWebhookRepositoryMappers(&generated.InternalWebhookRepoMapperImpl{})
+}
+
+func TestMain(m *testing.M) {
+	code := 1
+	ctx := context.Background()
+	defer func() { os.Exit(code) }()
+	suite = testutils.SetupPostgresTestSuite(ctx)
+	defer suite.Terminate(ctx)
+
+	code = m.Run()
+}
+
+func TestWebhookRepository_Get(t *testing.T) {
+	t.Run("should return all webhooks", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, "Irrigation system", got[0].Name)
+		assert.Equal(t, []entities.EventType{entities.EventTypeCreateTree, entities.EventTypeUpdateTree}, got[0].EventTypes)
+	})
+
+	t.Run("should return webhook by id", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), got.ID)
+		assert.Equal(t, "http://localhost:8081/webhook", got.URL)
+		assert.False(t, got.Enabled)
+	})
+
+	t.Run("should return error when webhook not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.GetByID(context.Background(), 99)
+
+		// then
+		assert.Nil(t, got)
+		assert.ErrorAs(t, err, new(storage.ErrEntityNotFound))
+	})
+
+	t.Run("should return only enabled webhooks subscribing the event type", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.GetAllEnabledByEventType(context.Background(), entities.EventTypeUpdateTree)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(1), got[0].ID)
+	})
+}
+
+func TestWebhookRepository_Create(t *testing.T) {
+	t.Run("should create webhook", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(wh *entities.Webhook, _ storage.WebhookRepository) (bool, error) {
+			wh.Name = "Irrigation system"
+			wh.URL = "https://example.com/hook"
+			wh.Secret = "0123456789abcdef0123456789abcdef"
+			wh.EventTypes = []entities.EventType{entities.EventTypeDeleteTree}
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.NotZero(t, got.ID)
+		assert.Equal(t, "Irrigation system", got.Name)
+		assert.Equal(t, []entities.EventType{entities.EventTypeDeleteTree}, got.EventTypes)
+		assert.True(t, got.Enabled)
+	})
+
+	t.Run("should return error when secret is missing", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.Create(context.Background(), func(wh *entities.Webhook, _ storage.WebhookRepository) (bool, error) {
+			wh.Name = "Irrigation system"
+			wh.URL = "https://example.com/hook"
+			return true, nil
+		})
+
+		// then
+		assert.Nil(t, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookRepository_Update(t *testing.T) {
+	t.Run("should update webhook", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		err := r.Update(context.Background(), 1, func(wh *entities.Webhook, _ storage.WebhookRepository) (bool, error) {
+			wh.Name = "Renamed"
+			wh.Enabled = false
+			return true, nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		got, err := r.GetByID(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Renamed", got.Name)
+		assert.False(t, got.Enabled)
+	})
+}
+
+func TestWebhookRepository_Delete(t *testing.T) {
+	t.Run("should delete webhook with its deliveries", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		err := r.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		deliveries, err := r.GetDeliveriesByWebhookID(context.Background(), 1, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("should return error when webhook not found", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		err := r.Delete(context.Background(), 99)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookRepository_Deliveries(t *testing.T) {
+	t.Run("should return deliveries of webhook", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.GetDeliveriesByWebhookID(context.Background(), 1, 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("should return only due deliveries", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		got, err := r.ClaimDueDeliveries(context.Background(), time.Now(), time.Minute, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(1), got[0].ID)
+	})
+
+	t.Run("should not claim deliveries twice while the lease is held", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+		now := time.Now()
+		_, err := r.ClaimDueDeliveries(context.Background(), now, time.Minute, 10)
+		assert.NoError(t, err)
+
+		// when
+		got, err := r.ClaimDueDeliveries(context.Background(), now.Add(30*time.Second), time.Minute, 10)
+		expired, expiredErr := r.ClaimDueDeliveries(context.Background(), now.Add(2*time.Minute), time.Minute, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+		assert.NoError(t, expiredErr)
+		assert.Len(t, expired, 1)
+	})
+
+	t.Run("should create and update delivery", func(t *testing.T) {
+		// given
+		suite.ResetDB(t)
+		suite.InsertSeed(t, "internal/storage/postgres/seed/test/webhook")
+		r := NewWebhookRepository(suite.Store, defaultWebhookMappers())
+
+		// when
+		created, err := r.CreateDelivery(context.Background(), &entities.WebhookDelivery{
+			WebhookID: 1,
+			EventID:   "e0e0e0e0-1111-4222-8333-444455556666",
+			EventType: entities.EventTypeCreateTree,
+			Payload:   []byte(`{"id":"e0e0e0e0-1111-4222-8333-444455556666"}`),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WebhookDeliveryStatusPending, created.Status)
+		assert.Zero(t, created.Attempts)
+
+		now := time.Now()
+		statusCode := int32(200)
+		created.Status = entities.WebhookDeliveryStatusSuccess
+		created.Attempts = 1
+		created.LastStatusCode = &statusCode
+		created.DeliveredAt = &now
+		err = r.UpdateDelivery(context.Background(), created)
+		assert.NoError(t, err)
+
+		deliveries, err := r.GetDeliveriesByWebhookID(context.Background(), 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, created.ID, deliveries[0].ID)
+		assert.Equal(t, entities.WebhookDeliveryStatusSuccess, deliveries[0].Status)
+		assert.Equal(t, statusCode, *deliveries[0].LastStatusCode)
+		assert.NotNil(t, deliveries[0].DeliveredAt)
+	})
+}
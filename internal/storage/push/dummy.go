@@ -0,0 +1,23 @@
+package push
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// DummyPushSender is used to disable the push messages by configuration
+type DummyPushSender struct{}
+
+func NewDummyPushSender() *DummyPushSender {
+	return &DummyPushSender{}
+}
+
+func (s *DummyPushSender) Send(_ context.Context, _ *entities.PushSubscription, _ []byte) error {
+	return storage.ErrPushServiceDisabled
+}
+
+func (s *DummyPushSender) PublicKey() string {
+	return ""
+}
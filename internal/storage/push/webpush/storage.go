@@ -0,0 +1,33 @@
+package webpush
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+func NewRepository(cfg *config.Config) (*storage.Repository, error) {
+	pushCfg := cfg.Notification.Push
+	if pushCfg.PublicKey == "" || pushCfg.PrivateKey == "" {
+		err := errors.New("the vapid keys are missing")
+		slog.Error("failed to setup push sender", "error", err, "service", "webpush")
+		return nil, err
+	}
+
+	sender := NewWebPushSender(WebPushSenderConfig{
+		PublicKey:  pushCfg.PublicKey,
+		PrivateKey: pushCfg.PrivateKey,
+		Subject:    pushCfg.Subject,
+		TTL:        pushCfg.TTL,
+		Client:     &http.Client{Timeout: 30 * time.Second},
+	})
+
+	slog.Info("successfully initialized push sender", "service", "webpush")
+	return &storage.Repository{
+		PushSender: sender,
+	}, nil
+}
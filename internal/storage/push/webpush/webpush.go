@@ -0,0 +1,77 @@
+package webpush
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/SherClockHolmes/webpush-go"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+var _ storage.PushSender = (*WebPushSender)(nil)
+
+type WebPushSenderConfig struct {
+	PublicKey  string
+	PrivateKey string
+	Subject    string
+	TTL        time.Duration
+	Client     *http.Client
+}
+
+// WebPushSender sends encrypted push messages to the push services of the browsers. The sender is
+// identified by its VAPID keys, the public key is needed by the frontend to subscribe.
+type WebPushSender struct {
+	cfg WebPushSenderConfig
+}
+
+func NewWebPushSender(cfg WebPushSenderConfig) *WebPushSender {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	return &WebPushSender{cfg: cfg}
+}
+
+func (s *WebPushSender) PublicKey() string {
+	return s.cfg.PublicKey
+}
+
+func (s *WebPushSender) Send(ctx context.Context, sub *entities.PushSubscription, payload []byte) error {
+	log := logger.GetLogger(ctx)
+	resp, err := webpush.SendNotificationWithContext(ctx, payload, &webpush.Subscription{
+		Endpoint: sub.Endpoint,
+		Keys: webpush.Keys{
+			Auth:   sub.Auth,
+			P256dh: sub.P256dh,
+		},
+	}, &webpush.Options{
+		HTTPClient:      s.cfg.Client,
+		Subscriber:      s.cfg.Subject,
+		VAPIDPublicKey:  s.cfg.PublicKey,
+		VAPIDPrivateKey: s.cfg.PrivateKey,
+		TTL:             int(s.cfg.TTL.Seconds()),
+		Urgency:         webpush.UrgencyNormal,
+	})
+	if err != nil {
+		log.Error("failed to send push message", "error", err, "push_subscription_id", sub.ID)
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		log.Debug("push subscription is expired", "push_subscription_id", sub.ID, "status", resp.StatusCode)
+		return storage.ErrPushSubscriptionGone
+	case resp.StatusCode >= http.StatusBadRequest:
+		log.Error("push service rejected the push message", "push_subscription_id", sub.ID, "status", resp.StatusCode)
+		return fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+
+	log.Debug("push message sent successfully", "push_subscription_id", sub.ID)
+	return nil
+}
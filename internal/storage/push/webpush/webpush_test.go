@@ -0,0 +1,102 @@
+package webpush
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SherClockHolmes/webpush-go"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSubscription(t *testing.T, endpoint string) *entities.PushSubscription {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	assert.NoError(t, err)
+
+	return &entities.PushSubscription{
+		ID:       1,
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}
+}
+
+func newSender(t *testing.T) *WebPushSender {
+	t.Helper()
+	privateKey, publicKey, err := webpush.GenerateVAPIDKeys()
+	assert.NoError(t, err)
+
+	return NewWebPushSender(WebPushSenderConfig{
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+		Subject:    "mailto:info@example.com",
+		TTL:        time.Hour,
+	})
+}
+
+func TestWebPushSender_Send(t *testing.T) {
+	t.Run("should send encrypted payload with vapid authorization", func(t *testing.T) {
+		// given
+		var req *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req = r
+			w.WriteHeader(http.StatusCreated)
+		}))
+		t.Cleanup(server.Close)
+		sender := newSender(t)
+
+		// when
+		err := sender.Send(context.Background(), newSubscription(t, server.URL), []byte(`{"title":"test"}`))
+
+		// then
+		assert.NoError(t, err)
+		assert.NotNil(t, req)
+		assert.Equal(t, "aes128gcm", req.Header.Get("Content-Encoding"))
+		assert.Equal(t, "3600", req.Header.Get("TTL"))
+		assert.Contains(t, req.Header.Get("Authorization"), "vapid t=")
+	})
+
+	t.Run("should return gone error for expired subscription", func(t *testing.T) {
+		for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+			// given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(status)
+			}))
+			t.Cleanup(server.Close)
+			sender := newSender(t)
+
+			// when
+			err := sender.Send(context.Background(), newSubscription(t, server.URL), []byte("{}"))
+
+			// then
+			assert.ErrorIs(t, err, storage.ErrPushSubscriptionGone)
+		}
+	})
+
+	t.Run("should return error for rejected push message", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		t.Cleanup(server.Close)
+		sender := newSender(t)
+
+		// when
+		err := sender.Send(context.Background(), newSubscription(t, server.URL), []byte("{}"))
+
+		// then
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, storage.ErrPushSubscriptionGone)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/twpayne/go-geos"
 )
 
 const (
@@ -170,7 +171,7 @@ func (v *VroomClient) toVroomShipments(cluster []*entities.TreeCluster) []VroomS
 			Delivery: VroomShipmentStep{
 				Description: c.Name,
 				ID:          nextID + 1,
-				Location:    []float64{*c.Longitude, *c.Latitude},
+				Location:    v.toDeliveryLocation(c),
 			},
 		}
 
@@ -179,6 +180,28 @@ func (v *VroomClient) toVroomShipments(cluster []*entities.TreeCluster) []VroomS
 	})
 }
 
+// toDeliveryLocation returns the point on the edge of the tree cluster polygon that is nearest to the
+// watering point, because every delivery starts there. Without a polygon the centroid is used.
+func (v *VroomClient) toDeliveryLocation(c *entities.TreeCluster) []float64 {
+	centroid := []float64{*c.Longitude, *c.Latitude}
+	if c.Polygon == "" || len(v.cfg.wateringPoint) != 2 {
+		return centroid
+	}
+
+	polygon, err := geos.NewGeomFromWKT(c.Polygon)
+	if err != nil || polygon.IsEmpty() {
+		slog.Debug("failed to parse polygon of tree cluster, using centroid as delivery location", "error", err, "cluster_id", c.ID)
+		return centroid
+	}
+
+	nearest := polygon.Boundary().NearestPoints(geos.NewPoint(v.cfg.wateringPoint))
+	if len(nearest) == 0 {
+		return centroid
+	}
+
+	return nearest[0]
+}
+
 func (v *VroomClient) toVroomVehicle(vehicle *entities.Vehicle) (*VroomVehicle, error) {
 	vehicleType, err := v.toOrsVehicleType(vehicle.Type)
 	if err != nil {
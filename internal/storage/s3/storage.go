@@ -10,26 +10,48 @@ import (
 
 func NewRepository(cfg *config.Config) (*storage.Repository, error) {
 	slog.Info("creating s3 repository", "bucket_name", cfg.S3.RouteGpx.Bucket, "endpoint", cfg.S3.Endpoint, "region", cfg.S3.Region, "use_ssl", cfg.S3.UseSSL)
-	gpxBucket, err := NewS3Repository(&S3RepoCfg{
-		bucketName:      cfg.S3.RouteGpx.Bucket,
+	gpxBucket, err := newBucket(cfg, &cfg.S3.RouteGpx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the report bucket is optional to keep existing deployments running
+	var reportBucket storage.S3Repository
+	if cfg.S3.Report.Bucket == "" {
+		slog.Warn("the report bucket is not configured, generated reports can not be downloaded")
+		reportBucket = NewS3DummyRepo()
+	} else {
+		slog.Info("creating s3 repository", "bucket_name", cfg.S3.Report.Bucket, "endpoint", cfg.S3.Endpoint, "region", cfg.S3.Region, "use_ssl", cfg.S3.UseSSL)
+		if reportBucket, err = newBucket(cfg, &cfg.S3.Report); err != nil {
+			return nil, err
+		}
+	}
+
+	return &storage.Repository{
+		GpxBucket:    gpxBucket,
+		ReportBucket: reportBucket,
+	}, nil
+}
+
+func newBucket(cfg *config.Config, bucketCfg *config.S3ServiceConfig) (*S3Repository, error) {
+	bucket, err := NewS3Repository(&S3RepoCfg{
+		bucketName:      bucketCfg.Bucket,
 		endpoint:        cfg.S3.Endpoint,
 		region:          cfg.S3.Region,
-		accessKeyID:     cfg.S3.RouteGpx.AccessKey,
-		secretAccessKey: cfg.S3.RouteGpx.SecretAccessKey,
+		accessKeyID:     bucketCfg.AccessKey,
+		secretAccessKey: bucketCfg.SecretAccessKey,
 		useSSL:          cfg.S3.UseSSL,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	bucketExists, err := gpxBucket.BucketExists(context.Background())
+	bucketExists, err := bucket.BucketExists(context.Background())
 	if err != nil || !bucketExists {
-		slog.Error("bucket don't exists", "error", err, "bucket_name", gpxBucket.cfg.bucketName)
+		slog.Error("bucket don't exists", "error", err, "bucket_name", bucket.cfg.bucketName)
 		return nil, storage.ErrBucketNotExists
 	}
 
-	slog.Info("successfully initialized s3 repository", "bucket_name", gpxBucket.cfg.bucketName)
-	return &storage.Repository{
-		GpxBucket: gpxBucket,
-	}, nil
+	slog.Info("successfully initialized s3 repository", "bucket_name", bucket.cfg.bucketName)
+	return bucket, nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
@@ -50,10 +51,19 @@ var (
 
 	ErrPaginationValueInvalid = errors.New("pagination values are invalid")
 	ErrInvalidMapConfig       = errors.New("map configuration not valid")
+	ErrVersionMismatch        = errors.New("entity version does not match")
+	ErrRegionOverlapping      = errors.New("region overlaps with another region")
+	ErrSubscriptionNotClaimed = errors.New("event subscription is not claimed by this owner")
 
 	ErrS3ServiceDisabled      = errors.New("s3 service is disabled")
 	ErrAuthServiceDisabled    = errors.New("auth service is disabled")
 	ErrRoutingServiceDisabled = errors.New("routing service is disabled")
+	ErrWeatherServiceDisabled = errors.New("weather service is disabled")
+	ErrMailServiceDisabled    = errors.New("mail service is disabled")
+	ErrPushServiceDisabled    = errors.New("push service is disabled")
+
+	// ErrPushSubscriptionGone is returned by the push service if the browser unsubscribed, the subscription has to be deleted
+	ErrPushSubscriptionGone = errors.New("push subscription is expired or unsubscribed")
 )
 
 type BasicCrudRepository[T entities.Entities] interface {
@@ -80,9 +90,13 @@ type RegionRepository interface {
 	GetByID(ctx context.Context, id int32) (*entities.Region, error)
 	// GetByPoint returns one region by latitude and longitude
 	GetByPoint(ctx context.Context, latitude, longitude float64) (*entities.Region, error)
-	// Create creates a new region. It accepts a list of functions to apply to the new region
+	// HasOverlap returns true if the area of the geometry given as WKT overlaps with any region except the one with excludeID
+	HasOverlap(ctx context.Context, geometry string, excludeID int32) (bool, error)
+	// Create creates a new region. It accepts a list of functions to apply to the new region.
+	// ErrRegionOverlapping is returned if the geometry overlaps with another region.
 	Create(ctx context.Context, fn ...entities.EntityFunc[entities.Region]) (*entities.Region, error)
-	// Update updates a already existing region. It accepts a list of functions to apply to the region
+	// Update updates a already existing region. It accepts a list of functions to apply to the region.
+	// ErrRegionOverlapping is returned if the geometry overlaps with another region.
 	Update(ctx context.Context, id int32, fn ...entities.EntityFunc[entities.Region]) (*entities.Region, error)
 	// Delete deletes a region by id
 	Delete(ctx context.Context, id int32) error
@@ -150,6 +164,27 @@ type WateringPlanRepository interface {
 	Delete(ctx context.Context, id int32) error
 }
 
+// WateringPlanTemplateRepository stores the recurring watering plan templates and their materialized occurrences
+type WateringPlanTemplateRepository interface {
+	// GetAll returns all watering plan templates
+	GetAll(ctx context.Context) ([]*entities.WateringPlanTemplate, error)
+	// GetByID returns one watering plan template by id
+	GetByID(ctx context.Context, id int32) (*entities.WateringPlanTemplate, error)
+	// Create creates a new watering plan template. It accepts a function that takes a template that can be modified. Any changes made to the template will be saved in the storage. If the function returns true, the template will be created, otherwise it will not be created.
+	Create(ctx context.Context, fn func(t *entities.WateringPlanTemplate, repo WateringPlanTemplateRepository) (bool, error)) (*entities.WateringPlanTemplate, error)
+	// Update updates a watering plan template by id. It takes the id of the template to update and a function that takes a template that can be modified. Any changes made to the template will be saved updated in the storage. If the function returns true, the template will be updated, otherwise it will not be updated.
+	Update(ctx context.Context, id int32, fn func(t *entities.WateringPlanTemplate, repo WateringPlanTemplateRepository) (bool, error)) error
+	// Delete deletes a watering plan template and its occurrences by id, the created watering plans are kept
+	Delete(ctx context.Context, id int32) error
+
+	// GetOccurrences returns the materialized occurrences of a template, oldest first
+	GetOccurrences(ctx context.Context, templateID int32) ([]*entities.WateringPlanTemplateOccurrence, error)
+	// CreateOccurrence stores a materialized occurrence of a template. If createFn is set, it is called before in the same
+	// transaction to create the watering plan of the occurrence. The context passed to createFn holds the transaction,
+	// the repositories called with it write in the transaction.
+	CreateOccurrence(ctx context.Context, occurrence *entities.WateringPlanTemplateOccurrence, createFn func(context.Context, *entities.WateringPlanTemplateOccurrence) error) error
+}
+
 type TreeClusterRepository interface {
 	// GetAll returns all tree clusters
 	GetAll(ctx context.Context, query entities.TreeClusterQuery) ([]*entities.TreeCluster, int64, error)
@@ -173,6 +208,10 @@ type TreeClusterRepository interface {
 	LinkTreesToCluster(ctx context.Context, treeClusterID int32, treeIDs []int32) error
 	GetCenterPoint(ctx context.Context, id int32) (float64, float64, error)
 	GetAllLatestSensorDataByClusterID(ctx context.Context, tcID int32) ([]*entities.SensorData, error)
+	// GetSuggestions groups trees by their distance to each other and returns a suggested tree cluster for every group
+	GetSuggestions(ctx context.Context, query entities.TreeClusterSuggestionQuery) ([]*entities.TreeClusterSuggestion, error)
+	// Upsert creates the tree clusters without id and updates the others in a single transaction. Afterwards updateFn is applied to the tree clusters with the updateIDs in the same transaction. If one tree cluster fails, no tree cluster is written.
+	Upsert(ctx context.Context, clusters []*entities.TreeCluster, updateIDs []int32, updateFn func(*entities.TreeCluster, TreeClusterRepository) (bool, error)) ([]*entities.TreeCluster, error)
 }
 
 type TreeRepository interface {
@@ -199,10 +238,15 @@ type TreeRepository interface {
 	UnlinkTreeClusterID(ctx context.Context, treeClusterID int32) error
 	UnlinkSensorID(ctx context.Context, sensorID string) error
 	FindNearestTree(ctx context.Context, latitude, longitude float64) (*entities.Tree, error)
+
+	// GetByProviderAndNumber returns the tree with the given number of the provider. An empty provider matches trees without provider.
+	GetByProviderAndNumber(ctx context.Context, provider, number string) (*entities.Tree, error)
+	// Upsert creates or updates all trees in a single transaction. Existing trees are matched by provider and number and keep their watering status. Their tree cluster and sensor are only replaced if the imported tree has one. If one tree fails, no tree is written.
+	Upsert(ctx context.Context, trees []*entities.Tree) ([]*entities.TreeImportChange, error)
 }
 
 type SensorRepository interface {
-	GetAll(ctx context.Context, query entities.Query) ([]*entities.Sensor, int64, error)
+	GetAll(ctx context.Context, query entities.SensorQuery) ([]*entities.Sensor, int64, error)
 	GetCount(ctx context.Context, query entities.Query) (int64, error)
 	GetByID(ctx context.Context, id string) (*entities.Sensor, error)
 	Create(ctx context.Context, createFn func(*entities.Sensor, SensorRepository) (bool, error)) (*entities.Sensor, error)
@@ -214,6 +258,295 @@ type SensorRepository interface {
 	InsertSensorData(ctx context.Context, data *entities.SensorData, id string) error
 }
 
+type PluginRepository interface {
+	// GetAll returns all registered plugins
+	GetAll(ctx context.Context) ([]*entities.Plugin, error)
+	// GetBySlug returns one registered plugin by its slug
+	GetBySlug(ctx context.Context, slug string) (*entities.Plugin, error)
+	// GetByClientID returns one registered plugin by the client id used to register it
+	GetByClientID(ctx context.Context, clientID string) (*entities.Plugin, error)
+	// GetAllWithHeartbeatBefore returns all registered plugins whose last heartbeat is older than the given time
+	GetAllWithHeartbeatBefore(ctx context.Context, before time.Time) ([]*entities.Plugin, error)
+	// Register creates or replaces the registration of a plugin and records a register event
+	Register(ctx context.Context, plugin *entities.Plugin) (*entities.Plugin, error)
+	// Unregister deletes the registration of a plugin and records an event of the given type
+	Unregister(ctx context.Context, slug string, eventType entities.PluginEventType) error
+	// UpdateHeartbeat sets the last heartbeat of a registered plugin to now
+	UpdateHeartbeat(ctx context.Context, slug string) error
+
+	// GetAllGrants returns all plugin grants of the allow-list
+	GetAllGrants(ctx context.Context) ([]*entities.PluginGrant, error)
+	// GetGrantBySlug returns the plugin grant for a slug
+	GetGrantBySlug(ctx context.Context, slug string) (*entities.PluginGrant, error)
+	// GetGrantByClientID returns the plugin grant bound to a client id
+	GetGrantByClientID(ctx context.Context, clientID string) (*entities.PluginGrant, error)
+	// SaveGrant creates or updates a plugin grant
+	SaveGrant(ctx context.Context, grant *entities.PluginGrant) (*entities.PluginGrant, error)
+	// DeleteGrant deletes a plugin grant and the registration of the plugin
+	DeleteGrant(ctx context.Context, slug string) error
+
+	// GetEventsBySlug returns the register, unregister and timeout history of a plugin
+	GetEventsBySlug(ctx context.Context, slug string) ([]*entities.PluginEvent, error)
+}
+
+type WebhookRepository interface {
+	// GetAll returns all webhooks
+	GetAll(ctx context.Context) ([]*entities.Webhook, error)
+	// GetByID returns one webhook by id
+	GetByID(ctx context.Context, id int32) (*entities.Webhook, error)
+	// GetAllEnabledByEventType returns all enabled webhooks that subscribed the event type
+	GetAllEnabledByEventType(ctx context.Context, eventType entities.EventType) ([]*entities.Webhook, error)
+	// Create creates a new webhook. It accepts a function that takes a webhook that can be modified. Any changes made to the webhook will be saved in the storage. If the function returns true, the webhook will be created, otherwise it will not be created.
+	Create(ctx context.Context, fn func(wh *entities.Webhook, repo WebhookRepository) (bool, error)) (*entities.Webhook, error)
+	// Update updates a webhook by id. It takes the id of the webhook to update and a function that takes a webhook that can be modified. Any changes made to the webhook will be saved updated in the storage. If the function returns true, the webhook will be updated, otherwise it will not be updated.
+	Update(ctx context.Context, id int32, fn func(wh *entities.Webhook, repo WebhookRepository) (bool, error)) error
+	// Delete deletes a webhook and its delivery log by id
+	Delete(ctx context.Context, id int32) error
+
+	// CreateDelivery queues a new delivery of a payload to a webhook
+	CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) (*entities.WebhookDelivery, error)
+	// GetDeliveriesByWebhookID returns the latest deliveries of a webhook
+	GetDeliveriesByWebhookID(ctx context.Context, webhookID int32, limit int32) ([]*entities.WebhookDelivery, error)
+	// ClaimDueDeliveries locks the pending or retrying deliveries whose next attempt is due at the given time for the lease and returns them. Concurrent callers never claim the same delivery.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int32) ([]*entities.WebhookDelivery, error)
+	// UpdateDelivery saves the result of a delivery attempt
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+}
+
+type APIKeyRepository interface {
+	// GetAll returns all api keys including revoked and expired ones
+	GetAll(ctx context.Context) ([]*entities.APIKey, error)
+	// GetByID returns one api key by id
+	GetByID(ctx context.Context, id int32) (*entities.APIKey, error)
+	// GetByPrefix returns the api key with the given public prefix
+	GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+	// Create creates a new api key. It accepts a function that takes an api key that can be modified. Any changes made to the api key will be saved in the storage. If the function returns true, the api key will be created, otherwise it will not be created.
+	Create(ctx context.Context, fn func(key *entities.APIKey, repo APIKeyRepository) (bool, error)) (*entities.APIKey, error)
+	// Update updates an api key by id. It takes the id of the api key to update and a function that takes an api key that can be modified. Any changes made to the api key will be saved updated in the storage. If the function returns true, the api key will be updated, otherwise it will not be updated.
+	Update(ctx context.Context, id int32, fn func(key *entities.APIKey, repo APIKeyRepository) (bool, error)) error
+	// UpdateLastUsed sets the time the api key was last used
+	UpdateLastUsed(ctx context.Context, id int32, lastUsed time.Time) error
+	// Delete deletes an api key by id
+	Delete(ctx context.Context, id int32) error
+}
+
+type TreeImportRepository interface {
+	// GetAll returns all import jobs, newest first
+	GetAll(ctx context.Context) ([]*entities.TreeImportJob, error)
+	// GetByID returns one import job by id
+	GetByID(ctx context.Context, id int32) (*entities.TreeImportJob, error)
+	// GetFile returns the uploaded file of the import job
+	GetFile(ctx context.Context, id int32) ([]byte, error)
+	// Create creates a new import job and stores the uploaded file. It accepts a function that takes an import job that can be modified. If the function returns true, the import job will be created, otherwise it will not be created.
+	Create(ctx context.Context, file []byte, fn func(job *entities.TreeImportJob, repo TreeImportRepository) (bool, error)) (*entities.TreeImportJob, error)
+	// Update updates an import job by id. It takes the id of the import job to update and a function that takes an import job that can be modified. If the function returns true, the import job will be updated, otherwise it will not be updated.
+	Update(ctx context.Context, id int32, fn func(job *entities.TreeImportJob, repo TreeImportRepository) (bool, error)) error
+	// Claim moves the oldest import job with status from to status to and returns it. A job left in status to whose claim is older than the lease is claimed again. Concurrent callers never claim the same job. If there is no such job ErrEntityNotFound is returned.
+	Claim(ctx context.Context, from, to entities.TreeImportStatus, now time.Time, lease time.Duration) (*entities.TreeImportJob, error)
+	// Delete deletes an import job and its file by id
+	Delete(ctx context.Context, id int32) error
+}
+
+// AuditLogRepository reads the audit log. The entries are written by the repositories of the
+// audited entities in the same transaction as the change.
+type AuditLogRepository interface {
+	// GetAll returns the audit log entries matching the query, newest first, together with the number of all matching entries
+	GetAll(ctx context.Context, query *entities.AuditLogQuery) ([]*entities.AuditLog, int64, error)
+}
+
+// EventRepository stores the published events in an outbox and tracks the offset of every durable
+// subscription. A subscription is claimed by one owner at a time, so several instances of the backend
+// can consume the same subscriptions.
+type EventRepository interface {
+	// Append stores an event in the outbox and notifies the listeners of all instances. If the context holds a transaction
+	// of WithTx, the event is stored and the listeners are notified when that transaction is committed.
+	Append(ctx context.Context, eventType entities.EventType, payload []byte) (*entities.OutboxEvent, error)
+	// WithTx runs fn in a transaction. The repositories called with the context passed to fn take part in it, so the
+	// changes of fn and the events appended in fn are committed together or not at all.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Listen calls fn with the event type of every appended event until the context is canceled or the connection fails
+	Listen(ctx context.Context, fn func(eventType entities.EventType)) error
+	// Subscribe creates the subscription if it does not exist yet. A new subscription starts after the latest event of the outbox.
+	Subscribe(ctx context.Context, name string, eventType entities.EventType) error
+	// GetSubscription returns the subscription by name
+	GetSubscription(ctx context.Context, name string) (*entities.EventSubscription, error)
+	// Claim locks the subscription for the owner until the lease has passed and returns it together with the next event after its offset.
+	// Events are ordered by the transaction that appended them and are only returned once every older transaction has ended.
+	// If the subscription is claimed by another owner, waits for a retry or has no pending event ErrEntityNotFound is returned.
+	Claim(ctx context.Context, name, owner string, lease time.Duration) (*entities.EventSubscription, *entities.OutboxEvent, error)
+	// Ack moves the offset of the subscription to the event and releases the claim. ErrSubscriptionNotClaimed is returned if the lease has been lost.
+	Ack(ctx context.Context, name, owner string, event *entities.OutboxEvent) error
+	// Retry counts a failed attempt, releases the claim and delays the next attempt. ErrSubscriptionNotClaimed is returned if the lease has been lost.
+	Retry(ctx context.Context, name, owner string, delay time.Duration, eventErr string) error
+	// DeadLetter stores the event as dead letter of the subscription and moves the offset past it
+	DeadLetter(ctx context.Context, name, owner string, event *entities.OutboxEvent, attempts int32, eventErr string) error
+	// GetLatestID returns the id of the latest event of the outbox that can be consumed or 0 if there is none
+	GetLatestID(ctx context.Context) (int64, error)
+	// GetAfter returns up to limit events of the type after the event with the given id in the order of Claim, without a subscription
+	GetAfter(ctx context.Context, eventType entities.EventType, afterID int64, limit int32) ([]*entities.OutboxEvent, error)
+	// GetDeadLetters returns the dead letters of the subscription, oldest first
+	GetDeadLetters(ctx context.Context, name string) ([]*entities.EventDeadLetter, error)
+	// DeleteProcessed deletes the events created before the given time that are handled by all subscriptions of their type and returns the number of deleted events
+	DeleteProcessed(ctx context.Context, before time.Time) (int64, error)
+}
+
+// LeaderLock is held by one instance until it is released or the connection holding it is lost.
+// The methods must not be called concurrently.
+type LeaderLock interface {
+	// Check returns an error if the connection holding the lock is lost
+	Check(ctx context.Context) error
+	// Release releases the lock and closes its connection
+	Release(ctx context.Context) error
+}
+
+// JobRepository records the runs of the scheduled jobs and elects the instance that runs them
+type JobRepository interface {
+	// TryLock takes the advisory lock of the job scheduler. Nil is returned if another instance holds the lock.
+	TryLock(ctx context.Context) (LeaderLock, error)
+	// CreateRun stores a new run. A running run is started immediately, a pending run waits for StartRun.
+	CreateRun(ctx context.Context, createData *entities.JobRunCreate) (*entities.JobRun, error)
+	// StartRun marks a pending run as running on the instance. ErrEntityNotFound is returned if the run is not pending.
+	StartRun(ctx context.Context, id int64, instance string) (*entities.JobRun, error)
+	// FinishRun stores the status, the error and the duration of a running run
+	FinishRun(ctx context.Context, id int64, status entities.JobRunStatus, runErr *string) (*entities.JobRun, error)
+	// AbortRunning fails all running runs with the reason and returns the number of aborted runs
+	AbortRunning(ctx context.Context, reason string) (int64, error)
+	GetRunByID(ctx context.Context, id int64) (*entities.JobRun, error)
+	// GetPendingRuns returns the pending runs, oldest first
+	GetPendingRuns(ctx context.Context) ([]*entities.JobRun, error)
+	// GetRuns returns the runs of a job, newest first, and the number of all runs of the job
+	GetRuns(ctx context.Context, job string) ([]*entities.JobRun, int64, error)
+	// GetLatestRuns returns the latest run of every job that has run
+	GetLatestRuns(ctx context.Context) ([]*entities.JobRun, error)
+	// GetLatestScheduledRun returns the latest run of the job that was started by the schedule
+	GetLatestScheduledRun(ctx context.Context, job string) (*entities.JobRun, error)
+}
+
+// WeatherProvider fetches the daily weather from an external weather service
+type WeatherProvider interface {
+	// GetDailyWeather returns the weather of the past days, today and the forecast days at the location, oldest first
+	GetDailyWeather(ctx context.Context, latitude, longitude float64, pastDays, forecastDays int) ([]*entities.WeatherDay, error)
+}
+
+// WeatherRepository stores the daily weather of the regions and the water balance of the tree clusters
+type WeatherRepository interface {
+	// GetLocations returns a point inside every region with a geometry
+	GetLocations(ctx context.Context) ([]*entities.WeatherLocation, error)
+	// UpsertDays stores the weather days of the region and overwrites stored days with the same date
+	UpsertDays(ctx context.Context, regionID int32, days []*entities.WeatherDay) error
+	// GetDays returns the weather days of the region between from and to, oldest first
+	GetDays(ctx context.Context, regionID int32, from, to time.Time) ([]*entities.WeatherDay, error)
+	// ReplaceWaterBalances replaces the water balance of the tree cluster in one transaction
+	ReplaceWaterBalances(ctx context.Context, treeClusterID int32, balances []*entities.WaterBalance) error
+	// GetWaterBalances returns the water balance of the tree cluster, oldest first
+	GetWaterBalances(ctx context.Context, treeClusterID int32) ([]*entities.WaterBalance, error)
+}
+
+// EvaluationRepository reads the facts of the watering plans and the daily tree status snapshots the evaluation is calculated from
+type EvaluationRepository interface {
+	// GetWateringPlanFacts returns one row per watering plan and tree cluster of the watering plans between from and to
+	GetWateringPlanFacts(ctx context.Context, from, to time.Time) ([]*entities.EvaluationWateringPlanFact, error)
+	// GetWateringPlanVehicles returns the vehicles assigned to the watering plans between from and to
+	GetWateringPlanVehicles(ctx context.Context, from, to time.Time) ([]*entities.EvaluationWateringPlanVehicle, error)
+	// GetWateringPlanUsers returns the users assigned to the watering plans between from and to
+	GetWateringPlanUsers(ctx context.Context, from, to time.Time) ([]*entities.EvaluationWateringPlanUser, error)
+	// CreateTreeStatusSnapshot counts the trees per tree cluster and watering status and replaces the snapshot of the date
+	CreateTreeStatusSnapshot(ctx context.Context, date time.Time) error
+	// GetTreeStatusSnapshots returns the tree status snapshots between from and to, oldest first
+	GetTreeStatusSnapshots(ctx context.Context, from, to time.Time) ([]*entities.TreeStatusSnapshot, error)
+}
+
+// NotificationRepository stores the notifications, their email and push deliveries, the notification
+// settings of the users and the push subscriptions of their browsers
+type NotificationRepository interface {
+	// Create stores a notification of a user together with the pending deliveries of the notification. If a notification
+	// of the same type was already created for the user and the event of EventID, it is returned and nothing is stored.
+	Create(ctx context.Context, notification *entities.Notification, deliveries []*entities.NotificationDelivery) (*entities.Notification, error)
+	// GetByUserID returns the in-app notifications of a user, newest first
+	GetByUserID(ctx context.Context, userID uuid.UUID, query entities.NotificationQuery) ([]*entities.Notification, int64, error)
+	// CountUnread returns the number of unread in-app notifications of a user
+	CountUnread(ctx context.Context, userID uuid.UUID) (int64, error)
+	// MarkRead marks a notification of the user as read. If the notification does not belong to the user ErrEntityNotFound is returned.
+	MarkRead(ctx context.Context, userID uuid.UUID, id int32, readAt time.Time) error
+	// MarkAllRead marks all notifications of the user as read
+	MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) error
+
+	// ClaimDueDeliveries locks the pending deliveries that are due at the given time for the lease and returns them with their notification. Concurrent callers never claim the same delivery.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int32) ([]*entities.NotificationDelivery, error)
+	// UpdateDelivery saves the result of a delivery attempt and releases the claim
+	UpdateDelivery(ctx context.Context, delivery *entities.NotificationDelivery) error
+
+	// GetSettings returns the notification settings of a user. If the user has not saved settings ErrEntityNotFound is returned.
+	GetSettings(ctx context.Context, userID uuid.UUID) (*entities.NotificationSettings, error)
+	// GetAllSettings returns the saved notification settings of all users
+	GetAllSettings(ctx context.Context) ([]*entities.NotificationSettings, error)
+	// SaveSettings creates or replaces the notification settings of a user
+	SaveSettings(ctx context.Context, settings *entities.NotificationSettings) error
+
+	// GetPushSubscriptions returns the push subscriptions of a user
+	GetPushSubscriptions(ctx context.Context, userID uuid.UUID) ([]*entities.PushSubscription, error)
+	// SavePushSubscription creates a push subscription or moves an existing subscription with the same endpoint to the user
+	SavePushSubscription(ctx context.Context, subscription *entities.PushSubscription) (*entities.PushSubscription, error)
+	// DeletePushSubscription deletes the push subscription of the endpoint
+	DeletePushSubscription(ctx context.Context, endpoint string) error
+}
+
+// MailSender sends emails over a mail server
+type MailSender interface {
+	Send(ctx context.Context, mail *entities.Mail) error
+}
+
+// PushSender sends Web Push messages to the push service of a browser
+type PushSender interface {
+	// Send encrypts the payload for the subscription and sends it. ErrPushSubscriptionGone is returned if the browser unsubscribed.
+	Send(ctx context.Context, subscription *entities.PushSubscription, payload []byte) error
+	// PublicKey returns the VAPID public key that the browser needs to subscribe
+	PublicKey() string
+}
+
+// AlertRepository stores the alert rules and the alerts that were raised by them
+type AlertRepository interface {
+	// GetAllRules returns all alert rules
+	GetAllRules(ctx context.Context) ([]*entities.AlertRule, error)
+	// GetRuleByID returns one alert rule by id
+	GetRuleByID(ctx context.Context, id int32) (*entities.AlertRule, error)
+	// CreateRule creates a new alert rule. It accepts a function that takes a rule that can be modified. Any changes made to the rule will be saved in the storage. If the function returns true, the rule will be created, otherwise it will not be created.
+	CreateRule(ctx context.Context, fn func(r *entities.AlertRule, repo AlertRepository) (bool, error)) (*entities.AlertRule, error)
+	// UpdateRule updates an alert rule by id. It takes the id of the rule to update and a function that takes a rule that can be modified. Any changes made to the rule will be saved in the storage. If the function returns true, the rule will be updated, otherwise it will not be updated.
+	UpdateRule(ctx context.Context, id int32, fn func(r *entities.AlertRule, repo AlertRepository) (bool, error)) error
+	// DeleteRule deletes an alert rule together with its alerts
+	DeleteRule(ctx context.Context, id int32) error
+
+	// GetAll returns the alerts matching the query, newest first
+	GetAll(ctx context.Context, query entities.AlertQuery) ([]*entities.Alert, int64, error)
+	// GetByID returns one alert by id
+	GetByID(ctx context.Context, id int32) (*entities.Alert, error)
+	// GetOpenByRuleID returns the pending and firing alerts of a rule
+	GetOpenByRuleID(ctx context.Context, ruleID int32) ([]*entities.Alert, error)
+	// Create stores a new alert, CreatedAt is the time the condition was first observed
+	Create(ctx context.Context, alert *entities.Alert) (*entities.Alert, error)
+	// Update saves the status, message and value of an alert
+	Update(ctx context.Context, alert *entities.Alert) error
+	// Acknowledge marks an alert as acknowledged. An alert that was acknowledged before keeps its first acknowledgement.
+	Acknowledge(ctx context.Context, id int32, acknowledgedBy string, acknowledgedAt time.Time) error
+	// Delete deletes an alert
+	Delete(ctx context.Context, id int32) error
+}
+
+// FeatureRepository reads the features of the OGC API Features collections and vector tiles. The collections
+// are backed by the geometry columns of trees, tree clusters, sensors and regions.
+type FeatureRepository interface {
+	// GetAll returns the features of a collection matching the query ordered by id together with the number of all matching features
+	GetAll(ctx context.Context, collection entities.OGCCollectionID, query *entities.OGCFeatureQuery) ([]*entities.OGCFeature, int64, error)
+	// GetByID returns one feature of a collection by id
+	GetByID(ctx context.Context, collection entities.OGCCollectionID, id string) (*entities.OGCFeature, error)
+	// GetExtent returns the spatial and temporal extent of a collection. The bounding box is nil if no feature has a geometry.
+	GetExtent(ctx context.Context, collection entities.OGCCollectionID) (*entities.OGCExtent, error)
+	// GetTile returns the features of a collection intersecting the tile encoded as Mapbox Vector Tile
+	// with the collection as layer name. The tile is empty if no feature intersects it.
+	GetTile(ctx context.Context, collection entities.OGCCollectionID, query *entities.TileQuery) ([]byte, error)
+}
+
 type RoutingRepository interface {
 	GenerateRoute(ctx context.Context, vehicle *entities.Vehicle, clusters []*entities.TreeCluster) (*entities.GeoJSON, error)
 	GenerateRawGpxRoute(ctx context.Context, vehicle *entities.Vehicle, clusters []*entities.TreeCluster) (io.ReadCloser, error)
@@ -235,15 +568,32 @@ type AuthRepository interface {
 }
 
 type Repository struct {
-	Auth         AuthRepository
-	Info         InfoRepository
-	Sensor       SensorRepository
-	Tree         TreeRepository
-	User         UserRepository
-	Vehicle      VehicleRepository
-	TreeCluster  TreeClusterRepository
-	Region       RegionRepository
-	WateringPlan WateringPlanRepository
-	Routing      RoutingRepository
-	GpxBucket    S3Repository
+	Auth                 AuthRepository
+	Info                 InfoRepository
+	Sensor               SensorRepository
+	Tree                 TreeRepository
+	User                 UserRepository
+	Vehicle              VehicleRepository
+	TreeCluster          TreeClusterRepository
+	Region               RegionRepository
+	WateringPlan         WateringPlanRepository
+	WateringPlanTemplate WateringPlanTemplateRepository
+	Routing              RoutingRepository
+	GpxBucket            S3Repository
+	ReportBucket         S3Repository
+	Plugin               PluginRepository
+	Webhook              WebhookRepository
+	APIKey               APIKeyRepository
+	TreeImport           TreeImportRepository
+	Feature              FeatureRepository
+	AuditLog             AuditLogRepository
+	Event                EventRepository
+	Job                  JobRepository
+	Weather              WeatherRepository
+	WeatherProvider      WeatherProvider
+	Evaluation           EvaluationRepository
+	Notification         NotificationRepository
+	MailSender           MailSender
+	PushSender           PushSender
+	Alert                AlertRepository
 }
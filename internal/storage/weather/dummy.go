@@ -0,0 +1,19 @@
+package weather
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// DummyWeatherProvider is used to disable the weather provider by configuration
+type DummyWeatherProvider struct{}
+
+func NewDummyWeatherProvider() *DummyWeatherProvider {
+	return &DummyWeatherProvider{}
+}
+
+func (p *DummyWeatherProvider) GetDailyWeather(_ context.Context, _, _ float64, _, _ int) ([]*entities.WeatherDay, error) {
+	return nil, storage.ErrWeatherServiceDisabled
+}
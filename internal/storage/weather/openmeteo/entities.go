@@ -0,0 +1,25 @@
+package openmeteo
+
+// ForecastResponse is the response of the forecast endpoint with daily values. The values of a day
+// are null if the weather model does not provide them.
+type ForecastResponse struct {
+	Latitude         float64       `json:"latitude"`
+	Longitude        float64       `json:"longitude"`
+	Timezone         string        `json:"timezone"`
+	UTCOffsetSeconds int           `json:"utc_offset_seconds"`
+	Daily            DailyResponse `json:"daily"`
+}
+
+type DailyResponse struct {
+	Time             []string   `json:"time"`
+	PrecipitationSum []*float64 `json:"precipitation_sum"`
+	TemperatureMin   []*float64 `json:"temperature_2m_min"`
+	TemperatureMax   []*float64 `json:"temperature_2m_max"`
+	TemperatureMean  []*float64 `json:"temperature_2m_mean"`
+	ET0              []*float64 `json:"et0_fao_evapotranspiration"`
+}
+
+type ErrorResponse struct {
+	Error  bool   `json:"error"`
+	Reason string `json:"reason"`
+}
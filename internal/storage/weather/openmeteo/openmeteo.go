@@ -0,0 +1,165 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// dailyVariables are the daily values requested from the forecast endpoint
+const dailyVariables = "precipitation_sum,temperature_2m_min,temperature_2m_max,temperature_2m_mean,et0_fao_evapotranspiration"
+
+var _ storage.WeatherProvider = (*OpenMeteoClient)(nil)
+
+type OpenMeteoClientConfig struct {
+	url    *url.URL
+	client *http.Client
+	models string
+}
+
+type OpenMeteoClientOption func(*OpenMeteoClientConfig)
+
+// OpenMeteoClient reads the daily weather from the forecast endpoint of the Open-Meteo api. The api
+// can be self-hosted and serves the models of the DWD among others.
+type OpenMeteoClient struct {
+	cfg OpenMeteoClientConfig
+}
+
+func WithClient(client *http.Client) OpenMeteoClientOption {
+	return func(cfg *OpenMeteoClientConfig) {
+		cfg.client = client
+	}
+}
+
+func WithHostURL(hostURL *url.URL) OpenMeteoClientOption {
+	return func(cfg *OpenMeteoClientConfig) {
+		cfg.url = hostURL
+	}
+}
+
+// WithModels selects the weather models, the api chooses the best models for the location if it is empty
+func WithModels(models string) OpenMeteoClientOption {
+	return func(cfg *OpenMeteoClientConfig) {
+		cfg.models = models
+	}
+}
+
+var defaultCfg = OpenMeteoClientConfig{
+	client: http.DefaultClient,
+}
+
+func NewOpenMeteoClient(opts ...OpenMeteoClientOption) *OpenMeteoClient {
+	cfg := defaultCfg
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &OpenMeteoClient{
+		cfg: cfg,
+	}
+}
+
+func (o *OpenMeteoClient) GetDailyWeather(ctx context.Context, latitude, longitude float64, pastDays, forecastDays int) ([]*entities.WeatherDay, error) {
+	log := logger.GetLogger(ctx)
+	path := fmt.Sprintf("%s/v1/forecast", o.cfg.url.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Add("latitude", strconv.FormatFloat(latitude, 'f', -1, 64))
+	query.Add("longitude", strconv.FormatFloat(longitude, 'f', -1, 64))
+	query.Add("daily", dailyVariables)
+	query.Add("timezone", "auto")
+	query.Add("past_days", strconv.Itoa(pastDays))
+	// the forecast days include today
+	query.Add("forecast_days", strconv.Itoa(forecastDays+1))
+	if o.cfg.models != "" {
+		query.Add("models", o.cfg.models)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := o.cfg.client.Do(req)
+	if err != nil {
+		log.Error("failed to send request to open-meteo service", "error", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Reason != "" {
+			log.Error("response from the open-meteo service with a not successful code", "status_code", resp.StatusCode, "reason", errResp.Reason)
+		} else {
+			log.Error("response from the open-meteo service with a not successful code", "status_code", resp.StatusCode)
+		}
+		return nil, errors.New("response not successful")
+	}
+
+	var response ForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		log.Error("failed to decode open-meteo response", "error", err)
+		return nil, err
+	}
+
+	return toWeatherDays(ctx, &response, time.Now())
+}
+
+// toWeatherDays converts the daily values. Days without precipitation or evapotranspiration are skipped, the
+// days from today on in the timezone of the location are marked as forecast.
+func toWeatherDays(ctx context.Context, resp *ForecastResponse, now time.Time) ([]*entities.WeatherDay, error) {
+	log := logger.GetLogger(ctx)
+	daily := resp.Daily
+	n := len(daily.Time)
+	if len(daily.PrecipitationSum) != n || len(daily.TemperatureMin) != n || len(daily.TemperatureMax) != n ||
+		len(daily.TemperatureMean) != n || len(daily.ET0) != n {
+		return nil, errors.New("daily values of the open-meteo response have different lengths")
+	}
+
+	local := now.In(time.FixedZone(resp.Timezone, resp.UTCOffsetSeconds))
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := make([]*entities.WeatherDay, 0, n)
+	for i, value := range daily.Time {
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date in open-meteo response: %w", err)
+		}
+
+		if daily.PrecipitationSum[i] == nil || daily.ET0[i] == nil {
+			log.Debug("open-meteo response has no precipitation or evapotranspiration for the day, day is skipped", "date", value)
+			continue
+		}
+
+		day := &entities.WeatherDay{
+			Date:          date,
+			Precipitation: *daily.PrecipitationSum[i],
+			ET0:           *daily.ET0[i],
+			Forecast:      !date.Before(today),
+		}
+		if daily.TemperatureMin[i] != nil {
+			day.TemperatureMin = *daily.TemperatureMin[i]
+		}
+		if daily.TemperatureMax[i] != nil {
+			day.TemperatureMax = *daily.TemperatureMax[i]
+		}
+		if daily.TemperatureMean[i] != nil {
+			day.TemperatureMean = *daily.TemperatureMean[i]
+		} else {
+			day.TemperatureMean = (day.TemperatureMin + day.TemperatureMax) / 2
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
@@ -0,0 +1,127 @@
+package openmeteo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFixtureServer(t *testing.T, status int, fixture string, handle func(r *http.Request)) *OpenMeteoClient {
+	t.Helper()
+	body, err := os.ReadFile(fixture)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handle != nil {
+			handle(r)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	hostURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	return NewOpenMeteoClient(WithHostURL(hostURL), WithModels("icon_seamless"))
+}
+
+func TestOpenMeteoClient_GetDailyWeather(t *testing.T) {
+	t.Run("should request daily values at the location", func(t *testing.T) {
+		// given
+		var query url.Values
+		var path string
+		client := newFixtureServer(t, http.StatusOK, "testdata/forecast.json", func(r *http.Request) {
+			path = r.URL.Path
+			query = r.URL.Query()
+		})
+
+		// when
+		got, err := client.GetDailyWeather(context.Background(), 54.78, 9.44, 30, 7)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, "/v1/forecast", path)
+		assert.Equal(t, "54.78", query.Get("latitude"))
+		assert.Equal(t, "9.44", query.Get("longitude"))
+		assert.Equal(t, dailyVariables, query.Get("daily"))
+		assert.Equal(t, "30", query.Get("past_days"))
+		assert.Equal(t, "8", query.Get("forecast_days"))
+		assert.Equal(t, "icon_seamless", query.Get("models"))
+	})
+
+	t.Run("should return error for unsuccessful response", func(t *testing.T) {
+		// given
+		client := newFixtureServer(t, http.StatusBadRequest, "testdata/error.json", nil)
+
+		// when
+		got, err := client.GetDailyWeather(context.Background(), 54.78, 9.44, 30, 7)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestToWeatherDays(t *testing.T) {
+	t.Run("should convert daily values and mark days from today on as forecast", func(t *testing.T) {
+		// given
+		resp := &ForecastResponse{
+			Timezone:         "Europe/Berlin",
+			UTCOffsetSeconds: 3600,
+			Daily: DailyResponse{
+				Time:             []string{"2025-03-01", "2025-03-02", "2025-03-03"},
+				PrecipitationSum: []*float64{ptr(4.2), nil, ptr(1.5)},
+				TemperatureMin:   []*float64{ptr(1.2), ptr(-0.4), ptr(3.1)},
+				TemperatureMax:   []*float64{ptr(8.4), ptr(10.2), ptr(12.5)},
+				TemperatureMean:  []*float64{ptr(4.9), ptr(4.6), nil},
+				ET0:              []*float64{ptr(0.8), ptr(1.3), ptr(1.6)},
+			},
+		}
+		// 23:30 UTC is already the next day in the timezone of the location
+		now := time.Date(2025, 3, 2, 23, 30, 0, 0, time.UTC)
+
+		// when
+		got, err := toWeatherDays(context.Background(), resp, now)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), got[0].Date)
+		assert.Equal(t, 4.2, got[0].Precipitation)
+		assert.Equal(t, 0.8, got[0].ET0)
+		assert.Equal(t, 4.9, got[0].TemperatureMean)
+		assert.False(t, got[0].Forecast)
+		assert.Equal(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), got[1].Date)
+		assert.InDelta(t, 7.8, got[1].TemperatureMean, 0.001)
+		assert.True(t, got[1].Forecast)
+	})
+
+	t.Run("should return error for daily values of different lengths", func(t *testing.T) {
+		// given
+		resp := &ForecastResponse{
+			Daily: DailyResponse{
+				Time:             []string{"2025-03-01"},
+				PrecipitationSum: []*float64{},
+			},
+		}
+
+		// when
+		got, err := toWeatherDays(context.Background(), resp, time.Now())
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,29 @@
+package openmeteo
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+func NewRepository(cfg *config.Config) (*storage.Repository, error) {
+	hostURL, err := url.Parse(cfg.Weather.Host)
+	if err != nil {
+		slog.Error("failed to setup weather provider", "error", err, "service", "open-meteo")
+		return nil, err
+	}
+
+	client := NewOpenMeteoClient(
+		WithHostURL(hostURL),
+		WithModels(cfg.Weather.Models),
+		WithClient(&http.Client{Timeout: cfg.Weather.Timeout}),
+	)
+
+	slog.Info("successfully initialized weather provider", "service", "open-meteo")
+	return &storage.Repository{
+		WeatherProvider: client,
+	}, nil
+}
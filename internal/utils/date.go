@@ -0,0 +1,10 @@
+package utils
+
+import "time"
+
+// ToDate returns the calendar day of t in its location as midnight UTC, the form in which the days
+// of weather data, plans and evaluations are compared. A point in time has to be converted to the
+// local timezone first, a date that is already midnight UTC is returned unchanged.
+func ToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
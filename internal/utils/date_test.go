@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDate(t *testing.T) {
+	t.Run("should return the day of the time in its location as midnight UTC", func(t *testing.T) {
+		berlin := time.FixedZone("CEST", 2*60*60)
+		got := ToDate(time.Date(2025, 4, 2, 1, 30, 0, 0, berlin))
+		assert.Equal(t, time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), got)
+	})
+
+	t.Run("should return a date unchanged", func(t *testing.T) {
+		date := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, date, ToDate(date))
+	})
+}
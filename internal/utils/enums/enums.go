@@ -4,4 +4,11 @@ type contextKey int
 
 const (
 	ContextKeyClaims contextKey = iota
+	ContextKeyAPIKey
+	// ContextKeyActor holds the id of the user or api key that made the request
+	ContextKeyActor
+	// ContextKeyIfMatch holds the entity versions of the If-Match header
+	ContextKeyIfMatch
+	// ContextKeyEventID holds the outbox id of the event that is handled
+	ContextKeyEventID
 )
@@ -0,0 +1,93 @@
+package etag
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+var ErrIfMatchInvalid = errors.New("if-match header must be * or a list of etags")
+
+// noVersion is not the version of any entity, it makes an If-Match header without a
+// strong etag fail the version check
+const noVersion int32 = -1
+
+// New returns the strong entity tag of the given entity version
+func New(version int32) string {
+	return strconv.Quote(strconv.FormatInt(int64(version), 10))
+}
+
+// ParseIfMatch returns the entity versions of an If-Match header. The header "*" matches
+// every version and returns nil. Weak etags never match, as If-Match uses the strong comparison.
+func ParseIfMatch(header string) ([]int32, error) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return nil, nil
+	}
+
+	tags := strings.Split(header, ",")
+	versions := make([]int32, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		weak, tag := strings.HasPrefix(tag, "W/"), strings.TrimPrefix(tag, "W/")
+
+		unquoted, err := strconv.Unquote(tag)
+		if err != nil {
+			return nil, ErrIfMatchInvalid
+		}
+
+		version, err := strconv.ParseInt(unquoted, 10, 32)
+		if err != nil {
+			return nil, ErrIfMatchInvalid
+		}
+
+		if !weak {
+			versions = append(versions, int32(version))
+		}
+	}
+
+	if len(versions) == 0 {
+		return []int32{noVersion}, nil
+	}
+
+	return versions, nil
+}
+
+// WithIfMatch returns a context that makes the repositories only update or delete the
+// entity if it has one of the given versions. The key is the same as the one set by the
+// if-match middleware.
+func WithIfMatch(ctx context.Context, versions ...int32) context.Context {
+	return context.WithValue(ctx, enums.ContextKeyIfMatch, versions)
+}
+
+// WithoutIfMatch returns a context without the If-Match header of the request, for writes
+// that follow the one requested by the client
+func WithoutIfMatch(ctx context.Context) context.Context {
+	if versions, _ := ctx.Value(enums.ContextKeyIfMatch).([]int32); len(versions) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, enums.ContextKeyIfMatch, []int32(nil))
+}
+
+// Check returns storage.ErrVersionMismatch if the request has an If-Match header that does
+// not contain the current version of the entity.
+func Check(ctx context.Context, version int32) error {
+	versions, ok := ctx.Value(enums.ContextKeyIfMatch).([]int32)
+	if !ok || len(versions) == 0 {
+		return nil
+	}
+
+	if !slices.Contains(versions, version) {
+		logger.GetLogger(ctx).Debug("entity version does not match the if-match header", "version", version, "if_match", versions)
+		return storage.ErrVersionMismatch
+	}
+
+	return nil
+}
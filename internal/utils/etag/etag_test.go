@@ -0,0 +1,92 @@
+package etag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETagUtil_New(t *testing.T) {
+	t.Run("should return quoted version", func(t *testing.T) {
+		assert.Equal(t, `"3"`, New(3))
+	})
+}
+
+func TestETagUtil_ParseIfMatch(t *testing.T) {
+	t.Run("should return single version", func(t *testing.T) {
+		versions, err := ParseIfMatch(`"3"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{3}, versions)
+	})
+
+	t.Run("should return list of versions", func(t *testing.T) {
+		versions, err := ParseIfMatch(`"3", "4" ,"5"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{3, 4, 5}, versions)
+	})
+
+	t.Run("should return nil on wildcard", func(t *testing.T) {
+		versions, err := ParseIfMatch(" * ")
+
+		assert.NoError(t, err)
+		assert.Nil(t, versions)
+	})
+
+	t.Run("should ignore weak etags", func(t *testing.T) {
+		versions, err := ParseIfMatch(`W/"3", "4"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{4}, versions)
+	})
+
+	t.Run("should not match any version with only weak etags", func(t *testing.T) {
+		versions, err := ParseIfMatch(`W/"3"`)
+
+		assert.NoError(t, err)
+		assert.ErrorIs(t, Check(WithIfMatch(context.Background(), versions...), 3), storage.ErrVersionMismatch)
+	})
+
+	t.Run("should return error on invalid header", func(t *testing.T) {
+		for _, header := range []string{"3", `W/3`, `W/"abc"`, `"abc"`, `"3", *`, ""} {
+			_, err := ParseIfMatch(header)
+
+			assert.ErrorIs(t, err, ErrIfMatchInvalid, header)
+		}
+	})
+}
+
+func TestETagUtil_Check(t *testing.T) {
+	t.Run("should pass without if-match", func(t *testing.T) {
+		assert.NoError(t, Check(context.Background(), 3))
+	})
+
+	t.Run("should pass on wildcard", func(t *testing.T) {
+		assert.NoError(t, Check(WithIfMatch(context.Background()), 3))
+	})
+
+	t.Run("should pass when version is contained", func(t *testing.T) {
+		assert.NoError(t, Check(WithIfMatch(context.Background(), 2, 3), 3))
+	})
+
+	t.Run("should return error when version does not match", func(t *testing.T) {
+		err := Check(WithIfMatch(context.Background(), 2), 3)
+
+		assert.ErrorIs(t, err, storage.ErrVersionMismatch)
+	})
+
+	t.Run("should pass after if-match is removed", func(t *testing.T) {
+		ctx := WithoutIfMatch(WithIfMatch(context.Background(), 2))
+
+		assert.NoError(t, Check(ctx, 3))
+	})
+
+	t.Run("should keep context without if-match", func(t *testing.T) {
+		ctx := context.Background()
+
+		assert.Equal(t, ctx, WithoutIfMatch(ctx))
+	})
+}
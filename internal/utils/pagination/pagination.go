@@ -26,6 +26,13 @@ func GetValues(ctx context.Context) (page, limit int32, err error) {
 	return page, limit, nil
 }
 
+// WithValues returns a context that makes the repositories return the given page.
+// The keys are the same as the ones set by the pagination middleware.
+func WithValues(ctx context.Context, page, limit int32) context.Context {
+	ctx = context.WithValue(ctx, "page", page)    //nolint:staticcheck // the middleware stores the values as fiber locals
+	return context.WithValue(ctx, "limit", limit) //nolint:staticcheck // the middleware stores the values as fiber locals
+}
+
 func Create(ctx context.Context, totalCount int64) *entities.Pagination {
 	page, pageOk := ctx.Value("page").(int32)
 	limit, limitOk := ctx.Value("limit").(int32)
@@ -59,6 +59,18 @@ func TestPaginationUtil_GetValues(t *testing.T) {
 	})
 }
 
+func TestPaginationUtil_WithValues(t *testing.T) {
+	t.Run("should return values set in context", func(t *testing.T) {
+		ctx := WithValues(context.Background(), int32(3), int32(500))
+
+		page, limit, err := GetValues(ctx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int32(3), page)
+		assert.Equal(t, int32(500), limit)
+	})
+}
+
 func TestPaginationUtil_Create(t *testing.T) {
 	t.Run("should return a valid pagination", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "page", int32(2))
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/twpayne/go-geos"
 )
 
 func PgTimestampToTime(t pgtype.Timestamp) time.Time {
@@ -31,6 +32,42 @@ func TimeToPgTimestamp(t *time.Time) pgtype.Timestamp {
 	}
 }
 
+func GeomToWKT(g *geos.Geom) string {
+	if g == nil {
+		return ""
+	}
+
+	return g.ToWKT()
+}
+
+// WKTToGeoJSON returns the GeoJSON of a WKT geometry or nil if the geometry is empty or invalid
+func WKTToGeoJSON(wkt string) json.RawMessage {
+	if wkt == "" {
+		return nil
+	}
+
+	g, err := geos.NewGeomFromWKT(wkt)
+	if err != nil {
+		return nil
+	}
+
+	return json.RawMessage(g.ToGeoJSON(0))
+}
+
+// GeoJSONToWKT returns the WKT of a GeoJSON geometry or an empty string if no geometry is given
+func GeoJSONToWKT(geometry json.RawMessage) (string, error) {
+	if len(geometry) == 0 || string(geometry) == "null" {
+		return "", nil
+	}
+
+	g, err := geos.NewGeomFromGeoJSON(string(geometry))
+	if err != nil {
+		return "", err
+	}
+
+	return g.ToWKT(), nil
+}
+
 func PgDateToTime(pgDate pgtype.Date) time.Time {
 	if pgDate.Valid {
 		return pgDate.Time
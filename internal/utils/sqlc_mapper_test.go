@@ -1,11 +1,13 @@
 package utils
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/twpayne/go-geos"
 )
 
 func TestPgTimestampToTime(t *testing.T) {
@@ -64,6 +66,57 @@ func TestTimeToPgTimestamp(t *testing.T) {
 	})
 }
 
+func TestGeomToWKT(t *testing.T) {
+	t.Run("should return WKT of geometry", func(t *testing.T) {
+		g, err := geos.NewGeomFromWKT("POINT (9 54)")
+		assert.NoError(t, err)
+
+		assert.Equal(t, g.ToWKT(), GeomToWKT(g))
+	})
+
+	t.Run("should return empty string for nil geometry", func(t *testing.T) {
+		assert.Equal(t, "", GeomToWKT(nil))
+	})
+}
+
+func TestWKTToGeoJSON(t *testing.T) {
+	t.Run("should return GeoJSON of WKT geometry", func(t *testing.T) {
+		assert.JSONEq(t, `{"type":"Point","coordinates":[9.0,54.0]}`, string(WKTToGeoJSON("POINT (9 54)")))
+	})
+
+	t.Run("should return nil for empty or invalid WKT", func(t *testing.T) {
+		assert.Nil(t, WKTToGeoJSON(""))
+		assert.Nil(t, WKTToGeoJSON("POINT (9"))
+	})
+}
+
+func TestGeoJSONToWKT(t *testing.T) {
+	t.Run("should return WKT of GeoJSON geometry", func(t *testing.T) {
+		wkt, err := GeoJSONToWKT(json.RawMessage(`{"type":"Point","coordinates":[9,54]}`))
+		assert.NoError(t, err)
+
+		g, err := geos.NewGeomFromWKT(wkt)
+		assert.NoError(t, err)
+		assert.Equal(t, 9.0, g.X())
+		assert.Equal(t, 54.0, g.Y())
+	})
+
+	t.Run("should return empty string without geometry", func(t *testing.T) {
+		wkt, err := GeoJSONToWKT(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "", wkt)
+
+		wkt, err = GeoJSONToWKT(json.RawMessage("null"))
+		assert.NoError(t, err)
+		assert.Equal(t, "", wkt)
+	})
+
+	t.Run("should return error for invalid GeoJSON", func(t *testing.T) {
+		_, err := GeoJSONToWKT(json.RawMessage(`{"type":"Point"}`))
+		assert.Error(t, err)
+	})
+}
+
 func TestPgDateToTime(t *testing.T) {
 	t.Run("should return time from pgtype.Date", func(t *testing.T) {
 		date := pgtype.Date{Time: time.Now(), Valid: true}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// EventBus delivers published events to the subscribers of the event type.
+//
+// The EventManager delivers the events in memory of one instance, the OutboxEventBus stores them
+// in the database and shares the subscriptions between all instances of the backend. Both retry
+// a failed event with backoff and dead-letter it after the last attempt, so the events are
+// delivered at least once and a subscriber must handle duplicates.
+type EventBus interface {
+	// Publish sends an event to all subscribers of its type
+	Publish(ctx context.Context, event entities.Event) error
+	// WithTx runs fn. A durable bus commits the events published with the context passed to fn together with the changes of fn
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// RunSubscription forwards the events of the subscribed type to the subscriber until the context is canceled
+	RunSubscription(ctx context.Context, sub Subscriber) error
+	// Run processes the published events until the context is canceled
+	Run(ctx context.Context)
+}
+
+// Subscriber defines the interface for handling events of a specific type.
+type Subscriber interface {
+	// HandleEvent processes the received event.
+	HandleEvent(ctx context.Context, event entities.Event) error
+
+	// EventType returns the type of events this subscriber is interested in.
+	EventType() entities.EventType
+}
+
+// BroadcastSubscriber is a Subscriber that has to receive the events on every instance of the backend,
+// for example to forward them to the clients connected to the instance. The OutboxEventBus delivers
+// the events to such subscribers without a durable subscription. Events published while the instance
+// is not running are not delivered and failed events are neither retried nor dead-lettered.
+type BroadcastSubscriber interface {
+	Subscriber
+
+	// Broadcast reports whether the events are delivered on every instance
+	Broadcast() bool
+}
+
+// SubscriptionName returns the name of the durable subscription of a subscriber. The name is derived
+// from the type of the subscriber and its event type, renaming the subscriber starts a new subscription.
+func SubscriptionName(sub Subscriber) string {
+	return fmt.Sprintf("%s/%s", strings.TrimPrefix(fmt.Sprintf("%T", sub), "*"), sub.EventType())
+}
+
+// RetryPolicy defines how often a failed event is handled again before it is dead-lettered
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one
+	MaxAttempts int32
+	// Backoff is the delay after the first failed attempt, it doubles with every further attempt
+	Backoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+// NextBackoff returns the delay after the given number of failed attempts
+func (p RetryPolicy) NextBackoff(attempts int32) time.Duration {
+	backoff := p.Backoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"encoding/json"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// eventDecoders restore the events stored by the OutboxEventBus. Every event type that is published
+// on the OutboxEventBus needs a decoder.
+var eventDecoders = map[entities.EventType]func([]byte) (entities.Event, error){
+	entities.EventTypeUpdateTree:         decodeEvent(entities.NewEventUpdateTree(nil, nil, nil)),
+	entities.EventTypeCreateTree:         decodeEvent(entities.NewEventCreateTree(nil, nil)),
+	entities.EventTypeDeleteTree:         decodeEvent(entities.NewEventDeleteTree(nil)),
+	entities.EventTypeUpdateTreeCluster:  decodeEvent(entities.NewEventUpdateTreeCluster(nil, nil)),
+	entities.EventTypeNewSensorData:      decodeEvent(entities.NewEventSensorData(nil)),
+	entities.EventTypeUpdateWateringPlan: decodeEvent(entities.NewEventUpdateWateringPlan(nil, nil)),
+	entities.EventTypeImportTrees:        decodeEvent(entities.NewEventImportTrees(0, nil, nil)),
+	entities.EventTypeCreateWateringPlan: decodeEvent(entities.NewEventCreateWateringPlan(nil)),
+	entities.EventTypeUpdateSensor:       decodeEvent(entities.NewEventUpdateSensor(nil, nil)),
+	entities.EventTypeFireAlert:          decodeEvent(entities.NewEventFireAlert(nil, nil)),
+}
+
+// decodeEvent returns a decoder that unmarshals the payload into a copy of the empty event. The
+// empty event is created by the constructor of the event, because the event type is unexported.
+func decodeEvent[T entities.Event](empty T) func([]byte) (entities.Event, error) {
+	return func(payload []byte) (entities.Event, error) {
+		event := empty
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+// EncodeEvent returns the JSON encoding of an event
+func EncodeEvent(event entities.Event) ([]byte, error) {
+	if _, ok := eventDecoders[event.Type()]; !ok {
+		return nil, ErrUnknownEventTypeErr
+	}
+	return json.Marshal(event)
+}
+
+// DecodeEvent restores an event of the given type from its JSON encoding
+func DecodeEvent(eventType entities.EventType, payload []byte) (entities.Event, error) {
+	decode, ok := eventDecoders[eventType]
+	if !ok {
+		return nil, ErrUnknownEventTypeErr
+	}
+	return decode(payload)
+}
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodec_EncodeDecodeEvent(t *testing.T) {
+	t.Run("should restore event with its type", func(t *testing.T) {
+		// given
+		prev := &entities.Tree{ID: 1, Number: "T-1", TreeCluster: &entities.TreeCluster{ID: 2}}
+		next := &entities.Tree{ID: 1, Number: "T-2", LastWatered: utils.P(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))}
+		event := entities.NewEventUpdateTree(prev, next, nil)
+
+		// when
+		payload, errEncode := EncodeEvent(event)
+		got, errDecode := DecodeEvent(entities.EventTypeUpdateTree, payload)
+
+		// then
+		assert.NoError(t, errEncode)
+		assert.NoError(t, errDecode)
+		assert.Equal(t, event, got)
+		assert.Equal(t, entities.EventTypeUpdateTree, got.Type())
+	})
+
+	t.Run("should restore every event type", func(t *testing.T) {
+		for eventType := range eventDecoders {
+			// when
+			got, err := DecodeEvent(eventType, []byte(`{}`))
+
+			// then
+			assert.NoError(t, err, eventType)
+			assert.Equal(t, eventType, got.Type())
+		}
+	})
+
+	t.Run("should return error on unknown event type", func(t *testing.T) {
+		// when
+		_, errEncode := EncodeEvent(TestEvent{eventType: EventTypeTest})
+		_, errDecode := DecodeEvent(EventTypeTest, []byte(`{}`))
+
+		// then
+		assert.ErrorIs(t, errEncode, ErrUnknownEventTypeErr)
+		assert.ErrorIs(t, errDecode, ErrUnknownEventTypeErr)
+	})
+
+	t.Run("should return error on invalid payload", func(t *testing.T) {
+		// when
+		_, err := DecodeEvent(entities.EventTypeDeleteTree, []byte(`not json`))
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -5,9 +5,12 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 )
 
 var (
@@ -17,22 +20,31 @@ var (
 
 const eventChSize = 100
 
-// Subscriber defines the interface for handling events of a specific type.
-type Subscriber interface {
-	// HandleEvent processes the received event.
-	HandleEvent(ctx context.Context, event entities.Event) error
+// maxDeadLetters is the number of dead letters the EventManager keeps in memory
+const maxDeadLetters = 100
 
-	// EventType returns the type of events this subscriber is interested in.
-	EventType() entities.EventType
-}
+var _ EventBus = (*EventManager)(nil)
 
-// EventManager manages event publication and subscription.
+// EventManager manages event publication and subscription in memory of one instance.
 type EventManager struct {
 	eventCh    chan entities.Event
 	subscriber map[entities.EventType]map[int]chan<- entities.Event
 	nextID     int
 	eventTypes map[entities.EventType]struct{}
 	rwMutex    sync.RWMutex
+
+	retry       RetryPolicy
+	deadLetters []*DeadLetter
+	deadMutex   sync.Mutex
+}
+
+// DeadLetter is an event a subscriber of the EventManager failed to handle after all attempts
+type DeadLetter struct {
+	Subscription string
+	Event        entities.Event
+	Attempts     int32
+	Error        error
+	CreatedAt    time.Time
 }
 
 // NewEventManager creates a new EventManager for the given event types.
@@ -69,6 +81,7 @@ func NewEventManager(eventTypes ...entities.EventType) *EventManager {
 		subscriber: subscriber,
 		nextID:     0,
 		eventTypes: eventTypeMap,
+		retry:      DefaultRetryPolicy,
 	}
 }
 
@@ -90,7 +103,10 @@ func NewEventManager(eventTypes ...entities.EventType) *EventManager {
 //		log.Fatalf("Failed to publish event: %v", err)
 //	}
 func (e *EventManager) Publish(ctx context.Context, event entities.Event) error {
-	if _, ok := e.eventTypes[event.Type()]; !ok {
+	e.rwMutex.RLock()
+	_, ok := e.eventTypes[event.Type()]
+	e.rwMutex.RUnlock()
+	if !ok {
 		return ErrUnknownEventTypeErr
 	}
 
@@ -102,6 +118,12 @@ func (e *EventManager) Publish(ctx context.Context, event entities.Event) error
 	}
 }
 
+// WithTx runs fn. The EventManager keeps the events in memory and has no transaction, the events are sent
+// when they are published.
+func (e *EventManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 // Subscribe registers a new subscription for the specified event type.
 //
 // A subscription allows a caller to receive events of a specific type via a dedicated channel.
@@ -122,16 +144,16 @@ func (e *EventManager) Publish(ctx context.Context, event entities.Event) error
 //		log.Fatalf("Failed to subscribe: %v", err)
 //	}
 func (e *EventManager) Subscribe(eventType entities.EventType) (id int, ch <-chan entities.Event, err error) {
+	e.rwMutex.Lock()
+	defer e.rwMutex.Unlock()
+
 	if _, ok := e.eventTypes[eventType]; !ok {
 		return -1, nil, ErrUnknownEventTypeErr
 	}
 
-	e.rwMutex.Lock()
-	defer e.rwMutex.Unlock()
-
 	channel := make(chan entities.Event)
 	subID := e.nextID
-	e.subscriber[eventType][id] = channel
+	e.subscriber[eventType][subID] = channel
 	e.nextID++
 
 	slog.Info("start to subscribe an event", "event_type", eventType, "event_id", subID)
@@ -157,13 +179,13 @@ func (e *EventManager) Subscribe(eventType entities.EventType) (id int, ch <-cha
 //		log.Printf("Failed to unsubscribe: %v", err)
 //	}
 func (e *EventManager) Unsubscribe(eventType entities.EventType, id int) error {
+	e.rwMutex.Lock()
+	defer e.rwMutex.Unlock()
+
 	if _, ok := e.eventTypes[eventType]; !ok {
 		return ErrUnknownEventTypeErr
 	}
 
-	e.rwMutex.Lock()
-	defer e.rwMutex.Unlock()
-
 	slog.Info("unsubscribe to an event", "event_type", eventType, "event_id", id)
 	return e.unsubscribe(eventType, id)
 }
@@ -246,18 +268,33 @@ func (e *EventManager) Run(ctx context.Context) {
 	}
 }
 
+// SetRetryPolicy sets how often a subscriber handles a failed event again before it is dead-lettered
+func (e *EventManager) SetRetryPolicy(retry RetryPolicy) {
+	e.retry = retry
+}
+
+// DeadLetters returns the most recent events the subscribers failed to handle after all attempts
+func (e *EventManager) DeadLetters() []*DeadLetter {
+	e.deadMutex.Lock()
+	defer e.deadMutex.Unlock()
+	return slices.Clone(e.deadLetters)
+}
+
 // RunSubscription manages a single subscription, forwarding events to the Subscriber.
 //
 // This is a blocking method and should be run in a separate goroutine.
 // It allows a Subscriber implementation to process events in its own context.
-// It ensures that the subscription is cleaned up when the context is canceled or an error occurs.
+// The received events are queued per subscription, so a slow subscriber does not block the delivery
+// to other subscribers. A failed event is retried with backoff and dead-lettered after the last
+// attempt, the subscription keeps running.
+// It ensures that the subscription is cleaned up when the context is canceled.
 //
 // Parameters:
 // - ctx: A context to control the lifecycle of the subscription.
 // - sub: The Subscriber implementation to handle events of a specific type.
 //
 // Returns:
-// - An error if event handling fails or the subscription encounters an issue.
+// - An error if the subscription encounters an issue.
 //
 // Example usage:
 //
@@ -276,17 +313,123 @@ func (e *EventManager) RunSubscription(ctx context.Context, sub Subscriber) erro
 	defer func() {
 		_ = e.Unsubscribe(sub.EventType(), id)
 	}()
+
+	queue := newEventQueue()
+	go func() {
+		for v := range ch {
+			queue.push(v)
+		}
+		queue.close()
+	}()
+
+	name := SubscriptionName(sub)
+	for {
+		v, ok := queue.pop(ctx)
+		if !ok {
+			return nil
+		}
+		e.handle(ctx, name, sub, v)
+	}
+}
+
+// handle passes the event to the subscriber until it succeeds or the last attempt has failed
+func (e *EventManager) handle(ctx context.Context, name string, sub Subscriber, event entities.Event) {
+	log := logger.GetLogger(ctx)
+	for attempts := int32(1); ; attempts++ {
+		err := sub.HandleEvent(ctx, event)
+		if err == nil {
+			return
+		}
+
+		if attempts >= e.retry.MaxAttempts {
+			log.Error("failed to handle event, event is dead-lettered", "error", err, "subscription", name, "event_type", event.Type(), "attempts", attempts)
+			e.deadLetter(&DeadLetter{
+				Subscription: name,
+				Event:        event,
+				Attempts:     attempts,
+				Error:        err,
+				CreatedAt:    time.Now(),
+			})
+			return
+		}
+
+		backoff := e.retry.NextBackoff(attempts)
+		log.Warn("failed to handle event, retry later", "error", err, "subscription", name, "event_type", event.Type(), "attempts", attempts, "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (e *EventManager) deadLetter(letter *DeadLetter) {
+	e.deadMutex.Lock()
+	defer e.deadMutex.Unlock()
+	e.deadLetters = append(e.deadLetters, letter)
+	if len(e.deadLetters) > maxDeadLetters {
+		e.deadLetters = e.deadLetters[len(e.deadLetters)-maxDeadLetters:]
+	}
+}
+
+// eventQueue is an unbounded queue of the events of one subscription
+type eventQueue struct {
+	mutex  sync.Mutex
+	events []entities.Event
+	closed bool
+	signal chan struct{}
+}
+
+func newEventQueue() *eventQueue {
+	return &eventQueue{
+		signal: make(chan struct{}, 1),
+	}
+}
+
+func (q *eventQueue) push(event entities.Event) {
+	q.mutex.Lock()
+	q.events = append(q.events, event)
+	q.mutex.Unlock()
+	q.notify()
+}
+
+func (q *eventQueue) close() {
+	q.mutex.Lock()
+	q.closed = true
+	q.mutex.Unlock()
+	q.notify()
+}
+
+func (q *eventQueue) notify() {
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the next event and blocks until there is one. It returns false if the context is
+// canceled or the queue is closed and empty.
+func (q *eventQueue) pop(ctx context.Context) (entities.Event, bool) {
 	for {
+		q.mutex.Lock()
+		if len(q.events) > 0 {
+			event := q.events[0]
+			q.events[0] = nil
+			q.events = q.events[1:]
+			q.mutex.Unlock()
+			return event, true
+		}
+		closed := q.closed
+		q.mutex.Unlock()
+
+		if closed {
+			return nil, false
+		}
+
 		select {
 		case <-ctx.Done():
-			return nil
-		case v, ok := <-ch:
-			if !ok {
-				return nil
-			}
-			if err := sub.HandleEvent(ctx, v); err != nil {
-				return err
-			}
+			return nil, false
+		case <-q.signal:
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -138,6 +139,40 @@ func TestEventManager_Run(t *testing.T) {
 
 		_ = em.Unsubscribe(EventTypeTest, id)
 	})
+
+	t.Run("should deliver events to every subscriber of the event type", func(t *testing.T) {
+		// given
+		em := NewEventManager(EventTypeTest)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go em.Run(ctx)
+
+		id1, ch1, _ := em.Subscribe(EventTypeTest)
+		id2, ch2, _ := em.Subscribe(EventTypeTest)
+		event := TestEvent{eventType: EventTypeTest}
+
+		// when
+		_ = em.Publish(context.Background(), event)
+
+		// then
+		assert.NotEqual(t, id1, id2)
+		// the subscribers are served in any order, so both channels are read at once
+		for range 2 {
+			select {
+			case receivedEvent := <-ch1:
+				assert.Equal(t, event, receivedEvent)
+				ch1 = nil
+			case receivedEvent := <-ch2:
+				assert.Equal(t, event, receivedEvent)
+				ch2 = nil
+			case <-time.After(1 * time.Second):
+				t.Fatal("event was not received")
+			}
+		}
+
+		_ = em.Unsubscribe(EventTypeTest, id1)
+		_ = em.Unsubscribe(EventTypeTest, id2)
+	})
 }
 
 func TestEventManager_RunSubscription(t *testing.T) {
@@ -246,3 +281,116 @@ func TestEventManager_PublishEventsInLoop(t *testing.T) {
 		<-ctx.Done()
 	})
 }
+
+type FailingSubscriber struct {
+	failures int
+	calls    atomic.Int32
+}
+
+func (s *FailingSubscriber) EventType() entities.EventType {
+	return EventTypeTest
+}
+
+func (s *FailingSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	if int(s.calls.Add(1)) <= s.failures {
+		return errors.New("failed to handle event")
+	}
+	return nil
+}
+
+type BlockingSubscriber struct {
+	release chan struct{}
+}
+
+func (s *BlockingSubscriber) EventType() entities.EventType {
+	return EventTypeTest
+}
+
+func (s *BlockingSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func TestEventManager_RunSubscriptionRetry(t *testing.T) {
+	retry := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	t.Run("should retry failed events", func(t *testing.T) {
+		// given
+		em := NewEventManager(EventTypeTest)
+		em.SetRetryPolicy(retry)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go em.Run(ctx)
+
+		subscriber := &FailingSubscriber{failures: 2}
+		go func() {
+			_ = em.RunSubscription(ctx, subscriber)
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		// when
+		_ = em.Publish(context.Background(), TestEvent{eventType: EventTypeTest})
+
+		// then
+		assert.Eventually(t, func() bool { return subscriber.calls.Load() == 3 }, time.Second, time.Millisecond)
+		assert.Empty(t, em.DeadLetters())
+	})
+
+	t.Run("should dead-letter event after the last attempt and keep the subscription running", func(t *testing.T) {
+		// given
+		em := NewEventManager(EventTypeTest)
+		em.SetRetryPolicy(retry)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go em.Run(ctx)
+
+		subscriber := &FailingSubscriber{failures: 3}
+		go func() {
+			_ = em.RunSubscription(ctx, subscriber)
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		// when
+		_ = em.Publish(context.Background(), TestEvent{eventType: EventTypeTest})
+		assert.Eventually(t, func() bool { return len(em.DeadLetters()) == 1 }, time.Second, time.Millisecond)
+		_ = em.Publish(context.Background(), TestEvent{eventType: EventTypeTest})
+
+		// then
+		assert.Eventually(t, func() bool { return subscriber.calls.Load() == 4 }, time.Second, time.Millisecond)
+		deadLetters := em.DeadLetters()
+		assert.Len(t, deadLetters, 1)
+		assert.Equal(t, "worker.FailingSubscriber/test event", deadLetters[0].Subscription)
+		assert.Equal(t, int32(3), deadLetters[0].Attempts)
+		assert.EqualError(t, deadLetters[0].Error, "failed to handle event")
+	})
+
+	t.Run("should not block other subscribers by a slow subscriber", func(t *testing.T) {
+		// given
+		em := NewEventManager(EventTypeTest)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go em.Run(ctx)
+
+		slow := &BlockingSubscriber{release: make(chan struct{})}
+		defer close(slow.release)
+		fast := &FailingSubscriber{}
+		go func() {
+			_ = em.RunSubscription(ctx, slow)
+		}()
+		go func() {
+			_ = em.RunSubscription(ctx, fast)
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		// when
+		for i := 0; i < 10; i++ {
+			_ = em.Publish(context.Background(), TestEvent{eventType: EventTypeTest})
+		}
+
+		// then
+		assert.Eventually(t, func() bool { return fast.calls.Load() == 10 }, time.Second, time.Millisecond)
+	})
+}
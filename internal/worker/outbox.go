@@ -0,0 +1,364 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+var _ EventBus = (*OutboxEventBus)(nil)
+
+// broadcastBatchSize is the number of events a broadcast subscriber reads from the outbox at once
+const broadcastBatchSize = 100
+
+// OutboxEventBus stores the published events in the outbox of the database and delivers them to
+// durable subscriptions. Every subscription keeps the position of the last handled event, so events
+// published while no instance is running are delivered after the restart. A subscription is claimed
+// by one instance at a time, the subscriptions of several instances share the work. An event is
+// delivered once every transaction that started before it was appended has ended, a long running
+// transaction delays the delivery.
+//
+// The services publish inside WithTx, which runs the change and the publish in one transaction of the
+// database. The event is committed together with the change, an event is neither lost when the
+// instance stops after the change nor delivered for a change that has been rolled back.
+//
+// The instances are woken up by notifications of the database and poll the subscriptions in case
+// a notification is lost. If an instance stops while handling an event, the event is handled again
+// by another instance once the lease of the claim has passed.
+type OutboxEventBus struct {
+	repo       storage.EventRepository
+	eventTypes map[entities.EventType]struct{}
+	owner      string
+
+	retry         RetryPolicy
+	lease         time.Duration
+	pollInterval  time.Duration
+	retention     time.Duration
+	cleanupPeriod time.Duration
+
+	wakeMutex sync.Mutex
+	wake      map[entities.EventType]map[chan struct{}]struct{}
+}
+
+type OutboxEventBusOption func(*OutboxEventBus)
+
+// WithRetryPolicy sets how often a failed event is handled again before it is dead-lettered
+func WithRetryPolicy(retry RetryPolicy) OutboxEventBusOption {
+	return func(e *OutboxEventBus) {
+		e.retry = retry
+	}
+}
+
+// WithPollInterval sets how often the subscriptions look for pending events without a notification
+func WithPollInterval(interval time.Duration) OutboxEventBusOption {
+	return func(e *OutboxEventBus) {
+		e.pollInterval = interval
+	}
+}
+
+// WithLease sets how long a subscription is claimed while one event is handled. It must be longer than
+// the slowest subscriber takes, otherwise another instance handles the event again.
+func WithLease(lease time.Duration) OutboxEventBusOption {
+	return func(e *OutboxEventBus) {
+		e.lease = lease
+	}
+}
+
+// WithRetention sets how long handled events are kept in the outbox
+func WithRetention(retention time.Duration) OutboxEventBusOption {
+	return func(e *OutboxEventBus) {
+		e.retention = retention
+	}
+}
+
+// NewOutboxEventBus creates a new OutboxEventBus for the given event types. Every event type needs a
+// decoder, see DecodeEvent.
+func NewOutboxEventBus(repo storage.EventRepository, eventTypes []entities.EventType, opts ...OutboxEventBusOption) *OutboxEventBus {
+	eventTypeMap := make(map[entities.EventType]struct{})
+	for _, eventType := range eventTypes {
+		eventTypeMap[eventType] = struct{}{}
+	}
+
+	e := &OutboxEventBus{
+		repo:          repo,
+		eventTypes:    eventTypeMap,
+		owner:         newOwner(),
+		retry:         DefaultRetryPolicy,
+		lease:         5 * time.Minute,
+		pollInterval:  10 * time.Second,
+		retention:     7 * 24 * time.Hour,
+		cleanupPeriod: time.Hour,
+		wake:          make(map[entities.EventType]map[chan struct{}]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// newOwner returns a name of this instance that is unique between restarts
+func newOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+}
+
+// Publish stores the event in the outbox. The event is delivered to the subscribers of all instances.
+// Inside WithTx the event is stored in the transaction of the caller and delivered once it is committed.
+func (e *OutboxEventBus) Publish(ctx context.Context, event entities.Event) error {
+	if _, ok := e.eventTypes[event.Type()]; !ok {
+		return ErrUnknownEventTypeErr
+	}
+
+	payload, err := EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.repo.Append(ctx, event.Type(), payload)
+	return err
+}
+
+// WithTx runs fn in a transaction of the outbox. The repositories called with the context passed to fn and
+// the events published with it are committed together.
+func (e *OutboxEventBus) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return e.repo.WithTx(ctx, fn)
+}
+
+// Run listens for the notifications of appended events and deletes the handled events after the
+// retention. This is a blocking method and should be run in a separate goroutine.
+func (e *OutboxEventBus) Run(ctx context.Context) {
+	log := logger.GetLogger(ctx)
+	log.Info("starting outbox event bus", "owner", e.owner)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		e.runCleanup(ctx)
+	}()
+	defer wg.Wait()
+
+	for {
+		err := e.repo.Listen(ctx, e.notify)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Error("stopped listening for events, reconnect later", "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.pollInterval):
+		}
+	}
+}
+
+func (e *OutboxEventBus) runCleanup(ctx context.Context) {
+	log := logger.GetLogger(ctx)
+	ticker := time.NewTicker(e.cleanupPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := e.repo.DeleteProcessed(ctx, time.Now().Add(-e.retention)); err != nil {
+				log.Error("failed to delete processed events", "error", err)
+			}
+		}
+	}
+}
+
+// notify wakes up the subscriptions of the event type
+func (e *OutboxEventBus) notify(eventType entities.EventType) {
+	e.wakeMutex.Lock()
+	defer e.wakeMutex.Unlock()
+
+	for ch := range e.wake[eventType] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *OutboxEventBus) register(eventType entities.EventType) chan struct{} {
+	e.wakeMutex.Lock()
+	defer e.wakeMutex.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if e.wake[eventType] == nil {
+		e.wake[eventType] = make(map[chan struct{}]struct{})
+	}
+	e.wake[eventType][ch] = struct{}{}
+	return ch
+}
+
+func (e *OutboxEventBus) unregister(eventType entities.EventType, ch chan struct{}) {
+	e.wakeMutex.Lock()
+	defer e.wakeMutex.Unlock()
+
+	delete(e.wake[eventType], ch)
+}
+
+// RunSubscription creates the durable subscription of the subscriber and handles its pending events
+// until the context is canceled. A failed event is retried with backoff and dead-lettered after the
+// last attempt. This is a blocking method and should be run in a separate goroutine.
+func (e *OutboxEventBus) RunSubscription(ctx context.Context, sub Subscriber) error {
+	if _, ok := e.eventTypes[sub.EventType()]; !ok {
+		return ErrUnknownEventTypeErr
+	}
+
+	if b, ok := sub.(BroadcastSubscriber); ok && b.Broadcast() {
+		return e.runBroadcast(ctx, sub)
+	}
+
+	name := SubscriptionName(sub)
+	if err := e.repo.Subscribe(ctx, name, sub.EventType()); err != nil {
+		return err
+	}
+
+	wake := e.register(sub.EventType())
+	defer e.unregister(sub.EventType(), wake)
+
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	logger.GetLogger(ctx).Info("start to subscribe an event", "event_type", sub.EventType(), "subscription", name)
+	for {
+		e.consume(ctx, name, sub)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runBroadcast delivers the events published after the start to the subscriber of this instance.
+// The offset is kept in memory only. The context passed to the subscriber holds the outbox id of the
+// event, see enums.ContextKeyEventID.
+func (e *OutboxEventBus) runBroadcast(ctx context.Context, sub Subscriber) error {
+	lastID, err := e.repo.GetLatestID(ctx)
+	if err != nil {
+		return err
+	}
+
+	wake := e.register(sub.EventType())
+	defer e.unregister(sub.EventType(), wake)
+
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	logger.GetLogger(ctx).Info("start to broadcast an event", "event_type", sub.EventType(), "after_event_id", lastID)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-ticker.C:
+		}
+
+		lastID = e.consumeBroadcast(ctx, sub, lastID)
+	}
+}
+
+// consumeBroadcast passes the events after lastID to the subscriber and returns the id of the last
+// delivered event. A failed event is skipped.
+func (e *OutboxEventBus) consumeBroadcast(ctx context.Context, sub Subscriber, lastID int64) int64 {
+	log := logger.GetLogger(ctx)
+	for ctx.Err() == nil {
+		events, err := e.repo.GetAfter(ctx, sub.EventType(), lastID, broadcastBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error("failed to get events to broadcast", "error", err, "event_type", sub.EventType())
+			}
+			return lastID
+		}
+
+		for _, event := range events {
+			lastID = event.ID
+			decoded, err := DecodeEvent(event.EventType, event.Payload)
+			if err != nil {
+				log.Error("failed to decode event, event is skipped", "error", err, "event_type", event.EventType, "event_id", event.ID)
+				continue
+			}
+
+			if err := sub.HandleEvent(context.WithValue(ctx, enums.ContextKeyEventID, event.ID), decoded); err != nil {
+				log.Error("failed to handle broadcast event, event is skipped", "error", err, "event_type", event.EventType, "event_id", event.ID)
+			}
+		}
+
+		if len(events) < broadcastBatchSize {
+			return lastID
+		}
+	}
+	return lastID
+}
+
+// consume handles the pending events of the subscription until there is none left, it waits for a
+// retry or is claimed by another instance
+func (e *OutboxEventBus) consume(ctx context.Context, name string, sub Subscriber) {
+	log := logger.GetLogger(ctx)
+	for ctx.Err() == nil {
+		subscription, event, err := e.repo.Claim(ctx, name, e.owner, e.lease)
+		if err != nil {
+			var notFound storage.ErrEntityNotFound
+			if !errors.As(err, &notFound) && ctx.Err() == nil {
+				log.Error("failed to claim event subscription", "error", err, "subscription", name)
+			}
+			return
+		}
+
+		if err := e.handle(ctx, name, sub, subscription, event); err != nil {
+			if errors.Is(err, storage.ErrSubscriptionNotClaimed) {
+				log.Warn("lease of event subscription has passed, the event may be handled twice", "subscription", name, "event_id", event.ID)
+				continue
+			}
+			log.Error("failed to update event subscription", "error", err, "subscription", name, "event_id", event.ID)
+			return
+		}
+	}
+}
+
+// handle passes the event to the subscriber and records the outcome in the subscription
+func (e *OutboxEventBus) handle(ctx context.Context, name string, sub Subscriber, subscription *entities.EventSubscription, event *entities.OutboxEvent) error {
+	log := logger.GetLogger(ctx)
+	attempts := subscription.Attempts + 1
+
+	decoded, err := DecodeEvent(event.EventType, event.Payload)
+	if err != nil {
+		log.Error("failed to decode event, event is dead-lettered", "error", err, "subscription", name, "event_id", event.ID)
+		return e.repo.DeadLetter(ctx, name, e.owner, event, attempts, err.Error())
+	}
+
+	if err := sub.HandleEvent(context.WithValue(ctx, enums.ContextKeyEventID, event.ID), decoded); err != nil {
+		if attempts >= e.retry.MaxAttempts {
+			log.Error("failed to handle event, event is dead-lettered", "error", err, "subscription", name, "event_id", event.ID, "attempts", attempts)
+			return e.repo.DeadLetter(ctx, name, e.owner, event, attempts, err.Error())
+		}
+
+		backoff := e.retry.NextBackoff(attempts)
+		log.Warn("failed to handle event, retry later", "error", err, "subscription", name, "event_id", event.ID, "attempts", attempts, "backoff", backoff)
+		return e.repo.Retry(ctx, name, e.owner, backoff, err.Error())
+	}
+
+	return e.repo.Ack(ctx, name, e.owner, event)
+}
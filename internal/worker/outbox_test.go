@@ -0,0 +1,392 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type DeleteTreeTestSubscriber struct {
+	err    error
+	events []entities.Event
+}
+
+func (s *DeleteTreeTestSubscriber) EventType() entities.EventType {
+	return entities.EventTypeDeleteTree
+}
+
+func (s *DeleteTreeTestSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	s.events = append(s.events, e)
+	return s.err
+}
+
+type BroadcastTestSubscriber struct {
+	DeleteTreeTestSubscriber
+}
+
+func (s *BroadcastTestSubscriber) Broadcast() bool {
+	return true
+}
+
+const deleteTreeSubscription = "worker.DeleteTreeTestSubscriber/delete tree"
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: time.Minute}
+
+func newTestOutboxEventBus(repo storage.EventRepository) *OutboxEventBus {
+	e := NewOutboxEventBus(repo, []entities.EventType{entities.EventTypeDeleteTree}, WithRetryPolicy(testRetryPolicy))
+	e.owner = "test-owner"
+	return e
+}
+
+func testOutboxEvent(t *testing.T) (entities.EventDeleteTree, *entities.OutboxEvent) {
+	event := entities.NewEventDeleteTree(&entities.Tree{ID: 1, Number: "T-1"})
+	payload, err := EncodeEvent(event)
+	assert.NoError(t, err)
+	return event, &entities.OutboxEvent{ID: 7, EventType: entities.EventTypeDeleteTree, Payload: payload}
+}
+
+func TestOutboxEventBus_Publish(t *testing.T) {
+	t.Run("should append encoded event to the outbox", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		event, outboxEvent := testOutboxEvent(t)
+
+		repo.EXPECT().Append(context.Background(), entities.EventTypeDeleteTree, outboxEvent.Payload).Return(outboxEvent, nil)
+
+		// when
+		err := e.Publish(context.Background(), event)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return an error for unsupported event type", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+
+		// when
+		err := e.Publish(context.Background(), entities.NewEventSensorData(nil))
+
+		// then
+		assert.ErrorIs(t, err, ErrUnknownEventTypeErr)
+		repo.AssertNotCalled(t, "Append")
+	})
+
+	t.Run("should return error when event can not be stored", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		event, _ := testOutboxEvent(t)
+
+		repo.EXPECT().Append(context.Background(), entities.EventTypeDeleteTree, mock.Anything).Return(nil, errors.New("database error"))
+
+		// when
+		err := e.Publish(context.Background(), event)
+
+		// then
+		assert.EqualError(t, err, "database error")
+	})
+}
+
+func TestOutboxEventBus_WithTx(t *testing.T) {
+	type txKey struct{}
+
+	t.Run("should append the published events in the transaction of the repository", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		event, outboxEvent := testOutboxEvent(t)
+		txCtx := context.WithValue(context.Background(), txKey{}, "tx")
+
+		repo.EXPECT().WithTx(context.Background(), mock.Anything).RunAndReturn(func(_ context.Context, fn func(context.Context) error) error {
+			return fn(txCtx)
+		})
+		repo.EXPECT().Append(txCtx, entities.EventTypeDeleteTree, outboxEvent.Payload).Return(outboxEvent, nil)
+
+		// when
+		err := e.WithTx(context.Background(), func(ctx context.Context) error {
+			return e.Publish(ctx, event)
+		})
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return the error of the transaction", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+
+		repo.EXPECT().WithTx(context.Background(), mock.Anything).RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+		// when
+		err := e.WithTx(context.Background(), func(_ context.Context) error {
+			return errors.New("change failed")
+		})
+
+		// then
+		assert.EqualError(t, err, "change failed")
+	})
+}
+
+func TestOutboxEventBus_Consume(t *testing.T) {
+	notFound := storage.ErrEntityNotFound("EventSubscription")
+
+	t.Run("should handle pending events and move the offset", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &DeleteTreeTestSubscriber{}
+		event, outboxEvent := testOutboxEvent(t)
+		subscription := &entities.EventSubscription{Name: deleteTreeSubscription, LastEventID: 6}
+
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(subscription, outboxEvent, nil).Once()
+		repo.EXPECT().Ack(context.Background(), deleteTreeSubscription, "test-owner", outboxEvent).Return(nil)
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(nil, nil, notFound).Once()
+
+		// when
+		e.consume(context.Background(), deleteTreeSubscription, sub)
+
+		// then
+		assert.Equal(t, []entities.Event{event}, sub.events)
+	})
+
+	t.Run("should retry failed event with backoff", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &DeleteTreeTestSubscriber{err: errors.New("failed to handle event")}
+		_, outboxEvent := testOutboxEvent(t)
+		subscription := &entities.EventSubscription{Name: deleteTreeSubscription, Attempts: 1}
+
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(subscription, outboxEvent, nil).Once()
+		repo.EXPECT().Retry(context.Background(), deleteTreeSubscription, "test-owner", 2*time.Second, "failed to handle event").Return(nil)
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(nil, nil, notFound).Once()
+
+		// when
+		e.consume(context.Background(), deleteTreeSubscription, sub)
+
+		// then
+		assert.Len(t, sub.events, 1)
+		repo.AssertNotCalled(t, "Ack")
+	})
+
+	t.Run("should dead-letter event after the last attempt", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &DeleteTreeTestSubscriber{err: errors.New("failed to handle event")}
+		_, outboxEvent := testOutboxEvent(t)
+		subscription := &entities.EventSubscription{Name: deleteTreeSubscription, Attempts: 2}
+
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(subscription, outboxEvent, nil).Once()
+		repo.EXPECT().DeadLetter(context.Background(), deleteTreeSubscription, "test-owner", outboxEvent, int32(3), "failed to handle event").Return(nil)
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(nil, nil, notFound).Once()
+
+		// when
+		e.consume(context.Background(), deleteTreeSubscription, sub)
+
+		// then
+		assert.Len(t, sub.events, 1)
+		repo.AssertNotCalled(t, "Retry")
+	})
+
+	t.Run("should dead-letter event that can not be decoded", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &DeleteTreeTestSubscriber{}
+		outboxEvent := &entities.OutboxEvent{ID: 7, EventType: entities.EventTypeDeleteTree, Payload: []byte(`not json`)}
+		subscription := &entities.EventSubscription{Name: deleteTreeSubscription}
+
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(subscription, outboxEvent, nil).Once()
+		repo.EXPECT().DeadLetter(context.Background(), deleteTreeSubscription, "test-owner", outboxEvent, int32(1), mock.Anything).Return(nil)
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(nil, nil, notFound).Once()
+
+		// when
+		e.consume(context.Background(), deleteTreeSubscription, sub)
+
+		// then
+		assert.Empty(t, sub.events)
+	})
+
+	t.Run("should stop when subscription can not be claimed", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &DeleteTreeTestSubscriber{}
+
+		repo.EXPECT().Claim(context.Background(), deleteTreeSubscription, "test-owner", e.lease).Return(nil, nil, errors.New("database error")).Once()
+
+		// when
+		e.consume(context.Background(), deleteTreeSubscription, sub)
+
+		// then
+		assert.Empty(t, sub.events)
+	})
+}
+
+func TestOutboxEventBus_ConsumeBroadcast(t *testing.T) {
+	t.Run("should handle events after the offset and return the last id", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &BroadcastTestSubscriber{}
+		event, outboxEvent := testOutboxEvent(t)
+
+		repo.EXPECT().GetAfter(context.Background(), entities.EventTypeDeleteTree, int64(6), int32(broadcastBatchSize)).Return([]*entities.OutboxEvent{outboxEvent}, nil)
+
+		// when
+		lastID := e.consumeBroadcast(context.Background(), sub, 6)
+
+		// then
+		assert.Equal(t, int64(7), lastID)
+		assert.Equal(t, []entities.Event{event}, sub.events)
+	})
+
+	t.Run("should skip events that fail or can not be decoded", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &BroadcastTestSubscriber{DeleteTreeTestSubscriber{err: errors.New("failed to handle event")}}
+		_, outboxEvent := testOutboxEvent(t)
+		invalid := &entities.OutboxEvent{ID: 8, EventType: entities.EventTypeDeleteTree, Payload: []byte(`not json`)}
+
+		repo.EXPECT().GetAfter(context.Background(), entities.EventTypeDeleteTree, int64(6), int32(broadcastBatchSize)).Return([]*entities.OutboxEvent{outboxEvent, invalid}, nil)
+
+		// when
+		lastID := e.consumeBroadcast(context.Background(), sub, 6)
+
+		// then
+		assert.Equal(t, int64(8), lastID)
+		assert.Len(t, sub.events, 1)
+		repo.AssertNotCalled(t, "Retry")
+		repo.AssertNotCalled(t, "DeadLetter")
+	})
+
+	t.Run("should keep the offset when events can not be read", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		sub := &BroadcastTestSubscriber{}
+
+		repo.EXPECT().GetAfter(context.Background(), entities.EventTypeDeleteTree, int64(6), int32(broadcastBatchSize)).Return(nil, errors.New("database error"))
+
+		// when
+		lastID := e.consumeBroadcast(context.Background(), sub, 6)
+
+		// then
+		assert.Equal(t, int64(6), lastID)
+		assert.Empty(t, sub.events)
+	})
+}
+
+func TestOutboxEventBus_RunSubscription(t *testing.T) {
+	t.Run("should return an error for unsupported event type", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+
+		// when
+		err := e.RunSubscription(context.Background(), &TestSubscriber{})
+
+		// then
+		assert.ErrorIs(t, err, ErrUnknownEventTypeErr)
+	})
+
+	t.Run("should handle event after notification", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		e.pollInterval = time.Hour
+		sub := &DeleteTreeTestSubscriber{}
+		_, outboxEvent := testOutboxEvent(t)
+		subscription := &entities.EventSubscription{Name: deleteTreeSubscription}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		handled := make(chan struct{})
+		var closeOnce sync.Once
+
+		repo.EXPECT().Subscribe(ctx, deleteTreeSubscription, entities.EventTypeDeleteTree).Return(nil)
+		repo.EXPECT().Claim(ctx, deleteTreeSubscription, "test-owner", e.lease).Return(nil, nil, storage.ErrEntityNotFound("EventSubscription")).Once()
+		repo.EXPECT().Claim(ctx, deleteTreeSubscription, "test-owner", e.lease).Return(subscription, outboxEvent, nil).Once()
+		repo.EXPECT().Ack(ctx, deleteTreeSubscription, "test-owner", outboxEvent).Return(nil)
+		repo.EXPECT().Claim(ctx, deleteTreeSubscription, "test-owner", e.lease).Run(func(context.Context, string, string, time.Duration) {
+			closeOnce.Do(func() { close(handled) })
+		}).Return(nil, nil, storage.ErrEntityNotFound("EventSubscription"))
+
+		done := make(chan error)
+		go func() {
+			done <- e.RunSubscription(ctx, sub)
+		}()
+
+		// when
+		assert.Eventually(t, func() bool {
+			e.notify(entities.EventTypeDeleteTree)
+			select {
+			case <-handled:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+		cancel()
+
+		// then
+		assert.NoError(t, <-done)
+		assert.Len(t, sub.events, 1)
+	})
+	t.Run("should broadcast events without durable subscription", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockEventRepository(t)
+		e := newTestOutboxEventBus(repo)
+		e.pollInterval = time.Hour
+		sub := &BroadcastTestSubscriber{}
+		_, outboxEvent := testOutboxEvent(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		handled := make(chan struct{})
+		var closeOnce sync.Once
+
+		repo.EXPECT().GetLatestID(ctx).Return(int64(6), nil)
+		repo.EXPECT().GetAfter(ctx, entities.EventTypeDeleteTree, int64(6), int32(broadcastBatchSize)).Return([]*entities.OutboxEvent{outboxEvent}, nil).Once()
+		repo.EXPECT().GetAfter(ctx, entities.EventTypeDeleteTree, int64(7), int32(broadcastBatchSize)).Run(func(context.Context, entities.EventType, int64, int32) {
+			closeOnce.Do(func() { close(handled) })
+		}).Return(nil, nil)
+
+		done := make(chan error)
+		go func() {
+			done <- e.RunSubscription(ctx, sub)
+		}()
+
+		// when
+		assert.Eventually(t, func() bool {
+			e.notify(entities.EventTypeDeleteTree)
+			select {
+			case <-handled:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+		cancel()
+
+		// then
+		assert.NoError(t, <-done)
+		assert.Len(t, sub.events, 1)
+		repo.AssertNotCalled(t, "Subscribe")
+		repo.AssertNotCalled(t, "Claim")
+	})
+}
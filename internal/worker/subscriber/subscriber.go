@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/logger"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
@@ -111,3 +112,104 @@ func (s *UpdateWateringPlanSubscriber) HandleEvent(ctx context.Context, e entiti
 
 	return s.tcSvc.HandleUpdateWateringPlan(ctx, &event)
 }
+
+type ImportTreesSubscriber struct {
+	tcSvc service.TreeClusterService
+}
+
+func NewImportTreesSubscriber(tcSvc service.TreeClusterService) *ImportTreesSubscriber {
+	return &ImportTreesSubscriber{
+		tcSvc: tcSvc,
+	}
+}
+
+func (s *ImportTreesSubscriber) EventType() entities.EventType {
+	return entities.EventTypeImportTrees
+}
+
+func (s *ImportTreesSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	event := e.(entities.EventImportTrees)
+	return s.tcSvc.HandleImportTrees(ctx, &event)
+}
+
+// WebhookSubscriber forwards every event of its type to the webhook service
+// which queues a delivery for each subscribed webhook. Errors are only logged,
+// a failing webhook must not stop the subscription.
+type WebhookSubscriber struct {
+	eventType  entities.EventType
+	webhookSvc service.WebhookService
+}
+
+func NewWebhookSubscriber(eventType entities.EventType, webhookSvc service.WebhookService) *WebhookSubscriber {
+	return &WebhookSubscriber{
+		eventType:  eventType,
+		webhookSvc: webhookSvc,
+	}
+}
+
+func (s *WebhookSubscriber) EventType() entities.EventType {
+	return s.eventType
+}
+
+func (s *WebhookSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	if err := s.webhookSvc.HandleEvent(ctx, e); err != nil {
+		logger.GetLogger(ctx).Error("failed to queue webhook deliveries", "error", err, "event_type", e.Type())
+	}
+	return nil
+}
+
+// NotificationSubscriber forwards every event of its type to the notification
+// service which notifies the users that enabled the notification. Errors are
+// only logged, a failing notification must not stop the subscription.
+type NotificationSubscriber struct {
+	eventType       entities.EventType
+	notificationSvc service.NotificationService
+}
+
+func NewNotificationSubscriber(eventType entities.EventType, notificationSvc service.NotificationService) *NotificationSubscriber {
+	return &NotificationSubscriber{
+		eventType:       eventType,
+		notificationSvc: notificationSvc,
+	}
+}
+
+func (s *NotificationSubscriber) EventType() entities.EventType {
+	return s.eventType
+}
+
+func (s *NotificationSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	if err := s.notificationSvc.HandleEvent(ctx, e); err != nil {
+		logger.GetLogger(ctx).Error("failed to create notifications", "error", err, "event_type", e.Type())
+	}
+	return nil
+}
+
+// StreamSubscriber forwards every event of its type to the clients of the event
+// stream. The clients are connected to every instance, so the events are
+// broadcast instead of being shared between the instances.
+type StreamSubscriber struct {
+	eventType entities.EventType
+	streamSvc service.EventStreamService
+}
+
+func NewStreamSubscriber(eventType entities.EventType, streamSvc service.EventStreamService) *StreamSubscriber {
+	return &StreamSubscriber{
+		eventType: eventType,
+		streamSvc: streamSvc,
+	}
+}
+
+func (s *StreamSubscriber) EventType() entities.EventType {
+	return s.eventType
+}
+
+func (s *StreamSubscriber) Broadcast() bool {
+	return true
+}
+
+func (s *StreamSubscriber) HandleEvent(ctx context.Context, e entities.Event) error {
+	if err := s.streamSvc.HandleEvent(ctx, e); err != nil {
+		logger.GetLogger(ctx).Error("failed to send event to the event stream", "error", err, "event_type", e.Type())
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package subscriber
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
@@ -97,4 +98,121 @@ func TestUpdateTreeSubsciber(t *testing.T) {
 			assert.NoError(t, err)
 		})
 	})
+
+	t.Run("should handle import trees event", func(t *testing.T) {
+		// given
+		tcSvc := svcMock.NewMockTreeClusterService(t)
+		sub := NewImportTreesSubscriber(tcSvc)
+		event := entities.NewEventImportTrees(1, nil, []int32{1, 2})
+
+		tcSvc.EXPECT().HandleImportTrees(mock.Anything, &event).Return(nil)
+
+		assert.NotPanics(t, func() {
+			// when
+			err := sub.HandleEvent(context.Background(), event)
+
+			// then
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func TestWebhookSubscriber(t *testing.T) {
+	t.Run("should forward event to webhook service", func(t *testing.T) {
+		// given
+		whSvc := svcMock.NewMockWebhookService(t)
+		sub := NewWebhookSubscriber(entities.EventTypeCreateTree, whSvc)
+		event := entities.NewEventCreateTree(nil, nil)
+
+		whSvc.EXPECT().HandleEvent(mock.Anything, event).Return(nil)
+
+		// when
+		err := sub.HandleEvent(context.Background(), event)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.EventTypeCreateTree, sub.EventType())
+	})
+
+	t.Run("should not return error when webhook service fails", func(t *testing.T) {
+		// given
+		whSvc := svcMock.NewMockWebhookService(t)
+		sub := NewWebhookSubscriber(entities.EventTypeDeleteTree, whSvc)
+		event := entities.NewEventDeleteTree(nil)
+
+		whSvc.EXPECT().HandleEvent(mock.Anything, event).Return(errors.New("database error"))
+
+		// when
+		err := sub.HandleEvent(context.Background(), event)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestNotificationSubscriber(t *testing.T) {
+	t.Run("should forward event to notification service", func(t *testing.T) {
+		// given
+		notificationSvc := svcMock.NewMockNotificationService(t)
+		sub := NewNotificationSubscriber(entities.EventTypeUpdateSensor, notificationSvc)
+		event := entities.NewEventUpdateSensor(nil, nil)
+
+		notificationSvc.EXPECT().HandleEvent(mock.Anything, event).Return(nil)
+
+		// when
+		err := sub.HandleEvent(context.Background(), event)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.EventTypeUpdateSensor, sub.EventType())
+	})
+
+	t.Run("should not return error when notification service fails", func(t *testing.T) {
+		// given
+		notificationSvc := svcMock.NewMockNotificationService(t)
+		sub := NewNotificationSubscriber(entities.EventTypeCreateWateringPlan, notificationSvc)
+		event := entities.NewEventCreateWateringPlan(nil)
+
+		notificationSvc.EXPECT().HandleEvent(mock.Anything, event).Return(errors.New("database error"))
+
+		// when
+		err := sub.HandleEvent(context.Background(), event)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestStreamSubscriber(t *testing.T) {
+	t.Run("should forward event to event stream service", func(t *testing.T) {
+		// given
+		streamSvc := svcMock.NewMockEventStreamService(t)
+		sub := NewStreamSubscriber(entities.EventTypeUpdateTree, streamSvc)
+		event := entities.NewEventUpdateTree(nil, nil, nil)
+
+		streamSvc.EXPECT().HandleEvent(mock.Anything, event).Return(nil)
+
+		// when
+		err := sub.HandleEvent(context.Background(), event)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.EventTypeUpdateTree, sub.EventType())
+		assert.True(t, sub.Broadcast())
+	})
+
+	t.Run("should not return error when event stream service fails", func(t *testing.T) {
+		// given
+		streamSvc := svcMock.NewMockEventStreamService(t)
+		sub := NewStreamSubscriber(entities.EventTypeDeleteTree, streamSvc)
+		event := entities.NewEventDeleteTree(nil)
+
+		streamSvc.EXPECT().HandleEvent(mock.Anything, event).Return(errors.New("unsupported event"))
+
+		// when
+		err := sub.HandleEvent(context.Background(), event)
+
+		// then
+		assert.NoError(t, err)
+	})
 }
@@ -29,11 +29,17 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/auth"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/local"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/mail"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/mail/smtp"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/push"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/push/webpush"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/routing"
 	_ "github.com/green-ecolution/green-ecolution-backend/internal/storage/routing/openrouteservice"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/routing/valhalla"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/s3"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/weather"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/weather/openmeteo"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker"
 	"github.com/green-ecolution/green-ecolution-backend/internal/worker/subscriber"
 	"github.com/jackc/pgx/v5"
@@ -115,7 +121,7 @@ func startAppServices(ctx context.Context, cfg *config.Config) {
 	repositories, closeFn := initializeRepositories(ctx, cfg)
 	defer closeFn()
 
-	em := initializeEventManager()
+	em := initializeEventBus(cfg, repositories)
 
 	services := domain.NewService(cfg, repositories, em)
 	httpServer := http.NewServer(cfg, services)
@@ -146,6 +152,45 @@ func initializeRepositories(ctx context.Context, cfg *config.Config) (repos *sto
 		}
 	}
 
+	var weatherRepo *storage.Repository
+	if cfg.Weather.Enable {
+		weatherRepo, err = openmeteo.NewRepository(cfg)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		slog.Warn("the weather service is disabled due to the configuration")
+		weatherRepo = &storage.Repository{
+			WeatherProvider: weather.NewDummyWeatherProvider(),
+		}
+	}
+
+	var mailRepo *storage.Repository
+	if cfg.Notification.SMTP.Enable {
+		mailRepo, err = smtp.NewRepository(cfg)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		slog.Warn("the mail service is disabled due to the configuration")
+		mailRepo = &storage.Repository{
+			MailSender: mail.NewDummyMailSender(),
+		}
+	}
+
+	var pushRepo *storage.Repository
+	if cfg.Notification.Push.Enable {
+		pushRepo, err = webpush.NewRepository(cfg)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		slog.Warn("the push service is disabled due to the configuration")
+		pushRepo = &storage.Repository{
+			PushSender: push.NewDummyPushSender(),
+		}
+	}
+
 	keycloakRepo := auth.NewRepository(&cfg.IdentityAuth)
 
 	var s3Repos *storage.Repository
@@ -157,7 +202,8 @@ func initializeRepositories(ctx context.Context, cfg *config.Config) (repos *sto
 	} else {
 		slog.Warn("the s3 service is disabled due to the configuration")
 		s3Repos = &storage.Repository{
-			GpxBucket: s3.NewS3DummyRepo(),
+			GpxBucket:    s3.NewS3DummyRepo(),
+			ReportBucket: s3.NewS3DummyRepo(),
 		}
 	}
 
@@ -165,32 +211,73 @@ func initializeRepositories(ctx context.Context, cfg *config.Config) (repos *sto
 		Auth: keycloakRepo.Auth,
 		User: keycloakRepo.User,
 
-		Info:         localRepo.Info,
-		Sensor:       postgresRepo.Sensor,
-		Tree:         postgresRepo.Tree,
-		TreeCluster:  postgresRepo.TreeCluster,
-		Vehicle:      postgresRepo.Vehicle,
-		Region:       postgresRepo.Region,
-		WateringPlan: postgresRepo.WateringPlan,
-		Routing:      routingRepo.Routing,
-		GpxBucket:    s3Repos.GpxBucket,
+		Info:                 localRepo.Info,
+		Sensor:               postgresRepo.Sensor,
+		Tree:                 postgresRepo.Tree,
+		TreeCluster:          postgresRepo.TreeCluster,
+		Vehicle:              postgresRepo.Vehicle,
+		Region:               postgresRepo.Region,
+		WateringPlan:         postgresRepo.WateringPlan,
+		Plugin:               postgresRepo.Plugin,
+		Webhook:              postgresRepo.Webhook,
+		APIKey:               postgresRepo.APIKey,
+		TreeImport:           postgresRepo.TreeImport,
+		Feature:              postgresRepo.Feature,
+		AuditLog:             postgresRepo.AuditLog,
+		Event:                postgresRepo.Event,
+		Job:                  postgresRepo.Job,
+		Weather:              postgresRepo.Weather,
+		WateringPlanTemplate: postgresRepo.WateringPlanTemplate,
+		Evaluation:           postgresRepo.Evaluation,
+		Notification:         postgresRepo.Notification,
+		Routing:              routingRepo.Routing,
+		GpxBucket:            s3Repos.GpxBucket,
+		ReportBucket:         s3Repos.ReportBucket,
+
+		WeatherProvider: weatherRepo.WeatherProvider,
+		MailSender:      mailRepo.MailSender,
+		PushSender:      pushRepo.PushSender,
 	}
 
 	return repositories, closeFn
 }
 
-func initializeEventManager() *worker.EventManager {
-	return worker.NewEventManager(
+func initializeEventBus(cfg *config.Config, repos *storage.Repository) worker.EventBus {
+	eventTypes := []entities.EventType{
 		entities.EventTypeUpdateTree,
 		entities.EventTypeUpdateTreeCluster,
 		entities.EventTypeCreateTree,
 		entities.EventTypeDeleteTree,
 		entities.EventTypeNewSensorData,
 		entities.EventTypeUpdateWateringPlan,
-	)
+		entities.EventTypeImportTrees,
+		entities.EventTypeCreateWateringPlan,
+		entities.EventTypeUpdateSensor,
+		entities.EventTypeFireAlert,
+	}
+
+	retry := worker.RetryPolicy{
+		MaxAttempts: cfg.Events.MaxAttempts,
+		Backoff:     cfg.Events.Backoff,
+		MaxBackoff:  cfg.Events.MaxBackoff,
+	}
+
+	switch cfg.Events.Driver {
+	case config.EventsDriverPostgres:
+		slog.Info("using the postgres event bus")
+		return worker.NewOutboxEventBus(repos.Event, eventTypes, worker.WithRetryPolicy(retry))
+	case config.EventsDriverMemory:
+		slog.Info("using the in-memory event bus")
+	default:
+		slog.Warn("unknown event bus driver, fall back to the in-memory event bus", "driver", cfg.Events.Driver)
+	}
+
+	em := worker.NewEventManager(eventTypes...)
+	em.SetRetryPolicy(retry)
+	return em
 }
 
-func runServices(ctx context.Context, httpServer *http.Server, mqttServer *mqtt.Mqtt, em *worker.EventManager, services *service.Services) {
+func runServices(ctx context.Context, httpServer *http.Server, mqttServer *mqtt.Mqtt, em worker.EventBus, services *service.Services) {
 	var wg sync.WaitGroup
 
 	if viper.GetBool("mqtt.enable") {
@@ -222,13 +309,26 @@ func runServices(ctx context.Context, httpServer *http.Server, mqttServer *mqtt.
 	wg.Wait()
 }
 
-func runEventSubscriptions(ctx context.Context, wg *sync.WaitGroup, em *worker.EventManager, services *service.Services) {
+func runEventSubscriptions(ctx context.Context, wg *sync.WaitGroup, em worker.EventBus, services *service.Services) {
 	subscribers := []worker.Subscriber{
 		subscriber.NewUpdateTreeSubscriber(services.TreeClusterService),
 		subscriber.NewCreateTreeSubscriber(services.TreeClusterService),
 		subscriber.NewDeleteTreeSubscriber(services.TreeClusterService),
 		subscriber.NewSensorDataSubscriber(services.TreeClusterService, services.TreeService),
 		subscriber.NewUpdateWateringPlanSubscriber(services.TreeClusterService),
+		subscriber.NewImportTreesSubscriber(services.TreeClusterService),
+	}
+
+	for _, eventType := range entities.WebhookEventTypes {
+		subscribers = append(subscribers, subscriber.NewWebhookSubscriber(eventType, services.WebhookService))
+	}
+
+	for _, eventType := range entities.NotificationEventTypes {
+		subscribers = append(subscribers, subscriber.NewNotificationSubscriber(eventType, services.NotificationService))
+	}
+
+	for _, eventType := range entities.StreamEventTypes {
+		subscribers = append(subscribers, subscriber.NewStreamSubscriber(eventType, services.EventStreamService))
 	}
 
 	for _, sub := range subscribers {
@@ -193,6 +193,13 @@ type ApiDeleteTreeRequest struct {
 	ctx        context.Context
 	ApiService *TreeAPIService
 	treeId     int32
+	ifMatch    *string
+}
+
+// ETag of the tree, the request fails with 412 if the tree has been modified since
+func (r ApiDeleteTreeRequest) IfMatch(ifMatch string) ApiDeleteTreeRequest {
+	r.ifMatch = &ifMatch
+	return r
 }
 
 func (r ApiDeleteTreeRequest) Execute() (*http.Response, error) {
@@ -253,6 +260,9 @@ func (a *TreeAPIService) DeleteTreeExecute(r ApiDeleteTreeRequest) (*http.Respon
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -319,6 +329,17 @@ func (a *TreeAPIService) DeleteTreeExecute(r ApiDeleteTreeRequest) (*http.Respon
 			newErr.model = v
 			return localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -682,6 +703,7 @@ type ApiUpdateTreeRequest struct {
 	ApiService *TreeAPIService
 	treeId     int32
 	body       *TreeUpdate
+	ifMatch    *string
 }
 
 // Tree to update
@@ -690,6 +712,12 @@ func (r ApiUpdateTreeRequest) Body(body TreeUpdate) ApiUpdateTreeRequest {
 	return r
 }
 
+// ETag of the tree, the request fails with 412 if the tree has been modified since
+func (r ApiUpdateTreeRequest) IfMatch(ifMatch string) ApiUpdateTreeRequest {
+	r.ifMatch = &ifMatch
+	return r
+}
+
 func (r ApiUpdateTreeRequest) Execute() (*Tree, *http.Response, error) {
 	return r.ApiService.UpdateTreeExecute(r)
 }
@@ -754,6 +782,9 @@ func (a *TreeAPIService) UpdateTreeExecute(r ApiUpdateTreeRequest) (*Tree, *http
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	// body params
 	localVarPostBody = r.body
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
@@ -822,6 +853,17 @@ func (a *TreeAPIService) UpdateTreeExecute(r ApiUpdateTreeRequest) (*Tree, *http
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -193,6 +193,13 @@ type ApiDeleteTreeClusterRequest struct {
 	ctx        context.Context
 	ApiService *TreeClusterAPIService
 	clusterId  int32
+	ifMatch    *string
+}
+
+// ETag of the tree cluster, the request fails with 412 if the tree cluster has been modified since
+func (r ApiDeleteTreeClusterRequest) IfMatch(ifMatch string) ApiDeleteTreeClusterRequest {
+	r.ifMatch = &ifMatch
+	return r
 }
 
 func (r ApiDeleteTreeClusterRequest) Execute() (*http.Response, error) {
@@ -253,6 +260,9 @@ func (a *TreeClusterAPIService) DeleteTreeClusterExecute(r ApiDeleteTreeClusterR
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -319,6 +329,17 @@ func (a *TreeClusterAPIService) DeleteTreeClusterExecute(r ApiDeleteTreeClusterR
 			newErr.model = v
 			return localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -682,6 +703,7 @@ type ApiUpdateTreeClusterRequest struct {
 	ApiService *TreeClusterAPIService
 	clusterId  int32
 	body       *TreeClusterUpdate
+	ifMatch    *string
 }
 
 // Tree Cluster Update Request
@@ -690,6 +712,12 @@ func (r ApiUpdateTreeClusterRequest) Body(body TreeClusterUpdate) ApiUpdateTreeC
 	return r
 }
 
+// ETag of the tree cluster, the request fails with 412 if the tree cluster has been modified since
+func (r ApiUpdateTreeClusterRequest) IfMatch(ifMatch string) ApiUpdateTreeClusterRequest {
+	r.ifMatch = &ifMatch
+	return r
+}
+
 func (r ApiUpdateTreeClusterRequest) Execute() (*TreeCluster, *http.Response, error) {
 	return r.ApiService.UpdateTreeClusterExecute(r)
 }
@@ -754,6 +782,9 @@ func (a *TreeClusterAPIService) UpdateTreeClusterExecute(r ApiUpdateTreeClusterR
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	// body params
 	localVarPostBody = r.body
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
@@ -822,6 +853,17 @@ func (a *TreeClusterAPIService) UpdateTreeClusterExecute(r ApiUpdateTreeClusterR
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
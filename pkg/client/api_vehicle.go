@@ -350,6 +350,13 @@ type ApiDeleteVehicleRequest struct {
 	ctx        context.Context
 	ApiService *VehicleAPIService
 	id         int32
+	ifMatch    *string
+}
+
+// ETag of the vehicle, the request fails with 412 if the vehicle has been modified since
+func (r ApiDeleteVehicleRequest) IfMatch(ifMatch string) ApiDeleteVehicleRequest {
+	r.ifMatch = &ifMatch
+	return r
 }
 
 func (r ApiDeleteVehicleRequest) Execute() (*http.Response, error) {
@@ -410,6 +417,9 @@ func (a *VehicleAPIService) DeleteVehicleExecute(r ApiDeleteVehicleRequest) (*ht
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -476,6 +486,17 @@ func (a *VehicleAPIService) DeleteVehicleExecute(r ApiDeleteVehicleRequest) (*ht
 			newErr.model = v
 			return localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1171,6 +1192,7 @@ type ApiUpdateVehicleRequest struct {
 	ApiService *VehicleAPIService
 	id         string
 	body       *VehicleUpdate
+	ifMatch    *string
 }
 
 // Vehicle Update Request
@@ -1179,6 +1201,12 @@ func (r ApiUpdateVehicleRequest) Body(body VehicleUpdate) ApiUpdateVehicleReques
 	return r
 }
 
+// ETag of the vehicle, the request fails with 412 if the vehicle has been modified since
+func (r ApiUpdateVehicleRequest) IfMatch(ifMatch string) ApiUpdateVehicleRequest {
+	r.ifMatch = &ifMatch
+	return r
+}
+
 func (r ApiUpdateVehicleRequest) Execute() (*Vehicle, *http.Response, error) {
 	return r.ApiService.UpdateVehicleExecute(r)
 }
@@ -1243,6 +1271,9 @@ func (a *VehicleAPIService) UpdateVehicleExecute(r ApiUpdateVehicleRequest) (*Ve
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	// body params
 	localVarPostBody = r.body
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
@@ -1311,6 +1342,17 @@ func (a *VehicleAPIService) UpdateVehicleExecute(r ApiUpdateVehicleRequest) (*Ve
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
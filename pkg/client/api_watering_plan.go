@@ -194,6 +194,13 @@ type ApiDeleteWateringPlanRequest struct {
 	ctx        context.Context
 	ApiService *WateringPlanAPIService
 	id         int32
+	ifMatch    *string
+}
+
+// ETag of the watering plan, the request fails with 412 if the watering plan has been modified since
+func (r ApiDeleteWateringPlanRequest) IfMatch(ifMatch string) ApiDeleteWateringPlanRequest {
+	r.ifMatch = &ifMatch
+	return r
 }
 
 func (r ApiDeleteWateringPlanRequest) Execute() (*http.Response, error) {
@@ -254,6 +261,9 @@ func (a *WateringPlanAPIService) DeleteWateringPlanExecute(r ApiDeleteWateringPl
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return nil, err
@@ -320,6 +330,17 @@ func (a *WateringPlanAPIService) DeleteWateringPlanExecute(r ApiDeleteWateringPl
 			newErr.model = v
 			return localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -683,6 +704,7 @@ type ApiUpdateWateringPlanRequest struct {
 	ApiService *WateringPlanAPIService
 	id         string
 	body       *WateringPlanUpdate
+	ifMatch    *string
 }
 
 // Watering Plan Update Request
@@ -691,6 +713,12 @@ func (r ApiUpdateWateringPlanRequest) Body(body WateringPlanUpdate) ApiUpdateWat
 	return r
 }
 
+// ETag of the watering plan, the request fails with 412 if the watering plan has been modified since
+func (r ApiUpdateWateringPlanRequest) IfMatch(ifMatch string) ApiUpdateWateringPlanRequest {
+	r.ifMatch = &ifMatch
+	return r
+}
+
 func (r ApiUpdateWateringPlanRequest) Execute() (*WateringPlan, *http.Response, error) {
 	return r.ApiService.UpdateWateringPlanExecute(r)
 }
@@ -755,6 +783,9 @@ func (a *WateringPlanAPIService) UpdateWateringPlanExecute(r ApiUpdateWateringPl
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		parameterAddToHeaderOrQuery(localVarHeaderParams, "If-Match", r.ifMatch, "simple", "")
+	}
 	// body params
 	localVarPostBody = r.body
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
@@ -823,6 +854,17 @@ func (a *WateringPlanAPIService) UpdateWateringPlanExecute(r ApiUpdateWateringPl
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v HTTPError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v HTTPError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -36,6 +36,7 @@ type Tree struct {
 	Species               string                 `json:"species"`
 	TreeClusterId         *int32                 `json:"tree_cluster_id,omitempty"`
 	UpdatedAt             string                 `json:"updated_at"`
+	Version               int32                  `json:"version"`
 	WateringStatus        WateringStatus         `json:"watering_status"`
 }
 
@@ -45,7 +46,7 @@ type _Tree Tree
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewTree(createdAt string, description string, id int32, latitude float32, longitude float32, number string, plantingYear int32, provider string, species string, updatedAt string, wateringStatus WateringStatus) *Tree {
+func NewTree(createdAt string, description string, id int32, latitude float32, longitude float32, number string, plantingYear int32, provider string, species string, updatedAt string, version int32, wateringStatus WateringStatus) *Tree {
 	this := Tree{}
 	this.CreatedAt = createdAt
 	this.Description = description
@@ -57,6 +58,7 @@ func NewTree(createdAt string, description string, id int32, latitude float32, l
 	this.Provider = provider
 	this.Species = species
 	this.UpdatedAt = updatedAt
+	this.Version = version
 	this.WateringStatus = wateringStatus
 	return &this
 }
@@ -437,6 +439,30 @@ func (o *Tree) SetUpdatedAt(v string) {
 	o.UpdatedAt = v
 }
 
+// GetVersion returns the Version field value
+func (o *Tree) GetVersion() int32 {
+	if o == nil {
+		var ret int32
+		return ret
+	}
+
+	return o.Version
+}
+
+// GetVersionOk returns a tuple with the Version field value
+// and a boolean to check if the value has been set.
+func (o *Tree) GetVersionOk() (*int32, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Version, true
+}
+
+// SetVersion sets field value
+func (o *Tree) SetVersion(v int32) {
+	o.Version = v
+}
+
 // GetWateringStatus returns the WateringStatus field value
 func (o *Tree) GetWateringStatus() WateringStatus {
 	if o == nil {
@@ -493,6 +519,7 @@ func (o Tree) ToMap() (map[string]interface{}, error) {
 		toSerialize["tree_cluster_id"] = o.TreeClusterId
 	}
 	toSerialize["updated_at"] = o.UpdatedAt
+	toSerialize["version"] = o.Version
 	toSerialize["watering_status"] = o.WateringStatus
 	return toSerialize, nil
 }
@@ -512,6 +539,7 @@ func (o *Tree) UnmarshalJSON(data []byte) (err error) {
 		"provider",
 		"species",
 		"updated_at",
+		"version",
 		"watering_status",
 	}
 
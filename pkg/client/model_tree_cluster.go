@@ -38,6 +38,7 @@ type TreeCluster struct {
 	SoilCondition         SoilCondition          `json:"soil_condition"`
 	Trees                 []Tree                 `json:"trees,omitempty"`
 	UpdatedAt             string                 `json:"updated_at"`
+	Version               int32                  `json:"version"`
 	WateringStatus        WateringStatus         `json:"watering_status"`
 }
 
@@ -47,7 +48,7 @@ type _TreeCluster TreeCluster
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewTreeCluster(address string, archived bool, createdAt string, description string, id int32, latitude float32, longitude float32, moistureLevel float32, name string, provider string, soilCondition SoilCondition, updatedAt string, wateringStatus WateringStatus) *TreeCluster {
+func NewTreeCluster(address string, archived bool, createdAt string, description string, id int32, latitude float32, longitude float32, moistureLevel float32, name string, provider string, soilCondition SoilCondition, updatedAt string, version int32, wateringStatus WateringStatus) *TreeCluster {
 	this := TreeCluster{}
 	this.Address = address
 	this.Archived = archived
@@ -61,6 +62,7 @@ func NewTreeCluster(address string, archived bool, createdAt string, description
 	this.Provider = provider
 	this.SoilCondition = soilCondition
 	this.UpdatedAt = updatedAt
+	this.Version = version
 	this.WateringStatus = wateringStatus
 	return &this
 }
@@ -489,6 +491,30 @@ func (o *TreeCluster) SetUpdatedAt(v string) {
 	o.UpdatedAt = v
 }
 
+// GetVersion returns the Version field value
+func (o *TreeCluster) GetVersion() int32 {
+	if o == nil {
+		var ret int32
+		return ret
+	}
+
+	return o.Version
+}
+
+// GetVersionOk returns a tuple with the Version field value
+// and a boolean to check if the value has been set.
+func (o *TreeCluster) GetVersionOk() (*int32, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Version, true
+}
+
+// SetVersion sets field value
+func (o *TreeCluster) SetVersion(v int32) {
+	o.Version = v
+}
+
 // GetWateringStatus returns the WateringStatus field value
 func (o *TreeCluster) GetWateringStatus() WateringStatus {
 	if o == nil {
@@ -547,6 +573,7 @@ func (o TreeCluster) ToMap() (map[string]interface{}, error) {
 		toSerialize["trees"] = o.Trees
 	}
 	toSerialize["updated_at"] = o.UpdatedAt
+	toSerialize["version"] = o.Version
 	toSerialize["watering_status"] = o.WateringStatus
 	return toSerialize, nil
 }
@@ -568,6 +595,7 @@ func (o *TreeCluster) UnmarshalJSON(data []byte) (err error) {
 		"provider",
 		"soil_condition",
 		"updated_at",
+		"version",
 		"watering_status",
 	}
 
@@ -36,6 +36,7 @@ type TreeClusterInList struct {
 	SoilCondition  SoilCondition  `json:"soil_condition"`
 	TreeIds        []int32        `json:"tree_ids,omitempty"`
 	UpdatedAt      string         `json:"updated_at"`
+	Version        int32          `json:"version"`
 	WateringStatus WateringStatus `json:"watering_status"`
 }
 
@@ -45,7 +46,7 @@ type _TreeClusterInList TreeClusterInList
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewTreeClusterInList(address string, archived bool, createdAt string, description string, id int32, latitude float32, longitude float32, moistureLevel float32, name string, soilCondition SoilCondition, updatedAt string, wateringStatus WateringStatus) *TreeClusterInList {
+func NewTreeClusterInList(address string, archived bool, createdAt string, description string, id int32, latitude float32, longitude float32, moistureLevel float32, name string, soilCondition SoilCondition, updatedAt string, version int32, wateringStatus WateringStatus) *TreeClusterInList {
 	this := TreeClusterInList{}
 	this.Address = address
 	this.Archived = archived
@@ -58,6 +59,7 @@ func NewTreeClusterInList(address string, archived bool, createdAt string, descr
 	this.Name = name
 	this.SoilCondition = soilCondition
 	this.UpdatedAt = updatedAt
+	this.Version = version
 	this.WateringStatus = wateringStatus
 	return &this
 }
@@ -430,6 +432,30 @@ func (o *TreeClusterInList) SetUpdatedAt(v string) {
 	o.UpdatedAt = v
 }
 
+// GetVersion returns the Version field value
+func (o *TreeClusterInList) GetVersion() int32 {
+	if o == nil {
+		var ret int32
+		return ret
+	}
+
+	return o.Version
+}
+
+// GetVersionOk returns a tuple with the Version field value
+// and a boolean to check if the value has been set.
+func (o *TreeClusterInList) GetVersionOk() (*int32, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Version, true
+}
+
+// SetVersion sets field value
+func (o *TreeClusterInList) SetVersion(v int32) {
+	o.Version = v
+}
+
 // GetWateringStatus returns the WateringStatus field value
 func (o *TreeClusterInList) GetWateringStatus() WateringStatus {
 	if o == nil {
@@ -484,6 +510,7 @@ func (o TreeClusterInList) ToMap() (map[string]interface{}, error) {
 		toSerialize["tree_ids"] = o.TreeIds
 	}
 	toSerialize["updated_at"] = o.UpdatedAt
+	toSerialize["version"] = o.Version
 	toSerialize["watering_status"] = o.WateringStatus
 	return toSerialize, nil
 }
@@ -504,6 +531,7 @@ func (o *TreeClusterInList) UnmarshalJSON(data []byte) (err error) {
 		"name",
 		"soil_condition",
 		"updated_at",
+		"version",
 		"watering_status",
 	}
 
@@ -36,6 +36,7 @@ type Vehicle struct {
 	Status                VehicleStatus          `json:"status"`
 	Type                  VehicleType            `json:"type"`
 	UpdatedAt             string                 `json:"updated_at"`
+	Version               int32                  `json:"version"`
 	WaterCapacity         float32                `json:"water_capacity"`
 	Weight                float32                `json:"weight"`
 	Width                 float32                `json:"width"`
@@ -47,7 +48,7 @@ type _Vehicle Vehicle
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewVehicle(archivedAt string, createdAt string, description string, drivingLicense DrivingLicense, height float32, id int32, length float32, model string, numberPlate string, provider string, status VehicleStatus, type_ VehicleType, updatedAt string, waterCapacity float32, weight float32, width float32) *Vehicle {
+func NewVehicle(archivedAt string, createdAt string, description string, drivingLicense DrivingLicense, height float32, id int32, length float32, model string, numberPlate string, provider string, status VehicleStatus, type_ VehicleType, updatedAt string, version int32, waterCapacity float32, weight float32, width float32) *Vehicle {
 	this := Vehicle{}
 	this.ArchivedAt = archivedAt
 	this.CreatedAt = createdAt
@@ -62,6 +63,7 @@ func NewVehicle(archivedAt string, createdAt string, description string, driving
 	this.Status = status
 	this.Type = type_
 	this.UpdatedAt = updatedAt
+	this.Version = version
 	this.WaterCapacity = waterCapacity
 	this.Weight = weight
 	this.Width = width
@@ -420,6 +422,30 @@ func (o *Vehicle) SetUpdatedAt(v string) {
 	o.UpdatedAt = v
 }
 
+// GetVersion returns the Version field value
+func (o *Vehicle) GetVersion() int32 {
+	if o == nil {
+		var ret int32
+		return ret
+	}
+
+	return o.Version
+}
+
+// GetVersionOk returns a tuple with the Version field value
+// and a boolean to check if the value has been set.
+func (o *Vehicle) GetVersionOk() (*int32, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Version, true
+}
+
+// SetVersion sets field value
+func (o *Vehicle) SetVersion(v int32) {
+	o.Version = v
+}
+
 // GetWaterCapacity returns the WaterCapacity field value
 func (o *Vehicle) GetWaterCapacity() float32 {
 	if o == nil {
@@ -518,6 +544,7 @@ func (o Vehicle) ToMap() (map[string]interface{}, error) {
 	toSerialize["status"] = o.Status
 	toSerialize["type"] = o.Type
 	toSerialize["updated_at"] = o.UpdatedAt
+	toSerialize["version"] = o.Version
 	toSerialize["water_capacity"] = o.WaterCapacity
 	toSerialize["weight"] = o.Weight
 	toSerialize["width"] = o.Width
@@ -542,6 +569,7 @@ func (o *Vehicle) UnmarshalJSON(data []byte) (err error) {
 		"status",
 		"type",
 		"updated_at",
+		"version",
 		"water_capacity",
 		"weight",
 		"width",
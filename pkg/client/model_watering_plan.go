@@ -41,6 +41,7 @@ type WateringPlan struct {
 	Treeclusters          []TreeClusterInList    `json:"treeclusters"`
 	UpdatedAt             string                 `json:"updated_at"`
 	UserIds               []string               `json:"user_ids"`
+	Version               int32                  `json:"version"`
 }
 
 type _WateringPlan WateringPlan
@@ -49,7 +50,7 @@ type _WateringPlan WateringPlan
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewWateringPlan(cancellationNote string, createdAt string, date string, description string, distance float32, duration float32, evaluation []EvaluationValue, gpxUrl string, id int32, provider string, refillCount int32, status WateringPlanStatus, totalWaterRequired float32, transporter Vehicle, treeclusters []TreeClusterInList, updatedAt string, userIds []string) *WateringPlan {
+func NewWateringPlan(cancellationNote string, createdAt string, date string, description string, distance float32, duration float32, evaluation []EvaluationValue, gpxUrl string, id int32, provider string, refillCount int32, status WateringPlanStatus, totalWaterRequired float32, transporter Vehicle, treeclusters []TreeClusterInList, updatedAt string, userIds []string, version int32) *WateringPlan {
 	this := WateringPlan{}
 	this.CancellationNote = cancellationNote
 	this.CreatedAt = createdAt
@@ -68,6 +69,7 @@ func NewWateringPlan(cancellationNote string, createdAt string, date string, des
 	this.Treeclusters = treeclusters
 	this.UpdatedAt = updatedAt
 	this.UserIds = userIds
+	this.Version = version
 	return &this
 }
 
@@ -551,6 +553,30 @@ func (o *WateringPlan) SetUserIds(v []string) {
 	o.UserIds = v
 }
 
+// GetVersion returns the Version field value
+func (o *WateringPlan) GetVersion() int32 {
+	if o == nil {
+		var ret int32
+		return ret
+	}
+
+	return o.Version
+}
+
+// GetVersionOk returns a tuple with the Version field value
+// and a boolean to check if the value has been set.
+func (o *WateringPlan) GetVersionOk() (*int32, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Version, true
+}
+
+// SetVersion sets field value
+func (o *WateringPlan) SetVersion(v int32) {
+	o.Version = v
+}
+
 func (o WateringPlan) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -584,6 +610,7 @@ func (o WateringPlan) ToMap() (map[string]interface{}, error) {
 	toSerialize["treeclusters"] = o.Treeclusters
 	toSerialize["updated_at"] = o.UpdatedAt
 	toSerialize["user_ids"] = o.UserIds
+	toSerialize["version"] = o.Version
 	return toSerialize, nil
 }
 
@@ -609,6 +636,7 @@ func (o *WateringPlan) UnmarshalJSON(data []byte) (err error) {
 		"treeclusters",
 		"updated_at",
 		"user_ids",
+		"version",
 	}
 
 	allProperties := make(map[string]interface{})
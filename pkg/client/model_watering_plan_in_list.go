@@ -35,6 +35,7 @@ type WateringPlanInList struct {
 	Treeclusters       []TreeClusterInList `json:"treeclusters"`
 	UpdatedAt          string              `json:"updated_at"`
 	UserIds            []string            `json:"user_ids"`
+	Version            int32               `json:"version"`
 }
 
 type _WateringPlanInList WateringPlanInList
@@ -43,7 +44,7 @@ type _WateringPlanInList WateringPlanInList
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewWateringPlanInList(cancellationNote string, createdAt string, date string, description string, distance float32, id int32, status WateringPlanStatus, totalWaterRequired float32, transporter Vehicle, treeclusters []TreeClusterInList, updatedAt string, userIds []string) *WateringPlanInList {
+func NewWateringPlanInList(cancellationNote string, createdAt string, date string, description string, distance float32, id int32, status WateringPlanStatus, totalWaterRequired float32, transporter Vehicle, treeclusters []TreeClusterInList, updatedAt string, userIds []string, version int32) *WateringPlanInList {
 	this := WateringPlanInList{}
 	this.CancellationNote = cancellationNote
 	this.CreatedAt = createdAt
@@ -57,6 +58,7 @@ func NewWateringPlanInList(cancellationNote string, createdAt string, date strin
 	this.Treeclusters = treeclusters
 	this.UpdatedAt = updatedAt
 	this.UserIds = userIds
+	this.Version = version
 	return &this
 }
 
@@ -388,6 +390,30 @@ func (o *WateringPlanInList) SetUserIds(v []string) {
 	o.UserIds = v
 }
 
+// GetVersion returns the Version field value
+func (o *WateringPlanInList) GetVersion() int32 {
+	if o == nil {
+		var ret int32
+		return ret
+	}
+
+	return o.Version
+}
+
+// GetVersionOk returns a tuple with the Version field value
+// and a boolean to check if the value has been set.
+func (o *WateringPlanInList) GetVersionOk() (*int32, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Version, true
+}
+
+// SetVersion sets field value
+func (o *WateringPlanInList) SetVersion(v int32) {
+	o.Version = v
+}
+
 func (o WateringPlanInList) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -413,6 +439,7 @@ func (o WateringPlanInList) ToMap() (map[string]interface{}, error) {
 	toSerialize["treeclusters"] = o.Treeclusters
 	toSerialize["updated_at"] = o.UpdatedAt
 	toSerialize["user_ids"] = o.UserIds
+	toSerialize["version"] = o.Version
 	return toSerialize, nil
 }
 
@@ -433,6 +460,7 @@ func (o *WateringPlanInList) UnmarshalJSON(data []byte) (err error) {
 		"treeclusters",
 		"updated_at",
 		"user_ids",
+		"version",
 	}
 
 	allProperties := make(map[string]interface{})
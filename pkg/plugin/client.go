@@ -0,0 +1,217 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+var ErrNotRegistered = errors.New("plugin is not registered")
+
+// APIError is returned by the Client if the plugin host responds with an error status code.
+//
+// Fields:
+// - StatusCode: The http status code of the response.
+// - Message: The error message of the plugin host.
+type APIError struct {
+	StatusCode int    `json:"code"`
+	Message    string `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("plugin host responded with %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client is a typed client for the core resources of the plugin host.
+// It authenticates with the token of the PluginWorker and uses the host api
+// version negotiated on registration, so the worker must be registered first.
+//
+// The plugin needs the matching scopes, e.g. "tree:read" for ListTrees and
+// "tree:write" for CreateTree.
+//
+// Example usage:
+//
+//	if _, err := worker.Register(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	client := worker.Client()
+//	trees, err := client.ListTrees(ctx, &ListOptions{Page: 1, Limit: 50})
+type Client struct {
+	w *PluginWorker
+}
+
+// Client returns a typed client for the plugin host that shares the token of the worker.
+func (w *PluginWorker) Client() *Client {
+	return &Client{w: w}
+}
+
+// ListOptions paginates list requests. Zero values return all entities.
+type ListOptions struct {
+	Page     int32
+	Limit    int32
+	Provider string
+}
+
+func (o *ListOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(int(o.Page)))
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(int(o.Limit)))
+	}
+	if o.Provider != "" {
+		q.Set("provider", o.Provider)
+	}
+	return q
+}
+
+// ListTrees returns the trees of the plugin host.
+func (c *Client) ListTrees(ctx context.Context, opts *ListOptions) (*TreeList, error) {
+	return doJSON[TreeList](ctx, c, http.MethodGet, "/tree", opts.query(), nil)
+}
+
+// GetTree returns the tree with the given id.
+func (c *Client) GetTree(ctx context.Context, id int32) (*Tree, error) {
+	return doJSON[Tree](ctx, c, http.MethodGet, fmt.Sprintf("/tree/%d", id), nil, nil)
+}
+
+// CreateTree creates a new tree.
+func (c *Client) CreateTree(ctx context.Context, tree *TreeCreate) (*Tree, error) {
+	return doJSON[Tree](ctx, c, http.MethodPost, "/tree", nil, tree)
+}
+
+// UpdateTree replaces the tree with the given id.
+func (c *Client) UpdateTree(ctx context.Context, id int32, tree *TreeUpdate) (*Tree, error) {
+	return doJSON[Tree](ctx, c, http.MethodPut, fmt.Sprintf("/tree/%d", id), nil, tree)
+}
+
+// DeleteTree deletes the tree with the given id.
+func (c *Client) DeleteTree(ctx context.Context, id int32) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/tree/%d", id), nil, nil, nil)
+}
+
+// ListTreeClusters returns the tree clusters of the plugin host.
+func (c *Client) ListTreeClusters(ctx context.Context, opts *ListOptions) (*TreeClusterList, error) {
+	return doJSON[TreeClusterList](ctx, c, http.MethodGet, "/cluster", opts.query(), nil)
+}
+
+// GetTreeCluster returns the tree cluster with the given id.
+func (c *Client) GetTreeCluster(ctx context.Context, id int32) (*TreeCluster, error) {
+	return doJSON[TreeCluster](ctx, c, http.MethodGet, fmt.Sprintf("/cluster/%d", id), nil, nil)
+}
+
+// CreateTreeCluster creates a new tree cluster.
+func (c *Client) CreateTreeCluster(ctx context.Context, cluster *TreeClusterCreate) (*TreeCluster, error) {
+	return doJSON[TreeCluster](ctx, c, http.MethodPost, "/cluster", nil, cluster)
+}
+
+// UpdateTreeCluster replaces the tree cluster with the given id.
+func (c *Client) UpdateTreeCluster(ctx context.Context, id int32, cluster *TreeClusterUpdate) (*TreeCluster, error) {
+	return doJSON[TreeCluster](ctx, c, http.MethodPut, fmt.Sprintf("/cluster/%d", id), nil, cluster)
+}
+
+// DeleteTreeCluster deletes the tree cluster with the given id.
+func (c *Client) DeleteTreeCluster(ctx context.Context, id int32) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/cluster/%d", id), nil, nil, nil)
+}
+
+// ListSensors returns the sensors of the plugin host.
+func (c *Client) ListSensors(ctx context.Context, opts *ListOptions) (*SensorList, error) {
+	return doJSON[SensorList](ctx, c, http.MethodGet, "/sensor", opts.query(), nil)
+}
+
+// GetSensor returns the sensor with the given id.
+func (c *Client) GetSensor(ctx context.Context, id string) (*Sensor, error) {
+	return doJSON[Sensor](ctx, c, http.MethodGet, "/sensor/"+url.PathEscape(id), nil, nil)
+}
+
+// ListSensorData returns all measurements of the sensor with the given id.
+func (c *Client) ListSensorData(ctx context.Context, id string) ([]*SensorData, error) {
+	var list struct {
+		Data []*SensorData `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/sensor/data/"+url.PathEscape(id), nil, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Data, nil
+}
+
+// doJSON sends the request and decodes the response into a new T. On error no partially decoded value is returned.
+func doJSON[T any](ctx context.Context, c *Client, method, path string, query url.Values, body any) (*T, error) {
+	var out T
+	if err := c.do(ctx, method, path, query, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	if c.w.cfg.token == nil {
+		return ErrNotRegistered
+	}
+
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(buf)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/%s%s", c.w.cfg.host, c.w.cfg.hostAPIVersion, path)
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return err
+	}
+
+	if err := c.w.checkAndRenewToken(ctx); err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", c.w.cfg.token.TokenType, c.w.cfg.token.AccessToken))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.w.cfg.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		apiErr.StatusCode = resp.StatusCode
+		return apiErr
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
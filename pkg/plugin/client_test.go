@@ -0,0 +1,197 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...PluginWorkerOption) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	host, _ := url.Parse(server.URL)
+	options := []PluginWorkerOption{
+		WithHost(host),
+		WithClient(server.Client()),
+		WithPlugin(NewPlugin(WithName("Test"), WithSlug("test"), WithHostPath(host))),
+		WithToken(&Token{AccessToken: "access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}),
+	}
+	worker, err := NewPluginWorker(append(options, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create worker: %v", err)
+	}
+	return worker.Client()
+}
+
+func TestClient_ListTrees(t *testing.T) {
+	var gotReq *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotReq = r
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"species":"Oak"}],"pagination":{"current_page":1}}`))
+	})
+
+	got, err := client.ListTrees(context.Background(), &ListOptions{Page: 1, Limit: 50, Provider: "csv"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotReq.Method != http.MethodGet || gotReq.URL.Path != "/api/v1/tree" {
+		t.Errorf("unexpected request %s %s", gotReq.Method, gotReq.URL.Path)
+	}
+	if q := gotReq.URL.Query(); q.Get("page") != "1" || q.Get("limit") != "50" || q.Get("provider") != "csv" {
+		t.Errorf("unexpected query %q", gotReq.URL.RawQuery)
+	}
+	if auth := gotReq.Header.Get("Authorization"); auth != "Bearer access-token" {
+		t.Errorf("unexpected authorization header %q", auth)
+	}
+	if len(got.Data) != 1 || got.Data[0].ID != 1 || got.Data[0].Species != "Oak" {
+		t.Errorf("unexpected trees %+v", got.Data)
+	}
+}
+
+func TestClient_ListTreesWithoutOptions(t *testing.T) {
+	var gotQuery string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+
+	if _, err := client.ListTrees(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "" {
+		t.Errorf("expected no query, got %q", gotQuery)
+	}
+}
+
+func TestClient_CreateTree(t *testing.T) {
+	var gotBody TreeCreate
+	var gotContentType string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":7,"species":"Linden","number":"T-7"}`))
+	})
+
+	got, err := client.CreateTree(context.Background(), &TreeCreate{Species: "Linden", Number: "T-7"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotContentType != "application/json" {
+		t.Errorf("unexpected content type %q", gotContentType)
+	}
+	if gotBody.Species != "Linden" || gotBody.Number != "T-7" {
+		t.Errorf("unexpected request body %+v", gotBody)
+	}
+	if got.ID != 7 {
+		t.Errorf("expected created tree 7, got %d", got.ID)
+	}
+}
+
+func TestClient_DeleteTree(t *testing.T) {
+	var gotReq *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotReq = r
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.DeleteTree(context.Background(), 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotReq.Method != http.MethodDelete || gotReq.URL.Path != "/api/v1/tree/3" {
+		t.Errorf("unexpected request %s %s", gotReq.Method, gotReq.URL.Path)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	t.Run("should return api error and no value", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"error":"tree not found"}`))
+		})
+
+		got, err := client.GetTree(context.Background(), 1)
+
+		if got != nil {
+			t.Errorf("expected no tree, got %+v", got)
+		}
+		if !IsNotFound(err) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Message != "tree not found" {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("should fall back to the status text", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, "forbidden")
+		})
+
+		got, err := client.GetTreeCluster(context.Background(), 1)
+
+		var apiErr *APIError
+		if got != nil || !errors.As(err, &apiErr) {
+			t.Fatalf("expected api error and no cluster, got %+v, %v", got, err)
+		}
+		if apiErr.StatusCode != http.StatusForbidden || apiErr.Message != http.StatusText(http.StatusForbidden) {
+			t.Errorf("unexpected api error %+v", apiErr)
+		}
+	})
+
+	t.Run("should return no value when the response can not be decoded", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "not json")
+		})
+
+		got, err := client.GetSensor(context.Background(), "sensor-1")
+
+		if got != nil || err == nil {
+			t.Fatalf("expected error and no sensor, got %+v, %v", got, err)
+		}
+	})
+
+	t.Run("should return error when worker is not registered", func(t *testing.T) {
+		client := newTestClient(t, func(http.ResponseWriter, *http.Request) {
+			t.Error("no request expected")
+		}, WithToken(nil))
+
+		got, err := client.ListSensors(context.Background(), nil)
+
+		if got != nil || !errors.Is(err, ErrNotRegistered) {
+			t.Fatalf("expected not registered error and no sensors, got %+v, %v", got, err)
+		}
+	})
+}
+
+func TestClient_ListSensorData(t *testing.T) {
+	var gotPath string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(`{"data":[{"battery":3.4},{"battery":3.3}]}`))
+	})
+
+	got, err := client.ListSensorData(context.Background(), "sensor/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/api/v1/sensor/data/sensor%2F1" {
+		t.Errorf("unexpected path %q", gotPath)
+	}
+	if len(got) != 2 || got[0].Battery != 3.4 {
+		t.Errorf("unexpected sensor data %+v", got)
+	}
+}
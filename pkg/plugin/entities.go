@@ -3,12 +3,40 @@ package plugin
 import "time"
 
 type PluginRegisterRequest struct {
-	Slug        string     `json:"slug"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Version     string     `json:"version"`
-	Path        string     `json:"path"`
-	Auth        PluginAuth `json:"auth"`
+	Slug         string              `json:"slug"`
+	Name         string              `json:"name"`
+	Description  string              `json:"description"`
+	Version      string              `json:"version"`
+	Path         string              `json:"path"`
+	Scopes       []string            `json:"scopes"`
+	Auth         PluginAuth          `json:"auth"`
+	Manifest     *PluginManifest     `json:"manifest,omitempty"`
+	Proxy        *PluginProxy        `json:"proxy,omitempty"`
+	Requirements *PluginRequirements `json:"requirements,omitempty"`
+}
+
+type PluginRequirements struct {
+	MinAPIVersion string   `json:"min_api_version"`
+	MaxAPIVersion string   `json:"max_api_version"`
+	Features      []string `json:"features"`
+}
+
+// HostCapabilities are the api versions and features supported by the plugin host.
+type HostCapabilities struct {
+	APIVersions []string `json:"api_versions"`
+	Features    []string `json:"features"`
+}
+
+type PluginManifest struct {
+	Entry     string   `json:"entry"`
+	MenuLabel string   `json:"menu_label"`
+	Icon      string   `json:"icon"`
+	Roles     []string `json:"roles"`
+}
+
+type PluginProxy struct {
+	TimeoutMs   int64 `json:"timeout_ms"`
+	MaxBodySize int64 `json:"max_body_size"`
 }
 
 type PluginAuth struct {
@@ -28,3 +56,119 @@ type ClientTokenResponse struct {
 	SessionState     string    `json:"session_state"`
 	Scope            string    `json:"scope"`
 }
+
+// Pagination is returned by list endpoints if the request was paginated.
+type Pagination struct {
+	Total       int64  `json:"total_records"`
+	CurrentPage int32  `json:"current_page"`
+	TotalPages  int32  `json:"total_pages"`
+	NextPage    *int32 `json:"next_page"`
+	PrevPage    *int32 `json:"prev_page"`
+}
+
+type Tree struct {
+	ID             int32          `json:"id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	TreeClusterID  *int32         `json:"tree_cluster_id"`
+	Sensor         *Sensor        `json:"sensor"`
+	LastWatered    *time.Time     `json:"last_watered,omitempty"`
+	PlantingYear   int32          `json:"planting_year"`
+	Species        string         `json:"species"`
+	Number         string         `json:"number"`
+	Latitude       float64        `json:"latitude"`
+	Longitude      float64        `json:"longitude"`
+	WateringStatus string         `json:"watering_status"`
+	Description    string         `json:"description"`
+	Provider       string         `json:"provider,omitempty"`
+	AdditionalInfo map[string]any `json:"additional_information,omitempty"`
+}
+
+type TreeList struct {
+	Data       []*Tree     `json:"data"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+type TreeCreate struct {
+	TreeClusterID  *int32         `json:"tree_cluster_id"`
+	PlantingYear   int32          `json:"planting_year"`
+	Species        string         `json:"species"`
+	Number         string         `json:"number"`
+	Latitude       float64        `json:"latitude"`
+	Longitude      float64        `json:"longitude"`
+	SensorID       *string        `json:"sensor_id"`
+	Description    string         `json:"description"`
+	Provider       string         `json:"provider"`
+	AdditionalInfo map[string]any `json:"additional_information"`
+}
+
+type TreeUpdate = TreeCreate
+
+type TreeCluster struct {
+	ID             int32          `json:"id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	WateringStatus string         `json:"watering_status"`
+	LastWatered    *time.Time     `json:"last_watered,omitempty"`
+	MoistureLevel  float64        `json:"moisture_level"`
+	Region         *Region        `json:"region,omitempty"`
+	Address        string         `json:"address"`
+	Description    string         `json:"description"`
+	Archived       bool           `json:"archived"`
+	Latitude       *float64       `json:"latitude"`
+	Longitude      *float64       `json:"longitude"`
+	Trees          []*Tree        `json:"trees,omitempty"`
+	TreeIDs        []*int32       `json:"tree_ids,omitempty"`
+	SoilCondition  string         `json:"soil_condition"`
+	Name           string         `json:"name"`
+	Provider       string         `json:"provider,omitempty"`
+	AdditionalInfo map[string]any `json:"additional_information,omitempty"`
+}
+
+type TreeClusterList struct {
+	Data       []*TreeCluster `json:"data"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+type TreeClusterCreate struct {
+	Address        string         `json:"address"`
+	Description    string         `json:"description"`
+	TreeIDs        []*int32       `json:"tree_ids"`
+	SoilCondition  string         `json:"soil_condition"`
+	Name           string         `json:"name"`
+	Provider       string         `json:"provider"`
+	AdditionalInfo map[string]any `json:"additional_information"`
+}
+
+type TreeClusterUpdate = TreeClusterCreate
+
+type Region struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+type Sensor struct {
+	ID             string         `json:"id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Status         string         `json:"status"`
+	LatestData     *SensorData    `json:"latest_data"`
+	Latitude       float64        `json:"latitude"`
+	Longitude      float64        `json:"longitude"`
+	Provider       string         `json:"provider,omitempty"`
+	AdditionalInfo map[string]any `json:"additional_information,omitempty"`
+}
+
+type SensorList struct {
+	Data       []*Sensor   `json:"data"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+type SensorData struct {
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	Battery     float64            `json:"battery"`
+	Humidity    float64            `json:"humidity"`
+	Temperature float64            `json:"temperature"`
+	Watermarks  []WebhookWatermark `json:"watermarks"`
+}
@@ -10,6 +10,62 @@ import (
 	"time"
 )
 
+// HeaderAPIVersion contains the host api version negotiated on registration.
+const HeaderAPIVersion = "X-GE-API-Version"
+
+// IncompatibleError is returned by Register if the plugin host can not satisfy the requirements of the plugin.
+//
+// Fields:
+// - Reason: A human readable description of the incompatibility.
+// - APIVersions: The api versions supported by the plugin host.
+// - Features: The features supported by the plugin host.
+// - MissingFeatures: The required features the plugin host does not support.
+type IncompatibleError struct {
+	Reason          string   `json:"error"`
+	APIVersions     []string `json:"api_versions"`
+	Features        []string `json:"features"`
+	MissingFeatures []string `json:"missing_features"`
+}
+
+func (e *IncompatibleError) Error() string {
+	return fmt.Sprintf("plugin is incompatible with host: %s", e.Reason)
+}
+
+// Capabilities returns the api versions and features supported by the plugin host.
+// The endpoint is public, so it can be used to check the compatibility before registering.
+//
+// Example usage:
+//
+//	caps, err := pluginWorker.Capabilities(ctx)
+//	if err != nil {
+//		log.Fatalf("Failed to get host capabilities: %v", err)
+//	}
+//	log.Printf("host supports api versions %v", caps.APIVersions)
+func (w *PluginWorker) Capabilities(ctx context.Context) (*HostCapabilities, error) {
+	capabilitiesPath := fmt.Sprintf("%s/api/v1/plugin/capabilities", w.cfg.host)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, capabilitiesPath, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := w.cfg.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("failed to get host capabilities")
+	}
+
+	var caps HostCapabilities
+	if err := json.NewDecoder(resp.Body).Decode(&caps); err != nil {
+		return nil, err
+	}
+
+	return &caps, nil
+}
+
 // Register registers the plugin with the plugin host and returns an authentication token. Upon successful registration of the plugin, the Authorisation header is set on every protected route to the backend, which already contains this token.
 //
 // This function performs the following steps:
@@ -30,9 +86,12 @@ import (
 // - If the request payload cannot be marshaled into JSON, an error is returned.
 // - If creating the HTTP request fails, an error is returned.
 // - If the HTTP request fails (e.g., network error), an error is returned.
+// - If the plugin host can not satisfy the requirements of the plugin, an *IncompatibleError is returned.
 // - If the plugin host returns a non-200 status code, an error is returned with a generic failure message.
 // - If the response body cannot be decoded into the expected format, an error is returned.
 //
+// On success the api version negotiated by the plugin host is used for all further requests.
+//
 // Example usage:
 //
 //	token, err := pluginWorker.Register(ctx)
@@ -47,12 +106,37 @@ func (w *PluginWorker) Register(ctx context.Context) (*Token, error) {
 		Path:        w.cfg.plugin.PluginHostPath.String(),
 		Version:     w.cfg.plugin.Version,
 		Description: w.cfg.plugin.Description,
+		Scopes:      w.cfg.plugin.Scopes,
 		Auth: PluginAuth{
 			ClientID:     w.cfg.clientID,
 			ClientSecret: w.cfg.clientSecret,
 		},
 	}
 
+	if m := w.cfg.plugin.Manifest; m != nil {
+		reqBody.Manifest = &PluginManifest{
+			Entry:     m.Entry,
+			MenuLabel: m.MenuLabel,
+			Icon:      m.Icon,
+			Roles:     m.Roles,
+		}
+	}
+
+	if p := w.cfg.plugin.Proxy; p != nil {
+		reqBody.Proxy = &PluginProxy{
+			TimeoutMs:   p.Timeout.Milliseconds(),
+			MaxBodySize: p.MaxBodySize,
+		}
+	}
+
+	if r := w.cfg.plugin.Requirements; r.MinHostAPIVersion != "" || r.MaxHostAPIVersion != "" || len(r.Features) > 0 {
+		reqBody.Requirements = &PluginRequirements{
+			MinAPIVersion: r.MinHostAPIVersion,
+			MaxAPIVersion: r.MaxHostAPIVersion,
+			Features:      r.Features,
+		}
+	}
+
 	buf, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
@@ -71,10 +155,22 @@ func (w *PluginWorker) Register(ctx context.Context) (*Token, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusConflict {
+		var incompatibleErr IncompatibleError
+		if err := json.NewDecoder(resp.Body).Decode(&incompatibleErr); err != nil {
+			return nil, err
+		}
+		return nil, &incompatibleErr
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, errors.New("failed to register plugin")
 	}
 
+	if apiVersion := resp.Header.Get(HeaderAPIVersion); apiVersion != "" {
+		w.cfg.hostAPIVersion = apiVersion
+	}
+
 	var tokenResp ClientTokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
 		return nil, err
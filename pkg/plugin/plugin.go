@@ -1,6 +1,9 @@
 package plugin
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+)
 
 // Plugin represents a plugin that can be registered with the plugin host.
 //
@@ -10,12 +13,70 @@ import "net/url"
 // - Version: The version of the plugin, typically following semantic versioning (e.g., "1.0.0").
 // - Description: A brief description of the plugin, detailing its purpose or functionality.
 // - PluginHostPath: The URL path where the plugin can be accessed on the plugin host.
+// - Scopes: The API scopes the plugin requests, e.g. "tree:read". They must be granted to the plugin on the plugin host.
+// - Manifest: Describes how the plugin is integrated into the frontend. Optional.
+// - Proxy: Limits of the reverse proxy the plugin host uses to serve the plugin. Optional.
+// - Requirements: The host api versions and features the plugin needs. The plugin host rejects the registration if it can not satisfy them.
 type Plugin struct {
 	Slug           string
 	Name           string
 	Version        string
 	Description    string
 	PluginHostPath *url.URL
+	Scopes         []string
+	Manifest       *Manifest
+	Proxy          *ProxyConfig
+	Requirements   Requirements
+}
+
+// Host api versions that can be requested by a plugin.
+const (
+	HostAPIVersionV1 = "v1"
+)
+
+// Features of the plugin host that can be required by a plugin.
+const (
+	FeatureScopes      = "scopes"
+	FeatureWebhooks    = "webhooks"
+	FeatureUIManifest  = "ui-manifest"
+	FeatureProxyConfig = "proxy-config"
+)
+
+// Requirements declares which plugin host the plugin is compatible with.
+//
+// Fields:
+// - MinHostAPIVersion: The lowest host api version the plugin supports, e.g. "v1". Empty means no lower bound.
+// - MaxHostAPIVersion: The highest host api version the plugin supports. Empty means no upper bound.
+// - Features: The host features the plugin depends on, e.g. FeatureWebhooks.
+type Requirements struct {
+	MinHostAPIVersion string
+	MaxHostAPIVersion string
+	Features          []string
+}
+
+// Manifest describes how the plugin is integrated into the frontend of the plugin host.
+//
+// Fields:
+// - Entry: The path of the UI entry point relative to the plugin host path, e.g. "index.js".
+// - MenuLabel: The label of the menu entry.
+// - Icon: The name of the icon of the menu entry.
+// - Roles: The user roles required to see the plugin, e.g. "tbz". An empty list allows every user.
+type Manifest struct {
+	Entry     string
+	MenuLabel string
+	Icon      string
+	Roles     []string
+}
+
+// ProxyConfig configures the reverse proxy of the plugin host.
+// Zero values use the defaults of the plugin host, which also caps the values at its own maximum.
+//
+// Fields:
+// - Timeout: The maximum duration of a proxied request.
+// - MaxBodySize: The maximum size of a proxied response in bytes.
+type ProxyConfig struct {
+	Timeout     time.Duration
+	MaxBodySize int64
 }
 
 // PluginOption is a functional option for configuring a Plugin.
@@ -80,6 +141,64 @@ func WithHostPath(hostPath *url.URL) PluginOption {
 	}
 }
 
+// WithScopes sets the API scopes the plugin requests on registration.
+// A scope has the form "<resource>:<access>" where access is either "read" or "write".
+//
+// Example usage:
+//
+//	plugin := NewPlugin(WithScopes("tree:read", "sensor:read"))
+func WithScopes(scopes ...string) PluginOption {
+	return func(p *Plugin) {
+		p.Scopes = scopes
+	}
+}
+
+// WithManifest sets the UI manifest of the plugin.
+//
+// Example usage:
+//
+//	plugin := NewPlugin(WithManifest(Manifest{Entry: "index.js", MenuLabel: "My Plugin", Icon: "leaf"}))
+func WithManifest(manifest Manifest) PluginOption {
+	return func(p *Plugin) {
+		p.Manifest = &manifest
+	}
+}
+
+// WithProxyConfig sets the limits of the reverse proxy to the plugin.
+//
+// Example usage:
+//
+//	plugin := NewPlugin(WithProxyConfig(ProxyConfig{Timeout: 5 * time.Second, MaxBodySize: 1 << 20}))
+func WithProxyConfig(cfg ProxyConfig) PluginOption {
+	return func(p *Plugin) {
+		p.Proxy = &cfg
+	}
+}
+
+// WithHostAPIRange sets the range of host api versions the plugin supports.
+// On registration the plugin host picks the highest version inside the range.
+//
+// Example usage:
+//
+//	plugin := NewPlugin(WithHostAPIRange(HostAPIVersionV1, ""))
+func WithHostAPIRange(minVersion, maxVersion string) PluginOption {
+	return func(p *Plugin) {
+		p.Requirements.MinHostAPIVersion = minVersion
+		p.Requirements.MaxHostAPIVersion = maxVersion
+	}
+}
+
+// WithFeatures sets the host features the plugin depends on.
+//
+// Example usage:
+//
+//	plugin := NewPlugin(WithFeatures(FeatureWebhooks, FeatureUIManifest))
+func WithFeatures(features ...string) PluginOption {
+	return func(p *Plugin) {
+		p.Requirements.Features = features
+	}
+}
+
 // defaultPlugin provides default values for Plugin instances.
 // By default, the version is set to "develop". Other fields must be explicitly configured via options.
 var defaultPlugin = Plugin{
@@ -0,0 +1,299 @@
+package plugin
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header names used by the plugin host when delivering webhooks.
+const (
+	WebhookHeaderEvent     = "X-GE-Event"
+	WebhookHeaderDelivery  = "X-GE-Delivery"
+	WebhookHeaderTimestamp = "X-GE-Timestamp"
+	WebhookHeaderSignature = "X-GE-Signature"
+)
+
+// Event types that can be subscribed by a webhook.
+const (
+	EventTypeCreateTree         = "create tree"
+	EventTypeUpdateTree         = "update tree"
+	EventTypeDeleteTree         = "delete tree"
+	EventTypeUpdateTreeCluster  = "update tree cluster"
+	EventTypeNewSensorData      = "receive sensor data"
+	EventTypeUpdateWateringPlan = "update watering plan"
+)
+
+var (
+	ErrWebhookSignatureMissing = errors.New("webhook signature is missing")
+	ErrWebhookSignatureInvalid = errors.New("webhook signature is invalid")
+	ErrWebhookTimestampExpired = errors.New("webhook timestamp is outside of the tolerance")
+)
+
+const signaturePrefix = "sha256="
+
+// WebhookEvent is the envelope of every webhook delivery.
+//
+// Fields:
+// - ID: The unique id of the event. It is the same for every webhook receiving the event and can be used for deduplication.
+// - Type: The event type, e.g. "update tree".
+// - CreatedAt: The time the event occurred on the plugin host.
+// - Data: The raw event data. Use the typed handlers of WebhookHandler to decode it.
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Change contains the state of an entity before and after the event.
+// Prev is nil for created entities and New is nil for deleted entities.
+type Change[T any] struct {
+	Prev *T `json:"prev,omitempty"`
+	New  *T `json:"new,omitempty"`
+}
+
+type WebhookTree struct {
+	ID             int32          `json:"id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	TreeClusterID  *int32         `json:"tree_cluster_id,omitempty"`
+	SensorID       *string        `json:"sensor_id,omitempty"`
+	PlantingYear   int32          `json:"planting_year"`
+	Species        string         `json:"species"`
+	Number         string         `json:"number"`
+	Latitude       float64        `json:"latitude"`
+	Longitude      float64        `json:"longitude"`
+	WateringStatus string         `json:"watering_status"`
+	Description    string         `json:"description"`
+	LastWatered    *time.Time     `json:"last_watered,omitempty"`
+	Provider       string         `json:"provider,omitempty"`
+	AdditionalInfo map[string]any `json:"additional_information,omitempty"`
+}
+
+type WebhookTreeCluster struct {
+	ID             int32          `json:"id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Name           string         `json:"name"`
+	Address        string         `json:"address"`
+	Description    string         `json:"description"`
+	RegionID       *int32         `json:"region_id,omitempty"`
+	WateringStatus string         `json:"watering_status"`
+	MoistureLevel  float64        `json:"moisture_level"`
+	SoilCondition  string         `json:"soil_condition"`
+	LastWatered    *time.Time     `json:"last_watered,omitempty"`
+	Latitude       *float64       `json:"latitude,omitempty"`
+	Longitude      *float64       `json:"longitude,omitempty"`
+	Archived       bool           `json:"archived"`
+	TreeIDs        []int32        `json:"tree_ids"`
+	Provider       string         `json:"provider,omitempty"`
+	AdditionalInfo map[string]any `json:"additional_information,omitempty"`
+}
+
+type WebhookWatermark struct {
+	Centibar   int `json:"centibar"`
+	Resistance int `json:"resistance"`
+	Depth      int `json:"depth"`
+}
+
+type WebhookSensorData struct {
+	ID          int32              `json:"id"`
+	SensorID    string             `json:"sensor_id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	Battery     float64            `json:"battery"`
+	Humidity    float64            `json:"humidity"`
+	Temperature float64            `json:"temperature"`
+	Latitude    float64            `json:"latitude"`
+	Longitude   float64            `json:"longitude"`
+	Watermarks  []WebhookWatermark `json:"watermarks"`
+}
+
+type WebhookWateringPlan struct {
+	ID                 int32          `json:"id"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	Date               time.Time      `json:"date"`
+	Description        string         `json:"description"`
+	Status             string         `json:"status"`
+	Distance           *float64       `json:"distance,omitempty"`
+	TotalWaterRequired *float64       `json:"total_water_required,omitempty"`
+	TreeClusterIDs     []int32        `json:"tree_cluster_ids"`
+	TransporterID      *int32         `json:"transporter_id,omitempty"`
+	TrailerID          *int32         `json:"trailer_id,omitempty"`
+	CancellationNote   string         `json:"cancellation_note,omitempty"`
+	Provider           string         `json:"provider,omitempty"`
+	AdditionalInfo     map[string]any `json:"additional_information,omitempty"`
+}
+
+// VerifyWebhookSignature checks that the body was signed by the plugin host with the webhook secret.
+//
+// Parameters:
+// - secret: The secret returned by the plugin host when the webhook was created.
+// - timestamp: The value of the X-GE-Timestamp header.
+// - body: The raw request body.
+// - signature: The value of the X-GE-Signature header, e.g. "sha256=<hex>".
+// - tolerance: The maximum age of the timestamp. A zero tolerance disables the check.
+//
+// Returns:
+// - An error if the signature does not match or the timestamp is too old.
+func VerifyWebhookSignature(secret, timestamp string, body []byte, signature string, tolerance time.Duration) error {
+	if timestamp == "" || signature == "" {
+		return ErrWebhookSignatureMissing
+	}
+
+	if tolerance > 0 {
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return ErrWebhookSignatureInvalid
+		}
+		if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+			return ErrWebhookTimestampExpired
+		}
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return ErrWebhookSignatureInvalid
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrWebhookSignatureInvalid
+	}
+
+	return nil
+}
+
+// WebhookHandlerOption is a functional option for configuring a WebhookHandler.
+type WebhookHandlerOption func(*WebhookHandler)
+
+// WithSignatureTolerance sets the maximum age of a delivery. Defaults to 5 minutes.
+func WithSignatureTolerance(tolerance time.Duration) WebhookHandlerOption {
+	return func(h *WebhookHandler) {
+		h.tolerance = tolerance
+	}
+}
+
+// WebhookHandler is an http.Handler that verifies webhook deliveries of the plugin host
+// and dispatches them to the registered typed handlers.
+//
+// The handler responds with 401 if the signature is invalid, with 500 if the
+// registered handler returns an error and with 204 otherwise. Events without a
+// registered handler are acknowledged.
+//
+// Example usage:
+//
+//	wh := NewWebhookHandler(secret)
+//	wh.OnUpdateTree(func(ctx context.Context, e *WebhookEvent, c *Change[WebhookTree]) error {
+//		log.Printf("tree %d updated", c.New.ID)
+//		return nil
+//	})
+//	http.Handle("/webhook", wh)
+type WebhookHandler struct {
+	secret    string
+	tolerance time.Duration
+	handlers  map[string]func(context.Context, *WebhookEvent) error
+}
+
+// NewWebhookHandler creates a new WebhookHandler that verifies deliveries with the given secret.
+func NewWebhookHandler(secret string, opts ...WebhookHandlerOption) *WebhookHandler {
+	h := &WebhookHandler{
+		secret:    secret,
+		tolerance: 5 * time.Minute,
+		handlers:  make(map[string]func(context.Context, *WebhookEvent) error),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// On registers a handler for the raw event of the given type.
+func (h *WebhookHandler) On(eventType string, fn func(ctx context.Context, event *WebhookEvent) error) {
+	h.handlers[eventType] = fn
+}
+
+func (h *WebhookHandler) OnCreateTree(fn func(ctx context.Context, event *WebhookEvent, change *Change[WebhookTree]) error) {
+	onChange(h, EventTypeCreateTree, fn)
+}
+
+func (h *WebhookHandler) OnUpdateTree(fn func(ctx context.Context, event *WebhookEvent, change *Change[WebhookTree]) error) {
+	onChange(h, EventTypeUpdateTree, fn)
+}
+
+func (h *WebhookHandler) OnDeleteTree(fn func(ctx context.Context, event *WebhookEvent, change *Change[WebhookTree]) error) {
+	onChange(h, EventTypeDeleteTree, fn)
+}
+
+func (h *WebhookHandler) OnUpdateTreeCluster(fn func(ctx context.Context, event *WebhookEvent, change *Change[WebhookTreeCluster]) error) {
+	onChange(h, EventTypeUpdateTreeCluster, fn)
+}
+
+func (h *WebhookHandler) OnNewSensorData(fn func(ctx context.Context, event *WebhookEvent, change *Change[WebhookSensorData]) error) {
+	onChange(h, EventTypeNewSensorData, fn)
+}
+
+func (h *WebhookHandler) OnUpdateWateringPlan(fn func(ctx context.Context, event *WebhookEvent, change *Change[WebhookWateringPlan]) error) {
+	onChange(h, EventTypeUpdateWateringPlan, fn)
+}
+
+func onChange[T any](h *WebhookHandler, eventType string, fn func(context.Context, *WebhookEvent, *Change[T]) error) {
+	h.On(eventType, func(ctx context.Context, event *WebhookEvent) error {
+		var change Change[T]
+		if err := json.Unmarshal(event.Data, &change); err != nil {
+			return fmt.Errorf("failed to decode %q event: %w", eventType, err)
+		}
+		return fn(ctx, event, &change)
+	})
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	timestamp := r.Header.Get(WebhookHeaderTimestamp)
+	signature := r.Header.Get(WebhookHeaderSignature)
+	if err := VerifyWebhookSignature(h.secret, timestamp, body, signature, h.tolerance); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "failed to decode event", http.StatusBadRequest)
+		return
+	}
+
+	if fn, ok := h.handlers[event.Type]; ok {
+		if err := fn(r.Context(), &event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,186 @@
+package plugin
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		tolerance time.Duration
+		want      error
+	}{
+		{name: "valid signature", timestamp: now, signature: sign("secret", now, body), tolerance: time.Minute},
+		{name: "valid signature without prefix", timestamp: now, signature: strings.TrimPrefix(sign("secret", now, body), signaturePrefix), tolerance: time.Minute},
+		{name: "missing timestamp", timestamp: "", signature: sign("secret", now, body), tolerance: time.Minute, want: ErrWebhookSignatureMissing},
+		{name: "missing signature", timestamp: now, signature: "", tolerance: time.Minute, want: ErrWebhookSignatureMissing},
+		{name: "wrong secret", timestamp: now, signature: sign("other", now, body), tolerance: time.Minute, want: ErrWebhookSignatureInvalid},
+		{name: "signature of other timestamp", timestamp: now, signature: sign("secret", old, body), tolerance: time.Minute, want: ErrWebhookSignatureInvalid},
+		{name: "signature not hex", timestamp: now, signature: "sha256=zz", tolerance: time.Minute, want: ErrWebhookSignatureInvalid},
+		{name: "timestamp not a number", timestamp: "yesterday", signature: sign("secret", "yesterday", body), tolerance: time.Minute, want: ErrWebhookSignatureInvalid},
+		{name: "expired timestamp", timestamp: old, signature: sign("secret", old, body), tolerance: time.Minute, want: ErrWebhookTimestampExpired},
+		{name: "expired timestamp without tolerance", timestamp: old, signature: sign("secret", old, body), tolerance: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature("secret", tt.timestamp, body, tt.signature, tt.tolerance)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected error %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func newDelivery(t *testing.T, secret, body string) *http.Request {
+	t.Helper()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(WebhookHeaderSignature, sign(secret, timestamp, []byte(body)))
+	return req
+}
+
+func TestWebhookHandler(t *testing.T) {
+	const updateTree = `{"id":"1","type":"update tree","created_at":"2025-03-01T10:00:00Z","data":{"prev":{"id":1,"species":"Oak"},"new":{"id":1,"species":"Linden"}}}`
+
+	t.Run("should dispatch typed event", func(t *testing.T) {
+		var got *Change[WebhookTree]
+		h := NewWebhookHandler("secret")
+		h.OnUpdateTree(func(_ context.Context, event *WebhookEvent, change *Change[WebhookTree]) error {
+			if event.ID != "1" {
+				t.Errorf("expected event id 1, got %q", event.ID)
+			}
+			got = change
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newDelivery(t, "secret", updateTree))
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204, got %d", rec.Code)
+		}
+		if got == nil || got.Prev.Species != "Oak" || got.New.Species != "Linden" {
+			t.Fatalf("unexpected change %+v", got)
+		}
+	})
+
+	t.Run("should acknowledge event without handler", func(t *testing.T) {
+		h := NewWebhookHandler("secret")
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newDelivery(t, "secret", updateTree))
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should reject invalid signature", func(t *testing.T) {
+		called := false
+		h := NewWebhookHandler("secret")
+		h.OnUpdateTree(func(context.Context, *WebhookEvent, *Change[WebhookTree]) error {
+			called = true
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newDelivery(t, "other", updateTree))
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401, got %d", rec.Code)
+		}
+		if called {
+			t.Fatal("handler must not be called for an invalid signature")
+		}
+	})
+
+	t.Run("should reject other methods", func(t *testing.T) {
+		h := NewWebhookHandler("secret")
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", http.NoBody))
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected status 405, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should reject body that is not an event", func(t *testing.T) {
+		h := NewWebhookHandler("secret")
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newDelivery(t, "secret", "not json"))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should respond with 500 when handler fails", func(t *testing.T) {
+		h := NewWebhookHandler("secret")
+		h.On(EventTypeUpdateTree, func(context.Context, *WebhookEvent) error {
+			return errors.New("failed")
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newDelivery(t, "secret", updateTree))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected status 500, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should respond with 500 when data does not match the event type", func(t *testing.T) {
+		h := NewWebhookHandler("secret")
+		h.OnUpdateTree(func(context.Context, *WebhookEvent, *Change[WebhookTree]) error {
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newDelivery(t, "secret", `{"id":"1","type":"update tree","data":{"new":"tree"}}`))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected status 500, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should reject expired delivery", func(t *testing.T) {
+		h := NewWebhookHandler("secret", WithSignatureTolerance(time.Minute))
+		timestamp := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(updateTree))
+		req.Header.Set(WebhookHeaderTimestamp, timestamp)
+		req.Header.Set(WebhookHeaderSignature, sign("secret", timestamp, []byte(updateTree)))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401, got %d", rec.Code)
+		}
+	})
+}